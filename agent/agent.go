@@ -150,6 +150,11 @@ const (
 	MongoOplogSize    = "MONGO_OPLOG_SIZE"
 	NUMACtlPreference = "NUMA_CTL_PREFERENCE"
 
+	// MongoSidecar is set to "true" when mongod runs in a container
+	// alongside the agent's, as it does in a Kubernetes controller
+	// pod. The agent then neither installs nor manages mongod.
+	MongoSidecar = "MONGO_SIDECAR"
+
 	AgentLoginRateLimit  = "AGENT_LOGIN_RATE_LIMIT"
 	AgentLoginMinPause   = "AGENT_LOGIN_MIN_PAUSE"
 	AgentLoginMaxPause   = "AGENT_LOGIN_MAX_PAUSE"
@@ -5,9 +5,12 @@ package action
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
 )
 
 // Client provides access to the action facade.
@@ -90,6 +93,31 @@ func (c *Client) Cancel(arg params.Entities) (params.ActionResults, error) {
 	return results, err
 }
 
+// WatchActionProgress returns a watcher that reports the progress
+// messages logged by the action with the given id. Each change is a
+// JSON encoded params.ActionMessage.
+func (c *Client) WatchActionProgress(actionId string) (watcher.StringsWatcher, error) {
+	if v := c.BestAPIVersion(); v < 3 {
+		return nil, errors.NotSupportedf("WatchActionProgress not supported by this version (%d) of Juju", v)
+	}
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: names.NewActionTag(actionId).String()},
+	}}
+	var results params.StringsWatchResults
+	err := c.facade.FacadeCall("WatchActionsProgress", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
 // applicationsCharmActions is a batched query for the charm.Actions for a slice
 // of applications by Entity.
 func (c *Client) applicationsCharmActions(arg params.Entities) (params.ApplicationsCharmActionsResults, error) {
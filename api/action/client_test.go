@@ -4,14 +4,18 @@
 package action_test
 
 import (
+	"encoding/json"
 	"errors"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/workertest"
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type actionSuite struct {
@@ -26,6 +30,31 @@ func (s *actionSuite) TestClient(c *gc.C) {
 	c.Check(facade.Name(), gc.Equals, "Action")
 }
 
+func (s *actionSuite) TestWatchActionProgress(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	a, err := unit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Log("hello"), jc.ErrorIsNil)
+
+	w, err := s.client.WatchActionProgress(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case changes, ok := <-w.Changes():
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(changes, gc.HasLen, 1)
+		var message params.ActionMessage
+		err := json.Unmarshal([]byte(changes[0]), &message)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(message.Message, gc.Equals, "hello")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for action messages")
+	}
+}
+
 func (s *actionSuite) TestApplicationCharmActions(c *gc.C) {
 	tests := []struct {
 		description    string
@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentintrospection provides the API client used by agents
+// to handle introspection requests made through the controller.
+package agentintrospection
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Request is a request for the agent to run one of its
+// introspection handlers.
+type Request struct {
+	Id        string
+	Path      string
+	Completed bool
+}
+
+// Client provides access to the AgentIntrospection facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new AgentIntrospection client.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{base.NewFacadeCaller(caller, "AgentIntrospection")}
+}
+
+// WatchIntrospectionRequests returns a StringsWatcher reporting the
+// ids of introspection requests made of the agent. The initial event
+// contains the ids of any requests outstanding when the watcher is
+// started.
+func (c *Client) WatchIntrospectionRequests(agent names.Tag) (watcher.StringsWatcher, error) {
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: agent.String()}},
+	}
+	var results params.StringsWatchResults
+	if err := c.facade.FacadeCall("WatchIntrospectionRequests", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// IntrospectionRequest returns the introspection request with the
+// given id.
+func (c *Client) IntrospectionRequest(id string) (Request, error) {
+	args := params.IntrospectionRequestIds{Ids: []string{id}}
+	var results params.IntrospectionRequestResults
+	if err := c.facade.FacadeCall("IntrospectionRequests", args, &results); err != nil {
+		return Request{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return Request{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return Request{}, errors.Trace(result.Error)
+	}
+	return Request{
+		Id:        result.Result.Id,
+		Path:      result.Result.Path,
+		Completed: result.Result.Completed,
+	}, nil
+}
+
+// CompleteIntrospectionRequest records the output of the introspection
+// handler run for the request, or the error encountered running it.
+func (c *Client) CompleteIntrospectionRequest(id string, output []byte, errMsg string) error {
+	args := params.IntrospectionResponses{
+		Responses: []params.IntrospectionResponse{{
+			Id:     id,
+			Output: output,
+			Error:  errMsg,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("CompleteIntrospectionRequests", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentintrospection_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/agentintrospection"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestIntrospectionRequest(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "AgentIntrospection")
+		c.Check(request, gc.Equals, "IntrospectionRequests")
+		c.Check(arg, jc.DeepEquals, params.IntrospectionRequestIds{Ids: []string{"machine-0_i_1"}})
+		*(result.(*params.IntrospectionRequestResults)) = params.IntrospectionRequestResults{
+			Results: []params.IntrospectionRequestResult{{
+				Result: &params.IntrospectionRequest{Id: "machine-0_i_1", Path: "/metrics"},
+			}},
+		}
+		return nil
+	})
+	req, err := agentintrospection.NewClient(apiCaller).IntrospectionRequest("machine-0_i_1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(req, jc.DeepEquals, agentintrospection.Request{Id: "machine-0_i_1", Path: "/metrics"})
+}
+
+func (s *clientSuite) TestIntrospectionRequestError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.IntrospectionRequestResults)) = params.IntrospectionRequestResults{
+			Results: []params.IntrospectionRequestResult{{
+				Error: &params.Error{Message: "not found", Code: params.CodeNotFound},
+			}},
+		}
+		return nil
+	})
+	_, err := agentintrospection.NewClient(apiCaller).IntrospectionRequest("machine-0_i_1")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *clientSuite) TestCompleteIntrospectionRequest(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "AgentIntrospection")
+		c.Check(request, gc.Equals, "CompleteIntrospectionRequests")
+		c.Check(arg, jc.DeepEquals, params.IntrospectionResponses{
+			Responses: []params.IntrospectionResponse{{Id: "machine-0_i_1", Output: []byte("output"), Error: "boom"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	err := agentintrospection.NewClient(apiCaller).CompleteIntrospectionRequest("machine-0_i_1", []byte("output"), "boom")
+	c.Assert(err, jc.ErrorIsNil)
+}
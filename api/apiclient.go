@@ -163,6 +163,10 @@ type RedirectError struct {
 
 	// CACert holds the certificate of the remote server.
 	CACert string
+
+	// ControllerTag holds the tag of the controller hosting
+	// the model, if known.
+	ControllerTag names.ControllerTag
 }
 
 func (e *RedirectError) Error() string {
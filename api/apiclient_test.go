@@ -562,12 +562,14 @@ func (s *apiclientSuite) TestOpenWithNoCACert(c *gc.C) {
 func (s *apiclientSuite) TestOpenWithRedirect(c *gc.C) {
 	redirectToHosts := []string{"0.1.2.3:1234", "0.1.2.4:1235"}
 	redirectToCACert := "fake CA cert"
+	redirectToController := names.NewControllerTag("deadbeef-1bad-500d-9000-4b1d0d06f00d")
 
 	srv := apiservertesting.NewAPIServer(func(modelUUID string) interface{} {
 		return &redirectAPI{
-			modelUUID:        modelUUID,
-			redirectToHosts:  redirectToHosts,
-			redirectToCACert: redirectToCACert,
+			modelUUID:            modelUUID,
+			redirectToHosts:      redirectToHosts,
+			redirectToCACert:     redirectToCACert,
+			redirectToController: redirectToController.String(),
 		}
 	})
 	defer srv.Close()
@@ -581,8 +583,9 @@ func (s *apiclientSuite) TestOpenWithRedirect(c *gc.C) {
 
 	hps, _ := network.ParseHostPorts(redirectToHosts...)
 	c.Assert(errors.Cause(err), jc.DeepEquals, &api.RedirectError{
-		Servers: [][]network.HostPort{hps},
-		CACert:  redirectToCACert,
+		Servers:       [][]network.HostPort{hps},
+		CACert:        redirectToCACert,
+		ControllerTag: redirectToController,
 	})
 }
 
@@ -1179,10 +1182,11 @@ func (f *fakeRPCConnection) Call(req rpc.Request, params, response interface{})
 }
 
 type redirectAPI struct {
-	redirected       bool
-	modelUUID        string
-	redirectToHosts  []string
-	redirectToCACert string
+	redirected           bool
+	modelUUID            string
+	redirectToHosts      []string
+	redirectToCACert     string
+	redirectToController string
 }
 
 func (r *redirectAPI) Admin(id string) (*redirectAPIAdmin, error) {
@@ -1213,8 +1217,9 @@ func (a *redirectAPIAdmin) RedirectInfo() (params.RedirectInfoResult, error) {
 		panic(err)
 	}
 	return params.RedirectInfoResult{
-		Servers: [][]params.HostPort{params.FromNetworkHostPorts(hps)},
-		CACert:  a.r.redirectToCACert,
+		Servers:       [][]params.HostPort{params.FromNetworkHostPorts(hps)},
+		CACert:        a.r.redirectToCACert,
+		ControllerTag: a.r.redirectToController,
 	}, nil
 }
 
@@ -105,6 +105,11 @@ type DeployArgs struct {
 	// may be non-empty only if NumUnits is 1.
 	AttachStorage []string
 
+	// AttachSnapshots contains IDs of volume snapshots from which new
+	// storage should be restored for the application unit that will
+	// be deployed. This may be non-empty only if NumUnits is 1.
+	AttachSnapshots []string
+
 	// EndpointBindings
 	EndpointBindings map[string]string
 
@@ -126,6 +131,14 @@ func (c *Client) Deploy(args DeployArgs) error {
 			return errors.New("this juju controller does not support AttachStorage")
 		}
 	}
+	if len(args.AttachSnapshots) > 0 {
+		if args.NumUnits != 1 {
+			return errors.New("cannot restore storage from snapshots when more than one unit is requested")
+		}
+		if c.BestAPIVersion() < 9 {
+			return errors.New("this juju controller does not support AttachSnapshots")
+		}
+	}
 	attachStorage := make([]string, len(args.AttachStorage))
 	for i, id := range args.AttachStorage {
 		if !names.IsValidStorage(id) {
@@ -147,6 +160,7 @@ func (c *Client) Deploy(args DeployArgs) error {
 			Storage:          args.Storage,
 			Devices:          args.Devices,
 			AttachStorage:    attachStorage,
+			AttachSnapshots:  args.AttachSnapshots,
 			EndpointBindings: args.EndpointBindings,
 			Resources:        args.Resources,
 		}},
@@ -377,6 +391,14 @@ func (c *Client) AddUnits(args AddUnitsParams) ([]string, error) {
 			return nil, errors.New("this juju controller does not support AttachStorage")
 		}
 	}
+	if len(args.AttachSnapshots) > 0 {
+		if args.NumUnits != 1 {
+			return errors.New("cannot restore storage from snapshots when more than one unit is requested")
+		}
+		if c.BestAPIVersion() < 9 {
+			return errors.New("this juju controller does not support AttachSnapshots")
+		}
+	}
 	attachStorage := make([]string, len(args.AttachStorage))
 	for i, id := range args.AttachStorage {
 		if !names.IsValidStorage(id) {
@@ -665,6 +687,21 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeEndpoints exposes the specified endpoints of the application,
+// keyed by endpoint name, to the spaces and CIDRs in their settings.
+// The empty endpoint name refers to all endpoints. The settings are
+// merged with any existing expose settings of the application.
+func (c *Client) ExposeEndpoints(application string, exposedEndpoints map[string]params.ExposedEndpoint) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("exposing individual endpoints or to specific spaces or CIDRs on this version of Juju")
+	}
+	args := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposedEndpoints,
+	}
+	return c.facade.FacadeCall("Expose", args, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -672,6 +709,20 @@ func (c *Client) Unexpose(application string) error {
 	return c.facade.FacadeCall("Unexpose", params, nil)
 }
 
+// UnexposeEndpoints removes the expose settings of the specified
+// endpoints of the application. The application is unexposed if no
+// expose settings remain.
+func (c *Client) UnexposeEndpoints(application string, endpoints []string) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("unexposing individual endpoints on this version of Juju")
+	}
+	args := params.ApplicationUnexpose{
+		ApplicationName:  application,
+		ExposedEndpoints: endpoints,
+	}
+	return c.facade.FacadeCall("Unexpose", args, nil)
+}
+
 // Get returns the configuration for the named application.
 func (c *Client) Get(application string) (*params.ApplicationGetResults, error) {
 	var results params.ApplicationGetResults
@@ -30,7 +30,7 @@ type applicationSuite struct {
 var _ = gc.Suite(&applicationSuite{})
 
 func newClient(f basetesting.APICallerFunc) *application.Client {
-	return application.NewClient(basetesting.BestVersionCaller{f, 8})
+	return application.NewClient(basetesting.BestVersionCaller{f, 9})
 }
 
 func newClientV4(f basetesting.APICallerFunc) *application.Client {
@@ -164,6 +164,57 @@ func (s *applicationSuite) TestDeployAttachStorageMultipleUnits(c *gc.C) {
 	c.Assert(called, jc.IsFalse)
 }
 
+func (s *applicationSuite) TestDeployAttachSnapshots(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				c.Assert(request, gc.Equals, "Deploy")
+				args, ok := a.(params.ApplicationsDeploy)
+				c.Assert(ok, jc.IsTrue)
+				c.Assert(args.Applications, gc.HasLen, 1)
+				c.Assert(args.Applications[0].AttachSnapshots, jc.DeepEquals, []string{"0/1@2"})
+
+				result := response.(*params.ErrorResults)
+				result.Results = make([]params.ErrorResult, 1)
+				return nil
+			},
+		),
+		BestVersion: 9,
+	})
+	args := application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/a-charm-1"),
+		},
+		NumUnits:        1,
+		AttachSnapshots: []string{"0/1@2"},
+	}
+	err := client.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestDeployAttachSnapshotsV8(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				return nil
+			},
+		),
+		BestVersion: 8, // v8 does not support AttachSnapshots
+	})
+	args := application.DeployArgs{
+		NumUnits:        1,
+		AttachSnapshots: []string{"0/1@2"},
+	}
+	err := client.Deploy(args)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support AttachSnapshots")
+	c.Assert(called, jc.IsFalse)
+}
+
 func (s *applicationSuite) TestAddUnits(c *gc.C) {
 	client := application.NewClient(basetesting.BestVersionCaller{
 		APICallerFunc: basetesting.APICallerFunc(
@@ -955,6 +1006,58 @@ func (s *applicationSuite) TestGetConstraintsAPIv4(c *gc.C) {
 	})
 }
 
+func (s *applicationSuite) TestExposeEndpoints(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Application")
+		c.Check(request, gc.Equals, "Expose")
+		c.Check(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "foo",
+			ExposedEndpoints: map[string]params.ExposedEndpoint{
+				"db": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+			},
+		})
+		return nil
+	})
+	err := client.ExposeEndpoints("foo", map[string]params.ExposedEndpoint{
+		"db": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeEndpointsNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 8,
+	})
+	err := client.ExposeEndpoints("foo", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.UnexposeEndpoints("foo", []string{"db"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *applicationSuite) TestUnexposeEndpoints(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Check(objType, gc.Equals, "Application")
+		c.Check(request, gc.Equals, "Unexpose")
+		c.Check(a, jc.DeepEquals, params.ApplicationUnexpose{
+			ApplicationName:  "foo",
+			ExposedEndpoints: []string{"db"},
+		})
+		return nil
+	})
+	err := client.UnexposeEndpoints("foo", []string{"db"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *applicationSuite) TestSetApplicationConfig(c *gc.C) {
 	fooConfig := map[string]string{
 		"foo":   "bar",
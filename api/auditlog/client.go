@@ -0,0 +1,34 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the audit logs of all of the controller
+// machines.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new AuditLog client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the audit log entries from every controller machine
+// which match the arguments, ordered by time.
+func (c *Client) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResult, error) {
+	var result params.AuditLogQueryResult
+	if err := c.facade.FacadeCall("Query", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestQuery(c *gc.C) {
+	args := params.AuditLogQueryArgs{Who: "bob", ErrorsOnly: true}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "AuditLog")
+		c.Check(request, gc.Equals, "Query")
+		c.Check(arg, jc.DeepEquals, args)
+		*(result.(*params.AuditLogQueryResult)) = params.AuditLogQueryResult{
+			Entries:     []params.AuditLogEntry{{Who: "bob", Method: "Deploy"}},
+			Unavailable: []string{"2"},
+		}
+		return nil
+	})
+	result, err := auditlog.NewClient(apiCaller).Query(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 1)
+	c.Assert(result.Entries[0].Method, gc.Equals, "Deploy")
+	c.Assert(result.Unavailable, jc.DeepEquals, []string{"2"})
+}
+
+func (s *clientSuite) TestQueryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
+		return errors.New("boom")
+	})
+	_, err := auditlog.NewClient(apiCaller).Query(params.AuditLogQueryArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -60,6 +60,24 @@ func (c *Client) WatchApplications() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of subnets being
+// added to or removed from the current model. It returns a NotSupported
+// error if the controller does not support watching subnets.
+func (c *Client) WatchSubnets() (watcher.StringsWatcher, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("watching subnets")
+	}
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchSubnets", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // WatchApplication returns a NotifyWatcher that notifies of
 // changes to the application in the current model.
 func (c *Client) WatchApplication(appName string) (watcher.NotifyWatcher, error) {
@@ -130,6 +148,36 @@ func (c *Client) IsExposed(appName string) (bool, error) {
 	return results.Results[0].Result, nil
 }
 
+// ExposeInfo returns whether the specified CAAS application in the
+// current model is exposed, and the settings of its exposed endpoints.
+// The CIDRs of each endpoint include the subnets of the spaces the
+// endpoint is exposed to. If the controller does not support
+// per-endpoint expose settings, or the application was exposed
+// without any, the returned settings are nil.
+func (c *Client) ExposeInfo(appName string) (bool, map[string]params.ExposedEndpoint, error) {
+	if c.facade.BestAPIVersion() < 2 {
+		exposed, err := c.IsExposed(appName)
+		return exposed, nil, errors.Trace(err)
+	}
+	appTag, err := applicationTag(appName)
+	if err != nil {
+		return false, nil, errors.Trace(err)
+	}
+	args := entities(appTag)
+
+	var results params.ExposeInfoResults
+	if err := c.facade.FacadeCall("GetExposeInfo", args, &results); err != nil {
+		return false, nil, err
+	}
+	if n := len(results.Results); n != 1 {
+		return false, nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return false, nil, maybeNotFound(err)
+	}
+	return results.Results[0].Exposed, results.Results[0].ExposedEndpoints, nil
+}
+
 // maybeNotFound returns an error satisfying errors.IsNotFound
 // if the supplied error has a CodeNotFound error.
 func maybeNotFound(err *params.Error) error {
@@ -66,6 +66,56 @@ func (s *FirewallerSuite) TestIsExposedError(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *FirewallerSuite) TestExposeInfo(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASFirewaller")
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "GetExposeInfo")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{
+				Tag: "application-gitlab",
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ExposeInfoResults{})
+		*(result.(*params.ExposeInfoResults)) = params.ExposeInfoResults{
+			Results: []params.ExposeInfoResult{{
+				Exposed: true,
+				ExposedEndpoints: map[string]params.ExposedEndpoint{
+					"http": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+				},
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(basetesting.BestVersionCaller{apiCaller, 2})
+	exposed, exposedEndpoints, err := client.ExposeInfo("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsTrue)
+	c.Assert(exposedEndpoints, jc.DeepEquals, map[string]params.ExposedEndpoint{
+		"http": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+}
+
+func (s *FirewallerSuite) TestExposeInfoV1(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "IsExposed")
+		*(result.(*params.BoolResults)) = params.BoolResults{
+			Results: []params.BoolResult{{
+				Result: true,
+			}},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(basetesting.BestVersionCaller{apiCaller, 1})
+	exposed, exposedEndpoints, err := client.ExposeInfo("gitlab")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsTrue)
+	c.Assert(exposedEndpoints, gc.IsNil)
+}
+
 func (s *FirewallerSuite) TestIsExposedInvalidEntityame(c *gc.C) {
 	client := caasfirewaller.NewClient(basetesting.APICallerFunc(func(_ string, _ int, _, _ string, _, _ interface{}) error {
 		return errors.New("should not be called")
@@ -145,6 +195,36 @@ func (s *FirewallerSuite) TestWatchApplications(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "FAIL")
 }
 
+func (s *FirewallerSuite) TestWatchSubnets(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CAASFirewaller")
+		c.Check(version, gc.Equals, 2)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchSubnets")
+		c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResult{})
+		*(result.(*params.StringsWatchResult)) = params.StringsWatchResult{
+			Error: &params.Error{Message: "FAIL"},
+		}
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(basetesting.BestVersionCaller{apiCaller, 2})
+	watcher, err := client.WatchSubnets()
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *FirewallerSuite) TestWatchSubnetsV1(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	client := caasfirewaller.NewClient(basetesting.BestVersionCaller{apiCaller, 1})
+	_, err := client.WatchSubnets()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *FirewallerSuite) TestWatchApplication(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		c.Check(objType, gc.Equals, "CAASFirewaller")
@@ -523,6 +523,10 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		Replay:        true,
 		NoTail:        true,
 		StartTime:     time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		EndTime:       time.Date(2016, 11, 30, 12, 48, 0, 0, time.UTC),
+
+		IncludeMessage: "^hook",
+		ExcludeMessage: "ignored$",
 	}
 
 	client := s.APIState.Client()
@@ -544,6 +548,10 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 		"replay":        {"true"},
 		"noTail":        {"true"},
 		"startTime":     {"2016-11-30T11:48:00.0000001Z"},
+		"endTime":       {"2016-11-30T12:48:00Z"},
+
+		"includeMessage": {"^hook"},
+		"excludeMessage": {"ignored$"},
 	})
 }
 
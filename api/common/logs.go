@@ -53,6 +53,18 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, means only records with a log time on or before
+	// EndTime will be returned. Once EndTime has passed, no more
+	// records are sent and the connection is closed.
+	EndTime time.Time
+	// IncludeMessage is a regular expression; if set, only records
+	// with a matching message are returned.
+	IncludeMessage string
+	// ExcludeMessage is a regular expression; if set, records with a
+	// matching message are not returned.
+	//
+	// Both expressions use Go's regexp syntax.
+	ExcludeMessage string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -80,6 +92,15 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if args.IncludeMessage != "" {
+		attrs.Set("includeMessage", args.IncludeMessage)
+	}
+	if args.ExcludeMessage != "" {
+		attrs.Set("excludeMessage", args.ExcludeMessage)
+	}
 	return attrs
 }
 
@@ -170,6 +170,110 @@ func (s *Suite) TestInitiateMigrationValidationError(c *gc.C) {
 	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
 }
 
+func (s *Suite) TestCheckMigration(c *gc.C) {
+	client, stub := makeCheckMigrationClient(params.CheckMigrationResults{
+		Results: []params.CheckMigrationResult{{
+			Failures: []string{"source: model is dying"},
+		}},
+	})
+	spec := makeSpec()
+	failures, err := client.CheckMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(failures, jc.DeepEquals, []string{"source: model is dying"})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.CheckMigration", []interface{}{specToArgs(spec)}},
+	})
+}
+
+func (s *Suite) TestCheckMigrationError(c *gc.C) {
+	client, _ := makeCheckMigrationClient(params.CheckMigrationResults{
+		Results: []params.CheckMigrationResult{{
+			Error: common.ServerError(errors.New("boom")),
+		}},
+	})
+	failures, err := client.CheckMigration(makeSpec())
+	c.Check(failures, gc.IsNil)
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestCheckMigrationValidationError(c *gc.C) {
+	client, stub := makeCheckMigrationClient(params.CheckMigrationResults{})
+	spec := makeSpec()
+	spec.ModelUUID = "not-a-uuid"
+	_, err := client.CheckMigration(spec)
+	c.Check(err, gc.ErrorMatches, "client-side validation failed: model UUID not valid")
+	c.Check(stub.Calls(), gc.HasLen, 0) // API call shouldn't have happened
+}
+
+func (s *Suite) TestCheckMigrationNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.CheckMigration(makeSpec())
+	c.Check(err, gc.ErrorMatches, "this controller version doesn't support checking migrations")
+}
+
+func makeCheckMigrationClient(results params.CheckMigrationResults) (
+	*controller.Client, *jujutesting.Stub,
+) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.CheckMigrationResults)
+			*out = results
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	return client, &stub
+}
+
+func (s *Suite) TestBackupScheduleStatus(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 7,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.BackupScheduleStatus)
+			*out = params.BackupScheduleStatus{
+				Schedule:  "@daily",
+				LastError: "disk full",
+			}
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	result, err := client.BackupScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.BackupScheduleStatus{
+		Schedule:  "@daily",
+		LastError: "disk full",
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.BackupScheduleStatus", []interface{}{nil}},
+	})
+}
+
+func (s *Suite) TestBackupScheduleStatusNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.BackupScheduleStatus()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *Suite) TestHostedModelConfigs_CallError(c *gc.C) {
 	apiCaller := apitesting.APICallerFunc(func(string, int, string, string, interface{}, interface{}) error {
 		return errors.New("boom")
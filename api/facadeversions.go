@@ -11,21 +11,23 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"Action":                       3,
 	"ActionPruner":                 1,
 	"Agent":                        2,
+	"AgentIntrospection":           1,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  8,
+	"Application":                  9,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
+	"AuditLog":                     1,
 	"Backups":                      2,
 	"Block":                        2,
 	"Bundle":                       2,
 	"CAASAgent":                    1,
-	"CAASFirewaller":               1,
+	"CAASFirewaller":               2,
 	"CAASOperator":                 1,
 	"CAASOperatorProvisioner":      1,
 	"CAASUnitProvisioner":          1,
@@ -34,7 +36,7 @@ var facadeVersions = map[string]int{
 	"Cleaner":                      2,
 	"Client":                       2,
 	"Cloud":                        2,
-	"Controller":                   5,
+	"Controller":                   7,
 	"CredentialManager":            1,
 	"CredentialValidator":          1,
 	"CrossController":              1,
@@ -45,7 +47,7 @@ var facadeVersions = map[string]int{
 	"ExternalControllerUpdater":    1,
 	"FanConfigurer":                1,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   5,
+	"Firewaller":                   6,
 	"FirewallRules":                1,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
@@ -53,6 +55,7 @@ var facadeVersions = map[string]int{
 	"ImageMetadata":                3,
 	"ImageMetadataManager":         1,
 	"InstancePoller":               3,
+	"Introspection":                1,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
 	"LeadershipService":            2,
@@ -71,7 +74,7 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              2,
 	"ModelConfig":                  2,
 	"ModelManager":                 4,
 	"ModelUpgrader":                1,
@@ -85,7 +88,7 @@ var facadeVersions = map[string]int{
 	"Reboot":                       2,
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
-	"RemoteRelations":              1,
+	"RemoteRelations":              2,
 	"Resources":                    1,
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
@@ -94,13 +97,13 @@ var facadeVersions = map[string]int{
 	"Spaces":                       3,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      4,
-	"StorageProvisioner":           4,
+	"Storage":                      5,
+	"StorageProvisioner":           5,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       8,
+	"Uniter":                       9,
 	"Upgrader":                     1,
 	"UpgradeSeries":                1,
 	"UserManager":                  2,
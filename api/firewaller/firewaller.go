@@ -100,6 +100,25 @@ func (c *Client) WatchModelMachines() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of subnets being
+// added to or removed from the current model. It returns a NotSupported
+// error if the controller does not support watching subnets.
+func (c *Client) WatchSubnets() (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("watching subnets")
+	}
+	var result params.StringsWatchResult
+	err := c.facade.FacadeCall("WatchSubnets", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // WatchOpenedPorts returns a StringsWatcher that notifies of
 // changes to the opened ports for the current model.
 func (c *Client) WatchOpenedPorts() (watcher.StringsWatcher, error) {
@@ -4,6 +4,7 @@
 package firewaller_test
 
 import (
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -140,6 +141,39 @@ func (s *firewallerSuite) TestWatchInressAddressesForRelation(c *gc.C) {
 	c.Check(callCount, gc.Equals, 1)
 }
 
+func (s *firewallerSuite) TestWatchSubnets(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Firewaller")
+		c.Check(version, gc.Equals, 6)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchSubnets")
+		c.Assert(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResult{})
+		*(result.(*params.StringsWatchResult)) = params.StringsWatchResult{
+			Error: &params.Error{Message: "FAIL"},
+		}
+		callCount++
+		return nil
+	})
+	client, err := firewaller.NewClient(testing.BestVersionCaller{apiCaller, 6})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.WatchSubnets()
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *firewallerSuite) TestWatchSubnetsNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	client, err := firewaller.NewClient(testing.BestVersionCaller{apiCaller, 5})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.WatchSubnets()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *firewallerSuite) TestControllerAPIInfoForModel(c *gc.C) {
 	var callCount int
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -144,6 +144,48 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 	return endResult, nil
 }
 
+// OpenedEndpointPorts returns the port ranges opened by each unit on the
+// machine for the subnet matching given subnetTag, keyed by the endpoint
+// they were opened for. Port ranges opened for all of a unit's endpoints
+// are keyed by the empty string.
+func (m *Machine) OpenedEndpointPorts(subnetTag names.SubnetTag) (map[names.UnitTag]map[string][]network.PortRange, error) {
+	var results params.MachinePortsResults
+	var subnetTagAsString string
+	if subnetTag.Id() != "" {
+		subnetTagAsString = subnetTag.String()
+	}
+	args := params.MachinePortsParams{
+		Params: []params.MachinePorts{
+			{MachineTag: m.tag.String(), SubnetTag: subnetTagAsString},
+		},
+	}
+	err := m.st.facade.FacadeCall("GetMachinePorts", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	endResult := make(map[names.UnitTag]map[string][]network.PortRange)
+	for _, ports := range result.Ports {
+		unitTag, err := names.ParseUnitTag(ports.UnitTag)
+		if err != nil {
+			return nil, err
+		}
+		unitPorts, ok := endResult[unitTag]
+		if !ok {
+			unitPorts = make(map[string][]network.PortRange)
+			endResult[unitTag] = unitPorts
+		}
+		unitPorts[ports.Endpoint] = append(unitPorts[ports.Endpoint], ports.PortRange.NetworkPortRange())
+	}
+	return endResult, nil
+}
+
 // IsManual returns true if the machine was manually provisioned.
 func (m *Machine) IsManual() (bool, error) {
 	var results params.BoolResults
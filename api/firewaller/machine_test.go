@@ -135,6 +135,28 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 	})
 }
 
+func (s *machineSuite) TestOpenedEndpointPorts(c *gc.C) {
+	unitTag := s.units[0].Tag().(names.UnitTag)
+
+	// No ports opened at first.
+	ports, err := s.apiMachine.OpenedEndpointPorts(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, gc.HasLen, 0)
+
+	err = s.units[0].OpenPort("tcp", 1234)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err = s.apiMachine.OpenedEndpointPorts(names.SubnetTag{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports, jc.DeepEquals, map[names.UnitTag]map[string][]network.PortRange{
+		unitTag: {
+			"":    {{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}},
+			"url": {{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+		},
+	})
+}
+
 func (s *machineSuite) TestIsManual(c *gc.C) {
 	answer, err := s.machines[0].IsManual()
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,46 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the introspection handlers of the
+// model's machine and unit agents.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Introspection client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Introspection")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Introspect asks the machine or unit agent with the given tag to run
+// the introspection handler at the given path, such as "/depengine",
+// and returns its output.
+func (c *Client) Introspect(agent names.Tag, path string) ([]byte, error) {
+	args := params.IntrospectArgs{
+		Args: []params.IntrospectArg{{Tag: agent.String(), Path: path}},
+	}
+	var results params.IntrospectResults
+	if err := c.facade.FacadeCall("Introspect", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Output, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/introspection"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestIntrospect(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Introspection")
+		c.Check(request, gc.Equals, "Introspect")
+		c.Check(arg, jc.DeepEquals, params.IntrospectArgs{
+			Args: []params.IntrospectArg{{Tag: "unit-mysql-0", Path: "/depengine"}},
+		})
+		*(result.(*params.IntrospectResults)) = params.IntrospectResults{
+			Results: []params.IntrospectResult{{Output: []byte("some output")}},
+		}
+		return nil
+	})
+	output, err := introspection.NewClient(apiCaller).Introspect(names.NewUnitTag("mysql/0"), "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(output), gc.Equals, "some output")
+}
+
+func (s *clientSuite) TestIntrospectError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.IntrospectResults)) = params.IntrospectResults{
+			Results: []params.IntrospectResult{{Error: &params.Error{Message: "timed out"}}},
+		}
+		return nil
+	})
+	_, err := introspection.NewClient(apiCaller).Introspect(names.NewMachineTag("0"), "/metrics")
+	c.Assert(err, gc.ErrorMatches, "timed out")
+}
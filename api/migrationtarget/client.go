@@ -43,14 +43,39 @@ type Client struct {
 }
 
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
-	args := params.MigrationModelInfo{
+	args := modelInfoParams(model)
+	return c.caller.FacadeCall("Prechecks", args, nil)
+}
+
+// CheckMigration asks the target controller to report every reason it
+// would refuse a migration of the model, including trying an import of
+// the serialized model without keeping it.
+func (c *Client) CheckMigration(model coremigration.ModelInfo, bytes []byte) ([]string, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("checking migrations on this version of Juju")
+	}
+	args := params.CheckMigrationArgs{
+		Model: modelInfoParams(model),
+		Bytes: bytes,
+	}
+	var result params.StringsResult
+	if err := c.caller.FacadeCall("CheckMigration", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+func modelInfoParams(model coremigration.ModelInfo) params.MigrationModelInfo {
+	return params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
 	}
-	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
 // Import takes a serialized model and imports it into the target
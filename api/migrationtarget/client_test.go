@@ -77,6 +77,53 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestCheckMigration(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			out := result.(*params.StringsResult)
+			out.Result = []string{"upgrade in progress"}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+
+	ownerTag := names.NewUserTag("owner")
+	vers := version.MustParse("1.2.3")
+	failures, err := client.CheckMigration(coremigration.ModelInfo{
+		UUID:                   "uuid",
+		Owner:                  ownerTag,
+		Name:                   "name",
+		AgentVersion:           vers,
+		ControllerAgentVersion: vers,
+	}, []byte("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, jc.DeepEquals, []string{"upgrade in progress"})
+
+	expectedArg := params.CheckMigrationArgs{
+		Model: params.MigrationModelInfo{
+			UUID:                   "uuid",
+			Name:                   "name",
+			OwnerTag:               ownerTag.String(),
+			AgentVersion:           vers,
+			ControllerAgentVersion: vers,
+		},
+		Bytes: []byte("foo"),
+	}
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.CheckMigration", []interface{}{"", expectedArg}},
+	})
+}
+
+func (s *ClientSuite) TestCheckMigrationNotSupported(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	_, err := client.CheckMigration(coremigration.ModelInfo{}, nil)
+	c.Assert(err, gc.ErrorMatches, "checking migrations on this version of Juju not supported")
+	stub.CheckNoCalls(c)
+}
+
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
@@ -295,3 +296,23 @@ func (c *Client) SetRemoteApplicationStatus(applicationName string, status statu
 	}
 	return results.OneError()
 }
+
+// UpdateControllerForModel records that the specified remote model is
+// now hosted by the given controller.
+func (c *Client) UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error {
+	args := params.UpdateControllersForModelsParams{Changes: []params.UpdateControllerForModel{{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Info: params.ExternalControllerInfo{
+			ControllerTag: controller.ControllerTag.String(),
+			Alias:         controller.Alias,
+			Addrs:         controller.Addrs,
+			CACert:        controller.CACert,
+		},
+	}}}
+	var results params.ErrorResults
+	err := c.facade.FacadeCall("UpdateControllersForModels", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api/remoterelations"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -463,3 +464,40 @@ func (s *remoteRelationsSuite) TestSetRemoteApplicationStatus(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "FAIL")
 	c.Check(callCount, gc.Equals, 1)
 }
+
+func (s *remoteRelationsSuite) TestUpdateControllerForModel(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "RemoteRelations")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "UpdateControllersForModels")
+		c.Assert(arg, gc.DeepEquals, params.UpdateControllersForModelsParams{
+			Changes: []params.UpdateControllerForModel{{
+				ModelTag: coretesting.ModelTag.String(),
+				Info: params.ExternalControllerInfo{
+					ControllerTag: coretesting.ControllerTag.String(),
+					Alias:         "target",
+					Addrs:         []string{"10.0.0.1:17070"},
+					CACert:        coretesting.CACert,
+				},
+			}}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "FAIL"},
+			}},
+		}
+		callCount++
+		return nil
+	})
+	client := remoterelations.NewClient(apiCaller)
+	err := client.UpdateControllerForModel(crossmodel.ControllerInfo{
+		ControllerTag: coretesting.ControllerTag,
+		Alias:         "target",
+		Addrs:         []string{"10.0.0.1:17070"},
+		CACert:        coretesting.CACert,
+	}, coretesting.ModelTag.Id())
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
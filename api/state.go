@@ -70,10 +70,18 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 			if err := st.APICall("Admin", 3, "", "RedirectInfo", nil, &resp); err != nil {
 				return errors.Annotatef(err, "cannot get redirect addresses")
 			}
-			return &RedirectError{
+			redirectErr := &RedirectError{
 				Servers: params.NetworkHostsPorts(resp.Servers),
 				CACert:  resp.CACert,
 			}
+			if resp.ControllerTag != "" {
+				controllerTag, err := names.ParseControllerTag(resp.ControllerTag)
+				if err != nil {
+					return errors.Annotatef(err, "cannot get redirect controller")
+				}
+				redirectErr.ControllerTag = controllerTag
+			}
+			return redirectErr
 		}
 		return errors.Trace(err)
 	}
@@ -143,6 +143,13 @@ func (c *Client) ListFilesystems(machines []string) ([]params.FilesystemDetailsL
 // NOTE(axw) for old controllers, the results will only
 // contain errors.
 func (c *Client) AddToUnit(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
+	if c.BestAPIVersion() < 5 {
+		for _, s := range storages {
+			if s.Snapshot != "" {
+				return nil, errors.NotSupportedf("restoring storage from snapshots with this version of Juju")
+			}
+		}
+	}
 	out := params.AddStorageResults{}
 	in := params.StoragesAddParams{Storages: storages}
 	err := c.facade.FacadeCall("AddToUnit", in, &out)
@@ -287,3 +294,124 @@ func (c *Client) Import(
 	}
 	return names.ParseStorageTag(results.Results[0].Result.StorageTag)
 }
+
+// Resize requests that the storage instance with the specified ID be
+// grown to the specified size, in MiB.
+func (c *Client) Resize(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("resizing storage with this version of Juju")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	var results params.ErrorResults
+	args := params.ResizeStorage{
+		[]params.ResizeStorageInstance{{
+			Tag:  names.NewStorageTag(storageId).String(),
+			Size: size,
+		}},
+	}
+	if err := c.facade.FacadeCall("Resize", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CreateSnapshots requests snapshots of the volumes backing the
+// storage instances with the specified IDs.
+func (c *Client) CreateSnapshots(storageIds []string) ([]params.VolumeSnapshotDetailsResult, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("snapshotting storage with this version of Juju")
+	}
+	args := params.Entities{Entities: make([]params.Entity, len(storageIds))}
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		args.Entities[i].Tag = names.NewStorageTag(id).String()
+	}
+	var results params.VolumeSnapshotDetailsResults
+	if err := c.facade.FacadeCall("CreateSnapshots", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(storageIds), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// ListSnapshots returns the details of all volume snapshots in the model.
+func (c *Client) ListSnapshots() ([]params.VolumeSnapshotDetails, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("listing storage snapshots with this version of Juju")
+	}
+	var results params.VolumeSnapshotDetailsResults
+	if err := c.facade.FacadeCall("ListSnapshots", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshots := make([]params.VolumeSnapshotDetails, len(results.Results))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, errors.Trace(result.Error)
+		}
+		snapshots[i] = *result.Result
+	}
+	return snapshots, nil
+}
+
+// UpdatePool replaces the provider type and attributes of the named
+// storage pool. If provider is empty, the pool's provider is unchanged.
+func (c *Client) UpdatePool(pname, provider string, attrs map[string]interface{}) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("updating storage pools with this version of Juju")
+	}
+	var results params.ErrorResults
+	args := params.StoragePoolArgs{
+		Pools: []params.StoragePool{{
+			Name:     pname,
+			Provider: provider,
+			Attrs:    attrs,
+		}},
+	}
+	if err := c.facade.FacadeCall("UpdatePools", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemovePool removes the named storage pool.
+func (c *Client) RemovePool(pname string) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("removing storage pools with this version of Juju")
+	}
+	var results params.ErrorResults
+	args := params.StoragePoolNames{
+		Pools: []params.StoragePoolName{{Name: pname}},
+	}
+	if err := c.facade.FacadeCall("RemovePools", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ShowPool returns the details of the named storage pool, including
+// the storage instances provisioned from it.
+func (c *Client) ShowPool(pname string) (params.StoragePoolDetails, error) {
+	if c.BestAPIVersion() < 5 {
+		return params.StoragePoolDetails{}, errors.NotSupportedf("showing storage pools with this version of Juju")
+	}
+	var results params.StoragePoolDetailsResults
+	args := params.StoragePoolNames{
+		Pools: []params.StoragePoolName{{Name: pname}},
+	}
+	if err := c.facade.FacadeCall("ShowPools", args, &results); err != nil {
+		return params.StoragePoolDetails{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.StoragePoolDetails{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.StoragePoolDetails{}, errors.Trace(err)
+	}
+	return *results.Results[0].Result, nil
+}
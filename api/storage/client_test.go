@@ -847,3 +847,230 @@ func (s *storageMockSuite) TestImportArityMismatch(c *gc.C) {
 	_, err := client.Import(jujustorage.StorageKindBlock, "foo", "bar", "baz")
 	c.Check(err, gc.ErrorMatches, `expected 1 result, got 2`)
 }
+
+func (s *storageMockSuite) TestResize(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "Resize")
+				c.Check(a, jc.DeepEquals, params.ResizeStorage{[]params.ResizeStorageInstance{
+					{Tag: "storage-foo-0", Size: 2048},
+				}})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "qux"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("foo/0", 2048)
+	c.Check(err, gc.ErrorMatches, "qux")
+}
+
+func (s *storageMockSuite) TestResizeNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.Resize("foo/0", 2048)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestCreateSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CreateSnapshots")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "storage-foo-0"}, {Tag: "storage-bar-1"},
+				}})
+				results := result.(*params.VolumeSnapshotDetailsResults)
+				results.Results = []params.VolumeSnapshotDetailsResult{{
+					Result: &params.VolumeSnapshotDetails{Id: "0@0", VolumeTag: "volume-0"},
+				}, {
+					Error: &params.Error{Message: "qux"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.CreateSnapshots([]string{"foo/0", "bar/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{{
+		Result: &params.VolumeSnapshotDetails{Id: "0@0", VolumeTag: "volume-0"},
+	}, {
+		Error: &params.Error{Message: "qux"},
+	}})
+}
+
+func (s *storageMockSuite) TestCreateSnapshotsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.CreateSnapshots([]string{"foo/0"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestListSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ListSnapshots")
+				c.Check(a, gc.IsNil)
+				results := result.(*params.VolumeSnapshotDetailsResults)
+				results.Results = []params.VolumeSnapshotDetailsResult{{
+					Result: &params.VolumeSnapshotDetails{Id: "0@0", VolumeTag: "volume-0"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	snapshots, err := client.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshotDetails{
+		{Id: "0@0", VolumeTag: "volume-0"},
+	})
+}
+
+func (s *storageMockSuite) TestAddToUnitSnapshotNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.AddToUnit([]params.StorageAddParams{{
+		UnitTag:     "unit-foo-0",
+		StorageName: "data",
+		Snapshot:    "0@0",
+	}})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *storageMockSuite) TestUpdatePool(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "UpdatePools")
+				c.Check(a, jc.DeepEquals, params.StoragePoolArgs{[]params.StoragePool{{
+					Name:     "pname",
+					Provider: "loop",
+					Attrs:    map[string]interface{}{"foo": "bar"},
+				}}})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "qux"},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.UpdatePool("pname", "loop", map[string]interface{}{"foo": "bar"})
+	c.Check(err, gc.ErrorMatches, "qux")
+}
+
+func (s *storageMockSuite) TestRemovePool(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RemovePools")
+				c.Check(a, jc.DeepEquals, params.StoragePoolNames{[]params.StoragePoolName{
+					{Name: "pname"},
+				}})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.RemovePool("pname")
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *storageMockSuite) TestShowPool(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ShowPools")
+				c.Check(a, jc.DeepEquals, params.StoragePoolNames{[]params.StoragePoolName{
+					{Name: "pname"},
+				}})
+				results := result.(*params.StoragePoolDetailsResults)
+				results.Results = []params.StoragePoolDetailsResult{{
+					Result: &params.StoragePoolDetails{
+						Pool:        params.StoragePool{Name: "pname", Provider: "loop"},
+						StorageTags: []string{"storage-data-0"},
+					},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	details, err := client.ShowPool("pname")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details, jc.DeepEquals, params.StoragePoolDetails{
+		Pool:        params.StoragePool{Name: "pname", Provider: "loop"},
+		StorageTags: []string{"storage-data-0"},
+	})
+}
+
+func (s *storageMockSuite) TestPoolManagementNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.UpdatePool("pname", "", nil)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = client.RemovePool("pname")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = client.ShowPool("pname")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
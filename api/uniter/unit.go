@@ -492,6 +492,52 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
 	return result.OneError()
 }
 
+// OpenEndpointPorts sets the policy of the port range with protocol to
+// be opened for the named endpoint only.
+func (u *Unit) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	if v := u.st.BestAPIVersion(); v < 9 {
+		return errors.NotSupportedf("opening ports for an endpoint with this version (%d) of Juju", v)
+	}
+	var result params.ErrorResults
+	args := params.EntitiesPortRanges{
+		Entities: []params.EntityPortRange{{
+			Tag:      u.tag.String(),
+			Protocol: protocol,
+			FromPort: fromPort,
+			ToPort:   toPort,
+			Endpoint: endpoint,
+		}},
+	}
+	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// CloseEndpointPorts sets the policy of the port range with protocol to
+// be closed for the named endpoint.
+func (u *Unit) CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	if v := u.st.BestAPIVersion(); v < 9 {
+		return errors.NotSupportedf("closing ports for an endpoint with this version (%d) of Juju", v)
+	}
+	var result params.ErrorResults
+	args := params.EntitiesPortRanges{
+		Entities: []params.EntityPortRange{{
+			Tag:      u.tag.String(),
+			Protocol: protocol,
+			FromPort: fromPort,
+			ToPort:   toPort,
+			Endpoint: endpoint,
+		}},
+	}
+	err := u.st.facade.FacadeCall("ClosePorts", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 var ErrNoCharmURLSet = errors.New("unit has no charm url set")
 
 // CharmURL returns the charm URL this unit is currently using.
@@ -814,3 +860,45 @@ func (u *Unit) NetworkInfo(bindings []string, relationId *int) (map[string]param
 
 	return results.Results, nil
 }
+
+// CharmState returns the charm state stored on behalf of the unit.
+func (u *Unit) CharmState() (map[string]string, error) {
+	if v := u.st.BestAPIVersion(); v < 9 {
+		return nil, errors.NotSupportedf("CharmState not supported by this version (%d) of Juju", v)
+	}
+	var results params.CharmStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("CharmState", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.CharmState, nil
+}
+
+// SetCharmState replaces the charm state stored on behalf of the unit.
+func (u *Unit) SetCharmState(charmState map[string]string) error {
+	if v := u.st.BestAPIVersion(); v < 9 {
+		return errors.NotSupportedf("SetCharmState not supported by this version (%d) of Juju", v)
+	}
+	var results params.ErrorResults
+	args := params.EntityCharmStates{
+		Entities: []params.EntityCharmState{{
+			Tag:        u.tag.String(),
+			CharmState: charmState,
+		}},
+	}
+	err := u.st.facade.FacadeCall("SetCharmState", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
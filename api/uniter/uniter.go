@@ -268,6 +268,24 @@ func (st *State) ActionBegin(tag names.ActionTag) error {
 	return nil
 }
 
+// LogActionMessage logs a progress message for the specified action.
+func (st *State) LogActionMessage(tag names.ActionTag, message string) error {
+	if v := st.BestAPIVersion(); v < 9 {
+		return errors.NotSupportedf("LogActionMessage not supported by this version (%d) of Juju", v)
+	}
+	var outcome params.ErrorResults
+	args := params.ActionMessageParams{
+		Messages: []params.EntityString{
+			{Tag: tag.String(), Value: message},
+		},
+	}
+	err := st.facade.FacadeCall("LogActionsMessages", args, &outcome)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return outcome.OneError()
+}
+
 // ActionFinish captures the structured output of an action.
 func (st *State) ActionFinish(tag names.ActionTag, status string, results map[string]interface{}, message string) error {
 	var outcome params.ErrorResults
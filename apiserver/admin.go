@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -20,7 +21,9 @@ import (
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/auditlog"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/rpc"
 	"github.com/juju/juju/rpc/rpcreflect"
@@ -171,7 +174,7 @@ func (a *admin) getAuditRecorder(req params.LoginRequest, authResult *authResult
 	// lots of readonly conversations (like "juju status" requests).
 	filter := observer.MakeInterestingRequestFilter(cfg.ExcludeMethods)
 	result, err := auditlog.NewRecorder(
-		observer.NewAuditLogFilter(cfg.Target, filter),
+		observer.NewAuditLogFilter(newPublishingAuditLog(cfg.Target, a.srv.shared.centralHub), filter),
 		a.srv.clock,
 		auditlog.ConversationArgs{
 			Who:          a.root.entity.Tag().Id(),
@@ -524,3 +527,80 @@ func (r *errRoot) FindMethod(rootName string, version int, methodName string) (r
 
 func (r *errRoot) Kill() {
 }
+
+// redirectRoot implements the API that a client first sees when
+// connecting to a model which has been migrated to another controller.
+// Logins are rejected with a redirect error, after which the client
+// can ask for the details of the controller now hosting the model.
+type redirectRoot struct {
+	admin *redirectAdmin
+}
+
+func newRedirectRoot(targetInfo *coremigration.TargetInfo) *redirectRoot {
+	return &redirectRoot{admin: &redirectAdmin{targetInfo: targetInfo}}
+}
+
+// FindMethod conforms to the same API as initialRoot, but only the
+// Admin facade is available.
+func (r *redirectRoot) FindMethod(rootName string, version int, methodName string) (rpcreflect.MethodCaller, error) {
+	if rootName != "Admin" {
+		return nil, &rpcreflect.CallNotImplementedError{
+			RootMethod: rootName,
+			Version:    version,
+		}
+	}
+	return rpcreflect.ValueOf(reflect.ValueOf(r.admin)).FindMethod(rootName, 0, methodName)
+}
+
+func (r *redirectRoot) Kill() {
+}
+
+// redirectAdmin is the Admin facade served by redirectRoot.
+type redirectAdmin struct {
+	targetInfo *coremigration.TargetInfo
+}
+
+// Admin returns an object that provides API access to methods that can be
+// called even when not authenticated.
+func (a *redirectAdmin) Admin(id string) (*redirectAdmin, error) {
+	if id != "" {
+		// Safeguard id for possible future use.
+		return nil, common.ErrBadId
+	}
+	return a, nil
+}
+
+// Login always fails with a redirect error, as the model is no
+// longer hosted by this controller.
+func (a *redirectAdmin) Login(req params.LoginRequest) (params.LoginResult, error) {
+	return params.LoginResult{}, &params.Error{
+		Message: "model has been migrated to another controller",
+		Code:    params.CodeRedirect,
+	}
+}
+
+// RedirectInfo returns the details of the controller the model was
+// migrated to.
+func (a *redirectAdmin) RedirectInfo() (params.RedirectInfoResult, error) {
+	hostPorts, err := network.ParseHostPorts(a.targetInfo.Addrs...)
+	if err != nil {
+		return params.RedirectInfoResult{}, errors.Trace(err)
+	}
+	return params.RedirectInfoResult{
+		Servers:       [][]params.HostPort{params.FromNetworkHostPorts(hostPorts)},
+		CACert:        a.targetInfo.CACert,
+		ControllerTag: a.targetInfo.ControllerTag.String(),
+	}, nil
+}
+
+// migratedModelTargetInfo returns the details of the controller that
+// the model with the given UUID was migrated to, or an error satisfying
+// errors.IsNotFound if the model has not been migrated away.
+func migratedModelTargetInfo(st *state.State, modelUUID string) (*coremigration.TargetInfo, error) {
+	mig, err := state.CompletedMigration(st, modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	targetInfo, err := mig.TargetInfo()
+	return targetInfo, errors.Trace(err)
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/juju/juju/constraints"
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/migration"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
@@ -765,6 +766,48 @@ func (s *loginSuite) TestNonExistentModel(c *gc.C) {
 	})
 }
 
+func (s *loginSuite) TestMigratedModel(c *gc.C) {
+	info, srv := s.newServer(c)
+	defer assertStop(c, srv)
+
+	modelState := s.Factory.MakeModel(c, nil)
+	defer modelState.Close()
+	targetControllerTag := names.NewControllerTag(utils.MustNewUUID().String())
+	mig, err := modelState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: s.AdminUserTag(c),
+		TargetInfo: migration.TargetInfo{
+			ControllerTag: targetControllerTag,
+			Addrs:         []string{"1.2.3.4:5555"},
+			CACert:        "target-cert",
+			AuthTag:       s.AdminUserTag(c),
+			Password:      "secret",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	for _, phase := range []migration.Phase{
+		migration.IMPORT,
+		migration.VALIDATION,
+		migration.SUCCESS,
+		migration.LOGTRANSFER,
+		migration.REAP,
+		migration.DONE,
+	} {
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+	c.Assert(modelState.RemoveExportingModelDocs(), jc.ErrorIsNil)
+
+	info.ModelTag = modelState.ModelTag()
+	_, openErr := api.Open(info, fastDialOpts)
+	c.Assert(openErr, gc.ErrorMatches, "redirection to alternative server required")
+	hostPorts, err := network.ParseHostPorts("1.2.3.4:5555")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errors.Cause(openErr), jc.DeepEquals, &api.RedirectError{
+		Servers:       [][]network.HostPort{hostPorts},
+		CACert:        "target-cert",
+		ControllerTag: targetControllerTag,
+	})
+}
+
 func (s *loginSuite) TestInvalidModel(c *gc.C) {
 	info, srv := s.newServer(c)
 	defer assertStop(c, srv)
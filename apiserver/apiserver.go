@@ -36,8 +36,10 @@ import (
 	"github.com/juju/juju/apiserver/observer"
 	"github.com/juju/juju/apiserver/websocket"
 	"github.com/juju/juju/core/auditlog"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/pubsub/apiserver"
+	psauditlog "github.com/juju/juju/pubsub/auditlog"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/rpc"
@@ -306,12 +308,19 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		return nil, errors.Annotate(err, "unable to subscribe to restart message")
 	}
 
+	unsubscribeAuditLogQuery, err := cfg.Hub.Subscribe(psauditlog.QueryTopic, srv.onAuditLogQuery)
+	if err != nil {
+		unsubscribe()
+		return nil, errors.Annotate(err, "unable to subscribe to audit log queries")
+	}
+
 	ready := make(chan struct{})
 	srv.tomb.Go(func() error {
 		defer srv.dbloggers.dispose()
 		defer srv.logSinkWriter.Close()
 		defer srv.shared.Close()
 		defer unsubscribe()
+		defer unsubscribeAuditLogQuery()
 		return srv.loop(ready)
 	})
 
@@ -841,11 +850,19 @@ func (srv *Server) serveConn(
 		defer st.Release()
 		h, err = newAPIHandler(srv, st.State, conn, modelUUID, connectionID, host)
 	}
+	var redirectInfo *coremigration.TargetInfo
 	if errors.IsNotFound(err) {
-		err = errors.Wrap(err, common.UnknownModelError(resolvedModelUUID))
+		// The model may have been migrated to another controller,
+		// in which case the client is redirected there.
+		redirectInfo, err = migratedModelTargetInfo(statePool.SystemState(), resolvedModelUUID)
+		if errors.IsNotFound(err) {
+			err = errors.Wrap(err, common.UnknownModelError(resolvedModelUUID))
+		}
 	}
 
-	if err != nil {
+	if redirectInfo != nil {
+		conn.ServeRoot(newRedirectRoot(redirectInfo), recorderFactory, serverError)
+	} else if err != nil {
 		conn.ServeRoot(&errRoot{errors.Trace(err)}, recorderFactory, serverError)
 	} else {
 		// Set up the admin apis used to accept logins and direct
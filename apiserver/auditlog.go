@@ -0,0 +1,110 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/auditlog"
+	psauditlog "github.com/juju/juju/pubsub/auditlog"
+)
+
+// publishingAuditLog is an auditlog.AuditLog which, as well as writing
+// records to the target, publishes them on the local hub so that they
+// can be forwarded along with the other logs.
+type publishingAuditLog struct {
+	auditlog.AuditLog
+	hub SharedHub
+}
+
+func newPublishingAuditLog(target auditlog.AuditLog, hub SharedHub) auditlog.AuditLog {
+	return &publishingAuditLog{
+		AuditLog: target,
+		hub:      hub,
+	}
+}
+
+// AddConversation implements auditlog.AuditLog.
+func (a *publishingAuditLog) AddConversation(c auditlog.Conversation) error {
+	if err := a.AuditLog.AddConversation(c); err != nil {
+		return errors.Trace(err)
+	}
+	a.publish(auditlog.Record{Conversation: &c})
+	return nil
+}
+
+// AddRequest implements auditlog.AuditLog.
+func (a *publishingAuditLog) AddRequest(r auditlog.Request) error {
+	if err := a.AuditLog.AddRequest(r); err != nil {
+		return errors.Trace(err)
+	}
+	a.publish(auditlog.Record{Request: &r})
+	return nil
+}
+
+// AddResponse implements auditlog.AuditLog.
+func (a *publishingAuditLog) AddResponse(r auditlog.ResponseErrors) error {
+	if err := a.AuditLog.AddResponse(r); err != nil {
+		return errors.Trace(err)
+	}
+	a.publish(auditlog.Record{Errors: &r})
+	return nil
+}
+
+// publish sends the record to any local subscribers. The audit log
+// file is the record of truth, so failing to publish doesn't fail
+// the request.
+func (a *publishingAuditLog) publish(record auditlog.Record) {
+	_, err := a.hub.Publish(psauditlog.RecordTopic, psauditlog.RecordMessage{
+		Record:    record,
+		LocalOnly: true,
+	})
+	if err != nil {
+		logger.Errorf("publishing audit record: %v", err)
+	}
+}
+
+// onAuditLogQuery responds to audit log queries published by the
+// AuditLog facade on any controller, with the matching entries from
+// the audit log written by this API server. Reading the logs can take
+// a while, so it's done in its own goroutine rather than holding up
+// the hub.
+func (srv *Server) onAuditLogQuery(topic string, req psauditlog.QueryRequest, err error) {
+	if err != nil {
+		logger.Criticalf("programming error in %s message data: %v", topic, err)
+		return
+	}
+	select {
+	case <-srv.tomb.Dying():
+		// Don't start any more work once the server is stopping;
+		// the facade will report this controller as unavailable.
+		return
+	default:
+	}
+	srv.wg.Add(1)
+	go func() {
+		defer srv.wg.Done()
+		srv.answerAuditLogQuery(req)
+	}()
+}
+
+func (srv *Server) answerAuditLogQuery(req psauditlog.QueryRequest) {
+	response := psauditlog.QueryResponse{
+		Origin: srv.tag.Id(),
+	}
+	filter, err := req.Filter()
+	if err == nil {
+		if filter.Limit <= 0 || filter.Limit > auditlog.MaxQueryLimit {
+			filter.Limit = auditlog.MaxQueryLimit
+		}
+		response.Entries, err = auditlog.QueryLogDir(srv.logDir, filter)
+	}
+	if err != nil {
+		logger.Errorf("querying audit log: %v", err)
+		response.Error = err.Error()
+	}
+	if _, err := srv.shared.centralHub.Publish(req.ResponseTopic, response); err != nil {
+		logger.Errorf("publishing audit log query response: %v", err)
+	}
+}
@@ -19,13 +19,16 @@ import (
 	"github.com/juju/juju/state/backups"
 )
 
-var newBackups = func(st *state.State, m *state.Model) (backups.Backups, io.Closer) {
+var newBackups = func(st *state.State, m *state.Model) (backups.Backups, io.Closer, error) {
 	backend := struct {
 		*state.State
 		*state.Model
 	}{st, m}
-	stor := backups.NewStorage(backend)
-	return backups.NewBackups(stor), stor
+	stor, err := backups.NewStorage(backend)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return backups.NewBackups(stor), stor, nil
 }
 
 // backupHandler handles backup requests.
@@ -54,7 +57,11 @@ func (h *backupHandler) ServeHTTP(resp http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	backups, closer := newBackups(st.State, m)
+	backups, closer, err := newBackups(st.State, m)
+	if err != nil {
+		h.sendError(resp, err)
+		return
+	}
 	defer closer.Close()
 
 	switch req.Method {
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 )
 
@@ -36,7 +37,14 @@ func (s *ControllerConfigAPI) ControllerConfig() (params.ControllerConfigResult,
 	if err != nil {
 		return result, err
 	}
-	result.Config = params.ControllerConfig(config)
+	result.Config = make(params.ControllerConfig)
+	for key, value := range config {
+		// Credentials are only needed inside the controller.
+		if controller.SecretConfigAttributes.Contains(key) {
+			continue
+		}
+		result.Config[key] = value
+	}
 	return result, nil
 }
 
@@ -8,10 +8,12 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
@@ -82,6 +84,11 @@ func newDebugLogHandler(
 //   replay -> string - one of [true, false], if true, start the file from the start
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
+//   startTime -> string - RFC3339 time, only send lines logged at or after this time
+//   endTime -> string - RFC3339 time, only send lines logged at or before this time
+//      - the connection is closed once the end time has passed
+//   includeMessage -> string - regular expression, only send lines with matching messages
+//   excludeMessage -> string - regular expression, do not send lines with matching messages
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	handler := func(conn *websocket.Conn) {
 		socket := &debugLogSocketImpl{conn}
@@ -111,6 +118,7 @@ func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 			socket.sendError(err)
 			return
 		}
+		params.clock = h.ctxt.srv.clock
 
 		if err := h.handle(st, params, socket, h.ctxt.stop()); err != nil {
 			if isBrokenPipe(err) {
@@ -174,16 +182,23 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	startTime      time.Time
+	endTime        time.Time
+	maxLines       uint
+	fromTheStart   bool
+	noTail         bool
+	backlog        uint
+	filterLevel    loggo.Level
+	includeEntity  []string
+	excludeEntity  []string
+	includeModule  []string
+	excludeModule  []string
+	includeMessage string
+	excludeMessage string
+
+	// clock is the API server's clock, used to decide when the end
+	// time has passed.
+	clock clock.Clock
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -239,6 +254,31 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("endTime"); value != "" {
+		endTime, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return params, errors.Errorf("end time %q is not a valid time in RFC3339 format", value)
+		}
+		if endTime.Before(params.startTime) {
+			return params, errors.Errorf("end time %q is before start time", value)
+		}
+		params.endTime = endTime
+	}
+
+	if value := queryMap.Get("includeMessage"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return params, errors.Errorf("includeMessage value %q is not a valid regular expression", value)
+		}
+		params.includeMessage = value
+	}
+
+	if value := queryMap.Get("excludeMessage"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return params, errors.Errorf("excludeMessage value %q is not a valid regular expression", value)
+		}
+		params.excludeMessage = value
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
@@ -5,8 +5,10 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -31,16 +33,21 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	t1 := time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC)
+	t2 := time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC)
 	reqParams := debugLogParams{
-		fromTheStart:  false,
-		noTail:        true,
-		backlog:       11,
-		startTime:     t1,
-		filterLevel:   loggo.INFO,
-		includeEntity: []string{"foo"},
-		includeModule: []string{"bar"},
-		excludeEntity: []string{"baz"},
-		excludeModule: []string{"qux"},
+		fromTheStart:   false,
+		noTail:         true,
+		backlog:        11,
+		startTime:      t1,
+		endTime:        t2,
+		filterLevel:    loggo.INFO,
+		includeEntity:  []string{"foo"},
+		includeModule:  []string{"bar"},
+		excludeEntity:  []string{"baz"},
+		excludeModule:  []string{"qux"},
+		includeMessage: "^hook",
+		excludeMessage: "ignored$",
+		clock:          testclock.NewClock(t1),
 	}
 
 	called := false
@@ -57,6 +64,10 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.EndTime, gc.Equals, t2)
+		c.Assert(params.IncludeMessage, gc.Equals, "^hook")
+		c.Assert(params.ExcludeMessage, gc.Equals, "ignored$")
+		c.Assert(params.Clock, gc.Equals, reqParams.clock)
 
 		return newFakeLogTailer(), nil
 	})
@@ -68,6 +79,43 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestReadParamsEndTimeAndMessages(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"startTime":      {"2016-11-30T10:51:00Z"},
+		"endTime":        {"2016-11-30T11:51:00Z"},
+		"includeMessage": {"^hook"},
+		"excludeMessage": {"ignored$"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.endTime, gc.Equals, time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC))
+	c.Assert(params.includeMessage, gc.Equals, "^hook")
+	c.Assert(params.excludeMessage, gc.Equals, "ignored$")
+
+	for i, test := range []struct {
+		query  url.Values
+		expect string
+	}{{
+		query:  url.Values{"endTime": {"yesterday"}},
+		expect: `end time "yesterday" is not a valid time in RFC3339 format`,
+	}, {
+		query: url.Values{
+			"startTime": {"2016-11-30T10:51:00Z"},
+			"endTime":   {"2016-11-30T09:51:00Z"},
+		},
+		expect: `end time "2016-11-30T09:51:00Z" is before start time`,
+	}, {
+		query:  url.Values{"includeMessage": {"(unclosed"}},
+		expect: `includeMessage value "\(unclosed" is not a valid regular expression`,
+	}, {
+		query:  url.Values{"excludeMessage": {"[z-a]"}},
+		expect: `excludeMessage value "\[z-a\]" is not a valid regular expression`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := readDebugLogParams(test.query)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
 func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	reqParams := debugLogParams{
 		fromTheStart: true,
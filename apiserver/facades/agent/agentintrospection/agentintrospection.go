@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentintrospection implements the AgentIntrospection
+// facade, used by machine and unit agents to handle introspection
+// requests made through the Introspection facade.
+package agentintrospection
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the state methods needed by the facade.
+type Backend interface {
+	WatchIntrospectionRequests(agent names.Tag) state.StringsWatcher
+	IntrospectionRequest(id string) (Request, error)
+}
+
+// Request defines the methods of a state.IntrospectionRequest needed
+// by the facade.
+type Request interface {
+	Id() string
+	Agent() names.Tag
+	Path() string
+	Completed() bool
+	Complete(output []byte, errMsg string) error
+}
+
+// Facade implements the AgentIntrospection facade.
+type Facade struct {
+	backend    Backend
+	resources  facade.Resources
+	authorizer facade.Authorizer
+}
+
+// NewExternalFacade is used for API registration.
+func NewExternalFacade(ctx facade.Context) (*Facade, error) {
+	return NewFacade(backendShim{ctx.State()}, ctx.Resources(), ctx.Auth())
+}
+
+// NewFacade returns an AgentIntrospection facade. Only machine and
+// unit agents are allowed access.
+func NewFacade(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() && !authorizer.AuthUnitAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:    backend,
+		resources:  resources,
+		authorizer: authorizer,
+	}, nil
+}
+
+// WatchIntrospectionRequests returns a StringsWatcher for each agent,
+// reporting the ids of introspection requests made of the agent.
+func (f *Facade) WatchIntrospectionRequests(args params.Entities) params.StringsWatchResults {
+	results := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !f.authorizer.AuthOwner(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		w := f.backend.WatchIntrospectionRequests(tag)
+		if changes, ok := <-w.Changes(); ok {
+			results.Results[i].StringsWatcherId = f.resources.Register(w)
+			results.Results[i].Changes = changes
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return results
+}
+
+// IntrospectionRequests returns the introspection requests with the
+// given ids. Agents may only get their own requests.
+func (f *Facade) IntrospectionRequests(args params.IntrospectionRequestIds) params.IntrospectionRequestResults {
+	results := params.IntrospectionRequestResults{
+		Results: make([]params.IntrospectionRequestResult, len(args.Ids)),
+	}
+	for i, id := range args.Ids {
+		req, err := f.request(id)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = &params.IntrospectionRequest{
+			Id:        req.Id(),
+			Path:      req.Path(),
+			Completed: req.Completed(),
+		}
+	}
+	return results
+}
+
+// CompleteIntrospectionRequests records the responses of the agent to
+// its introspection requests.
+func (f *Facade) CompleteIntrospectionRequests(args params.IntrospectionResponses) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Responses)),
+	}
+	for i, resp := range args.Responses {
+		req, err := f.request(resp.Id)
+		if err == nil {
+			err = req.Complete(resp.Output, resp.Error)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results
+}
+
+// request returns the introspection request with the given id, if it
+// was made of the authenticated agent.
+func (f *Facade) request(id string) (Request, error) {
+	req, err := f.backend.IntrospectionRequest(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !f.authorizer.AuthOwner(req.Agent()) {
+		return nil, common.ErrPerm
+	}
+	return req, nil
+}
+
+type backendShim struct {
+	*state.State
+}
+
+func (shim backendShim) IntrospectionRequest(id string) (Request, error) {
+	req, err := shim.State.IntrospectionRequest(id)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
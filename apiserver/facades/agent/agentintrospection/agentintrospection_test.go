@@ -0,0 +1,159 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentintrospection_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/agentintrospection"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type agentIntrospectionSuite struct {
+	testing.IsolationSuite
+
+	backend   *fakeBackend
+	resources *common.Resources
+	facade    *agentintrospection.Facade
+}
+
+var _ = gc.Suite(&agentIntrospectionSuite{})
+
+func (s *agentIntrospectionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{
+		Stub: &testing.Stub{},
+		requests: map[string]*fakeRequest{
+			"unit-mysql-0_i_1": {id: "unit-mysql-0_i_1", agent: names.NewUnitTag("mysql/0"), path: "/depengine"},
+			"unit-mysql-1_i_2": {id: "unit-mysql-1_i_2", agent: names.NewUnitTag("mysql/1"), path: "/depengine"},
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	facade, err := agentintrospection.NewFacade(
+		s.backend,
+		s.resources,
+		apiservertesting.FakeAuthorizer{Tag: names.NewUnitTag("mysql/0")},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *agentIntrospectionSuite) TestPermissionDenied(c *gc.C) {
+	_, err := agentintrospection.NewFacade(
+		s.backend,
+		s.resources,
+		apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin")},
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *agentIntrospectionSuite) TestWatchIntrospectionRequests(c *gc.C) {
+	results := s.facade.WatchIntrospectionRequests(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-mysql-0"}, {Tag: "unit-mysql-1"}, {Tag: "bad"}},
+	})
+	c.Assert(results, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{{
+			StringsWatcherId: "1",
+			Changes:          []string{"unit-mysql-0_i_1"},
+		}, {
+			Error: common.ServerError(common.ErrPerm),
+		}, {
+			Error: common.ServerError(common.ErrPerm),
+		}},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"WatchIntrospectionRequests", []interface{}{names.NewUnitTag("mysql/0")}},
+	})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectionRequests(c *gc.C) {
+	results := s.facade.IntrospectionRequests(params.IntrospectionRequestIds{
+		Ids: []string{"unit-mysql-0_i_1", "unit-mysql-1_i_2", "unit-mysql-0_i_3"},
+	})
+	c.Assert(results, jc.DeepEquals, params.IntrospectionRequestResults{
+		Results: []params.IntrospectionRequestResult{{
+			Result: &params.IntrospectionRequest{Id: "unit-mysql-0_i_1", Path: "/depengine"},
+		}, {
+			Error: common.ServerError(common.ErrPerm),
+		}, {
+			Error: common.ServerError(errors.NotFoundf(`introspection request "unit-mysql-0_i_3"`)),
+		}},
+	})
+}
+
+func (s *agentIntrospectionSuite) TestCompleteIntrospectionRequests(c *gc.C) {
+	results := s.facade.CompleteIntrospectionRequests(params.IntrospectionResponses{
+		Responses: []params.IntrospectionResponse{
+			{Id: "unit-mysql-0_i_1", Output: []byte("some output")},
+			{Id: "unit-mysql-1_i_2", Error: "boom"},
+		},
+	})
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: common.ServerError(common.ErrPerm)},
+		},
+	})
+	req := s.backend.requests["unit-mysql-0_i_1"]
+	c.Check(req.completed, jc.IsTrue)
+	c.Check(string(req.output), gc.Equals, "some output")
+	c.Check(s.backend.requests["unit-mysql-1_i_2"].completed, jc.IsFalse)
+}
+
+type fakeBackend struct {
+	*testing.Stub
+	requests map[string]*fakeRequest
+}
+
+func (b *fakeBackend) WatchIntrospectionRequests(agent names.Tag) state.StringsWatcher {
+	b.MethodCall(b, "WatchIntrospectionRequests", agent)
+	ch := make(chan []string, 1)
+	var ids []string
+	for id, req := range b.requests {
+		if req.agent == agent {
+			ids = append(ids, id)
+		}
+	}
+	ch <- ids
+	return statetesting.NewMockStringsWatcher(ch)
+}
+
+func (b *fakeBackend) IntrospectionRequest(id string) (agentintrospection.Request, error) {
+	req, ok := b.requests[id]
+	if !ok {
+		return nil, errors.NotFoundf("introspection request %q", id)
+	}
+	return req, nil
+}
+
+type fakeRequest struct {
+	id        string
+	agent     names.Tag
+	path      string
+	completed bool
+	output    []byte
+	err       string
+}
+
+func (r *fakeRequest) Id() string       { return r.id }
+func (r *fakeRequest) Agent() names.Tag { return r.agent }
+func (r *fakeRequest) Path() string     { return r.path }
+func (r *fakeRequest) Completed() bool  { return r.completed }
+
+func (r *fakeRequest) Complete(output []byte, errMsg string) error {
+	r.completed = true
+	r.output = output
+	r.err = errMsg
+	return nil
+}
@@ -4,6 +4,7 @@
 package storageprovisioner_test
 
 import (
+	"fmt"
 	"sort"
 	"time"
 
@@ -1059,6 +1060,229 @@ func (s *iaasProvisionerSuite) TestWatchVolumes(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *iaasProvisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.Model.ModelTag().String()},
+		{"application-mysql"},
+		{"machine-42"}},
+	}
+	api := storageprovisioner.NewStorageProvisionerAPIv5(s.api)
+	result, err := api.WatchVolumeResizes(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sort.Strings(result.Results[1].Changes)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{"0/0"}},
+			{StringsWatcherId: "2", Changes: []string{"1", "2", "3", "4"}},
+			{Error: &params.Error{
+				Code:    params.CodeNotSupported,
+				Message: "watching storage for application-mysql not supported",
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v0Watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, v0Watcher)
+	v1Watcher := s.resources.Get("2")
+	defer statetesting.AssertStop(c, v1Watcher)
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, v1Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("2")
+}
+
+func (s *iaasProvisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	s.setupVolumes(c)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := storageprovisioner.NewStorageProvisionerAPIv5(s.api)
+	results, err := api.VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-2"},
+			{"volume-0-0"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{
+			{Result: params.VolumeResizeParams{
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "modelscoped",
+				Size:      8192,
+			}},
+			{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `pending resize for volume 0/0 not found`,
+			}},
+			{Error: &params.Error{"permission denied", "unauthorized access"}},
+		},
+	})
+}
+
+func (s *iaasProvisionerSuite) TestSetVolumeInfoAfterResize(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	s.setupVolumes(c)
+	volumeTag := names.NewVolumeTag("2")
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	err = sb.ResizeVolume(volumeTag, 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.SetVolumeInfo(params.Volumes{
+		Volumes: []params.Volume{{
+			VolumeTag: volumeTag.String(),
+			Info: params.VolumeInfo{
+				HardwareId: "456",
+				VolumeId:   "def",
+				Size:       8192,
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+
+	volume, err := s.storageBackend.Volume(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := volume.PendingSize()
+	c.Assert(ok, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(8192))
+	c.Assert(info.Pool, gc.Equals, "modelscoped")
+}
+
+// setupVolumeSnapshots requests snapshots of the provisioned volumes
+// created by setupVolumes, returning their IDs.
+func (s *iaasProvisionerSuite) setupVolumeSnapshots(c *gc.C) (modelSnapshotId, machineSnapshotId string) {
+	s.setupVolumes(c)
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err := sb.CreateVolumeSnapshot(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	modelSnapshotId = snapshot.Id()
+	snapshot, err = sb.CreateVolumeSnapshot(names.NewVolumeTag("0/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	machineSnapshotId = snapshot.Id()
+	return modelSnapshotId, machineSnapshotId
+}
+
+func (s *iaasProvisionerSuite) TestWatchVolumeSnapshots(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	modelSnapshotId, machineSnapshotId := s.setupVolumeSnapshots(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{"machine-0"},
+		{s.Model.ModelTag().String()},
+		{"application-mysql"},
+		{"machine-42"}},
+	}
+	api := storageprovisioner.NewStorageProvisionerAPIv5(s.api)
+	result, err := api.WatchVolumeSnapshots(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{machineSnapshotId}},
+			{StringsWatcherId: "2", Changes: []string{modelSnapshotId}},
+			{Error: &params.Error{
+				Code:    params.CodeNotSupported,
+				Message: "watching storage for application-mysql not supported",
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	v0Watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, v0Watcher)
+	v1Watcher := s.resources.Get("2")
+	defer statetesting.AssertStop(c, v1Watcher)
+
+	wc := statetesting.NewStringsWatcherC(c, s.State, v1Watcher.(state.StringsWatcher))
+	wc.AssertNoChange()
+	err = s.storageBackend.SetVolumeSnapshotInfo(modelSnapshotId, state.VolumeSnapshotInfo{SnapshotId: "snap"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(modelSnapshotId)
+}
+
+func (s *iaasProvisionerSuite) TestVolumeSnapshotParams(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	modelSnapshotId, machineSnapshotId := s.setupVolumeSnapshots(c)
+	err := s.storageBackend.SetVolumeSnapshotInfo(machineSnapshotId, state.VolumeSnapshotInfo{SnapshotId: "snap"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := storageprovisioner.NewStorageProvisionerAPIv5(s.api)
+	results, err := api.VolumeSnapshotParams(params.VolumeSnapshotIds{
+		Ids: []string{modelSnapshotId, machineSnapshotId, "2@42"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeSnapshotParamsResults{
+		Results: []params.VolumeSnapshotParamsResult{
+			{Result: params.VolumeSnapshotParams{
+				Id:        modelSnapshotId,
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Provider:  "modelscoped",
+				Size:      4096,
+			}},
+			{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: fmt.Sprintf(`pending volume snapshot %q not found`, machineSnapshotId),
+			}},
+			{Error: &params.Error{"permission denied", "unauthorized access"}},
+		},
+	})
+}
+
+func (s *iaasProvisionerSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	// Only IAAS models support block storage right now.
+	modelSnapshotId, _ := s.setupVolumeSnapshots(c)
+
+	api := storageprovisioner.NewStorageProvisionerAPIv5(s.api)
+	results, err := api.SetVolumeSnapshotInfo(params.VolumeSnapshotInfos{
+		Snapshots: []params.VolumeSnapshotInfo{{
+			Id:         modelSnapshotId,
+			SnapshotId: "snap-def",
+			Size:       4096,
+		}, {
+			Id:         "2@42",
+			SnapshotId: "snap-xyz",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{"permission denied", "unauthorized access"}},
+		},
+	})
+
+	snapshot, err := s.storageBackend.VolumeSnapshot(modelSnapshotId)
+	c.Assert(err, jc.ErrorIsNil)
+	info, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, state.VolumeSnapshotInfo{SnapshotId: "snap-def", Size: 4096})
+}
+
 func (s *iaasProvisionerSuite) TestWatchVolumeAttachments(c *gc.C) {
 	// Only IAAS models support block storage right now.
 	s.setupVolumes(c)
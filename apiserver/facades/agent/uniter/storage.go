@@ -198,6 +198,10 @@ func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.Sto
 	if owner, ok := stateStorageInstance.Owner(); ok {
 		ownerTag = owner.String()
 	}
+	size, err := storageSize(s.storage.VolumeAccess(), stateStorageInstance.StorageTag())
+	if err != nil {
+		return params.StorageAttachment{}, err
+	}
 	return params.StorageAttachment{
 		stateStorageAttachment.StorageInstance().String(),
 		ownerTag,
@@ -205,9 +209,33 @@ func (s *StorageAPI) fromStateStorageAttachment(stateStorageAttachment state.Sto
 		params.StorageKind(stateStorageInstance.Kind()),
 		info.Location,
 		params.Life(stateStorageAttachment.Life().String()),
+		size,
 	}, nil
 }
 
+// storageSize returns the size, in MiB, of the volume backing the
+// storage instance, so that the unit can be told when it's resized.
+// Zero is returned if there's no backing volume, or it hasn't been
+// provisioned.
+func storageSize(stVolume storageVolumeInterface, tag names.StorageTag) (uint64, error) {
+	if stVolume == nil {
+		return 0, nil
+	}
+	volume, err := stVolume.StorageInstanceVolume(tag)
+	if errors.IsNotFound(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if errors.IsNotProvisioned(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return info.Size, nil
+}
+
 // WatchUnitStorageAttachments creates watchers for a collection of units,
 // each of which can be used to watch for lifecycle changes to the corresponding
 // unit's storage attachments.
@@ -432,7 +460,8 @@ func accessUnitTag(tag string, canAccess func(names.Tag) bool) (names.UnitTag, e
 
 // watchStorageAttachment returns a state.NotifyWatcher that reacts to changes
 // to the VolumeAttachmentInfo or FilesystemAttachmentInfo corresponding to the
-// tags specified.
+// tags specified, and to the volume backing the storage, so that resizes are
+// seen.
 func watchStorageAttachment(
 	st storageInterface,
 	stVolume storageVolumeInterface,
@@ -459,6 +488,7 @@ func watchStorageAttachment(
 		// machine's block devices. A volume attachment's block
 		// device could change (most likely, become present).
 		watchers = []state.NotifyWatcher{
+			stVolume.WatchVolume(volume.VolumeTag()),
 			stVolume.WatchVolumeAttachment(hostTag, volume.VolumeTag()),
 		}
 
@@ -484,6 +514,14 @@ func watchStorageAttachment(
 		watchers = []state.NotifyWatcher{
 			stFile.WatchFilesystemAttachment(hostTag, filesystem.FilesystemTag()),
 		}
+		if stVolume != nil {
+			volume, err := stVolume.StorageInstanceVolume(storageTag)
+			if err == nil {
+				watchers = append(watchers, stVolume.WatchVolume(volume.VolumeTag()))
+			} else if !errors.IsNotFound(err) {
+				return nil, errors.Annotate(err, "getting storage volume")
+			}
+		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
 	}
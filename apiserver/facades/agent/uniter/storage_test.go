@@ -81,6 +81,10 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 		changes: make(chan struct{}, 1),
 	}
 	volumeWatcher.changes <- struct{}{}
+	volumeAttachmentWatcher := &mockNotifyWatcher{
+		changes: make(chan struct{}, 1),
+	}
+	volumeAttachmentWatcher.changes <- struct{}{}
 	blockDevicesWatcher := &mockNotifyWatcher{
 		changes: make(chan struct{}, 1),
 	}
@@ -104,11 +108,16 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 			c.Assert(u, gc.DeepEquals, unitTag)
 			return storageWatcher
 		},
+		watchVolume: func(v names.VolumeTag) state.NotifyWatcher {
+			calls = append(calls, "WatchVolume")
+			c.Assert(v, gc.DeepEquals, volumeTag)
+			return volumeWatcher
+		},
 		watchVolumeAttachment: func(host names.Tag, v names.VolumeTag) state.NotifyWatcher {
 			calls = append(calls, "WatchVolumeAttachment")
 			c.Assert(host, gc.DeepEquals, machineTag)
 			c.Assert(v, gc.DeepEquals, volumeTag)
-			return volumeWatcher
+			return volumeAttachmentWatcher
 		},
 		watchBlockDevices: func(m names.MachineTag) state.NotifyWatcher {
 			calls = append(calls, "WatchBlockDevices")
@@ -134,6 +143,7 @@ func (s *storageSuite) TestWatchStorageAttachmentVolume(c *gc.C) {
 	c.Assert(calls, gc.DeepEquals, []string{
 		"StorageInstance",
 		"StorageInstanceVolume",
+		"WatchVolume",
 		"WatchVolumeAttachment",
 		"WatchBlockDevices",
 		"WatchStorageAttachment",
@@ -186,6 +196,11 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 			c.Assert(s, gc.DeepEquals, storageTag)
 			return filesystem, nil
 		},
+		storageInstanceVolume: func(s names.StorageTag) (state.Volume, error) {
+			calls = append(calls, "StorageInstanceVolume")
+			c.Assert(s, gc.DeepEquals, storageTag)
+			return nil, errors.NotFoundf("volume for storage instance %q", s.Id())
+		},
 		watchStorageAttachment: func(s names.StorageTag, u names.UnitTag) state.NotifyWatcher {
 			calls = append(calls, "WatchStorageAttachment")
 			c.Assert(s, gc.DeepEquals, storageTag)
@@ -218,6 +233,7 @@ func (s *storageSuite) assertWatchStorageAttachmentFilesystem(c *gc.C, assignedM
 		"StorageInstance",
 		"StorageInstanceFilesystem",
 		"WatchFilesystemAttachment",
+		"StorageInstanceVolume",
 		"WatchStorageAttachment",
 	})
 }
@@ -489,6 +505,7 @@ type mockStorageState struct {
 	watchStorageAttachments       func(names.UnitTag) state.StringsWatcher
 	watchStorageAttachment        func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment     func(names.Tag, names.FilesystemTag) state.NotifyWatcher
+	watchVolume                   func(names.VolumeTag) state.NotifyWatcher
 	watchVolumeAttachment         func(names.Tag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices             func(names.MachineTag) state.NotifyWatcher
 	addUnitStorage                func(u names.UnitTag, name string, cons state.StorageConstraints) error
@@ -540,6 +557,10 @@ func (m *mockStorageState) WatchFilesystemAttachment(hostTag names.Tag, f names.
 	return m.watchFilesystemAttachment(hostTag, f)
 }
 
+func (m *mockStorageState) WatchVolume(v names.VolumeTag) state.NotifyWatcher {
+	return m.watchVolume(v)
+}
+
 func (m *mockStorageState) WatchVolumeAttachment(hostTag names.Tag, v names.VolumeTag) state.NotifyWatcher {
 	return m.watchVolumeAttachment(hostTag, v)
 }
@@ -621,6 +642,7 @@ type watchStorageAttachmentSuite struct {
 	st                       *fakeStorage
 	storageInstance          *fakeStorageInstance
 	volume                   *fakeVolume
+	volumeWatcher            *apiservertesting.FakeNotifyWatcher
 	volumeAttachmentWatcher  *apiservertesting.FakeNotifyWatcher
 	blockDevicesWatcher      *apiservertesting.FakeNotifyWatcher
 	storageAttachmentWatcher *apiservertesting.FakeNotifyWatcher
@@ -638,6 +660,7 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 		kind:  state.StorageKindBlock,
 	}
 	s.volume = &fakeVolume{tag: names.NewVolumeTag("0")}
+	s.volumeWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.volumeAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.blockDevicesWatcher = apiservertesting.NewFakeNotifyWatcher()
 	s.storageAttachmentWatcher = apiservertesting.NewFakeNotifyWatcher()
@@ -648,6 +671,9 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 		storageInstanceVolume: func(tag names.StorageTag) (state.Volume, error) {
 			return s.volume, nil
 		},
+		watchVolume: func(names.VolumeTag) state.NotifyWatcher {
+			return s.volumeWatcher
+		},
 		watchVolumeAttachment: func(names.Tag, names.VolumeTag) state.NotifyWatcher {
 			return s.volumeAttachmentWatcher
 		},
@@ -660,6 +686,12 @@ func (s *watchStorageAttachmentSuite) SetUpTest(c *gc.C) {
 	}
 }
 
+func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentVolumeChanges(c *gc.C) {
+	s.testWatchBlockStorageAttachment(c, func() {
+		s.volumeWatcher.C <- struct{}{}
+	})
+}
+
 func (s *watchStorageAttachmentSuite) TestWatchStorageAttachmentVolumeAttachmentChanges(c *gc.C) {
 	s.testWatchBlockStorageAttachment(c, func() {
 		s.volumeAttachmentWatcher.C <- struct{}{}
@@ -683,6 +715,7 @@ func (s *watchStorageAttachmentSuite) testWatchBlockStorageAttachment(c *gc.C, c
 	s.st.CheckCallNames(c,
 		"StorageInstance",
 		"StorageInstanceVolume",
+		"WatchVolume",
 		"WatchVolumeAttachment",
 		"WatchBlockDevices",
 		"WatchStorageAttachment",
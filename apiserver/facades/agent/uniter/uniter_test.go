@@ -903,6 +903,49 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestCharmState(c *gc.C) {
+	err := s.wordpressUnit.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.CharmState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.CharmStateResults{
+		Results: []params.CharmStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{CharmState: map[string]string{"foo": "bar"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: common.ServerError(errors.New(`"application-wordpress" is not a valid unit tag`))},
+		},
+	})
+}
+
+func (s *uniterSuite) TestSetCharmState(c *gc.C) {
+	args := params.EntityCharmStates{Entities: []params.EntityCharmState{
+		{Tag: "unit-mysql-0", CharmState: map[string]string{"foo": "bar"}},
+		{Tag: "unit-wordpress-0", CharmState: map[string]string{"foo": "baz"}},
+		{Tag: "unit-foo-42", CharmState: map[string]string{"foo": "qux"}},
+	}}
+	result, err := s.uniter.SetCharmState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	charmState, err := s.wordpressUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "baz"})
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
 // ActionAPI implements the client API for interacting with Actions
@@ -43,6 +44,24 @@ func NewActionAPI(st *state.State, resources facade.Resources, authorizer facade
 	}, nil
 }
 
+// ActionAPIV2 implements version 2 of the Action API, which has no
+// WatchActionsProgress method.
+type ActionAPIV2 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV2 returns an initialized ActionAPIV2.
+func NewActionAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV2, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV2{api}, nil
+}
+
+// WatchActionsProgress isn't on the v2 API.
+func (a *ActionAPIV2) WatchActionsProgress(_, _ struct{}) {}
+
 func (a *ActionAPI) checkCanRead() error {
 	canRead, err := a.authorizer.HasPermission(permission.ReadAccess, a.model.ModelTag())
 	if err != nil {
@@ -300,6 +319,35 @@ func (a *ActionAPI) Cancel(arg params.Entities) (params.ActionResults, error) {
 	return response, nil
 }
 
+// WatchActionsProgress returns a StringsWatcher for each of the given
+// actions, reporting the progress messages logged by the action. Each
+// message is a JSON encoded params.ActionMessage.
+func (a *ActionAPI) WatchActionsProgress(arg params.Entities) (params.StringsWatchResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StringsWatchResults{}, errors.Trace(err)
+	}
+
+	results := params.StringsWatchResults{Results: make([]params.StringsWatchResult, len(arg.Entities))}
+	for i, entity := range arg.Entities {
+		currentResult := &results.Results[i]
+		actionTag, err := names.ParseActionTag(entity.Tag)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		w := a.model.WatchActionLogs(actionTag.Id())
+		// Consume the initial event, which holds the messages logged so far.
+		changes, ok := <-w.Changes()
+		if !ok {
+			currentResult.Error = common.ServerError(watcher.EnsureErr(w))
+			continue
+		}
+		currentResult.StringsWatcherId = a.resources.Register(w)
+		currentResult.Changes = changes
+	}
+	return results, nil
+}
+
 // ApplicationsCharmsActions returns a slice of charm Actions for a slice of
 // services.
 func (a *ActionAPI) ApplicationsCharmsActions(args params.Entities) (params.ApplicationsCharmActionsResults, error) {
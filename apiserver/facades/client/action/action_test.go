@@ -4,6 +4,7 @@
 package action_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -630,6 +631,33 @@ func (s *actionSuite) TestCancel(c *gc.C) {
 	c.Assert(myActions[1].Status, gc.Equals, params.ActionCancelled)
 }
 
+func (s *actionSuite) TestWatchActionsProgress(c *gc.C) {
+	api, err := action.NewActionAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Log("hello"), jc.ErrorIsNil)
+
+	results, err := api.WatchActionsProgress(params.Entities{Entities: []params.Entity{
+		{Tag: a.Tag().String()},
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].StringsWatcherId, gc.Equals, "1")
+	c.Assert(results.Results[0].Changes, gc.HasLen, 1)
+	var message params.ActionMessage
+	err = json.Unmarshal([]byte(results.Results[0].Changes[0]), &message)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(message.Message, gc.Equals, "hello")
+	c.Assert(results.Results[1].Error, jc.DeepEquals, common.ServerError(common.ErrBadId))
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+}
+
 func (s *actionSuite) TestApplicationsCharmsActions(c *gc.C) {
 	actionSchemas := map[string]map[string]interface{}{
 		"snapshot": {
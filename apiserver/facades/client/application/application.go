@@ -62,6 +62,11 @@ type APIv7 struct {
 
 // APIv8 provides the Application API facade for version 8.
 type APIv8 struct {
+	*APIv9
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
 	*APIBase
 }
 
@@ -129,14 +134,26 @@ func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
 	return &APIv7{api}, nil
 }
 
+// NewFacadeV8 provides the signature required for facade registration
+// for version 8.
 func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV9(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv8{api}, nil
 }
 
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	model, err := ctx.State().Model()
 	if err != nil {
@@ -384,6 +401,12 @@ func deployApplication(
 				modelType,
 			)
 		}
+		if len(args.AttachSnapshots) > 0 {
+			return errors.Errorf(
+				"AttachSnapshots may not be specified for %s models",
+				modelType,
+			)
+		}
 		if len(args.Placement) > 0 {
 			return errors.Errorf(
 				"Placement may not be specified for %s models",
@@ -459,6 +482,9 @@ func deployApplication(
 		}
 		attachStorage[i] = tag
 	}
+	if len(args.AttachSnapshots) > 0 && args.NumUnits != 1 {
+		return errors.Errorf("AttachSnapshots is non-empty, but NumUnits is %d", args.NumUnits)
+	}
 
 	_, err = deployApplicationFunc(backend, DeployApplicationParams{
 		ApplicationName:   args.ApplicationName,
@@ -473,6 +499,7 @@ func deployApplication(
 		Storage:           args.Storage,
 		Devices:           args.Devices,
 		AttachStorage:     attachStorage,
+		RestoreSnapshots:  args.AttachSnapshots,
 		EndpointBindings:  args.EndpointBindings,
 		Resources:         args.Resources,
 	})
@@ -924,7 +951,17 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 					"juju config %s %s=<value>", caas.JujuExternalHostNameKey, args.ApplicationName, caas.JujuExternalHostNameKey)
 		}
 	}
-	return app.SetExposed()
+	var exposedEndpoints map[string]state.ExposedEndpoint
+	if len(args.ExposedEndpoints) > 0 {
+		exposedEndpoints = make(map[string]state.ExposedEndpoint)
+		for endpoint, exposed := range args.ExposedEndpoints {
+			exposedEndpoints[endpoint] = state.ExposedEndpoint{
+				ExposeToSpaces: exposed.ExposeToSpaces,
+				ExposeToCIDRs:  exposed.ExposeToCIDRs,
+			}
+		}
+	}
+	return app.MergeExposeSettings(exposedEndpoints)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -940,6 +977,9 @@ func (api *APIBase) Unexpose(args params.ApplicationUnexpose) error {
 	if err != nil {
 		return err
 	}
+	if len(args.ExposedEndpoints) > 0 {
+		return app.UnsetExposeSettings(args.ExposedEndpoints)
+	}
 	return app.ClearExposed()
 }
 
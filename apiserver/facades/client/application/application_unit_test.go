@@ -39,7 +39,8 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv8
+	api          *application.APIv9
+	deployArgs   []application.DeployApplicationParams
 }
 
 var _ = gc.Suite(&ApplicationSuite{})
@@ -56,12 +57,13 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		func(application.Charm) *state.Charm {
 			return &state.Charm{}
 		},
-		func(application.ApplicationDeployer, application.DeployApplicationParams) (application.Application, error) {
+		func(_ application.ApplicationDeployer, args application.DeployApplicationParams) (application.Application, error) {
+			s.deployArgs = append(s.deployArgs, args)
 			return nil, nil
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv8{api}
+	s.api = &application.APIv9{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -469,6 +471,29 @@ func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-baz-0" is not a valid volume tag`)
 }
 
+func (s *ApplicationSuite) TestDeployAttachSnapshots(c *gc.C) {
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+			AttachSnapshots: []string{"0/1@2"},
+		}, {
+			ApplicationName: "bar",
+			CharmURL:        "local:bar-1",
+			NumUnits:        2,
+			AttachSnapshots: []string{"0/1@2"},
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "AttachSnapshots is non-empty, but NumUnits is 2")
+	c.Assert(s.deployArgs, gc.HasLen, 1)
+	c.Assert(s.deployArgs[0].RestoreSnapshots, jc.DeepEquals, []string{"0/1@2"})
+}
+
 func (s *ApplicationSuite) TestDeployCAASModel(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	args := params.ApplicationsDeploy{
@@ -486,14 +511,20 @@ func (s *ApplicationSuite) TestDeployCAASModel(c *gc.C) {
 			CharmURL:        "local:baz-0",
 			NumUnits:        1,
 			Placement:       []*instance.Placement{{}},
+		}, {
+			ApplicationName: "qux",
+			CharmURL:        "local:qux-0",
+			NumUnits:        1,
+			AttachSnapshots: []string{"0/1@2"},
 		}},
 	}
 	results, err := s.api.Deploy(args)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results, gc.HasLen, 4)
 	c.Assert(results.Results[0].Error, gc.IsNil)
 	c.Assert(results.Results[1].Error, gc.ErrorMatches, "AttachStorage may not be specified for caas models")
 	c.Assert(results.Results[2].Error, gc.ErrorMatches, "Placement may not be specified for caas models")
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, "AttachSnapshots may not be specified for caas models")
 }
 
 func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
@@ -1222,5 +1253,40 @@ func (s *ApplicationSuite) TestCAASExposeWithHostname(c *gc.C) {
 		ApplicationName: "postgresql",
 	})
 	c.Assert(err, jc.ErrorIsNil)
-	app.CheckCallNames(c, "ApplicationConfig", "SetExposed")
+	app.CheckCallNames(c, "ApplicationConfig", "MergeExposeSettings")
+}
+
+func (s *ApplicationSuite) TestExposeEndpoints(c *gc.C) {
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"":   {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+			"db": {ExposeToSpaces: []string{"dmz"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 0, "MergeExposeSettings", map[string]state.ExposedEndpoint{
+		"":   {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"db": {ExposeToSpaces: []string{"dmz"}},
+	})
+}
+
+func (s *ApplicationSuite) TestExposeAllEndpoints(c *gc.C) {
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 0, "MergeExposeSettings", map[string]state.ExposedEndpoint(nil))
+}
+
+func (s *ApplicationSuite) TestUnexposeEndpoints(c *gc.C) {
+	err := s.api.Unexpose(params.ApplicationUnexpose{
+		ApplicationName:  "postgresql",
+		ExposedEndpoints: []string{"db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 0, "UnsetExposeSettings", []string{"db"})
 }
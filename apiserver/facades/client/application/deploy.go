@@ -39,6 +39,7 @@ type DeployApplicationParams struct {
 	Storage          map[string]storage.Constraints
 	Devices          map[string]devices.Constraints
 	AttachStorage    []names.StorageTag
+	RestoreSnapshots []string
 	EndpointBindings map[string]string
 	// Resources is a map of resource name to IDs of pending resources.
 	Resources map[string]string
@@ -82,6 +83,7 @@ func DeployApplication(st ApplicationDeployer, args DeployApplicationParams) (Ap
 		Storage:           stateStorageConstraints(args.Storage),
 		Devices:           stateDeviceConstraints(args.Devices),
 		AttachStorage:     args.AttachStorage,
+		RestoreSnapshots:  args.RestoreSnapshots,
 		ApplicationConfig: args.ApplicationConfig,
 		CharmConfig:       charmConfig,
 		NumUnits:          args.NumUnits,
@@ -180,6 +180,16 @@ func (a *mockApplication) SetExposed() error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) MergeExposeSettings(exposedEndpoints map[string]state.ExposedEndpoint) error {
+	a.MethodCall(a, "MergeExposeSettings", exposedEndpoints)
+	return a.NextErr()
+}
+
+func (a *mockApplication) UnsetExposeSettings(endpoints []string) error {
+	a.MethodCall(a, "UnsetExposeSettings", endpoints)
+	return a.NextErr()
+}
+
 type mockRemoteApplication struct {
 	jtesting.Stub
 	name           string
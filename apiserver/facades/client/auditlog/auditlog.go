@@ -0,0 +1,254 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/permission"
+	psauditlog "github.com/juju/juju/pubsub/auditlog"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.auditlog")
+
+// queryTimeout is how long to wait for every controller to respond
+// to a query.
+const queryTimeout = 30 * time.Second
+
+// Backend defines the state methods needed by the facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ControllerInfo() (*state.ControllerInfo, error)
+	ModelBasicInfoForUser(user names.UserTag) ([]state.ModelAccessInfo, error)
+}
+
+// Hub defines the methods of the central hub used to query the audit
+// logs of all of the controllers.
+type Hub interface {
+	Publish(topic string, data interface{}) (<-chan struct{}, error)
+	Subscribe(topic string, handler interface{}) (func(), error)
+}
+
+// API implements the AuditLog facade, which queries the audit logs
+// written by every controller machine.
+type API struct {
+	backend Backend
+	user    names.UserTag
+	hub     Hub
+	clock   clock.Clock
+}
+
+// NewFacade creates an AuditLog facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	hub, ok := ctx.Hub().(Hub)
+	if !ok {
+		return nil, errors.Errorf("hub %T does not support subscriptions", ctx.Hub())
+	}
+	return NewAPI(ctx.State(), ctx.Auth(), hub, clock.WallClock)
+}
+
+// NewAPI returns an AuditLog facade. Only controller superusers are
+// allowed access.
+func NewAPI(backend Backend, authorizer facade.Authorizer, hub Hub, clock clock.Clock) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		user:    authorizer.GetAuthTag().(names.UserTag),
+		hub:     hub,
+		clock:   clock,
+	}, nil
+}
+
+type controllerEntry struct {
+	machine string
+	when    time.Time
+	entry   auditlog.Entry
+}
+
+// Query returns the audit log entries matching the arguments from
+// all of the controller machines, ordered by time.
+func (api *API) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResult, error) {
+	var result params.AuditLogQueryResult
+	filter := auditlog.Filter{
+		Who:        args.Who,
+		Model:      args.Model,
+		Facade:     args.Facade,
+		Method:     args.Method,
+		ErrorsOnly: args.ErrorsOnly,
+		Limit:      args.Limit,
+	}
+	if args.After != nil {
+		filter.After = *args.After
+	}
+	if args.Before != nil {
+		filter.Before = *args.Before
+	}
+	if err := filter.Validate(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if filter.Limit == 0 || filter.Limit > auditlog.MaxQueryLimit {
+		// Each controller returns at most this many entries.
+		filter.Limit = auditlog.MaxQueryLimit
+	}
+	if strings.Contains(filter.Model, "/") {
+		uuid, err := api.modelUUID(filter.Model)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		filter.Model = uuid
+	}
+
+	info, err := api.backend.ControllerInfo()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	pending := set.NewStrings(info.MachineIds...)
+
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	responseTopic := psauditlog.QueryTopic + "." + uuid.String()
+	responses := make(chan psauditlog.QueryResponse, pending.Size())
+	unsubscribe, err := api.hub.Subscribe(responseTopic, func(topic string, resp psauditlog.QueryResponse, err error) {
+		if err != nil {
+			logger.Errorf("bad audit log query response: %v", err)
+			return
+		}
+		select {
+		case responses <- resp:
+		default:
+			// Unexpected extra responses are dropped.
+		}
+	})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer unsubscribe()
+
+	if _, err := api.hub.Publish(psauditlog.QueryTopic, psauditlog.NewQueryRequest(filter, responseTopic)); err != nil {
+		return result, errors.Annotate(err, "publishing audit log query")
+	}
+
+	var entries []controllerEntry
+	timeout := api.clock.After(queryTimeout)
+	for !pending.IsEmpty() {
+		select {
+		case <-timeout:
+			logger.Warningf("no audit log query response from controllers %v", pending.SortedValues())
+			result.Unavailable = pending.SortedValues()
+			pending = set.NewStrings()
+		case resp := <-responses:
+			if !pending.Contains(resp.Origin) {
+				continue
+			}
+			pending.Remove(resp.Origin)
+			if resp.Error != "" {
+				logger.Warningf("controller %s audit log query failed: %s", resp.Origin, resp.Error)
+				result.Unavailable = append(result.Unavailable, resp.Origin)
+				continue
+			}
+			for _, entry := range resp.Entries {
+				when, err := entry.When()
+				if err != nil {
+					logger.Warningf("skipping audit log entry with bad time %q", entry.Request.When)
+					continue
+				}
+				entries = append(entries, controllerEntry{
+					machine: resp.Origin,
+					when:    when,
+					entry:   entry,
+				})
+			}
+		}
+	}
+	sort.Strings(result.Unavailable)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].when.Before(entries[j].when)
+	})
+	if filter.Limit > 0 && len(entries) > filter.Limit {
+		entries = entries[len(entries)-filter.Limit:]
+	}
+	result.Entries = make([]params.AuditLogEntry, len(entries))
+	for i, e := range entries {
+		result.Entries[i] = toParams(e)
+	}
+	return result, nil
+}
+
+// modelUUID returns the UUID of the model with the given
+// owner-qualified name. The audit log only records the bare model
+// name, so filtering on it would include the requests made against
+// other owners' models with the same name.
+func (api *API) modelUUID(qualifiedName string) (string, error) {
+	parts := strings.SplitN(qualifiedName, "/", 2)
+	if !names.IsValidUser(parts[0]) {
+		return "", errors.NotValidf("model owner %q", parts[0])
+	}
+	owner := names.NewUserTag(parts[0])
+	// The API user is a controller superuser, so can see every model.
+	models, err := api.backend.ModelBasicInfoForUser(api.user)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, model := range models {
+		if model.Name == parts[1] && names.NewUserTag(model.Owner) == owner {
+			return model.UUID, nil
+		}
+	}
+	return "", errors.NotFoundf("model %q", qualifiedName)
+}
+
+func toParams(e controllerEntry) params.AuditLogEntry {
+	conversation, request := e.entry.Conversation, e.entry.Request
+	result := params.AuditLogEntry{
+		ControllerMachine: e.machine,
+		Who:               conversation.Who,
+		What:              conversation.What,
+		ModelName:         conversation.ModelName,
+		ModelUUID:         conversation.ModelUUID,
+		ConversationID:    conversation.ConversationID,
+		ConnectionID:      conversation.ConnectionID,
+		RequestID:         request.RequestID,
+		When:              e.when,
+		Facade:            request.Facade,
+		Method:            request.Method,
+		Version:           request.Version,
+		Args:              request.Args,
+	}
+	for _, err := range e.entry.Errors {
+		if err == nil {
+			continue
+		}
+		result.Errors = append(result.Errors, params.AuditLogError{
+			Message: err.Message,
+			Code:    err.Code,
+		})
+	}
+	return result
+}
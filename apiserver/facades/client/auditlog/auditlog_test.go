@@ -0,0 +1,225 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	facadeauditlog "github.com/juju/juju/apiserver/facades/client/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/auditlog"
+	psauditlog "github.com/juju/juju/pubsub/auditlog"
+	"github.com/juju/juju/pubsub/centralhub"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	testing.IsolationSuite
+
+	backend *fakeBackend
+	hub     *pubsub.StructuredHub
+	clock   *testclock.Clock
+	api     *facadeauditlog.API
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{machineIds: []string{"0", "1"}}
+	s.hub = centralhub.New(names.NewMachineTag("0"))
+	s.clock = testclock.NewClock(time.Now())
+	api, err := facadeauditlog.NewAPI(
+		s.backend,
+		apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin"), AdminTag: names.NewUserTag("admin")},
+		s.hub,
+		s.clock,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func entry(who, facade, method, when string) auditlog.Entry {
+	return auditlog.Entry{
+		Conversation: auditlog.Conversation{
+			Who:            who,
+			What:           "juju something",
+			ModelName:      "admin/default",
+			ConversationID: "c-" + who,
+		},
+		Request: auditlog.Request{
+			ConversationID: "c-" + who,
+			RequestID:      1,
+			When:           when,
+			Facade:         facade,
+			Method:         method,
+			Version:        1,
+		},
+	}
+}
+
+// respond subscribes a fake API server for the machine which replies
+// to queries with the entries given, recording the filter used.
+func (s *auditLogSuite) respond(c *gc.C, machine string, entries ...auditlog.Entry) <-chan auditlog.Filter {
+	filters := make(chan auditlog.Filter, 1)
+	unsubscribe, err := s.hub.Subscribe(psauditlog.QueryTopic, func(_ string, req psauditlog.QueryRequest, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		filter, err := req.Filter()
+		c.Check(err, jc.ErrorIsNil)
+		filters <- filter
+		_, err = s.hub.Publish(req.ResponseTopic, psauditlog.QueryResponse{
+			Origin:  machine,
+			Entries: entries,
+		})
+		c.Check(err, jc.ErrorIsNil)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(*gc.C) { unsubscribe() })
+	return filters
+}
+
+func (s *auditLogSuite) TestPermissionDenied(c *gc.C) {
+	_, err := facadeauditlog.NewAPI(
+		s.backend,
+		apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("bob")},
+		s.hub,
+		s.clock,
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = facadeauditlog.NewAPI(
+		s.backend,
+		apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")},
+		s.hub,
+		s.clock,
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *auditLogSuite) TestQueryMergesControllers(c *gc.C) {
+	filters := s.respond(c, "0",
+		entry("alice", "Application", "Deploy", "2018-06-01T10:00:00Z"),
+		entry("alice", "Application", "Destroy", "2018-06-01T12:00:00Z"),
+	)
+	s.respond(c, "1", entry("bob", "Client", "FullStatus", "2018-06-01T11:00:00Z"))
+
+	after := time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC)
+	result, err := s.api.Query(params.AuditLogQueryArgs{
+		Facade: "Application",
+		After:  &after,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Unavailable, gc.HasLen, 0)
+	var got []string
+	for _, e := range result.Entries {
+		got = append(got, e.ControllerMachine+":"+e.Who+":"+e.Method)
+	}
+	c.Assert(got, jc.DeepEquals, []string{
+		"0:alice:Deploy",
+		"1:bob:FullStatus",
+		"0:alice:Destroy",
+	})
+	c.Assert(result.Entries[0].When, gc.Equals, time.Date(2018, 6, 1, 10, 0, 0, 0, time.UTC))
+
+	select {
+	case filter := <-filters:
+		c.Assert(filter.Facade, gc.Equals, "Application")
+		c.Assert(filter.After.Equal(after), jc.IsTrue)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("no query received")
+	}
+}
+
+func (s *auditLogSuite) TestQueryLimitAcrossControllers(c *gc.C) {
+	s.respond(c, "0", entry("alice", "Application", "Deploy", "2018-06-01T10:00:00Z"))
+	s.respond(c, "1",
+		entry("bob", "Client", "FullStatus", "2018-06-01T11:00:00Z"),
+		entry("bob", "Client", "AddMachines", "2018-06-01T12:00:00Z"),
+	)
+	result, err := s.api.Query(params.AuditLogQueryArgs{Limit: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 2)
+	c.Assert(result.Entries[0].Method, gc.Equals, "FullStatus")
+	c.Assert(result.Entries[1].Method, gc.Equals, "AddMachines")
+}
+
+func (s *auditLogSuite) TestQueryTimeout(c *gc.C) {
+	s.respond(c, "0", entry("alice", "Application", "Deploy", "2018-06-01T10:00:00Z"))
+	go func() {
+		c.Check(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	}()
+	result, err := s.api.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entries, gc.HasLen, 1)
+	c.Assert(result.Unavailable, jc.DeepEquals, []string{"1"})
+}
+
+func (s *auditLogSuite) TestQueryInvalidFilter(c *gc.C) {
+	_, err := s.api.Query(params.AuditLogQueryArgs{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative Limit not valid")
+}
+
+func (s *auditLogSuite) TestQueryQualifiedModel(c *gc.C) {
+	s.backend.models = []state.ModelAccessInfo{
+		{Name: "default", UUID: "deadbeef", Owner: "admin"},
+		{Name: "default", UUID: "cafebabe", Owner: "bob"},
+	}
+	filters := s.respond(c, "0")
+	s.respond(c, "1")
+	_, err := s.api.Query(params.AuditLogQueryArgs{Model: "bob/default"})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case filter := <-filters:
+		c.Assert(filter.Model, gc.Equals, "cafebabe")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("no query received")
+	}
+}
+
+func (s *auditLogSuite) TestQueryQualifiedModelNotFound(c *gc.C) {
+	s.backend.models = []state.ModelAccessInfo{
+		{Name: "default", UUID: "deadbeef", Owner: "admin"},
+	}
+	_, err := s.api.Query(params.AuditLogQueryArgs{Model: "bob/default"})
+	c.Assert(err, gc.ErrorMatches, `model "bob/default" not found`)
+}
+
+func (s *auditLogSuite) TestQueryNoLimitCapped(c *gc.C) {
+	filters := s.respond(c, "0")
+	s.respond(c, "1")
+	_, err := s.api.Query(params.AuditLogQueryArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case filter := <-filters:
+		c.Assert(filter.Limit, gc.Equals, auditlog.MaxQueryLimit)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("no query received")
+	}
+}
+
+type fakeBackend struct {
+	machineIds []string
+	models     []state.ModelAccessInfo
+}
+
+func (b *fakeBackend) ModelBasicInfoForUser(user names.UserTag) ([]state.ModelAccessInfo, error) {
+	return b.models, nil
+}
+
+func (b *fakeBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *fakeBackend) ControllerInfo() (*state.ControllerInfo, error) {
+	return &state.ControllerInfo{MachineIds: b.machineIds}, nil
+}
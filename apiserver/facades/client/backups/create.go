@@ -31,7 +31,10 @@ func (a *API) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResul
 }
 
 func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataResult, error) {
-	backupsMethods, closer := newBackups(a.backend)
+	backupsMethods, closer, err := newBackups(a.backend)
+	if err != nil {
+		return params.BackupsMetadataResult{}, errors.Trace(err)
+	}
 	defer closer.Close()
 
 	session := a.backend.MongoSession().Copy()
@@ -39,7 +42,7 @@ func (a *APIv2) Create(args params.BackupsCreateArgs) (params.BackupsMetadataRes
 
 	result := params.BackupsMetadataResult{}
 	// Don't go if HA isn't ready.
-	err := waitUntilReady(session, 60)
+	err = waitUntilReady(session, 60)
 	if err != nil {
 		return result, errors.Annotatef(err, "HA not ready; try again later")
 	}
@@ -4,13 +4,18 @@
 package backups
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 )
 
 // Remove deletes the backups defined by ID from the database.
 func (a *APIv2) Remove(args params.BackupsRemoveArgs) (params.ErrorResults, error) {
-	backups, closer := newBackups(a.backend)
+	backups, closer, err := newBackups(a.backend)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
 	defer closer.Close()
 	results := make([]params.ErrorResult, len(args.IDs))
 	for i, id := range args.IDs {
@@ -6,6 +6,7 @@ package bundle
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
 
@@ -247,7 +249,7 @@ func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
 	}
 
 	// Fill it in charm.BundleData datastructure.
-	bundleData, err := b.fillBundleData(model)
+	bundleData, overlay, err := b.fillBundleData(model)
 	if err != nil {
 		return fail(err)
 	}
@@ -256,17 +258,69 @@ func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
 	if err != nil {
 		return fail(err)
 	}
+	result := string(bytes)
+
+	// Offers and expose settings may only be specified in overlays,
+	// so they are written as a second document following the bundle.
+	if overlay != nil {
+		bytes, err := yaml.Marshal(overlay)
+		if err != nil {
+			return fail(err)
+		}
+		result += "--- # overlay.yaml\n" + string(bytes)
+	}
 
 	return params.StringResult{
-		Result: string(bytes),
+		Result: result,
 	}, nil
 }
 
+// exportedBundle is the bundle written by ExportBundle. The charm
+// bundle data does not describe the consumed remote applications, so
+// they are written alongside it.
+type exportedBundle struct {
+	charm.BundleData `yaml:",inline"`
+	Saas             map[string]*saasSpec `yaml:"saas,omitempty"`
+}
+
+// saasSpec describes a remote application consumed by the model.
+type saasSpec struct {
+	URL string `yaml:"url"`
+}
+
+// exportedOverlay holds the offers made by the model's applications,
+// and their per-endpoint expose settings.
+type exportedOverlay struct {
+	Applications map[string]*overlayApplication `yaml:"applications"`
+}
+
+type overlayApplication struct {
+	Offers           map[string]*offerSpec           `yaml:"offers,omitempty"`
+	ExposedEndpoints map[string]*exposedEndpointSpec `yaml:"exposed-endpoints,omitempty"`
+}
+
+// exposedEndpointSpec describes the spaces and CIDRs an application
+// endpoint is exposed to.
+type exposedEndpointSpec struct {
+	ExposeToSpaces []string `yaml:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `yaml:"expose-to-cidrs,omitempty"`
+}
+
+// offerSpec describes an offer of an application's endpoints, and the
+// access granted to the offer's users.
+type offerSpec struct {
+	Endpoints []string          `yaml:"endpoints"`
+	ACL       map[string]string `yaml:"acl,omitempty"`
+}
+
 // Mask the new method from V1 API.
 // ExportBundle is not in V1 API.
 func (u *APIv1) ExportBundle() (_, _ struct{}) { return }
 
-func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData, error) {
+// fillBundleData returns the bundle data describing the model, and an
+// overlay describing its offers and per-endpoint expose settings, which
+// is nil if there are none.
+func (b *BundleAPI) fillBundleData(model description.Model) (*exportedBundle, *exportedOverlay, error) {
 	cfg := model.Config()
 	value, ok := cfg["default-series"]
 	if !ok {
@@ -274,17 +328,20 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 	}
 	defaultSeries := fmt.Sprintf("%v", value)
 
-	data := &charm.BundleData{
-		Series:       defaultSeries,
-		Applications: make(map[string]*charm.ApplicationSpec),
-		Relations:    [][]string{},
-		Machines:     make(map[string]*charm.MachineSpec),
+	data := &exportedBundle{
+		BundleData: charm.BundleData{
+			Series:       defaultSeries,
+			Applications: make(map[string]*charm.ApplicationSpec),
+			Relations:    [][]string{},
+			Machines:     make(map[string]*charm.MachineSpec),
+		},
 	}
 
 	if len(model.Applications()) == 0 {
-		return &charm.BundleData{}, errors.Errorf("nothing to export as there are no applications")
+		return nil, nil, errors.Errorf("nothing to export as there are no applications")
 	}
 	machineIds := make(set.Strings)
+	exposedEndpoints := make(map[string]map[string]*exposedEndpointSpec)
 	for _, application := range model.Applications() {
 		var newApplication *charm.ApplicationSpec
 		if application.Subordinate() {
@@ -324,6 +381,25 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 				newApplication.Constraints = strings.Join(result, " ")
 			}
 		}
+		newApplication.EndpointBindings = b.bindings(application.EndpointBindings())
+		newApplication.Storage = b.storage(application.StorageConstraints())
+		newApplication.Resources = b.resources(application.Resources())
+
+		deviceConstraints, err := b.backend.ApplicationDeviceConstraints(application.Name())
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "getting device constraints for %q", application.Name())
+		}
+		newApplication.Devices = b.devices(deviceConstraints)
+
+		if application.Exposed() {
+			exposed, err := b.backend.ApplicationExposedEndpoints(application.Name())
+			if err != nil {
+				return nil, nil, errors.Annotatef(err, "getting expose settings for %q", application.Name())
+			}
+			if result := b.exposedEndpoints(exposed); len(result) != 0 {
+				exposedEndpoints[application.Name()] = result
+			}
+		}
 
 		data.Applications[application.Name()] = newApplication
 	}
@@ -348,9 +424,27 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 		data.Machines[machine.Id()] = newMachine
 	}
 
+	// Consumer proxies represent the models consuming our offers; they
+	// are created by the consuming models, as are their relations.
+	consumerProxies := set.NewStrings()
+	for _, remoteApp := range model.RemoteApplications() {
+		if remoteApp.IsConsumerProxy() {
+			consumerProxies.Add(remoteApp.Name())
+			continue
+		}
+		if data.Saas == nil {
+			data.Saas = make(map[string]*saasSpec)
+		}
+		data.Saas[remoteApp.Name()] = &saasSpec{URL: remoteApp.URL()}
+	}
+
 	for _, relation := range model.Relations() {
 		endpointRelation := []string{}
 		for _, endpoint := range relation.Endpoints() {
+			if consumerProxies.Contains(endpoint.ApplicationName()) {
+				endpointRelation = nil
+				break
+			}
 			// skipping the 'peer' role which is not of concern in exporting the current model configuration.
 			if endpoint.Role() == "peer" {
 				continue
@@ -362,7 +456,171 @@ func (b *BundleAPI) fillBundleData(model description.Model) (*charm.BundleData,
 		}
 	}
 
-	return data, nil
+	overlay, err := b.offersOverlay()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	for appName, endpoints := range exposedEndpoints {
+		if overlay == nil {
+			overlay = &exportedOverlay{
+				Applications: make(map[string]*overlayApplication),
+			}
+		}
+		app, ok := overlay.Applications[appName]
+		if !ok {
+			app = &overlayApplication{}
+			overlay.Applications[appName] = app
+		}
+		app.ExposedEndpoints = endpoints
+	}
+	return data, overlay, nil
+}
+
+// exposedEndpoints returns the per-endpoint expose settings of an
+// application, or nil if the application has none and so is exposed
+// to everyone on all endpoints, which the bundle expose flag already
+// describes.
+func (b *BundleAPI) exposedEndpoints(exposed map[string]state.ExposedEndpoint) map[string]*exposedEndpointSpec {
+	if len(exposed) == 0 {
+		return nil
+	}
+	if all, ok := exposed[state.AllEndpoints]; ok && len(exposed) == 1 && len(all.ExposeToSpaces) == 0 {
+		if len(all.ExposeToCIDRs) == 0 || (len(all.ExposeToCIDRs) == 1 && all.ExposeToCIDRs[0] == "0.0.0.0/0") {
+			return nil
+		}
+	}
+	result := make(map[string]*exposedEndpointSpec)
+	for endpoint, details := range exposed {
+		result[endpoint] = &exposedEndpointSpec{
+			ExposeToSpaces: details.ExposeToSpaces,
+			ExposeToCIDRs:  details.ExposeToCIDRs,
+		}
+	}
+	return result
+}
+
+// offersOverlay returns an overlay describing the offers hosted by the
+// model, or nil if there are none.
+func (b *BundleAPI) offersOverlay() (*exportedOverlay, error) {
+	offers, err := b.backend.AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting application offers")
+	}
+	if len(offers) == 0 {
+		return nil, nil
+	}
+	overlay := &exportedOverlay{
+		Applications: make(map[string]*overlayApplication),
+	}
+	for _, offer := range offers {
+		var endpoints []string
+		for _, ep := range offer.Endpoints {
+			endpoints = append(endpoints, ep.Name)
+		}
+		sort.Strings(endpoints)
+
+		users, err := b.backend.GetOfferUsers(offer.OfferUUID)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting users for offer %q", offer.OfferName)
+		}
+		var acl map[string]string
+		if len(users) > 0 {
+			acl = make(map[string]string)
+			for user, access := range users {
+				acl[user] = string(access)
+			}
+		}
+
+		app, ok := overlay.Applications[offer.ApplicationName]
+		if !ok {
+			app = &overlayApplication{Offers: make(map[string]*offerSpec)}
+			overlay.Applications[offer.ApplicationName] = app
+		}
+		app.Offers[offer.OfferName] = &offerSpec{
+			Endpoints: endpoints,
+			ACL:       acl,
+		}
+	}
+	return overlay, nil
+}
+
+// bindings returns the endpoint bindings to spaces, omitting those
+// endpoints bound to the default space.
+func (b *BundleAPI) bindings(bindings map[string]string) map[string]string {
+	var result map[string]string
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[endpoint] = space
+	}
+	return result
+}
+
+// storage returns the storage directives in the format accepted by
+// bundles: [<pool>,][<count>,][<size>].
+func (b *BundleAPI) storage(cons map[string]description.StorageConstraint) map[string]string {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]string)
+	for name, c := range cons {
+		var fields []string
+		if pool := c.Pool(); pool != "" {
+			fields = append(fields, pool)
+		}
+		if count := c.Count(); count != 0 {
+			fields = append(fields, strconv.FormatUint(count, 10))
+		}
+		if size := c.Size(); size != 0 {
+			fields = append(fields, strconv.FormatUint(size, 10)+"M")
+		}
+		result[name] = strings.Join(fields, ",")
+	}
+	return result
+}
+
+// devices returns the device constraints in the format accepted by
+// bundles: <count>,<type>[,<key>=<value>;...].
+func (b *BundleAPI) devices(cons map[string]devices.Constraints) map[string]string {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]string)
+	for name, c := range cons {
+		value := fmt.Sprintf("%d,%s", c.Count, c.Type)
+		if len(c.Attributes) > 0 {
+			var attrs []string
+			for k, v := range c.Attributes {
+				attrs = append(attrs, k+"="+v)
+			}
+			sort.Strings(attrs)
+			value += "," + strings.Join(attrs, ";")
+		}
+		result[name] = value
+	}
+	return result
+}
+
+// resources returns the revisions of the application's charm store
+// resources. Uploaded resources cannot be recreated from a bundle, so
+// they are omitted.
+func (b *BundleAPI) resources(resources []description.Resource) map[string]interface{} {
+	var result map[string]interface{}
+	for _, res := range resources {
+		rev := res.ApplicationRevision()
+		if rev == nil || rev.Origin() != "store" {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]interface{})
+		}
+		result[res.Name()] = rev.Revision()
+	}
+	return result
 }
 
 func (b *BundleAPI) hardwareConstraints(instance description.CloudInstance) []string {
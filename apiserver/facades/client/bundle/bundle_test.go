@@ -5,15 +5,22 @@ package bundle_test
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/description"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/bundle"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 )
 
@@ -931,3 +938,280 @@ func (s *bundleSuite) TestExportBundleWithContainers(c *gc.C) {
 	c.Assert(result, gc.Equals, expectedResult)
 	s.st.CheckCall(c, 0, "ExportPartial", s.st.GetExportConfig())
 }
+
+func (s *bundleSuite) newModelWithApplication() (description.Model, description.Application) {
+	s.st.model = description.NewModel(description.ModelArgs{Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name": "awesome",
+			"uuid": "some-uuid",
+		},
+		CloudRegion: "some-region"})
+	args := s.minimalApplicationArgs(description.IAAS)
+	args.EndpointBindings = map[string]string{
+		"juju-info": "",
+		"db":        "internal",
+	}
+	args.StorageConstraints = map[string]description.StorageConstraintArgs{
+		"data":  {Pool: "ebs", Size: 10240, Count: 2},
+		"cache": {Size: 1024, Count: 1},
+	}
+	app := s.st.model.AddApplication(args)
+	app.SetStatus(minimalStatusArgs())
+	u := app.AddUnit(minimalUnitArgs(app.Type()))
+	u.SetAgentStatus(minimalStatusArgs())
+	s.st.model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+	return s.st.model, app
+}
+
+type exportedApplication struct {
+	Bindings  map[string]string `yaml:"bindings"`
+	Storage   map[string]string `yaml:"storage"`
+	Devices   map[string]string `yaml:"devices"`
+	Resources map[string]int    `yaml:"resources"`
+}
+
+type exportedBundle struct {
+	Applications map[string]exportedApplication `yaml:"applications"`
+	Saas         map[string]map[string]string   `yaml:"saas"`
+	Relations    [][]string                     `yaml:"relations"`
+}
+
+func (s *bundleSuite) TestExportBundleWithBindingsStorageDevicesResources(c *gc.C) {
+	_, app := s.newModelWithApplication()
+	res := app.AddResource(description.ResourceArgs{Name: "store-res"})
+	res.SetApplicationRevision(description.ResourceRevisionArgs{
+		Revision: 3,
+		Type:     "file",
+		Origin:   "store",
+	})
+	res = app.AddResource(description.ResourceArgs{Name: "upload-res"})
+	res.SetApplicationRevision(description.ResourceRevisionArgs{
+		Revision: 0,
+		Type:     "file",
+		Origin:   "upload",
+	})
+	s.st.deviceConstraints = map[string]map[string]devices.Constraints{
+		"ubuntu": {
+			"bitcoinminer": {
+				Type:       "nvidia.com/gpu",
+				Count:      2,
+				Attributes: map[string]string{"gpu": "nvidia-tesla-p100", "arch": "amd64"},
+			},
+		},
+	}
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var bundle exportedBundle
+	err = yaml.Unmarshal([]byte(result.Result), &bundle)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle.Applications["ubuntu"], jc.DeepEquals, exportedApplication{
+		Bindings: map[string]string{"db": "internal"},
+		Storage: map[string]string{
+			"data":  "ebs,2,10240M",
+			"cache": "1,1024M",
+		},
+		Devices: map[string]string{
+			"bitcoinminer": "2,nvidia.com/gpu,arch=amd64;gpu=nvidia-tesla-p100",
+		},
+		Resources: map[string]int{"store-res": 3},
+	})
+	s.st.CheckCall(c, 1, "ApplicationDeviceConstraints", "ubuntu")
+}
+
+// addRemoteApplications adds a consumed remote application and a
+// consumer proxy to the model, each related to the ubuntu application.
+func (s *bundleSuite) addRemoteApplications(model description.Model) {
+	model.AddRemoteApplication(description.RemoteApplicationArgs{
+		Tag:         names.NewApplicationTag("mysql"),
+		OfferUUID:   "offer-uuid",
+		URL:         "admin/prod.mysql",
+		SourceModel: names.NewModelTag("some-other-uuid"),
+	})
+	model.AddRemoteApplication(description.RemoteApplicationArgs{
+		Tag:             names.NewApplicationTag("remote-abcdef"),
+		SourceModel:     names.NewModelTag("consumer-uuid"),
+		IsConsumerProxy: true,
+	})
+	rel := model.AddRelation(description.RelationArgs{Id: 1, Key: "ubuntu:db mysql:db"})
+	rel.AddEndpoint(description.EndpointArgs{ApplicationName: "ubuntu", Name: "db"})
+	rel.AddEndpoint(description.EndpointArgs{ApplicationName: "mysql", Name: "db"})
+	rel = model.AddRelation(description.RelationArgs{Id: 2, Key: "remote-abcdef:info ubuntu:info"})
+	rel.AddEndpoint(description.EndpointArgs{ApplicationName: "remote-abcdef", Name: "info"})
+	rel.AddEndpoint(description.EndpointArgs{ApplicationName: "ubuntu", Name: "info"})
+}
+
+func (s *bundleSuite) TestExportBundleWithSaas(c *gc.C) {
+	model, _ := s.newModelWithApplication()
+	s.addRemoteApplications(model)
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The consumer proxy, and its relation, belong to the consuming
+	// model, so they aren't exported.
+	var bundle exportedBundle
+	err = yaml.Unmarshal([]byte(result.Result), &bundle)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle.Saas, jc.DeepEquals, map[string]map[string]string{
+		"mysql": {"url": "admin/prod.mysql"},
+	})
+	c.Assert(bundle.Relations, jc.DeepEquals, [][]string{{"ubuntu:db", "mysql:db"}})
+}
+
+func (s *bundleSuite) TestExportBundleWithOffers(c *gc.C) {
+	s.newModelWithApplication()
+	s.st.offers = []*crossmodel.ApplicationOffer{{
+		OfferUUID:       "offer-uuid",
+		OfferName:       "ubuntu-offer",
+		ApplicationName: "ubuntu",
+		Endpoints: map[string]charm.Relation{
+			"juju-info": {Name: "juju-info"},
+			"db":        {Name: "db"},
+		},
+	}}
+	s.st.offerUsers = map[string]map[string]permission.Access{
+		"offer-uuid": {
+			"admin":    permission.AdminAccess,
+			"everyone": permission.ReadAccess,
+		},
+	}
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	parts := strings.SplitN(result.Result, "--- # overlay.yaml\n", 2)
+	c.Assert(parts, gc.HasLen, 2)
+	c.Assert(parts[1], gc.Equals, ""+
+		"applications:\n"+
+		"  ubuntu:\n"+
+		"    offers:\n"+
+		"      ubuntu-offer:\n"+
+		"        endpoints:\n"+
+		"        - db\n"+
+		"        - juju-info\n"+
+		"        acl:\n"+
+		"          admin: admin\n"+
+		"          everyone: read\n")
+	s.st.CheckCall(c, 3, "GetOfferUsers", "offer-uuid")
+}
+
+func (s *bundleSuite) TestExportBundleCanBeDeployed(c *gc.C) {
+	model, app := s.newModelWithApplication()
+	res := app.AddResource(description.ResourceArgs{Name: "store-res"})
+	res.SetApplicationRevision(description.ResourceRevisionArgs{
+		Revision: 3,
+		Type:     "file",
+		Origin:   "store",
+	})
+	s.st.deviceConstraints = map[string]map[string]devices.Constraints{
+		"ubuntu": {
+			"bitcoinminer": {
+				Type:       "nvidia.com/gpu",
+				Count:      2,
+				Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
+			},
+		},
+	}
+	wordpress := model.AddApplication(description.ApplicationArgs{
+		Tag:                names.NewApplicationTag("wordpress"),
+		Series:             "trusty",
+		Type:               description.IAAS,
+		CharmURL:           "cs:trusty/wordpress-5",
+		CharmConfig:        map[string]interface{}{},
+		LeadershipSettings: map[string]interface{}{},
+	})
+	wordpress.SetStatus(minimalStatusArgs())
+	machine := model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("1")})
+	unit := wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/0"),
+		Machine: machine.Tag(),
+	})
+	unit.SetAgentStatus(minimalStatusArgs())
+	rel := model.AddRelation(description.RelationArgs{Id: 3, Key: "wordpress:db ubuntu:db"})
+	rel.AddEndpoint(description.EndpointArgs{ApplicationName: "wordpress", Name: "db"})
+	rel.AddEndpoint(description.EndpointArgs{ApplicationName: "ubuntu", Name: "db"})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The exported bundle is verified and turned into changes in the
+	// same way as when it is deployed.
+	changes, err := s.facade.GetChanges(params.BundleChangesParams{
+		BundleDataYAML: result.Result,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes.Errors, gc.HasLen, 0)
+
+	var methods []string
+	for _, change := range changes.Changes {
+		methods = append(methods, change.Method)
+	}
+	c.Assert(methods, jc.SameContents, []string{
+		"addCharm", "addCharm",
+		"addMachines", "addMachines",
+		"deploy", "deploy",
+		"addUnit", "addUnit",
+		"addRelation",
+	})
+}
+
+func (s *bundleSuite) newModelWithExposedApplication() {
+	s.st.model = description.NewModel(description.ModelArgs{Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name": "awesome",
+			"uuid": "some-uuid",
+		},
+		CloudRegion: "some-region"})
+	args := s.minimalApplicationArgs(description.IAAS)
+	args.Exposed = true
+	app := s.st.model.AddApplication(args)
+	app.SetStatus(minimalStatusArgs())
+	u := app.AddUnit(minimalUnitArgs(app.Type()))
+	u.SetAgentStatus(minimalStatusArgs())
+	s.st.model.AddMachine(description.MachineArgs{Id: names.NewMachineTag("0")})
+}
+
+func (s *bundleSuite) TestExportBundleWithExposedEndpoints(c *gc.C) {
+	s.newModelWithExposedApplication()
+	s.st.exposedEndpoints = map[string]map[string]state.ExposedEndpoint{
+		"ubuntu": {
+			"db": {
+				ExposeToSpaces: []string{"dmz"},
+				ExposeToCIDRs:  []string{"10.0.0.0/24"},
+			},
+		},
+	}
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+
+	parts := strings.SplitN(result.Result, "--- # overlay.yaml\n", 2)
+	c.Assert(parts, gc.HasLen, 2)
+	c.Assert(parts[0], jc.Contains, "expose: true")
+	c.Assert(parts[1], gc.Equals, ""+
+		"applications:\n"+
+		"  ubuntu:\n"+
+		"    exposed-endpoints:\n"+
+		"      db:\n"+
+		"        expose-to-spaces:\n"+
+		"        - dmz\n"+
+		"        expose-to-cidrs:\n"+
+		"        - 10.0.0.0/24\n")
+	s.st.CheckCall(c, 2, "ApplicationExposedEndpoints", "ubuntu")
+}
+
+func (s *bundleSuite) TestExportBundleExposedToAllHasNoOverlay(c *gc.C) {
+	s.newModelWithExposedApplication()
+	s.st.exposedEndpoints = map[string]map[string]state.ExposedEndpoint{
+		"ubuntu": {
+			state.AllEndpoints: {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+		},
+	}
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.Not(jc.Contains), "overlay.yaml")
+	c.Assert(result.Result, jc.Contains, "expose: true")
+}
@@ -8,13 +8,20 @@ import (
 	"github.com/juju/testing"
 
 	"github.com/juju/juju/apiserver/facades/client/bundle"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
 
 type mockState struct {
 	testing.Stub
 	bundle.Backend
-	model description.Model
+	model             description.Model
+	offers            []*crossmodel.ApplicationOffer
+	offerUsers        map[string]map[string]permission.Access
+	deviceConstraints map[string]map[string]devices.Constraints
+	exposedEndpoints  map[string]map[string]state.ExposedEndpoint
 }
 
 func (m *mockState) ExportPartial(config state.ExportConfig) (description.Model, error) {
@@ -38,6 +45,26 @@ func (m *mockState) GetExportConfig() state.ExportConfig {
 	}
 }
 
+func (m *mockState) AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error) {
+	m.MethodCall(m, "AllApplicationOffers")
+	return m.offers, m.NextErr()
+}
+
+func (m *mockState) GetOfferUsers(offerUUID string) (map[string]permission.Access, error) {
+	m.MethodCall(m, "GetOfferUsers", offerUUID)
+	return m.offerUsers[offerUUID], m.NextErr()
+}
+
+func (m *mockState) ApplicationDeviceConstraints(appName string) (map[string]devices.Constraints, error) {
+	m.MethodCall(m, "ApplicationDeviceConstraints", appName)
+	return m.deviceConstraints[appName], m.NextErr()
+}
+
+func (m *mockState) ApplicationExposedEndpoints(appName string) (map[string]state.ExposedEndpoint, error) {
+	m.MethodCall(m, "ApplicationExposedEndpoints", appName)
+	return m.exposedEndpoints[appName], m.NextErr()
+}
+
 func newMockState() *mockState {
 	st := &mockState{
 		Stub: testing.Stub{},
@@ -5,13 +5,32 @@ package bundle
 
 import (
 	"github.com/juju/description"
+	"github.com/juju/errors"
 
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
 
 type Backend interface {
 	ExportPartial(cfg state.ExportConfig) (description.Model, error)
 	GetExportConfig() state.ExportConfig
+
+	// AllApplicationOffers returns the offers hosted by the model.
+	AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error)
+
+	// GetOfferUsers returns the access levels of the users of an offer.
+	GetOfferUsers(offerUUID string) (map[string]permission.Access, error)
+
+	// ApplicationDeviceConstraints returns the device constraints of
+	// the named application, which are not included in the export.
+	ApplicationDeviceConstraints(appName string) (map[string]devices.Constraints, error)
+
+	// ApplicationExposedEndpoints returns the per-endpoint expose
+	// settings of the named application, which are not included in
+	// the export.
+	ApplicationExposedEndpoints(appName string) (map[string]state.ExposedEndpoint, error)
 }
 
 type stateShim struct {
@@ -32,6 +51,41 @@ func (m *stateShim) GetExportConfig() state.ExportConfig {
 	return cfg
 }
 
+// AllApplicationOffers implements Backend.AllApplicationOffers.
+func (m *stateShim) AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error) {
+	return state.NewApplicationOffers(m.State).AllApplicationOffers()
+}
+
+// ApplicationDeviceConstraints implements Backend.ApplicationDeviceConstraints.
+func (m *stateShim) ApplicationDeviceConstraints(appName string) (map[string]devices.Constraints, error) {
+	app, err := m.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cons, err := app.DeviceConstraints()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]devices.Constraints)
+	for name, c := range cons {
+		result[name] = devices.Constraints{
+			Type:       devices.DeviceType(c.Type),
+			Count:      c.Count,
+			Attributes: c.Attributes,
+		}
+	}
+	return result, nil
+}
+
+// ApplicationExposedEndpoints implements Backend.ApplicationExposedEndpoints.
+func (m *stateShim) ApplicationExposedEndpoints(appName string) (map[string]state.ExposedEndpoint, error) {
+	app, err := m.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app.ExposedEndpoints(), nil
+}
+
 // NewStateShim creates new state shim to be used by bundle Facade.
 func NewStateShim(st *state.State) Backend {
 	return &stateShim{st}
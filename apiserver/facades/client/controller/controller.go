@@ -7,6 +7,7 @@ package controller
 
 import (
 	"encoding/json"
+	"fmt"
 	"sort"
 
 	"github.com/juju/errors"
@@ -45,11 +46,25 @@ type ControllerAPI struct {
 	hub        facade.Hub
 }
 
+// ControllerAPIv6 provides the v6 Controller API. The only difference
+// between this and v7 is that v6 doesn't have the BackupScheduleStatus
+// method.
+type ControllerAPIv6 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv5 provides the v5 Controller API. The only difference
+// between this and v6 is that v5 doesn't have the CheckMigration
+// method.
+type ControllerAPIv5 struct {
+	*ControllerAPIv6
+}
+
 // ControllerAPIv4 provides the v4 Controller API. The only difference
 // between this and v5 is that v4 doesn't have the
 // UpdateControllerConfig method.
 type ControllerAPIv4 struct {
-	*ControllerAPI
+	*ControllerAPIv5
 }
 
 // ControllerAPIv3 provides the v3 Controller API.
@@ -57,8 +72,8 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv5 creates a new ControllerAPIv5.
-func NewControllerAPIv5(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv7 creates a new ControllerAPIv7.
+func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -76,6 +91,24 @@ func NewControllerAPIv5(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv6 creates a new ControllerAPIv6.
+func NewControllerAPIv6(ctx facade.Context) (*ControllerAPIv6, error) {
+	v7, err := NewControllerAPIv7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv6{v7}, nil
+}
+
+// NewControllerAPIv5 creates a new ControllerAPIv5.
+func NewControllerAPIv5(ctx facade.Context) (*ControllerAPIv5, error) {
+	v6, err := NewControllerAPIv6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv5{v6}, nil
+}
+
 // NewControllerAPIv4 creates a new ControllerAPIv4.
 func NewControllerAPIv4(ctx facade.Context) (*ControllerAPIv4, error) {
 	v5, err := NewControllerAPIv5(ctx)
@@ -428,38 +461,95 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, targetInfo, err := c.migrationSpecInfo(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Release()
 
-	// Ensure the model exists.
-	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
-		return "", errors.Annotate(err, "reading model")
-	} else if !modelExists {
-		return "", errors.NotFoundf("model")
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.statePool.Get(modelTag.Id())
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// CheckMigration runs all the checks made before a migration is
+// started, as well as a trial import of each model on the target
+// controller, without starting a migration. Every failing check is
+// reported, rather than just the first.
+func (c *ControllerAPI) CheckMigration(reqArgs params.InitiateMigrationArgs) (
+	params.CheckMigrationResults, error,
+) {
+	out := params.CheckMigrationResults{
+		Results: make([]params.CheckMigrationResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		failures, err := c.checkOneMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Failures = failures
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) checkOneMigration(spec params.MigrationSpec) ([]string, error) {
+	hostedState, targetInfo, err := c.migrationSpecInfo(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	defer hostedState.Release()
+	return runMigrationChecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence)
+}
+
+// migrationSpecInfo returns the state for the model to be migrated,
+// and the details of the target controller, from the migration spec.
+// The caller is responsible for releasing the state.
+func (c *ControllerAPI) migrationSpecInfo(spec params.MigrationSpec) (*state.PooledState, coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, empty, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if modelExists, err := c.state.ModelExists(modelTag.Id()); err != nil {
+		return nil, empty, errors.Annotate(err, "reading model")
+	} else if !modelExists {
+		return nil, empty, errors.NotFoundf("model")
+	}
 
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return nil, empty, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return nil, empty, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return nil, empty, errors.Annotate(err, "invalid macaroons")
 		}
 	}
 	targetInfo := coremigration.TargetInfo{
@@ -471,20 +561,11 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Macaroons:     macs,
 	}
 
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
+	hostedState, err := c.statePool.Get(modelTag.Id())
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, empty, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	return hostedState, targetInfo, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -550,6 +631,37 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 	return nil
 }
 
+// BackupScheduleStatus returns the configured backup schedule and the
+// outcome of the most recent scheduled backup.
+func (c *ControllerAPI) BackupScheduleStatus() (params.BackupScheduleStatus, error) {
+	var result params.BackupScheduleStatus
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	cfg, err := c.state.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	status, err := c.state.BackupScheduleStatus()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Schedule = cfg.BackupSchedule()
+	result.MaxCount = cfg.BackupMaxCount()
+	if maxAge := cfg.BackupMaxAge(); maxAge > 0 {
+		result.MaxAge = maxAge.String()
+	}
+	if !status.LastAttempt.IsZero() {
+		result.LastAttempt = &status.LastAttempt
+	}
+	if !status.LastSuccess.IsZero() {
+		result.LastSuccess = &status.LastSuccess
+	}
+	result.LastBackupID = status.LastBackupID
+	result.LastError = status.LastError
+	return result, nil
+}
+
 // Mask the ConfigSet method from the v4 API. The API reflection code
 // in rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so
 // this removes the method as far as the RPC machinery is concerned.
@@ -557,6 +669,12 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 // ConfigSet isn't on the v4 API.
 func (c *ControllerAPIv4) ConfigSet(_, _ struct{}) {}
 
+// CheckMigration isn't on the v5 API.
+func (c *ControllerAPIv5) CheckMigration(_, _ struct{}) {}
+
+// BackupScheduleStatus isn't on the v6 API.
+func (c *ControllerAPIv6) BackupScheduleStatus(_, _ struct{}) {}
+
 // runMigrationPrechecks runs prechecks on the migration and updates
 // information in targetInfo as needed based on information
 // retrieved from the target controller.
@@ -583,21 +701,81 @@ var runMigrationPrechecks = func(st, ctlrSt *state.State, targetInfo *coremigrat
 		return errors.Trace(err)
 	}
 	client := migrationtarget.NewClient(conn)
-	if targetInfo.CACert == "" {
-		targetInfo.CACert, err = client.CACert()
-		if err != nil {
-			if !params.IsCodeNotImplemented(err) {
-				return errors.Annotatef(err, "cannot retrieve CA certificate")
-			}
-			// If the call's not implemented, it indicates an earlier version
-			// of the controller, which we can't migrate to.
-			return errors.New("controller API version is too old")
-		}
+	if err := fetchTargetCACert(client, targetInfo); err != nil {
+		return errors.Trace(err)
 	}
 	err = client.Prechecks(modelInfo)
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationChecks runs the same checks as runMigrationPrechecks,
+// along with a trial import of the model on the target controller,
+// and returns every failing check rather than stopping at the first.
+// An error is returned if the checks couldn't be run.
+var runMigrationChecks = func(st, ctlrSt *state.State, targetInfo *coremigration.TargetInfo, presence facade.Presence) ([]string, error) {
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backend")
+	}
+	modelPresence := presence.ModelPresence(st.ModelUUID())
+	controllerPresence := presence.ModelPresence(ctlrSt.ModelUUID())
+	sourceFailures, err := migration.SourcePrecheckAll(backend, modelPresence, controllerPresence)
+	if err != nil {
+		return nil, errors.Annotate(err, "running source prechecks")
+	}
+	var failures []string
+	for _, failure := range sourceFailures {
+		failures = append(failures, "source: "+failure)
+	}
+	bytes, err := migration.ExportModel(st)
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("source: exporting model: %v", err))
+	}
+
+	// Check target controller.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		return nil, errors.Annotate(err, "connect to target controller")
+	}
+	defer conn.Close()
+	modelInfo, err := makeModelInfo(st, ctlrSt)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := migrationtarget.NewClient(conn)
+	if err := fetchTargetCACert(client, targetInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
+	targetFailures, err := client.CheckMigration(modelInfo, bytes)
+	if err != nil {
+		return nil, errors.Annotate(err, "running target prechecks")
+	}
+	for _, failure := range targetFailures {
+		failures = append(failures, "target: "+failure)
+	}
+	return failures, nil
+}
+
+// fetchTargetCACert sets the CA certificate in targetInfo from the
+// target controller if it wasn't supplied.
+func fetchTargetCACert(client *migrationtarget.Client, targetInfo *coremigration.TargetInfo) error {
+	if targetInfo.CACert != "" {
+		return nil
+	}
+	caCert, err := client.CACert()
+	if err != nil {
+		if !params.IsCodeNotImplemented(err) {
+			return errors.Annotatef(err, "cannot retrieve CA certificate")
+		}
+		// If the call's not implemented, it indicates an earlier version
+		// of the controller, which we can't migrate to.
+		return errors.New("controller API version is too old")
+	}
+	targetInfo.CACert = caCert
+	return nil
+}
+
 func makeModelInfo(st, ctlrSt *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
@@ -18,3 +18,9 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetMigrationChecksResult(p patcher, failures []string, err error) {
+	p.PatchValue(&runMigrationChecks, func(*state.State, *state.State, *migration.TargetInfo, facade.Presence) ([]string, error) {
+		return failures, err
+	})
+}
@@ -0,0 +1,196 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package introspection implements the Introspection facade, which
+// lets controller administrators run an agent's introspection
+// handlers remotely, without access to the agent's machine.
+package introspection
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.introspection")
+
+// responseTimeout is how long to wait for an agent to respond to an
+// introspection request, in addition to any time the request asks the
+// handler to spend collecting data.
+const responseTimeout = 30 * time.Second
+
+// maxCollectTime is the longest a handler may be asked to spend
+// collecting data.
+const maxCollectTime = 10 * time.Minute
+
+// Backend defines the state methods needed by the facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	FindEntity(names.Tag) (state.Entity, error)
+	AddIntrospectionRequest(agent names.Tag, path, requester string) (Request, error)
+}
+
+// Request defines the methods of a state.IntrospectionRequest needed
+// by the facade.
+type Request interface {
+	Completed() bool
+	Output() []byte
+	Error() string
+	Refresh() error
+	Watch() state.NotifyWatcher
+	Remove() error
+}
+
+// API implements the Introspection facade.
+type API struct {
+	backend   Backend
+	requester names.Tag
+	clock     clock.Clock
+}
+
+// NewFacade creates an Introspection facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(backendShim{ctx.State()}, ctx.Auth(), clock.WallClock)
+}
+
+// NewAPI returns an Introspection facade. Only controller superusers
+// are allowed access.
+func NewAPI(backend Backend, authorizer facade.Authorizer, clock clock.Clock) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		requester: authorizer.GetAuthTag(),
+		clock:     clock,
+	}, nil
+}
+
+// Introspect asks each of the machine or unit agents to run the
+// introspection handler at the given path, and returns the output.
+// The agent is asked over its own API connection, and each call
+// waits for at most 30 seconds for the agent to respond, plus the
+// duration of any "seconds" query, as used by the profiling handlers.
+func (api *API) Introspect(args params.IntrospectArgs) params.IntrospectResults {
+	results := params.IntrospectResults{
+		Results: make([]params.IntrospectResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		output, err := api.introspect(arg)
+		results.Results[i].Output = output
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results
+}
+
+func (api *API) introspect(arg params.IntrospectArg) ([]byte, error) {
+	collectTime, err := requestedDuration(arg.Path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch tag.(type) {
+	case names.MachineTag, names.UnitTag:
+	default:
+		return nil, errors.NotValidf("introspection agent %q", arg.Tag)
+	}
+	if _, err := api.backend.FindEntity(tag); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// This is logged whether or not the controller's audit log is
+	// enabled, so that there is always a record of who looked at
+	// what on which agent.
+	logger.Infof("%s requested introspection of %s from %s",
+		names.ReadableString(api.requester), arg.Path, names.ReadableString(tag))
+	req, err := api.backend.AddIntrospectionRequest(tag, arg.Path, api.requester.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err := req.Remove(); err != nil {
+			logger.Warningf("%v", err)
+		}
+	}()
+
+	w := req.Watch()
+	defer w.Stop()
+	timeout := api.clock.After(responseTimeout + collectTime)
+	for {
+		select {
+		case _, ok := <-w.Changes():
+			if !ok {
+				return nil, watcher.EnsureErr(w)
+			}
+			if err := req.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !req.Completed() {
+				continue
+			}
+			if req.Error() != "" {
+				return nil, errors.New(req.Error())
+			}
+			return req.Output(), nil
+		case <-timeout:
+			return nil, errors.Errorf("timed out waiting for %s to respond", names.ReadableString(tag))
+		}
+	}
+}
+
+// requestedDuration returns the time the handler at the path is asked
+// to spend collecting data by a "seconds" query, as the debug/pprof
+// profile and trace handlers accept.
+func requestedDuration(path string) (time.Duration, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return 0, errors.NotValidf("introspection path %q", path)
+	}
+	value := u.Query().Get("seconds")
+	if value == "" {
+		return 0, nil
+	}
+	seconds, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, errors.NotValidf("seconds %q", value)
+	}
+	duration := time.Duration(seconds) * time.Second
+	if duration > maxCollectTime {
+		return 0, errors.Errorf("cannot collect data for more than %v", maxCollectTime)
+	}
+	return duration, nil
+}
+
+type backendShim struct {
+	*state.State
+}
+
+func (shim backendShim) AddIntrospectionRequest(agent names.Tag, path, requester string) (Request, error) {
+	req, err := shim.State.AddIntrospectionRequest(agent, path, requester)
+	if err != nil {
+		return nil, err
+	}
+	return req, nil
+}
@@ -0,0 +1,213 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/introspection"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type introspectionSuite struct {
+	testing.IsolationSuite
+
+	backend *fakeBackend
+	clock   *testclock.Clock
+	api     *introspection.API
+}
+
+var _ = gc.Suite(&introspectionSuite{})
+
+func (s *introspectionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{
+		Stub:    &testing.Stub{},
+		request: &fakeRequest{Stub: &testing.Stub{}},
+	}
+	s.clock = testclock.NewClock(time.Now())
+	api, err := introspection.NewAPI(
+		s.backend,
+		apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin"), AdminTag: names.NewUserTag("admin")},
+		s.clock,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *introspectionSuite) introspect(tag, path string) params.IntrospectResult {
+	results := s.api.Introspect(params.IntrospectArgs{
+		Args: []params.IntrospectArg{{Tag: tag, Path: path}},
+	})
+	return results.Results[0]
+}
+
+func (s *introspectionSuite) TestPermissionDenied(c *gc.C) {
+	_, err := introspection.NewAPI(
+		s.backend,
+		apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("bob")},
+		s.clock,
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+
+	_, err = introspection.NewAPI(
+		s.backend,
+		apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")},
+		s.clock,
+	)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *introspectionSuite) TestIntrospect(c *gc.C) {
+	s.backend.request.completeOnRefresh = 2
+	s.backend.request.output = []byte("some output")
+	result := s.introspect("unit-mysql-0", "/depengine")
+	c.Assert(result, jc.DeepEquals, params.IntrospectResult{Output: []byte("some output")})
+
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"FindEntity", []interface{}{names.NewUnitTag("mysql/0")}},
+		{"AddIntrospectionRequest", []interface{}{names.NewUnitTag("mysql/0"), "/depengine", "admin"}},
+	})
+	s.backend.request.CheckCallNames(c, "Watch", "Refresh", "Refresh", "Remove")
+}
+
+func (s *introspectionSuite) TestIntrospectAgentError(c *gc.C) {
+	s.backend.request.completeOnRefresh = 1
+	s.backend.request.err = "boom"
+	result := s.introspect("machine-0", "/metrics")
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+	s.backend.request.CheckCallNames(c, "Watch", "Refresh", "Remove")
+}
+
+func (s *introspectionSuite) TestIntrospectTimeout(c *gc.C) {
+	done := make(chan params.IntrospectResult)
+	go func() {
+		done <- s.introspect("machine-0", "/metrics")
+	}()
+	c.Assert(s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case result := <-done:
+		c.Assert(result.Error, gc.ErrorMatches, "timed out waiting for machine 0 to respond")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for result")
+	}
+	s.backend.request.CheckCallNames(c, "Watch", "Refresh", "Remove")
+}
+
+func (s *introspectionSuite) TestIntrospectProfileTimeout(c *gc.C) {
+	done := make(chan params.IntrospectResult)
+	go func() {
+		done <- s.introspect("machine-0", "/debug/pprof/profile?seconds=30")
+	}()
+	// The agent is given the time it was asked to profile for, on
+	// top of the usual timeout.
+	c.Assert(s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case result := <-done:
+		c.Fatalf("unexpected result %v", result)
+	case <-time.After(coretesting.ShortWait):
+	}
+	c.Assert(s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case result := <-done:
+		c.Assert(result.Error, gc.ErrorMatches, "timed out waiting for machine 0 to respond")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for result")
+	}
+}
+
+func (s *introspectionSuite) TestIntrospectProfileTooLong(c *gc.C) {
+	result := s.introspect("machine-0", "/debug/pprof/profile?seconds=3600")
+	c.Assert(result.Error, gc.ErrorMatches, "cannot collect data for more than 10m0s")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *introspectionSuite) TestIntrospectNotAgent(c *gc.C) {
+	result := s.introspect("application-mysql", "/metrics")
+	c.Assert(result.Error, gc.ErrorMatches, `introspection agent "application-mysql" not valid`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *introspectionSuite) TestIntrospectNotFound(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf("machine 42"))
+	result := s.introspect("machine-42", "/metrics")
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+	s.backend.CheckCallNames(c, "FindEntity")
+}
+
+type fakeBackend struct {
+	*testing.Stub
+	request *fakeRequest
+}
+
+func (b *fakeBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *fakeBackend) FindEntity(tag names.Tag) (state.Entity, error) {
+	b.MethodCall(b, "FindEntity", tag)
+	return nil, b.NextErr()
+}
+
+func (b *fakeBackend) AddIntrospectionRequest(agent names.Tag, path, requester string) (introspection.Request, error) {
+	b.MethodCall(b, "AddIntrospectionRequest", agent, path, requester)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.request, nil
+}
+
+// fakeRequest is an introspection request which the agent completes
+// once it has been refreshed completeOnRefresh times. Its watcher
+// notifies on every refresh until then.
+type fakeRequest struct {
+	*testing.Stub
+	watcher           *apiservertesting.FakeNotifyWatcher
+	completeOnRefresh int
+	refreshes         int
+	output            []byte
+	err               string
+}
+
+func (r *fakeRequest) Completed() bool {
+	return r.completeOnRefresh > 0 && r.refreshes >= r.completeOnRefresh
+}
+
+func (r *fakeRequest) Output() []byte {
+	return r.output
+}
+
+func (r *fakeRequest) Error() string {
+	return r.err
+}
+
+func (r *fakeRequest) Refresh() error {
+	r.MethodCall(r, "Refresh")
+	r.refreshes++
+	if !r.Completed() && r.completeOnRefresh > 0 {
+		r.watcher.C <- struct{}{}
+	}
+	return r.NextErr()
+}
+
+func (r *fakeRequest) Watch() state.NotifyWatcher {
+	r.MethodCall(r, "Watch")
+	r.watcher = apiservertesting.NewFakeNotifyWatcher()
+	return r.watcher
+}
+
+func (r *fakeRequest) Remove() error {
+	r.MethodCall(r, "Remove")
+	return r.NextErr()
+}
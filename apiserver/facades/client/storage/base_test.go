@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -28,6 +30,7 @@ type baseStorageSuite struct {
 
 	api             *storage.APIv4
 	apiv3           *storage.APIv3
+	apiv5           *storage.APIv5
 	storageAccessor *mockStorageAccessor
 	state           *mockState
 
@@ -38,6 +41,7 @@ type baseStorageSuite struct {
 
 	volumeTag            names.VolumeTag
 	volume               *mockVolume
+	volumeSnapshot       *mockVolumeSnapshot
 	volumeAttachment     *mockVolumeAttachment
 	filesystemTag        names.FilesystemTag
 	filesystem           *mockFilesystem
@@ -70,6 +74,8 @@ func (s *baseStorageSuite) SetUpTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.apiv3, err = storage.NewAPIv3(s.state, s.storageAccessor, s.registry, s.poolManager, s.resources, s.authorizer, s.callContext)
 	c.Assert(err, jc.ErrorIsNil)
+	s.apiv5, err = storage.NewAPIv5(s.state, s.storageAccessor, s.registry, s.poolManager, s.resources, s.authorizer, s.callContext)
+	c.Assert(err, jc.ErrorIsNil)
 }
 
 // TODO(axw) get rid of assertCalls, use stub directly everywhere.
@@ -99,6 +105,12 @@ const (
 	detachStorageCall                       = "detachStorage"
 	destroyStorageInstanceCall              = "destroyStorageInstance"
 	releaseStorageInstanceCall              = "releaseStorageInstance"
+	resizeStorageInstanceCall               = "resizeStorageInstance"
+	createStorageInstanceSnapshotCall       = "createStorageInstanceSnapshot"
+	allVolumeSnapshotsCall                  = "allVolumeSnapshots"
+	storageInstancesInPoolCall              = "storageInstancesInPool"
+	updateStoragePoolCall                   = "updateStoragePool"
+	removeStoragePoolCall                   = "removeStoragePool"
 	addExistingFilesystemCall               = "addExistingFilesystem"
 )
 
@@ -145,6 +157,13 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 		life:       state.Dead,
 	}
 	s.volume = &mockVolume{tag: s.volumeTag, storage: &s.storageTag}
+	s.volumeSnapshot = &mockVolumeSnapshot{
+		id:      "22@0",
+		volume:  s.volumeTag,
+		storage: &s.storageTag,
+		created: time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC),
+		info:    &state.VolumeSnapshotInfo{SnapshotId: "snap-22", Size: 1024},
+	}
 	s.volumeAttachment = &mockVolumeAttachment{
 		VolumeTag: s.volumeTag,
 		HostTag:   s.machineTag,
@@ -279,6 +298,48 @@ func (s *baseStorageSuite) constructStorageAccessor() *mockStorageAccessor {
 			s.stub.AddCall(releaseStorageInstanceCall, tag, destroyAttached)
 			return errors.New("cannae do it")
 		},
+		resizeStorageInstance: func(tag names.StorageTag, size uint64) error {
+			s.stub.AddCall(resizeStorageInstanceCall, tag, size)
+			return errors.New("cannae do it")
+		},
+		createStorageInstanceSnapshot: func(tag names.StorageTag) (state.VolumeSnapshot, error) {
+			s.stub.AddCall(createStorageInstanceSnapshotCall, tag)
+			if err := s.stub.NextErr(); err != nil {
+				return nil, err
+			}
+			return s.volumeSnapshot, nil
+		},
+		allVolumeSnapshots: func() ([]state.VolumeSnapshot, error) {
+			s.stub.AddCall(allVolumeSnapshotsCall)
+			return []state.VolumeSnapshot{s.volumeSnapshot}, s.stub.NextErr()
+		},
+		storageInstancesInPool: func(pool string) ([]state.StorageInstance, error) {
+			s.stub.AddCall(storageInstancesInPoolCall, pool)
+			return []state.StorageInstance{s.storageInstance}, s.stub.NextErr()
+		},
+		updateStoragePool: func(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) error {
+			s.stub.AddCall(updateStoragePoolCall, name, providerType, attrs)
+			if err := s.stub.NextErr(); err != nil {
+				return err
+			}
+			existing, ok := s.pools[name]
+			if !ok {
+				return errors.NotFoundf("mock pool manager: get pool %v", name)
+			}
+			if providerType == "" {
+				providerType = existing.Provider()
+			}
+			pool, err := jujustorage.NewConfig(name, providerType, attrs)
+			if err != nil {
+				return err
+			}
+			s.pools[name] = pool
+			return nil
+		},
+		removeStoragePool: func(pool string) error {
+			s.stub.AddCall(removeStoragePoolCall, pool)
+			return s.stub.NextErr()
+		},
 		addExistingFilesystem: func(f state.FilesystemInfo, v *state.VolumeInfo, storageName string) (names.StorageTag, error) {
 			s.stub.AddCall(addExistingFilesystemCall, f, v, storageName)
 			return s.storageTag, s.stub.NextErr()
@@ -4,6 +4,8 @@
 package storage_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -30,6 +32,10 @@ func (m *mockPoolManager) Create(name string, providerType jujustorage.ProviderT
 	return m.createPool(name, providerType, attrs)
 }
 
+func (m *mockPoolManager) Replace(name string, providerType jujustorage.ProviderType, attrs map[string]interface{}) error {
+	panic("should not be called")
+}
+
 func (m *mockPoolManager) Delete(name string) error {
 	return m.deletePool(name)
 }
@@ -58,6 +64,12 @@ type mockStorageAccessor struct {
 	blockDevices                        func(names.MachineTag) ([]state.BlockDeviceInfo, error)
 	destroyStorageInstance              func(names.StorageTag, bool) error
 	releaseStorageInstance              func(names.StorageTag, bool) error
+	resizeStorageInstance               func(names.StorageTag, uint64) error
+	createStorageInstanceSnapshot       func(names.StorageTag) (state.VolumeSnapshot, error)
+	allVolumeSnapshots                  func() ([]state.VolumeSnapshot, error)
+	storageInstancesInPool              func(string) ([]state.StorageInstance, error)
+	updateStoragePool                   func(string, jujustorage.ProviderType, map[string]interface{}) error
+	removeStoragePool                   func(string) error
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag) error
 	addExistingFilesystem               func(state.FilesystemInfo, *state.VolumeInfo, string) (names.StorageTag, error)
@@ -158,6 +170,30 @@ func (st *mockStorageAccessor) ReleaseStorageInstance(tag names.StorageTag, dest
 	return st.releaseStorageInstance(tag, destroyAttached)
 }
 
+func (st *mockStorageAccessor) ResizeStorageInstance(tag names.StorageTag, size uint64) error {
+	return st.resizeStorageInstance(tag, size)
+}
+
+func (st *mockStorageAccessor) CreateStorageInstanceSnapshot(tag names.StorageTag) (state.VolumeSnapshot, error) {
+	return st.createStorageInstanceSnapshot(tag)
+}
+
+func (st *mockStorageAccessor) AllVolumeSnapshots() ([]state.VolumeSnapshot, error) {
+	return st.allVolumeSnapshots()
+}
+
+func (st *mockStorageAccessor) StorageInstancesInPool(pool string) ([]state.StorageInstance, error) {
+	return st.storageInstancesInPool(pool)
+}
+
+func (st *mockStorageAccessor) UpdateStoragePool(pool string, providerType jujustorage.ProviderType, attrs map[string]interface{}) error {
+	return st.updateStoragePool(pool, providerType, attrs)
+}
+
+func (st *mockStorageAccessor) RemoveStoragePool(pool string) error {
+	return st.removeStoragePool(pool)
+}
+
 func (st *mockStorageAccessor) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageAttachment, error) {
 	panic("should not be called")
 }
@@ -166,6 +202,45 @@ func (st *mockStorageAccessor) AddExistingFilesystem(f state.FilesystemInfo, v *
 	return st.addExistingFilesystem(f, v, s)
 }
 
+type mockVolumeSnapshot struct {
+	state.VolumeSnapshot
+	id      string
+	volume  names.VolumeTag
+	storage *names.StorageTag
+	created time.Time
+	info    *state.VolumeSnapshotInfo
+}
+
+func (m *mockVolumeSnapshot) Id() string {
+	return m.id
+}
+
+func (m *mockVolumeSnapshot) Volume() names.VolumeTag {
+	return m.volume
+}
+
+func (m *mockVolumeSnapshot) Storage() (names.StorageTag, bool) {
+	if m.storage != nil {
+		return *m.storage, true
+	}
+	return names.StorageTag{}, false
+}
+
+func (m *mockVolumeSnapshot) Pool() string {
+	return "loop"
+}
+
+func (m *mockVolumeSnapshot) Created() time.Time {
+	return m.created
+}
+
+func (m *mockVolumeSnapshot) Info() (state.VolumeSnapshotInfo, error) {
+	if m.info != nil {
+		return *m.info, nil
+	}
+	return state.VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", m.id)
+}
+
 type mockVolume struct {
 	state.Volume
 	tag     names.VolumeTag
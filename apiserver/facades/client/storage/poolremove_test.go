@@ -0,0 +1,39 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+type poolRemoveSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolRemoveSuite{})
+
+func (s *poolRemoveSuite) TestRemovePools(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New(`storage pool "bar" in use`))
+	results, err := s.apiv5.RemovePools(params.StoragePoolNames{[]params.StoragePoolName{
+		{Name: "foo"}, {Name: "bar"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `storage pool "bar" in use`}},
+	}})
+	s.stub.CheckCallNames(c, getBlockForTypeCall, getBlockForTypeCall, removeStoragePoolCall, removeStoragePoolCall)
+	s.stub.CheckCall(c, 2, removeStoragePoolCall, "foo")
+	s.stub.CheckCall(c, 3, removeStoragePoolCall, "bar")
+}
+
+func (s *poolRemoveSuite) TestRemovePoolsBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestRemovePoolsBlocked")
+	_, err := s.apiv5.RemovePools(params.StoragePoolNames{[]params.StoragePoolName{{Name: "foo"}}})
+	s.assertBlocked(c, err, "TestRemovePoolsBlocked")
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+)
+
+type poolShowSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolShowSuite{})
+
+func (s *poolShowSuite) TestShowPools(c *gc.C) {
+	pool, err := jujustorage.NewConfig("pname", provider.LoopProviderType, map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["pname"] = pool
+	s.registry.Providers["static"] = nil
+
+	results, err := s.apiv5.ShowPools(params.StoragePoolNames{[]params.StoragePoolName{
+		{Name: "pname"}, {Name: "static"}, {Name: "missing"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StoragePoolDetailsResults{[]params.StoragePoolDetailsResult{{
+		Result: &params.StoragePoolDetails{
+			Pool: params.StoragePool{
+				Name:     "pname",
+				Provider: "loop",
+				Attrs:    map[string]interface{}{"foo": "bar"},
+			},
+			StorageTags: []string{"storage-data-0"},
+		},
+	}, {
+		Result: &params.StoragePoolDetails{
+			Pool:        params.StoragePool{Name: "static", Provider: "static"},
+			StorageTags: []string{"storage-data-0"},
+		},
+	}, {
+		Error: &params.Error{
+			Code:    params.CodeNotFound,
+			Message: "mock pool manager: get pool missing not found",
+		},
+	}}})
+	s.stub.CheckCallNames(c, storageInstancesInPoolCall, storageInstancesInPoolCall)
+	s.stub.CheckCall(c, 0, storageInstancesInPoolCall, "pname")
+	s.stub.CheckCall(c, 1, storageInstancesInPoolCall, "static")
+}
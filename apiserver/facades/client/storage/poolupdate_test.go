@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	jujustorage "github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+)
+
+type poolUpdateSuite struct {
+	baseStorageSuite
+}
+
+var _ = gc.Suite(&poolUpdateSuite{})
+
+func (s *poolUpdateSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	pool, err := jujustorage.NewConfig("pname", provider.LoopProviderType, map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.pools["pname"] = pool
+}
+
+func (s *poolUpdateSuite) TestUpdatePools(c *gc.C) {
+	results, err := s.apiv5.UpdatePools(params.StoragePoolArgs{[]params.StoragePool{{
+		Name:  "pname",
+		Attrs: map[string]interface{}{"baz": "qux"},
+	}, {
+		Name:     "pname",
+		Provider: string(provider.TmpfsProviderType),
+	}, {
+		Name: "missing",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{[]params.ErrorResult{
+		{},
+		{},
+		{Error: &params.Error{
+			Code:    params.CodeNotFound,
+			Message: `mock pool manager: get pool missing not found`,
+		}},
+	}})
+	expected, err := jujustorage.NewConfig("pname", provider.TmpfsProviderType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.pools["pname"], jc.DeepEquals, expected)
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolsError(c *gc.C) {
+	s.stub.SetErrors(errors.New("as expected"))
+	results, err := s.apiv5.UpdatePools(params.StoragePoolArgs{[]params.StoragePool{{Name: "pname"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "as expected")
+}
+
+func (s *poolUpdateSuite) TestUpdatePoolsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestUpdatePoolsBlocked")
+	_, err := s.apiv5.UpdatePools(params.StoragePoolArgs{[]params.StoragePool{{Name: "pname"}}})
+	s.assertBlocked(c, err, "TestUpdatePoolsBlocked")
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 )
 
@@ -21,6 +22,19 @@ import (
 // to change any part of it so that it were no longer *obviously* and
 // *trivially* correct, you would be Doing It Wrong.
 
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	v4, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv5{v4}, nil
+}
+
 // NewFacadeV4 provides the signature required for facade registration.
 func NewFacadeV4(
 	st *state.State,
@@ -112,6 +126,29 @@ type storageInterface interface {
 
 	// ReleaseStorageInstance releases the storage instance with the specified tag.
 	ReleaseStorageInstance(names.StorageTag, bool) error
+
+	// ResizeStorageInstance requests that the storage instance with
+	// the specified tag be grown to the specified size, in MiB.
+	ResizeStorageInstance(names.StorageTag, uint64) error
+
+	// CreateStorageInstanceSnapshot requests a snapshot of the volume
+	// backing the storage instance with the specified tag.
+	CreateStorageInstanceSnapshot(names.StorageTag) (state.VolumeSnapshot, error)
+
+	// AllVolumeSnapshots returns all volume snapshots in the model.
+	AllVolumeSnapshots() ([]state.VolumeSnapshot, error)
+
+	// StorageInstancesInPool returns the storage instances provisioned
+	// from the named storage pool.
+	StorageInstancesInPool(string) ([]state.StorageInstance, error)
+
+	// UpdateStoragePool replaces the provider type and attributes of
+	// the named storage pool.
+	UpdateStoragePool(string, storage.ProviderType, map[string]interface{}) error
+
+	// RemoveStoragePool removes the named storage pool, if it is
+	// not in use.
+	RemoveStoragePool(string) error
 }
 
 type storageVolume interface {
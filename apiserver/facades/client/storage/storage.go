@@ -38,6 +38,28 @@ type APIv4 struct {
 	*APIv3
 }
 
+// APIv5 implements the storage v5 API.
+type APIv5 struct {
+	*APIv4
+}
+
+// NewAPIv5 returns a new storage v5 API facade.
+func NewAPIv5(
+	backend backend,
+	storageAccess storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	callContext context.ProviderCallContext,
+) (*APIv5, error) {
+	apiv4, err := NewAPIv4(backend, storageAccess, registry, pm, resources, authorizer, callContext)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv5{apiv4}, nil
+}
+
 // NewAPIv4 returns a new storage v4 API facade.
 func NewAPIv4(
 	backend backend,
@@ -800,9 +822,9 @@ func (a *APIv3) addToUnit(args params.StoragesAddParams) (params.AddStorageResul
 			continue
 		}
 
-		tags, err := a.storageAccess.AddStorageForUnit(
-			u, one.StorageName, paramsToState(one.Constraints),
-		)
+		cons := paramsToState(one.Constraints)
+		cons.Snapshot = one.Snapshot
+		tags, err := a.storageAccess.AddStorageForUnit(u, one.StorageName, cons)
 		if err != nil {
 			result[i].Error = common.ServerError(err)
 		}
@@ -872,6 +894,192 @@ func (a *APIv3) remove(args params.RemoveStorage) (params.ErrorResults, error) {
 	return params.ErrorResults{result}, nil
 }
 
+// Resize requests that the specified storage instances be grown to the
+// specified sizes. The storage is resized by the storage provisioner
+// responsible for it, if its storage provider supports resizing.
+func (a *APIv5) Resize(args params.ResizeStorage) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Storage))
+	for i, arg := range args.Storage {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		result[i].Error = common.ServerError(
+			a.storageAccess.ResizeStorageInstance(tag, arg.Size),
+		)
+	}
+	return params.ErrorResults{result}, nil
+}
+
+// CreateSnapshots requests snapshots of the volumes backing the specified
+// storage instances. The snapshots are taken by the storage provisioner
+// responsible for each volume, if its storage provider supports taking
+// snapshots.
+func (a *APIv5) CreateSnapshots(args params.Entities) (params.VolumeSnapshotDetailsResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.VolumeSnapshotDetailsResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		tag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		snapshot, err := a.storageAccess.CreateStorageInstanceSnapshot(tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		result[i].Result = createVolumeSnapshotDetails(snapshot)
+	}
+	return params.VolumeSnapshotDetailsResults{result}, nil
+}
+
+// ListSnapshots returns the details of all volume snapshots in the model.
+func (a *APIv5) ListSnapshots() (params.VolumeSnapshotDetailsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	snapshots, err := a.storageAccess.AllVolumeSnapshots()
+	if err != nil {
+		return params.VolumeSnapshotDetailsResults{}, errors.Trace(err)
+	}
+	result := make([]params.VolumeSnapshotDetailsResult, len(snapshots))
+	for i, snapshot := range snapshots {
+		result[i].Result = createVolumeSnapshotDetails(snapshot)
+	}
+	return params.VolumeSnapshotDetailsResults{result}, nil
+}
+
+func createVolumeSnapshotDetails(snapshot state.VolumeSnapshot) *params.VolumeSnapshotDetails {
+	details := &params.VolumeSnapshotDetails{
+		Id:        snapshot.Id(),
+		VolumeTag: snapshot.Volume().String(),
+		Pool:      snapshot.Pool(),
+		Created:   snapshot.Created(),
+	}
+	if storageTag, ok := snapshot.Storage(); ok {
+		details.StorageTag = storageTag.String()
+	}
+	if info, err := snapshot.Info(); err == nil {
+		details.Info = &params.VolumeSnapshotInfo{
+			Id:         snapshot.Id(),
+			SnapshotId: info.SnapshotId,
+			Size:       info.Size,
+		}
+	}
+	return details
+}
+
+// UpdatePools replaces the provider type and attributes of existing
+// storage pools. If a pool's provider type is empty, the pool's
+// existing provider type is kept. The provider type of a pool that is
+// in use may not be changed, nor may its attributes unless the
+// provider can apply the change to existing storage.
+func (a *APIv5) UpdatePools(args params.StoragePoolArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Pools))
+	for i, pool := range args.Pools {
+		err := a.storageAccess.UpdateStoragePool(pool.Name, storage.ProviderType(pool.Provider), pool.Attrs)
+		result[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{result}, nil
+}
+
+// RemovePools removes the named storage pools. Pools that are in use
+// by storage constraints or storage may not be removed.
+func (a *APIv5) RemovePools(args params.StoragePoolNames) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Pools))
+	for i, pool := range args.Pools {
+		err := a.storageAccess.RemoveStoragePool(pool.Name)
+		result[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{result}, nil
+}
+
+// ShowPools returns the details of the named storage pools, including
+// the storage instances provisioned from them. A storage provider type
+// may be named in place of a pool.
+func (a *APIv5) ShowPools(args params.StoragePoolNames) (params.StoragePoolDetailsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StoragePoolDetailsResults{}, errors.Trace(err)
+	}
+	result := make([]params.StoragePoolDetailsResult, len(args.Pools))
+	for i, pool := range args.Pools {
+		details, err := a.showPool(pool.Name)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		result[i].Result = details
+	}
+	return params.StoragePoolDetailsResults{result}, nil
+}
+
+func (a *APIv5) showPool(name string) (*params.StoragePoolDetails, error) {
+	var pool params.StoragePool
+	cfg, err := a.poolManager.Get(name)
+	if errors.IsNotFound(err) {
+		// There's no pool with the given name, but it
+		// may be the name of a storage provider type.
+		if _, err1 := a.registry.StorageProvider(storage.ProviderType(name)); err1 != nil {
+			return nil, errors.Trace(err)
+		}
+		pool = params.StoragePool{Name: name, Provider: name}
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else {
+		pool = params.StoragePool{
+			Name:     cfg.Name(),
+			Provider: string(cfg.Provider()),
+			Attrs:    cfg.Attrs(),
+		}
+	}
+	storageInstances, err := a.storageAccess.StorageInstancesInPool(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	details := &params.StoragePoolDetails{Pool: pool}
+	for _, si := range storageInstances {
+		details.StorageTags = append(details.StorageTags, si.StorageTag().String())
+	}
+	return details, nil
+}
+
 // Detach sets the specified storage attachments to Dying, unless they are
 // already Dying or Dead. Any associated, persistent storage will remain
 // alive.
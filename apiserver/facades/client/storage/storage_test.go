@@ -5,6 +5,7 @@ package storage_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -343,6 +344,88 @@ func (s *storageSuite) TestRemove(c *gc.C) {
 	s.stub.CheckCall(c, 4, releaseStorageInstanceCall, names.NewStorageTag("foo/1"), true)
 }
 
+func (s *storageSuite) TestResize(c *gc.C) {
+	results, err := s.apiv5.Resize(params.ResizeStorage{[]params.ResizeStorageInstance{
+		{Tag: "storage-foo-0", Size: 2048},
+		{Tag: "volume-0", Size: 2048},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: &params.Error{Message: "cannae do it"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall, // Change
+		resizeStorageInstanceCall,
+	)
+	s.stub.CheckCall(c, 1, resizeStorageInstanceCall, names.NewStorageTag("foo/0"), uint64(2048))
+}
+
+func (s *storageSuite) TestResizeBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeBlocked")
+	_, err := s.apiv5.Resize(params.ResizeStorage{[]params.ResizeStorageInstance{
+		{Tag: "storage-foo-0", Size: 2048},
+	}})
+	s.assertBlocked(c, err, "TestResizeBlocked")
+}
+
+func (s *storageSuite) TestCreateSnapshots(c *gc.C) {
+	s.stub.SetErrors(nil, errors.New("cannae do it"))
+	results, err := s.apiv5.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+		{Tag: "storage-foo-0"},
+		{Tag: "volume-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{
+		{Result: &params.VolumeSnapshotDetails{
+			Id:         "22@0",
+			VolumeTag:  "volume-22",
+			StorageTag: "storage-data-0",
+			Pool:       "loop",
+			Created:    time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC),
+			Info: &params.VolumeSnapshotInfo{
+				Id:         "22@0",
+				SnapshotId: "snap-22",
+				Size:       1024,
+			},
+		}},
+		{Error: &params.Error{Message: "cannae do it"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	s.stub.CheckCallNames(c,
+		getBlockForTypeCall, // Change
+		createStorageInstanceSnapshotCall,
+		createStorageInstanceSnapshotCall,
+	)
+	s.stub.CheckCall(c, 1, createStorageInstanceSnapshotCall, names.NewStorageTag("data/0"))
+	s.stub.CheckCall(c, 2, createStorageInstanceSnapshotCall, names.NewStorageTag("foo/0"))
+}
+
+func (s *storageSuite) TestCreateSnapshotsBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestCreateSnapshotsBlocked")
+	_, err := s.apiv5.CreateSnapshots(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+	}})
+	s.assertBlocked(c, err, "TestCreateSnapshotsBlocked")
+}
+
+func (s *storageSuite) TestListSnapshots(c *gc.C) {
+	s.volumeSnapshot.info = nil
+	results, err := s.apiv5.ListSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.VolumeSnapshotDetailsResult{
+		{Result: &params.VolumeSnapshotDetails{
+			Id:         "22@0",
+			VolumeTag:  "volume-22",
+			StorageTag: "storage-data-0",
+			Pool:       "loop",
+			Created:    time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC),
+		}},
+	})
+	s.stub.CheckCallNames(c, allVolumeSnapshotsCall)
+}
+
 func (s *storageSuite) TestDestroyV3(c *gc.C) {
 	results, err := s.apiv3.Destroy(params.Entities{[]params.Entity{
 		{Tag: "storage-foo-0"},
@@ -160,6 +160,27 @@ func (s *storageAddSuite) TestStorageAddUnitTags(c *gc.C) {
 	}})
 }
 
+func (s *storageAddSuite) TestStorageAddUnitSnapshot(c *gc.C) {
+	var addedCons state.StorageConstraints
+	s.storageAccessor.addStorageForUnit = func(u names.UnitTag, name string, cons state.StorageConstraints) ([]names.StorageTag, error) {
+		addedCons = cons
+		return nil, nil
+	}
+
+	count := uint64(1)
+	args := params.StorageAddParams{
+		UnitTag:     s.unitTag.String(),
+		StorageName: "data",
+		Constraints: params.StorageConstraints{Count: &count},
+		Snapshot:    "22@0",
+	}
+	s.assertStorageAddedNoErrors(c, args)
+	c.Assert(addedCons, jc.DeepEquals, state.StorageConstraints{
+		Count:    1,
+		Snapshot: "22@0",
+	})
+}
+
 func (s *storageAddSuite) TestStorageAddUnitNotFoundErr(c *gc.C) {
 	msg := "sanity"
 	s.storageAccessor.addStorageForUnit = func(u names.UnitTag, name string, cons state.StorageConstraints) ([]names.StorageTag, error) {
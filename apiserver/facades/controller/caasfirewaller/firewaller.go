@@ -20,6 +20,21 @@ type Facade struct {
 	state     CAASFirewallerState
 }
 
+// FacadeV1 provides the v1 CAAS firewaller API facade.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewStateFacadeV1 provides the signature required for facade registration
+// of the v1 facade.
+func NewStateFacadeV1(ctx facade.Context) (*FacadeV1, error) {
+	facade, err := NewStateFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{facade}, nil
+}
+
 // NewStateFacade provides the signature required for facade registration.
 func NewStateFacade(ctx facade.Context) (*Facade, error) {
 	authorizer := ctx.Auth()
@@ -71,6 +86,20 @@ func (f *Facade) WatchApplications() (params.StringsWatchResult, error) {
 	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
 }
 
+// WatchSubnets starts a StringsWatcher to watch for subnets being added
+// to or removed from this model, which changes the CIDRs of the spaces
+// that applications may be exposed to.
+func (f *Facade) WatchSubnets() (params.StringsWatchResult, error) {
+	watch := f.state.WatchSubnets(nil)
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: f.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
 // IsExposed returns whether the specified applications are exposed.
 func (f *Facade) IsExposed(args params.Entities) (params.BoolResults, error) {
 	results := params.BoolResults{
@@ -99,6 +128,68 @@ func (f *Facade) isExposed(backend CAASFirewallerState, tagString string) (bool,
 	return app.IsExposed(), nil
 }
 
+// GetExposeInfo returns whether the specified applications are exposed,
+// and the settings of their exposed endpoints. The subnets of any spaces
+// an endpoint is exposed to are included in the endpoint's CIDRs.
+//
+// The spaces are resolved when this is called, so the firewaller calls
+// it again whenever WatchSubnets reports a change.
+func (f *Facade) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	results := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		exposed, exposedEndpoints, err := f.exposeInfo(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Exposed = exposed
+		results.Results[i].ExposedEndpoints = exposedEndpoints
+	}
+	return results, nil
+}
+
+// GetExposeInfo isn't on the V1 API.
+func (f *FacadeV1) GetExposeInfo(_, _ struct{}) {}
+
+// WatchSubnets isn't on the V1 API.
+func (f *FacadeV1) WatchSubnets(_, _ struct{}) {}
+
+func (f *Facade) exposeInfo(tagString string) (bool, map[string]params.ExposedEndpoint, error) {
+	tag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return false, nil, errors.Trace(err)
+	}
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return false, nil, errors.Trace(err)
+	}
+	if !app.IsExposed() {
+		return false, nil, nil
+	}
+	exposed := app.ExposedEndpoints()
+	if len(exposed) == 0 {
+		return true, nil, nil
+	}
+	result := make(map[string]params.ExposedEndpoint)
+	for endpoint, details := range exposed {
+		cidrs := append([]string(nil), details.ExposeToCIDRs...)
+		for _, spaceName := range details.ExposeToSpaces {
+			spaceCIDRs, err := f.state.SpaceSubnetCIDRs(spaceName)
+			if err != nil {
+				return false, nil, errors.Annotatef(err, "getting subnets for space %q", spaceName)
+			}
+			cidrs = append(cidrs, spaceCIDRs...)
+		}
+		result[endpoint] = params.ExposedEndpoint{
+			ExposeToSpaces: details.ExposeToSpaces,
+			ExposeToCIDRs:  cidrs,
+		}
+	}
+	return true, result, nil
+}
+
 // ApplicationsConfig returns the config for the specified applications.
 func (f *Facade) ApplicationsConfig(args params.Entities) (params.ApplicationGetConfigResults, error) {
 	results := params.ApplicationGetConfigResults{
@@ -25,6 +25,7 @@ type CAASFirewallerSuite struct {
 
 	st                  *mockState
 	applicationsChanges chan []string
+	subnetsChanges      chan []string
 	appExposedChanges   chan struct{}
 
 	resources  *common.Resources
@@ -36,6 +37,7 @@ func (s *CAASFirewallerSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 
 	s.applicationsChanges = make(chan []string, 1)
+	s.subnetsChanges = make(chan []string, 1)
 	s.appExposedChanges = make(chan struct{}, 1)
 	appExposedWatcher := statetesting.NewMockNotifyWatcher(s.appExposedChanges)
 	s.st = &mockState{
@@ -44,9 +46,11 @@ func (s *CAASFirewallerSuite) SetUpTest(c *gc.C) {
 			watcher: appExposedWatcher,
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
+		subnetsWatcher:      statetesting.NewMockStringsWatcher(s.subnetsChanges),
 		appExposedWatcher:   appExposedWatcher,
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.subnetsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.appExposedWatcher) })
 
 	s.resources = common.NewResources()
@@ -81,6 +85,19 @@ func (s *CAASFirewallerSuite) TestWatchApplications(c *gc.C) {
 	c.Assert(resource, gc.Equals, s.st.applicationsWatcher)
 }
 
+func (s *CAASFirewallerSuite) TestWatchSubnets(c *gc.C) {
+	subnets := []string{"10.0.0.0/24"}
+	s.subnetsChanges <- subnets
+	result, err := s.facade.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Changes, jc.DeepEquals, subnets)
+
+	resource := s.resources.Get("1")
+	c.Assert(resource, gc.Equals, s.st.subnetsWatcher)
+}
+
 func (s *CAASFirewallerSuite) TestWatchApplication(c *gc.C) {
 	s.appExposedChanges <- struct{}{}
 
@@ -123,6 +140,51 @@ func (s *CAASFirewallerSuite) TestIsExposed(c *gc.C) {
 	})
 }
 
+func (s *CAASFirewallerSuite) TestGetExposeInfo(c *gc.C) {
+	s.st.application.exposed = true
+	s.st.application.exposedEndpoints = map[string]state.ExposedEndpoint{
+		"http": {
+			ExposeToSpaces: []string{"dmz"},
+			ExposeToCIDRs:  []string{"10.0.0.0/24"},
+		},
+	}
+	s.st.spaceCIDRs = map[string][]string{"dmz": {"192.168.1.0/24"}}
+	results, err := s.facade.GetExposeInfo(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-gitlab"},
+			{Tag: "unit-gitlab-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{{
+			Exposed: true,
+			ExposedEndpoints: map[string]params.ExposedEndpoint{
+				"http": {
+					ExposeToSpaces: []string{"dmz"},
+					ExposeToCIDRs:  []string{"10.0.0.0/24", "192.168.1.0/24"},
+				},
+			},
+		}, {
+			Error: &params.Error{
+				Message: `"unit-gitlab-0" is not a valid application tag`,
+			},
+		}},
+	})
+	s.st.CheckCall(c, 1, "SpaceSubnetCIDRs", "dmz")
+}
+
+func (s *CAASFirewallerSuite) TestGetExposeInfoNotExposed(c *gc.C) {
+	results, err := s.facade.GetExposeInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{{}},
+	})
+	s.st.application.CheckCallNames(c, "IsExposed")
+}
+
 func (s *CAASFirewallerSuite) TestLife(c *gc.C) {
 	results, err := s.facade.Life(params.Entities{
 		Entities: []params.Entity{
@@ -17,7 +17,17 @@ type mockState struct {
 	testing.Stub
 	application         mockApplication
 	applicationsWatcher *statetesting.MockStringsWatcher
+	subnetsWatcher      *statetesting.MockStringsWatcher
 	appExposedWatcher   *statetesting.MockNotifyWatcher
+	spaceCIDRs          map[string][]string
+}
+
+func (st *mockState) SpaceSubnetCIDRs(spaceName string) ([]string, error) {
+	st.MethodCall(st, "SpaceSubnetCIDRs", spaceName)
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	return st.spaceCIDRs[spaceName], nil
 }
 
 func (st *mockState) WatchApplications() state.StringsWatcher {
@@ -25,6 +35,11 @@ func (st *mockState) WatchApplications() state.StringsWatcher {
 	return st.applicationsWatcher
 }
 
+func (st *mockState) WatchSubnets(func(id interface{}) bool) state.StringsWatcher {
+	st.MethodCall(st, "WatchSubnets")
+	return st.subnetsWatcher
+}
+
 func (st *mockState) Application(name string) (caasfirewaller.Application, error) {
 	st.MethodCall(st, "Application", name)
 	if err := st.NextErr(); err != nil {
@@ -43,9 +58,10 @@ func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 
 type mockApplication struct {
 	testing.Stub
-	life    state.Life
-	exposed bool
-	watcher state.NotifyWatcher
+	life             state.Life
+	exposed          bool
+	exposedEndpoints map[string]state.ExposedEndpoint
+	watcher          state.NotifyWatcher
 }
 
 func (*mockApplication) Tag() names.Tag {
@@ -62,6 +78,11 @@ func (a *mockApplication) IsExposed() bool {
 	return a.exposed
 }
 
+func (a *mockApplication) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	a.MethodCall(a, "ExposedEndpoints")
+	return a.exposedEndpoints
+}
+
 func (a *mockApplication) ApplicationConfig() (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig")
 	return application.ConfigAttributes{"foo": "bar"}, a.NextErr()
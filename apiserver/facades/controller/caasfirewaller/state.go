@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/application"
@@ -16,12 +17,15 @@ type CAASFirewallerState interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 	Application(string) (Application, error)
 	WatchApplications() state.StringsWatcher
+	WatchSubnets(func(id interface{}) bool) state.StringsWatcher
+	SpaceSubnetCIDRs(spaceName string) ([]string, error)
 }
 
 // Application provides the subset of application state
 // required by the CAAS operator facade.
 type Application interface {
 	IsExposed() bool
+	ExposedEndpoints() map[string]state.ExposedEndpoint
 	ApplicationConfig() (application.ConfigAttributes, error)
 	Watch() state.NotifyWatcher
 }
@@ -33,3 +37,19 @@ type stateShim struct {
 func (s stateShim) Application(id string) (Application, error) {
 	return s.State.Application(id)
 }
+
+func (s stateShim) SpaceSubnetCIDRs(spaceName string) ([]string, error) {
+	space, err := s.State.Space(spaceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	subnets, err := space.Subnets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cidrs := make([]string, len(subnets))
+	for i, subnet := range subnets {
+		cidrs[i] = subnet.CIDR()
+	}
+	return cidrs, nil
+}
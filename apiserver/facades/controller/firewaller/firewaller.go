@@ -4,6 +4,8 @@
 package firewaller
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -51,6 +53,11 @@ type FirewallerAPIV5 struct {
 	*FirewallerAPIV4
 }
 
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+}
+
 // NewStateFirewallerAPIV3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -90,6 +97,17 @@ func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{
+		FirewallerAPIV5: facadev5,
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -243,20 +261,35 @@ func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (param
 			continue
 		}
 		if ports != nil {
-			portRangeMap := ports.AllPortRanges()
+			// The same range may be opened for several endpoints.
+			unitRanges := make(map[network.PortRange][]state.PortRange)
 			var portRanges []network.PortRange
-			for portRange := range portRangeMap {
-				portRanges = append(portRanges, portRange)
+			for _, unitRange := range ports.PortRanges() {
+				portRange := network.PortRange{
+					FromPort: unitRange.FromPort,
+					ToPort:   unitRange.ToPort,
+					Protocol: unitRange.Protocol,
+				}
+				if _, ok := unitRanges[portRange]; !ok {
+					portRanges = append(portRanges, portRange)
+				}
+				unitRanges[portRange] = append(unitRanges[portRange], unitRange)
 			}
 			network.SortPortRanges(portRanges)
 
 			for _, portRange := range portRanges {
-				unitTag := names.NewUnitTag(portRangeMap[portRange]).String()
-				result.Results[i].Ports = append(result.Results[i].Ports,
-					params.MachinePortRange{
-						UnitTag:   unitTag,
-						PortRange: params.FromNetworkPortRange(portRange),
-					})
+				opened := unitRanges[portRange]
+				sort.Slice(opened, func(a, b int) bool {
+					return opened[a].Endpoint < opened[b].Endpoint
+				})
+				for _, unitRange := range opened {
+					result.Results[i].Ports = append(result.Results[i].Ports,
+						params.MachinePortRange{
+							UnitTag:   names.NewUnitTag(unitRange.UnitName).String(),
+							PortRange: params.FromNetworkPortRange(portRange),
+							Endpoint:  unitRange.Endpoint,
+						})
+				}
 			}
 		}
 	}
@@ -532,3 +565,79 @@ func (f *FirewallerAPIV5) AreManuallyProvisioned(args params.Entities) (params.B
 	}
 	return result, nil
 }
+
+// GetExposeInfo returns the expose flag and the per-endpoint expose
+// settings for each given application. The subnets of any spaces an
+// endpoint is exposed to are included in the endpoint's CIDRs.
+//
+// The spaces are resolved when this is called, so the firewaller calls
+// it again whenever WatchSubnets reports a change.
+func (f *FirewallerAPIV6) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if !application.IsExposed() {
+			continue
+		}
+		exposedEndpoints, err := f.exposedEndpoints(application.ExposedEndpoints())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Exposed = true
+		result.Results[i].ExposedEndpoints = exposedEndpoints
+	}
+	return result, nil
+}
+
+func (f *FirewallerAPIV6) exposedEndpoints(exposed map[string]state.ExposedEndpoint) (map[string]params.ExposedEndpoint, error) {
+	if len(exposed) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]params.ExposedEndpoint)
+	for endpoint, details := range exposed {
+		cidrs := append([]string(nil), details.ExposeToCIDRs...)
+		for _, spaceName := range details.ExposeToSpaces {
+			spaceCIDRs, err := f.st.SpaceSubnetCIDRs(spaceName)
+			if err != nil {
+				return nil, errors.Annotatef(err, "getting subnets for space %q", spaceName)
+			}
+			cidrs = append(cidrs, spaceCIDRs...)
+		}
+		result[endpoint] = params.ExposedEndpoint{
+			ExposeToSpaces: details.ExposeToSpaces,
+			ExposeToCIDRs:  cidrs,
+		}
+	}
+	return result, nil
+}
+
+// WatchSubnets returns a StringsWatcher that notifies of subnets being
+// added to or removed from the model, which changes the CIDRs of the
+// spaces that applications may be exposed to.
+func (f *FirewallerAPIV6) WatchSubnets() (params.StringsWatchResult, error) {
+	watch := f.st.WatchSubnets(nil)
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: f.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
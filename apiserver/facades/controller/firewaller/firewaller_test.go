@@ -197,6 +197,40 @@ func (s *firewallerSuite) TestGetMachinePorts(c *gc.C) {
 
 }
 
+func (s *firewallerSuite) TestGetMachinePortsWithEndpoints(c *gc.C) {
+	err := s.units[0].OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPortsForEndpoint("logging-dir", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].OpenPort("tcp", 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.MachinePortsParams{
+		Params: []params.MachinePorts{
+			{MachineTag: s.machines[0].Tag().String()},
+		},
+	}
+	unit0Tag := s.units[0].Tag().String()
+	result, err := s.firewaller.GetMachinePorts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MachinePortsResults{
+		Results: []params.MachinePortsResult{{
+			Ports: []params.MachinePortRange{{
+				UnitTag:   unit0Tag,
+				PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				Endpoint:  "logging-dir",
+			}, {
+				UnitTag:   unit0Tag,
+				PortRange: params.PortRange{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+				Endpoint:  "url",
+			}, {
+				UnitTag:   unit0Tag,
+				PortRange: params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			}},
+		}},
+	})
+}
+
 func (s *firewallerSuite) TestGetMachineActiveSubnets(c *gc.C) {
 	s.openPorts(c)
 
@@ -270,3 +304,89 @@ func (s *firewallerSuite) TestAreManuallyProvisioned(c *gc.C) {
 		},
 	})
 }
+
+func (s *firewallerSuite) TestGetExposeInfo(c *gc.C) {
+	_, err := s.State.AddSpace("dmz", "", []string{"10.20.30.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"url": {
+			ExposeToSpaces: []string{"dmz"},
+			ExposeToCIDRs:  []string{"192.168.0.0/16"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+	}})
+	result, err := apiv6.GetExposeInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{
+			{
+				Exposed: true,
+				ExposedEndpoints: map[string]params.ExposedEndpoint{
+					"url": {
+						ExposeToSpaces: []string{"dmz"},
+						ExposeToCIDRs:  []string{"192.168.0.0/16", "10.20.30.0/24"},
+					},
+				},
+			},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.application.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = apiv6.GetExposeInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{{}},
+	})
+}
+
+func (s *firewallerSuite) TestWatchSubnets(c *gc.C) {
+	apiv6 := &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := apiv6.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{"10.20.30.0/24"},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.20.31.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("10.20.31.0/24")
+	wc.AssertNoChange()
+}
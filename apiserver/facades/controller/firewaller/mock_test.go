@@ -120,6 +120,11 @@ func (st *mockState) FirewallRule(service state.WellKnownServiceType) (*state.Fi
 	return r, nil
 }
 
+func (st *mockState) SpaceSubnetCIDRs(spaceName string) ([]string, error) {
+	st.MethodCall(st, "SpaceSubnetCIDRs", spaceName)
+	return nil, errors.NotImplementedf("SpaceSubnetCIDRs")
+}
+
 type mockWatcher struct {
 	testing.Stub
 	tomb.Tomb
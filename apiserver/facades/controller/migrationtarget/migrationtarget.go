@@ -4,6 +4,7 @@
 package migrationtarget
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -73,13 +74,64 @@ func checkAuth(authorizer facade.Authorizer, st *state.State) error {
 	return nil
 }
 
+// APIV1 implements the V1 API of the migration target facade.
+type APIV1 struct {
+	*API
+}
+
+// NewFacadeV1 is used for API registration.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // Prechecks ensure that the target controller is ready to accept a
 // model migration.
 func (api *API) Prechecks(model params.MigrationModelInfo) error {
-	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	backend, modelInfo, err := api.precheckArgs(model)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	return migration.TargetPrecheck(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		api.presence.ModelPresence(api.pool.SystemState().ModelUUID()),
+	)
+}
+
+// CheckMigration reports every reason the target controller would
+// refuse a migration of the model, without changing anything. As
+// well as the prechecks, the serialized model is imported into a
+// temporary model which is removed again.
+func (api *API) CheckMigration(args params.CheckMigrationArgs) (params.StringsResult, error) {
+	backend, modelInfo, err := api.precheckArgs(args.Model)
+	if err != nil {
+		return params.StringsResult{}, errors.Trace(err)
+	}
+	failures, err := migration.TargetPrecheckAll(
+		backend,
+		migration.PoolShim(api.pool),
+		modelInfo,
+		api.presence.ModelPresence(api.pool.SystemState().ModelUUID()),
+	)
+	if err != nil {
+		return params.StringsResult{}, errors.Trace(err)
+	}
+	if err := migration.TrialImportModel(api.pool, args.Bytes); err != nil {
+		failures = append(failures, fmt.Sprintf("importing model: %v", err))
+	}
+	return params.StringsResult{Result: failures}, nil
+}
+
+func (api *API) precheckArgs(model params.MigrationModelInfo) (migration.PrecheckBackend, coremigration.ModelInfo, error) {
+	ownerTag, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return nil, coremigration.ModelInfo{}, errors.Trace(err)
+	}
 	controllerState := api.pool.SystemState()
 	// NOTE (thumper): it isn't clear to me why api.state would be different
 	// from the controllerState as I had thought that the Precheck call was
@@ -87,20 +139,15 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	// controllerState.
 	backend, err := migration.PrecheckShim(api.state, controllerState)
 	if err != nil {
-		return errors.Annotate(err, "creating backend")
+		return nil, coremigration.ModelInfo{}, errors.Annotate(err, "creating backend")
 	}
-	return migration.TargetPrecheck(
-		backend,
-		migration.PoolShim(api.pool),
-		coremigration.ModelInfo{
-			UUID:                   model.UUID,
-			Name:                   model.Name,
-			Owner:                  ownerTag,
-			AgentVersion:           model.AgentVersion,
-			ControllerAgentVersion: model.ControllerAgentVersion,
-		},
-		api.presence.ModelPresence(controllerState.ModelUUID()),
-	)
+	return backend, coremigration.ModelInfo{
+		UUID:                   model.UUID,
+		Name:                   model.Name,
+		Owner:                  ownerTag,
+		AgentVersion:           model.AgentVersion,
+		ControllerAgentVersion: model.ControllerAgentVersion,
+	}, nil
 }
 
 // Import takes a serialized Juju model, deserializes it, and
@@ -270,3 +317,6 @@ func (api *API) CACert() (params.BytesResult, error) {
 	caCert, _ := cfg.CACert()
 	return params.BytesResult{Result: []byte(caCert)}, nil
 }
+
+// CheckMigration isn't on the V1 API.
+func (*APIV1) CheckMigration(_, _ struct{}) {}
@@ -65,6 +65,15 @@ func (st *mockState) ModelUUID() string {
 	return coretesting.ModelTag.Id()
 }
 
+func (st *mockState) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (st *mockState) UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error {
+	st.MethodCall(st, "UpdateControllerForModel", controller, modelUUID)
+	return st.NextErr()
+}
+
 func (st *mockState) AddRelation(eps ...state.Endpoint) (common.Relation, error) {
 	rel := &mockRelation{
 		key: fmt.Sprintf("%v:%v %v:%v", eps[0].ApplicationName, eps[0].Name, eps[1].ApplicationName, eps[1].Name)}
@@ -11,6 +11,7 @@ import (
 	commoncrossmodel "github.com/juju/juju/apiserver/common/crossmodel"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state/watcher"
 )
@@ -23,6 +24,12 @@ type RemoteRelationsAPI struct {
 	authorizer facade.Authorizer
 }
 
+// RemoteRelationsAPIV1 provides v1 of the RemoteRelations API facade,
+// which does not have UpdateControllersForModels.
+type RemoteRelationsAPIV1 struct {
+	*RemoteRelationsAPI
+}
+
 // NewStateRemoteRelationsAPI creates a new server-side RemoteRelationsAPI facade
 // backed by global state.
 func NewStateRemoteRelationsAPI(ctx facade.Context) (*RemoteRelationsAPI, error) {
@@ -34,6 +41,16 @@ func NewStateRemoteRelationsAPI(ctx facade.Context) (*RemoteRelationsAPI, error)
 
 }
 
+// NewStateRemoteRelationsAPIV1 creates a new server-side RemoteRelationsAPIV1
+// facade backed by global state.
+func NewStateRemoteRelationsAPIV1(ctx facade.Context) (*RemoteRelationsAPIV1, error) {
+	api, err := NewStateRemoteRelationsAPI(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &RemoteRelationsAPIV1{api}, nil
+}
+
 // NewRemoteRelationsAPI returns a new server-side RemoteRelationsAPI facade.
 func NewRemoteRelationsAPI(
 	st RemoteRelationsState,
@@ -419,3 +436,43 @@ func (f *RemoteRelationsAPI) SetRemoteApplicationsStatus(args params.SetStatus)
 	}
 	return result, nil
 }
+
+// UpdateControllersForModels records the controllers now hosting the
+// specified remote models. It is used when a model consumed by this
+// model has been migrated to another controller.
+func (api *RemoteRelationsAPI) UpdateControllersForModels(args params.UpdateControllersForModelsParams) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Changes)),
+	}
+	for i, change := range args.Changes {
+		if err := api.updateControllerForModel(change); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
+func (api *RemoteRelationsAPI) updateControllerForModel(change params.UpdateControllerForModel) error {
+	modelTag, err := names.ParseModelTag(change.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerTag, err := names.ParseControllerTag(change.Info.ControllerTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// A model migrated to this controller is found locally, so there
+	// is no external controller to record.
+	if controllerTag.Id() == api.st.ControllerTag().Id() {
+		return nil
+	}
+	return api.st.UpdateControllerForModel(crossmodel.ControllerInfo{
+		ControllerTag: controllerTag,
+		Alias:         change.Info.Alias,
+		Addrs:         change.Info.Addrs,
+		CACert:        change.Info.CACert,
+	}, modelTag.Id())
+}
+
+// UpdateControllersForModels isn't on the V1 API.
+func (api *RemoteRelationsAPIV1) UpdateControllersForModels(_, _ struct{}) {}
@@ -426,3 +426,42 @@ func (s *remoteRelationsSuite) TestSetRemoteApplicationsStatus(c *gc.C) {
 	c.Assert(remoteApp.status, gc.Equals, status.Blocked)
 	c.Assert(remoteApp.message, gc.Equals, "a message")
 }
+
+func (s *remoteRelationsSuite) TestUpdateControllersForModels(c *gc.C) {
+	controllerTag := names.NewControllerTag("badf00d1-dead-beef-0000-deadbeef0000")
+	result, err := s.api.UpdateControllersForModels(params.UpdateControllersForModelsParams{
+		Changes: []params.UpdateControllerForModel{{
+			ModelTag: coretesting.ModelTag.String(),
+			Info: params.ExternalControllerInfo{
+				ControllerTag: controllerTag.String(),
+				Alias:         "target",
+				Addrs:         []string{"10.0.0.1:17070"},
+				CACert:        coretesting.CACert,
+			},
+		}, {
+			// The model was migrated to this controller.
+			ModelTag: coretesting.ModelTag.String(),
+			Info: params.ExternalControllerInfo{
+				ControllerTag: coretesting.ControllerTag.String(),
+			},
+		}, {
+			ModelTag: "bad-tag",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
+	s.st.CheckCalls(c, []testing.StubCall{
+		{"UpdateControllerForModel", []interface{}{
+			crossmodel.ControllerInfo{
+				ControllerTag: controllerTag,
+				Alias:         "target",
+				Addrs:         []string{"10.0.0.1:17070"},
+				CACert:        coretesting.CACert,
+			},
+			coretesting.ModelTag.Id(),
+		}},
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/macaroon.v2-unstable"
 
 	common "github.com/juju/juju/apiserver/common/crossmodel"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/state"
 )
 
@@ -38,6 +39,10 @@ type RemoteRelationsState interface {
 
 	// SaveMacaroon saves the given macaroon for the specified entity.
 	SaveMacaroon(entity names.Tag, mac *macaroon.Macaroon) error
+
+	// UpdateControllerForModel records that the specified model is now
+	// hosted by the given external controller.
+	UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
@@ -62,6 +67,10 @@ func (st stateShim) SaveMacaroon(entity names.Tag, mac *macaroon.Macaroon) error
 	return r.SaveMacaroon(entity, mac)
 }
 
+func (st stateShim) UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error {
+	return state.NewExternalControllers(st.st).SaveAndMoveModels(controller, modelUUID)
+}
+
 func (st stateShim) WatchRemoteApplications() state.StringsWatcher {
 	return st.st.WatchRemoteApplications()
 }
@@ -113,9 +113,13 @@ func (l *bufferedLog) flush() error {
 // interesting if it's a call to a method that isn't listed. If one of
 // the entries is "ReadOnlyMethods", any method matching the fixed
 // list of read-only methods below will also be considered
-// uninteresting.
+// uninteresting. Calls to facades that are always audited are
+// interesting regardless of the methods excluded.
 func MakeInterestingRequestFilter(excludeMethods set.Strings) func(auditlog.Request) bool {
 	return func(req auditlog.Request) bool {
+		if alwaysAuditedFacades.Contains(req.Facade) {
+			return true
+		}
 		methodName := fmt.Sprintf("%s.%s", req.Facade, req.Method)
 		if excludeMethods.Contains(methodName) {
 			return false
@@ -127,6 +131,13 @@ func MakeInterestingRequestFilter(excludeMethods set.Strings) func(auditlog.Requ
 	}
 }
 
+// alwaysAuditedFacades lists the facades whose calls are always
+// recorded in the audit log, because they give access to the inner
+// workings of agents.
+var alwaysAuditedFacades = set.NewStrings(
+	"Introspection",
+)
+
 var readonlyMethods = set.NewStrings(
 	// Collected by running read-only commands.
 	"Action.Actions",
@@ -140,6 +151,7 @@ var readonlyMethods = set.NewStrings(
 	"Client.GetModelConstraints",
 	"Client.StatusHistory",
 	"Controller.AllModels",
+	"Controller.BackupScheduleStatus",
 	"Controller.ControllerConfig",
 	"Controller.GetControllerAccess",
 	"Controller.ModelConfig",
@@ -95,3 +95,8 @@ func (s *auditFilterSuite) TestOnlyExcludeReadonlyMethodsIfWeShould(c *gc.C) {
 	// Doesn't allow the readonly methods unless they've included the special key.
 	c.Assert(f1(auditlog.Request{Facade: "Client", Method: "FullStatus"}), jc.IsTrue)
 }
+
+func (s *auditFilterSuite) TestAlwaysAuditsIntrospection(c *gc.C) {
+	f1 := observer.MakeInterestingRequestFilter(set.NewStrings("ReadOnlyMethods", "Introspection.Introspect"))
+	c.Assert(f1(auditlog.Request{Facade: "Introspection", Method: "Introspect"}), jc.IsTrue)
+}
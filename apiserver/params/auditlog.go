@@ -0,0 +1,53 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AuditLogQueryArgs holds the filter for an audit log query. Fields
+// left empty match every entry.
+type AuditLogQueryArgs struct {
+	Who        string     `json:"who,omitempty"`
+	Model      string     `json:"model,omitempty"`
+	Facade     string     `json:"facade,omitempty"`
+	Method     string     `json:"method,omitempty"`
+	After      *time.Time `json:"after,omitempty"`
+	Before     *time.Time `json:"before,omitempty"`
+	ErrorsOnly bool       `json:"errors-only,omitempty"`
+	Limit      int        `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds an API request recorded in a controller's
+// audit log, along with the conversation it was part of.
+type AuditLogEntry struct {
+	ControllerMachine string          `json:"controller-machine"`
+	Who               string          `json:"who"`
+	What              string          `json:"what"`
+	ModelName         string          `json:"model-name"`
+	ModelUUID         string          `json:"model-uuid"`
+	ConversationID    string          `json:"conversation-id"`
+	ConnectionID      string          `json:"connection-id"`
+	RequestID         uint64          `json:"request-id"`
+	When              time.Time       `json:"when"`
+	Facade            string          `json:"facade"`
+	Method            string          `json:"method"`
+	Version           int             `json:"version"`
+	Args              string          `json:"args,omitempty"`
+	Errors            []AuditLogError `json:"errors,omitempty"`
+}
+
+// AuditLogError holds an error returned in response to an audited
+// API request.
+type AuditLogError struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// AuditLogQueryResult holds the entries matching an audit log query,
+// ordered by time. Controller machines that could not be queried are
+// reported in Unavailable.
+type AuditLogQueryResult struct {
+	Entries     []AuditLogEntry `json:"entries"`
+	Unavailable []string        `json:"unavailable,omitempty"`
+}
@@ -61,14 +61,15 @@ type BackupsMetadataResult struct {
 	Size           int64     `json:"size"`
 	Stored         time.Time `json:"stored"` // May be zero...
 
-	Started  time.Time      `json:"started"`
-	Finished time.Time      `json:"finished"` // May be zero...
-	Notes    string         `json:"notes"`
-	Model    string         `json:"model"`
-	Machine  string         `json:"machine"`
-	Hostname string         `json:"hostname"`
-	Version  version.Number `json:"version"`
-	Series   string         `json:"series"`
+	Started   time.Time      `json:"started"`
+	Finished  time.Time      `json:"finished"` // May be zero...
+	Notes     string         `json:"notes"`
+	Scheduled bool           `json:"scheduled,omitempty"`
+	Model     string         `json:"model"`
+	Machine   string         `json:"machine"`
+	Hostname  string         `json:"hostname"`
+	Version   version.Number `json:"version"`
+	Series    string         `json:"series"`
 
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
@@ -80,3 +81,15 @@ type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
 }
+
+// BackupScheduleStatus holds the schedule and outcome of the
+// controller's scheduled backups.
+type BackupScheduleStatus struct {
+	Schedule     string     `json:"schedule,omitempty"`
+	MaxCount     int        `json:"max-count,omitempty"`
+	MaxAge       string     `json:"max-age,omitempty"`
+	LastAttempt  *time.Time `json:"last-attempt,omitempty"`
+	LastSuccess  *time.Time `json:"last-success,omitempty"`
+	LastBackupID string     `json:"last-backup-id,omitempty"`
+	LastError    string     `json:"last-error,omitempty"`
+}
@@ -33,6 +33,19 @@ type SetExternalControllerInfoParams struct {
 	Info ExternalControllerInfo `json:"info"`
 }
 
+// UpdateControllersForModelsParams contains the parameters for recording
+// that a set of remote models are now hosted by different controllers.
+type UpdateControllersForModelsParams struct {
+	Changes []UpdateControllerForModel `json:"changes"`
+}
+
+// UpdateControllerForModel contains the details of the controller
+// now hosting a remote model.
+type UpdateControllerForModel struct {
+	ModelTag string                 `json:"model-tag"`
+	Info     ExternalControllerInfo `json:"info"`
+}
+
 // EndpointFilterAttributes is used to filter offers matching the
 // specified endpoint criteria.
 type EndpointFilterAttributes struct {
@@ -366,6 +366,30 @@ type EntityWorkloadVersions struct {
 	Entities []EntityWorkloadVersion `json:"entities"`
 }
 
+// EntityCharmState holds the charm state for an entity.
+type EntityCharmState struct {
+	Tag        string            `json:"tag"`
+	CharmState map[string]string `json:"charm-state"`
+}
+
+// EntityCharmStates holds the parameters for setting the charm
+// state for a set of entities.
+type EntityCharmStates struct {
+	Entities []EntityCharmState `json:"entities"`
+}
+
+// CharmStateResult holds the charm state of an entity or an error.
+type CharmStateResult struct {
+	Error      *Error            `json:"error,omitempty"`
+	CharmState map[string]string `json:"charm-state"`
+}
+
+// CharmStateResults holds the result of an API call that returns
+// the charm state for multiple entities.
+type CharmStateResults struct {
+	Results []CharmStateResult `json:"results"`
+}
+
 // BytesResult holds the result of an API call that returns a slice
 // of bytes.
 type BytesResult struct {
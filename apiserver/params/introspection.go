@@ -0,0 +1,69 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// IntrospectArgs holds the introspection calls to make on agents.
+type IntrospectArgs struct {
+	Args []IntrospectArg `json:"args"`
+}
+
+// IntrospectArg identifies an agent, by machine or unit tag, and the
+// introspection path to request from it, such as "/depengine".
+type IntrospectArg struct {
+	Tag  string `json:"tag"`
+	Path string `json:"path"`
+}
+
+// IntrospectResults holds the results of introspection calls.
+type IntrospectResults struct {
+	Results []IntrospectResult `json:"results"`
+}
+
+// IntrospectResult holds the output of an agent's introspection
+// handler, or the error encountered running it. The output is kept as
+// bytes, as handlers such as the pprof ones produce binary data.
+type IntrospectResult struct {
+	Output []byte `json:"output,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// IntrospectionRequestIds holds the ids of introspection requests
+// reported to an agent by its introspection request watcher.
+type IntrospectionRequestIds struct {
+	Ids []string `json:"ids"`
+}
+
+// IntrospectionRequest holds the details an agent needs to handle
+// an introspection request.
+type IntrospectionRequest struct {
+	Id        string `json:"id"`
+	Path      string `json:"path"`
+	Completed bool   `json:"completed"`
+}
+
+// IntrospectionRequestResults holds introspection requests.
+type IntrospectionRequestResults struct {
+	Results []IntrospectionRequestResult `json:"results"`
+}
+
+// IntrospectionRequestResult holds an introspection request, or the
+// error encountered getting it.
+type IntrospectionRequestResult struct {
+	Result *IntrospectionRequest `json:"result,omitempty"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// IntrospectionResponses holds the responses of an agent to
+// introspection requests.
+type IntrospectionResponses struct {
+	Responses []IntrospectionResponse `json:"responses"`
+}
+
+// IntrospectionResponse holds the output of the introspection handler
+// run for a request, or the error encountered running it.
+type IntrospectionResponse struct {
+	Id     string `json:"id"`
+	Output []byte `json:"output"`
+	Error  string `json:"error,omitempty"`
+}
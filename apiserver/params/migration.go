@@ -52,6 +52,28 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// CheckMigrationResults is used to return the result of checking
+// whether one or more model migrations are likely to succeed.
+type CheckMigrationResults struct {
+	Results []CheckMigrationResult `json:"results"`
+}
+
+// CheckMigrationResult is used to return the result of checking one
+// model migration. Failures holds every unmet precondition for the
+// migration; Error is set if the checks could not be run.
+type CheckMigrationResult struct {
+	ModelTag string   `json:"model-tag"`
+	Failures []string `json:"failures,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
+// CheckMigrationArgs holds the details of a model to be checked by
+// the target controller of a migration, without importing it.
+type CheckMigrationArgs struct {
+	Model MigrationModelInfo `json:"model"`
+	Bytes []byte             `json:"bytes"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
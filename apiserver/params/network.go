@@ -269,6 +269,10 @@ type EntityPortRange struct {
 	Protocol string `json:"protocol"`
 	FromPort int    `json:"from-port"`
 	ToPort   int    `json:"to-port"`
+
+	// Endpoint is the name of the endpoint the port range is opened
+	// or closed for. If empty, it applies to all endpoints.
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // EntitiesPortRanges holds the parameters for making an OpenPorts or
@@ -441,6 +445,10 @@ type MachinePortRange struct {
 	UnitTag     string    `json:"unit-tag"`
 	RelationTag string    `json:"relation-tag"`
 	PortRange   PortRange `json:"port-range"`
+
+	// Endpoint is the name of the endpoint the port range is opened
+	// for. If empty, it is opened for all endpoints.
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // MachinePorts holds a machine and subnet tags. It's used when referring to
@@ -267,6 +267,7 @@ type ApplicationDeploy struct {
 	Storage          map[string]storage.Constraints `json:"storage,omitempty"`
 	Devices          map[string]devices.Constraints `json:"devices,omitempty"`
 	AttachStorage    []string                       `json:"attach-storage,omitempty"`
+	AttachSnapshots  []string                       `json:"attach-snapshots,omitempty"`
 	EndpointBindings map[string]string              `json:"endpoint-bindings,omitempty"`
 	Resources        map[string]string              `json:"resources,omitempty"`
 }
@@ -390,6 +391,34 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints holds the expose settings for the endpoints to
+	// be exposed, keyed by endpoint name; the empty name means all
+	// endpoints. If empty, all endpoints are exposed to 0.0.0.0/0.
+	// This field is only understood by Application facade version 9
+	// and greater.
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint describes where the ports opened for an exposed
+// application endpoint may be accessed from. An endpoint with no
+// spaces or CIDRs is accessible from 0.0.0.0/0.
+type ExposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
+}
+
+// ExposeInfoResults holds the expose settings of a set of applications.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
+}
+
+// ExposeInfoResult holds whether an application is exposed, and the
+// settings of its exposed endpoints.
+type ExposeInfoResult struct {
+	Exposed          bool                       `json:"exposed,omitempty"`
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+	Error            *Error                     `json:"error,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -455,6 +484,12 @@ type ApplicationCharmRelationsResults struct {
 // ApplicationUnexpose holds parameters for the application Unexpose call.
 type ApplicationUnexpose struct {
 	ApplicationName string `json:"application"`
+
+	// ExposedEndpoints holds the endpoints whose expose settings are
+	// to be removed. If empty, the application is unexposed entirely.
+	// This field is only understood by Application facade version 9
+	// and greater.
+	ExposedEndpoints []string `json:"exposed-endpoints,omitempty"`
 }
 
 // ApplicationMetricCredential holds parameters for the SetApplicationCredentials call.
@@ -898,6 +933,10 @@ type RedirectInfoResult struct {
 	// TODO(rogpeppe) allow this to be empty if the
 	// server has a globally trusted certificate?
 	CACert string `json:"ca-cert"`
+
+	// ControllerTag holds the tag of the controller hosting
+	// the model, if known.
+	ControllerTag string `json:"controller-tag,omitempty"`
 }
 
 // ReauthRequest holds a challenge/response token meaningful to the identity
@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -94,6 +98,10 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+
+	// Size is the size, in MiB, of the volume backing the storage,
+	// if there is one and it has been provisioned.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -216,6 +224,10 @@ type VolumeParams struct {
 	Attributes map[string]interface{}  `json:"attributes,omitempty"`
 	Tags       map[string]string       `json:"tags,omitempty"`
 	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+
+	// SnapshotId, if non-empty, is the storage provider's unique ID
+	// for the snapshot from which the volume should be restored.
+	SnapshotId string `json:"snapshot-id,omitempty"`
 }
 
 // RemoveVolumeParams holds the parameters for destroying or releasing a
@@ -232,6 +244,101 @@ type RemoveVolumeParams struct {
 	Destroy bool `json:"destroy,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for growing a provisioned
+// storage volume.
+type VolumeResizeParams struct {
+	VolumeTag string `json:"volume-tag"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// Size is the size, in MiB, that the volume should be grown to.
+	Size uint64 `json:"size"`
+}
+
+// VolumeSnapshotIds holds the IDs of volume snapshots.
+type VolumeSnapshotIds struct {
+	Ids []string `json:"ids"`
+}
+
+// VolumeSnapshotParams holds the parameters for taking a snapshot
+// of a provisioned storage volume.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID assigned by Juju for the snapshot.
+	Id string `json:"id"`
+
+	VolumeTag string `json:"volume-tag"`
+
+	// VolumeId is the storage provider's unique ID for the volume.
+	VolumeId string `json:"volume-id"`
+
+	// Provider is the storage provider that manages the volume.
+	Provider string `json:"provider"`
+
+	// Size is the size of the volume, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// VolumeSnapshotInfo holds information about a volume snapshot
+// that has been taken.
+type VolumeSnapshotInfo struct {
+	// Id is the unique ID assigned by Juju for the snapshot.
+	Id string `json:"id"`
+
+	// SnapshotId is the storage provider's unique ID for the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// Size is the size of the volume from which the snapshot was
+	// taken, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// VolumeSnapshotInfos holds information about volume snapshots.
+type VolumeSnapshotInfos struct {
+	Snapshots []VolumeSnapshotInfo `json:"snapshots"`
+}
+
+// VolumeSnapshotDetails describes a volume snapshot.
+type VolumeSnapshotDetails struct {
+	// Id is the unique ID assigned by Juju for the snapshot.
+	Id string `json:"id"`
+
+	// VolumeTag is the tag of the volume that the snapshot
+	// was taken from.
+	VolumeTag string `json:"volume-tag"`
+
+	// StorageTag is the tag of the storage instance that the
+	// volume was assigned to, if any.
+	StorageTag string `json:"storage-tag,omitempty"`
+
+	// Pool is the name of the storage pool that the volume
+	// was provisioned from.
+	Pool string `json:"pool"`
+
+	// Created is the time at which the snapshot was requested.
+	Created time.Time `json:"created"`
+
+	// Info holds information about the snapshot once it has
+	// been taken; it is nil until then.
+	Info *VolumeSnapshotInfo `json:"info,omitempty"`
+}
+
+// VolumeSnapshotDetailsResult holds the details of a volume snapshot,
+// or an error.
+type VolumeSnapshotDetailsResult struct {
+	Result *VolumeSnapshotDetails `json:"result,omitempty"`
+	Error  *Error                 `json:"error,omitempty"`
+}
+
+// VolumeSnapshotDetailsResults holds the details of multiple volume
+// snapshots.
+type VolumeSnapshotDetailsResults struct {
+	Results []VolumeSnapshotDetailsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParams holds the parameters for creating a volume
 // attachment.
 type VolumeAttachmentParams struct {
@@ -301,6 +408,30 @@ type RemoveVolumeParamsResults struct {
 	Results []RemoveVolumeParamsResult `json:"results,omitempty"`
 }
 
+// VolumeResizeParamsResult holds parameters for resizing a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds parameters for resizing multiple volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
+// VolumeSnapshotParamsResult holds parameters for taking a snapshot
+// of a volume.
+type VolumeSnapshotParamsResult struct {
+	Result VolumeSnapshotParams `json:"result"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// VolumeSnapshotParamsResults holds parameters for taking snapshots
+// of multiple volumes.
+type VolumeSnapshotParamsResults struct {
+	Results []VolumeSnapshotParamsResult `json:"results,omitempty"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -549,6 +680,43 @@ type StoragePool struct {
 	Attrs map[string]interface{} `json:"attrs"`
 }
 
+// StoragePoolArgs holds a collection of storage pools.
+type StoragePoolArgs struct {
+	Pools []StoragePool `json:"pools"`
+}
+
+// StoragePoolName holds the name of a storage pool.
+type StoragePoolName struct {
+	Name string `json:"name"`
+}
+
+// StoragePoolNames holds a collection of storage pool names.
+type StoragePoolNames struct {
+	Pools []StoragePoolName `json:"pools"`
+}
+
+// StoragePoolDetails holds a storage pool, and the storage instances
+// that have been or will be provisioned from it.
+type StoragePoolDetails struct {
+	Pool StoragePool `json:"pool"`
+
+	// StorageTags are the tags of the storage instances in the pool.
+	StorageTags []string `json:"storage-tags,omitempty"`
+}
+
+// StoragePoolDetailsResult holds the details of a storage pool, or
+// an error.
+type StoragePoolDetailsResult struct {
+	Result *StoragePoolDetails `json:"result,omitempty"`
+	Error  *Error              `json:"error,omitempty"`
+}
+
+// StoragePoolDetailsResults holds a collection of storage pool details
+// results.
+type StoragePoolDetailsResults struct {
+	Results []StoragePoolDetailsResult `json:"results,omitempty"`
+}
+
 // StoragePoolFilter holds a filter for matching storage pools.
 type StoragePoolFilter struct {
 	// Names are pool's names to filter on.
@@ -785,6 +953,10 @@ type StorageAddParams struct {
 
 	// Constraints are specified storage constraints.
 	Constraints StorageConstraints `json:"storage"`
+
+	// Snapshot, if non-empty, is the ID of the volume snapshot
+	// from which the storage should be restored.
+	Snapshot string `json:"snapshot,omitempty"`
 }
 
 // StoragesAddParams holds storage details to add to units dynamically.
@@ -813,6 +985,22 @@ type RemoveStorageInstance struct {
 	DestroyStorage bool `json:"destroy-storage,omitempty"`
 }
 
+// ResizeStorage holds the parameters for resizing storage in the model.
+type ResizeStorage struct {
+	Storage []ResizeStorageInstance `json:"storage"`
+}
+
+// ResizeStorageInstance holds the parameters for resizing a storage
+// instance.
+type ResizeStorageInstance struct {
+	// Tag is the tag of the storage instance to be resized.
+	Tag string `json:"tag"`
+
+	// Size is the new size of the storage instance, in MiB. It
+	// must be larger than the storage instance's current size.
+	Size uint64 `json:"size"`
+}
+
 // BulkImportStorageParams contains the parameters for importing a collection
 // of storage entities.
 type BulkImportStorageParams struct {
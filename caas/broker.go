@@ -119,7 +119,8 @@ type Broker interface {
 	DeleteService(appName string) error
 
 	// ExposeService sets up external access to the specified service.
-	ExposeService(appName string, config application.ConfigAttributes) error
+	// If sourceCIDRs is not empty, access is only allowed from those CIDRs.
+	ExposeService(appName string, config application.ConfigAttributes, sourceCIDRs []string) error
 
 	// UnexposeService removes external access to the specified service.
 	UnexposeService(appName string) error
@@ -137,6 +138,85 @@ type Broker interface {
 	storage.ProviderRegistry
 }
 
+// ControllerBroker is implemented by brokers which are able to run a
+// Juju controller in the container substrate itself, so that no
+// machine cloud is needed to host it.
+type ControllerBroker interface {
+	Broker
+
+	// BootstrapController creates the storage, secrets, service and
+	// stateful set which run a Juju controller in this broker's
+	// namespace, and returns the service through which clients reach
+	// the controller's API.
+	BootstrapController(params ControllerBootstrapParams) (*Service, error)
+}
+
+// ControllerBootstrapParams holds the parameters used to create a
+// Juju controller in a container substrate.
+type ControllerBootstrapParams struct {
+	// ControllerUUID is the UUID of the controller being created.
+	ControllerUUID string
+
+	// OperatorImagePath is the docker registry URL of the jujud image.
+	OperatorImagePath string
+
+	// MongoImagePath is the docker registry URL of the mongod image.
+	MongoImagePath string
+
+	// Version is the Juju version of the controller.
+	Version version.Number
+
+	// APIPort is the port on which the controller serves its API.
+	APIPort int
+
+	// StatePort is the port on which the controller's database listens.
+	StatePort int
+
+	// StorageSize is the size, in MiB, of the volume holding the
+	// controller's database and agent state.
+	StorageSize uint64
+
+	// AgentConf is the contents of the controller agent's agent.conf file.
+	AgentConf []byte
+
+	// BootstrapParams is the contents of the bootstrap-params file
+	// passed to "jujud bootstrap-state".
+	BootstrapParams []byte
+
+	// ServerPEM is the controller's certificate and private key.
+	ServerPEM string
+
+	// SharedSecret is the key used to authenticate the members of
+	// the controller's database replica set.
+	SharedSecret string
+}
+
+// Validate returns an error if the parameters are not complete.
+func (p ControllerBootstrapParams) Validate() error {
+	if p.ControllerUUID == "" {
+		return errors.NotValidf("missing controller UUID")
+	}
+	if p.OperatorImagePath == "" {
+		return errors.NotValidf("missing operator image path")
+	}
+	if p.MongoImagePath == "" {
+		return errors.NotValidf("missing mongo image path")
+	}
+	if p.APIPort <= 0 || p.StatePort <= 0 {
+		return errors.NotValidf("API port %d, state port %d", p.APIPort, p.StatePort)
+	}
+	if len(p.AgentConf) == 0 {
+		return errors.NotValidf("missing agent config")
+	}
+	if len(p.BootstrapParams) == 0 {
+		return errors.NotValidf("missing bootstrap params")
+	}
+	if p.ServerPEM == "" || p.SharedSecret == "" {
+		return errors.NotValidf("missing controller secrets")
+	}
+	return nil
+}
+
 // Service represents information about the status of a caas service entity.
 type Service struct {
 	Id        string
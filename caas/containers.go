@@ -59,8 +59,44 @@ type ContainerSpec struct {
 // a pod on the CAAS substrate.
 type PodSpec struct {
 	Containers                []ContainerSpec            `yaml:"-"`
+	InitContainers            []ContainerSpec            `yaml:"-"`
 	OmitServiceFrontend       bool                       `yaml:"omitServiceFrontend"`
 	CustomResourceDefinitions []CustomResourceDefinition `yaml:"customResourceDefinition,omitempty"`
+	CustomResources           []CustomResource           `yaml:"-"`
+	ServiceAccount            *ServiceAccountSpec        `yaml:"serviceAccount,omitempty"`
+	ConfigMaps                map[string]ConfigMap       `yaml:"configMaps,omitempty"`
+	Secrets                   []Secret                   `yaml:"secrets,omitempty"`
+}
+
+// ServiceAccountSpec defines the service account the application's pods
+// run as, and the roles granted to it.
+type ServiceAccountSpec struct {
+	AutomountServiceAccountToken *bool  `yaml:"automountServiceAccountToken,omitempty"`
+	Roles                        []Role `yaml:"roles,omitempty"`
+}
+
+// Role defines a set of permissions granted to the application's
+// service account.
+type Role struct {
+	Name  string       `yaml:"name"`
+	Rules []PolicyRule `yaml:"rules"`
+}
+
+// PolicyRule defines the verbs permitted on a set of resources.
+type PolicyRule struct {
+	APIGroups     []string `yaml:"apiGroups,omitempty"`
+	Resources     []string `yaml:"resources,omitempty"`
+	ResourceNames []string `yaml:"resourceNames,omitempty"`
+	Verbs         []string `yaml:"verbs"`
+}
+
+// ConfigMap defines the key/value data of a config map.
+type ConfigMap map[string]string
+
+// Secret defines an opaque secret holding key/value data.
+type Secret struct {
+	Name string            `yaml:"name"`
+	Data map[string]string `yaml:"data"`
 }
 
 // CustomResourceDefinitionValidation defines the custom resource definition validation schema.
@@ -77,6 +113,14 @@ type CustomResourceDefinition struct {
 	Validation CustomResourceDefinitionValidation `yaml:"validation,omitempty"`
 }
 
+// CustomResource defines an instance of one of the custom resource
+// definitions declared in the pod spec.
+type CustomResource struct {
+	Kind string                 `yaml:"kind" json:"kind"`
+	Name string                 `yaml:"name" json:"name"`
+	Spec map[string]interface{} `yaml:"spec,omitempty" json:"spec,omitempty"`
+}
+
 // Validate returns an error if the crd is not valid.
 func (crd *CustomResourceDefinition) Validate() error {
 	if crd.Kind == "" {
@@ -94,6 +138,48 @@ func (crd *CustomResourceDefinition) Validate() error {
 	return nil
 }
 
+// Validate returns an error if the custom resource is not valid.
+func (cr *CustomResource) Validate() error {
+	if cr.Kind == "" {
+		return errors.NotValidf("missing custom resource kind")
+	}
+	if cr.Name == "" {
+		return errors.NotValidf("missing name for custom resource of kind %q", cr.Kind)
+	}
+	return nil
+}
+
+// Validate returns an error if the service account is not valid.
+func (sa *ServiceAccountSpec) Validate() error {
+	roleNames := make(map[string]bool)
+	for _, role := range sa.Roles {
+		if role.Name == "" {
+			return errors.NotValidf("missing role name")
+		}
+		if roleNames[role.Name] {
+			return errors.NotValidf("duplicate role name %q", role.Name)
+		}
+		roleNames[role.Name] = true
+		if len(role.Rules) == 0 {
+			return errors.NotValidf("role %q with no rules", role.Name)
+		}
+		for _, rule := range role.Rules {
+			if len(rule.Verbs) == 0 {
+				return errors.NotValidf("rule with no verbs in role %q", role.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the secret is not valid.
+func (secret *Secret) Validate() error {
+	if secret.Name == "" {
+		return errors.NotValidf("missing secret name")
+	}
+	return nil
+}
+
 // Validate returns an error if the spec is not valid.
 func (spec *PodSpec) Validate() error {
 	for _, c := range spec.Containers {
@@ -101,10 +187,51 @@ func (spec *PodSpec) Validate() error {
 			return errors.Trace(err)
 		}
 	}
+	for _, c := range spec.InitContainers {
+		if err := c.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	kinds := make(map[string]bool)
 	for _, crd := range spec.CustomResourceDefinitions {
 		if err := crd.Validate(); err != nil {
 			return errors.Trace(err)
 		}
+		kinds[crd.Kind] = true
+	}
+	resourceNames := make(map[string]bool)
+	for _, cr := range spec.CustomResources {
+		if err := cr.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if !kinds[cr.Kind] {
+			return errors.NotValidf("custom resource %q of undeclared kind %q", cr.Name, cr.Kind)
+		}
+		key := cr.Kind + "/" + cr.Name
+		if resourceNames[key] {
+			return errors.NotValidf("duplicate custom resource %q of kind %q", cr.Name, cr.Kind)
+		}
+		resourceNames[key] = true
+	}
+	if spec.ServiceAccount != nil {
+		if err := spec.ServiceAccount.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for name := range spec.ConfigMaps {
+		if name == "" {
+			return errors.NotValidf("missing config map name")
+		}
+	}
+	secretNames := make(map[string]bool)
+	for _, secret := range spec.Secrets {
+		if err := secret.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if secretNames[secret.Name] {
+			return errors.NotValidf("duplicate secret name %q", secret.Name)
+		}
+		secretNames[secret.Name] = true
 	}
 	return nil
 }
@@ -11,6 +11,7 @@ import (
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -36,15 +37,21 @@ type BaseSuite struct {
 	mockPods                   *mocks.MockPodInterface
 	mockServices               *mocks.MockServiceInterface
 	mockConfigMaps             *mocks.MockConfigMapInterface
+	mockSecrets                *mocks.MockSecretInterface
+	mockServiceAccounts        *mocks.MockServiceAccountInterface
 	mockPersistentVolumes      *mocks.MockPersistentVolumeInterface
 	mockPersistentVolumeClaims *mocks.MockPersistentVolumeClaimInterface
 	mockStorage                *mocks.MockStorageV1Interface
 	mockStorageClass           *mocks.MockStorageClassInterface
 	mockIngressInterface       *mocks.MockIngressInterface
+	mockRoles                  *mocks.MockRoleInterface
+	mockRoleBindings           *mocks.MockRoleBindingInterface
 
 	mockApiextensionsV1          *mocks.MockApiextensionsV1beta1Interface
 	mockApiextensionsClient      *mocks.MockApiExtensionsClientInterface
 	mockCustomResourceDefinition *mocks.MockCustomResourceDefinitionInterface
+
+	mockDynamicClient *mocks.MockDynamicInterface
 }
 
 const testNamespace = "test"
@@ -84,6 +91,12 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.mockConfigMaps = mocks.NewMockConfigMapInterface(ctrl)
 	mockCoreV1.EXPECT().ConfigMaps(testNamespace).AnyTimes().Return(s.mockConfigMaps)
 
+	s.mockSecrets = mocks.NewMockSecretInterface(ctrl)
+	mockCoreV1.EXPECT().Secrets(testNamespace).AnyTimes().Return(s.mockSecrets)
+
+	s.mockServiceAccounts = mocks.NewMockServiceAccountInterface(ctrl)
+	mockCoreV1.EXPECT().ServiceAccounts(testNamespace).AnyTimes().Return(s.mockServiceAccounts)
+
 	s.mockPersistentVolumes = mocks.NewMockPersistentVolumeInterface(ctrl)
 	mockCoreV1.EXPECT().PersistentVolumes().AnyTimes().Return(s.mockPersistentVolumes)
 
@@ -101,6 +114,13 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.mockApps.EXPECT().Deployments(testNamespace).AnyTimes().Return(s.mockDeployments)
 	s.mockExtensions.EXPECT().Ingresses(testNamespace).AnyTimes().Return(s.mockIngressInterface)
 
+	mockRbacV1 := mocks.NewMockRbacV1Interface(ctrl)
+	s.mockRoles = mocks.NewMockRoleInterface(ctrl)
+	s.mockRoleBindings = mocks.NewMockRoleBindingInterface(ctrl)
+	s.k8sClient.EXPECT().RbacV1().AnyTimes().Return(mockRbacV1)
+	mockRbacV1.EXPECT().Roles(testNamespace).AnyTimes().Return(s.mockRoles)
+	mockRbacV1.EXPECT().RoleBindings(testNamespace).AnyTimes().Return(s.mockRoleBindings)
+
 	s.mockStorage = mocks.NewMockStorageV1Interface(ctrl)
 	s.mockStorageClass = mocks.NewMockStorageClassInterface(ctrl)
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
@@ -112,8 +132,10 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.mockApiextensionsClient.EXPECT().ApiextensionsV1beta1().AnyTimes().Return(s.mockApiextensionsV1)
 	s.mockApiextensionsV1.EXPECT().CustomResourceDefinitions().AnyTimes().Return(s.mockCustomResourceDefinition)
 
+	s.mockDynamicClient = mocks.NewMockDynamicInterface(ctrl)
+
 	// Set up the mock k8sClient we pass to our broker under test.
-	newClient := func(cfg *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, error) {
+	newClient := func(cfg *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, dynamic.Interface, error) {
 		c.Assert(cfg.Username, gc.Equals, "fred")
 		c.Assert(cfg.Password, gc.Equals, "secret")
 		c.Assert(cfg.Host, gc.Equals, "some-host")
@@ -122,7 +144,7 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 			KeyData:  []byte("cert-key"),
 			CAData:   []byte(testing.CACert),
 		})
-		return s.k8sClient, s.mockApiextensionsClient, nil
+		return s.k8sClient, s.mockApiextensionsClient, s.mockDynamicClient, nil
 	}
 
 	var err error
@@ -0,0 +1,385 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+	"gopkg.in/juju/names.v2"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/network"
+)
+
+const (
+	labelController = "juju-controller"
+
+	// controllerStackName is the name given to the stateful set,
+	// service, secret and config map which make up a controller.
+	controllerStackName = "juju-controller"
+
+	controllerDataDir = "/var/lib/juju"
+
+	// controllerStorageName is the name of the volume claim template
+	// for the volume holding the controller's database and agent state.
+	controllerStorageName = "storage"
+
+	// defaultControllerStorageSize is the size, in MiB, of the
+	// controller's volume if none is requested.
+	defaultControllerStorageSize = 20 * 1024
+
+	controllerServiceType = core.ServiceTypeLoadBalancer
+
+	// These are the names of the files holding the controller's
+	// certificate, the parameters passed to "jujud bootstrap-state",
+	// and the initial agent config.
+	fileServerPEM       = "server.pem"
+	fileBootstrapParams = "bootstrap-params"
+	fileTemplateAgent   = "template-agent.conf"
+)
+
+// controllerAgentScript is run by the controller's agent container.
+// The first time the pod starts it seeds the machine agent's config
+// and initialises the controller's database; after that it just runs
+// the machine agent. mongod runs in a sibling container, which the
+// agent config tells the agent not to manage.
+const controllerAgentScript = `
+test -e %[1]s || (
+  mkdir -p %[2]s &&
+  cp %[3]s %[1]s &&
+  ./jujud bootstrap-state --data-dir %[4]s --debug %[5]s
+) &&
+./jujud machine --data-dir %[4]s --machine-id 0 --debug
+`
+
+var _ caas.ControllerBroker = (*kubernetesClient)(nil)
+
+// BootstrapController is part of the caas.ControllerBroker interface.
+// It creates a controller in the broker's namespace, which must not
+// already exist: a stateful set running jujud and mongod in a single
+// pod, a persistent volume for the controller's state, a service
+// through which the controller's API is reached, and the secret and
+// config map holding the controller's certificate and configuration.
+func (k *kubernetesClient) BootstrapController(params caas.ControllerBootstrapParams) (*caas.Service, error) {
+	if err := params.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Debugf("creating controller %s in namespace %q", params.ControllerUUID, k.namespace)
+
+	if err := k.createControllerNamespace(params.ControllerUUID); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := k.ensureK8sSecret(controllerSecret(params)); err != nil {
+		return nil, errors.Annotate(err, "creating controller secret")
+	}
+	if err := k.ensureConfigMap(controllerConfigMap(params)); err != nil {
+		return nil, errors.Annotate(err, "creating controller config map")
+	}
+	if err := k.ensureService(controllerService(params)); err != nil {
+		return nil, errors.Annotate(err, "creating controller service")
+	}
+	statefulSet, err := k.controllerStatefulSet(params)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := k.ensureStatefulSet(statefulSet, statefulSet.Spec.Template.Spec); err != nil {
+		return nil, errors.Annotate(err, "creating controller stateful set")
+	}
+	return k.waitForControllerService()
+}
+
+// createControllerNamespace creates the broker's namespace, labelled
+// with the controller's UUID. Unlike EnsureNamespace, it is an error
+// for the namespace to exist already, so that bootstrapping never
+// takes over another controller's resources.
+func (k *kubernetesClient) createControllerNamespace(controllerUUID string) error {
+	ns := &core.Namespace{ObjectMeta: v1.ObjectMeta{
+		Name:   k.namespace,
+		Labels: controllerLabels(controllerUUID),
+	}}
+	_, err := k.CoreV1().Namespaces().Create(ns)
+	if k8serrors.IsAlreadyExists(err) {
+		return errors.AlreadyExistsf("namespace %q", k.namespace)
+	}
+	return errors.Annotatef(err, "creating namespace %q", k.namespace)
+}
+
+// waitForControllerService waits for the controller's service to be
+// given a public address by the cluster's load balancer, and returns
+// the service.
+func (k *kubernetesClient) waitForControllerService() (*caas.Service, error) {
+	var result *caas.Service
+	errNoAddress := errors.New("no public address")
+	err := retry.Call(retry.CallArgs{
+		Clock: clock.WallClock,
+		IsFatalError: func(err error) bool {
+			return errors.Cause(err) != errNoAddress
+		},
+		Func: func() error {
+			service, err := k.CoreV1().Services(k.namespace).Get(controllerStackName, v1.GetOptions{})
+			if err != nil {
+				return errors.Trace(err)
+			}
+			result = controllerServiceResult(service)
+			if _, ok := network.SelectPublicAddress(result.Addresses); !ok {
+				return errNoAddress
+			}
+			return nil
+		},
+		Delay:       5 * time.Second,
+		MaxDuration: 10 * time.Minute,
+	})
+	if retry.IsDurationExceeded(err) {
+		return nil, errors.Errorf("controller service %q has no public address", controllerStackName)
+	}
+	return result, errors.Trace(err)
+}
+
+func controllerServiceResult(service *core.Service) *caas.Service {
+	result := &caas.Service{Id: string(service.UID)}
+	if service.Spec.ClusterIP != "" {
+		result.Addresses = append(result.Addresses, network.Address{
+			Value: service.Spec.ClusterIP,
+			Type:  network.DeriveAddressType(service.Spec.ClusterIP),
+			Scope: network.ScopeCloudLocal,
+		})
+	}
+	for _, ingress := range service.Status.LoadBalancer.Ingress {
+		addr := ingress.IP
+		if addr == "" {
+			addr = ingress.Hostname
+		}
+		if addr == "" {
+			continue
+		}
+		result.Addresses = append(result.Addresses, network.Address{
+			Value: addr,
+			Type:  network.DeriveAddressType(addr),
+			Scope: network.ScopePublic,
+		})
+	}
+	return result
+}
+
+func controllerLabels(controllerUUID string) map[string]string {
+	return map[string]string{labelController: controllerUUID}
+}
+
+func controllerSecret(params caas.ControllerBootstrapParams) *core.Secret {
+	return &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   controllerStackName + "-secret",
+			Labels: controllerLabels(params.ControllerUUID),
+		},
+		Type: core.SecretTypeOpaque,
+		Data: map[string][]byte{
+			fileServerPEM:          []byte(params.ServerPEM),
+			mongo.SharedSecretFile: []byte(params.SharedSecret),
+		},
+	}
+}
+
+func controllerConfigMap(params caas.ControllerBootstrapParams) *core.ConfigMap {
+	return &core.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   controllerStackName + "-config",
+			Labels: controllerLabels(params.ControllerUUID),
+		},
+		Data: map[string]string{
+			fileTemplateAgent:   string(params.AgentConf),
+			fileBootstrapParams: string(params.BootstrapParams),
+		},
+	}
+}
+
+func controllerService(params caas.ControllerBootstrapParams) *core.Service {
+	labels := controllerLabels(params.ControllerUUID)
+	return &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   controllerStackName,
+			Labels: labels,
+		},
+		Spec: core.ServiceSpec{
+			Selector: labels,
+			Type:     controllerServiceType,
+			Ports: []core.ServicePort{{
+				Name:       "api-server",
+				Protocol:   core.ProtocolTCP,
+				Port:       int32(params.APIPort),
+				TargetPort: intstr.FromInt(params.APIPort),
+			}},
+		},
+	}
+}
+
+func (k *kubernetesClient) controllerStatefulSet(params caas.ControllerBootstrapParams) (*apps.StatefulSet, error) {
+	labels := controllerLabels(params.ControllerUUID)
+	storageSize := params.StorageSize
+	if storageSize == 0 {
+		storageSize = defaultControllerStorageSize
+	}
+	pvcSpec, _, err := k.maybeGetVolumeClaimSpec(volumeParams{
+		storageLabels:       []string{controllerStackName + "-storage", k.namespace, "default"},
+		storageConfig:       &storageConfig{},
+		pvcName:             controllerStorageName,
+		requestedVolumeSize: fmt.Sprintf("%dMi", storageSize),
+		labels:              labels,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "finding volume for controller")
+	}
+
+	replicas := int32(1)
+	return &apps.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   controllerStackName,
+			Labels: labels,
+		},
+		Spec: apps.StatefulSetSpec{
+			ServiceName: controllerStackName,
+			Replicas:    &replicas,
+			Selector: &v1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: controllerPodSpec(params),
+			},
+			VolumeClaimTemplates: []core.PersistentVolumeClaim{{
+				ObjectMeta: v1.ObjectMeta{
+					Name:   controllerStorageName,
+					Labels: labels,
+				},
+				Spec: *pvcSpec,
+			}},
+		},
+	}, nil
+}
+
+// controllerPodSpec returns the spec of the controller pod, which runs
+// mongod and the controller's machine agent side by side, sharing the
+// controller's volume.
+func controllerPodSpec(params caas.ControllerBootstrapParams) core.PodSpec {
+	secretVolume := controllerStackName + "-secret"
+	configVolume := controllerStackName + "-config"
+	secretMode := int32(0400)
+
+	agentConfPath := agent.ConfigPath(controllerDataDir, names.NewMachineTag("0"))
+	agentScript := fmt.Sprintf(controllerAgentScript[1:],
+		agentConfPath,
+		path.Dir(agentConfPath),
+		path.Join(controllerDataDir, fileTemplateAgent),
+		controllerDataDir,
+		path.Join(controllerDataDir, fileBootstrapParams),
+	)
+
+	return core.PodSpec{
+		Containers: []core.Container{{
+			Name:            "mongodb",
+			ImagePullPolicy: core.PullIfNotPresent,
+			Image:           params.MongoImagePath,
+			Command:         []string{"mongod"},
+			Args:            controllerMongoArgs(params.StatePort),
+			Ports: []core.ContainerPort{{
+				Name:          "mongodb",
+				ContainerPort: int32(params.StatePort),
+				Protocol:      core.ProtocolTCP,
+			}},
+			VolumeMounts: []core.VolumeMount{{
+				Name:      controllerStorageName,
+				MountPath: path.Join(controllerDataDir, "db"),
+				SubPath:   "db",
+			}, {
+				Name:      secretVolume,
+				MountPath: path.Join(controllerDataDir, fileServerPEM),
+				SubPath:   fileServerPEM,
+				ReadOnly:  true,
+			}, {
+				Name:      secretVolume,
+				MountPath: path.Join(controllerDataDir, mongo.SharedSecretFile),
+				SubPath:   mongo.SharedSecretFile,
+				ReadOnly:  true,
+			}},
+		}, {
+			Name:            "api-server",
+			ImagePullPolicy: core.PullIfNotPresent,
+			Image:           params.OperatorImagePath,
+			Command:         []string{"sh", "-c"},
+			Args:            []string{agentScript},
+			Env: []core.EnvVar{
+				{Name: "JUJU_CONTROLLER_UUID", Value: params.ControllerUUID},
+			},
+			Ports: []core.ContainerPort{{
+				Name:          "api-server",
+				ContainerPort: int32(params.APIPort),
+				Protocol:      core.ProtocolTCP,
+			}},
+			VolumeMounts: []core.VolumeMount{{
+				Name:      controllerStorageName,
+				MountPath: agent.BaseDir(controllerDataDir),
+				SubPath:   "agents",
+			}, {
+				Name:      configVolume,
+				MountPath: path.Join(controllerDataDir, fileTemplateAgent),
+				SubPath:   fileTemplateAgent,
+			}, {
+				Name:      configVolume,
+				MountPath: path.Join(controllerDataDir, fileBootstrapParams),
+				SubPath:   fileBootstrapParams,
+			}},
+		}},
+		Volumes: []core.Volume{{
+			Name: secretVolume,
+			VolumeSource: core.VolumeSource{
+				Secret: &core.SecretVolumeSource{
+					SecretName:  controllerStackName + "-secret",
+					DefaultMode: &secretMode,
+				},
+			},
+		}, {
+			Name: configVolume,
+			VolumeSource: core.VolumeSource{
+				ConfigMap: &core.ConfigMapVolumeSource{
+					LocalObjectReference: core.LocalObjectReference{
+						Name: controllerStackName + "-config",
+					},
+				},
+			},
+		}},
+	}
+}
+
+// controllerMongoArgs returns the arguments passed to the controller's
+// mongod, which match those used for a controller machine.
+func controllerMongoArgs(statePort int) []string {
+	return []string{
+		"--dbpath=" + path.Join(controllerDataDir, "db"),
+		"--sslPEMKeyFile=" + path.Join(controllerDataDir, fileServerPEM),
+		"--sslPEMKeyPassword=ignored",
+		"--sslMode=requireSSL",
+		fmt.Sprintf("--port=%d", statePort),
+		"--journal",
+		"--replSet=" + mongo.ReplicaSetName,
+		"--quiet",
+		"--oplogSize=1024",
+		"--auth",
+		"--keyFile=" + path.Join(controllerDataDir, mongo.SharedSecretFile),
+		"--storageEngine=wiredTiger",
+		"--bind_ip_all",
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	appsv1 "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type bootstrapSuite struct {
+	BaseSuite
+}
+
+var _ = gc.Suite(&bootstrapSuite{})
+
+func (s *bootstrapSuite) params() caas.ControllerBootstrapParams {
+	return caas.ControllerBootstrapParams{
+		ControllerUUID:    testing.ControllerTag.Id(),
+		OperatorImagePath: "jujusolutions/jujud-operator:2.6.0",
+		MongoImagePath:    "jujusolutions/juju-db:4.0",
+		Version:           version.MustParse("2.6.0"),
+		APIPort:           17070,
+		StatePort:         37017,
+		StorageSize:       1024,
+		AgentConf:         []byte("agent-conf"),
+		BootstrapParams:   []byte("bootstrap-params"),
+		ServerPEM:         "server-pem",
+		SharedSecret:      "shared-secret",
+	}
+}
+
+func (s *bootstrapSuite) TestBootstrapController(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	labels := map[string]string{"juju-controller": testing.ControllerTag.Id()}
+	ns := &core.Namespace{ObjectMeta: v1.ObjectMeta{Name: "test", Labels: labels}}
+	secret := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-controller-secret", Labels: labels},
+		Type:       core.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"server.pem":    []byte("server-pem"),
+			"shared-secret": []byte("shared-secret"),
+		},
+	}
+	configMap := &core.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-controller-config", Labels: labels},
+		Data: map[string]string{
+			"template-agent.conf": "agent-conf",
+			"bootstrap-params":    "bootstrap-params",
+		},
+	}
+	service := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-controller", Labels: labels},
+		Spec: core.ServiceSpec{
+			Selector: labels,
+			Type:     core.ServiceTypeLoadBalancer,
+			Ports: []core.ServicePort{{
+				Name:       "api-server",
+				Protocol:   core.ProtocolTCP,
+				Port:       17070,
+				TargetPort: intstr.FromInt(17070),
+			}},
+		},
+	}
+	createdService := *service
+	createdService.UID = "service-uid"
+	createdService.Spec.ClusterIP = "10.0.0.1"
+	createdService.Status.LoadBalancer.Ingress = []core.LoadBalancerIngress{{IP: "54.0.0.1"}}
+
+	var statefulSet *appsv1.StatefulSet
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Create(ns).Times(1).Return(ns, nil),
+		s.mockSecrets.EXPECT().Update(secret).Times(1).Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(secret).Times(1).Return(secret, nil),
+		s.mockConfigMaps.EXPECT().Update(configMap).Times(1).Return(nil, s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().Create(configMap).Times(1).Return(configMap, nil),
+		s.mockServices.EXPECT().Get("juju-controller", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(service).Times(1).Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(service).Times(1).Return(service, nil),
+		s.mockPersistentVolumeClaims.EXPECT().Get("storage", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStorageClass.EXPECT().List(v1.ListOptions{
+			LabelSelector: "juju-storage in (juju-controller-storage, test, default)",
+		}).Times(1).Return(&storagev1.StorageClassList{Items: []storagev1.StorageClass{{
+			ObjectMeta: v1.ObjectMeta{Name: "controller-storage"},
+		}}}, nil),
+		s.mockStatefulSets.EXPECT().Update(gomock.Any()).Times(1).Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Create(gomock.Any()).Times(1).
+			DoAndReturn(func(ss *appsv1.StatefulSet) (*appsv1.StatefulSet, error) {
+				statefulSet = ss
+				return ss, nil
+			}),
+		s.mockServices.EXPECT().Get("juju-controller", v1.GetOptions{}).Times(1).
+			Return(&createdService, nil),
+	)
+
+	broker, ok := s.broker.(caas.ControllerBroker)
+	c.Assert(ok, jc.IsTrue)
+	result, err := broker.BootstrapController(s.params())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, &caas.Service{
+		Id: "service-uid",
+		Addresses: []network.Address{{
+			Value: "10.0.0.1",
+			Type:  network.IPv4Address,
+			Scope: network.ScopeCloudLocal,
+		}, {
+			Value: "54.0.0.1",
+			Type:  network.IPv4Address,
+			Scope: network.ScopePublic,
+		}},
+	})
+
+	c.Assert(statefulSet, gc.NotNil)
+	c.Check(statefulSet.Name, gc.Equals, "juju-controller")
+	c.Check(*statefulSet.Spec.Replicas, gc.Equals, int32(1))
+	c.Check(statefulSet.Spec.Selector.MatchLabels, jc.DeepEquals, labels)
+	scName := "controller-storage"
+	c.Check(statefulSet.Spec.VolumeClaimTemplates, jc.DeepEquals, []core.PersistentVolumeClaim{{
+		ObjectMeta: v1.ObjectMeta{Name: "storage", Labels: labels},
+		Spec: core.PersistentVolumeClaimSpec{
+			StorageClassName: &scName,
+			AccessModes:      []core.PersistentVolumeAccessMode{core.ReadWriteOnce},
+			Resources: core.ResourceRequirements{
+				Requests: core.ResourceList{
+					core.ResourceStorage: resource.MustParse("1024Mi"),
+				},
+			},
+		},
+	}})
+
+	podSpec := statefulSet.Spec.Template.Spec
+	c.Assert(podSpec.Containers, gc.HasLen, 2)
+	mongo, apiServer := podSpec.Containers[0], podSpec.Containers[1]
+	c.Check(mongo.Name, gc.Equals, "mongodb")
+	c.Check(mongo.Image, gc.Equals, "jujusolutions/juju-db:4.0")
+	mongoArgs := strings.Join(mongo.Args, " ")
+	c.Check(mongoArgs, jc.Contains, "--port=37017")
+	c.Check(mongoArgs, jc.Contains, "--keyFile=/var/lib/juju/shared-secret")
+	c.Check(mongo.VolumeMounts[0], jc.DeepEquals, core.VolumeMount{
+		Name:      "storage",
+		MountPath: "/var/lib/juju/db",
+		SubPath:   "db",
+	})
+	c.Check(apiServer.Name, gc.Equals, "api-server")
+	c.Check(apiServer.Image, gc.Equals, "jujusolutions/jujud-operator:2.6.0")
+	c.Check(apiServer.Ports, jc.DeepEquals, []core.ContainerPort{{
+		Name:          "api-server",
+		ContainerPort: 17070,
+		Protocol:      core.ProtocolTCP,
+	}})
+	c.Assert(apiServer.Args, gc.HasLen, 1)
+	c.Check(apiServer.Args[0], jc.Contains, "jujud bootstrap-state --data-dir /var/lib/juju --debug /var/lib/juju/bootstrap-params")
+	c.Check(apiServer.Args[0], jc.Contains, "jujud machine --data-dir /var/lib/juju --machine-id 0")
+}
+
+func (s *bootstrapSuite) TestBootstrapControllerNamespaceExists(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	s.mockNamespaces.EXPECT().Create(gomock.Any()).Times(1).
+		Return(nil, k8serrors.NewAlreadyExists(schema.GroupResource{}, "test"))
+
+	broker := s.broker.(caas.ControllerBroker)
+	_, err := broker.BootstrapController(s.params())
+	c.Assert(err, gc.ErrorMatches, `namespace "test" already exists`)
+}
+
+func (s *bootstrapSuite) TestBootstrapControllerInvalidParams(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	params := s.params()
+	params.SharedSecret = ""
+	broker := s.broker.(caas.ControllerBroker)
+	_, err := broker.BootstrapController(params)
+	c.Assert(err, gc.ErrorMatches, "missing controller secrets not valid")
+}
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -65,6 +66,7 @@ var defaultPropagationPolicy = v1.DeletePropagationForeground
 type kubernetesClient struct {
 	kubernetes.Interface
 	apiextensionsClient apiextensionsclientset.Interface
+	dynamicClient       dynamic.Interface
 
 	// namespace is the k8s namespace to use when
 	// creating k8s resources.
@@ -75,12 +77,14 @@ type kubernetesClient struct {
 // run "go generate" from the package directory.
 //go:generate mockgen -package mocks -destination mocks/k8sclient_mock.go k8s.io/client-go/kubernetes Interface
 //go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface
-//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface
+//go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,ServiceAccountInterface
+//go:generate mockgen -package mocks -destination mocks/rbacv1_mock.go k8s.io/client-go/kubernetes/typed/rbac/v1 RbacV1Interface,RoleInterface,RoleBindingInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
+//go:generate mockgen -package mocks -destination mocks/dynamic_mock.go -mock_names=Interface=MockDynamicInterface k8s.io/client-go/dynamic Interface,NamespaceableResourceInterface,ResourceInterface
 
 // NewK8sClientFunc defines a function which returns a k8s client based on the supplied config.
-type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, error)
+type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, dynamic.Interface, error)
 
 // NewK8sBroker returns a kubernetes client for the specified k8s cluster.
 func NewK8sBroker(cloudSpec environs.CloudSpec, namespace string, newClient NewK8sClientFunc) (caas.Broker, error) {
@@ -88,13 +92,14 @@ func NewK8sBroker(cloudSpec environs.CloudSpec, namespace string, newClient NewK
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	k8sClient, apiextensionsClient, err := newClient(config)
+	k8sClient, apiextensionsClient, dynamicClient, err := newClient(config)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &kubernetesClient{
 		Interface:           k8sClient,
 		apiextensionsClient: apiextensionsClient,
+		dynamicClient:       dynamicClient,
 		namespace:           namespace,
 	}, nil
 }
@@ -495,6 +500,9 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteStatefulSet(appName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deletePodSpecResources(appName); err != nil {
+		return errors.Trace(err)
+	}
 	pods := k.CoreV1().Pods(k.namespace)
 	podsList, err := pods.List(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
@@ -513,7 +521,7 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 // EnsureCustomResourceDefinition creates or updates a custom resource definition resource.
 func (k *kubernetesClient) EnsureCustomResourceDefinition(appName string, podSpec *caas.PodSpec) error {
 	for _, t := range podSpec.CustomResourceDefinitions {
-		crd, err := k.ensureCustomResourceDefinitionTemplate(&t)
+		crd, err := k.ensureCustomResourceDefinitionTemplate(appName, &t)
 		if err != nil {
 			return errors.Annotate(err, fmt.Sprintf("ensure custom resource definition %q", t.Kind))
 		}
@@ -522,14 +530,43 @@ func (k *kubernetesClient) EnsureCustomResourceDefinition(appName string, podSpe
 	return nil
 }
 
-func (k *kubernetesClient) ensureCustomResourceDefinitionTemplate(t *caas.CustomResourceDefinition) (
+func (k *kubernetesClient) ensureCustomResourceDefinitionTemplate(appName string, t *caas.CustomResourceDefinition) (
 	crd *apiextensionsv1beta1.CustomResourceDefinition, err error) {
+	crdIn := k.customResourceDefinition(appName, t)
+	crds := k.apiextensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions()
+	existing, err := crds.Get(crdIn.Name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		logger.Debugf("no existing crd, so create one %#v", crdIn)
+		return crds.Create(crdIn)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Keep any labels added to the definition by others.
+	labels := make(map[string]string)
+	for key, value := range existing.Labels {
+		labels[key] = value
+	}
+	for key, value := range crdIn.Labels {
+		labels[key] = value
+	}
+	crdIn.Labels = labels
+	crdIn.ResourceVersion = existing.ResourceVersion
+	logger.Debugf("update existing crd %#v", crdIn)
+	return crds.Update(crdIn)
+}
+
+// customResourceDefinition returns the custom resource definition
+// resource for the given template. Custom resource definitions are
+// labelled with the application so that instances of them created on
+// behalf of its pod spec can be found again.
+func (k *kubernetesClient) customResourceDefinition(appName string, t *caas.CustomResourceDefinition) *apiextensionsv1beta1.CustomResourceDefinition {
 	singularName := strings.ToLower(t.Kind)
 	pluralName := fmt.Sprintf("%ss", singularName)
-	crdIn := &apiextensionsv1beta1.CustomResourceDefinition{
+	return &apiextensionsv1beta1.CustomResourceDefinition{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      fmt.Sprintf("%s.%s", pluralName, t.Group),
 			Namespace: k.namespace,
+			Labels:    podSpecResourceLabels(appName),
 		},
 		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
 			Group:   t.Group,
@@ -547,14 +584,6 @@ func (k *kubernetesClient) ensureCustomResourceDefinitionTemplate(t *caas.Custom
 			},
 		},
 	}
-	apiextensionsV1beta1 := k.apiextensionsClient.ApiextensionsV1beta1()
-	logger.Debugf("try to update crd %#v", crdIn)
-	crd, err = apiextensionsV1beta1.CustomResourceDefinitions().Update(crdIn)
-	if k8serrors.IsNotFound(err) {
-		logger.Debugf("no existing crd, so create one %#v", crdIn)
-		crd, err = apiextensionsV1beta1.CustomResourceDefinitions().Create(crdIn)
-	}
-	return
 }
 
 // EnsureService creates or updates a service for pods with the given params.
@@ -596,7 +625,9 @@ func (k *kubernetesClient) EnsureService(
 		}
 	}
 
-	for _, c := range params.PodSpec.Containers {
+	allContainers := append([]caas.ContainerSpec(nil), params.PodSpec.Containers...)
+	allContainers = append(allContainers, params.PodSpec.InitContainers...)
+	for _, c := range allContainers {
 		if c.ImageDetails.Password == "" {
 			continue
 		}
@@ -607,6 +638,10 @@ func (k *kubernetesClient) EnsureService(
 		cleanups = append(cleanups, func() { k.deleteSecret(appName, c.Name) })
 	}
 
+	if err := k.ensurePodSpecResources(appName, params.PodSpec); err != nil {
+		return errors.Annotatef(err, "creating or updating resources for %s", appName)
+	}
+
 	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
 	// Defensively check to see if a stateful set is already used.
 	useStatefulSet := len(params.Filesystems) > 0
@@ -624,12 +659,12 @@ func (k *kubernetesClient) EnsureService(
 
 	numPods := int32(numUnits)
 	if useStatefulSet {
-		if err := k.configureStatefulSet(appName, unitSpec, params.PodSpec.Containers, &numPods, params.Filesystems); err != nil {
+		if err := k.configureStatefulSet(appName, unitSpec, params.PodSpec, &numPods, params.Filesystems); err != nil {
 			return errors.Annotate(err, "creating or updating StatefulSet")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	} else {
-		if err := k.configureDeployment(appName, unitSpec, params.PodSpec.Containers, &numPods); err != nil {
+		if err := k.configureDeployment(appName, unitSpec, params.PodSpec, &numPods); err != nil {
 			return errors.Annotate(err, "creating or updating DeploymentController")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
@@ -758,7 +793,16 @@ func (k *kubernetesClient) configureDevices(unitSpec *unitSpec, devices []device
 
 type configMapNameFunc func(fileSetName string) string
 
-func (k *kubernetesClient) configurePodFiles(podSpec *core.PodSpec, containers []caas.ContainerSpec, cfgMapName configMapNameFunc) error {
+func (k *kubernetesClient) configurePodFiles(podSpec *core.PodSpec, spec *caas.PodSpec, cfgMapName configMapNameFunc) error {
+	if err := k.configureContainerFiles(podSpec, podSpec.Containers, spec.Containers, cfgMapName); err != nil {
+		return errors.Trace(err)
+	}
+	return k.configureContainerFiles(podSpec, podSpec.InitContainers, spec.InitContainers, cfgMapName)
+}
+
+func (k *kubernetesClient) configureContainerFiles(
+	podSpec *core.PodSpec, k8sContainers []core.Container, containers []caas.ContainerSpec, cfgMapName configMapNameFunc,
+) error {
 	for i, container := range containers {
 		for _, fileSet := range container.Files {
 			cfgName := cfgMapName(fileSet.Name)
@@ -772,7 +816,7 @@ func (k *kubernetesClient) configurePodFiles(podSpec *core.PodSpec, containers [
 				},
 			}
 			podSpec.Volumes = append(podSpec.Volumes, vol)
-			podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, core.VolumeMount{
+			k8sContainers[i].VolumeMounts = append(k8sContainers[i].VolumeMounts, core.VolumeMount{
 				Name:      cfgName,
 				MountPath: fileSet.MountPath,
 			})
@@ -781,7 +825,7 @@ func (k *kubernetesClient) configurePodFiles(podSpec *core.PodSpec, containers [
 	return nil
 }
 
-func (k *kubernetesClient) configureDeployment(appName string, unitSpec *unitSpec, containers []caas.ContainerSpec, replicas *int32) error {
+func (k *kubernetesClient) configureDeployment(appName string, unitSpec *unitSpec, spec *caas.PodSpec, replicas *int32) error {
 	logger.Debugf("creating/updating deployment for %s", appName)
 
 	// Add the specified file to the pod spec.
@@ -789,7 +833,7 @@ func (k *kubernetesClient) configureDeployment(appName string, unitSpec *unitSpe
 		return applicationConfigMapName(appName, fileSetName)
 	}
 	podSpec := unitSpec.Pod
-	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
+	if err := k.configurePodFiles(&podSpec, spec, cfgName); err != nil {
 		return errors.Trace(err)
 	}
 
@@ -836,7 +880,7 @@ func (k *kubernetesClient) deleteDeployment(appName string) error {
 }
 
 func (k *kubernetesClient) configureStatefulSet(
-	appName string, unitSpec *unitSpec, containers []caas.ContainerSpec, replicas *int32, filesystems []storage.KubernetesFilesystemParams,
+	appName string, unitSpec *unitSpec, spec *caas.PodSpec, replicas *int32, filesystems []storage.KubernetesFilesystemParams,
 ) error {
 	logger.Debugf("creating/updating stateful set for %s", appName)
 
@@ -862,7 +906,7 @@ func (k *kubernetesClient) configureStatefulSet(
 		},
 	}
 	podSpec := unitSpec.Pod
-	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
+	if err := k.configurePodFiles(&podSpec, spec, cfgName); err != nil {
 		return errors.Trace(err)
 	}
 	existingPodSpec := podSpec
@@ -1010,7 +1054,8 @@ func (k *kubernetesClient) deleteService(appName string) error {
 }
 
 // ExposeService sets up external access to the specified application.
-func (k *kubernetesClient) ExposeService(appName string, config application.ConfigAttributes) error {
+// If sourceCIDRs is not empty, access is only allowed from those CIDRs.
+func (k *kubernetesClient) ExposeService(appName string, config application.ConfigAttributes, sourceCIDRs []string) error {
 	logger.Debugf("creating/updating ingress resource for %s", appName)
 
 	host := config.GetString(caas.JujuExternalHostNameKey, "")
@@ -1036,17 +1081,21 @@ func (k *kubernetesClient) ExposeService(appName string, config application.Conf
 	if len(svc.Spec.Ports) == 0 {
 		return errors.Errorf("cannot create ingress rule for service %q without a port", svc.Name)
 	}
+	annotations := map[string]string{
+		"ingress.kubernetes.io/rewrite-target":  "",
+		"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(ingressSSLRedirect),
+		"kubernetes.io/ingress.class":           ingressClass,
+		"kubernetes.io/ingress.allow-http":      strconv.FormatBool(ingressAllowHTTP),
+		"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(ingressSSLPassthrough),
+	}
+	if len(sourceCIDRs) > 0 {
+		annotations["ingress.kubernetes.io/whitelist-source-range"] = strings.Join(sourceCIDRs, ",")
+	}
 	spec := &v1beta1.Ingress{
 		ObjectMeta: v1.ObjectMeta{
-			Name:   deploymentName(appName),
-			Labels: map[string]string{labelApplication: appName},
-			Annotations: map[string]string{
-				"ingress.kubernetes.io/rewrite-target":  "",
-				"ingress.kubernetes.io/ssl-redirect":    strconv.FormatBool(ingressSSLRedirect),
-				"kubernetes.io/ingress.class":           ingressClass,
-				"kubernetes.io/ingress.allow-http":      strconv.FormatBool(ingressAllowHTTP),
-				"ingress.kubernetes.io/ssl-passthrough": strconv.FormatBool(ingressSSLPassthrough),
-			},
+			Name:        deploymentName(appName),
+			Labels:      map[string]string{labelApplication: appName},
+			Annotations: annotations,
 		},
 		Spec: v1beta1.IngressSpec{
 			Rules: []v1beta1.IngressRule{{
@@ -1481,9 +1530,7 @@ type unitSpec struct {
 }
 
 var defaultPodTemplate = `
-pod:
-  containers:
-  {{- range .Containers }}
+{{- define "container" }}
   - name: {{.Name}}
     {{if .Ports}}
     ports:
@@ -1509,6 +1556,13 @@ pod:
           value: {{$v}}
     {{- end}}
     {{end}}
+{{- end -}}
+pod:
+  containers:
+  {{- range .Containers }}{{template "container" .}}{{- end}}
+  {{- if .InitContainers}}
+  initContainers:
+  {{- range .InitContainers }}{{template "container" .}}{{- end}}
   {{- end}}
 `[1:]
 
@@ -1527,14 +1581,41 @@ func makeUnitSpec(appName string, podSpec *caas.PodSpec) (*unitSpec, error) {
 		return nil, errors.Trace(err)
 	}
 
-	var imageSecretNames []core.LocalObjectReference
 	// Now fill in the hard bits progamatically.
-	for i, c := range podSpec.Containers {
+	imageSecretNames, err := populateContainers(appName, unitSpec.Pod.Containers, podSpec.Containers)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	initImageSecretNames, err := populateContainers(appName, unitSpec.Pod.InitContainers, podSpec.InitContainers)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitSpec.Pod.ImagePullSecrets = append(imageSecretNames, initImageSecretNames...)
+	for i, c := range unitSpec.Pod.Containers {
+		unitSpec.Pod.Containers[i].EnvFrom = podSpecEnvFrom(appName, podSpec, c.EnvFrom)
+	}
+	for i, c := range unitSpec.Pod.InitContainers {
+		unitSpec.Pod.InitContainers[i].EnvFrom = podSpecEnvFrom(appName, podSpec, c.EnvFrom)
+	}
+	if podSpec.ServiceAccount != nil {
+		unitSpec.Pod.ServiceAccountName = serviceAccountName(appName)
+	}
+	return &unitSpec, nil
+}
+
+// populateContainers fills in the parts of the templated containers
+// which come from the container specs, and returns the image pull
+// secrets the containers need.
+func populateContainers(
+	appName string, containers []core.Container, specs []caas.ContainerSpec,
+) ([]core.LocalObjectReference, error) {
+	var imageSecretNames []core.LocalObjectReference
+	for i, c := range specs {
 		if c.Image != "" {
 			logger.Warningf("Image parameter deprecated, use ImageDetails")
-			unitSpec.Pod.Containers[i].Image = c.Image
+			containers[i].Image = c.Image
 		} else {
-			unitSpec.Pod.Containers[i].Image = c.ImageDetails.ImagePath
+			containers[i].Image = c.ImageDetails.ImagePath
 		}
 		if c.ImageDetails.Password != "" {
 			imageSecretNames = append(imageSecretNames, core.LocalObjectReference{Name: appSecretName(appName, c.Name)})
@@ -1547,16 +1628,16 @@ func makeUnitSpec(appName string, podSpec *caas.PodSpec) (*unitSpec, error) {
 		if !ok {
 			return nil, errors.Errorf("unexpected kubernetes container spec type %T", c.ProviderContainer)
 		}
-		unitSpec.Pod.Containers[i].ImagePullPolicy = spec.ImagePullPolicy
+		containers[i].ImagePullPolicy = spec.ImagePullPolicy
 		if spec.LivenessProbe != nil {
-			unitSpec.Pod.Containers[i].LivenessProbe = spec.LivenessProbe
+			containers[i].LivenessProbe = spec.LivenessProbe
 		}
 		if spec.ReadinessProbe != nil {
-			unitSpec.Pod.Containers[i].ReadinessProbe = spec.ReadinessProbe
+			containers[i].ReadinessProbe = spec.ReadinessProbe
 		}
+		containers[i].EnvFrom = spec.EnvFrom
 	}
-	unitSpec.Pod.ImagePullSecrets = imageSecretNames
-	return &unitSpec, nil
+	return imageSecretNames, nil
 }
 
 func operatorPodName(appName string) string {
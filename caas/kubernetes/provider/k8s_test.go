@@ -4,20 +4,27 @@
 package provider_test
 
 import (
+	"strings"
+
 	"github.com/golang/mock/gomock"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/caas/kubernetes/provider/mocks"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/storage"
@@ -97,6 +104,100 @@ var basicPodspec = &caas.PodSpec{
 	}},
 }
 
+func (s *K8sSuite) TestMakeUnitSpecInitContainers(c *gc.C) {
+	podSpec := caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:  "test",
+			Image: "juju/image",
+			ProviderContainer: &provider.K8sContainerSpec{
+				EnvFrom: []core.EnvFromSource{{
+					SecretRef: &core.SecretEnvSource{
+						LocalObjectReference: core.LocalObjectReference{Name: "creds"},
+					},
+				}},
+			},
+		}},
+		InitContainers: []caas.ContainerSpec{{
+			Name:    "init",
+			Command: []string{"sh", "-c"},
+			Args:    []string{"migrate"},
+			ImageDetails: caas.ImageDetails{
+				ImagePath: "juju/migrations",
+				Password:  "secret",
+			},
+		}},
+	}
+	spec, err := provider.MakeUnitSpec("app-name", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.PodSpec(spec), jc.DeepEquals, core.PodSpec{
+		Containers: []core.Container{{
+			Name:  "test",
+			Image: "juju/image",
+			EnvFrom: []core.EnvFromSource{{
+				SecretRef: &core.SecretEnvSource{
+					LocalObjectReference: core.LocalObjectReference{Name: "creds"},
+				},
+			}},
+		}},
+		InitContainers: []core.Container{{
+			Name:    "init",
+			Image:   "juju/migrations",
+			Command: []string{"sh", "-c"},
+			Args:    []string{"migrate"},
+		}},
+		ImagePullSecrets: []core.LocalObjectReference{{Name: "juju-app-name-init-secret"}},
+	})
+}
+
+func (s *K8sSuite) TestMakeUnitSpecEnvFromPodSpecResources(c *gc.C) {
+	podSpec := caas.PodSpec{
+		Containers: []caas.ContainerSpec{{
+			Name:  "test",
+			Image: "juju/image",
+			ProviderContainer: &provider.K8sContainerSpec{
+				EnvFrom: []core.EnvFromSource{{
+					SecretRef: &core.SecretEnvSource{
+						LocalObjectReference: core.LocalObjectReference{Name: "creds"},
+					},
+				}, {
+					ConfigMapRef: &core.ConfigMapEnvSource{
+						LocalObjectReference: core.LocalObjectReference{Name: "settings"},
+					},
+				}, {
+					ConfigMapRef: &core.ConfigMapEnvSource{
+						LocalObjectReference: core.LocalObjectReference{Name: "external"},
+					},
+				}},
+			},
+		}},
+		ConfigMaps: map[string]caas.ConfigMap{
+			"settings": {"foo": "bar"},
+		},
+		Secrets: []caas.Secret{{
+			Name: "creds",
+			Data: map[string]string{"password": "hunter2"},
+		}},
+	}
+	spec, err := provider.MakeUnitSpec("app-name", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.PodSpec(spec).Containers[0].EnvFrom, jc.DeepEquals, []core.EnvFromSource{{
+		SecretRef: &core.SecretEnvSource{
+			LocalObjectReference: core.LocalObjectReference{Name: "juju-app-name-creds"},
+		},
+	}, {
+		ConfigMapRef: &core.ConfigMapEnvSource{
+			LocalObjectReference: core.LocalObjectReference{Name: "juju-app-name-settings"},
+		},
+	}, {
+		ConfigMapRef: &core.ConfigMapEnvSource{
+			LocalObjectReference: core.LocalObjectReference{Name: "external"},
+		},
+	}})
+	// The container spec itself is left alone.
+	k8sSpec := podSpec.Containers[0].ProviderContainer.(*provider.K8sContainerSpec)
+	c.Assert(k8sSpec.EnvFrom[0].SecretRef.Name, gc.Equals, "creds")
+}
+
 func (s *K8sSuite) TestMakeUnitSpecConfigPairs(c *gc.C) {
 	spec, err := provider.MakeUnitSpec("app-name", basicPodspec)
 	c.Assert(err, jc.ErrorIsNil)
@@ -165,6 +266,8 @@ func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
 
+	s.expectPodSpecResourcesPruned(nil, nil, nil, nil)
+
 	// Delete operations below return a not found to ensure it's treated as a no-op.
 	gomock.InOrder(
 		s.mockServices.EXPECT().Delete("juju-test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
@@ -181,6 +284,59 @@ func (s *K8sBrokerSuite) TestDeleteService(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) TestExposeServiceToCIDRs(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "juju-test"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+			},
+		},
+	}
+	ingress := &extensionsv1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"},
+			Annotations: map[string]string{
+				"ingress.kubernetes.io/rewrite-target":         "",
+				"ingress.kubernetes.io/ssl-redirect":           "false",
+				"kubernetes.io/ingress.class":                  "nginx",
+				"kubernetes.io/ingress.allow-http":             "false",
+				"ingress.kubernetes.io/ssl-passthrough":        "false",
+				"ingress.kubernetes.io/whitelist-source-range": "10.0.0.0/24,192.168.0.0/16",
+			},
+		},
+		Spec: extensionsv1beta1.IngressSpec{
+			Rules: []extensionsv1beta1.IngressRule{{
+				Host: "exthost",
+				IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+					HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+						Paths: []extensionsv1beta1.HTTPIngressPath{{
+							Path: "/",
+							Backend: extensionsv1beta1.IngressBackend{
+								ServiceName: "juju-test", ServicePort: intstr.FromInt(80)},
+						}}},
+				}}},
+		},
+	}
+	gomock.InOrder(
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{}).Times(1).
+			Return(svc, nil),
+		s.mockIngressInterface.EXPECT().Update(ingress).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Create(ingress).Times(1).
+			Return(ingress, nil),
+	)
+
+	err := s.broker.ExposeService("test", application.ConfigAttributes{
+		caas.JujuExternalHostNameKey: "exthost",
+	}, []string{"10.0.0.0/24", "192.168.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *K8sBrokerSuite) TestEnsureServiceNoUnits(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
@@ -265,6 +421,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	params := &caas.ServiceParams{
 		PodSpec: basicPodspec,
 	}
+	s.expectPodSpecResourcesPruned(nil, nil, nil, nil)
 	err = s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
 		"kubernetes-service-type":            "nodeIP",
 		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
@@ -273,6 +430,396 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+// expectPodSpecResourcesPruned expects the pod spec resources for the
+// "test" application to be deleted, other than those with the given names.
+// The given custom resource definitions are those found when looking for
+// custom resources to delete.
+func (s *K8sBrokerSuite) expectPodSpecResourcesPruned(
+	roles, accounts, configMaps, secrets []string, crds ...apiextensionsv1beta1.CustomResourceDefinition,
+) {
+	listOptions := func(keep []string) v1.ListOptions {
+		var selectors []string
+		for _, name := range keep {
+			selectors = append(selectors, "metadata.name!="+name)
+		}
+		return v1.ListOptions{
+			LabelSelector: "juju-application==test,juju-pod-spec-resource==true",
+			FieldSelector: strings.Join(selectors, ","),
+		}
+	}
+	deleteOptions := s.deleteOptions(v1.DeletePropagationForeground)
+	s.mockRoleBindings.EXPECT().DeleteCollection(deleteOptions, listOptions(roles)).Times(1).Return(nil)
+	s.mockRoles.EXPECT().DeleteCollection(deleteOptions, listOptions(roles)).Times(1).Return(nil)
+	s.mockServiceAccounts.EXPECT().DeleteCollection(deleteOptions, listOptions(accounts)).Times(1).Return(nil)
+	s.mockConfigMaps.EXPECT().DeleteCollection(deleteOptions, listOptions(configMaps)).Times(1).Return(nil)
+	s.mockSecrets.EXPECT().DeleteCollection(deleteOptions, listOptions(secrets)).Times(1).Return(nil)
+	s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{
+		LabelSelector: "juju-application==test,juju-pod-spec-resource==true",
+	}).Times(1).Return(&apiextensionsv1beta1.CustomResourceDefinitionList{Items: crds}, nil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithPodSpecResources(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	automount := true
+	podSpec := *basicPodspec
+	podSpec.ServiceAccount = &caas.ServiceAccountSpec{
+		AutomountServiceAccountToken: &automount,
+		Roles: []caas.Role{{
+			Name: "reader",
+			Rules: []caas.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "list"},
+			}},
+		}},
+	}
+	podSpec.ConfigMaps = map[string]caas.ConfigMap{
+		"settings": {"foo": "bar"},
+	}
+	podSpec.Secrets = []caas.Secret{{
+		Name: "creds",
+		Data: map[string]string{"password": "hunter2"},
+	}}
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("test", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider.PodSpec(unitSpec).ServiceAccountName, gc.Equals, "juju-test")
+
+	labels := map[string]string{"juju-application": "test", "juju-pod-spec-resource": "true"}
+	serviceAccountArg := &core.ServiceAccount{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		AutomountServiceAccountToken: &automount,
+	}
+	roleArg := &rbacv1.Role{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test-reader",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		Rules: []rbacv1.PolicyRule{{
+			APIGroups: []string{""},
+			Resources: []string{"pods"},
+			Verbs:     []string{"get", "list"},
+		}},
+	}
+	roleBindingArg := &rbacv1.RoleBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test-reader",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      "ServiceAccount",
+			Name:      "juju-test",
+			Namespace: "test",
+		}},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "Role",
+			Name:     "juju-test-reader",
+		},
+	}
+	configMapArg := &core.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test-settings",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		Data: map[string]string{"foo": "bar"},
+	}
+	secretArg := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test-creds",
+			Namespace: "test",
+			Labels:    labels,
+		},
+		Type: core.SecretTypeOpaque,
+		Data: map[string][]byte{"password": []byte("hunter2")},
+	}
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "test"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-application-test-",
+					Labels:       map[string]string{"juju-application": "test"},
+				},
+				Spec: provider.PodSpec(unitSpec),
+			},
+		},
+	}
+
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: core.ServiceSpec{
+			Selector: map[string]string{"juju-application": "test"},
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+				{Port: 8080, Protocol: "TCP", Name: "fred"},
+			},
+		},
+	}
+
+	s.expectPodSpecResourcesPruned(
+		[]string{"juju-test-reader"}, []string{"juju-test"}, []string{"juju-test-settings"}, []string{"juju-test-creds"},
+	)
+	gomock.InOrder(
+		s.mockServiceAccounts.EXPECT().Update(serviceAccountArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServiceAccounts.EXPECT().Create(serviceAccountArg).Times(1).
+			Return(nil, nil),
+		s.mockRoles.EXPECT().Update(roleArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockRoles.EXPECT().Create(roleArg).Times(1).
+			Return(nil, nil),
+		s.mockRoleBindings.EXPECT().Update(roleBindingArg).Times(1).
+			Return(nil, nil),
+		s.mockConfigMaps.EXPECT().Get("juju-test-settings", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().Create(configMapArg).Times(1).
+			Return(nil, nil),
+		s.mockSecrets.EXPECT().Get("juju-test-creds", v1.GetOptions{}).Times(1).
+			Return(secretArg, nil),
+		s.mockSecrets.EXPECT().Update(secretArg).Times(1).
+			Return(nil, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(serviceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err = s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceConfigMapNotOwned(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.ConfigMaps = map[string]caas.ConfigMap{
+		"settings": {"foo": "bar"},
+	}
+
+	existing := &core.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-test-settings",
+			Namespace: "test",
+			Labels:    map[string]string{"juju-application": "other", "juju-pod-spec-resource": "true"},
+		},
+	}
+	gomock.InOrder(
+		s.mockRoleBindings.EXPECT().DeleteCollection(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+		s.mockRoles.EXPECT().DeleteCollection(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+		s.mockServiceAccounts.EXPECT().DeleteCollection(gomock.Any(), gomock.Any()).Times(1).Return(nil),
+		s.mockConfigMaps.EXPECT().Get("juju-test-settings", v1.GetOptions{}).Times(1).
+			Return(existing, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err := s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+	})
+	c.Assert(err, gc.ErrorMatches, `creating or updating resources for test: ensuring config maps: `+
+		`ensuring config map "settings": "juju-test-settings" not created by application "test" already exists`)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceWithCustomResources(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.CustomResourceDefinitions = []caas.CustomResourceDefinition{{
+		Kind:    "TFJob",
+		Group:   "kubeflow.org",
+		Version: "v1",
+		Scope:   "Namespaced",
+	}}
+	podSpec.CustomResources = []caas.CustomResource{{
+		Kind: "TFJob",
+		Name: "dist-mnist",
+		Spec: map[string]interface{}{"replicas": float64(2)},
+	}}
+
+	numUnits := int32(2)
+	unitSpec, err := provider.MakeUnitSpec("test", &podSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	deploymentArg := &appsv1.Deployment{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-application": "test"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					GenerateName: "juju-application-test-",
+					Labels:       map[string]string{"juju-application": "test"},
+				},
+				Spec: provider.PodSpec(unitSpec),
+			},
+		},
+	}
+	serviceArg := &core.Service{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "juju-test",
+			Labels: map[string]string{"juju-application": "test"}},
+		Spec: core.ServiceSpec{
+			Selector: map[string]string{"juju-application": "test"},
+			Type:     "nodeIP",
+			Ports: []core.ServicePort{
+				{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"},
+				{Port: 8080, Protocol: "TCP", Name: "fred"},
+			},
+		},
+	}
+	crd := apiextensionsv1beta1.CustomResourceDefinition{
+		ObjectMeta: v1.ObjectMeta{Name: "tfjobs.kubeflow.org"},
+		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+			Group:   "kubeflow.org",
+			Version: "v1",
+			Scope:   "Namespaced",
+			Names:   apiextensionsv1beta1.CustomResourceDefinitionNames{Plural: "tfjobs"},
+		},
+	}
+	tfJob := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "kubeflow.org/v1",
+		"kind":       "TFJob",
+		"metadata": map[string]interface{}{
+			"name":      "dist-mnist",
+			"namespace": "test",
+			"labels": map[string]interface{}{
+				"juju-application":       "test",
+				"juju-pod-spec-resource": "true",
+			},
+		},
+		"spec": map[string]interface{}{"replicas": float64(2)},
+	}}
+
+	mockResources := mocks.NewMockNamespaceableResourceInterface(ctrl)
+	mockTFJobs := mocks.NewMockResourceInterface(ctrl)
+	s.mockDynamicClient.EXPECT().Resource(schema.GroupVersionResource{
+		Group: "kubeflow.org", Version: "v1", Resource: "tfjobs",
+	}).AnyTimes().Return(mockResources)
+	mockResources.EXPECT().Namespace("test").AnyTimes().Return(mockTFJobs)
+
+	s.expectPodSpecResourcesPruned(nil, nil, nil, nil, crd)
+	mockTFJobs.EXPECT().DeleteCollection(s.deleteOptions(v1.DeletePropagationForeground), v1.ListOptions{
+		LabelSelector: "juju-application==test,juju-pod-spec-resource==true",
+		FieldSelector: "metadata.name!=dist-mnist",
+	}).Times(1).Return(nil)
+	gomock.InOrder(
+		mockTFJobs.EXPECT().Get("dist-mnist", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		mockTFJobs.EXPECT().Create(tfJob).Times(1).
+			Return(tfJob, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(deploymentArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(serviceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err = s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceUpdatesCustomResources(c *gc.C) {
+	ctrl := s.setupBroker(c)
+	defer ctrl.Finish()
+
+	podSpec := *basicPodspec
+	podSpec.CustomResourceDefinitions = []caas.CustomResourceDefinition{{
+		Kind:    "Mesh",
+		Group:   "example.com",
+		Version: "v1beta1",
+		Scope:   "Cluster",
+	}}
+	podSpec.CustomResources = []caas.CustomResource{{
+		Kind: "Mesh",
+		Name: "default",
+	}}
+
+	existing := &unstructured.Unstructured{}
+	existing.SetResourceVersion("42")
+	mockMeshes := mocks.NewMockNamespaceableResourceInterface(ctrl)
+	s.mockDynamicClient.EXPECT().Resource(schema.GroupVersionResource{
+		Group: "example.com", Version: "v1beta1", Resource: "meshs",
+	}).AnyTimes().Return(mockMeshes)
+
+	s.expectPodSpecResourcesPruned(nil, nil, nil, nil)
+	gomock.InOrder(
+		mockMeshes.EXPECT().Get("default", v1.GetOptions{}).Times(1).
+			Return(existing, nil),
+		mockMeshes.EXPECT().Update(gomock.Any()).Times(1).
+			DoAndReturn(func(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+				c.Check(obj.GetResourceVersion(), gc.Equals, "42")
+				c.Check(obj.GetNamespace(), gc.Equals, "")
+				c.Check(obj.GetLabels(), jc.DeepEquals, map[string]string{
+					"juju-application":       "test",
+					"juju-pod-spec-resource": "true",
+				})
+				return obj, nil
+			}),
+		s.mockStatefulSets.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Update(gomock.Any()).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("juju-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(gomock.Any()).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: &podSpec,
+	}
+	err := s.broker.EnsureService("test", params, 1, application.ConfigAttributes{
+		"kubernetes-service-type": "nodeIP",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *K8sBrokerSuite) TestEnsureCustomResourceDefinitionCreate(c *gc.C) {
 	ctrl := s.setupBroker(c)
 	defer ctrl.Finish()
@@ -323,6 +870,7 @@ func (s *K8sBrokerSuite) TestEnsureCustomResourceDefinitionCreate(c *gc.C) {
 		ObjectMeta: v1.ObjectMeta{
 			Name:      "tfjobs.kubeflow.org",
 			Namespace: "test",
+			Labels:    map[string]string{"juju-application": "test", "juju-pod-spec-resource": "true"},
 		},
 		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
 			Group:   "kubeflow.org",
@@ -371,7 +919,8 @@ func (s *K8sBrokerSuite) TestEnsureCustomResourceDefinitionCreate(c *gc.C) {
 	}
 
 	gomock.InOrder(
-		s.mockCustomResourceDefinition.EXPECT().Update(crd).Times(1).Return(nil, s.k8sNotFoundError()),
+		s.mockCustomResourceDefinition.EXPECT().Get("tfjobs.kubeflow.org", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
 		s.mockCustomResourceDefinition.EXPECT().Create(crd).Times(1).Return(crd, nil),
 	)
 	err := s.broker.EnsureCustomResourceDefinition("test", podSpec)
@@ -428,6 +977,7 @@ func (s *K8sBrokerSuite) TestEnsureCustomResourceDefinitionUpdate(c *gc.C) {
 		ObjectMeta: v1.ObjectMeta{
 			Name:      "tfjobs.kubeflow.org",
 			Namespace: "test",
+			Labels:    map[string]string{"juju-application": "test", "juju-pod-spec-resource": "true"},
 		},
 		Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
 			Group:   "kubeflow.org",
@@ -474,8 +1024,23 @@ func (s *K8sBrokerSuite) TestEnsureCustomResourceDefinitionUpdate(c *gc.C) {
 			},
 		},
 	}
+	existing := *crd
+	existing.ResourceVersion = "1"
+	existing.Labels = map[string]string{
+		"juju-application": "other",
+		"owner":            "someone",
+	}
+	updated := *crd
+	updated.ResourceVersion = "1"
+	updated.Labels = map[string]string{
+		"juju-application":       "test",
+		"juju-pod-spec-resource": "true",
+		"owner":                  "someone",
+	}
 	gomock.InOrder(
-		s.mockCustomResourceDefinition.EXPECT().Update(crd).Times(1).Return(crd, nil),
+		s.mockCustomResourceDefinition.EXPECT().Get("tfjobs.kubeflow.org", v1.GetOptions{}).Times(1).
+			Return(&existing, nil),
+		s.mockCustomResourceDefinition.EXPECT().Update(&updated).Times(1).Return(&updated, nil),
 	)
 	err := s.broker.EnsureCustomResourceDefinition("test", podSpec)
 	c.Assert(err, jc.ErrorIsNil)
@@ -571,6 +1136,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithStorage(c *gc.C) {
 			},
 		}},
 	}
+	s.expectPodSpecResourcesPruned(nil, nil, nil, nil)
 	err = s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
 		"kubernetes-service-type":            "nodeIP",
 		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
@@ -658,6 +1224,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceForDeploymentWithDevices(c *gc.C) {
 			},
 		},
 	}
+	s.expectPodSpecResourcesPruned(nil, nil, nil, nil)
 	err = s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
 		"kubernetes-service-type":            "nodeIP",
 		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
@@ -774,6 +1341,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceForStatefulSetWithDevices(c *gc.C) {
 			},
 		},
 	}
+	s.expectPodSpecResourcesPruned(nil, nil, nil, nil)
 	err = s.broker.EnsureService("test", params, 2, application.ConfigAttributes{
 		"kubernetes-service-type":            "nodeIP",
 		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
@@ -0,0 +1,451 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	rbac "k8s.io/api/rbac/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/juju/juju/caas"
+)
+
+// labelPodSpecResource marks the resources created on behalf of an
+// application's pod spec, so that they can be garbage collected when
+// they are removed from the pod spec or the application is removed.
+const labelPodSpecResource = "juju-pod-spec-resource"
+
+func podSpecResourceLabels(appName string) map[string]string {
+	return map[string]string{
+		labelApplication:     appName,
+		labelPodSpecResource: "true",
+	}
+}
+
+func podSpecResourceSelector(appName string) string {
+	return fmt.Sprintf("%v,%v==true", applicationSelector(appName), labelPodSpecResource)
+}
+
+// excludeNamesSelector returns a field selector which matches all
+// resources other than those with the specified names.
+func excludeNamesSelector(names []string) string {
+	sort.Strings(names)
+	selectors := make([]fields.Selector, len(names))
+	for i, name := range names {
+		selectors[i] = fields.OneTermNotEqualSelector("metadata.name", name)
+	}
+	return fields.AndSelectors(selectors...).String()
+}
+
+func (k *kubernetesClient) podSpecResourceListOptions(appName string, keep []string) v1.ListOptions {
+	return v1.ListOptions{
+		LabelSelector: podSpecResourceSelector(appName),
+		FieldSelector: excludeNamesSelector(keep),
+	}
+}
+
+// ensurePodSpecResources creates or updates the service account, roles,
+// config maps, secrets and custom resources declared in the pod spec, and
+// deletes any that were previously created for the application but are no
+// longer declared.
+func (k *kubernetesClient) ensurePodSpecResources(appName string, spec *caas.PodSpec) error {
+	if err := k.ensureServiceAccount(appName, spec.ServiceAccount); err != nil {
+		return errors.Annotate(err, "ensuring service account")
+	}
+	if err := k.ensureConfigMaps(appName, spec.ConfigMaps); err != nil {
+		return errors.Annotate(err, "ensuring config maps")
+	}
+	if err := k.ensureSecrets(appName, spec.Secrets); err != nil {
+		return errors.Annotate(err, "ensuring secrets")
+	}
+	if err := k.ensureCustomResources(appName, spec); err != nil {
+		return errors.Annotate(err, "ensuring custom resources")
+	}
+	return nil
+}
+
+// deletePodSpecResources deletes all resources created on behalf of the
+// application's pod spec.
+func (k *kubernetesClient) deletePodSpecResources(appName string) error {
+	listOptions := k.podSpecResourceListOptions(appName, nil)
+	deleteOptions := &v1.DeleteOptions{PropagationPolicy: &defaultPropagationPolicy}
+	rbacV1 := k.RbacV1()
+	if err := rbacV1.RoleBindings(k.namespace).DeleteCollection(deleteOptions, listOptions); err != nil {
+		return errors.Annotate(err, "deleting role bindings")
+	}
+	if err := rbacV1.Roles(k.namespace).DeleteCollection(deleteOptions, listOptions); err != nil {
+		return errors.Annotate(err, "deleting roles")
+	}
+	coreV1 := k.CoreV1()
+	if err := coreV1.ServiceAccounts(k.namespace).DeleteCollection(deleteOptions, listOptions); err != nil {
+		return errors.Annotate(err, "deleting service accounts")
+	}
+	if err := coreV1.ConfigMaps(k.namespace).DeleteCollection(deleteOptions, listOptions); err != nil {
+		return errors.Annotate(err, "deleting config maps")
+	}
+	if err := coreV1.Secrets(k.namespace).DeleteCollection(deleteOptions, listOptions); err != nil {
+		return errors.Annotate(err, "deleting secrets")
+	}
+	if err := k.pruneCustomResources(appName, nil); err != nil {
+		return errors.Annotate(err, "deleting custom resources")
+	}
+	return nil
+}
+
+func (k *kubernetesClient) ensureServiceAccount(appName string, spec *caas.ServiceAccountSpec) error {
+	var (
+		accountNames []string
+		roleNames    []string
+	)
+	if spec != nil {
+		accountName := serviceAccountName(appName)
+		accountNames = append(accountNames, accountName)
+		err := k.ensureK8sServiceAccount(&core.ServiceAccount{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      accountName,
+				Namespace: k.namespace,
+				Labels:    podSpecResourceLabels(appName),
+			},
+			AutomountServiceAccountToken: spec.AutomountServiceAccountToken,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, role := range spec.Roles {
+			name := roleName(appName, role.Name)
+			roleNames = append(roleNames, name)
+			if err := k.ensureRole(appName, name, role.Rules); err != nil {
+				return errors.Annotatef(err, "ensuring role %q", role.Name)
+			}
+			if err := k.ensureRoleBinding(appName, name, accountName); err != nil {
+				return errors.Annotatef(err, "ensuring role binding for %q", role.Name)
+			}
+		}
+	}
+
+	// Remove anything which is no longer in the spec.
+	deleteOptions := &v1.DeleteOptions{PropagationPolicy: &defaultPropagationPolicy}
+	rbacV1 := k.RbacV1()
+	err := rbacV1.RoleBindings(k.namespace).DeleteCollection(
+		deleteOptions, k.podSpecResourceListOptions(appName, roleNames))
+	if err != nil {
+		return errors.Annotate(err, "deleting role bindings")
+	}
+	err = rbacV1.Roles(k.namespace).DeleteCollection(
+		deleteOptions, k.podSpecResourceListOptions(appName, roleNames))
+	if err != nil {
+		return errors.Annotate(err, "deleting roles")
+	}
+	err = k.CoreV1().ServiceAccounts(k.namespace).DeleteCollection(
+		deleteOptions, k.podSpecResourceListOptions(appName, accountNames))
+	return errors.Annotate(err, "deleting service accounts")
+}
+
+func (k *kubernetesClient) ensureK8sServiceAccount(sa *core.ServiceAccount) error {
+	accounts := k.CoreV1().ServiceAccounts(k.namespace)
+	_, err := accounts.Update(sa)
+	if k8serrors.IsNotFound(err) {
+		_, err = accounts.Create(sa)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureRole(appName, name string, rules []caas.PolicyRule) error {
+	role := &rbac.Role{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: k.namespace,
+			Labels:    podSpecResourceLabels(appName),
+		},
+	}
+	for _, rule := range rules {
+		role.Rules = append(role.Rules, rbac.PolicyRule{
+			APIGroups:     rule.APIGroups,
+			Resources:     rule.Resources,
+			ResourceNames: rule.ResourceNames,
+			Verbs:         rule.Verbs,
+		})
+	}
+	roles := k.RbacV1().Roles(k.namespace)
+	_, err := roles.Update(role)
+	if k8serrors.IsNotFound(err) {
+		_, err = roles.Create(role)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureRoleBinding(appName, name, accountName string) error {
+	binding := &rbac.RoleBinding{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      name,
+			Namespace: k.namespace,
+			Labels:    podSpecResourceLabels(appName),
+		},
+		Subjects: []rbac.Subject{{
+			Kind:      rbac.ServiceAccountKind,
+			Name:      accountName,
+			Namespace: k.namespace,
+		}},
+		RoleRef: rbac.RoleRef{
+			APIGroup: rbac.GroupName,
+			Kind:     "Role",
+			Name:     name,
+		},
+	}
+	bindings := k.RbacV1().RoleBindings(k.namespace)
+	_, err := bindings.Update(binding)
+	if k8serrors.IsNotFound(err) {
+		_, err = bindings.Create(binding)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureConfigMaps(appName string, configMaps map[string]caas.ConfigMap) error {
+	var names []string
+	for name := range configMaps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var keep []string
+	for _, name := range names {
+		configMap := &core.ConfigMap{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      podSpecResourceName(appName, name),
+				Namespace: k.namespace,
+				Labels:    podSpecResourceLabels(appName),
+			},
+			Data: configMaps[name],
+		}
+		keep = append(keep, configMap.Name)
+		if err := k.ensurePodSpecConfigMap(appName, configMap); err != nil {
+			return errors.Annotatef(err, "ensuring config map %q", name)
+		}
+	}
+	err := k.CoreV1().ConfigMaps(k.namespace).DeleteCollection(
+		&v1.DeleteOptions{PropagationPolicy: &defaultPropagationPolicy},
+		k.podSpecResourceListOptions(appName, keep),
+	)
+	return errors.Trace(err)
+}
+
+// ensurePodSpecConfigMap creates or updates the config map, refusing to
+// replace one which wasn't created for the application.
+func (k *kubernetesClient) ensurePodSpecConfigMap(appName string, configMap *core.ConfigMap) error {
+	configMaps := k.CoreV1().ConfigMaps(k.namespace)
+	existing, err := configMaps.Get(configMap.Name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = configMaps.Create(configMap)
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkPodSpecResourceOwner(appName, existing.ObjectMeta); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = configMaps.Update(configMap)
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureSecrets(appName string, secrets []caas.Secret) error {
+	var keep []string
+	for _, s := range secrets {
+		secret := &core.Secret{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      podSpecResourceName(appName, s.Name),
+				Namespace: k.namespace,
+				Labels:    podSpecResourceLabels(appName),
+			},
+			Type: core.SecretTypeOpaque,
+			Data: make(map[string][]byte),
+		}
+		for key, value := range s.Data {
+			secret.Data[key] = []byte(value)
+		}
+		keep = append(keep, secret.Name)
+		if err := k.ensurePodSpecSecret(appName, secret); err != nil {
+			return errors.Annotatef(err, "ensuring secret %q", s.Name)
+		}
+	}
+	err := k.CoreV1().Secrets(k.namespace).DeleteCollection(
+		&v1.DeleteOptions{PropagationPolicy: &defaultPropagationPolicy},
+		k.podSpecResourceListOptions(appName, keep),
+	)
+	return errors.Trace(err)
+}
+
+// ensurePodSpecSecret creates or updates the secret, refusing to
+// replace one which wasn't created for the application.
+func (k *kubernetesClient) ensurePodSpecSecret(appName string, secret *core.Secret) error {
+	secrets := k.CoreV1().Secrets(k.namespace)
+	existing, err := secrets.Get(secret.Name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = secrets.Create(secret)
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkPodSpecResourceOwner(appName, existing.ObjectMeta); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = secrets.Update(secret)
+	return errors.Trace(err)
+}
+
+// checkPodSpecResourceOwner returns an error if the existing resource
+// wasn't created for the application's pod spec.
+func checkPodSpecResourceOwner(appName string, existing v1.ObjectMeta) error {
+	if existing.Labels[labelApplication] != appName || existing.Labels[labelPodSpecResource] != "true" {
+		return errors.AlreadyExistsf("%q not created by application %q", existing.Name, appName)
+	}
+	return nil
+}
+
+func (k *kubernetesClient) ensureK8sSecret(secret *core.Secret) error {
+	secrets := k.CoreV1().Secrets(k.namespace)
+	_, err := secrets.Update(secret)
+	if k8serrors.IsNotFound(err) {
+		_, err = secrets.Create(secret)
+	}
+	return errors.Trace(err)
+}
+
+// ensureCustomResources creates or updates the custom resources declared
+// in the pod spec. Custom resources which were previously created for the
+// application but are no longer declared are deleted.
+func (k *kubernetesClient) ensureCustomResources(appName string, spec *caas.PodSpec) error {
+	crds := make(map[string]*apiextensionsv1beta1.CustomResourceDefinition)
+	for _, t := range spec.CustomResourceDefinitions {
+		crds[t.Kind] = k.customResourceDefinition(appName, &t)
+	}
+	keep := make(map[string][]string)
+	for _, cr := range spec.CustomResources {
+		crd, ok := crds[cr.Kind]
+		if !ok {
+			return errors.NotValidf("custom resource %q of undeclared kind %q", cr.Name, cr.Kind)
+		}
+		keep[crd.Name] = append(keep[crd.Name], cr.Name)
+		if err := k.ensureCustomResource(appName, crd, cr); err != nil {
+			return errors.Annotatef(err, "ensuring %s %q", cr.Kind, cr.Name)
+		}
+	}
+	return errors.Trace(k.pruneCustomResources(appName, keep))
+}
+
+func (k *kubernetesClient) ensureCustomResource(
+	appName string, crd *apiextensionsv1beta1.CustomResourceDefinition, cr caas.CustomResource,
+) error {
+	obj := &unstructured.Unstructured{Object: make(map[string]interface{})}
+	obj.SetAPIVersion(crd.Spec.Group + "/" + crd.Spec.Version)
+	obj.SetKind(cr.Kind)
+	obj.SetName(cr.Name)
+	if crd.Spec.Scope != apiextensionsv1beta1.ClusterScoped {
+		obj.SetNamespace(k.namespace)
+	}
+	obj.SetLabels(podSpecResourceLabels(appName))
+	if cr.Spec != nil {
+		obj.Object["spec"] = cr.Spec
+	}
+
+	// Unlike most resources, custom resources can only be
+	// updated if the version being replaced is specified.
+	resources := k.customResourceClient(crd)
+	existing, err := resources.Get(cr.Name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		_, err = resources.Create(obj)
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	_, err = resources.Update(obj)
+	return errors.Trace(err)
+}
+
+// pruneCustomResources deletes the custom resources created for the
+// application, other than those to keep, which are keyed on the name
+// of their custom resource definition.
+func (k *kubernetesClient) pruneCustomResources(appName string, keep map[string][]string) error {
+	crds, err := k.apiextensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().List(v1.ListOptions{
+		LabelSelector: podSpecResourceSelector(appName),
+	})
+	if err != nil {
+		return errors.Annotate(err, "listing custom resource definitions")
+	}
+	deleteOptions := &v1.DeleteOptions{PropagationPolicy: &defaultPropagationPolicy}
+	for _, crd := range crds.Items {
+		err := k.customResourceClient(&crd).DeleteCollection(
+			deleteOptions, k.podSpecResourceListOptions(appName, keep[crd.Name]))
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting %s", crd.Spec.Names.Plural)
+		}
+	}
+	return nil
+}
+
+func (k *kubernetesClient) customResourceClient(crd *apiextensionsv1beta1.CustomResourceDefinition) dynamic.ResourceInterface {
+	resources := k.dynamicClient.Resource(schema.GroupVersionResource{
+		Group:    crd.Spec.Group,
+		Version:  crd.Spec.Version,
+		Resource: crd.Spec.Names.Plural,
+	})
+	if crd.Spec.Scope == apiextensionsv1beta1.ClusterScoped {
+		return resources
+	}
+	return resources.Namespace(k.namespace)
+}
+
+func serviceAccountName(appName string) string {
+	return deploymentName(appName)
+}
+
+func roleName(appName, name string) string {
+	return fmt.Sprintf("%v-%v", deploymentName(appName), name)
+}
+
+// podSpecResourceName returns the name of the config map or secret
+// created for the named pod spec resource. Names are prefixed so that
+// applications in the same namespace can't clash.
+func podSpecResourceName(appName, name string) string {
+	return fmt.Sprintf("%v-%v", deploymentName(appName), name)
+}
+
+// podSpecEnvFrom returns the env sources with any references to config
+// maps or secrets declared in the pod spec mapped to the names of the
+// resources created for them.
+func podSpecEnvFrom(appName string, podSpec *caas.PodSpec, envFrom []core.EnvFromSource) []core.EnvFromSource {
+	if len(envFrom) == 0 {
+		return envFrom
+	}
+	secrets := make(map[string]bool)
+	for _, s := range podSpec.Secrets {
+		secrets[s.Name] = true
+	}
+	result := make([]core.EnvFromSource, len(envFrom))
+	for i, source := range envFrom {
+		if ref := source.ConfigMapRef; ref != nil {
+			if _, ok := podSpec.ConfigMaps[ref.Name]; ok {
+				mapped := *ref
+				mapped.Name = podSpecResourceName(appName, ref.Name)
+				source.ConfigMapRef = &mapped
+			}
+		}
+		if ref := source.SecretRef; ref != nil && secrets[ref.Name] {
+			mapped := *ref
+			mapped.Name = podSpecResourceName(appName, ref.Name)
+			source.SecretRef = &mapped
+		}
+		result[i] = source
+	}
+	return result
+}
@@ -22,19 +22,38 @@ type k8sContainer struct {
 }
 
 type k8sContainers struct {
-	Containers []k8sContainer `json:"containers"`
+	Containers      []k8sContainer        `json:"containers"`
+	InitContainers  []k8sContainer        `json:"initContainers,omitempty"`
+	CustomResources []caas.CustomResource `json:"customResources,omitempty"`
 }
 
 // K8sContainerSpec is a subset of v1.Container which defines
 // attributes we expose for charms to set.
 type K8sContainerSpec struct {
-	LivenessProbe   *core.Probe     `json:"livenessProbe,omitempty"`
-	ReadinessProbe  *core.Probe     `json:"readinessProbe,omitempty"`
-	ImagePullPolicy core.PullPolicy `json:"imagePullPolicy,omitempty"`
+	LivenessProbe   *core.Probe          `json:"livenessProbe,omitempty"`
+	ReadinessProbe  *core.Probe          `json:"readinessProbe,omitempty"`
+	ImagePullPolicy core.PullPolicy      `json:"imagePullPolicy,omitempty"`
+	EnvFrom         []core.EnvFromSource `json:"envFrom,omitempty"`
 }
 
 // Validate is defined on ProviderContainer.
-func (*K8sContainerSpec) Validate() error {
+func (spec *K8sContainerSpec) Validate() error {
+	for _, source := range spec.EnvFrom {
+		switch {
+		case source.ConfigMapRef != nil && source.SecretRef != nil:
+			return errors.NotValidf("envFrom with both a config map and a secret")
+		case source.ConfigMapRef != nil:
+			if source.ConfigMapRef.Name == "" {
+				return errors.NotValidf("envFrom with missing config map name")
+			}
+		case source.SecretRef != nil:
+			if source.SecretRef.Name == "" {
+				return errors.NotValidf("envFrom with missing secret name")
+			}
+		default:
+			return errors.NotValidf("envFrom with no config map or secret")
+		}
+	}
 	return nil
 }
 
@@ -60,12 +79,21 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 	}
 
 	// Compose the result.
-	spec.Containers = make([]caas.ContainerSpec, len(containers.Containers))
-	for i, c := range containers.Containers {
-		if err := c.Validate(); err != nil {
-			return nil, errors.Trace(err)
-		}
-		spec.Containers[i] = caas.ContainerSpec{
+	spec.Containers = containerSpecs(containers.Containers)
+	spec.InitContainers = containerSpecs(containers.InitContainers)
+	spec.CustomResources = containers.CustomResources
+	return &spec, nil
+}
+
+// containerSpecs converts the parsed k8s containers to container specs.
+// They are validated along with the rest of the pod spec.
+func containerSpecs(containers []k8sContainer) []caas.ContainerSpec {
+	if len(containers) == 0 {
+		return nil
+	}
+	specs := make([]caas.ContainerSpec, len(containers))
+	for i, c := range containers {
+		specs[i] = caas.ContainerSpec{
 			ImageDetails: c.ImageDetails,
 			Name:         c.Name,
 			Image:        c.Image,
@@ -77,8 +105,8 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 			Files:        c.Files,
 		}
 		if c.K8sContainerSpec != nil {
-			spec.Containers[i].ProviderContainer = c.K8sContainerSpec
+			specs[i].ProviderContainer = c.K8sContainerSpec
 		}
 	}
-	return &spec, nil
+	return specs
 }
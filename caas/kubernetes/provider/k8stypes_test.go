@@ -143,3 +143,186 @@ foo: bar
 			},
 		}}})
 }
+
+func (s *ContainersSuite) TestParseResources(c *gc.C) {
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+serviceAccount:
+  automountServiceAccountToken: true
+  roles:
+    - name: reader
+      rules:
+        - apiGroups: [""]
+          resources: ["pods"]
+          verbs: ["get", "watch", "list"]
+configMaps:
+  settings:
+    foo: bar
+secrets:
+  - name: creds
+    data:
+      password: hunter2
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	automount := true
+	c.Assert(spec.ServiceAccount, jc.DeepEquals, &caas.ServiceAccountSpec{
+		AutomountServiceAccountToken: &automount,
+		Roles: []caas.Role{{
+			Name: "reader",
+			Rules: []caas.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"pods"},
+				Verbs:     []string{"get", "watch", "list"},
+			}},
+		}},
+	})
+	c.Assert(spec.ConfigMaps, jc.DeepEquals, map[string]caas.ConfigMap{
+		"settings": {"foo": "bar"},
+	})
+	c.Assert(spec.Secrets, jc.DeepEquals, []caas.Secret{{
+		Name: "creds",
+		Data: map[string]string{"password": "hunter2"},
+	}})
+}
+
+func (s *ContainersSuite) TestParseInitContainersAndCustomResources(c *gc.C) {
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+    envFrom:
+      - secretRef:
+          name: creds
+      - configMapRef:
+          name: settings
+initContainers:
+  - name: migrate
+    image: gitlab/migrate
+    command: ["sh", "-c"]
+    args: ["migrate"]
+customResourceDefinition:
+  - kind: TFJob
+    group: kubeflow.org
+    version: v1
+    scope: Namespaced
+customResources:
+  - kind: TFJob
+    name: dist-mnist
+    spec:
+      cleanPodPolicy: None
+      replicas: 2
+`[1:]
+
+	spec, err := provider.NewProvider().ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Containers, gc.HasLen, 1)
+	c.Assert(spec.Containers[0].ProviderContainer, jc.DeepEquals, &provider.K8sContainerSpec{
+		EnvFrom: []core.EnvFromSource{{
+			SecretRef: &core.SecretEnvSource{
+				LocalObjectReference: core.LocalObjectReference{Name: "creds"},
+			},
+		}, {
+			ConfigMapRef: &core.ConfigMapEnvSource{
+				LocalObjectReference: core.LocalObjectReference{Name: "settings"},
+			},
+		}},
+	})
+	c.Assert(spec.InitContainers, jc.DeepEquals, []caas.ContainerSpec{{
+		Name:    "migrate",
+		Image:   "gitlab/migrate",
+		Command: []string{"sh", "-c"},
+		Args:    []string{"migrate"},
+	}})
+	c.Assert(spec.CustomResources, jc.DeepEquals, []caas.CustomResource{{
+		Kind: "TFJob",
+		Name: "dist-mnist",
+		Spec: map[string]interface{}{
+			"cleanPodPolicy": "None",
+			"replicas":       float64(2),
+		},
+	}})
+}
+
+func (s *ContainersSuite) TestValidateResources(c *gc.C) {
+	for i, t := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: `
+serviceAccount:
+  roles:
+    - rules:
+        - verbs: ["get"]
+`,
+		err: "missing role name not valid",
+	}, {
+		spec: `
+serviceAccount:
+  roles:
+    - name: reader
+`,
+		err: `role "reader" with no rules not valid`,
+	}, {
+		spec: `
+serviceAccount:
+  roles:
+    - name: reader
+      rules:
+        - resources: ["pods"]
+`,
+		err: `rule with no verbs in role "reader" not valid`,
+	}, {
+		spec: `
+secrets:
+  - data:
+      foo: bar
+`,
+		err: "missing secret name not valid",
+	}, {
+		spec: `
+secrets:
+  - name: creds
+  - name: creds
+`,
+		err: `duplicate secret name "creds" not valid`,
+	}, {
+		spec: `
+customResources:
+  - kind: TFJob
+    name: dist-mnist
+`,
+		err: `custom resource "dist-mnist" of undeclared kind "TFJob" not valid`,
+	}, {
+		spec: `
+customResourceDefinition:
+  - kind: TFJob
+    group: kubeflow.org
+    version: v1
+    scope: Namespaced
+customResources:
+  - kind: TFJob
+`,
+		err: `missing name for custom resource of kind "TFJob" not valid`,
+	}, {
+		spec: `
+initContainers:
+  - name: migrate
+`,
+		err: "spec image details is missing",
+	}, {
+		spec: `
+    envFrom:
+      - prefix: DB_
+`,
+		err: "envFrom with no config map or secret not valid",
+	}} {
+		c.Logf("test %d", i)
+		specStr := "containers:\n  - name: gitlab\n    image: gitlab/latest" + t.spec
+		_, err := provider.NewProvider().ParsePodSpec(specStr)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/jsonschema"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 
@@ -31,17 +32,21 @@ func (kubernetesEnvironProvider) Version() int {
 	return 0
 }
 
-func newK8sClient(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, error) {
+func newK8sClient(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, dynamic.Interface, error) {
 	k8sClient, err := kubernetes.NewForConfig(c)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	var apiextensionsclient *apiextensionsclientset.Clientset
 	apiextensionsclient, err = apiextensionsclientset.NewForConfig(c)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	return k8sClient, apiextensionsclient, nil
+	dynamicClient, err := dynamic.NewForConfig(c)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return k8sClient, apiextensionsclient, dynamicClient, nil
 }
 
 // Open is part of the ContainerEnvironProvider interface.
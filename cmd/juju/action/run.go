@@ -57,6 +57,9 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+When waiting for results with --wait, any progress messages logged by the
+action with action-log are shown as they arrive.
+
 Examples:
 
 $ juju run-action mysql/3 backup --wait
@@ -308,12 +311,41 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(c.wait.d)
 	}
 
-	for _, result := range results.Results {
+	// Show the progress messages logged by the actions while waiting,
+	// identifying the unit if there is more than one.
+	progress := make([]*actionProgress, len(results.Results))
+	defer func() {
+		for _, p := range progress {
+			p.stop(nil)
+		}
+	}()
+	for i, result := range results.Results {
+		tag, err := names.ParseActionTag(result.Action.Tag)
+		if err != nil {
+			return err
+		}
+		var prefix string
+		if len(results.Results) > 1 {
+			unitTag, err := names.ParseUnitTag(result.Action.Receiver)
+			if err != nil {
+				return err
+			}
+			prefix = unitTag.Id() + ": "
+		}
+		progress[i], err = watchActionProgress(api, ctx.Stderr, tag.Id(), prefix)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	for i, result := range results.Results {
 		tag, err := names.ParseActionTag(result.Action.Tag)
 		if err != nil {
 			return err
 		}
 		result, err = GetActionResult(api, tag.Id(), wait)
+		progress[i].stop(result.Log)
+		progress[i] = nil
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -4,16 +4,21 @@
 package action
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"regexp"
 	"time"
 
 	"github.com/juju/cmd"
 	errors "github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/watcher"
 )
 
 func NewShowOutputCommand() cmd.Command {
@@ -27,6 +32,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
 const showOutputDoc = `
@@ -38,6 +44,10 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+To follow the progress messages logged by the action with action-log while
+it runs, use the --watch flag. This waits for the action to finish, unless
+a timeout is given with --wait.
 `
 
 // Set up the output.
@@ -45,6 +55,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.watch, "watch", false, "Show progress messages as they are logged")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -90,6 +101,11 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
+	if c.watch && waitDur.Nanoseconds() < 0 {
+		// Watching without a timeout waits indefinitely.
+		waitDur = 0
+	}
+
 	wait := time.NewTimer(0 * time.Second)
 
 	switch {
@@ -104,7 +120,22 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
+	var progress *actionProgress
+	if c.watch {
+		actionTag, err := getActionTagByPrefix(api, c.requestedId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		progress, err = watchActionProgress(api, ctx.Stderr, actionTag.Id(), "")
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	result, err := GetActionResult(api, c.requestedId, wait)
+	if progress != nil {
+		progress.stop(result.Log)
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -112,6 +143,93 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, FormatActionResult(result))
 }
 
+// actionProgress writes the progress messages logged by an action as
+// they are reported by the API.
+type actionProgress struct {
+	out     io.Writer
+	prefix  string
+	watcher watcher.StringsWatcher
+	lastSeq int
+	stopped chan struct{}
+	done    chan struct{}
+}
+
+// watchActionProgress starts writing the progress messages logged by
+// the action with the given id to out, each preceded by prefix. If the
+// controller does not support watching action progress, it returns a
+// nil *actionProgress, which is safe to stop.
+func watchActionProgress(api APIClient, out io.Writer, actionId, prefix string) (*actionProgress, error) {
+	w, err := api.WatchActionProgress(actionId)
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not showing action progress: %v", err)
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	p := &actionProgress{
+		out:     out,
+		prefix:  prefix,
+		watcher: w,
+		stopped: make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go p.loop()
+	return p, nil
+}
+
+func (p *actionProgress) loop() {
+	defer close(p.done)
+	for {
+		select {
+		case <-p.stopped:
+			return
+		case changes, ok := <-p.watcher.Changes():
+			if !ok {
+				return
+			}
+			for _, change := range changes {
+				var message params.ActionMessage
+				if err := json.Unmarshal([]byte(change), &message); err != nil {
+					logger.Warningf("cannot decode action message %q: %v", change, err)
+					continue
+				}
+				p.write(message)
+			}
+		}
+	}
+}
+
+func (p *actionProgress) write(message params.ActionMessage) {
+	fmt.Fprintf(p.out, "%s%s\n", p.prefix, formatActionMessage(message))
+	p.lastSeq = message.Seq
+}
+
+// stop stops watching for progress messages, and then writes any of the
+// final messages of the action that have not yet been written.
+func (p *actionProgress) stop(final []params.ActionMessage) {
+	if p == nil {
+		return
+	}
+	close(p.stopped)
+	<-p.done
+	if err := worker.Stop(p.watcher); err != nil {
+		logger.Debugf("stopping action progress watcher: %v", err)
+	}
+	// Only the most recent messages are kept, so the final messages
+	// are matched with those already written by sequence number
+	// rather than position.
+	for _, message := range final {
+		if message.Seq > p.lastSeq {
+			p.write(message)
+		}
+	}
+}
+
+// formatActionMessage formats a progress message for display.
+func formatActionMessage(message params.ActionMessage) string {
+	return message.Timestamp.Local().Format("2006-01-02 15:04:05") + " " + message.Message
+}
+
 // GetActionResult tries to repeatedly fetch an action until it is
 // in a completed state and then it returns it.
 // It waits for a maximum of "wait" before returning with the latest action status.
@@ -204,6 +322,13 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		log := make([]string, len(result.Log))
+		for i, message := range result.Log {
+			log[i] = formatActionMessage(message)
+		}
+		response["log"] = log
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
@@ -5,7 +5,9 @@ package action_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -290,6 +292,59 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestWatch(c *gc.C) {
+	// Older messages have been discarded, so the sequence numbers
+	// don't match the positions in the final log.
+	first := params.ActionMessage{
+		Seq:       1500,
+		Timestamp: time.Date(2015, time.February, 14, 8, 14, 0, 0, time.UTC),
+		Message:   "starting backup",
+	}
+	second := params.ActionMessage{
+		Seq:       1501,
+		Timestamp: time.Date(2015, time.February, 14, 8, 15, 0, 0, time.UTC),
+		Message:   "backup copied",
+	}
+	firstJSON, err := json.Marshal(first)
+	c.Assert(err, gc.IsNil)
+
+	client := makeFakeClient(
+		0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status:    "completed",
+			Log:       []params.ActionMessage{first, second},
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	// The watcher only reports the first message; the second
+	// is taken from the final result of the action.
+	client.actionLogs = []string{string(firstJSON)}
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch")
+	c.Assert(err, gc.IsNil)
+
+	format := func(m params.ActionMessage) string {
+		return m.Timestamp.Local().Format("2006-01-02 15:04:05") + " " + m.Message
+	}
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, format(first)+"\n"+format(second)+"\n")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, fmt.Sprintf(`
+log:
+- %s
+- %s
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
+`[1:], format(first), format(second)))
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
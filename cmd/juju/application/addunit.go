@@ -85,19 +85,23 @@ type UnitCommandBase struct {
 	// AttachStorage is a list of storage IDs, identifying storage to
 	// attach to the unit created by deploy.
 	AttachStorage []string
+	// AttachSnapshots is a list of volume snapshot IDs, identifying
+	// snapshots from which to restore storage for the unit created
+	// by deploy.
+	AttachSnapshots []string
 }
 
 func (c *UnitCommandBase) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumUnits, "num-units", 1, "")
 	f.StringVar(&c.PlacementSpec, "to", "", "The machine and/or container to deploy the unit in (bypasses constraints)")
-	f.Var(attachStorageFlag{&c.AttachStorage}, "attach-storage", "Existing storage to attach to the deployed unit (not available on kubernetes models)")
+	f.Var(attachStorageFlag{&c.AttachStorage, &c.AttachSnapshots}, "attach-storage", "Existing storage, or volume snapshots to restore storage from, to attach to the deployed unit (not available on kubernetes models)")
 }
 
 func (c *UnitCommandBase) Init(args []string) error {
 	if c.NumUnits < 1 {
 		return errors.New("--num-units must be a positive integer")
 	}
-	if len(c.AttachStorage) > 0 && c.NumUnits != 1 {
+	if len(c.AttachStorage)+len(c.AttachSnapshots) > 0 && c.NumUnits != 1 {
 		return errors.New("--attach-storage cannot be used with -n")
 	}
 	if c.PlacementSpec != "" {
@@ -170,6 +174,9 @@ func (c *addUnitCommand) Init(args []string) error {
 	if err := cmd.CheckEmpty(args[1:]); err != nil {
 		return err
 	}
+	if len(c.AttachSnapshots) > 0 {
+		return errors.New("add-unit cannot restore storage from volume snapshots")
+	}
 
 	return c.UnitCommandBase.Init(args)
 }
@@ -106,6 +106,9 @@ var initAddUnitErrorTests = []struct {
 	}, {
 		args: []string{"some-application-name", "--attach-storage", "foo/0", "-n", "2"},
 		err:  `--attach-storage cannot be used with -n`,
+	}, {
+		args: []string{"some-application-name", "--attach-storage", "0@1"},
+		err:  `add-unit cannot restore storage from volume snapshots`,
 	},
 }
 
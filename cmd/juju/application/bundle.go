@@ -73,8 +73,47 @@ func deployBundle(
 	bundleMachines map[string]string,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
 
+	if err := composeAndVerifyBundle(ctx, bundleDir, data, bundleOverlayFile); err != nil {
+		return nil, errors.Trace(err)
+	}
+	exposedEndpoints, err := readBundleExposedEndpoints(bundleOverlayFile...)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for appName := range exposedEndpoints {
+		spec, ok := data.Applications[appName]
+		if !ok {
+			return nil, errors.Errorf("exposed-endpoints specified for unknown application %q", appName)
+		}
+		spec.Expose = true
+	}
+
+	// TODO: move bundle parsing and checking into the handler.
+	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleStorage, bundleDevices)
+	h.exposedEndpoints = exposedEndpoints
+	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.resolveCharmsAndEndpoints(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.getChanges(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.handleChanges(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return h.macaroons, nil
+
+}
+
+// composeAndVerifyBundle applies the overlays and includes to the
+// bundle data, then verifies the result. If bundleDir is empty the
+// bundle is not local, and includes are resolved relative to the
+// current directory.
+func composeAndVerifyBundle(ctx *cmd.Context, bundleDir string, data *charm.BundleData, bundleOverlayFile []string) error {
 	if err := processBundleOverlay(data, bundleOverlayFile...); err != nil {
-		return nil, err
+		return err
 	}
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
@@ -92,13 +131,13 @@ func deployBundle(
 	if bundleDir == "" {
 		// Process includes in the bundle data.
 		if err := processBundleIncludes(ctx.Dir, data); err != nil {
-			return nil, errors.Annotate(err, "unable to process includes")
+			return errors.Annotate(err, "unable to process includes")
 		}
 		verifyError = data.Verify(verifyConstraints, verifyStorage, verifyDevices)
 	} else {
 		// Process includes in the bundle data.
 		if err := processBundleIncludes(bundleDir, data); err != nil {
-			return nil, errors.Annotate(err, "unable to process includes")
+			return errors.Annotate(err, "unable to process includes")
 		}
 		verifyError = data.VerifyLocal(bundleDir, verifyConstraints, verifyStorage, verifyDevices)
 	}
@@ -108,27 +147,11 @@ func deployBundle(
 			for i, err := range verr.Errors {
 				errs[i] = err.Error()
 			}
-			return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+			return errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
 		}
-		return nil, errors.Trace(verifyError)
-	}
-
-	// TODO: move bundle parsing and checking into the handler.
-	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleStorage, bundleDevices)
-	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := h.resolveCharmsAndEndpoints(); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := h.getChanges(); err != nil {
-		return nil, errors.Trace(err)
+		return errors.Trace(verifyError)
 	}
-	if err := h.handleChanges(); err != nil {
-		return nil, errors.Trace(err)
-	}
-	return h.macaroons, nil
-
+	return nil
 }
 
 // bundleHandler provides helpers and the state required to deploy a bundle.
@@ -175,6 +198,10 @@ type bundleHandler struct {
 	// in the bundle itself.
 	bundleDevices map[string]map[string]devices.Constraints
 
+	// exposedEndpoints holds the per-endpoint expose settings for
+	// applications, as specified in the bundle overlays.
+	exposedEndpoints map[string]map[string]params.ExposedEndpoint
+
 	// ctx is the command context, which is used to output messages to the
 	// user, so that the user can keep track of the bundle deployment
 	// progress.
@@ -895,6 +922,12 @@ func (h *bundleHandler) exposeApplication(change *bundlechanges.ExposeChange) er
 	}
 
 	application := resolve(change.Params.Application, h.results)
+	if exposedEndpoints, ok := h.exposedEndpoints[application]; ok {
+		if err := h.api.ExposeEndpoints(application, exposedEndpoints); err != nil {
+			return errors.Annotatef(err, "cannot expose application %s", application)
+		}
+		return nil
+	}
 	if err := h.api.Expose(application); err != nil {
 		return errors.Annotatef(err, "cannot expose application %s", application)
 	}
@@ -1159,17 +1192,9 @@ type bundleOverlayValueExists struct {
 
 func processBundleOverlay(data *charm.BundleData, bundleOverlayFiles ...string) error {
 	for _, filename := range bundleOverlayFiles {
-		bundleOverlayFile, err := utils.NormalizePath(filename)
+		bundleOverlayFile, err := overlayFilePath(filename)
 		if err != nil {
-			return errors.Annotate(err, "unable to normalise bundle overlay file")
-		}
-		// Make sure the filename is absolute.
-		if !filepath.IsAbs(bundleOverlayFile) {
-			cwd, err := os.Getwd()
-			if err != nil {
-				return errors.Trace(err)
-			}
-			bundleOverlayFile = filepath.Clean(filepath.Join(cwd, bundleOverlayFile))
+			return errors.Trace(err)
 		}
 		if err := processSingleBundleOverlay(data, bundleOverlayFile); err != nil {
 			return errors.Trace(err)
@@ -1178,6 +1203,69 @@ func processBundleOverlay(data *charm.BundleData, bundleOverlayFiles ...string)
 	return nil
 }
 
+// overlayFilePath returns the normalised, absolute path of the given
+// bundle overlay file.
+func overlayFilePath(filename string) (string, error) {
+	bundleOverlayFile, err := utils.NormalizePath(filename)
+	if err != nil {
+		return "", errors.Annotate(err, "unable to normalise bundle overlay file")
+	}
+	// Make sure the filename is absolute.
+	if !filepath.IsAbs(bundleOverlayFile) {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		bundleOverlayFile = filepath.Clean(filepath.Join(cwd, bundleOverlayFile))
+	}
+	return bundleOverlayFile, nil
+}
+
+type bundleOverlayExposedEndpoints struct {
+	Applications map[string]*struct {
+		ExposedEndpoints map[string]struct {
+			ExposeToSpaces []string `yaml:"expose-to-spaces"`
+			ExposeToCIDRs  []string `yaml:"expose-to-cidrs"`
+		} `yaml:"exposed-endpoints"`
+	} `yaml:"applications"`
+}
+
+// readBundleExposedEndpoints returns the per-endpoint expose settings
+// specified for applications in the bundle overlays. The bundle format
+// has no notion of these, so they are only read from overlays, where
+// later overlays replace the settings of earlier ones.
+func readBundleExposedEndpoints(bundleOverlayFiles ...string) (map[string]map[string]params.ExposedEndpoint, error) {
+	result := make(map[string]map[string]params.ExposedEndpoint)
+	for _, filename := range bundleOverlayFiles {
+		bundleOverlayFile, err := overlayFilePath(filename)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		content, err := ioutil.ReadFile(bundleOverlayFile)
+		if err != nil {
+			return nil, errors.Annotate(err, "unable to open bundle overlay file")
+		}
+		var overlay bundleOverlayExposedEndpoints
+		if err := yaml.Unmarshal(content, &overlay); err != nil {
+			return nil, errors.Annotatef(err, "unable to read exposed endpoints from bundle overlay file %q", bundleOverlayFile)
+		}
+		for appName, app := range overlay.Applications {
+			if app == nil || len(app.ExposedEndpoints) == 0 {
+				continue
+			}
+			exposedEndpoints := make(map[string]params.ExposedEndpoint)
+			for endpoint, spec := range app.ExposedEndpoints {
+				exposedEndpoints[endpoint] = params.ExposedEndpoint{
+					ExposeToSpaces: spec.ExposeToSpaces,
+					ExposeToCIDRs:  spec.ExposeToCIDRs,
+				}
+			}
+			result[appName] = exposedEndpoints
+		}
+	}
+	return result, nil
+}
+
 func processSingleBundleOverlay(data *charm.BundleData, bundleOverlayFile string) error {
 	config, err := charmrepo.ReadBundleFile(bundleOverlayFile)
 	if err != nil {
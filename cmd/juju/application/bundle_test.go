@@ -908,6 +908,40 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleLocalDeploymentWithBundleO
 	c.Assert(settings["blog-title"], gc.Equals, "magic bundle config")
 }
 
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleWithOverlayExposedEndpoints(c *gc.C) {
+	configFile := filepath.Join(c.MkDir(), "config.yaml")
+	c.Assert(
+		ioutil.WriteFile(
+			configFile, []byte(`
+                applications:
+                    mysql:
+                        exposed-endpoints:
+                            server:
+                                expose-to-cidrs:
+                                    - 10.0.0.0/24
+            `), 0644),
+		jc.ErrorIsNil)
+
+	charmsPath := c.MkDir()
+	mysqlPath := testcharms.Repo.ClonedDirPath(charmsPath, "mysql")
+	err := s.DeployBundleYAML(c, fmt.Sprintf(`
+        series: xenial
+        applications:
+            mysql:
+                charm: %s
+                num_units: 1
+    `, mysqlPath),
+		"--overlay", configFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mysql.IsExposed(), jc.IsTrue)
+	c.Assert(mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleLocalAndCharmStoreCharms(c *gc.C) {
 	charmsPath := c.MkDir()
 	_, wpch := testcharms.UploadCharm(c, s.client, "xenial/wordpress-42", "wordpress")
@@ -0,0 +1,492 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+const (
+	// missingFromBundle and missingFromModel are the values reported
+	// in a diff for entities that only exist on one side.
+	missingFromBundle = "bundle"
+	missingFromModel  = "model"
+)
+
+// bundleDiff describes the differences between a bundle and a model.
+type bundleDiff struct {
+	Applications map[string]*applicationDiff `yaml:"applications,omitempty"`
+	Machines     map[string]*machineDiff     `yaml:"machines,omitempty"`
+	Relations    *relationsDiff              `yaml:"relations,omitempty"`
+}
+
+// Empty returns whether the bundle and the model are equivalent.
+func (d *bundleDiff) Empty() bool {
+	return len(d.Applications) == 0 && len(d.Machines) == 0 && d.Relations == nil
+}
+
+// applicationDiff describes the differences between an application
+// in the bundle and the application of the same name in the model.
+type applicationDiff struct {
+	Missing     string                `yaml:"missing,omitempty"`
+	Charm       *stringDiff           `yaml:"charm,omitempty"`
+	Series      *stringDiff           `yaml:"series,omitempty"`
+	NumUnits    *intDiff              `yaml:"num_units,omitempty"`
+	Constraints *stringDiff           `yaml:"constraints,omitempty"`
+	Expose      *boolDiff             `yaml:"expose,omitempty"`
+	Bindings    map[string]stringDiff `yaml:"bindings,omitempty"`
+	Options     map[string]optionDiff `yaml:"options,omitempty"`
+	Annotations map[string]stringDiff `yaml:"annotations,omitempty"`
+}
+
+func (d *applicationDiff) empty() bool {
+	return d.Missing == "" &&
+		d.Charm == nil &&
+		d.Series == nil &&
+		d.NumUnits == nil &&
+		d.Constraints == nil &&
+		d.Expose == nil &&
+		len(d.Bindings) == 0 &&
+		len(d.Options) == 0 &&
+		len(d.Annotations) == 0
+}
+
+// machineDiff describes the differences between a machine in the
+// bundle and the model machine it is mapped to.
+type machineDiff struct {
+	Missing      string                `yaml:"missing,omitempty"`
+	ModelMachine string                `yaml:"model-machine,omitempty"`
+	Series       *stringDiff           `yaml:"series,omitempty"`
+	Constraints  *stringDiff           `yaml:"constraints,omitempty"`
+	Annotations  map[string]stringDiff `yaml:"annotations,omitempty"`
+}
+
+func (d *machineDiff) empty() bool {
+	return d.Missing == "" &&
+		d.Series == nil &&
+		d.Constraints == nil &&
+		len(d.Annotations) == 0
+}
+
+// relationsDiff holds the relations that only exist on one side.
+type relationsDiff struct {
+	BundleAdditions [][]string `yaml:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `yaml:"model-additions,omitempty"`
+}
+
+type stringDiff struct {
+	Bundle string `yaml:"bundle"`
+	Model  string `yaml:"model"`
+}
+
+type intDiff struct {
+	Bundle int `yaml:"bundle"`
+	Model  int `yaml:"model"`
+}
+
+type boolDiff struct {
+	Bundle bool `yaml:"bundle"`
+	Model  bool `yaml:"model"`
+}
+
+type optionDiff struct {
+	Bundle interface{} `yaml:"bundle"`
+	Model  interface{} `yaml:"model"`
+}
+
+// bundleModel is the bundle data representation of a model, along
+// with the details of the model needed for comparison which can't be
+// represented in bundle data.
+type bundleModel struct {
+	*charm.BundleData
+
+	// unitMachines holds the top level machine of each unit of each
+	// application, ordered by unit number.
+	unitMachines map[string][]string
+
+	// optionDefaults holds the charm default of each option of each
+	// application.
+	optionDefaults map[string]map[string]interface{}
+}
+
+// bundleDiffer compares bundle data with the bundle data representation
+// of a model.
+type bundleDiffer struct {
+	bundle             *charm.BundleData
+	model              *bundleModel
+	includeAnnotations bool
+}
+
+// diff returns the differences between the bundle and the model.
+func (d *bundleDiffer) diff() *bundleDiff {
+	return &bundleDiff{
+		Applications: d.diffApplications(),
+		Machines:     d.diffMachines(),
+		Relations:    d.diffRelations(),
+	}
+}
+
+func (d *bundleDiffer) diffApplications() map[string]*applicationDiff {
+	results := make(map[string]*applicationDiff)
+	for name, bundleApp := range d.bundle.Applications {
+		modelApp, found := d.model.Applications[name]
+		if !found {
+			results[name] = &applicationDiff{Missing: missingFromModel}
+			continue
+		}
+		if result := d.diffApplication(name, bundleApp, modelApp); !result.empty() {
+			results[name] = result
+		}
+	}
+	for name := range d.model.Applications {
+		if _, found := d.bundle.Applications[name]; !found {
+			results[name] = &applicationDiff{Missing: missingFromBundle}
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
+
+func (d *bundleDiffer) diffApplication(name string, bundleApp, modelApp *charm.ApplicationSpec) *applicationDiff {
+	result := &applicationDiff{}
+	if !charmsEqual(bundleApp.Charm, modelApp.Charm) {
+		result.Charm = &stringDiff{Bundle: bundleApp.Charm, Model: modelApp.Charm}
+	}
+	// The series is only compared if the bundle specifies one, either
+	// explicitly, in the charm URL or as the bundle default.
+	if series := d.bundleApplicationSeries(bundleApp); series != "" && series != modelApp.Series {
+		result.Series = &stringDiff{Bundle: series, Model: modelApp.Series}
+	}
+	if bundleApp.NumUnits != modelApp.NumUnits {
+		result.NumUnits = &intDiff{Bundle: bundleApp.NumUnits, Model: modelApp.NumUnits}
+	}
+	if !constraintsEqual(bundleApp.Constraints, modelApp.Constraints) {
+		result.Constraints = &stringDiff{Bundle: bundleApp.Constraints, Model: modelApp.Constraints}
+	}
+	if bundleApp.Expose != modelApp.Expose {
+		result.Expose = &boolDiff{Bundle: bundleApp.Expose, Model: modelApp.Expose}
+	}
+	// Only the bindings in the bundle are compared, as the model
+	// reports a binding for every endpoint of the charm.
+	for endpoint, space := range bundleApp.EndpointBindings {
+		if modelSpace := modelApp.EndpointBindings[endpoint]; space != modelSpace {
+			if result.Bindings == nil {
+				result.Bindings = make(map[string]stringDiff)
+			}
+			result.Bindings[endpoint] = stringDiff{Bundle: space, Model: modelSpace}
+		}
+	}
+	result.Options = diffOptions(bundleApp.Options, modelApp.Options, d.model.optionDefaults[name])
+	if d.includeAnnotations {
+		result.Annotations = diffAnnotations(bundleApp.Annotations, modelApp.Annotations)
+	}
+	return result
+}
+
+func (d *bundleDiffer) bundleApplicationSeries(app *charm.ApplicationSpec) string {
+	if app.Series != "" {
+		return app.Series
+	}
+	if curl, err := charm.ParseURL(app.Charm); err == nil && curl.Series != "" {
+		return curl.Series
+	}
+	return d.bundle.Series
+}
+
+func (d *bundleDiffer) diffMachines() map[string]*machineDiff {
+	machineMap := d.machineMap()
+	mapped := make(map[string]bool)
+	results := make(map[string]*machineDiff)
+	for id, bundleMachine := range d.bundle.Machines {
+		modelID, found := machineMap[id]
+		if !found {
+			results[id] = &machineDiff{Missing: missingFromModel}
+			continue
+		}
+		mapped[modelID] = true
+		modelMachine := d.model.Machines[modelID]
+		// A machine without any details in the bundle is nil.
+		if bundleMachine == nil {
+			bundleMachine = &charm.MachineSpec{}
+		}
+		result := &machineDiff{}
+		series := bundleMachine.Series
+		if series == "" {
+			series = d.bundle.Series
+		}
+		if series != "" && series != modelMachine.Series {
+			result.Series = &stringDiff{Bundle: series, Model: modelMachine.Series}
+		}
+		if !constraintsEqual(bundleMachine.Constraints, modelMachine.Constraints) {
+			result.Constraints = &stringDiff{Bundle: bundleMachine.Constraints, Model: modelMachine.Constraints}
+		}
+		if d.includeAnnotations {
+			result.Annotations = diffAnnotations(bundleMachine.Annotations, modelMachine.Annotations)
+		}
+		if !result.empty() {
+			if modelID != id {
+				result.ModelMachine = modelID
+			}
+			results[id] = result
+		}
+	}
+	for id := range d.model.Machines {
+		if mapped[id] {
+			continue
+		}
+		// Machines which are only in the model are reported using
+		// their model id, which may also be the id of a bundle machine
+		// which was mapped to another model machine.
+		if _, found := results[id]; found {
+			id = "model-" + id
+		}
+		results[id] = &machineDiff{Missing: missingFromBundle}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
+
+// machineMap returns the model machine id for each bundle machine that
+// can be matched with a model machine. Bundle machines are matched with
+// the model machines hosting the units placed on them by the bundle,
+// with the first unit of each application placed on the machine taking
+// precedence. Any remaining bundle machines are matched with the
+// unmatched model machine with the same id, if there is one.
+func (d *bundleDiffer) machineMap() map[string]string {
+	result := make(map[string]string)
+	used := make(map[string]bool)
+	var appNames []string
+	for name := range d.bundle.Applications {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+	for _, name := range appNames {
+		unitMachines := d.model.unitMachines[name]
+		for i, to := range d.bundle.Applications[name].To {
+			if i >= len(unitMachines) {
+				break
+			}
+			placement, err := charm.ParsePlacement(to)
+			if err != nil || placement.Machine == "" || placement.Machine == "new" {
+				continue
+			}
+			bundleID, modelID := placement.Machine, unitMachines[i]
+			if _, found := d.bundle.Machines[bundleID]; !found {
+				continue
+			}
+			if _, found := result[bundleID]; found || used[modelID] {
+				continue
+			}
+			result[bundleID] = modelID
+			used[modelID] = true
+		}
+	}
+	for id := range d.bundle.Machines {
+		if _, found := result[id]; found || used[id] {
+			continue
+		}
+		if _, found := d.model.Machines[id]; found {
+			result[id] = id
+			used[id] = true
+		}
+	}
+	return result
+}
+
+// unitMachines returns the top level machine of each of the units,
+// ordered by unit number.
+func unitMachines(units map[string]params.UnitStatus) []string {
+	unitNames := make([]string, 0, len(units))
+	for name := range units {
+		unitNames = append(unitNames, name)
+	}
+	sort.Slice(unitNames, func(i, j int) bool {
+		return names.NewUnitTag(unitNames[i]).Number() < names.NewUnitTag(unitNames[j]).Number()
+	})
+	result := make([]string, len(unitNames))
+	for i, name := range unitNames {
+		// Containers are placed in bundles using their top level
+		// machine, eg "lxd:0".
+		result[i] = strings.SplitN(units[name].Machine, "/", 2)[0]
+	}
+	return result
+}
+
+func (d *bundleDiffer) diffRelations() *relationsDiff {
+	matched := make([]bool, len(d.model.Relations))
+	var result relationsDiff
+	for _, bundleRelation := range d.bundle.Relations {
+		found := false
+		for i, modelRelation := range d.model.Relations {
+			if !matched[i] && relationsEqual(bundleRelation, modelRelation) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			result.BundleAdditions = append(result.BundleAdditions, bundleRelation)
+		}
+	}
+	for i, modelRelation := range d.model.Relations {
+		if !matched[i] {
+			result.ModelAdditions = append(result.ModelAdditions, modelRelation)
+		}
+	}
+	if len(result.BundleAdditions) == 0 && len(result.ModelAdditions) == 0 {
+		return nil
+	}
+	sortRelations(result.BundleAdditions)
+	sortRelations(result.ModelAdditions)
+	return &result
+}
+
+// charmsEqual returns whether the charm specified in the bundle matches
+// the charm URL of the model application. The revision and series are
+// only compared if the bundle specifies them.
+func charmsEqual(bundleCharm, modelCharm string) bool {
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return bundleCharm == modelCharm
+	}
+	bundleURL, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		// Local charms are specified in the bundle by path, so the
+		// best we can do is compare the charm name.
+		return modelURL.Schema == "local" && filepath.Base(bundleCharm) == modelURL.Name
+	}
+	if bundleURL.Schema != modelURL.Schema ||
+		bundleURL.User != modelURL.User ||
+		bundleURL.Name != modelURL.Name {
+		return false
+	}
+	if bundleURL.Series != "" && bundleURL.Series != modelURL.Series {
+		return false
+	}
+	return bundleURL.Revision == -1 || bundleURL.Revision == modelURL.Revision
+}
+
+func constraintsEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	// The bundle constraints have already been verified, and the model
+	// constraints come from the controller, so errors are not expected.
+	ac, err := constraints.Parse(a)
+	if err != nil {
+		return false
+	}
+	bc, err := constraints.Parse(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(ac, bc)
+}
+
+// diffOptions compares the options set in the bundle with those set
+// in the model. Options which aren't set on one side are taken to have
+// the charm default value.
+func diffOptions(bundleOptions, modelOptions, defaults map[string]interface{}) map[string]optionDiff {
+	results := make(map[string]optionDiff)
+	compare := func(key string) {
+		bundleValue, found := bundleOptions[key]
+		if !found {
+			bundleValue = defaults[key]
+		}
+		modelValue, found := modelOptions[key]
+		if !found {
+			modelValue = defaults[key]
+		}
+		if !optionValuesEqual(bundleValue, modelValue) {
+			results[key] = optionDiff{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	for key := range bundleOptions {
+		compare(key)
+	}
+	for key := range modelOptions {
+		if _, found := bundleOptions[key]; !found {
+			compare(key)
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
+
+// optionValuesEqual compares option values, allowing for numbers read
+// from YAML and numbers read from the API having different types.
+func optionValuesEqual(a, b interface{}) bool {
+	return reflect.DeepEqual(normaliseOptionValue(a), normaliseOptionValue(b))
+}
+
+func normaliseOptionValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+func diffAnnotations(bundleAnnotations, modelAnnotations map[string]string) map[string]stringDiff {
+	results := make(map[string]stringDiff)
+	for key, value := range bundleAnnotations {
+		if modelValue, found := modelAnnotations[key]; !found || value != modelValue {
+			results[key] = stringDiff{Bundle: value, Model: modelValue}
+		}
+	}
+	for key, value := range modelAnnotations {
+		if _, found := bundleAnnotations[key]; !found {
+			results[key] = stringDiff{Model: value}
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
+
+// relationsEqual returns whether a bundle relation matches a model
+// relation. Relations in the bundle may omit the endpoint names, while
+// those in the model always include them.
+func relationsEqual(bundleRelation, modelRelation []string) bool {
+	if len(bundleRelation) != 2 || len(modelRelation) != 2 {
+		return false
+	}
+	return endpointsEqual(bundleRelation[0], modelRelation[0]) && endpointsEqual(bundleRelation[1], modelRelation[1]) ||
+		endpointsEqual(bundleRelation[0], modelRelation[1]) && endpointsEqual(bundleRelation[1], modelRelation[0])
+}
+
+func endpointsEqual(bundleEndpoint, modelEndpoint string) bool {
+	if bundleEndpoint == modelEndpoint {
+		return true
+	}
+	return !strings.Contains(bundleEndpoint, ":") && strings.HasPrefix(modelEndpoint, bundleEndpoint+":")
+}
+
+func sortRelations(relations [][]string) {
+	sort.Slice(relations, func(i, j int) bool {
+		return strings.Join(relations[i], " ") < strings.Join(relations[j], " ")
+	})
+}
@@ -56,6 +56,7 @@ type ApplicationAPI interface {
 	AddRelation(endpoints, viaCIDRs []string) (*apiparams.AddRelationResults, error)
 	AddUnits(application.AddUnitsParams) ([]string, error)
 	Expose(application string) error
+	ExposeEndpoints(application string, exposedEndpoints map[string]apiparams.ExposedEndpoint) error
 	GetAnnotations(tags []string) ([]apiparams.AnnotationsGetResult, error)
 	GetConfig(appNames ...string) ([]map[string]interface{}, error)
 	GetConstraints(appNames ...string) ([]constraints.Value, error)
@@ -649,12 +650,12 @@ func (c *DeployCommand) Init(args []string) error {
 	if c.Force && c.Series == "" && c.PlacementSpec == "" {
 		return errors.New("--force is only used with --series")
 	}
-	if len(c.AttachStorage) > 0 {
+	if len(c.AttachStorage)+len(c.AttachSnapshots) > 0 {
 		modelType, err := c.ModelType()
 		if err != nil {
 			return err
 		}
-		if modelType == model.CAAS && len(c.AttachStorage) > 0 {
+		if modelType == model.CAAS {
 			return errors.New("--attach-storage cannot be used on kubernetes models")
 		}
 	}
@@ -816,6 +817,11 @@ func (c *DeployCommand) deployCharm(
 		// Application API version 5 and onwards.
 		return errors.New("this juju controller does not support --attach-storage")
 	}
+	if len(c.AttachSnapshots) > 0 && apiRoot.BestFacadeVersion("Application") < 9 {
+		// DeployArgs.AttachSnapshots is only supported from
+		// Application API version 9 and onwards.
+		return errors.New("this juju controller does not support restoring volume snapshots with --attach-storage")
+	}
 
 	numUnits := c.NumUnits
 	if charmInfo.Meta.Subordinate {
@@ -960,6 +966,7 @@ func (c *DeployCommand) deployCharm(
 		Storage:          c.Storage,
 		Devices:          c.Devices,
 		AttachStorage:    c.AttachStorage,
+		AttachSnapshots:  c.AttachSnapshots,
 		Resources:        ids,
 		EndpointBindings: c.Bindings,
 	}
@@ -116,6 +116,9 @@ var initErrorTests = []struct {
 	}, {
 		args: []string{"charm", "--attach-storage", "foo/0", "-n", "2"},
 		err:  `--attach-storage cannot be used with -n`,
+	}, {
+		args: []string{"charm", "--attach-storage", "0@1", "-n", "2"},
+		err:  `--attach-storage cannot be used with -n`,
 	}, {
 		args: []string{"bundle", "--map-machines", "foo"},
 		err:  `error in --map-machines: expected "existing" or "<bundle-id>=<machine-id>", got "foo"`,
@@ -1758,6 +1761,57 @@ func (s *DeployUnitTestSuite) TestDeployAttachStorageNotSupported(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "this juju controller does not support --attach-storage")
 }
 
+func (s *DeployUnitTestSuite) TestDeployAttachSnapshots(c *gc.C) {
+	charmsPath := c.MkDir()
+	charmDir := testcharms.Repo.ClonedDir(charmsPath, "dummy")
+
+	fakeAPI := vanillaFakeModelAPI(map[string]interface{}{
+		"name": "name",
+		"uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"type": "foo",
+	})
+	fakeAPI.Call("BestFacadeVersion", "Application").Returns(9)
+	dummyURL := charm.MustParseURL("local:trusty/dummy-0")
+	withLocalCharmDeployable(fakeAPI, dummyURL, charmDir)
+	withCharmDeployable(
+		fakeAPI, dummyURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 1, []string{"foo/0"}, nil,
+	)
+	fakeAPI.Call("Deploy", application.DeployArgs{
+		CharmID:         jjcharmstore.CharmID{URL: dummyURL},
+		ApplicationName: dummyURL.Name,
+		Series:          "trusty",
+		NumUnits:        1,
+		AttachStorage:   []string{"foo/0"},
+		AttachSnapshots: []string{"0@1"},
+	}).Returns(error(nil))
+
+	cmd := NewDeployCommandForTest(func() (DeployAPI, error) { return fakeAPI, nil }, nil)
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, dummyURL.String(), "--attach-storage", "foo/0,0@1")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DeployUnitTestSuite) TestDeployAttachSnapshotsNotSupported(c *gc.C) {
+	charmsPath := c.MkDir()
+	charmDir := testcharms.Repo.ClonedDir(charmsPath, "dummy")
+
+	fakeAPI := vanillaFakeModelAPI(map[string]interface{}{
+		"name": "name",
+		"uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"type": "foo",
+	})
+	dummyURL := charm.MustParseURL("local:trusty/dummy-0")
+	withLocalCharmDeployable(fakeAPI, dummyURL, charmDir)
+	withCharmDeployable(
+		fakeAPI, dummyURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, 1, nil, nil,
+	)
+
+	cmd := NewDeployCommandForTest(func() (DeployAPI, error) { return fakeAPI, nil }, nil)
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, dummyURL.String(), "--attach-storage", "0@1")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support restoring volume snapshots with --attach-storage")
+}
+
 // fakeDeployAPI is a mock of the API used by the deploy command. It's
 // a little muddled at the moment, but as the DeployAPI interface is
 // sharpened, this will become so as well.
@@ -1897,6 +1951,11 @@ func (f *fakeDeployAPI) Expose(application string) error {
 	return jujutesting.TypeAssertError(results[0])
 }
 
+func (f *fakeDeployAPI) ExposeEndpoints(application string, exposedEndpoints map[string]params.ExposedEndpoint) error {
+	results := f.MethodCall(f, "ExposeEndpoints", application, exposedEndpoints)
+	return jujutesting.TypeAssertError(results[0])
+}
+
 func (f *fakeDeployAPI) SetAnnotation(annotations map[string]map[string]string) ([]params.ErrorResult, error) {
 	results := f.MethodCall(f, "SetAnnotation", annotations)
 	return results[0].([]params.ErrorResult), jujutesting.TypeAssertError(results[1])
@@ -0,0 +1,377 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/annotations"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+)
+
+const diffBundleDoc = `
+Bundle can be a local bundle file or the name of a bundle in
+the charm store. The bundle can also be combined with overlays (in the
+same way as the deploy command) before comparing with the model.
+
+The differences are written as YAML. Each application, machine or
+relation that differs is listed with the value in the bundle and the
+value in the model. Entities that only exist on one side are reported
+as missing from the other side.
+
+Machines in the bundle are compared with the model machines hosting the
+units placed on them, so bundle machine 0 is compared with the machine
+hosting the first unit placed with "to: [0]". Bundle machines without
+any such units are compared with the model machine with the same id,
+if it hasn't already been matched. Where a bundle machine is compared
+with a model machine with a different id, the model machine id is
+reported as model-machine. Model machines which aren't matched are
+reported using their id, prefixed with "model-" if that id is also
+used by a bundle machine.
+
+Options which are not set in the bundle or in the model are compared
+using the charm default.
+
+Annotations are only compared if --annotations is specified.
+
+Examples:
+    juju diff-bundle localbundle.yaml
+    juju diff-bundle canonical-kubernetes
+    juju diff-bundle -m othermodel hadoop-spark
+    juju diff-bundle mongodb-cluster --channel beta
+    juju diff-bundle canonical-kubernetes --overlay local-config.yaml --overlay extra.yaml
+
+See also:
+    deploy
+`
+
+// NewDiffBundleCommand returns a command to compare a bundle against
+// the current model.
+func NewDiffBundleCommand() modelcmd.ModelCommand {
+	cmd := &diffBundleCommand{}
+	cmd.newAPIRootFn = func() (DiffBundleAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return newDiffBundleAPIAdapter(root), nil
+	}
+	cmd.charmStoreFn = func() (BundleResolver, error) {
+		controllerAPIRoot, err := cmd.NewControllerAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer controllerAPIRoot.Close()
+		csURL, err := getCharmStoreAPIURL(controllerAPIRoot)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		bakeryClient, err := cmd.BakeryClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		cstoreClient := newCharmStoreClient(bakeryClient, csURL).WithChannel(cmd.channel)
+		return charmrepo.NewCharmStoreFromClient(cstoreClient), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// DiffBundleAPI provides the methods the diff-bundle command needs
+// to read the current state of the model.
+type DiffBundleAPI interface {
+	Close() error
+	Status(patterns []string) (*params.FullStatus, error)
+	GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error)
+	GetConfig(appNames ...string) ([]map[string]interface{}, error)
+	GetConstraints(appNames ...string) ([]constraints.Value, error)
+}
+
+// BundleResolver provides the methods the diff-bundle command needs
+// to fetch a bundle from the charm store.
+type BundleResolver interface {
+	ResolveWithChannel(*charm.URL) (*charm.URL, csparams.Channel, []string, error)
+	GetBundle(*charm.URL) (charm.Bundle, error)
+}
+
+type diffBundleAPIAdapter struct {
+	api.Connection
+	*apiClient
+	*applicationClient
+	*annotationsClient
+}
+
+func newDiffBundleAPIAdapter(root api.Connection) *diffBundleAPIAdapter {
+	return &diffBundleAPIAdapter{
+		Connection:        root,
+		apiClient:         &apiClient{Client: root.Client()},
+		applicationClient: &applicationClient{Client: application.NewClient(root)},
+		annotationsClient: &annotationsClient{Client: annotations.NewClient(root)},
+	}
+}
+
+func (a *diffBundleAPIAdapter) GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error) {
+	return a.annotationsClient.Get(tags)
+}
+
+// diffBundleCommand compares a bundle with the current model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+
+	bundle         string
+	bundleOverlays []string
+	channel        csparams.Channel
+	annotations    bool
+
+	newAPIRootFn func() (DiffBundleAPI, error)
+	charmStoreFn func() (BundleResolver, error)
+}
+
+// Info implements cmd.Command.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or name>",
+		Purpose: "Compare a bundle with a model and report any differences.",
+		Doc:     diffBundleDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar((*string)(&c.channel), "channel", "", "Channel to use when getting the bundle from the charm store")
+	f.Var(cmd.NewAppendStringsValue(&c.bundleOverlays), "overlay", "Bundles to overlay on the primary bundle, applied in order")
+	f.BoolVar(&c.annotations, "annotations", false, "Include differences in annotations")
+}
+
+// Init implements cmd.Command.
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("no bundle specified")
+	}
+	c.bundle = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	bundleData, bundleDir, err := c.readBundle(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := composeAndVerifyBundle(ctx, bundleDir, bundleData, c.bundleOverlays); err != nil {
+		return errors.Trace(err)
+	}
+
+	apiRoot, err := c.newAPIRootFn()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiRoot.Close()
+
+	modelData, err := c.readModel(apiRoot)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	differ := &bundleDiffer{
+		bundle:             bundleData,
+		model:              modelData,
+		includeAnnotations: c.annotations,
+	}
+	out, err := yaml.Marshal(differ.diff())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = ctx.Stdout.Write(out)
+	return errors.Trace(err)
+}
+
+// readBundle returns the bundle data, and the directory of the bundle
+// if it is a local bundle.
+func (c *diffBundleCommand) readBundle(ctx *cmd.Context) (*charm.BundleData, string, error) {
+	bundlePath := ctx.AbsPath(c.bundle)
+	info, err := os.Stat(bundlePath)
+	if err == nil {
+		var readErr error
+		if !info.IsDir() {
+			data, err := charmrepo.ReadBundleFile(bundlePath)
+			if err == nil {
+				return data, filepath.Dir(bundlePath), nil
+			}
+			readErr = err
+		}
+		// We may have been given a bundle archive or exploded directory.
+		bundle, _, err := charmrepo.NewBundleAtPath(bundlePath)
+		if err != nil {
+			if readErr != nil {
+				err = readErr
+			}
+			return nil, "", errors.Annotatef(err, "cannot read bundle %q", c.bundle)
+		}
+		bundleDir := ""
+		if info.IsDir() {
+			bundleDir = bundlePath
+		}
+		return bundle.Data(), bundleDir, nil
+	} else if !os.IsNotExist(err) {
+		return nil, "", errors.Trace(err)
+	}
+
+	// There is no local bundle, so look in the charm store.
+	bundleURL, err := charm.ParseURL(c.bundle)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	store, err := c.charmStoreFn()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	resolvedURL, _, _, err := store.ResolveWithChannel(bundleURL)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	if resolvedURL.Series != "bundle" {
+		return nil, "", errors.Errorf("%q is a charm, not a bundle", c.bundle)
+	}
+	bundle, err := store.GetBundle(resolvedURL)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	ctx.Infof("Located bundle %q", resolvedURL)
+	return bundle.Data(), "", nil
+}
+
+// readModel returns a representation of the current model as bundle
+// data, so that it can be compared with the bundle.
+func (c *diffBundleCommand) readModel(apiRoot DiffBundleAPI) (*bundleModel, error) {
+	status, err := apiRoot.Status(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting model status")
+	}
+	data := &bundleModel{
+		BundleData: &charm.BundleData{
+			Applications: make(map[string]*charm.ApplicationSpec),
+			Machines:     make(map[string]*charm.MachineSpec),
+		},
+		unitMachines:   make(map[string][]string),
+		optionDefaults: make(map[string]map[string]interface{}),
+	}
+	var (
+		annotationTags []string
+		appNames       []string
+		principalApps  []string
+	)
+	// Only top level machines can be specified in a bundle.
+	for id, machine := range status.Machines {
+		data.Machines[id] = &charm.MachineSpec{
+			Series:      machine.Series,
+			Constraints: machine.Constraints,
+		}
+		annotationTags = append(annotationTags, names.NewMachineTag(id).String())
+	}
+	for name, appStatus := range status.Applications {
+		data.Applications[name] = &charm.ApplicationSpec{
+			Charm:            appStatus.Charm,
+			Series:           appStatus.Series,
+			NumUnits:         len(appStatus.Units),
+			Expose:           appStatus.Exposed,
+			EndpointBindings: appStatus.EndpointBindings,
+		}
+		data.unitMachines[name] = unitMachines(appStatus.Units)
+		annotationTags = append(annotationTags, names.NewApplicationTag(name).String())
+		appNames = append(appNames, name)
+		if len(appStatus.Units) > 0 {
+			// Subordinate applications cannot have constraints, and
+			// only principal applications have units in the status.
+			principalApps = append(principalApps, name)
+		}
+	}
+	for _, relation := range status.Relations {
+		// All relations have two endpoints except peers.
+		if len(relation.Endpoints) != 2 {
+			continue
+		}
+		data.Relations = append(data.Relations, []string{
+			fmt.Sprintf("%s:%s", relation.Endpoints[0].ApplicationName, relation.Endpoints[0].Name),
+			fmt.Sprintf("%s:%s", relation.Endpoints[1].ApplicationName, relation.Endpoints[1].Name),
+		})
+	}
+
+	if c.annotations && len(annotationTags) > 0 {
+		results, err := apiRoot.GetAnnotations(annotationTags)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting annotations")
+		}
+		for _, result := range results {
+			if result.Error.Error != nil {
+				return nil, errors.Trace(result.Error.Error)
+			}
+			tag, err := names.ParseTag(result.EntityTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			switch kind := tag.Kind(); kind {
+			case names.ApplicationTagKind:
+				data.Applications[tag.Id()].Annotations = result.Annotations
+			case names.MachineTagKind:
+				data.Machines[tag.Id()].Annotations = result.Annotations
+			default:
+				return nil, errors.Errorf("unexpected tag kind for annotations: %q", kind)
+			}
+		}
+	}
+
+	if len(appNames) > 0 {
+		configValues, err := apiRoot.GetConfig(appNames...)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting application options")
+		}
+		for i, config := range configValues {
+			options := make(map[string]interface{})
+			defaults := make(map[string]interface{})
+			for key, valueMap := range config {
+				value, err := applicationConfigValue(key, valueMap)
+				if err != nil {
+					return nil, errors.Annotatef(err, "bad application config for %q", appNames[i])
+				}
+				if value != nil {
+					options[key] = value
+				}
+				// applicationConfigValue has checked the type.
+				if value, found := valueMap.(map[string]interface{})["default"]; found {
+					defaults[key] = value
+				}
+			}
+			data.Applications[appNames[i]].Options = options
+			data.optionDefaults[appNames[i]] = defaults
+		}
+	}
+
+	if len(principalApps) > 0 {
+		constraintValues, err := apiRoot.GetConstraints(principalApps...)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting application constraints")
+		}
+		for i, value := range constraintValues {
+			data.Applications[principalApps[i]].Constraints = value.String()
+		}
+	}
+	return data, nil
+}
@@ -0,0 +1,480 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type diffBundleSuite struct {
+	testing.IsolationSuite
+
+	api   *fakeDiffBundleAPI
+	store *fakeBundleResolver
+	dir   string
+}
+
+var _ = gc.Suite(&diffBundleSuite{})
+
+func (s *diffBundleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.api = &fakeDiffBundleAPI{
+		Stub: &testing.Stub{},
+		status: &params.FullStatus{
+			Machines: map[string]params.MachineStatus{
+				"0": {Id: "0", Series: "bionic"},
+			},
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Charm:  "cs:bionic/mysql-57",
+					Series: "bionic",
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {Machine: "0"},
+					},
+					EndpointBindings: map[string]string{
+						"db":        "alpha",
+						"juju-info": "alpha",
+					},
+				},
+			},
+		},
+		config: map[string]map[string]interface{}{
+			"mysql": {
+				"query-cache": map[string]interface{}{"value": float64(10), "source": "user"},
+				"flavour":     map[string]interface{}{"value": "percona", "default": "percona", "source": "default"},
+			},
+		},
+		constraints: map[string]constraints.Value{
+			"mysql": constraints.MustParse("mem=4G"),
+		},
+	}
+	s.store = &fakeBundleResolver{Stub: &testing.Stub{}}
+}
+
+func (s *diffBundleSuite) runDiffBundle(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &diffBundleCommand{
+		newAPIRootFn: func() (DiffBundleAPI, error) { return s.api, nil },
+		charmStoreFn: func() (BundleResolver, error) { return s.store, nil },
+	}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	ctx := cmdtesting.Context(c)
+	ctx.Dir = s.dir
+	if err := cmdtesting.InitCommand(modelcmd.Wrap(command), args); err != nil {
+		return ctx, err
+	}
+	return ctx, command.Run(ctx)
+}
+
+func (s *diffBundleSuite) writeBundle(c *gc.C, name, content string) {
+	err := ioutil.WriteFile(filepath.Join(s.dir, name), []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *diffBundleSuite) TestInitNoBundle(c *gc.C) {
+	_, err := s.runDiffBundle(c)
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
+
+func (s *diffBundleSuite) TestNoDifferences(c *gc.C) {
+	s.writeBundle(c, "bundle.yaml", `
+series: bionic
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    constraints: mem=4096M
+    options:
+      query-cache: 10
+    to: ["0"]
+machines:
+  "0": {}
+`)
+	ctx, err := s.runDiffBundle(c, "bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+	s.api.CheckCallNames(c, "Status", "GetConfig", "GetConstraints", "Close")
+}
+
+func (s *diffBundleSuite) TestDifferences(c *gc.C) {
+	s.api.status.Applications["logging"] = params.ApplicationStatus{
+		Charm:         "cs:bionic/logging-2",
+		Series:        "bionic",
+		SubordinateTo: []string{"mysql"},
+	}
+	s.api.status.Relations = []params.RelationStatus{{
+		Endpoints: []params.EndpointStatus{
+			{ApplicationName: "logging", Name: "info"},
+			{ApplicationName: "mysql", Name: "juju-info"},
+		},
+	}}
+	s.api.status.Machines["0"] = params.MachineStatus{Id: "0", Series: "xenial"}
+	s.api.config["logging"] = map[string]interface{}{}
+	s.api.config["mysql"]["tuning-level"] = map[string]interface{}{"value": "fast", "source": "user"}
+
+	s.writeBundle(c, "bundle.yaml", `
+series: bionic
+applications:
+  mysql:
+    charm: cs:mysql-58
+    num_units: 2
+    expose: true
+    constraints: mem=4G
+    bindings:
+      db: db-space
+    options:
+      query-cache: 20
+    to: ["0", "1"]
+  wordpress:
+    charm: cs:wordpress
+relations:
+  - ["wordpress:db", "mysql:db"]
+machines:
+  "0": {}
+  "1": {}
+`)
+	ctx, err := s.runDiffBundle(c, "bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  logging:
+    missing: bundle
+  mysql:
+    charm:
+      bundle: cs:mysql-58
+      model: cs:bionic/mysql-57
+    num_units:
+      bundle: 2
+      model: 1
+    expose:
+      bundle: true
+      model: false
+    bindings:
+      db:
+        bundle: db-space
+        model: alpha
+    options:
+      query-cache:
+        bundle: 20
+        model: 10
+      tuning-level:
+        bundle: null
+        model: fast
+  wordpress:
+    missing: model
+machines:
+  "0":
+    series:
+      bundle: bionic
+      model: xenial
+  "1":
+    missing: model
+relations:
+  bundle-additions:
+  - - wordpress:db
+    - mysql:db
+  model-additions:
+  - - logging:info
+    - mysql:juju-info
+`[1:])
+}
+
+func (s *diffBundleSuite) TestOptionsComparedWithDefaults(c *gc.C) {
+	s.api.config["mysql"]["flavour"] = map[string]interface{}{"value": "mariadb", "default": "percona", "source": "user"}
+	s.writeBundle(c, "bundle.yaml", `
+series: bionic
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    constraints: mem=4G
+    options:
+      query-cache: 10
+    to: ["0"]
+machines:
+  "0": {}
+`)
+	ctx, err := s.runDiffBundle(c, "bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  mysql:
+    options:
+      flavour:
+        bundle: percona
+        model: mariadb
+`[1:])
+
+	// Setting an option to its default in the bundle matches the
+	// model, where it's unset.
+	s.api.config["mysql"]["flavour"] = map[string]interface{}{"value": "percona", "default": "percona", "source": "default"}
+	s.writeBundle(c, "bundle.yaml", `
+series: bionic
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    constraints: mem=4G
+    options:
+      query-cache: 10
+      flavour: percona
+    to: ["0"]
+machines:
+  "0": {}
+`)
+	ctx, err = s.runDiffBundle(c, "bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+}
+
+func (s *diffBundleSuite) TestMachinesMappedByPlacement(c *gc.C) {
+	s.api.status.Machines = map[string]params.MachineStatus{
+		"0": {Id: "0", Series: "bionic"},
+		"3": {Id: "3", Series: "xenial"},
+	}
+	s.api.status.Applications["mysql"].Units["mysql/0"] = params.UnitStatus{Machine: "3/lxd/0"}
+	s.writeBundle(c, "bundle.yaml", `
+series: bionic
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    constraints: mem=4G
+    options:
+      query-cache: 10
+    to: ["lxd:0"]
+machines:
+  "0": {}
+`)
+	ctx, err := s.runDiffBundle(c, "bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+machines:
+  "0":
+    model-machine: "3"
+    series:
+      bundle: bionic
+      model: xenial
+  model-0:
+    missing: bundle
+`[1:])
+}
+
+func (s *diffBundleSuite) TestOverlay(c *gc.C) {
+	s.writeBundle(c, "bundle.yaml", `
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    constraints: mem=4G
+    options:
+      query-cache: 10
+`)
+	s.writeBundle(c, "overlay.yaml", `
+applications:
+  mysql:
+    num_units: 3
+`)
+	ctx, err := s.runDiffBundle(c, "bundle.yaml", "--overlay", "overlay.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  mysql:
+    num_units:
+      bundle: 3
+      model: 1
+machines:
+  "0":
+    missing: bundle
+`[1:])
+}
+
+func (s *diffBundleSuite) TestAnnotations(c *gc.C) {
+	s.api.annotations = map[string]map[string]string{
+		"application-mysql": {"gui-x": "200"},
+		"machine-0":         {},
+	}
+	s.writeBundle(c, "bundle.yaml", `
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+    constraints: mem=4G
+    options:
+      query-cache: 10
+    annotations:
+      gui-x: "100"
+      gui-y: "50"
+    to: ["0"]
+machines:
+  "0": {}
+`)
+	ctx, err := s.runDiffBundle(c, "bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+
+	ctx, err = s.runDiffBundle(c, "bundle.yaml", "--annotations")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  mysql:
+    annotations:
+      gui-x:
+        bundle: "100"
+        model: "200"
+      gui-y:
+        bundle: "50"
+        model: ""
+`[1:])
+}
+
+func (s *diffBundleSuite) TestCharmStoreBundle(c *gc.C) {
+	s.store.bundle = &fakeBundle{data: &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"mysql": {
+				Charm:       "cs:bionic/mysql-57",
+				NumUnits:    1,
+				Constraints: "mem=4G",
+				Options:     map[string]interface{}{"query-cache": 10},
+				To:          []string{"0"},
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{"0": {}},
+	}}
+	ctx, err := s.runDiffBundle(c, "cs:mysql-bundle", "--channel", "beta")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Located bundle \"cs:bundle/mysql-bundle-2\"\n")
+	s.store.CheckCall(c, 0, "ResolveWithChannel", charm.MustParseURL("cs:mysql-bundle"))
+	s.store.CheckCall(c, 1, "GetBundle", charm.MustParseURL("cs:bundle/mysql-bundle-2"))
+}
+
+func (s *diffBundleSuite) TestCharmStoreCharm(c *gc.C) {
+	s.store.charm = true
+	_, err := s.runDiffBundle(c, "cs:mysql")
+	c.Assert(err, gc.ErrorMatches, `"cs:mysql" is a charm, not a bundle`)
+}
+
+func (s *diffBundleSuite) TestCharmsEqual(c *gc.C) {
+	for i, test := range []struct {
+		bundle string
+		model  string
+		equal  bool
+	}{
+		{"cs:mysql", "cs:bionic/mysql-57", true},
+		{"mysql", "cs:bionic/mysql-57", true},
+		{"cs:bionic/mysql-57", "cs:bionic/mysql-57", true},
+		{"cs:xenial/mysql", "cs:bionic/mysql-57", false},
+		{"cs:mysql-56", "cs:bionic/mysql-57", false},
+		{"cs:~user/mysql", "cs:bionic/mysql-57", false},
+		{"./charms/mysql", "local:bionic/mysql-0", true},
+		{"./charms/mysql", "cs:bionic/mysql-57", false},
+	} {
+		c.Logf("test %d: %s vs %s", i, test.bundle, test.model)
+		c.Check(charmsEqual(test.bundle, test.model), gc.Equals, test.equal)
+	}
+}
+
+func (s *diffBundleSuite) TestRelationsEqual(c *gc.C) {
+	c.Check(relationsEqual([]string{"wordpress", "mysql"}, []string{"mysql:db", "wordpress:db"}), jc.IsTrue)
+	c.Check(relationsEqual([]string{"wordpress:db", "mysql"}, []string{"wordpress:db", "mysql:db"}), jc.IsTrue)
+	c.Check(relationsEqual([]string{"wordpress:cache", "mysql"}, []string{"wordpress:db", "mysql:db"}), jc.IsFalse)
+	c.Check(relationsEqual([]string{"wordpress", "mysql"}, []string{"wordpress:db", "mysql-slave:db"}), jc.IsFalse)
+}
+
+type fakeDiffBundleAPI struct {
+	*testing.Stub
+	status      *params.FullStatus
+	annotations map[string]map[string]string
+	config      map[string]map[string]interface{}
+	constraints map[string]constraints.Value
+}
+
+func (f *fakeDiffBundleAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.MethodCall(f, "Status", patterns)
+	return f.status, f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error) {
+	f.MethodCall(f, "GetAnnotations", tags)
+	var results []params.AnnotationsGetResult
+	for _, tag := range tags {
+		results = append(results, params.AnnotationsGetResult{
+			EntityTag:   tag,
+			Annotations: f.annotations[tag],
+		})
+	}
+	return results, f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) GetConfig(appNames ...string) ([]map[string]interface{}, error) {
+	f.MethodCall(f, "GetConfig", appNames)
+	var results []map[string]interface{}
+	for _, name := range appNames {
+		results = append(results, f.config[name])
+	}
+	return results, f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) GetConstraints(appNames ...string) ([]constraints.Value, error) {
+	f.MethodCall(f, "GetConstraints", appNames)
+	var results []constraints.Value
+	for _, name := range appNames {
+		results = append(results, f.constraints[name])
+	}
+	return results, f.NextErr()
+}
+
+type fakeBundleResolver struct {
+	*testing.Stub
+	bundle charm.Bundle
+	charm  bool
+}
+
+func (f *fakeBundleResolver) ResolveWithChannel(url *charm.URL) (*charm.URL, csparams.Channel, []string, error) {
+	f.MethodCall(f, "ResolveWithChannel", url)
+	resolved := *url
+	resolved.Revision = 2
+	resolved.Series = "bundle"
+	if f.charm {
+		resolved.Series = "bionic"
+	}
+	return &resolved, csparams.BetaChannel, nil, f.NextErr()
+}
+
+func (f *fakeBundleResolver) GetBundle(url *charm.URL) (charm.Bundle, error) {
+	f.MethodCall(f, "GetBundle", url)
+	return f.bundle, f.NextErr()
+}
+
+type fakeBundle struct {
+	data *charm.BundleData
+}
+
+func (b *fakeBundle) Data() *charm.BundleData {
+	return b.data
+}
+
+func (b *fakeBundle) ReadMe() string {
+	return ""
+}
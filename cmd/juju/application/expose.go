@@ -4,10 +4,15 @@
 package application
 
 import (
+	"net"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -19,8 +24,22 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+By default, all of the ports opened by the application's units may be
+accessed from 0.0.0.0/0. Access can be restricted to the subnets of
+particular spaces with --to-spaces, or to particular CIDRs with
+--to-cidrs. If both are specified, access is allowed from either.
+
+The --endpoints option applies the expose settings to the named
+endpoints of the application only. Expose settings are merged with
+any existing settings for the application, replacing the settings of
+the endpoints that are specified. When the application is exposed,
+its opened ports may be accessed from the sources allowed by any of
+its endpoints' settings.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --to-cidrs 10.0.0.0/24,192.168.1.0/24
+    juju expose wordpress --endpoints website --to-spaces public
 
 See also: 
     unexpose`[1:]
@@ -34,6 +53,10 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+
+	endpoints string
+	toSpaces  string
+	toCIDRs   string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,18 +68,32 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.endpoints, "endpoints", "", "Comma-separated list of the endpoints to expose (defaults to all endpoints)")
+	f.StringVar(&c.toSpaces, "to-spaces", "", "Comma-separated list of the spaces whose subnets may access the exposed ports")
+	f.StringVar(&c.toCIDRs, "to-cidrs", "", "Comma-separated list of the CIDRs that may access the exposed ports")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	for _, cidr := range splitCommaList(c.toCIDRs) {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type applicationExposeAPI interface {
 	Close() error
 	Expose(applicationName string) error
+	ExposeEndpoints(applicationName string, exposedEndpoints map[string]params.ExposedEndpoint) error
 	Unexpose(applicationName string) error
+	UnexposeEndpoints(applicationName string, endpoints []string) error
 }
 
 func (c *exposeCommand) getAPI() (applicationExposeAPI, error) {
@@ -75,5 +112,34 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if c.endpoints == "" && c.toSpaces == "" && c.toCIDRs == "" {
+		return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	}
+
+	exposed := params.ExposedEndpoint{
+		ExposeToSpaces: splitCommaList(c.toSpaces),
+		ExposeToCIDRs:  splitCommaList(c.toCIDRs),
+	}
+	endpoints := splitCommaList(c.endpoints)
+	if len(endpoints) == 0 {
+		// The empty endpoint name refers to all endpoints.
+		endpoints = []string{""}
+	}
+	exposedEndpoints := make(map[string]params.ExposedEndpoint)
+	for _, endpoint := range endpoints {
+		exposedEndpoints[endpoint] = exposed
+	}
+	return block.ProcessBlockedError(client.ExposeEndpoints(c.ApplicationName, exposedEndpoints), block.BlockChange)
+}
+
+// splitCommaList returns the non-empty, whitespace trimmed elements
+// of the comma separated list.
+func splitCommaList(list string) []string {
+	var result []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
 }
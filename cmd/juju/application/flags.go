@@ -5,6 +5,7 @@ package application
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/juju/errors"
@@ -142,8 +143,12 @@ func (f devicesFlag) String() string {
 	return strings.Join(strs, " ")
 }
 
+// attachStorageFlag parses storage IDs, and the IDs of volume
+// snapshots from which storage is to be restored, which have the
+// form <volume-id>@<n>.
 type attachStorageFlag struct {
-	storageIDs *[]string
+	storageIDs  *[]string
+	snapshotIDs *[]string
 }
 
 // Set implements gnuflag.Value.Set.
@@ -152,6 +157,13 @@ func (f attachStorageFlag) Set(s string) error {
 		return nil
 	}
 	for _, id := range strings.Split(s, ",") {
+		if strings.Contains(id, "@") {
+			if !isValidVolumeSnapshot(id) {
+				return errors.NotValidf("volume snapshot ID %q", id)
+			}
+			*f.snapshotIDs = append(*f.snapshotIDs, id)
+			continue
+		}
 		if !names.IsValidStorage(id) {
 			return errors.NotValidf("storage ID %q", id)
 		}
@@ -162,7 +174,16 @@ func (f attachStorageFlag) Set(s string) error {
 
 // String implements gnuflag.Value.String.
 func (f attachStorageFlag) String() string {
-	return strings.Join(*f.storageIDs, ",")
+	return strings.Join(append(append([]string{}, *f.storageIDs...), *f.snapshotIDs...), ",")
+}
+
+func isValidVolumeSnapshot(id string) bool {
+	i := strings.LastIndex(id, "@")
+	if i < 0 || !names.IsValidVolume(id[:i]) {
+		return false
+	}
+	_, err := strconv.ParseUint(id[i+1:], 10, 64)
+	return err == nil
 }
 
 // stringMap is a type that deserializes a CLI string using gnuflag's Value
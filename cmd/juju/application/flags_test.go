@@ -96,17 +96,32 @@ func (FlagSuite) TestStorageFlagBundleStorageErrors(c *gc.C) {
 }
 
 func (FlagSuite) TestAttachStorageFlag(c *gc.C) {
-	var stores []string
-	flag := attachStorageFlag{&stores}
+	var stores, snapshots []string
+	flag := attachStorageFlag{&stores, &snapshots}
 	err := flag.Set("foo/0,bar/1")
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(stores, jc.DeepEquals, []string{"foo/0", "bar/1"})
+	c.Assert(snapshots, gc.HasLen, 0)
+}
+
+func (FlagSuite) TestAttachStorageFlagSnapshots(c *gc.C) {
+	var stores, snapshots []string
+	flag := attachStorageFlag{&stores, &snapshots}
+	err := flag.Set("foo/0,0@1,0/1@2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stores, jc.DeepEquals, []string{"foo/0"})
+	c.Assert(snapshots, jc.DeepEquals, []string{"0@1", "0/1@2"})
+	c.Assert(flag.String(), gc.Equals, "foo/0,0@1,0/1@2")
 }
 
 func (FlagSuite) TestAttachStorageFlagErrors(c *gc.C) {
-	flag := attachStorageFlag{new([]string)}
+	flag := attachStorageFlag{new([]string), new([]string)}
 	err := flag.Set("zing")
 	c.Assert(err, gc.ErrorMatches, `storage ID "zing" not valid`)
+	err = flag.Set("zing@1")
+	c.Assert(err, gc.ErrorMatches, `volume snapshot ID "zing@1" not valid`)
+	err = flag.Set("0@x")
+	c.Assert(err, gc.ErrorMatches, `volume snapshot ID "0@x" not valid`)
 }
 
 func (FlagSuite) TestDevicesFlag(c *gc.C) {
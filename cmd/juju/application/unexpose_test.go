@@ -12,8 +12,10 @@ import (
 
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
 )
 
 type UnexposeSuite struct {
@@ -65,6 +67,25 @@ func (s *UnexposeSuite) TestUnexpose(c *gc.C) {
 	})
 }
 
+func (s *UnexposeSuite) TestUnexposeEndpoints(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "some-application-name"})
+	err := runExpose(c, "some-application-name", "--endpoints", "server,juju-info")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runUnexpose(c, "some-application-name", "--endpoints", "server")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name", true)
+	app, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"juju-info": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	})
+
+	err = runUnexpose(c, "some-application-name", "--endpoints", "juju-info")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name", false)
+}
+
 func (s *UnexposeSuite) TestBlockUnexpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
 	err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
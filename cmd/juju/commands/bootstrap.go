@@ -66,6 +66,12 @@ Note that a 'default' model is also created and becomes the current model
 of the environment once the command completes. It can be discarded if
 other models are created.
 
+When bootstrapping to a Kubernetes cloud, no machine is provisioned.
+Instead, the controller runs in a namespace of its own, named after the
+'controller' model, as a stateful set with persistent storage for its
+database. The size of that storage can be set with the 'root-disk'
+bootstrap constraint.
+
 If '--bootstrap-constraints' is used, its values will also apply to any
 future controllers provisioned for high availability (HA).
 
@@ -471,25 +477,29 @@ func (c *bootstrapCommand) Run(ctx *cmd.Context) (resultErr error) {
 		}
 	}()
 
-	environ, err := bootstrapPrepare(
-		modelcmd.BootstrapContext(ctx), store,
-		bootstrap.PrepareParams{
-			ModelConfig:      config.bootstrapModel,
-			ControllerConfig: config.controller,
-			ControllerName:   c.controllerName,
-			Cloud: environs.CloudSpec{
-				Type:             cloud.Type,
-				Name:             cloud.Name,
-				Region:           region.Name,
-				Endpoint:         region.Endpoint,
-				IdentityEndpoint: region.IdentityEndpoint,
-				StorageEndpoint:  region.StorageEndpoint,
-				Credential:       credentials.credential,
-				CACertificates:   cloud.CACertificates,
-			},
-			CredentialName: credentials.name,
-			AdminSecret:    config.bootstrap.AdminSecret,
+	prepareParams := bootstrap.PrepareParams{
+		ModelConfig:      config.bootstrapModel,
+		ControllerConfig: config.controller,
+		ControllerName:   c.controllerName,
+		Cloud: environs.CloudSpec{
+			Type:             cloud.Type,
+			Name:             cloud.Name,
+			Region:           region.Name,
+			Endpoint:         region.Endpoint,
+			IdentityEndpoint: region.IdentityEndpoint,
+			StorageEndpoint:  region.StorageEndpoint,
+			Credential:       credentials.credential,
+			CACertificates:   cloud.CACertificates,
 		},
+		CredentialName: credentials.name,
+		AdminSecret:    config.bootstrap.AdminSecret,
+	}
+	if jujucloud.CloudIsCAAS(cloud) {
+		return c.bootstrapCAAS(ctx, cloud, region, credentials, config, prepareParams, cloudCallCtx)
+	}
+
+	environ, err := bootstrapPrepare(
+		modelcmd.BootstrapContext(ctx), store, prepareParams,
 	)
 	if err != nil {
 		return errors.Trace(err)
@@ -595,7 +605,7 @@ See `[1:] + "`juju kill-controller`" + `.`)
 	logger.Infof("combined bootstrap constraints: %v", bootstrapConstraints)
 
 	hostedModelConfig := c.hostedModelConfig(
-		hostedModelUUID, config.inheritedControllerAttrs, config.userConfigAttrs, environ)
+		hostedModelUUID, config.inheritedControllerAttrs, config.userConfigAttrs, environ.Config())
 
 	// Check whether the Juju GUI must be installed in the controller.
 	// Leaving this value empty means no GUI will be installed.
@@ -1068,7 +1078,7 @@ func (c *bootstrapCommand) hostedModelConfig(
 	hostedModelUUID utils.UUID,
 	inheritedControllerAttrs,
 	userConfigAttrs map[string]interface{},
-	environ environs.Environ,
+	controllerModelConfig *config.Config,
 ) map[string]interface{} {
 
 	hostedModelConfig := map[string]interface{}{
@@ -1082,7 +1092,7 @@ func (c *bootstrapCommand) hostedModelConfig(
 	// We copy across any user supplied attributes to the hosted model config.
 	// But only if the attributes have not been removed from the controller
 	// model config as part of preparing the controller model.
-	controllerModelConfigAttrs := environ.Config().AllAttrs()
+	controllerModelConfigAttrs := controllerModelConfig.AllAttrs()
 	for k, v := range userConfigAttrs {
 		if _, ok := controllerModelConfigAttrs[k]; ok {
 			hostedModelConfig[k] = v
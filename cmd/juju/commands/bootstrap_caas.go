@@ -0,0 +1,184 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/juju"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/network"
+	jujuversion "github.com/juju/juju/version"
+)
+
+var (
+	bootstrapPrepareCAAS = bootstrap.PrepareCAAS
+	bootstrapCAAS        = bootstrap.BootstrapCAAS
+)
+
+// bootstrapCAAS bootstraps a controller into the container substrate
+// of a CAAS cloud, rather than onto a machine provisioned by an
+// environ. The controller runs in a namespace of its own, which is
+// removed again if bootstrap fails.
+func (c *bootstrapCommand) bootstrapCAAS(
+	ctx *cmd.Context,
+	cloud jujucloud.Cloud,
+	region jujucloud.Region,
+	credentials bootstrapCredentials,
+	config bootstrapConfigs,
+	prepareParams bootstrap.PrepareParams,
+	cloudCallCtx context.ProviderCallContext,
+) (resultErr error) {
+	store := c.ClientStore()
+	broker, controllerModelConfig, err := bootstrapPrepareCAAS(
+		modelcmd.BootstrapContext(ctx), store, prepareParams,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	hostedModelUUID, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Set the current model to the initial hosted model.
+	if err := store.UpdateModel(
+		c.controllerName,
+		c.hostedModelName,
+		jujuclient.ModelDetails{ModelUUID: hostedModelUUID.String(), ModelType: model.CAAS},
+	); err != nil {
+		return errors.Trace(err)
+	}
+
+	if !c.noSwitch {
+		if err := store.SetCurrentModel(c.controllerName, c.hostedModelName); err != nil {
+			return errors.Trace(err)
+		}
+		if err := store.SetCurrentController(c.controllerName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	cloudRegion := c.Cloud
+	if region.Name != "" {
+		cloudRegion = fmt.Sprintf("%s/%s", cloudRegion, region.Name)
+	}
+	ctx.Infof(
+		"Creating Juju controller %q on %s",
+		c.controllerName, cloudRegion,
+	)
+
+	// If we error out for any reason, remove the controller's namespace
+	// and everything in it.
+	defer func() {
+		if resultErr != nil {
+			if c.KeepBrokenEnvironment {
+				ctx.Infof(`
+bootstrap failed but --keep-broken was specified.
+This means that the controller namespace and its resources are left behind,
+but not registered to your local client, as the controller was not
+successfully created. You should be able to inspect the controller pod
+with kubectl for diagnosis and investigation.
+When you are ready to clean up the failed controller, delete its namespace.`[1:])
+			} else {
+				logger.Errorf("%v", resultErr)
+				logger.Debugf("(error details: %v)", errors.Details(resultErr))
+				// Set resultErr to cmd.ErrSilent to prevent
+				// logging the error twice.
+				resultErr = cmd.ErrSilent
+				handleBootstrapError(ctx, func() error {
+					return broker.Destroy(cloudCallCtx)
+				})
+			}
+		}
+	}()
+
+	// Block interruption during bootstrap.
+	interrupted := make(chan os.Signal, 1)
+	defer close(interrupted)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+	go func() {
+		for range interrupted {
+			ctx.Infof("Interrupt signalled: waiting for bootstrap to exit")
+		}
+	}()
+
+	bootstrapConstraints, err := constraints.Merge(c.Constraints, c.BootstrapConstraints)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("combined bootstrap constraints: %v", bootstrapConstraints)
+
+	hostedModelConfig := c.hostedModelConfig(
+		hostedModelUUID, config.inheritedControllerAttrs, config.userConfigAttrs, controllerModelConfig)
+
+	if credentials.name == "" {
+		// credentialName will be empty if the credential was detected.
+		// We must supply a name for the credential in the database,
+		// so choose one.
+		credentials.name = credentials.detectedName
+	}
+
+	service, err := bootstrapCAAS(
+		modelcmd.BootstrapContext(ctx),
+		broker,
+		controllerModelConfig,
+		bootstrap.BootstrapParams{
+			ModelConstraints:          c.Constraints,
+			BootstrapConstraints:      bootstrapConstraints,
+			AgentVersion:              c.AgentVersion,
+			Cloud:                     cloud,
+			CloudRegion:               region.Name,
+			CloudCredential:           credentials.credential,
+			CloudCredentialName:       credentials.name,
+			ControllerConfig:          config.controller,
+			ControllerInheritedConfig: config.inheritedControllerAttrs,
+			RegionInheritedConfig:     cloud.RegionConfig,
+			HostedModelConfig:         hostedModelConfig,
+			AdminSecret:               config.bootstrap.AdminSecret,
+			CAPrivateKey:              config.bootstrap.CAPrivateKey,
+		})
+	if err != nil {
+		return errors.Annotate(err, "failed to bootstrap model")
+	}
+
+	if err := c.SetModelName(modelcmd.JoinModelName(c.controllerName, c.hostedModelName), false); err != nil {
+		return errors.Trace(err)
+	}
+
+	agentVersion := jujuversion.Current
+	if c.AgentVersion != nil {
+		agentVersion = *c.AgentVersion
+	}
+	if err := juju.UpdateControllerDetailsFromLogin(
+		c.ClientStore(),
+		c.controllerName,
+		juju.UpdateControllerParams{
+			AgentVersion:           agentVersion.String(),
+			CurrentHostPorts:       [][]network.HostPort{network.AddressesWithPort(service.Addresses, config.controller.APIPort())},
+			PublicDNSName:          newStringIfNonEmpty(config.controller.AutocertDNSName()),
+			MachineCount:           newInt(1),
+			ControllerMachineCount: newInt(1),
+		}); err != nil {
+		return errors.Annotate(err, "saving bootstrap endpoint address")
+	}
+
+	// To avoid race conditions when running scripted bootstraps, wait
+	// for the controller's agent to be ready to accept commands before
+	// exiting this bootstrap command.
+	return waitForAgentInitialisation(ctx, &c.ModelCommandBase, c.controllerName, c.hostedModelName)
+}
@@ -0,0 +1,123 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type fakeControllerBroker struct {
+	caas.ControllerBroker
+	destroyed bool
+}
+
+func (b *fakeControllerBroker) Destroy(context.ProviderCallContext) error {
+	b.destroyed = true
+	return nil
+}
+
+func (s *BootstrapSuite) setupCAASBootstrap(c *gc.C, bootstrapErr error) (*fakeControllerBroker, *bootstrap.BootstrapParams) {
+	err := ioutil.WriteFile(cloud.JujuPersonalCloudsPath(), []byte(`
+clouds:
+    k8s-cloud:
+        type: kubernetes
+        auth-types: [userpass]
+        endpoint: https://10.0.0.1:8443
+`[1:]), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Credentials = map[string]cloud.CloudCredential{
+		"k8s-cloud": {
+			AuthCredentials: map[string]cloud.Credential{
+				"admin": cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+					"username": "admin",
+					"password": "secret",
+				}),
+			},
+		},
+	}
+	s.patchVersionAndSeries(c, "bionic")
+
+	broker := &fakeControllerBroker{}
+	s.PatchValue(&bootstrapPrepareCAAS, func(
+		_ environs.BootstrapContext,
+		store jujuclient.ClientStore,
+		args bootstrap.PrepareParams,
+	) (caas.ControllerBroker, *config.Config, error) {
+		err := store.AddController(args.ControllerName, jujuclient.ControllerDetails{
+			ControllerUUID: args.ControllerConfig.ControllerUUID(),
+			CACert:         coretesting.CACert,
+		})
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		cfg, err := config.New(config.NoDefaults, args.ModelConfig)
+		return broker, cfg, errors.Trace(err)
+	})
+
+	var bootstrapArgs bootstrap.BootstrapParams
+	s.PatchValue(&bootstrapCAAS, func(
+		_ environs.BootstrapContext,
+		_ caas.ControllerBroker,
+		_ *config.Config,
+		args bootstrap.BootstrapParams,
+	) (*caas.Service, error) {
+		bootstrapArgs = args
+		if bootstrapErr != nil {
+			return nil, bootstrapErr
+		}
+		return &caas.Service{
+			Addresses: []network.Address{network.NewScopedAddress("54.0.0.1", network.ScopePublic)},
+		}, nil
+	})
+	return broker, &bootstrapArgs
+}
+
+func (s *BootstrapSuite) TestBootstrapCAAS(c *gc.C) {
+	broker, args := s.setupCAASBootstrap(c, nil)
+
+	_, err := cmdtesting.RunCommand(c, s.newBootstrapCommand(), "k8s-cloud", "k8s-controller")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(broker.destroyed, jc.IsFalse)
+
+	c.Check(args.Cloud.Name, gc.Equals, "k8s-cloud")
+	c.Check(args.CloudCredentialName, gc.Equals, "admin")
+	c.Check(args.HostedModelConfig["name"], gc.Equals, "default")
+
+	c.Check(s.store.CurrentControllerName, gc.Equals, "k8s-controller")
+	details, err := s.store.ControllerByName("k8s-controller")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(details.APIEndpoints, jc.DeepEquals, []string{"54.0.0.1:17070"})
+	modelDetails, err := s.store.ModelByName("k8s-controller", "admin/default")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(modelDetails.ModelType, gc.Equals, model.CAAS)
+}
+
+func (s *BootstrapSuite) TestBootstrapCAASFailureDestroysBroker(c *gc.C) {
+	broker, _ := s.setupCAASBootstrap(c, errors.New("pod not scheduled"))
+
+	_, err := cmdtesting.RunCommand(c, s.newBootstrapCommand(), "k8s-cloud", "k8s-controller")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(broker.destroyed, jc.IsTrue)
+	c.Check(s.tw.Log(), jc.LogMatches, []jc.SimpleMessage{
+		{loggo.ERROR, "failed to bootstrap model: pod not scheduled"},
+	})
+}
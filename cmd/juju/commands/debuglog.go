@@ -4,8 +4,10 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -45,17 +47,36 @@ machines and units can be seen in the output of `[1:] + "`juju status`" + `.
 The '--include' and '--exclude' options filter by entity. The entity can be
 a machine, unit, or application.
 
+The '--include-application' and '--exclude-application' options filter by
+application, matching the application itself and all of its units.
+
 The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-message' and '--exclude-message' options filter by matching
+a regular expression against the log message. The expressions use Go's
+regular expression syntax (https://golang.org/s/re2syntax).
+
+The '--until' option shows only messages logged at or before the given time
+(RFC3339 format). Once that time has passed, no more messages are shown.
+
+The '--format' option selects the output format. The "json" format writes
+one JSON object per line, for processing by other tools. The objects use
+the field names of the controller's log streaming API: "mid" (model UUID),
+"ent" (entity), "ts" (timestamp), "mod" (module), "lo" (location), "lv"
+(level) and "msg" (message).
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
+* All --include and --include-application options are logically ORed together.
+* All --exclude and --exclude-application options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --include-message and --exclude-message selections are logically ANDed to
+  form the complete filter.
 
 Examples:
 
@@ -94,6 +115,13 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+Show all messages from the mysql units mentioning "hook failed", logged
+before midday UTC on 1 October 2018, as JSON:
+
+    juju debug-log --replay --include-application mysql \
+        --include-message "hook failed" \
+        --until 2018-10-01T12:00:00Z --format json
+
 See also: 
     status
     ssh`
@@ -131,8 +159,13 @@ type debugLogCommand struct {
 	notail bool
 	color  bool
 
-	format string
-	tz     *time.Location
+	includeApplications []string
+	excludeApplications []string
+	until               string
+	outputFormat        string
+
+	timeFormat string
+	tz         *time.Location
 }
 
 func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
@@ -143,6 +176,12 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.Var(cmd.NewAppendStringsValue(&c.includeApplications), "include-application", "Only show log messages for these applications and their units")
+	f.Var(cmd.NewAppendStringsValue(&c.excludeApplications), "exclude-application", "Do not show log messages for these applications and their units")
+	f.StringVar(&c.params.IncludeMessage, "include-message", "", "Only show log messages matching this regular expression")
+	f.StringVar(&c.params.ExcludeMessage, "exclude-message", "", "Do not show log messages matching this regular expression")
+	f.StringVar(&c.until, "until", "", "Only show log messages logged at or before this time (RFC3339)")
+	f.StringVar(&c.outputFormat, "format", "text", "Output format, one of [text, json]")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -178,18 +217,61 @@ func (c *debugLogCommand) Init(args []string) error {
 		c.tz = time.UTC
 	}
 	if c.date {
-		c.format = "2006-01-02 15:04:05"
+		c.timeFormat = "2006-01-02 15:04:05"
 	} else {
-		c.format = "15:04:05"
+		c.timeFormat = "15:04:05"
 	}
 	if c.ms {
-		c.format = c.format + ".000"
+		c.timeFormat = c.timeFormat + ".000"
+	}
+	switch c.outputFormat {
+	case "text", "json":
+	default:
+		return errors.Errorf("format %q is not one of %q, %q", c.outputFormat, "text", "json")
+	}
+	for _, pattern := range []string{c.params.IncludeMessage, c.params.ExcludeMessage} {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return errors.Errorf("message pattern %q is not a valid regular expression", pattern)
+		}
+	}
+	if c.until != "" {
+		until, err := time.Parse(time.RFC3339, c.until)
+		if err != nil {
+			return errors.Errorf("until value %q is not a valid time in RFC3339 format", c.until)
+		}
+		c.params.EndTime = until
 	}
 	c.params.IncludeEntity = c.processEntities(c.params.IncludeEntity)
 	c.params.ExcludeEntity = c.processEntities(c.params.ExcludeEntity)
+	include, err := applicationEntities(c.includeApplications)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.params.IncludeEntity = append(c.params.IncludeEntity, include...)
+	exclude, err := applicationEntities(c.excludeApplications)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.params.ExcludeEntity = append(c.params.ExcludeEntity, exclude...)
 	return cmd.CheckEmpty(args)
 }
 
+// applicationEntities returns the entity patterns matching the given
+// applications and their units.
+func applicationEntities(applications []string) ([]string, error) {
+	var result []string
+	for _, application := range applications {
+		if !names.IsValidApplication(application) {
+			return nil, errors.NotValidf("application name %q", application)
+		}
+		result = append(result,
+			names.NewApplicationTag(application).String(),
+			names.UnitTagKind+"-"+application+"-*",
+		)
+	}
+	return result, nil
+}
+
 func (c *debugLogCommand) processEntities(entities []string) []string {
 	if entities == nil {
 		return nil
@@ -260,6 +342,9 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if c.outputFormat == "json" {
+		return c.writeJSONRecords(ctx, messages)
+	}
 	writer := ansiterm.NewWriter(ctx.Stdout)
 	if c.color {
 		writer.SetColorCapable(true)
@@ -275,6 +360,41 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	return nil
 }
 
+// jsonLogRecord is the JSON representation of a log message. The field
+// names match those of params.LogStreamRecord.
+type jsonLogRecord struct {
+	ModelUUID string    `json:"mid,omitempty"`
+	Entity    string    `json:"ent"`
+	Timestamp time.Time `json:"ts"`
+	Module    string    `json:"mod"`
+	Location  string    `json:"lo"`
+	Level     string    `json:"lv"`
+	Message   string    `json:"msg"`
+}
+
+func (c *debugLogCommand) writeJSONRecords(ctx *cmd.Context, messages <-chan common.LogMessage) error {
+	var modelUUID string
+	if _, details, err := c.ModelDetails(); err == nil {
+		modelUUID = details.ModelUUID
+	}
+	encoder := json.NewEncoder(ctx.Stdout)
+	for msg := range messages {
+		err := encoder.Encode(jsonLogRecord{
+			ModelUUID: modelUUID,
+			Entity:    msg.Entity,
+			Timestamp: msg.Timestamp,
+			Module:    msg.Module,
+			Location:  msg.Location,
+			Level:     msg.Severity,
+			Message:   msg.Message,
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 var SeverityColor = map[string]*ansiterm.Context{
 	"TRACE":   ansiterm.Foreground(ansiterm.Default),
 	"DEBUG":   ansiterm.Foreground(ansiterm.Green),
@@ -288,7 +408,7 @@ var SeverityColor = map[string]*ansiterm.Context{
 }
 
 func (c *debugLogCommand) writeLogRecord(w *ansiterm.Writer, r common.LogMessage) {
-	ts := r.Timestamp.In(c.tz).Format(c.format)
+	ts := r.Timestamp.In(c.tz).Format(c.timeFormat)
 	fmt.Fprintf(w, "%s: %s ", r.Entity, ts)
 	SeverityColor[r.Severity].Fprintf(w, r.Severity)
 	fmt.Fprintf(w, " %s ", r.Module)
@@ -104,6 +104,42 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{
+				"--include-application", "mysql",
+				"-i", "machine-1",
+				"--exclude-application", "wordpress",
+			},
+			expected: common.DebugLogParams{
+				IncludeEntity: []string{"machine-1", "application-mysql", "unit-mysql-*"},
+				ExcludeEntity: []string{"application-wordpress", "unit-wordpress-*"},
+				Backlog:       10,
+			},
+		}, {
+			args:     []string{"--include-application", "mysql/0"},
+			errMatch: `application name "mysql/0" not valid`,
+		}, {
+			args: []string{"--include-message", "hook (failed|error)", "--exclude-message", "^debug"},
+			expected: common.DebugLogParams{
+				IncludeMessage: "hook (failed|error)",
+				ExcludeMessage: "^debug",
+				Backlog:        10,
+			},
+		}, {
+			args:     []string{"--exclude-message", "hook ("},
+			errMatch: `message pattern "hook \(" is not a valid regular expression`,
+		}, {
+			args: []string{"--until", "2018-10-01T12:00:00Z"},
+			expected: common.DebugLogParams{
+				EndTime: time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC),
+				Backlog: 10,
+			},
+		}, {
+			args:     []string{"--until", "yesterday"},
+			errMatch: `until value "yesterday" is not a valid time in RFC3339 format`,
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format "yaml" is not one of "text", "json"`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -182,6 +218,10 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	checkOutput(
 		"--location",
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 this is the log output\n")
+	checkOutput(
+		"--format", "json",
+		`{"ent":"machine-0","ts":"2016-10-09T08:15:23.345Z","mod":"test.module",`+
+			`"lo":"somefile.go:123","lv":"INFO","msg":"this is the log output"}`+"\n")
 }
 
 type fakeDebugLogAPI struct {
@@ -33,6 +33,7 @@ Currently available charm hook tools are:
 
     action-fail              set action fail status with message
     action-get               get action parameters
+    action-log               record a progress message for the current action
     action-set               set action results
     add-metric               add metrics
     application-version-set  specify which version of the application is deployed
@@ -53,6 +54,8 @@ Currently available charm hook tools are:
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
     relation-set             set relation settings
+    state-get                print charm state
+    state-set                set charm state
     status-get               print status information
     status-set               set status information
     storage-add              add storage instances
@@ -73,6 +76,7 @@ Aliases: help-tool, hook-tools
 var expectedCommands = []string{
 	"action-fail",
 	"action-get",
+	"action-log",
 	"action-set",
 	"add-metric",
 	"application-version-set",
@@ -97,6 +101,8 @@ var expectedCommands = []string{
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"state-get",
+	"state-set",
 	"status-get",
 	"status-set",
 	"storage-add",
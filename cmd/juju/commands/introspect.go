@@ -0,0 +1,141 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/introspection"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const introspectDoc = `
+Runs one of the introspection handlers of a machine or unit agent and
+displays its output. The agent is asked to run the handler over its
+existing connection to the controller, so this works without access to
+the machine the agent is running on.
+
+The path is the same as would be given to juju-introspect on the
+machine itself, and may include a query string. Commonly used paths
+include:
+
+    depengine      the state of the agent's dependency engine
+    statetracker   the state objects tracked by a controller agent
+    metrics        the agent's prometheus metrics
+    machinelock    the holder and history of the machine lock
+
+Output is written exactly as the handler produced it, so binary output,
+such as that of the debug/pprof handlers, can be redirected to a file.
+The agent is given the time requested by a "seconds" query to produce
+a profile, in addition to the usual 30 second limit.
+
+Only controller superusers can introspect agents, and every request is
+recorded in the controller's audit log.
+
+Examples:
+
+    juju introspect 0 depengine
+    juju introspect mysql/0 machinelock
+    juju introspect -m controller 0 'statetracker?format=yaml'
+    juju introspect 0 'debug/pprof/profile?seconds=30' > cpu.pprof
+
+See also:
+    debug-log
+    audit-log
+`
+
+// IntrospectAPI defines the API methods used by the introspect
+// command.
+type IntrospectAPI interface {
+	Introspect(agent names.Tag, path string) ([]byte, error)
+	Close() error
+}
+
+func newIntrospectCommand() cmd.Command {
+	command := &introspectCommand{}
+	command.newAPIFunc = func() (IntrospectAPI, error) {
+		root, err := command.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot get API connection")
+		}
+		return introspection.NewClient(root), nil
+	}
+	return modelcmd.Wrap(command)
+}
+
+// introspectCommand runs an introspection handler of a remote agent.
+type introspectCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (IntrospectAPI, error)
+
+	agent names.Tag
+	path  string
+}
+
+// Info implements Command.Info.
+func (c *introspectCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "introspect",
+		Args:    "<machine|unit> <path>",
+		Purpose: "Displays introspection output from a machine or unit agent.",
+		Doc:     introspectDoc,
+	}
+}
+
+// Init implements Command.Init.
+func (c *introspectCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no machine or unit specified")
+	case 1:
+		return errors.New("no introspection path specified")
+	}
+	target, path := args[0], args[1]
+	switch {
+	case names.IsValidMachine(target):
+		c.agent = names.NewMachineTag(target)
+	case names.IsValidUnit(target):
+		c.agent = names.NewUnitTag(target)
+	default:
+		return errors.NotValidf("machine or unit %q", target)
+	}
+	if path == "" {
+		return errors.New("no introspection path specified")
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	c.path = path
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *introspectCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer api.Close()
+
+	output, err := api.Introspect(c.agent, c.path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := ctx.Stdout.Write(output); err != nil {
+		return errors.Trace(err)
+	}
+	// Terminate text output with a newline, but leave binary output,
+	// such as profiles, untouched.
+	if len(output) > 0 && utf8.Valid(output) && !bytes.HasSuffix(output, []byte("\n")) {
+		fmt.Fprintln(ctx.Stdout)
+	}
+	return nil
+}
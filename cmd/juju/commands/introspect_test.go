@@ -0,0 +1,128 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"bytes"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type introspectSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api   *fakeIntrospectAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&introspectSuite{})
+
+func (s *introspectSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeIntrospectAPI{
+		Stub:   &testing.Stub{},
+		output: []byte("engine report"),
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Models["ctrl"] = &jujuclient.ControllerModels{
+		Models:       map[string]jujuclient.ModelDetails{"admin/test": {ModelType: "iaas"}},
+		CurrentModel: "admin/test",
+	}
+	s.store.Accounts["ctrl"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+}
+
+func (s *introspectSuite) runIntrospect(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := &introspectCommand{
+		newAPIFunc: func() (IntrospectAPI, error) {
+			return s.api, nil
+		},
+	}
+	command.SetClientStore(s.store)
+	return cmdtesting.RunCommand(c, modelcmd.Wrap(command), args...)
+}
+
+func (s *introspectSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no machine or unit specified",
+	}, {
+		args: []string{"0"},
+		err:  "no introspection path specified",
+	}, {
+		args: []string{"0", ""},
+		err:  "no introspection path specified",
+	}, {
+		args: []string{"mysql", "depengine"},
+		err:  `machine or unit "mysql" not valid`,
+	}, {
+		args: []string{"0", "depengine", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.runIntrospect(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *introspectSuite) TestIntrospectMachine(c *gc.C) {
+	ctx, err := s.runIntrospect(c, "0", "depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "engine report\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Introspect", []interface{}{names.NewMachineTag("0"), "/depengine"}},
+		{"Close", nil},
+	})
+}
+
+func (s *introspectSuite) TestIntrospectBinaryOutput(c *gc.C) {
+	s.api.output = []byte{0x1f, 0x8b, 0x08, 0x00, 0xff}
+	ctx, err := s.runIntrospect(c, "0", "debug/pprof/heap")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ctx.Stdout.(*bytes.Buffer).Bytes(), jc.DeepEquals, s.api.output)
+}
+
+func (s *introspectSuite) TestIntrospectUnit(c *gc.C) {
+	_, err := s.runIntrospect(c, "mysql/0", "/machinelock?format=yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Introspect", names.NewUnitTag("mysql/0"), "/machinelock?format=yaml")
+}
+
+func (s *introspectSuite) TestIntrospectError(c *gc.C) {
+	s.api.SetErrors(errors.New("timed out waiting for machine 0 to respond"))
+	_, err := s.runIntrospect(c, "0", "depengine")
+	c.Assert(err, gc.ErrorMatches, "timed out waiting for machine 0 to respond")
+	s.api.CheckCallNames(c, "Introspect", "Close")
+}
+
+type fakeIntrospectAPI struct {
+	*testing.Stub
+	output []byte
+}
+
+func (f *fakeIntrospectAPI) Introspect(agent names.Tag, path string) ([]byte, error) {
+	f.MethodCall(f, "Introspect", agent, path)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.output, nil
+}
+
+func (f *fakeIntrospectAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/juju/subnet"
 	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/cmd/juju/waitfor"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju"
@@ -298,6 +299,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(waitfor.NewWaitForCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newDefaultRunCommand(nil))
@@ -306,6 +308,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewResolvedCommand())
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
+	r.Register(newIntrospectCommand())
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -378,6 +381,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 		r.Register(model.NewDumpDBCommand())
 	}
 	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewCreateModelBackupCommand())
+	r.Register(model.NewRestoreModelBackupCommand())
 
 	// Manage and control actions
 	r.Register(action.NewStatusCommand())
@@ -393,6 +398,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewAddUnitCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
+	r.Register(application.NewDiffBundleCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewApplicationGetConstraintsCommand())
@@ -408,11 +414,17 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewListCommand())
 	r.Register(storage.NewPoolCreateCommand())
 	r.Register(storage.NewPoolListCommand())
+	r.Register(storage.NewPoolUpdateCommand())
+	r.Register(storage.NewPoolRemoveCommand())
+	r.Register(storage.NewPoolShowCommand())
 	r.Register(storage.NewShowCommand())
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
+	r.Register(storage.NewResizeStorageCommand(storage.NewStorageResizer, nil))
+	r.Register(storage.NewCreateSnapshotCommand(storage.NewStorageSnapshotter, nil))
+	r.Register(storage.NewListSnapshotsCommand(storage.NewStorageSnapshotter, nil))
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
@@ -442,6 +454,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewUnregisterCommand(jujuclient.NewFileClientStore()))
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
+	r.Register(controller.NewAuditLogCommand())
 	r.Register(controller.NewConfigCommand())
 
 	// Debug Metrics
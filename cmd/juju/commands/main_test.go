@@ -407,6 +407,7 @@ var commandNames = []string{
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
@@ -423,7 +424,9 @@ var commandNames = []string{
 	"controller-config",
 	"controllers",
 	"create-backup",
+	"create-model-backup",
 	"create-storage-pool",
+	"create-storage-snapshot",
 	"create-wallet",
 	"credentials",
 	"debug-hooks",
@@ -432,6 +435,7 @@ var commandNames = []string{
 	"destroy-controller",
 	"destroy-model",
 	"detach-storage",
+	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -454,6 +458,7 @@ var commandNames = []string{
 	"hook-tools",
 	"import-filesystem",
 	"import-ssh-key",
+	"introspect",
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
@@ -476,6 +481,7 @@ var commandNames = []string{
 	"list-ssh-keys",
 	"list-storage",
 	"list-storage-pools",
+	"list-storage-snapshots",
 	"list-subnets",
 	"list-users",
 	"list-wallets",
@@ -509,12 +515,15 @@ var commandNames = []string{
 	"remove-saas",
 	"remove-ssh-key",
 	"remove-storage",
+	"remove-storage-pool",
 	"remove-unit",
 	"remove-user",
+	"resize-storage",
 	"resolved",
 	"resolve",
 	"resources",
 	"restore-backup",
+	"restore-model-backup",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
@@ -544,6 +553,7 @@ var commandNames = []string{
 	"show-status",
 	"show-status-log",
 	"show-storage",
+	"show-storage-pool",
 	"show-user",
 	"show-wallet",
 	"sla",
@@ -553,6 +563,7 @@ var commandNames = []string{
 	"status",
 	"storage",
 	"storage-pools",
+	"storage-snapshots",
 	"subnets",
 	"suspend-relation",
 	"switch",
@@ -564,6 +575,7 @@ var commandNames = []string{
 	"update-clouds",
 	"update-credential",
 	"update-series",
+	"update-storage-pool",
 	"upgrade-charm",
 	"upgrade-gui",
 	"upgrade-juju",
@@ -571,6 +583,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait-for",
 	"wallets",
 	"whoami",
 }
@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
 
@@ -28,10 +31,12 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	targetController string
+	dryRun           bool
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	CheckMigration(spec controller.MigrationSpec) ([]string, error)
 }
 
 const migrateDoc = `
@@ -57,6 +62,15 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+With --dry-run, the checks that are made before a migration starts are
+run, along with a trial import of the model on the target controller,
+but the migration isn't started and the model is left untouched. Every
+check that fails is reported, rather than only the first.
+
+Examples:
+    juju migrate mymodel othercontroller
+    juju migrate --dry-run mymodel othercontroller
+
 See also:
     login
     controllers
@@ -73,6 +87,12 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the migration would succeed, without starting it")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -141,6 +161,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.checkMigration(ctx, api, *spec)
+	}
 	id, err := api.InitiateMigration(*spec)
 	if err != nil {
 		return err
@@ -149,6 +172,22 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) checkMigration(ctx *cmd.Context, api migrateAPI, spec controller.MigrationSpec) error {
+	failures, err := api.CheckMigration(spec)
+	if err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		ctx.Infof("Migration checks passed")
+		return nil
+	}
+	fmt.Fprintln(ctx.Stderr, "Migration checks failed:")
+	for _, failure := range failures {
+		fmt.Fprintf(ctx.Stderr, "  - %s\n", failure)
+	}
+	return cmd.ErrSilent
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
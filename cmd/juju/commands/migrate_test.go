@@ -213,6 +213,32 @@ func (s *MigrateSuite) TestSpecifyOwner(c *gc.C) {
 	c.Check(s.api.specSeen.ModelUUID, gc.Equals, "prod-1-uuid")
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Migration checks passed\n")
+	c.Check(s.api.checked, jc.IsTrue)
+	c.Check(s.api.specSeen.ModelUUID, gc.Equals, modelUUID)
+	c.Check(s.api.specSeen.TargetControllerUUID, gc.Equals, targetControllerUUID)
+}
+
+func (s *MigrateSuite) TestDryRunFailures(c *gc.C) {
+	s.api.checkFailures = []string{
+		"source: model is dying",
+		"target: upgrade in progress",
+	}
+	ctx, err := s.makeAndRun(c, "--dry-run", "model", "target")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Migration checks failed:
+  - source: model is dying
+  - target: upgrade in progress
+`[1:])
+	c.Check(s.api.checked, jc.IsTrue)
+}
+
 func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	_, err := s.makeAndRun(c, "model", "wat")
 	c.Check(err, gc.ErrorMatches, "controller wat not found")
@@ -236,7 +262,9 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	specSeen      *controller.MigrationSpec
+	checkFailures []string
+	checked       bool
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
@@ -244,6 +272,12 @@ func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (strin
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) CheckMigration(spec controller.MigrationSpec) ([]string, error) {
+	a.specSeen = &spec
+	a.checked = true
+	return a.checkFailures, nil
+}
+
 type fakeModelAPI struct {
 	models []base.UserModel
 }
@@ -0,0 +1,267 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewAuditLogCommand returns a command to query the audit logs of the
+// controller machines.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{clock: clock.WallClock})
+}
+
+// AuditLogAPI defines the API methods used by the audit-log command.
+type AuditLogAPI interface {
+	Query(params.AuditLogQueryArgs) (params.AuditLogQueryResult, error)
+	Close() error
+}
+
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out   cmd.Output
+	api   AuditLogAPI
+	clock clock.Clock
+
+	args       params.AuditLogQueryArgs
+	after      string
+	before     string
+	facadeCall string
+}
+
+// defaultAuditLogLimit is the number of entries shown when no --limit
+// is given.
+const defaultAuditLogLimit = 20
+
+const auditLogDoc = `
+Queries the audit logs of all of the controller machines, showing the
+API requests which match the filters supplied. The audit log files on
+each controller remain the record of what happened; this command reads
+and merges them so they can be searched from one place.
+
+Requests can be filtered by the user that made them, the model they
+were made against, the API facade and method called, and the time they
+were made. The --after and --before options take either an RFC3339
+timestamp or a duration, which is taken to mean that long ago. Models
+can be given by UUID, by name, or as owner/name.
+
+By default only the 20 most recent matching requests are shown; use
+--limit=0 to show as many as the controllers allow, which is the 1000
+most recent.
+
+Audit logging must be enabled in the controller configuration for any
+requests to be recorded. Only controller superusers can query the audit
+logs.
+
+Examples:
+
+    juju audit-log --user=bob --after=24h
+    juju audit-log --model=admin/default --method=Application.Destroy
+    juju audit-log --errors-only --after=2018-06-01T00:00:00Z --format=json
+    juju audit-log --limit=0
+
+See also:
+    controller-config
+`
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Queries the controller audit logs.",
+		Doc:     auditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.args.Who, "user", "", "Only show requests made by this user")
+	f.StringVar(&c.args.Model, "model", "", "Only show requests made against this model (name, owner/name or UUID)")
+	f.StringVar(&c.facadeCall, "method", "", "Only show calls to this facade or Facade.Method")
+	f.StringVar(&c.after, "after", "", "Only show requests made after this time or duration ago")
+	f.StringVar(&c.before, "before", "", "Only show requests made before this time or duration ago")
+	f.BoolVar(&c.args.ErrorsOnly, "errors-only", false, "Only show requests which resulted in an error")
+	f.IntVar(&c.args.Limit, "limit", defaultAuditLogLimit, "Show at most this many of the most recent requests (0 for the controller maximum)")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	if c.args.Limit < 0 {
+		return errors.Errorf("--limit must not be negative")
+	}
+	if c.facadeCall != "" {
+		parts := strings.SplitN(c.facadeCall, ".", 2)
+		c.args.Facade = parts[0]
+		if len(parts) == 2 {
+			c.args.Method = parts[1]
+		}
+		if c.args.Facade == "" && c.args.Method == "" {
+			return errors.Errorf("invalid --method %q", c.facadeCall)
+		}
+	}
+	now := c.clock.Now()
+	var err error
+	if c.args.After, err = parseTimeOrAgo("--after", c.after, now); err != nil {
+		return errors.Trace(err)
+	}
+	if c.args.Before, err = parseTimeOrAgo("--before", c.before, now); err != nil {
+		return errors.Trace(err)
+	}
+	if c.args.After != nil && c.args.Before != nil && !c.args.After.Before(*c.args.Before) {
+		return errors.Errorf("--after must be earlier than --before")
+	}
+	return nil
+}
+
+// parseTimeOrAgo parses value as either an RFC3339 timestamp or a
+// duration before now.
+func parseTimeOrAgo(flag, value string, now time.Time) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		t := now.Add(-d)
+		return &t, nil
+	}
+	return nil, errors.Errorf("%s: expected an RFC3339 time or a duration, got %q", flag, value)
+}
+
+func (c *auditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.Query(c.args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(result.Unavailable) > 0 {
+		ctx.Warningf("no response from controller machines %s; results are incomplete",
+			strings.Join(result.Unavailable, ", "))
+	}
+	entries := make([]auditLogEntry, len(result.Entries))
+	for i, e := range result.Entries {
+		entries[i] = newAuditLogEntry(e)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No matching audit log entries.")
+		return nil
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// auditLogEntry is the serialised form of an audit log entry.
+type auditLogEntry struct {
+	When           time.Time       `yaml:"when" json:"when"`
+	Controller     string          `yaml:"controller-machine" json:"controller-machine"`
+	User           string          `yaml:"user" json:"user"`
+	Model          string          `yaml:"model" json:"model"`
+	ModelUUID      string          `yaml:"model-uuid" json:"model-uuid"`
+	Command        string          `yaml:"command" json:"command"`
+	ConversationID string          `yaml:"conversation-id" json:"conversation-id"`
+	ConnectionID   string          `yaml:"connection-id" json:"connection-id"`
+	RequestID      uint64          `yaml:"request-id" json:"request-id"`
+	Facade         string          `yaml:"facade" json:"facade"`
+	Method         string          `yaml:"method" json:"method"`
+	Version        int             `yaml:"version" json:"version"`
+	Args           string          `yaml:"args,omitempty" json:"args,omitempty"`
+	Errors         []auditLogError `yaml:"errors,omitempty" json:"errors,omitempty"`
+}
+
+type auditLogError struct {
+	Message string `yaml:"message" json:"message"`
+	Code    string `yaml:"code,omitempty" json:"code,omitempty"`
+}
+
+func newAuditLogEntry(e params.AuditLogEntry) auditLogEntry {
+	entry := auditLogEntry{
+		When:           e.When,
+		Controller:     e.ControllerMachine,
+		User:           e.Who,
+		Model:          e.ModelName,
+		ModelUUID:      e.ModelUUID,
+		Command:        e.What,
+		ConversationID: e.ConversationID,
+		ConnectionID:   e.ConnectionID,
+		RequestID:      e.RequestID,
+		Facade:         e.Facade,
+		Method:         e.Method,
+		Version:        e.Version,
+		Args:           e.Args,
+	}
+	for _, err := range e.Errors {
+		entry.Errors = append(entry.Errors, auditLogError{
+			Message: err.Message,
+			Code:    err.Code,
+		})
+	}
+	return entry
+}
+
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Controller", "User", "Model", "Call", "Error", "Command")
+	for _, e := range entries {
+		errorText := noValueDisplay
+		if len(e.Errors) > 0 {
+			messages := make([]string, len(e.Errors))
+			for i, err := range e.Errors {
+				messages[i] = err.Message
+			}
+			errorText = strings.Join(messages, "; ")
+		}
+		model := e.Model
+		if model == "" {
+			model = noValueDisplay
+		}
+		call := fmt.Sprintf("%s.%s", e.Facade, e.Method)
+		w.Println(e.When.Format(time.RFC3339), e.Controller, e.User, model, call, errorText, e.Command)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,193 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type auditLogSuite struct {
+	baseControllerSuite
+	api   *fakeAuditLogAPI
+	clock *testclock.Clock
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+var auditLogNow = time.Date(2018, 6, 8, 12, 0, 0, 0, time.UTC)
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.api = &fakeAuditLogAPI{
+		result: params.AuditLogQueryResult{
+			Entries: []params.AuditLogEntry{{
+				ControllerMachine: "0",
+				Who:               "bob",
+				What:              "juju remove-application wordpress",
+				ModelName:         "admin/default",
+				ModelUUID:         "deadbeef",
+				ConversationID:    "c1",
+				ConnectionID:      "A1",
+				RequestID:         1,
+				When:              time.Date(2018, 6, 7, 10, 0, 0, 0, time.UTC),
+				Facade:            "Application",
+				Method:            "Destroy",
+				Version:           8,
+				Errors:            []params.AuditLogError{{Message: "boom"}},
+			}},
+		},
+	}
+	s.clock = testclock.NewClock(auditLogNow)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *auditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.clock, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *auditLogSuite) TestFilters(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "admin/default",
+		"--method", "Application.Destroy",
+		"--after", "24h",
+		"--before", "2018-06-08T00:00:00Z",
+		"--errors-only",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	after := auditLogNow.Add(-24 * time.Hour)
+	before := time.Date(2018, 6, 8, 0, 0, 0, 0, time.UTC)
+	c.Assert(s.api.args, jc.DeepEquals, params.AuditLogQueryArgs{
+		Who:        "bob",
+		Model:      "admin/default",
+		Facade:     "Application",
+		Method:     "Destroy",
+		After:      &after,
+		Before:     &before,
+		ErrorsOnly: true,
+		Limit:      5,
+	})
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *auditLogSuite) TestDefaultLimit(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.args.Limit, gc.Equals, 20)
+
+	_, err = s.run(c, "--limit", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.args.Limit, gc.Equals, 0)
+}
+
+func (s *auditLogSuite) TestFacadeOnly(c *gc.C) {
+	_, err := s.run(c, "--method", "Application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.args.Facade, gc.Equals, "Application")
+	c.Assert(s.api.args.Method, gc.Equals, "")
+}
+
+func (s *auditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  "--limit must not be negative",
+	}, {
+		args: []string{"--method", "."},
+		err:  `invalid --method "."`,
+	}, {
+		args: []string{"--after", "yesterday"},
+		err:  `--after: expected an RFC3339 time or a duration, got "yesterday"`,
+	}, {
+		args: []string{"--after", "1h", "--before", "2h"},
+		err:  "--after must be earlier than --before",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *auditLogSuite) TestTabular(c *gc.C) {
+	s.api.result.Unavailable = []string{"2"}
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  Controller  User  Model          Call                 Error  Command\n"+
+		"2018-06-07T10:00:00Z  0           bob   admin/default  Application.Destroy  boom   juju remove-application wordpress\n")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, "no response from controller machines 2; results are incomplete")
+}
+
+func (s *auditLogSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- when: 2018-06-07T10:00:00Z
+  controller-machine: "0"
+  user: bob
+  model: admin/default
+  model-uuid: deadbeef
+  command: juju remove-application wordpress
+  conversation-id: c1
+  connection-id: A1
+  request-id: 1
+  facade: Application
+  method: Destroy
+  version: 8
+  errors:
+  - message: boom
+`[1:])
+}
+
+func (s *auditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.result.Entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No matching audit log entries.\n")
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	s.api.err = errors.New("permission denied")
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	args   params.AuditLogQueryArgs
+	result params.AuditLogQueryResult
+	err    error
+	closed bool
+}
+
+func (f *fakeAuditLogAPI) Query(args params.AuditLogQueryArgs) (params.AuditLogQueryResult, error) {
+	f.args = args
+	return f.result, f.err
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.closed = true
+	return nil
+}
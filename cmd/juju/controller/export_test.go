@@ -189,3 +189,14 @@ func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, er
 var (
 	NoModelsMessage = noModelsMessage
 )
+
+// NewAuditLogCommandForTest returns an auditLogCommand with the API
+// and clock provided.
+func NewAuditLogCommandForTest(api AuditLogAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	c := &auditLogCommand{
+		api:   api,
+		clock: clock,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
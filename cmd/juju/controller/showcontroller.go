@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -15,6 +16,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/bootstrap"
@@ -88,6 +90,7 @@ type ControllerAccessAPI interface {
 	ModelConfig() (map[string]interface{}, error)
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 	AllModels() ([]base.UserModel, error)
+	BackupScheduleStatus() (params.BackupScheduleStatus, error)
 	Close() error
 }
 
@@ -164,6 +167,11 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 		}
 
 		c.convertControllerForShow(&details, controllerName, one, access, allModels, modelStatusResults)
+		if backups, err := scheduledBackups(client); err != nil {
+			details.Errors = append(details.Errors, err.Error())
+		} else {
+			details.ScheduledBackups = backups
+		}
 		controllers[controllerName] = details
 		machineCount := 0
 		for _, r := range modelStatusResults {
@@ -218,6 +226,35 @@ func (c *showControllerCommand) agentVersion(client ControllerAccessAPI, ctx *cm
 	return mc["agent-version"].(string)
 }
 
+// scheduledBackups returns the details of the controller's scheduled
+// backups, or nil if there are none or the user can't see them.
+func scheduledBackups(client ControllerAccessAPI) (*ScheduledBackupDetails, error) {
+	result, err := client.BackupScheduleStatus()
+	if errors.IsNotSupported(err) || params.IsCodeUnauthorized(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting scheduled backup status")
+	}
+	if result.Schedule == "" && result.LastAttempt == nil {
+		return nil, nil
+	}
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return common.FormatTime(t, true)
+	}
+	return &ScheduledBackupDetails{
+		Schedule:     result.Schedule,
+		MaxCount:     result.MaxCount,
+		MaxAge:       result.MaxAge,
+		LastAttempt:  formatTime(result.LastAttempt),
+		LastSuccess:  formatTime(result.LastSuccess),
+		LastBackupID: result.LastBackupID,
+		LastError:    result.LastError,
+	}, nil
+}
+
 type ShowControllerDetails struct {
 	// Details contains the same details that client store caches for this controller.
 	Details ControllerDetails `yaml:"details,omitempty" json:"details,omitempty"`
@@ -234,10 +271,39 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// ScheduledBackups holds the schedule and outcome of the
+	// controller's scheduled backups.
+	ScheduledBackups *ScheduledBackupDetails `yaml:"scheduled-backups,omitempty" json:"scheduled-backups,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
 
+// ScheduledBackupDetails holds details of a controller's scheduled
+// backups to show.
+type ScheduledBackupDetails struct {
+	// Schedule is the cron-like schedule the backups are made on.
+	Schedule string `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+
+	// MaxCount is the number of scheduled backups kept.
+	MaxCount int `yaml:"max-count,omitempty" json:"max-count,omitempty"`
+
+	// MaxAge is how long scheduled backups are kept for.
+	MaxAge string `yaml:"max-age,omitempty" json:"max-age,omitempty"`
+
+	// LastAttempt is when a scheduled backup was last started.
+	LastAttempt string `yaml:"last-attempt,omitempty" json:"last-attempt,omitempty"`
+
+	// LastSuccess is when a scheduled backup last succeeded.
+	LastSuccess string `yaml:"last-success,omitempty" json:"last-success,omitempty"`
+
+	// LastBackupID is the ID of the last successful scheduled backup.
+	LastBackupID string `yaml:"last-backup-id,omitempty" json:"last-backup-id,omitempty"`
+
+	// LastError is the error from the last scheduled backup, if it failed.
+	LastError string `yaml:"last-error,omitempty" json:"last-error,omitempty"`
+}
+
 // ControllerDetails holds details of a controller to show.
 type ControllerDetails struct {
 	// TODO(anastasiamac 2018-08-10) This is a deprecated property, see lp#1596607.
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -14,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
@@ -277,6 +279,26 @@ func (s *ShowControllerSuite) TestShowControllerJsonOne(c *gc.C) {
 	s.assertShowController(c, "--format", "json", "aws-test")
 }
 
+func (s *ShowControllerSuite) TestShowControllerScheduledBackups(c *gc.C) {
+	s.createTestClientStore(c)
+	attempt := time.Date(2019, 3, 1, 2, 0, 0, 0, time.UTC)
+	success := attempt.Add(-24 * time.Hour)
+	s.fakeController.backupStatus = &params.BackupScheduleStatus{
+		Schedule:     "0 2 * * *",
+		MaxCount:     7,
+		LastAttempt:  &attempt,
+		LastSuccess:  &success,
+		LastBackupID: "backup-id",
+		LastError:    "disk full",
+	}
+
+	s.expectedOutput = `
+{"aws-test":{"details":{"uuid":"this-is-the-aws-test-uuid","api-endpoints":["this-is-aws-test-of-many-api-endpoints"],"ca-cert":"this-is-aws-test-ca-cert","cloud":"aws","region":"us-east-1","agent-version":"999.99.99"},"controller-machines":{"0":{"instance-id":"id-0","ha-status":"ha-pending"},"1":{"instance-id":"id-1","ha-status":"down, lost connection"},"2":{"instance-id":"id-2","ha-status":"ha-enabled"}},"models":{"controller":{"uuid":"ghi","machine-count":2,"core-count":4}},"current-model":"admin/controller","account":{"user":"admin","access":"superuser"},"scheduled-backups":{"schedule":"0 2 * * *","max-count":7,"last-attempt":"2019-03-01 02:00:00Z","last-success":"2019-02-28 02:00:00Z","last-backup-id":"backup-id","last-error":"disk full"}}}
+`[1:]
+
+	s.assertShowController(c, "--format", "json", "aws-test")
+}
+
 func (s *ShowControllerSuite) TestShowControllerJsonMany(c *gc.C) {
 	s.createTestClientStore(c)
 	s.expectedOutput = `
@@ -383,6 +405,14 @@ func (s *ShowControllerSuite) assertShowController(c *gc.C, args ...string) {
 type fakeController struct {
 	controllerName string
 	machines       map[string][]base.Machine
+	backupStatus   *params.BackupScheduleStatus
+}
+
+func (c *fakeController) BackupScheduleStatus() (params.BackupScheduleStatus, error) {
+	if c.backupStatus == nil {
+		return params.BackupScheduleStatus{}, errors.NotSupportedf("scheduled backups")
+	}
+	return *c.backupStatus, nil
 }
 
 func (*fakeController) GetControllerAccess(user string) (permission.Access, error) {
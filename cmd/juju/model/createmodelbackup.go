@@ -0,0 +1,228 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewCreateModelBackupCommand returns a fully constructed
+// create-model-backup command.
+func NewCreateModelBackupCommand() cmd.Command {
+	cmd := &createModelBackupCommand{}
+	cmd.newAPIFunc = cmd.getAPI
+	return modelcmd.Wrap(cmd)
+}
+
+type createModelBackupCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (CreateModelBackupAPI, error)
+	filename   string
+}
+
+const createModelBackupHelpDoc = `
+Writes a backup of a single model to a local file. The backup holds the
+model's description, as used when migrating models, along with the
+charms and resources deployed in the model. It can be restored into
+this or another controller with restore-model-backup, even if the
+model has since been destroyed.
+
+Unlike create-backup, which backs up the whole controller, the backup
+does not include the state of the model's machines themselves.
+
+If --filename is not given, the backup is written to a file in the
+current directory named after the model and the time.
+
+Examples:
+
+    juju create-model-backup
+    juju create-model-backup -m mymodel --filename mymodel.tar.gz
+
+See also:
+    restore-model-backup
+    create-backup
+`
+
+// Info implements Command.
+func (c *createModelBackupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-model-backup",
+		Purpose: "Creates a backup of a single model.",
+		Doc:     createModelBackupHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *createModelBackupCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.filename, "filename", "", "Write the backup to this file")
+}
+
+// Init implements Command.
+func (c *createModelBackupCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// CreateModelBackupAPI specifies the API methods needed to back up
+// a model.
+type CreateModelBackupAPI interface {
+	Close() error
+	ExportModel(names.ModelTag) ([]byte, error)
+	OpenCharm(*charm.URL) (io.ReadCloser, error)
+	OpenResource(application, name string) (io.ReadCloser, error)
+}
+
+// createModelBackupAPI exports the model over a controller connection
+// and downloads its binaries over a model connection.
+type createModelBackupAPI struct {
+	*modelmanager.Client
+	client *api.Client
+}
+
+// OpenCharm is part of CreateModelBackupAPI.
+func (a *createModelBackupAPI) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return a.client.OpenCharm(curl)
+}
+
+// OpenResource is part of CreateModelBackupAPI.
+func (a *createModelBackupAPI) OpenResource(application, name string) (io.ReadCloser, error) {
+	uri := fmt.Sprintf("/applications/%s/resources/%s", application, name)
+	return a.client.OpenURI(uri, nil)
+}
+
+// Close is part of CreateModelBackupAPI.
+func (a *createModelBackupAPI) Close() error {
+	err := a.client.Close()
+	if err2 := a.Client.Close(); err == nil {
+		err = err2
+	}
+	return err
+}
+
+func (c *createModelBackupCommand) getAPI() (CreateModelBackupAPI, error) {
+	modelManager, err := c.NewModelManagerAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		modelManager.Close()
+		return nil, errors.Trace(err)
+	}
+	return &createModelBackupAPI{
+		Client: modelManager,
+		client: root.Client(),
+	}, nil
+}
+
+// Run implements Command.
+func (c *createModelBackupCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	modelName, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	bytes, err := client.ExportModel(names.NewModelTag(modelDetails.ModelUUID))
+	if err != nil {
+		return errors.Annotate(err, "exporting model")
+	}
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return errors.Annotate(err, "reading model description")
+	}
+
+	filename := c.filename
+	if filename == "" {
+		filename = fmt.Sprintf("juju-model-backup-%s-%s.tar.gz",
+			strings.Replace(modelName, "/", "-", -1),
+			time.Now().UTC().Format("20060102-150405"),
+		)
+	}
+	path := ctx.AbsPath(filename)
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = writeModelBackup(f, client, bytes, model)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return errors.Trace(err)
+	}
+
+	ctx.Infof("created model backup %s", filename)
+	return nil
+}
+
+func writeModelBackup(w io.Writer, client CreateModelBackupAPI, bytes []byte, model description.Model) error {
+	archive := newModelBackupWriter(w)
+	if err := archive.AddBytes(modelBackupDescription, bytes); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, curl := range modelCharms(model) {
+		logger.Debugf("backing up charm %s", curl)
+		parsed, err := charm.ParseURL(curl)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := client.OpenCharm(parsed)
+		if err != nil {
+			return errors.Annotatef(err, "downloading charm %s", curl)
+		}
+		err = archive.AddReader(charmBackupPath(curl), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "backing up charm %s", curl)
+		}
+	}
+
+	resources, err := modelResources(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, res := range resources {
+		rev := res.ApplicationRevision
+		if rev.IsPlaceholder() {
+			// Placeholders have no content, and are recreated
+			// from the model description.
+			continue
+		}
+		logger.Debugf("backing up resource %s/%s", rev.ApplicationID, rev.Name)
+		reader, err := client.OpenResource(rev.ApplicationID, rev.Name)
+		if err != nil {
+			return errors.Annotatef(err, "downloading resource %s/%s", rev.ApplicationID, rev.Name)
+		}
+		err = archive.AddReader(resourceBackupPath(rev.ApplicationID, rev.Name), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "backing up resource %s/%s", rev.ApplicationID, rev.Name)
+		}
+	}
+
+	return errors.Trace(archive.Close())
+}
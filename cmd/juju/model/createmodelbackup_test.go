@@ -0,0 +1,253 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type CreateModelBackupCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeCreateModelBackupClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&CreateModelBackupCommandSuite{})
+
+func (s *CreateModelBackupCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	_, bytes := modelBackupTestModel(c)
+	s.fake = &fakeCreateModelBackupClient{
+		Stub:  &jujutesting.Stub{},
+		bytes: bytes,
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+const (
+	modelBackupTestCharm    = "cs:quantal/ubuntu-1"
+	modelBackupTestResource = "some resource content"
+)
+
+// modelBackupTestModel returns a model with one application that has
+// a unit, a machine and an uploaded resource, along with its
+// serialized form.
+func modelBackupTestModel(c *gc.C) (description.Model, []byte) {
+	m := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name": "mymodel",
+			"uuid": testing.ModelTag.Id(),
+		},
+		CloudRegion: "some-region",
+	})
+	m.SetStatus(description.StatusArgs{Value: "available"})
+	tools := description.AgentToolsArgs{
+		Version: version.MustParseBinary("2.6.0-quantal-amd64"),
+		URL:     "some-url",
+		SHA256:  "some-sha",
+		Size:    1234,
+	}
+
+	machine := m.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("0"),
+		Series: "quantal",
+	})
+	machine.SetStatus(description.StatusArgs{Value: "started"})
+	machine.SetTools(tools)
+	machine.SetInstance(description.CloudInstanceArgs{InstanceId: "inst-0"})
+	machine.Instance().SetStatus(description.StatusArgs{Value: "running"})
+	container := machine.AddContainer(description.MachineArgs{
+		Id:     names.NewMachineTag("0/lxd/0"),
+		Series: "quantal",
+	})
+	container.SetStatus(description.StatusArgs{Value: "started"})
+	container.SetTools(tools)
+	container.SetInstance(description.CloudInstanceArgs{InstanceId: "inst-0-lxd-0"})
+	container.Instance().SetStatus(description.StatusArgs{Value: "running"})
+
+	app := m.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("ubuntu"),
+		Series:   "quantal",
+		CharmURL: modelBackupTestCharm,
+	})
+	app.SetStatus(description.StatusArgs{Value: "active"})
+	unit := app.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("ubuntu/0"),
+		Machine: names.NewMachineTag("0"),
+	})
+	unit.SetAgentStatus(description.StatusArgs{Value: "idle"})
+	unit.SetWorkloadStatus(description.StatusArgs{Value: "active"})
+	unit.SetTools(tools)
+
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader(modelBackupTestResource))
+	c.Assert(err, jc.ErrorIsNil)
+	revision := description.ResourceRevisionArgs{
+		Revision:       1,
+		Type:           "file",
+		Path:           "blob.txt",
+		Origin:         "upload",
+		FingerprintHex: fp.Hex(),
+		Size:           int64(len(modelBackupTestResource)),
+		Timestamp:      time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		Username:       "admin",
+	}
+	res := app.AddResource(description.ResourceArgs{Name: "blob"})
+	res.SetApplicationRevision(revision)
+	unit.AddResource(description.UnitResourceArgs{
+		Name:         "blob",
+		RevisionArgs: revision,
+	})
+	placeholder := app.AddResource(description.ResourceArgs{Name: "unused"})
+	placeholder.SetApplicationRevision(description.ResourceRevisionArgs{
+		Type:   "file",
+		Path:   "unused.txt",
+		Origin: "upload",
+	})
+
+	bytes, err := description.Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+	return m, bytes
+}
+
+// readModelBackup returns the contents of the files in the model
+// backup archive.
+func readModelBackup(c *gc.C, filename string) map[string]string {
+	f, err := os.Open(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	gzr, err := gzip.NewReader(f)
+	c.Assert(err, jc.ErrorIsNil)
+	tr := tar.NewReader(gzr)
+	files := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		data, err := ioutil.ReadAll(tr)
+		c.Assert(err, jc.ErrorIsNil)
+		files[hdr.Name] = string(data)
+	}
+}
+
+func (s *CreateModelBackupCommandSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := model.NewCreateModelBackupCommandForTest(s.fake, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *CreateModelBackupCommandSuite) TestCreate(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	ctx, err := s.run(c, "--filename", filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "created model backup "+filename+"\n")
+
+	s.fake.CheckCalls(c, []jujutesting.StubCall{
+		{"ExportModel", []interface{}{testing.ModelTag}},
+		{"OpenCharm", []interface{}{modelBackupTestCharm}},
+		{"OpenResource", []interface{}{"ubuntu", "blob"}},
+		{"Close", nil},
+	})
+	c.Assert(readModelBackup(c, filename), jc.DeepEquals, map[string]string{
+		"model.yaml":                         string(s.fake.bytes),
+		"charms/cs%3Aquantal%2Fubuntu-1.zip": "charm " + modelBackupTestCharm,
+		"resources/ubuntu/blob":              modelBackupTestResource,
+	})
+}
+
+func (s *CreateModelBackupCommandSuite) TestCreateDefaultFilename(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	matches, err := filepath.Glob(filepath.Join(ctx.Dir, "juju-model-backup-admin-mymodel-*.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(matches, gc.HasLen, 1)
+}
+
+func (s *CreateModelBackupCommandSuite) TestCreateExportError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	_, err := s.run(c, "--filename", filename)
+	c.Assert(err, gc.ErrorMatches, "exporting model: boom")
+	_, err = os.Stat(filename)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *CreateModelBackupCommandSuite) TestCreateDownloadError(c *gc.C) {
+	s.fake.SetErrors(nil, nil, errors.New("boom"))
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz")
+	_, err := s.run(c, "--filename", filename)
+	c.Assert(err, gc.ErrorMatches, "downloading resource ubuntu/blob: boom")
+	_, err = os.Stat(filename)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+type fakeCreateModelBackupClient struct {
+	*jujutesting.Stub
+	bytes []byte
+}
+
+func (f *fakeCreateModelBackupClient) ExportModel(model names.ModelTag) ([]byte, error) {
+	f.MethodCall(f, "ExportModel", model)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.bytes, nil
+}
+
+func (f *fakeCreateModelBackupClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl.String())
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(strings.NewReader("charm " + curl.String())), nil
+}
+
+func (f *fakeCreateModelBackupClient) OpenResource(application, name string) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenResource", application, name)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(strings.NewReader(modelBackupTestResource)), nil
+}
+
+func (f *fakeCreateModelBackupClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
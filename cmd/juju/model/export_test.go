@@ -76,6 +76,24 @@ func NewExportBundleCommandForTest(api ExportBundleAPI, store jujuclient.ClientS
 	return modelcmd.Wrap(cmd)
 }
 
+// NewCreateModelBackupCommandForTest returns a CreateModelBackupCommand with the api provided as specified.
+func NewCreateModelBackupCommandForTest(api CreateModelBackupAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &createModelBackupCommand{newAPIFunc: func() (CreateModelBackupAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRestoreModelBackupCommandForTest returns a RestoreModelBackupCommand with the api provided as specified.
+func NewRestoreModelBackupCommandForTest(api RestoreModelBackupAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &restoreModelBackupCommand{newAPIFunc: func() (RestoreModelBackupAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the api provided as specified.
 func NewDestroyCommandForTest(
 	api DestroyModelAPI,
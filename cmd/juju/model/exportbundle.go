@@ -37,6 +37,11 @@ Exports the current model configuration as bundle.
 If --filename is not used, the configuration is printed to stdout.
  --filename specifies an output file.
 
+Consumed remote applications are listed in the bundle's saas section.
+Offers and per-endpoint expose settings can only be given in an
+overlay, so they are written in a second YAML document following the
+bundle. Save it to a file and pass it to deploy with --overlay.
+
 Examples:
 
     juju export-bundle
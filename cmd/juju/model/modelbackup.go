@@ -0,0 +1,260 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/naturalsort"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/yaml.v2"
+
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/resource"
+)
+
+// A model backup is a gzipped tar archive holding the serialized model
+// description, as used for model migrations, along with the archives
+// of the charms and the resource blobs the model uses.
+const (
+	modelBackupDescription  = "model.yaml"
+	modelBackupCharmsDir    = "charms"
+	modelBackupResourcesDir = "resources"
+)
+
+func charmBackupPath(curl string) string {
+	return path.Join(modelBackupCharmsDir, url.QueryEscape(curl)+".zip")
+}
+
+func resourceBackupPath(application, name string) string {
+	return path.Join(modelBackupResourcesDir, application, name)
+}
+
+// modelCharms returns the URLs of the charms used by the model, in
+// the order in which they need to be uploaded so that charm revisions
+// are preserved.
+func modelCharms(model description.Model) []string {
+	seen := make(map[string]bool)
+	var charms []string
+	for _, app := range model.Applications() {
+		if curl := app.CharmURL(); !seen[curl] {
+			seen[curl] = true
+			charms = append(charms, curl)
+		}
+	}
+	naturalsort.Sort(charms)
+	return charms
+}
+
+// modelResources returns the resources used by the model's
+// applications, along with the revisions used by each unit.
+func modelResources(model description.Model) ([]coremigration.SerializedModelResource, error) {
+	var out []coremigration.SerializedModelResource
+	for _, app := range model.Applications() {
+		for _, res := range app.Resources() {
+			appRev, err := resourceRevision(app.Name(), res.Name(), res.ApplicationRevision())
+			if err != nil {
+				return nil, errors.Annotatef(err, "resource %s/%s", app.Name(), res.Name())
+			}
+			unitRevs := make(map[string]resource.Resource)
+			for _, unit := range app.Units() {
+				for _, unitRes := range unit.Resources() {
+					if unitRes.Name() != res.Name() {
+						continue
+					}
+					unitRev, err := resourceRevision(app.Name(), res.Name(), unitRes.Revision())
+					if err != nil {
+						return nil, errors.Annotatef(err, "unit %s resource %s", unit.Name(), res.Name())
+					}
+					unitRevs[unit.Name()] = unitRev
+				}
+			}
+			out = append(out, coremigration.SerializedModelResource{
+				ApplicationRevision: appRev,
+				UnitRevisions:       unitRevs,
+			})
+		}
+	}
+	return out, nil
+}
+
+func resourceRevision(app, name string, rev description.ResourceRevision) (resource.Resource, error) {
+	var empty resource.Resource
+	type_, err := charmresource.ParseType(rev.Type())
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin())
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+	var fp charmresource.Fingerprint
+	if rev.FingerprintHex() != "" {
+		if fp, err = charmresource.ParseFingerprint(rev.FingerprintHex()); err != nil {
+			return empty, errors.Annotate(err, "invalid fingerprint")
+		}
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        type_,
+				Path:        rev.Path(),
+				Description: rev.Description(),
+			},
+			Origin:      origin,
+			Revision:    rev.Revision(),
+			Size:        rev.Size(),
+			Fingerprint: fp,
+		},
+		ApplicationID: app,
+		Username:      rev.Username(),
+		Timestamp:     rev.Timestamp(),
+	}, nil
+}
+
+// removeMachineInstances removes the instance details of every machine
+// in the serialized model, so that new instances are provisioned for
+// them when the model is imported.
+func removeMachineInstances(bytes []byte) ([]byte, error) {
+	var desc map[string]interface{}
+	if err := yaml.Unmarshal(bytes, &desc); err != nil {
+		return nil, errors.Trace(err)
+	}
+	machines, _ := desc["machines"].(map[interface{}]interface{})
+	removeInstances(machines["machines"])
+	out, err := yaml.Marshal(desc)
+	return out, errors.Trace(err)
+}
+
+func removeInstances(machines interface{}) {
+	list, _ := machines.([]interface{})
+	for _, item := range list {
+		machine, ok := item.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		delete(machine, "instance")
+		removeInstances(machine["containers"])
+	}
+}
+
+// modelBackupWriter writes a model backup archive.
+type modelBackupWriter struct {
+	gzw *gzip.Writer
+	tw  *tar.Writer
+}
+
+func newModelBackupWriter(w io.Writer) *modelBackupWriter {
+	gzw := gzip.NewWriter(w)
+	return &modelBackupWriter{
+		gzw: gzw,
+		tw:  tar.NewWriter(gzw),
+	}
+}
+
+// AddBytes adds a file with the given content to the archive.
+func (w *modelBackupWriter) AddBytes(name string, data []byte) error {
+	if err := w.writeHeader(name, int64(len(data))); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := w.tw.Write(data)
+	return errors.Trace(err)
+}
+
+// AddReader adds a file with the content read from r to the archive.
+// The content is spooled to a temporary file first, as its size has
+// to be known before it is written.
+func (w *modelBackupWriter) AddReader(name string, r io.Reader) error {
+	tempFile, err := ioutil.TempFile("", "juju-model-backup")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		tempFile.Close()
+		os.Remove(tempFile.Name())
+	}()
+	size, err := io.Copy(tempFile, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := tempFile.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.writeHeader(name, size); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(w.tw, tempFile)
+	return errors.Trace(err)
+}
+
+func (w *modelBackupWriter) writeHeader(name string, size int64) error {
+	return w.tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Mode:     0600,
+		Size:     size,
+		Typeflag: tar.TypeReg,
+	})
+}
+
+// Close finishes writing the archive.
+func (w *modelBackupWriter) Close() error {
+	if err := w.tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.gzw.Close())
+}
+
+// extractModelBackup unpacks the model backup archive read from r
+// into dir.
+func extractModelBackup(r io.Reader, dir string) error {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Annotate(err, "reading model backup")
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Annotate(err, "reading model backup")
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return errors.NotValidf("model backup file name %q", hdr.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return errors.Trace(err)
+		}
+		if err := extractFile(target, tr); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+func extractFile(target string, r io.Reader) error {
+	f, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r)
+	return errors.Trace(err)
+}
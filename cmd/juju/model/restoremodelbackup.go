@@ -0,0 +1,222 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/resource"
+)
+
+// NewRestoreModelBackupCommand returns a fully constructed
+// restore-model-backup command.
+func NewRestoreModelBackupCommand() cmd.Command {
+	cmd := &restoreModelBackupCommand{}
+	cmd.newAPIFunc = cmd.getAPI
+	return modelcmd.WrapController(cmd)
+}
+
+type restoreModelBackupCommand struct {
+	modelcmd.ControllerCommandBase
+	newAPIFunc  func() (RestoreModelBackupAPI, error)
+	filename    string
+	reprovision bool
+}
+
+const restoreModelBackupHelpDoc = `
+Restores a model from a backup written by create-model-backup. The
+model is recreated with its original name, owner and UUID, so the
+controller must not already have a model with the same UUID. The
+backup may be restored into the controller it was taken from, once the
+original model has been destroyed, or into another controller.
+
+By default the restored model expects the machines recorded in the
+backup to still exist. If they have gone, use --reprovision to forget
+their instances, so that new ones are provisioned for each machine.
+
+Examples:
+
+    juju restore-model-backup juju-model-backup-admin-mymodel-20190101-000000.tar.gz
+    juju restore-model-backup -c other-controller --reprovision mymodel.tar.gz
+
+See also:
+    create-model-backup
+`
+
+// Info implements Command.
+func (c *restoreModelBackupCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "restore-model-backup",
+		Args:    "<filename>",
+		Purpose: "Restores a model from a model backup.",
+		Doc:     restoreModelBackupHelpDoc,
+	}
+}
+
+// SetFlags implements Command.
+func (c *restoreModelBackupCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.reprovision, "reprovision", false, "Provision new instances for the model's machines")
+}
+
+// Init implements Command.
+func (c *restoreModelBackupCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no backup filename specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// RestoreModelBackupAPI specifies the API methods needed to restore
+// a model backup.
+type RestoreModelBackupAPI interface {
+	Close() error
+	Import([]byte) error
+	Abort(modelUUID string) error
+	Activate(modelUUID string) error
+	UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error)
+	UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error
+	SetUnitResource(modelUUID, unit string, res resource.Resource) error
+}
+
+func (c *restoreModelBackupCommand) getAPI() (RestoreModelBackupAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return struct {
+		*migrationtarget.Client
+		io.Closer
+	}{migrationtarget.NewClient(root), root}, nil
+}
+
+// Run implements Command.
+func (c *restoreModelBackupCommand) Run(ctx *cmd.Context) error {
+	f, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+
+	dir, err := ioutil.TempDir("", "juju-model-backup")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := extractModelBackup(f, dir); err != nil {
+		return errors.Trace(err)
+	}
+
+	bytes, err := ioutil.ReadFile(filepath.Join(dir, modelBackupDescription))
+	if os.IsNotExist(err) {
+		return errors.Errorf("%q is not a model backup", c.filename)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if c.reprovision {
+		if bytes, err = removeMachineInstances(bytes); err != nil {
+			return errors.Annotate(err, "removing machine instances")
+		}
+	}
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return errors.Annotate(err, "reading model description")
+	}
+	modelUUID := model.Tag().Id()
+	modelName, _ := model.Config()["name"].(string)
+
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Import(bytes); err != nil {
+		return errors.Annotate(err, "importing model")
+	}
+	if err := uploadModelBackupBinaries(client, dir, modelUUID, model); err != nil {
+		if abortErr := client.Abort(modelUUID); abortErr != nil {
+			logger.Errorf("aborting model restore: %v", abortErr)
+		}
+		return errors.Trace(err)
+	}
+	if err := client.Activate(modelUUID); err != nil {
+		return errors.Annotate(err, "activating model")
+	}
+
+	ctx.Infof("restored model %q (%s)", modelName, modelUUID)
+	return nil
+}
+
+func uploadModelBackupBinaries(client RestoreModelBackupAPI, dir, modelUUID string, model description.Model) error {
+	// Charms are uploaded in order so that each gets the revision it
+	// had in the original model.
+	for _, curl := range modelCharms(model) {
+		logger.Debugf("restoring charm %s", curl)
+		parsed, err := charm.ParseURL(curl)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		var uploaded *charm.URL
+		err = uploadFile(filepath.Join(dir, charmBackupPath(curl)), func(r io.ReadSeeker) (err error) {
+			uploaded, err = client.UploadCharm(modelUUID, parsed, r)
+			return err
+		})
+		if err != nil {
+			return errors.Annotatef(err, "restoring charm %s", curl)
+		}
+		if uploaded.String() != curl {
+			return errors.Errorf("charm %s unexpectedly restored as %s", curl, uploaded)
+		}
+	}
+
+	resources, err := modelResources(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, res := range resources {
+		rev := res.ApplicationRevision
+		if !rev.IsPlaceholder() {
+			logger.Debugf("restoring resource %s/%s", rev.ApplicationID, rev.Name)
+			path := filepath.Join(dir, resourceBackupPath(rev.ApplicationID, rev.Name))
+			err := uploadFile(path, func(r io.ReadSeeker) error {
+				return client.UploadResource(modelUUID, rev, r)
+			})
+			if err != nil {
+				return errors.Annotatef(err, "restoring resource %s/%s", rev.ApplicationID, rev.Name)
+			}
+		}
+		for unitName, unitRev := range res.UnitRevisions {
+			if err := client.SetUnitResource(modelUUID, unitName, unitRev); err != nil {
+				return errors.Annotatef(err, "restoring resource %s for unit %s", rev.Name, unitName)
+			}
+		}
+	}
+	return nil
+}
+
+// uploadFile calls upload with the content of the named file
+// extracted from a model backup.
+func uploadFile(path string, upload func(io.ReadSeeker) error) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("%s in model backup", filepath.Base(path))
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	return upload(f)
+}
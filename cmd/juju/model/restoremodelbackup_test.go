@@ -0,0 +1,229 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/testing"
+)
+
+type RestoreModelBackupCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake     *fakeRestoreModelBackupClient
+	store    *jujuclient.MemStore
+	bytes    []byte
+	filename string
+}
+
+var _ = gc.Suite(&RestoreModelBackupCommandSuite{})
+
+func (s *RestoreModelBackupCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeRestoreModelBackupClient{
+		Stub:    &jujutesting.Stub{},
+		uploads: make(map[string]string),
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	_, s.bytes = modelBackupTestModel(c)
+	s.filename = filepath.Join(c.MkDir(), "backup.tar.gz")
+	writeModelBackup(c, s.filename, map[string]string{
+		"model.yaml":                         string(s.bytes),
+		"charms/cs%3Aquantal%2Fubuntu-1.zip": "charm " + modelBackupTestCharm,
+		"resources/ubuntu/blob":              modelBackupTestResource,
+	})
+}
+
+func writeModelBackup(c *gc.C, filename string, files map[string]string) {
+	f, err := os.Create(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0600,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = tw.Write([]byte(content))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(tw.Close(), jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+}
+
+func (s *RestoreModelBackupCommandSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := model.NewRestoreModelBackupCommandForTest(s.fake, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *RestoreModelBackupCommandSuite) TestInitNoFilename(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "no backup filename specified")
+}
+
+func (s *RestoreModelBackupCommandSuite) TestInitTooManyArgs(c *gc.C) {
+	_, err := s.run(c, "one", "two")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+}
+
+func (s *RestoreModelBackupCommandSuite) TestRestore(c *gc.C) {
+	ctx, err := s.run(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals,
+		`restored model "mymodel" (`+testing.ModelTag.Id()+")\n")
+
+	uuid := testing.ModelTag.Id()
+	s.fake.CheckCallNames(c, "Import", "UploadCharm", "UploadResource", "SetUnitResource", "Activate", "Close")
+	s.fake.CheckCall(c, 0, "Import", string(s.bytes))
+	s.fake.CheckCall(c, 1, "UploadCharm", uuid, modelBackupTestCharm)
+	s.fake.CheckCall(c, 2, "UploadResource", uuid, "ubuntu/blob")
+	s.fake.CheckCall(c, 3, "SetUnitResource", uuid, "ubuntu/0", "ubuntu/blob")
+	s.fake.CheckCall(c, 4, "Activate", uuid)
+	c.Assert(s.fake.uploads, jc.DeepEquals, map[string]string{
+		modelBackupTestCharm: "charm " + modelBackupTestCharm,
+		"ubuntu/blob":        modelBackupTestResource,
+	})
+}
+
+func (s *RestoreModelBackupCommandSuite) TestRestoreReprovision(c *gc.C) {
+	_, err := s.run(c, "--reprovision", s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := description.Deserialize([]byte(s.fake.Calls()[0].Args[0].(string)))
+	c.Assert(err, jc.ErrorIsNil)
+	machines := imported.Machines()
+	c.Assert(machines, gc.HasLen, 1)
+	c.Check(machines[0].Instance(), gc.IsNil)
+	containers := machines[0].Containers()
+	c.Assert(containers, gc.HasLen, 1)
+	c.Check(containers[0].Instance(), gc.IsNil)
+}
+
+func (s *RestoreModelBackupCommandSuite) TestRestoreKeepsInstances(c *gc.C) {
+	_, err := s.run(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	imported, err := description.Deserialize([]byte(s.fake.Calls()[0].Args[0].(string)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.Machines()[0].Instance().InstanceId(), gc.Equals, "inst-0")
+}
+
+func (s *RestoreModelBackupCommandSuite) TestRestoreUploadErrorAborts(c *gc.C) {
+	s.fake.SetErrors(nil, errors.New("boom"))
+	_, err := s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "restoring charm cs:quantal/ubuntu-1: boom")
+	s.fake.CheckCallNames(c, "Import", "UploadCharm", "Abort", "Close")
+	s.fake.CheckCall(c, 2, "Abort", testing.ModelTag.Id())
+}
+
+func (s *RestoreModelBackupCommandSuite) TestRestoreMissingResource(c *gc.C) {
+	writeModelBackup(c, s.filename, map[string]string{
+		"model.yaml":                         string(s.bytes),
+		"charms/cs%3Aquantal%2Fubuntu-1.zip": "charm " + modelBackupTestCharm,
+	})
+	_, err := s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "restoring resource ubuntu/blob: blob in model backup not found")
+	s.fake.CheckCallNames(c, "Import", "UploadCharm", "Abort", "Close")
+}
+
+func (s *RestoreModelBackupCommandSuite) TestRestoreNotModelBackup(c *gc.C) {
+	writeModelBackup(c, s.filename, map[string]string{
+		"something": "else",
+	})
+	_, err := s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, `".*backup.tar.gz" is not a model backup`)
+	s.fake.CheckNoCalls(c)
+}
+
+func (s *RestoreModelBackupCommandSuite) TestRestoreBadPath(c *gc.C) {
+	writeModelBackup(c, s.filename, map[string]string{
+		"../model.yaml": string(s.bytes),
+	})
+	_, err := s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, `model backup file name "../model.yaml" not valid`)
+	s.fake.CheckNoCalls(c)
+}
+
+type fakeRestoreModelBackupClient struct {
+	*jujutesting.Stub
+	uploads map[string]string
+}
+
+func (f *fakeRestoreModelBackupClient) Import(bytes []byte) error {
+	f.MethodCall(f, "Import", string(bytes))
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelBackupClient) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelBackupClient) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelBackupClient) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	f.MethodCall(f, "UploadCharm", modelUUID, curl.String())
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	f.uploads[curl.String()] = string(data)
+	return curl, nil
+}
+
+func (f *fakeRestoreModelBackupClient) UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error {
+	f.MethodCall(f, "UploadResource", modelUUID, res.ApplicationID+"/"+res.Name)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	f.uploads[res.ApplicationID+"/"+res.Name] = string(data)
+	return nil
+}
+
+func (f *fakeRestoreModelBackupClient) SetUnitResource(modelUUID, unit string, res resource.Resource) error {
+	f.MethodCall(f, "SetUnitResource", modelUUID, unit, res.ApplicationID+"/"+res.Name)
+	return f.NextErr()
+}
+
+func (f *fakeRestoreModelBackupClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
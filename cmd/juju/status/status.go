@@ -48,6 +48,10 @@ type statusCommand struct {
 
 	// relationsFlagProvidedF indicates whether 'relations' option was provided by the user.
 	relationsFlagProvidedF func() bool
+
+	// watch indicates that the status should be continuously
+	// updated as the model changes.
+	watch bool
 }
 
 var usageSummary = `
@@ -89,11 +93,19 @@ In tabular format, 'Relations' section is not displayed by default.
 Use --relations option to see this section. This option is ignored in all other 
 formats.
 
+With --watch, the status is displayed and then kept up to date as the model
+changes, until interrupted. Changes are received from the controller as they
+happen, rather than by polling. When filter patterns are given, the filtered
+status is requested again from the controller on each change, so that the
+patterns match exactly as they do without --watch. This option may be used
+with the tabular, oneline and summary formats.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
     juju show-status --relations
+    juju show-status --watch mysql
 
 See also:
     machines
@@ -118,6 +130,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.color, "color", false, "Force use of ANSI color codes")
 
 	f.BoolVar(&c.relations, "relations", false, "Show 'relations' section")
+	f.BoolVar(&c.watch, "watch", false, "Keep the status up to date as the model changes")
 
 	c.relationsFlagProvidedF = func() bool {
 		provided := false
@@ -155,6 +168,19 @@ func (c *statusCommand) Init(args []string) error {
 			}
 		}
 	}
+	if c.watch {
+		format := c.out.Name()
+		supported := false
+		for _, name := range watchFormats {
+			if name == format {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return errors.Errorf("--watch is not supported with format %q", format)
+		}
+	}
 	return nil
 }
 
@@ -163,6 +189,10 @@ var newAPIClientForStatus = func(c *statusCommand) (statusAPI, error) {
 }
 
 func (c *statusCommand) Run(ctx *cmd.Context) error {
+	if c.watch {
+		return c.runWatchCommand(ctx)
+	}
+
 	apiclient, err := newAPIClientForStatus(c)
 	if err != nil {
 		return errors.Trace(err)
@@ -186,15 +216,7 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	showRelations := true
-	if c.out.Name() != "tabular" {
-		if c.relationsFlagProvidedF() {
-			// For non-tabular formats this is redundant and needs to be mentioned to the user.
-			ctx.Infof("provided --relations option is ignored")
-		}
-	} else {
-		showRelations = c.relations
-	}
+	showRelations := c.showRelations(ctx)
 	formatter := newStatusFormatter(status, controllerName, c.isoTime, showRelations)
 	formatted, err := formatter.format()
 	if err != nil {
@@ -226,6 +248,31 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *statusCommand) runWatchCommand(ctx *cmd.Context) error {
+	apiclient, err := newWatchAPIForStatus(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiclient.Close()
+
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.runWatch(ctx, apiclient, controllerName, c.showRelations(ctx))
+}
+
+func (c *statusCommand) showRelations(ctx *cmd.Context) bool {
+	if c.out.Name() != "tabular" {
+		if c.relationsFlagProvidedF() {
+			// For non-tabular formats this is redundant and needs to be mentioned to the user.
+			ctx.Infof("provided --relations option is ignored")
+		}
+		return true
+	}
+	return c.relations
+}
+
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
 	return FormatTabular(writer, c.color, value)
 }
@@ -0,0 +1,606 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"bytes"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// allWatcher is the subset of the api.AllWatcher methods used by
+// status --watch.
+type allWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// watchAPI is the API used by status --watch: a single Status call
+// seeds the display, and the AllWatcher keeps it up to date.
+type watchAPI interface {
+	statusAPI
+	WatchAll() (allWatcher, error)
+}
+
+// watchClient adapts an *api.Client to the watchAPI interface.
+type watchClient struct {
+	*api.Client
+}
+
+// WatchAll is part of the watchAPI interface.
+func (c watchClient) WatchAll() (allWatcher, error) {
+	return c.Client.WatchAll()
+}
+
+var newWatchAPIForStatus = func(c *statusCommand) (watchAPI, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, err
+	}
+	return watchClient{client}, nil
+}
+
+// deltaStatus maintains an unfiltered params.FullStatus, updating it
+// with the deltas reported by the AllWatcher.
+type deltaStatus struct {
+	status *params.FullStatus
+
+	// subordinates records which applications are subordinate, since
+	// that isn't recorded directly in the full status.
+	subordinates map[string]bool
+
+	// needsRefresh is set when a delta can't be placed in the status
+	// tree (e.g. a subordinate unit whose principal isn't known); the
+	// caller should then reseed with a fresh Status call.
+	needsRefresh bool
+}
+
+func newDeltaStatus(full *params.FullStatus) *deltaStatus {
+	s := &deltaStatus{
+		status:       full,
+		subordinates: make(map[string]bool),
+	}
+	if s.status.Machines == nil {
+		s.status.Machines = make(map[string]params.MachineStatus)
+	}
+	if s.status.Applications == nil {
+		s.status.Applications = make(map[string]params.ApplicationStatus)
+	}
+	if s.status.RemoteApplications == nil {
+		s.status.RemoteApplications = make(map[string]params.RemoteApplicationStatus)
+	}
+	if s.status.Offers == nil {
+		s.status.Offers = make(map[string]params.ApplicationOfferStatus)
+	}
+	for name, app := range s.status.Applications {
+		if len(app.SubordinateTo) > 0 {
+			s.subordinates[name] = true
+		}
+	}
+	return s
+}
+
+// apply updates the status with the supplied deltas.
+func (s *deltaStatus) apply(deltas []multiwatcher.Delta) {
+	relationsChanged := false
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			if !delta.Removed {
+				s.updateModel(info)
+			}
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				removeMachine(s.status.Machines, info.Id)
+			} else {
+				s.updateMachine(info)
+			}
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(s.status.Applications, info.Name)
+				delete(s.subordinates, info.Name)
+			} else {
+				s.updateApplication(info)
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				s.removeUnit(info.Application, info.Name)
+			} else {
+				s.updateUnit(info)
+			}
+		case *multiwatcher.RelationInfo:
+			if delta.Removed {
+				s.removeRelation(info.Id)
+			} else {
+				s.updateRelation(info)
+			}
+			relationsChanged = true
+		case *multiwatcher.RemoteApplicationInfo:
+			if delta.Removed {
+				delete(s.status.RemoteApplications, info.Name)
+			} else {
+				s.updateRemoteApplication(info)
+			}
+		case *multiwatcher.ApplicationOfferInfo:
+			if delta.Removed {
+				delete(s.status.Offers, info.OfferName)
+			} else {
+				s.updateOffer(info)
+			}
+		}
+	}
+	if relationsChanged {
+		s.updateApplicationRelations()
+	}
+}
+
+func (s *deltaStatus) updateModel(info *multiwatcher.ModelInfo) {
+	model := &s.status.Model
+	model.Name = info.Name
+	model.ModelStatus = mergeStatus(model.ModelStatus, info.Status, info.Life)
+	model.SLA = info.SLA.Level
+}
+
+func (s *deltaStatus) updateMachine(info *multiwatcher.MachineInfo) {
+	m, found := getMachine(s.status.Machines, info.Id)
+	if !found {
+		if parentId := parentMachineId(info.Id); parentId != "" {
+			if _, ok := getMachine(s.status.Machines, parentId); !ok {
+				s.needsRefresh = true
+				return
+			}
+		}
+		m = params.MachineStatus{Id: info.Id}
+	}
+	m.AgentStatus = mergeStatus(m.AgentStatus, info.AgentStatus, info.Life)
+	m.InstanceStatus = mergeStatus(m.InstanceStatus, info.InstanceStatus, "")
+	m.InstanceId = instance.Id(info.InstanceId)
+	m.Series = info.Series
+	m.Jobs = info.Jobs
+	m.HasVote = info.HasVote
+	m.WantsVote = info.WantsVote
+	if info.HardwareCharacteristics != nil {
+		m.Hardware = info.HardwareCharacteristics.String()
+	}
+	if len(info.Addresses) > 0 {
+		m.IPAddresses = make([]string, len(info.Addresses))
+		m.DNSName = ""
+		for i, addr := range info.Addresses {
+			m.IPAddresses[i] = addr.Value
+			if m.DNSName == "" && network.Scope(addr.Scope) == network.ScopePublic {
+				m.DNSName = addr.Value
+			}
+		}
+		if m.DNSName == "" {
+			m.DNSName = info.Addresses[0].Value
+		}
+	}
+	setMachine(s.status.Machines, info.Id, m)
+}
+
+func (s *deltaStatus) updateApplication(info *multiwatcher.ApplicationInfo) {
+	app, found := s.status.Applications[info.Name]
+	if !found {
+		app.Units = make(map[string]params.UnitStatus)
+	}
+	app.Charm = info.CharmURL
+	app.Exposed = info.Exposed
+	app.Life = lifeString(info.Life)
+	app.Status = mergeStatus(app.Status, info.Status, "")
+	app.WorkloadVersion = info.WorkloadVersion
+	if info.Subordinate {
+		s.subordinates[info.Name] = true
+	}
+	s.status.Applications[info.Name] = app
+	if !found {
+		s.updateApplicationRelations()
+	}
+}
+
+func (s *deltaStatus) updateUnit(info *multiwatcher.UnitInfo) {
+	app, found := s.status.Applications[info.Application]
+	if !found {
+		// Units are always reported after their applications, so
+		// this means we've missed something.
+		s.needsRefresh = true
+		return
+	}
+	if !info.Subordinate {
+		unit := app.Units[info.Name]
+		updateUnitStatus(&unit, info, app.Charm)
+		unit.Machine = info.MachineId
+		if app.Units == nil {
+			app.Units = make(map[string]params.UnitStatus)
+		}
+		app.Units[info.Name] = unit
+		s.status.Applications[info.Application] = app
+		return
+	}
+
+	// Subordinate units are recorded against their principal, which
+	// the AllWatcher doesn't tell us directly; find the unit it's
+	// already attached to, or a principal on the same machine that
+	// the subordinate application is related to.
+	principalApp, principalName, found := s.findPrincipal(info)
+	if !found {
+		s.needsRefresh = true
+		return
+	}
+	papp := s.status.Applications[principalApp]
+	principal := papp.Units[principalName]
+	if principal.Subordinates == nil {
+		principal.Subordinates = make(map[string]params.UnitStatus)
+	}
+	unit := principal.Subordinates[info.Name]
+	updateUnitStatus(&unit, info, app.Charm)
+	principal.Subordinates[info.Name] = unit
+	papp.Units[principalName] = principal
+	s.status.Applications[principalApp] = papp
+}
+
+func (s *deltaStatus) findPrincipal(info *multiwatcher.UnitInfo) (string, string, bool) {
+	for appName, app := range s.status.Applications {
+		for unitName, unit := range app.Units {
+			if _, ok := unit.Subordinates[info.Name]; ok {
+				return appName, unitName, true
+			}
+		}
+	}
+	if info.MachineId == "" {
+		return "", "", false
+	}
+	subordinateTo := s.status.Applications[info.Application].SubordinateTo
+	for _, appName := range subordinateTo {
+		for unitName, unit := range s.status.Applications[appName].Units {
+			if unit.Machine == info.MachineId {
+				return appName, unitName, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func updateUnitStatus(unit *params.UnitStatus, info *multiwatcher.UnitInfo, appCharm string) {
+	unit.AgentStatus = mergeStatus(unit.AgentStatus, info.AgentStatus, "")
+	unit.WorkloadStatus = mergeStatus(unit.WorkloadStatus, info.WorkloadStatus, "")
+	unit.PublicAddress = info.PublicAddress
+	unit.Charm = ""
+	if info.CharmURL != appCharm {
+		unit.Charm = info.CharmURL
+	}
+	unit.OpenedPorts = nil
+	for _, pr := range info.PortRanges {
+		unit.OpenedPorts = append(unit.OpenedPorts, network.PortRange{
+			FromPort: pr.FromPort,
+			ToPort:   pr.ToPort,
+			Protocol: pr.Protocol,
+		}.String())
+	}
+}
+
+func (s *deltaStatus) removeUnit(appName, unitName string) {
+	if app, ok := s.status.Applications[appName]; ok {
+		if _, ok := app.Units[unitName]; ok {
+			delete(app.Units, unitName)
+			return
+		}
+	}
+	for _, app := range s.status.Applications {
+		for principalName, principal := range app.Units {
+			if _, ok := principal.Subordinates[unitName]; ok {
+				delete(principal.Subordinates, unitName)
+				app.Units[principalName] = principal
+				return
+			}
+		}
+	}
+}
+
+func (s *deltaStatus) updateRelation(info *multiwatcher.RelationInfo) {
+	rel := params.RelationStatus{
+		Id:  info.Id,
+		Key: info.Key,
+	}
+	for i, ep := range info.Endpoints {
+		if i == 0 {
+			rel.Interface = ep.Relation.Interface
+			rel.Scope = ep.Relation.Scope
+		}
+		rel.Endpoints = append(rel.Endpoints, params.EndpointStatus{
+			ApplicationName: ep.ApplicationName,
+			Name:            ep.Relation.Name,
+			Role:            ep.Relation.Role,
+			Subordinate:     s.subordinates[ep.ApplicationName],
+		})
+	}
+	for i, existing := range s.status.Relations {
+		if existing.Id == info.Id {
+			// The relation status isn't reported by the AllWatcher.
+			rel.Status = existing.Status
+			s.status.Relations[i] = rel
+			return
+		}
+	}
+	s.status.Relations = append(s.status.Relations, rel)
+}
+
+func (s *deltaStatus) removeRelation(id int) {
+	for i, existing := range s.status.Relations {
+		if existing.Id == id {
+			s.status.Relations = append(s.status.Relations[:i], s.status.Relations[i+1:]...)
+			return
+		}
+	}
+}
+
+// updateApplicationRelations recomputes the relations recorded
+// against each application from the model's relations.
+func (s *deltaStatus) updateApplicationRelations() {
+	related := make(map[string]map[string][]string)
+	subordinateTo := make(map[string][]string)
+	for _, rel := range s.status.Relations {
+		for _, ep := range rel.Endpoints {
+			if related[ep.ApplicationName] == nil {
+				related[ep.ApplicationName] = make(map[string][]string)
+			}
+			var others []string
+			for _, other := range rel.Endpoints {
+				if other.ApplicationName != ep.ApplicationName || len(rel.Endpoints) == 1 {
+					others = append(others, other.ApplicationName)
+				}
+			}
+			names := append(related[ep.ApplicationName][ep.Name], others...)
+			sort.Strings(names)
+			related[ep.ApplicationName][ep.Name] = names
+			if rel.Scope == "container" && s.subordinates[ep.ApplicationName] {
+				subordinateTo[ep.ApplicationName] = appendUnique(subordinateTo[ep.ApplicationName], others...)
+			}
+		}
+	}
+	for name, app := range s.status.Applications {
+		app.Relations = related[name]
+		if s.subordinates[name] {
+			sort.Strings(subordinateTo[name])
+			app.SubordinateTo = subordinateTo[name]
+		}
+		s.status.Applications[name] = app
+	}
+	for name, app := range s.status.RemoteApplications {
+		app.Relations = related[name]
+		s.status.RemoteApplications[name] = app
+	}
+}
+
+func (s *deltaStatus) updateRemoteApplication(info *multiwatcher.RemoteApplicationInfo) {
+	app := s.status.RemoteApplications[info.Name]
+	app.OfferURL = info.OfferURL
+	app.Life = lifeString(info.Life)
+	app.Status = mergeStatus(app.Status, info.Status, "")
+	s.status.RemoteApplications[info.Name] = app
+}
+
+func (s *deltaStatus) updateOffer(info *multiwatcher.ApplicationOfferInfo) {
+	offer := s.status.Offers[info.OfferName]
+	offer.OfferName = info.OfferName
+	offer.ApplicationName = info.ApplicationName
+	offer.ActiveConnectedCount = info.ActiveConnectedCount
+	offer.TotalConnectedCount = info.TotalConnectedCount
+	s.status.Offers[info.OfferName] = offer
+}
+
+// mergeStatus updates the supplied detailed status with the values
+// reported by the AllWatcher, keeping anything the AllWatcher doesn't
+// know about.
+func mergeStatus(existing params.DetailedStatus, info multiwatcher.StatusInfo, life multiwatcher.Life) params.DetailedStatus {
+	existing.Status = string(info.Current)
+	existing.Info = info.Message
+	existing.Data = info.Data
+	existing.Since = info.Since
+	existing.Version = info.Version
+	existing.Err = info.Err
+	if life != "" {
+		existing.Life = lifeString(life)
+	}
+	return existing
+}
+
+// lifeString converts the life to the form used in the full status,
+// where alive is the usual state and so omitted.
+func lifeString(life multiwatcher.Life) string {
+	if life == "alive" {
+		return ""
+	}
+	return string(life)
+}
+
+func appendUnique(values []string, more ...string) []string {
+	for _, v := range more {
+		found := false
+		for _, existing := range values {
+			if existing == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parentMachineId returns the id of the machine hosting the supplied
+// container, or "" if the id isn't a container.
+func parentMachineId(id string) string {
+	parts := strings.Split(id, "/")
+	if len(parts) < 3 {
+		return ""
+	}
+	return strings.Join(parts[:len(parts)-2], "/")
+}
+
+func getMachine(machines map[string]params.MachineStatus, id string) (params.MachineStatus, bool) {
+	parentId := parentMachineId(id)
+	if parentId == "" {
+		m, ok := machines[id]
+		return m, ok
+	}
+	parent, ok := getMachine(machines, parentId)
+	if !ok {
+		return params.MachineStatus{}, false
+	}
+	m, ok := parent.Containers[id]
+	return m, ok
+}
+
+func setMachine(machines map[string]params.MachineStatus, id string, m params.MachineStatus) {
+	parentId := parentMachineId(id)
+	if parentId == "" {
+		machines[id] = m
+		return
+	}
+	parent, ok := getMachine(machines, parentId)
+	if !ok {
+		return
+	}
+	if parent.Containers == nil {
+		parent.Containers = make(map[string]params.MachineStatus)
+	}
+	parent.Containers[id] = m
+	setMachine(machines, parentId, parent)
+}
+
+func removeMachine(machines map[string]params.MachineStatus, id string) {
+	parentId := parentMachineId(id)
+	if parentId == "" {
+		delete(machines, id)
+		return
+	}
+	parent, ok := getMachine(machines, parentId)
+	if !ok {
+		return
+	}
+	delete(parent.Containers, id)
+	setMachine(machines, parentId, parent)
+}
+
+func unitApplication(unitName string) string {
+	if i := strings.Index(unitName, "/"); i >= 0 {
+		return unitName[:i]
+	}
+	return unitName
+}
+
+// clearScreen is written before each update when watching status in
+// one of the human readable formats.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// watchFormats holds the output formats that may be used with --watch.
+var watchFormats = []string{"tabular", "short", "line", "oneline", "summary"}
+
+// runWatch displays the model status, updating it whenever the model
+// changes. Without patterns, the status is kept up to date by applying
+// the AllWatcher's deltas to it. Patterns may match on things the
+// deltas don't carry (such as subnets), so with patterns the deltas
+// only trigger a fresh filtered Status call.
+func (c *statusCommand) runWatch(ctx *cmd.Context, client watchAPI, controllerName string, showRelations bool) error {
+	full, err := client.Status(c.patterns)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer watcher.Stop()
+
+	var lastOutput []byte
+	render := func(status *params.FullStatus) error {
+		formatted, err := newStatusFormatter(status, controllerName, c.isoTime, showRelations).format()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		var buf bytes.Buffer
+		if err := c.out.Write(&cmd.Context{Stdout: &buf, Stderr: ctx.Stderr}, formatted); err != nil {
+			return errors.Trace(err)
+		}
+		if bytes.Equal(buf.Bytes(), lastOutput) {
+			return nil
+		}
+		if lastOutput != nil {
+			if _, err := ctx.Stdout.Write([]byte(clearScreen)); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		lastOutput = buf.Bytes()
+		_, err = ctx.Stdout.Write(lastOutput)
+		return errors.Trace(err)
+	}
+	if err := render(full); err != nil {
+		return errors.Trace(err)
+	}
+	current := newDeltaStatus(full)
+
+	done := make(chan struct{})
+	defer close(done)
+	deltasCh := make(chan []multiwatcher.Delta)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case deltasCh <- deltas:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+	for {
+		select {
+		case <-interrupted:
+			return nil
+		case err := <-errCh:
+			return errors.Annotate(err, "watching model")
+		case deltas := <-deltasCh:
+			if len(c.patterns) > 0 {
+				// The patterns can only be applied by the
+				// controller, so ask it for the filtered status.
+				current.needsRefresh = true
+			} else {
+				current.apply(deltas)
+				if current.needsRefresh {
+					logger.Debugf("status out of sync with model, refreshing")
+				}
+			}
+			if current.needsRefresh {
+				full, err := client.Status(c.patterns)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				current = newDeltaStatus(full)
+			}
+			if err := render(current.status); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
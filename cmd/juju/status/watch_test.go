@@ -0,0 +1,281 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"strings"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	corestatus "github.com/juju/juju/core/status"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+type WatchSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WatchSuite{})
+
+func seedStatus() *params.FullStatus {
+	return &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Name:     "default",
+			CloudTag: "cloud-dummy",
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {Id: "0"},
+		},
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {
+				Charm: "cs:mysql-1",
+				Units: map[string]params.UnitStatus{
+					"mysql/0": {Machine: "0"},
+				},
+			},
+		},
+	}
+}
+
+func unitDelta(name, machine string, workload corestatus.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    unitApplication(name),
+		CharmURL:       "cs:" + unitApplication(name) + "-1",
+		MachineId:      machine,
+		PublicAddress:  "10.0.0.1",
+		AgentStatus:    multiwatcher.StatusInfo{Current: corestatus.Idle},
+		WorkloadStatus: multiwatcher.StatusInfo{Current: workload},
+		PortRanges: []multiwatcher.PortRange{
+			{FromPort: 3306, ToPort: 3306, Protocol: "tcp"},
+		},
+	}}
+}
+
+func (s *WatchSuite) TestApplyUnitChanges(c *gc.C) {
+	ds := newDeltaStatus(seedStatus())
+	ds.apply([]multiwatcher.Delta{
+		unitDelta("mysql/0", "0", corestatus.Active),
+		unitDelta("mysql/1", "0", corestatus.Waiting),
+	})
+	c.Assert(ds.needsRefresh, jc.IsFalse)
+
+	units := ds.status.Applications["mysql"].Units
+	c.Assert(units, gc.HasLen, 2)
+	c.Assert(units["mysql/0"].WorkloadStatus.Status, gc.Equals, "active")
+	c.Assert(units["mysql/0"].OpenedPorts, jc.DeepEquals, []string{"3306/tcp"})
+	c.Assert(units["mysql/0"].Charm, gc.Equals, "")
+	c.Assert(units["mysql/1"].WorkloadStatus.Status, gc.Equals, "waiting")
+
+	ds.apply([]multiwatcher.Delta{{
+		Removed: true,
+		Entity:  &multiwatcher.UnitInfo{Name: "mysql/1", Application: "mysql"},
+	}})
+	c.Assert(ds.status.Applications["mysql"].Units, gc.HasLen, 1)
+}
+
+func (s *WatchSuite) TestApplyUnknownApplicationNeedsRefresh(c *gc.C) {
+	ds := newDeltaStatus(seedStatus())
+	ds.apply([]multiwatcher.Delta{unitDelta("wordpress/0", "0", corestatus.Active)})
+	c.Assert(ds.needsRefresh, jc.IsTrue)
+}
+
+func (s *WatchSuite) TestApplyContainers(c *gc.C) {
+	ds := newDeltaStatus(seedStatus())
+	ds.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.MachineInfo{
+			Id:     "0/lxd/0",
+			Series: "bionic",
+			AgentStatus: multiwatcher.StatusInfo{
+				Current: corestatus.Started,
+			},
+			Addresses: []multiwatcher.Address{
+				{Value: "10.0.0.2", Scope: "local-cloud"},
+				{Value: "54.0.0.2", Scope: "public"},
+			},
+		},
+	}})
+	container := ds.status.Machines["0"].Containers["0/lxd/0"]
+	c.Assert(container.Series, gc.Equals, "bionic")
+	c.Assert(container.AgentStatus.Status, gc.Equals, "started")
+	c.Assert(container.DNSName, gc.Equals, "54.0.0.2")
+	c.Assert(container.IPAddresses, jc.DeepEquals, []string{"10.0.0.2", "54.0.0.2"})
+
+	ds.apply([]multiwatcher.Delta{{
+		Removed: true,
+		Entity:  &multiwatcher.MachineInfo{Id: "0/lxd/0"},
+	}})
+	c.Assert(ds.status.Machines["0"].Containers, gc.HasLen, 0)
+}
+
+func (s *WatchSuite) TestApplyRelationsAndSubordinates(c *gc.C) {
+	ds := newDeltaStatus(seedStatus())
+	ds.apply([]multiwatcher.Delta{{
+		Entity: &multiwatcher.ApplicationInfo{
+			Name:        "logging",
+			CharmURL:    "cs:logging-1",
+			Subordinate: true,
+		},
+	}, {
+		Entity: &multiwatcher.RelationInfo{
+			Id:  1,
+			Key: "logging:info mysql:juju-info",
+			Endpoints: []multiwatcher.Endpoint{{
+				ApplicationName: "logging",
+				Relation: multiwatcher.CharmRelation{
+					Name: "info", Role: "requirer", Interface: "juju-info", Scope: "container",
+				},
+			}, {
+				ApplicationName: "mysql",
+				Relation: multiwatcher.CharmRelation{
+					Name: "juju-info", Role: "provider", Interface: "juju-info", Scope: "container",
+				},
+			}},
+		},
+	}})
+	c.Assert(ds.status.Applications["logging"].SubordinateTo, jc.DeepEquals, []string{"mysql"})
+	c.Assert(ds.status.Applications["mysql"].Relations, jc.DeepEquals, map[string][]string{
+		"juju-info": {"logging"},
+	})
+
+	sub := unitDelta("logging/0", "0", corestatus.Active)
+	sub.Entity.(*multiwatcher.UnitInfo).Subordinate = true
+	ds.apply([]multiwatcher.Delta{sub})
+	c.Assert(ds.needsRefresh, jc.IsFalse)
+	subordinates := ds.status.Applications["mysql"].Units["mysql/0"].Subordinates
+	c.Assert(subordinates["logging/0"].WorkloadStatus.Status, gc.Equals, "active")
+}
+
+func (s *WatchSuite) TestInitRejectsStructuredFormats(c *gc.C) {
+	command := &statusCommand{relationsFlagProvidedF: func() bool { return false }}
+	err := cmdtesting.InitCommand(command, []string{"--watch", "--format", "yaml"})
+	c.Assert(err, gc.ErrorMatches, `--watch is not supported with format "yaml"`)
+}
+
+func (s *WatchSuite) TestRunWatch(c *gc.C) {
+	command := &statusCommand{relationsFlagProvidedF: func() bool { return false }}
+	err := cmdtesting.InitCommand(command, []string{"--watch", "--format", "oneline"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	watcher := &fakeAllWatcher{
+		deltas: [][]multiwatcher.Delta{
+			{unitDelta("mysql/0", "0", corestatus.Active)},
+			// No visible change, so no new output.
+			{unitDelta("mysql/0", "0", corestatus.Active)},
+		},
+		err: errors.New("watcher stopped"),
+	}
+	api := &fakeWatchAPI{status: seedStatus(), watcher: watcher}
+
+	ctx := cmdtesting.Context(c)
+	err = command.runWatch(ctx, api, "kontroll", false)
+	c.Assert(err, gc.ErrorMatches, "watching model: watcher stopped")
+	c.Assert(watcher.stopped, jc.IsTrue)
+	// The initial status is displayed, then replaced once by the
+	// update from the watcher.
+	output := cmdtesting.Stdout(ctx)
+	c.Assert(strings.Count(output, clearScreen), gc.Equals, 1)
+	updated := output[strings.Index(output, clearScreen):]
+	c.Assert(updated, jc.Contains, "- mysql/0: 10.0.0.1 (agent:idle, workload:active) 3306/tcp")
+}
+
+func (s *WatchSuite) TestRunWatchWithPatterns(c *gc.C) {
+	// Patterns may match on workload status or exposure, which
+	// can only be evaluated by the controller; so each change
+	// should request the filtered status again.
+	for i, pattern := range []string{"active", "exposed"} {
+		c.Logf("test %d: %s", i, pattern)
+		command := &statusCommand{relationsFlagProvidedF: func() bool { return false }}
+		err := cmdtesting.InitCommand(command, []string{"--watch", "--format", "oneline", pattern})
+		c.Assert(err, jc.ErrorIsNil)
+
+		watcher := &fakeAllWatcher{
+			deltas: [][]multiwatcher.Delta{
+				{unitDelta("wordpress/0", "1", corestatus.Active)},
+			},
+			err: errors.New("watcher stopped"),
+		}
+		matched := seedStatus()
+		matched.Applications["mysql"] = params.ApplicationStatus{
+			Charm:   "cs:mysql-1",
+			Exposed: true,
+			Units: map[string]params.UnitStatus{
+				"mysql/0": {
+					Machine:        "0",
+					PublicAddress:  "10.0.0.2",
+					WorkloadStatus: params.DetailedStatus{Status: "active"},
+				},
+			},
+		}
+		api := &fakeWatchAPI{
+			statuses: []*params.FullStatus{seedStatus(), matched},
+			watcher:  watcher,
+		}
+
+		ctx := cmdtesting.Context(c)
+		err = command.runWatch(ctx, api, "kontroll", false)
+		c.Assert(err, gc.ErrorMatches, "watching model: watcher stopped")
+		c.Assert(api.patterns, jc.DeepEquals, [][]string{{pattern}, {pattern}})
+		// The delta is for an unrelated unit, so it's only the
+		// refreshed filtered status that is shown.
+		output := cmdtesting.Stdout(ctx)
+		c.Assert(strings.Count(output, clearScreen), gc.Equals, 1)
+		updated := output[strings.Index(output, clearScreen):]
+		c.Assert(updated, jc.Contains, "- mysql/0: 10.0.0.2")
+		c.Assert(updated, gc.Not(jc.Contains), "wordpress")
+	}
+}
+
+type fakeWatchAPI struct {
+	status  *params.FullStatus
+	watcher *fakeAllWatcher
+
+	// statuses, if set, are returned in turn by Status
+	// in place of status.
+	statuses []*params.FullStatus
+	patterns [][]string
+}
+
+func (a *fakeWatchAPI) Status(patterns []string) (*params.FullStatus, error) {
+	a.patterns = append(a.patterns, patterns)
+	if len(a.statuses) > 0 {
+		status := a.statuses[0]
+		a.statuses = a.statuses[1:]
+		return status, nil
+	}
+	return a.status, nil
+}
+
+func (a *fakeWatchAPI) WatchAll() (allWatcher, error) {
+	return a.watcher, nil
+}
+
+func (a *fakeWatchAPI) Close() error {
+	return nil
+}
+
+type fakeAllWatcher struct {
+	deltas  [][]multiwatcher.Delta
+	err     error
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.deltas) == 0 {
+		return nil, w.err
+	}
+	next := w.deltas[0]
+	w.deltas = w.deltas[1:]
+	return next, nil
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
@@ -57,6 +58,12 @@ Storage constraints can be optionally omitted.
 Model default values will be used for all omitted constraint values.
 There is no need to comma-separate omitted constraints. 
 
+Block storage may be restored from a volume snapshot, as listed by
+"juju storage-snapshots", by specifying the snapshot's ID with
+--snapshot. Only a single storage directive may then be given. The
+pool and size default to those of the snapshot; the size may be
+larger, but not smaller, and the pool must be the same.
+
 Examples:
     # Add 3 ebs storage instances for "data" storage to unit u/0:
 
@@ -73,6 +80,11 @@ Examples:
       juju add-storage u/0 data=1 
     or
       juju add-storage u/0 data 
+
+    # Add 1 "data" storage instance to unit u/0, restored from
+    # the snapshot 0/1@2:
+
+      juju add-storage u/0 data --snapshot 0/1@2
 `
 	addCommandAgs = `<unit name> <charm storage name>[=<storage constraints>]`
 )
@@ -87,6 +99,16 @@ type addCommand struct {
 	// defined in charm storage metadata.
 	storageCons map[string]storage.Constraints
 	newAPIFunc  func() (StorageAddAPI, error)
+
+	// snapshot is the ID of the volume snapshot from which
+	// the storage should be restored, if any.
+	snapshot string
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.snapshot, "snapshot", "", "Restore the storage from the volume snapshot with this ID")
 }
 
 // Init implements Command.Init.
@@ -102,7 +124,13 @@ func (c *addCommand) Init(args []string) (err error) {
 	c.unitTag = names.NewUnitTag(u)
 
 	c.storageCons, err = storage.ParseConstraintsMap(args[1:], false)
-	return
+	if err != nil {
+		return err
+	}
+	if c.snapshot != "" && len(c.storageCons) > 1 {
+		return errors.New("--snapshot requires a single storage directive")
+	}
+	return nil
 }
 
 // Info implements Command.Info.
@@ -191,6 +219,7 @@ func (c *addCommand) createStorageAddParams() []params.StorageAddParams {
 				&cons.Size,
 				&cons.Count,
 			},
+			Snapshot: c.snapshot,
 		})
 	}
 
@@ -118,6 +118,29 @@ added storage foo/1 to tst/123
 	}
 }
 
+func (s *addSuite) TestAddFromSnapshot(c *gc.C) {
+	var added []params.StorageAddParams
+	addToUnit := s.mockAPI.addToUnitFunc
+	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
+		added = storages
+		return addToUnit(storages)
+	}
+	context, err := s.runAdd(c, "tst/123", "data", "--snapshot", "0/1@2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExpectedOutput(c, context, `
+added storage foo/0 to tst/123
+added storage foo/1 to tst/123
+`[1:])
+	c.Assert(added, gc.HasLen, 1)
+	c.Assert(added[0].Snapshot, gc.Equals, "0/1@2")
+}
+
+func (s *addSuite) TestAddFromSnapshotMultipleDirectives(c *gc.C) {
+	s.args = []string{"tst/123", "data", "logs", "--snapshot", "0/1@2"}
+	expectedErr := "--snapshot requires a single storage directive"
+	s.assertAddErrorOutput(c, expectedErr, visibleErrorMessage(expectedErr))
+}
+
 func (s *addSuite) TestAddOperationAborted(c *gc.C) {
 	s.args = []string{"tst/123", "data=676"}
 	s.mockAPI.addToUnitFunc = func(storages []params.StorageAddParams) ([]params.AddStorageResult, error) {
@@ -0,0 +1,73 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// PoolRemoveAPI defines the API methods that pool remove command uses.
+type PoolRemoveAPI interface {
+	Close() error
+	RemovePool(pname string) error
+}
+
+const poolRemoveCommandDoc = `
+Removes a storage pool from the model.
+
+A pool may not be removed while it is the model's default storage
+source, or while any application's storage constraints, or any storage,
+refer to it.
+
+Examples:
+    juju remove-storage-pool fast
+`
+
+// NewPoolRemoveCommand returns a command that removes a storage pool.
+func NewPoolRemoveCommand() cmd.Command {
+	cmd := &poolRemoveCommand{}
+	cmd.newAPIFunc = func() (PoolRemoveAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// poolRemoveCommand removes a storage pool.
+type poolRemoveCommand struct {
+	PoolCommandBase
+	newAPIFunc func() (PoolRemoveAPI, error)
+	poolName   string
+}
+
+// Init implements Command.Init.
+func (c *poolRemoveCommand) Init(args []string) (err error) {
+	if len(args) < 1 {
+		return errors.New("pool removal requires a pool name")
+	}
+	c.poolName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Info implements Command.Info.
+func (c *poolRemoveCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-storage-pool",
+		Args:    "<name>",
+		Purpose: "Remove a storage pool.",
+		Doc:     poolRemoveCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *poolRemoveCommand) Run(ctx *cmd.Context) (err error) {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	return api.RemovePool(c.poolName)
+}
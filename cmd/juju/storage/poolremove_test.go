@@ -0,0 +1,68 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	_ "github.com/juju/juju/provider/dummy"
+)
+
+type PoolRemoveSuite struct {
+	SubStorageSuite
+	mockAPI *mockPoolRemoveAPI
+}
+
+var _ = gc.Suite(&PoolRemoveSuite{})
+
+func (s *PoolRemoveSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockPoolRemoveAPI{}
+}
+
+func (s *PoolRemoveSuite) runPoolRemove(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewPoolRemoveCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *PoolRemoveSuite) TestPoolRemoveInitErrors(c *gc.C) {
+	_, err := s.runPoolRemove(c)
+	c.Check(err, gc.ErrorMatches, "pool removal requires a pool name")
+	_, err = s.runPoolRemove(c, "sunshine", "lollypop")
+	c.Check(err, gc.ErrorMatches, `unrecognized args: \["lollypop"\]`)
+}
+
+func (s *PoolRemoveSuite) TestPoolRemove(c *gc.C) {
+	_, err := s.runPoolRemove(c, "sunshine")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"RemovePool", []interface{}{"sunshine"}},
+		{"Close", nil},
+	})
+}
+
+func (s *PoolRemoveSuite) TestPoolRemoveInUse(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`cannot remove storage pool "sunshine": storage pool "sunshine" in use`))
+	_, err := s.runPoolRemove(c, "sunshine")
+	c.Assert(err, gc.ErrorMatches, `cannot remove storage pool "sunshine": storage pool "sunshine" in use`)
+}
+
+type mockPoolRemoveAPI struct {
+	testing.Stub
+}
+
+func (m *mockPoolRemoveAPI) RemovePool(pname string) error {
+	m.MethodCall(m, "RemovePool", pname)
+	return m.NextErr()
+}
+
+func (m *mockPoolRemoveAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
@@ -0,0 +1,116 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// PoolShowAPI defines the API methods that pool show command uses.
+type PoolShowAPI interface {
+	Close() error
+	ShowPool(pname string) (params.StoragePoolDetails, error)
+}
+
+// PoolDetailsInfo defines the serialization behaviour of the details
+// of a storage pool.
+type PoolDetailsInfo struct {
+	Provider string                 `yaml:"provider" json:"provider"`
+	Attrs    map[string]interface{} `yaml:"attrs,omitempty" json:"attrs,omitempty"`
+	Storage  []string               `yaml:"storage,omitempty" json:"storage,omitempty"`
+}
+
+const poolShowCommandDoc = `
+Shows the provider type and configuration attributes of a storage pool,
+and the IDs of the storage instances provisioned from it.
+
+Examples:
+    juju show-storage-pool fast
+`
+
+// NewPoolShowCommand returns a command that shows the details of a
+// storage pool.
+func NewPoolShowCommand() cmd.Command {
+	cmd := &poolShowCommand{}
+	cmd.newAPIFunc = func() (PoolShowAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// poolShowCommand shows the details of a storage pool.
+type poolShowCommand struct {
+	PoolCommandBase
+	newAPIFunc func() (PoolShowAPI, error)
+	poolName   string
+	out        cmd.Output
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *poolShowCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements Command.Init.
+func (c *poolShowCommand) Init(args []string) (err error) {
+	if len(args) < 1 {
+		return errors.New("pool show requires a pool name")
+	}
+	c.poolName = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Info implements Command.Info.
+func (c *poolShowCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-storage-pool",
+		Args:    "<name>",
+		Purpose: "Show the details of a storage pool.",
+		Doc:     poolShowCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *poolShowCommand) Run(ctx *cmd.Context) (err error) {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	details, err := api.ShowPool(c.poolName)
+	if err != nil {
+		return err
+	}
+	info, err := formatPoolDetailsInfo(details)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, map[string]PoolDetailsInfo{details.Pool.Name: info})
+}
+
+func formatPoolDetailsInfo(details params.StoragePoolDetails) (PoolDetailsInfo, error) {
+	info := PoolDetailsInfo{
+		Provider: details.Pool.Provider,
+		Attrs:    details.Pool.Attrs,
+	}
+	for _, tag := range details.StorageTags {
+		storageTag, err := names.ParseStorageTag(tag)
+		if err != nil {
+			return PoolDetailsInfo{}, errors.Trace(err)
+		}
+		info.Storage = append(info.Storage, storageTag.Id())
+	}
+	sort.Strings(info.Storage)
+	return info, nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	_ "github.com/juju/juju/provider/dummy"
+)
+
+type PoolShowSuite struct {
+	SubStorageSuite
+	mockAPI *mockPoolShowAPI
+}
+
+var _ = gc.Suite(&PoolShowSuite{})
+
+func (s *PoolShowSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockPoolShowAPI{
+		details: params.StoragePoolDetails{
+			Pool: params.StoragePool{
+				Name:     "sunshine",
+				Provider: "loop",
+				Attrs:    map[string]interface{}{"something": "too"},
+			},
+			StorageTags: []string{"storage-pgdata-1", "storage-pgdata-0"},
+		},
+	}
+}
+
+func (s *PoolShowSuite) runPoolShow(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewPoolShowCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *PoolShowSuite) TestPoolShowNoArgs(c *gc.C) {
+	_, err := s.runPoolShow(c)
+	c.Check(err, gc.ErrorMatches, "pool show requires a pool name")
+}
+
+func (s *PoolShowSuite) TestPoolShowYAML(c *gc.C) {
+	ctx, err := s.runPoolShow(c, "sunshine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+sunshine:
+  provider: loop
+  attrs:
+    something: too
+  storage:
+  - pgdata/0
+  - pgdata/1
+`[1:])
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"ShowPool", []interface{}{"sunshine"}},
+		{"Close", nil},
+	})
+}
+
+func (s *PoolShowSuite) TestPoolShowJSON(c *gc.C) {
+	s.mockAPI.details.StorageTags = nil
+	ctx, err := s.runPoolShow(c, "sunshine", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"sunshine":{"provider":"loop","attrs":{"something":"too"}}}`+"\n")
+}
+
+func (s *PoolShowSuite) TestPoolShowError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotFoundf(`pool "sunshine"`))
+	_, err := s.runPoolShow(c, "sunshine")
+	c.Assert(err, gc.ErrorMatches, `pool "sunshine" not found`)
+}
+
+type mockPoolShowAPI struct {
+	testing.Stub
+	details params.StoragePoolDetails
+}
+
+func (m *mockPoolShowAPI) ShowPool(pname string) (params.StoragePoolDetails, error) {
+	m.MethodCall(m, "ShowPool", pname)
+	return m.details, m.NextErr()
+}
+
+func (m *mockPoolShowAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// PoolUpdateAPI defines the API methods that pool update command uses.
+type PoolUpdateAPI interface {
+	Close() error
+	UpdatePool(pname, ptype string, pconfig map[string]interface{}) error
+}
+
+const poolUpdateCommandDoc = `
+Replaces the configuration attributes of an existing storage pool, and
+optionally its provider type. The attributes given replace all of the
+pool's existing attributes; attributes that are not given are removed.
+
+The new configuration is validated by the storage provider before the
+pool is updated. The provider type of a pool that is in use by storage
+constraints or storage may not be changed, and its attributes may only
+be changed if the provider can apply the change to existing storage.
+
+Examples:
+    # Use provisioned IOPS volumes for the "fast" pool.
+    juju update-storage-pool fast volume-type=provisioned-iops iops=40
+
+    # Change the "scratch" pool to use the tmpfs provider, with no attributes.
+    juju update-storage-pool --provider tmpfs scratch
+`
+
+// NewPoolUpdateCommand returns a command that updates a storage pool.
+func NewPoolUpdateCommand() cmd.Command {
+	cmd := &poolUpdateCommand{}
+	cmd.newAPIFunc = func() (PoolUpdateAPI, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// poolUpdateCommand updates a storage pool.
+type poolUpdateCommand struct {
+	PoolCommandBase
+	newAPIFunc func() (PoolUpdateAPI, error)
+	poolName   string
+	provider   string
+	attrs      map[string]interface{}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *poolUpdateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.StringVar(&c.provider, "provider", "", "Change the pool's provider type")
+}
+
+// Init implements Command.Init.
+func (c *poolUpdateCommand) Init(args []string) (err error) {
+	if len(args) < 1 {
+		return errors.New("pool update requires a pool name")
+	}
+	c.poolName = args[0]
+	options, err := keyvalues.Parse(args[1:], false)
+	if err != nil {
+		return err
+	}
+	if len(options) == 0 && c.provider == "" {
+		return errors.New("pool update requires attributes for configuration, or a provider type")
+	}
+	c.attrs = make(map[string]interface{})
+	for key, value := range options {
+		c.attrs[key] = value
+	}
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *poolUpdateCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "update-storage-pool",
+		Args:    "<name> [<key>=<value> [<key>=<value>...]]",
+		Purpose: "Update a storage pool's configuration.",
+		Doc:     poolUpdateCommandDoc,
+	}
+}
+
+// Run implements Command.Run.
+func (c *poolUpdateCommand) Run(ctx *cmd.Context) (err error) {
+	api, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	return api.UpdatePool(c.poolName, c.provider, c.attrs)
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	_ "github.com/juju/juju/provider/dummy"
+)
+
+type PoolUpdateSuite struct {
+	SubStorageSuite
+	mockAPI *mockPoolUpdateAPI
+}
+
+var _ = gc.Suite(&PoolUpdateSuite{})
+
+func (s *PoolUpdateSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.mockAPI = &mockPoolUpdateAPI{}
+}
+
+func (s *PoolUpdateSuite) runPoolUpdate(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewPoolUpdateCommandForTest(s.mockAPI, s.store), args...)
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateNoArgs(c *gc.C) {
+	_, err := s.runPoolUpdate(c)
+	c.Check(err, gc.ErrorMatches, "pool update requires a pool name")
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateNothingToUpdate(c *gc.C) {
+	_, err := s.runPoolUpdate(c, "sunshine")
+	c.Check(err, gc.ErrorMatches, "pool update requires attributes for configuration, or a provider type")
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateAttrMissingValue(c *gc.C) {
+	_, err := s.runPoolUpdate(c, "sunshine", "something=")
+	c.Check(err, gc.ErrorMatches, `expected "key=value", got "something="`)
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateAttrs(c *gc.C) {
+	_, err := s.runPoolUpdate(c, "sunshine", "something=too", "another=one")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"UpdatePool", []interface{}{"sunshine", "", map[string]interface{}{
+			"something": "too",
+			"another":   "one",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateProvider(c *gc.C) {
+	_, err := s.runPoolUpdate(c, "--provider", "lollypop", "sunshine")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []testing.StubCall{
+		{"UpdatePool", []interface{}{"sunshine", "lollypop", map[string]interface{}{}}},
+		{"Close", nil},
+	})
+}
+
+func (s *PoolUpdateSuite) TestPoolUpdateError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("validating storage provider config: no good"))
+	_, err := s.runPoolUpdate(c, "sunshine", "something=too")
+	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
+}
+
+type mockPoolUpdateAPI struct {
+	testing.Stub
+}
+
+func (m *mockPoolUpdateAPI) UpdatePool(pname, ptype string, pconfig map[string]interface{}) error {
+	m.MethodCall(m, "UpdatePool", pname, ptype, pconfig)
+	return m.NextErr()
+}
+
+func (m *mockPoolUpdateAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
@@ -0,0 +1,141 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewResizeStorageCommand returns a command used to resize storage.
+//
+// newStorageResizer is the function to use to acquire a StorageResizer.
+// A non-nil function must be provided.
+//
+// store is an optional ClientStore to use for interacting with the client
+// model/controller storage. If nil, the default file-based store will be
+// used.
+func NewResizeStorageCommand(
+	newStorageResizer NewStorageResizerFunc,
+	store jujuclient.ClientStore,
+) cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newAPIFunc = newStorageResizer
+	if store != nil {
+		cmd.SetClientStore(store)
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewStorageResizerFunc is the type of a function passed to
+// NewResizeStorageCommand, in order to acquire a StorageResizer.
+type NewStorageResizerFunc func(*StorageCommandBase) (StorageResizer, error)
+
+// NewStorageResizer returns a new StorageResizer,
+// given a StorageCommandBase.
+func NewStorageResizer(cmd *StorageCommandBase) (StorageResizer, error) {
+	return cmd.NewStorageAPI()
+}
+
+// StorageResizer provides a method for resizing storage.
+type StorageResizer interface {
+	Close() error
+
+	// Resize requests that the storage instance with the specified
+	// ID be grown to the specified size, in MiB.
+	Resize(storageId string, size uint64) error
+}
+
+const (
+	resizeStorageCommandDoc = `
+Grows a storage instance to the specified size. Specify the storage ID,
+as output by "juju storage", and the new size, which must be larger than
+the storage's current size.
+
+Storage is resized while it remains attached, by the storage provisioner
+responsible for it. Not all storage providers support resizing storage;
+the volume's status reports progress or failure, and its message the
+new size once the volume has been resized. Filesystems are not
+grown automatically; once the underlying volume has been resized, the
+units the storage is attached to run their <name>-storage-resized
+hook, and the charm or operator must grow the filesystem to use the
+new space.
+
+The size is specified in mebibytes, or with a unit suffix of
+M, G, T, P or E.
+
+Examples:
+    # Grow the storage pgdata/0 to 100GiB.
+    juju resize-storage pgdata/0 100G
+`
+	resizeStorageCommandArgs = `<storage> <size>`
+)
+
+// resizeStorageCommand resizes storage instances.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc NewStorageResizerFunc
+
+	storageId string
+	size      uint64
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	if len(args) > 2 {
+		return errors.New("resize-storage takes exactly two arguments")
+	}
+	c.storageId = args[0]
+	if !names.IsValidStorage(c.storageId) {
+		return errors.NotValidf("storage ID %q", c.storageId)
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotatef(err, "cannot parse size")
+	}
+	if size == 0 {
+		return errors.NotValidf("size 0")
+	}
+	c.size = size
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows storage to a new size.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    resizeStorageCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc(&c.StorageCommandBase)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	if err := api.Resize(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return err
+	}
+	ctx.Infof("resizing storage %s to %dMiB", c.storageId, c.size)
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"errors"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/storage"
+	_ "github.com/juju/juju/provider/dummy"
+)
+
+type ResizeStorageSuite struct {
+	SubStorageSuite
+	resizer mockStorageResizer
+}
+
+var _ = gc.Suite(&ResizeStorageSuite{})
+
+func (s *ResizeStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.resizer = mockStorageResizer{}
+}
+
+func (s *ResizeStorageSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{{
+		args:        []string{"foo/0"},
+		expectedErr: "resize-storage requires a storage ID and a size",
+	}, {
+		args:        []string{"foo/0", "1G", "2G"},
+		expectedErr: "resize-storage takes exactly two arguments",
+	}, {
+		args:        []string{"foo", "1G"},
+		expectedErr: `storage ID "foo" not valid`,
+	}, {
+		args:        []string{"foo/0", "lots"},
+		expectedErr: `cannot parse size: .*`,
+	}, {
+		args:        []string{"foo/0", "0"},
+		expectedErr: `size 0 not valid`,
+	}} {
+		c.Logf("test %d for %q", i, t.args)
+		_, err := s.run(c, t.args...)
+		c.Assert(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *ResizeStorageSuite) TestResize(c *gc.C) {
+	ctx, err := s.run(c, "foo/0", "10G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resizing storage foo/0 to 10240MiB\n")
+	s.resizer.CheckCalls(c, []testing.StubCall{
+		{"Resize", []interface{}{"foo/0", uint64(10240)}},
+		{"Close", nil},
+	})
+}
+
+func (s *ResizeStorageSuite) TestResizeError(c *gc.C) {
+	s.resizer.SetErrors(errors.New("nope"))
+	ctx, err := s.run(c, "foo/0", "1024")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+}
+
+func (s *ResizeStorageSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewResizeStorageCommand(
+		func(*storage.StorageCommandBase) (storage.StorageResizer, error) {
+			return &s.resizer, nil
+		},
+		s.store,
+	), args...)
+}
+
+type mockStorageResizer struct {
+	testing.Stub
+}
+
+func (m *mockStorageResizer) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockStorageResizer) Resize(storageId string, size uint64) error {
+	m.MethodCall(m, "Resize", storageId, size)
+	return m.NextErr()
+}
@@ -0,0 +1,312 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewCreateSnapshotCommand returns a command used to snapshot storage.
+//
+// newStorageSnapshotter is the function to use to acquire a
+// StorageSnapshotter. A non-nil function must be provided.
+//
+// store is an optional ClientStore to use for interacting with the client
+// model/controller storage. If nil, the default file-based store will be
+// used.
+func NewCreateSnapshotCommand(
+	newStorageSnapshotter NewStorageSnapshotterFunc,
+	store jujuclient.ClientStore,
+) cmd.Command {
+	cmd := &createSnapshotCommand{}
+	cmd.newAPIFunc = newStorageSnapshotter
+	if store != nil {
+		cmd.SetClientStore(store)
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewListSnapshotsCommand returns a command used to list storage
+// snapshots.
+//
+// newStorageSnapshotter is the function to use to acquire a
+// StorageSnapshotter. A non-nil function must be provided.
+//
+// store is an optional ClientStore to use for interacting with the client
+// model/controller storage. If nil, the default file-based store will be
+// used.
+func NewListSnapshotsCommand(
+	newStorageSnapshotter NewStorageSnapshotterFunc,
+	store jujuclient.ClientStore,
+) cmd.Command {
+	cmd := &listSnapshotsCommand{}
+	cmd.newAPIFunc = newStorageSnapshotter
+	if store != nil {
+		cmd.SetClientStore(store)
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewStorageSnapshotterFunc is the type of a function passed to
+// NewCreateSnapshotCommand and NewListSnapshotsCommand, in order
+// to acquire a StorageSnapshotter.
+type NewStorageSnapshotterFunc func(*StorageCommandBase) (StorageSnapshotter, error)
+
+// NewStorageSnapshotter returns a new StorageSnapshotter,
+// given a StorageCommandBase.
+func NewStorageSnapshotter(cmd *StorageCommandBase) (StorageSnapshotter, error) {
+	return cmd.NewStorageAPI()
+}
+
+// StorageSnapshotter provides methods for taking and listing
+// snapshots of storage.
+type StorageSnapshotter interface {
+	Close() error
+
+	// CreateSnapshots requests snapshots of the volumes backing
+	// the storage instances with the specified IDs.
+	CreateSnapshots(storageIds []string) ([]params.VolumeSnapshotDetailsResult, error)
+
+	// ListSnapshots returns the details of all volume snapshots
+	// in the model.
+	ListSnapshots() ([]params.VolumeSnapshotDetails, error)
+}
+
+const (
+	createSnapshotCommandDoc = `
+Requests point-in-time snapshots of the volumes backing the specified
+storage instances. Specify the storage IDs, as output by "juju storage".
+
+Snapshots are taken by the storage provisioner responsible for each
+volume. Not all storage providers support taking snapshots; snapshots
+that have not yet been taken are shown as pending by
+"juju storage-snapshots". Snapshots of loop devices are local to the
+machine that the volume was created on.
+
+New storage may be restored from a snapshot with
+"juju add-storage --snapshot".
+
+Snapshots are not yet carried over by model migration, so a model
+with snapshots can't be migrated to another controller.
+
+Examples:
+    # Snapshot the storage pgdata/0.
+    juju create-storage-snapshot pgdata/0
+`
+	createSnapshotCommandArgs = `<storage ID> [<storage ID> ...]`
+)
+
+// createSnapshotCommand requests snapshots of storage instances.
+type createSnapshotCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc NewStorageSnapshotterFunc
+
+	storageIds []string
+}
+
+// Init implements Command.Init.
+func (c *createSnapshotCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("create-storage-snapshot requires at least one storage ID")
+	}
+	for _, id := range args {
+		if !names.IsValidStorage(id) {
+			return errors.NotValidf("storage ID %q", id)
+		}
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *createSnapshotCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "create-storage-snapshot",
+		Purpose: "Takes snapshots of storage.",
+		Doc:     createSnapshotCommandDoc,
+		Args:    createSnapshotCommandArgs,
+	}
+}
+
+// Run implements Command.Run.
+func (c *createSnapshotCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc(&c.StorageCommandBase)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	results, err := api.CreateSnapshots(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "snapshot storage")
+		}
+		return err
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			failed = true
+			ctx.Infof("failed to snapshot storage %s: %v", c.storageIds[i], result.Error)
+			continue
+		}
+		ctx.Infof("creating snapshot %s of storage %s", result.Result.Id, c.storageIds[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+const listSnapshotsCommandDoc = `
+Lists the volume snapshots in the model, and the storage and volumes
+that they were taken from. Snapshots that have not yet been taken by
+the storage provisioner are shown as pending.
+`
+
+// listSnapshotsCommand lists volume snapshots.
+type listSnapshotsCommand struct {
+	StorageCommandBase
+	modelcmd.IAASOnlyCommand
+	newAPIFunc NewStorageSnapshotterFunc
+	out        cmd.Output
+}
+
+// SnapshotInfo defines the serialization behaviour of volume snapshot
+// information.
+type SnapshotInfo struct {
+	Volume     string    `yaml:"volume" json:"volume"`
+	Storage    string    `yaml:"storage,omitempty" json:"storage,omitempty"`
+	Pool       string    `yaml:"pool" json:"pool"`
+	Created    time.Time `yaml:"created" json:"created"`
+	SnapshotId string    `yaml:"snapshot-id,omitempty" json:"snapshot-id,omitempty"`
+	Size       uint64    `yaml:"size,omitempty" json:"size,omitempty"`
+}
+
+// Init implements Command.Init.
+func (c *listSnapshotsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Info implements Command.Info.
+func (c *listSnapshotsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "storage-snapshots",
+		Purpose: "Lists storage snapshots.",
+		Doc:     listSnapshotsCommandDoc,
+		Aliases: []string{"list-storage-snapshots"},
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *listSnapshotsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Run implements Command.Run.
+func (c *listSnapshotsCommand) Run(ctx *cmd.Context) error {
+	api, err := c.newAPIFunc(&c.StorageCommandBase)
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+
+	snapshots, err := api.ListSnapshots()
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		ctx.Infof("No storage snapshots to display.")
+		return nil
+	}
+	info, err := formatSnapshotInfo(snapshots)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, info)
+}
+
+func formatSnapshotInfo(all []params.VolumeSnapshotDetails) (map[string]SnapshotInfo, error) {
+	result := make(map[string]SnapshotInfo)
+	for _, one := range all {
+		volumeTag, err := names.ParseVolumeTag(one.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info := SnapshotInfo{
+			Volume:  volumeTag.Id(),
+			Pool:    one.Pool,
+			Created: one.Created,
+		}
+		if one.StorageTag != "" {
+			storageTag, err := names.ParseStorageTag(one.StorageTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			info.Storage = storageTag.Id()
+		}
+		if one.Info != nil {
+			info.SnapshotId = one.Info.SnapshotId
+			info.Size = one.Info.Size
+		}
+		result[one.Id] = info
+	}
+	return result, nil
+}
+
+// formatSnapshotListTabular returns a tabular summary of volume
+// snapshots, or errors out if value is not a map of SnapshotInfo.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	print("Snapshot", "Storage", "Volume", "Pool", "Size", "Provider id", "Created")
+
+	ids := make([]string, 0, len(snapshots))
+	for id := range snapshots {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		snapshot := snapshots[id]
+		size, providerId := "", "pending"
+		if snapshot.SnapshotId != "" {
+			size = humanize.IBytes(snapshot.Size * humanize.MiByte)
+			providerId = snapshot.SnapshotId
+		}
+		print(
+			id, snapshot.Storage, snapshot.Volume, snapshot.Pool, size, providerId,
+			common.FormatTime(&snapshot.Created, true),
+		)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,168 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	_ "github.com/juju/juju/provider/dummy"
+)
+
+type SnapshotStorageSuite struct {
+	SubStorageSuite
+	snapshotter mockStorageSnapshotter
+}
+
+var _ = gc.Suite(&SnapshotStorageSuite{})
+
+func (s *SnapshotStorageSuite) SetUpTest(c *gc.C) {
+	s.SubStorageSuite.SetUpTest(c)
+	s.snapshotter = mockStorageSnapshotter{
+		snapshots: []params.VolumeSnapshotDetails{{
+			Id:         "0/0@1",
+			VolumeTag:  "volume-0-0",
+			StorageTag: "storage-pgdata-0",
+			Pool:       "loop",
+			Created:    time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC),
+		}, {
+			Id:         "0/0@0",
+			VolumeTag:  "volume-0-0",
+			StorageTag: "storage-pgdata-0",
+			Pool:       "loop",
+			Created:    time.Date(2019, 2, 28, 12, 0, 0, 0, time.UTC),
+			Info: &params.VolumeSnapshotInfo{
+				Id:         "0/0@0",
+				SnapshotId: "snapshot-0-0@0",
+				Size:       1024,
+			},
+		}},
+	}
+}
+
+func (s *SnapshotStorageSuite) TestCreateInitErrors(c *gc.C) {
+	_, err := s.runCreate(c)
+	c.Assert(err, gc.ErrorMatches, "create-storage-snapshot requires at least one storage ID")
+	_, err = s.runCreate(c, "foo/0", "foo")
+	c.Assert(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *SnapshotStorageSuite) TestCreate(c *gc.C) {
+	ctx, err := s.runCreate(c, "foo/0", "bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+creating snapshot 0@0 of storage foo/0
+creating snapshot 1@0 of storage bar/1
+`[1:])
+	s.snapshotter.CheckCalls(c, []testing.StubCall{
+		{"CreateSnapshots", []interface{}{[]string{"foo/0", "bar/1"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SnapshotStorageSuite) TestCreateResultError(c *gc.C) {
+	s.snapshotter.resultErr = &params.Error{Message: "not supported"}
+	ctx, err := s.runCreate(c, "foo/0")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "failed to snapshot storage foo/0: not supported\n")
+}
+
+func (s *SnapshotStorageSuite) TestCreateError(c *gc.C) {
+	s.snapshotter.SetErrors(errors.New("nope"))
+	ctx, err := s.runCreate(c, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+}
+
+func (s *SnapshotStorageSuite) TestListTabular(c *gc.C) {
+	ctx, err := s.runList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Snapshot  Storage   Volume  Pool  Size     Provider id     Created
+0/0@0     pgdata/0  0/0     loop  1.0 GiB  snapshot-0-0@0  2019-02-28 12:00:00Z
+0/0@1     pgdata/0  0/0     loop           pending         2019-03-01 12:00:00Z
+
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestListYAML(c *gc.C) {
+	ctx, err := s.runList(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+0/0@0:
+  volume: 0/0
+  storage: pgdata/0
+  pool: loop
+  created: 2019-02-28T12:00:00Z
+  snapshot-id: snapshot-0-0@0
+  size: 1024
+0/0@1:
+  volume: 0/0
+  storage: pgdata/0
+  pool: loop
+  created: 2019-03-01T12:00:00Z
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestListEmpty(c *gc.C) {
+	s.snapshotter.snapshots = nil
+	ctx, err := s.runList(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No storage snapshots to display.\n")
+}
+
+func (s *SnapshotStorageSuite) runCreate(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewCreateSnapshotCommand(s.newSnapshotter, s.store), args...)
+}
+
+func (s *SnapshotStorageSuite) runList(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, storage.NewListSnapshotsCommand(s.newSnapshotter, s.store), args...)
+}
+
+func (s *SnapshotStorageSuite) newSnapshotter(*storage.StorageCommandBase) (storage.StorageSnapshotter, error) {
+	return &s.snapshotter, nil
+}
+
+type mockStorageSnapshotter struct {
+	testing.Stub
+	snapshots []params.VolumeSnapshotDetails
+	resultErr *params.Error
+}
+
+func (m *mockStorageSnapshotter) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockStorageSnapshotter) CreateSnapshots(storageIds []string) ([]params.VolumeSnapshotDetailsResult, error) {
+	m.MethodCall(m, "CreateSnapshots", storageIds)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	results := make([]params.VolumeSnapshotDetailsResult, len(storageIds))
+	for i := range storageIds {
+		if m.resultErr != nil {
+			results[i].Error = m.resultErr
+			continue
+		}
+		results[i].Result = &params.VolumeSnapshotDetails{Id: fmt.Sprintf("%d@0", i)}
+	}
+	return results, nil
+}
+
+func (m *mockStorageSnapshotter) ListSnapshots() ([]params.VolumeSnapshotDetails, error) {
+	m.MethodCall(m, "ListSnapshots")
+	return m.snapshots, m.NextErr()
+}
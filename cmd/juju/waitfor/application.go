@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import "github.com/juju/cmd"
+
+const applicationDoc = `
+Wait for one or more applications to reach a goal state, as described
+by the query. The application name may contain shell-style wildcards,
+in which case every matching application must satisfy the query. By
+default the command waits for the application to be alive and active.
+
+Examples:
+    juju wait-for application mysql
+    juju wait-for application mysql --query='unit-count>=3'
+    juju wait-for application 'nova-*' --query='status=="active" && !exposed'
+`
+
+var applicationKind = entityKind{
+	name:         "application",
+	args:         "<application name>",
+	purpose:      "Wait for an application to reach a specified state.",
+	doc:          applicationDoc,
+	defaultQuery: `life=="alive" && status=="active"`,
+	identifiers: map[string]string{
+		"name":             "the application name",
+		"life":             "alive, dying or dead",
+		"status":           "the application status",
+		"message":          "the application status message",
+		"charm":            "the charm URL",
+		"exposed":          "whether the application is exposed",
+		"subordinate":      "whether the application is subordinate",
+		"workload-version": "the workload version",
+		"unit-count":       "the number of units of the application",
+		"min-units":        "the minimum number of units",
+	},
+	validateArg: validatePattern,
+	entities: func(store *entityStore, pattern string) map[string]scope {
+		result := make(map[string]scope)
+		for name, app := range store.applications {
+			if !matches(pattern, name) {
+				continue
+			}
+			result[name] = scope{
+				"name":             app.Name,
+				"life":             string(app.Life),
+				"status":           string(app.Status.Current),
+				"message":          app.Status.Message,
+				"charm":            app.CharmURL,
+				"exposed":          app.Exposed,
+				"subordinate":      app.Subordinate,
+				"workload-version": app.WorkloadVersion,
+				"unit-count":       store.unitCount(app.Name),
+				"min-units":        app.MinUnits,
+			}
+		}
+		return result
+	},
+}
+
+// NewApplicationCommand returns a command which waits for
+// applications to satisfy a query.
+func NewApplicationCommand() cmd.Command {
+	return newWaitForCommand(applicationKind)
+}
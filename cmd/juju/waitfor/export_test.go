@@ -0,0 +1,33 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+var kinds = map[string]entityKind{
+	"model":       modelKind,
+	"application": applicationKind,
+	"unit":        unitKind,
+	"machine":     machineKind,
+}
+
+// NewWaitForCommandForTest returns a wait-for subcommand for the
+// named kind of entity, using the api, clock and store provided.
+func NewWaitForCommandForTest(kind string, api WatchAllAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	c := &waitForCommand{
+		kind:  kinds[kind],
+		clock: clock,
+		newAPIFunc: func() (WatchAllAPI, error) {
+			return api, nil
+		},
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import "github.com/juju/cmd"
+
+const machineDoc = `
+Wait for one or more machines to reach a goal state, as described by
+the query. The machine id may contain shell-style wildcards, in which
+case every matching machine must satisfy the query. By default the
+command waits for the machine agent to be started.
+
+Examples:
+    juju wait-for machine 3
+    juju wait-for machine '0/lxd/*' --query='status=="started" && series=="bionic"'
+`
+
+var machineKind = entityKind{
+	name:         "machine",
+	args:         "<machine id>",
+	purpose:      "Wait for a machine to reach a specified state.",
+	doc:          machineDoc,
+	defaultQuery: `status=="started"`,
+	identifiers: map[string]string{
+		"id":              "the machine id",
+		"life":            "alive, dying or dead",
+		"status":          "the machine agent status",
+		"message":         "the machine agent status message",
+		"instance-status": "the status of the machine's cloud instance",
+		"instance-id":     "the id of the machine's cloud instance",
+		"series":          "the machine series",
+		"address-count":   "the number of addresses known for the machine",
+	},
+	validateArg: validatePattern,
+	entities: func(store *entityStore, pattern string) map[string]scope {
+		result := make(map[string]scope)
+		for id, machine := range store.machines {
+			if !matches(pattern, id) {
+				continue
+			}
+			result["machine "+id] = scope{
+				"id":              machine.Id,
+				"life":            string(machine.Life),
+				"status":          string(machine.AgentStatus.Current),
+				"message":         machine.AgentStatus.Message,
+				"instance-status": string(machine.InstanceStatus.Current),
+				"instance-id":     machine.InstanceId,
+				"series":          machine.Series,
+				"address-count":   len(machine.Addresses),
+			}
+		}
+		return result
+	},
+}
+
+// NewMachineCommand returns a command which waits for machines to
+// satisfy a query.
+func NewMachineCommand() cmd.Command {
+	return newWaitForCommand(machineKind)
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import "github.com/juju/cmd"
+
+const modelDoc = `
+Wait for the current model to reach a goal state, as described by the
+query. By default the command waits for the model to be alive and
+available.
+
+Examples:
+    juju wait-for model
+    juju wait-for model --query='application-count>=3'
+    juju wait-for model -m other --query='status=="available"' --timeout=1h
+`
+
+var modelKind = entityKind{
+	name:         "model",
+	purpose:      "Wait for the model to reach a specified state.",
+	doc:          modelDoc,
+	defaultQuery: `life=="alive" && status=="available"`,
+	identifiers: map[string]string{
+		"name":              "the model name",
+		"life":              "alive, dying or dead",
+		"status":            "the model status",
+		"message":           "the model status message",
+		"application-count": "the number of applications in the model",
+		"machine-count":     "the number of machines in the model",
+		"unit-count":        "the number of units in the model",
+	},
+	entities: func(store *entityStore, _ string) map[string]scope {
+		if store.model == nil {
+			return nil
+		}
+		model := store.model
+		return map[string]scope{
+			model.Name: {
+				"name":              model.Name,
+				"life":              string(model.Life),
+				"status":            string(model.Status.Current),
+				"message":           model.Status.Message,
+				"application-count": len(store.applications),
+				"machine-count":     len(store.machines),
+				"unit-count":        len(store.units),
+			},
+		}
+	},
+}
+
+// NewModelCommand returns a command which waits for the model to
+// satisfy a query.
+func NewModelCommand() cmd.Command {
+	return newWaitForCommand(modelKind)
+}
@@ -0,0 +1,145 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package query
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// tokenType identifies the kind of a lexed token.
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenIdent
+	tokenString
+	tokenInt
+	tokenTrue
+	tokenFalse
+	tokenLParen
+	tokenRParen
+	tokenNot
+	tokenAnd
+	tokenOr
+	tokenEq
+	tokenNotEq
+	tokenLT
+	tokenLE
+	tokenGT
+	tokenGE
+)
+
+var tokenNames = map[tokenType]string{
+	tokenEOF:    "end of query",
+	tokenIdent:  "identifier",
+	tokenString: "string",
+	tokenInt:    "integer",
+	tokenTrue:   "true",
+	tokenFalse:  "false",
+	tokenLParen: "(",
+	tokenRParen: ")",
+	tokenNot:    "!",
+	tokenAnd:    "&&",
+	tokenOr:     "||",
+	tokenEq:     "==",
+	tokenNotEq:  "!=",
+	tokenLT:     "<",
+	tokenLE:     "<=",
+	tokenGT:     ">",
+	tokenGE:     ">=",
+}
+
+func (t tokenType) String() string {
+	return tokenNames[t]
+}
+
+// token is a single lexical element of a query.
+type token struct {
+	typ   tokenType
+	value string
+	pos   int
+}
+
+// lex splits the input into tokens.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{typ: tokenLParen, pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{typ: tokenRParen, pos: i})
+			i++
+		case r == '&' || r == '|' || r == '=':
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, fmt.Errorf("unexpected %q at position %d", r, i)
+			}
+			typ := map[rune]tokenType{'&': tokenAnd, '|': tokenOr, '=': tokenEq}[r]
+			tokens = append(tokens, token{typ: typ, pos: i})
+			i += 2
+		case r == '!' || r == '<' || r == '>':
+			typ := map[rune]tokenType{'!': tokenNot, '<': tokenLT, '>': tokenGT}[r]
+			width := 1
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				typ = map[rune]tokenType{'!': tokenNotEq, '<': tokenLE, '>': tokenGE}[r]
+				width = 2
+			}
+			tokens = append(tokens, token{typ: typ, pos: i})
+			i += width
+		case r == '"' || r == '\'':
+			start := i
+			i++
+			var value []rune
+			for ; i < len(runes) && runes[i] != r; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value = append(value, runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			tokens = append(tokens, token{typ: tokenString, value: string(value), pos: start})
+			i++
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{typ: tokenInt, value: string(runes[start:i]), pos: start})
+		case isIdentStart(r):
+			start := i
+			for i < len(runes) && isIdentPart(runes[i]) {
+				i++
+			}
+			value := string(runes[start:i])
+			typ := tokenIdent
+			switch value {
+			case "true":
+				typ = tokenTrue
+			case "false":
+				typ = tokenFalse
+			}
+			tokens = append(tokens, token{typ: typ, value: value, pos: start})
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", r, i)
+		}
+	}
+	tokens = append(tokens, token{typ: tokenEOF, pos: len(runes)})
+	return tokens, nil
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_'
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || unicode.IsDigit(r) || r == '-' || r == '.'
+}
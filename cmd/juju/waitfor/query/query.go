@@ -0,0 +1,352 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package query implements the small expression language used by
+// "juju wait-for" to describe the condition being waited for.
+//
+// A query is made up of identifiers, string, integer and boolean
+// literals, the comparison operators ==, !=, <, <=, > and >=, the
+// logical operators &&, || and !, and parentheses. For example:
+//
+//	status=="active" && unit-count>=3
+//
+// Identifiers are resolved against a Scope supplied when the query
+// is evaluated.
+package query
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/errors"
+)
+
+// Scope resolves identifiers used in a query to values. Values must
+// be one of string, int or bool.
+type Scope interface {
+	GetIdentValue(name string) (interface{}, error)
+}
+
+// Query is a parsed query expression.
+type Query struct {
+	source string
+	root   node
+}
+
+// Parse parses the query expression.
+func Parse(source string) (*Query, error) {
+	tokens, err := lex(source)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing query %q", source)
+	}
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err == nil && p.peek().typ != tokenEOF {
+		next := p.peek()
+		err = fmt.Errorf("unexpected %s at position %d", next.typ, next.pos)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing query %q", source)
+	}
+	return &Query{source: source, root: root}, nil
+}
+
+// String returns the query source.
+func (q *Query) String() string {
+	return q.source
+}
+
+// Identifiers returns the names of the identifiers used in the query.
+func (q *Query) Identifiers() []string {
+	var names []string
+	seen := make(map[string]bool)
+	q.root.walk(func(n node) {
+		if ident, ok := n.(identNode); ok && !seen[ident.name] {
+			seen[ident.name] = true
+			names = append(names, ident.name)
+		}
+	})
+	return names
+}
+
+// Run evaluates the query against the scope, returning whether it
+// is satisfied.
+func (q *Query) Run(scope Scope) (bool, error) {
+	value, err := q.root.eval(scope)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	result, ok := value.(bool)
+	if !ok {
+		return false, errors.Errorf("query %q does not evaluate to a boolean", q.source)
+	}
+	return result, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().typ == tokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: tokenOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().typ == tokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = logicalNode{op: tokenAnd, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.peek().typ == tokenNot {
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	switch op := p.peek().typ; op {
+	case tokenEq, tokenNotEq, tokenLT, tokenLE, tokenGT, tokenGE:
+		p.next()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		return compareNode{op: op, left: left, right: right}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.typ {
+	case tokenLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.typ != tokenRParen {
+			return nil, fmt.Errorf("expected ) at position %d, got %s", closing.pos, closing.typ)
+		}
+		return expr, nil
+	case tokenString:
+		return literalNode{value: t.value}, nil
+	case tokenInt:
+		value, err := strconv.Atoi(t.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer %q at position %d", t.value, t.pos)
+		}
+		return literalNode{value: value}, nil
+	case tokenTrue:
+		return literalNode{value: true}, nil
+	case tokenFalse:
+		return literalNode{value: false}, nil
+	case tokenIdent:
+		return identNode{name: t.value}, nil
+	}
+	return nil, fmt.Errorf("unexpected %s at position %d", t.typ, t.pos)
+}
+
+type node interface {
+	eval(Scope) (interface{}, error)
+	walk(func(node))
+}
+
+type literalNode struct {
+	value interface{}
+}
+
+func (n literalNode) eval(Scope) (interface{}, error) { return n.value, nil }
+func (n literalNode) walk(f func(node))               { f(n) }
+
+type identNode struct {
+	name string
+}
+
+func (n identNode) eval(scope Scope) (interface{}, error) {
+	value, err := scope.GetIdentValue(n.name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch value.(type) {
+	case string, int, bool:
+		return value, nil
+	}
+	return nil, errors.Errorf("identifier %q has unsupported type %T", n.name, value)
+}
+
+func (n identNode) walk(f func(node)) { f(n) }
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) eval(scope Scope) (interface{}, error) {
+	value, err := evalBool(n.operand, scope, "!")
+	if err != nil {
+		return nil, err
+	}
+	return !value, nil
+}
+
+func (n notNode) walk(f func(node)) {
+	f(n)
+	n.operand.walk(f)
+}
+
+type logicalNode struct {
+	op          tokenType
+	left, right node
+}
+
+func (n logicalNode) eval(scope Scope) (interface{}, error) {
+	left, err := evalBool(n.left, scope, n.op.String())
+	if err != nil {
+		return nil, err
+	}
+	// Short-circuit evaluation.
+	if n.op == tokenAnd && !left {
+		return false, nil
+	}
+	if n.op == tokenOr && left {
+		return true, nil
+	}
+	return evalBool(n.right, scope, n.op.String())
+}
+
+func (n logicalNode) walk(f func(node)) {
+	f(n)
+	n.left.walk(f)
+	n.right.walk(f)
+}
+
+type compareNode struct {
+	op          tokenType
+	left, right node
+}
+
+func (n compareNode) eval(scope Scope) (interface{}, error) {
+	left, err := n.left.eval(scope)
+	if err != nil {
+		return nil, err
+	}
+	right, err := n.right.eval(scope)
+	if err != nil {
+		return nil, err
+	}
+	switch l := left.(type) {
+	case int:
+		if r, ok := right.(int); ok {
+			return compareInts(n.op, l, r), nil
+		}
+	case string:
+		if r, ok := right.(string); ok {
+			return compareStrings(n.op, l, r), nil
+		}
+	case bool:
+		if r, ok := right.(bool); ok {
+			switch n.op {
+			case tokenEq:
+				return l == r, nil
+			case tokenNotEq:
+				return l != r, nil
+			}
+			return nil, errors.Errorf("operator %s not supported for booleans", n.op)
+		}
+	}
+	return nil, errors.Errorf("cannot compare %T with %T using %s", left, right, n.op)
+}
+
+func (n compareNode) walk(f func(node)) {
+	f(n)
+	n.left.walk(f)
+	n.right.walk(f)
+}
+
+func evalBool(n node, scope Scope, op string) (bool, error) {
+	value, err := n.eval(scope)
+	if err != nil {
+		return false, err
+	}
+	result, ok := value.(bool)
+	if !ok {
+		return false, errors.Errorf("operand of %s must be a boolean, got %T", op, value)
+	}
+	return result, nil
+}
+
+func compareInts(op tokenType, l, r int) bool {
+	switch op {
+	case tokenEq:
+		return l == r
+	case tokenNotEq:
+		return l != r
+	case tokenLT:
+		return l < r
+	case tokenLE:
+		return l <= r
+	case tokenGT:
+		return l > r
+	}
+	return l >= r
+}
+
+func compareStrings(op tokenType, l, r string) bool {
+	switch op {
+	case tokenEq:
+		return l == r
+	case tokenNotEq:
+		return l != r
+	case tokenLT:
+		return l < r
+	case tokenLE:
+		return l <= r
+	case tokenGT:
+		return l > r
+	}
+	return l >= r
+}
@@ -0,0 +1,118 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package query_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+)
+
+type querySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&querySuite{})
+
+type mapScope map[string]interface{}
+
+func (s mapScope) GetIdentValue(name string) (interface{}, error) {
+	value, ok := s[name]
+	if !ok {
+		return nil, errors.NotFoundf("identifier %q", name)
+	}
+	return value, nil
+}
+
+var scope = mapScope{
+	"status":      "active",
+	"life":        "alive",
+	"unit-count":  3,
+	"exposed":     false,
+	"subordinate": true,
+}
+
+func (s *querySuite) TestRun(c *gc.C) {
+	tests := []struct {
+		query  string
+		result bool
+	}{
+		{`status=="active"`, true},
+		{`status == 'blocked'`, false},
+		{`status!="blocked"`, true},
+		{`unit-count>=3`, true},
+		{`unit-count>3`, false},
+		{`unit-count<4 && unit-count<=3`, true},
+		{`unit-count==2 || life=="alive"`, true},
+		{`!exposed`, true},
+		{`exposed==false && subordinate`, true},
+		{`!(status=="active" && life=="alive")`, false},
+		{`true`, true},
+		{`status=="active" && (exposed || unit-count==3)`, true},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.query)
+		q, err := query.Parse(test.query)
+		c.Assert(err, jc.ErrorIsNil)
+		result, err := q.Run(scope)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result, gc.Equals, test.result)
+	}
+}
+
+func (s *querySuite) TestShortCircuit(c *gc.C) {
+	q, err := query.Parse(`life=="dead" && missing=="x"`)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := q.Run(scope)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.IsFalse)
+}
+
+func (s *querySuite) TestParseErrors(c *gc.C) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{`status="active"`, `parsing query .*: unexpected '=' at position 6`},
+		{`status=="active`, `.*unterminated string at position 8`},
+		{`(status=="active"`, `.*expected \) at position 17, got end of query`},
+		{`status==`, `.*unexpected end of query at position 8`},
+		{`status "active"`, `.*unexpected string at position 7`},
+		{`status # 1`, `.*unexpected '#' at position 7`},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.query)
+		_, err := query.Parse(test.query)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *querySuite) TestRunErrors(c *gc.C) {
+	tests := []struct {
+		query string
+		err   string
+	}{
+		{`missing=="x"`, `identifier "missing" not found`},
+		{`status`, `query "status" does not evaluate to a boolean`},
+		{`status==3`, `cannot compare string with int using ==`},
+		{`exposed<true`, `operator < not supported for booleans`},
+		{`status && exposed`, `operand of && must be a boolean, got string`},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.query)
+		q, err := query.Parse(test.query)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = q.Run(scope)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *querySuite) TestIdentifiers(c *gc.C) {
+	q, err := query.Parse(`status=="active" && (unit-count>1 || status!="x")`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(q.Identifiers(), jc.DeepEquals, []string{"status", "unit-count"})
+}
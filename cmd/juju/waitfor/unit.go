@@ -0,0 +1,66 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import "github.com/juju/cmd"
+
+const unitDoc = `
+Wait for one or more units to reach a goal state, as described by the
+query. The unit name may contain shell-style wildcards, in which case
+every matching unit must satisfy the query. By default the command
+waits for the unit's workload to be active and its agent to be idle.
+
+Examples:
+    juju wait-for unit mysql/0
+    juju wait-for unit 'mysql/*'
+    juju wait-for unit 'mysql/*' --query='workload-status=="blocked"' --timeout=5m
+`
+
+var unitKind = entityKind{
+	name:         "unit",
+	args:         "<unit name>",
+	purpose:      "Wait for a unit to reach a specified state.",
+	doc:          unitDoc,
+	defaultQuery: `workload-status=="active" && agent-status=="idle"`,
+	identifiers: map[string]string{
+		"name":             "the unit name",
+		"application":      "the name of the unit's application",
+		"workload-status":  "the workload status",
+		"workload-message": "the workload status message",
+		"agent-status":     "the agent status",
+		"agent-message":    "the agent status message",
+		"machine":          "the id of the machine hosting the unit",
+		"public-address":   "the unit's public address",
+		"private-address":  "the unit's private address",
+		"subordinate":      "whether the unit is a subordinate",
+	},
+	validateArg: validatePattern,
+	entities: func(store *entityStore, pattern string) map[string]scope {
+		result := make(map[string]scope)
+		for name, unit := range store.units {
+			if !matches(pattern, name) {
+				continue
+			}
+			result[name] = scope{
+				"name":             unit.Name,
+				"application":      unit.Application,
+				"workload-status":  string(unit.WorkloadStatus.Current),
+				"workload-message": unit.WorkloadStatus.Message,
+				"agent-status":     string(unit.AgentStatus.Current),
+				"agent-message":    unit.AgentStatus.Message,
+				"machine":          unit.MachineId,
+				"public-address":   unit.PublicAddress,
+				"private-address":  unit.PrivateAddress,
+				"subordinate":      unit.Subordinate,
+			}
+		}
+		return result
+	},
+}
+
+// NewUnitCommand returns a command which waits for units to satisfy
+// a query.
+func NewUnitCommand() cmd.Command {
+	return newWaitForCommand(unitKind)
+}
@@ -0,0 +1,388 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/juju/waitfor/query"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+const waitForDoc = `
+The "wait-for" commands block until entities in the model satisfy a
+query, or until a timeout is reached. They are intended for use in
+scripts and CI pipelines which need to wait for a deployment to settle.
+
+Changes are received from the controller as they happen, so no polling
+of the model status is required.
+
+A query is an expression made up of the identifiers listed for each
+entity type, string ("active" or 'active'), integer and boolean
+literals, the comparison operators ==, !=, <, <=, > and >=, the logical
+operators &&, || and !, and parentheses.
+
+Each command exits with status 0 once the query is satisfied, and with
+a non-zero status on timeout, reporting the entities that did not
+satisfy the query.
+`
+
+const waitForPurpose = "Wait for an entity to reach a specified state."
+
+// NewWaitForCommand returns the "wait-for" super-command, with
+// subcommands for each kind of entity that can be waited for.
+func NewWaitForCommand() cmd.Command {
+	waitFor := cmd.NewSuperCommand(cmd.SuperCommandParams{
+		Name:        "wait-for",
+		Doc:         waitForDoc,
+		UsagePrefix: "juju",
+		Purpose:     waitForPurpose,
+	})
+	waitFor.Register(NewModelCommand())
+	waitFor.Register(NewApplicationCommand())
+	waitFor.Register(NewUnitCommand())
+	waitFor.Register(NewMachineCommand())
+	return waitFor
+}
+
+// AllWatcher is the subset of api.AllWatcher used by wait-for.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// WatchAllAPI defines the API methods that the wait-for commands use.
+type WatchAllAPI interface {
+	WatchAll() (AllWatcher, error)
+	Close() error
+}
+
+type watchAllClient struct {
+	*api.Client
+}
+
+// WatchAll is part of the WatchAllAPI interface.
+func (c watchAllClient) WatchAll() (AllWatcher, error) {
+	return c.Client.WatchAll()
+}
+
+// scope holds the values of the identifiers that may be used in a
+// query for a single entity.
+type scope map[string]interface{}
+
+// GetIdentValue is part of the query.Scope interface.
+func (s scope) GetIdentValue(name string) (interface{}, error) {
+	value, ok := s[name]
+	if !ok {
+		return nil, errors.NotFoundf("identifier %q", name)
+	}
+	return value, nil
+}
+
+// entityKind describes a kind of entity that can be waited for.
+type entityKind struct {
+	// name is the name of the entity kind, which is also the name
+	// of the subcommand.
+	name string
+
+	// args describes the positional argument, if any.
+	args string
+
+	purpose string
+	doc     string
+
+	// defaultQuery is used when no --query is supplied.
+	defaultQuery string
+
+	// identifiers holds the identifiers that may be used in queries
+	// for this kind of entity, with a description of each.
+	identifiers map[string]string
+
+	// validateArg validates the positional argument.
+	validateArg func(string) error
+
+	// entities returns the scopes for each of the entities matching
+	// the argument, keyed by entity name.
+	entities func(store *entityStore, arg string) map[string]scope
+}
+
+func (k entityKind) identifierHelp() string {
+	names := make([]string, 0, len(k.identifiers))
+	for name := range k.identifiers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var buf strings.Builder
+	buf.WriteString("\nThe following identifiers may be used in queries:\n\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "    %-18s %s\n", name, k.identifiers[name])
+	}
+	return buf.String()
+}
+
+func newWaitForCommand(kind entityKind) cmd.Command {
+	c := &waitForCommand{
+		kind:  kind,
+		clock: clock.WallClock,
+	}
+	c.newAPIFunc = func() (WatchAllAPI, error) {
+		client, err := c.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return watchAllClient{client}, nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+// waitForCommand waits for entities of a given kind to satisfy a
+// query.
+type waitForCommand struct {
+	modelcmd.ModelCommandBase
+
+	kind       entityKind
+	newAPIFunc func() (WatchAllAPI, error)
+	clock      clock.Clock
+
+	arg       string
+	queryText string
+	query     *query.Query
+	timeout   time.Duration
+	summary   bool
+}
+
+// Info implements Command.Info.
+func (c *waitForCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    c.kind.name,
+		Args:    c.kind.args,
+		Purpose: c.kind.purpose,
+		Doc:     c.kind.doc + c.kind.identifierHelp(),
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *waitForCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.queryText, "query", c.kind.defaultQuery, "Query the goal state of the "+c.kind.name)
+	f.DurationVar(&c.timeout, "timeout", 10*time.Minute, "How long to wait before timing out")
+	f.BoolVar(&c.summary, "summary", true, "Report a summary of the entities waited for")
+}
+
+// Init implements Command.Init.
+func (c *waitForCommand) Init(args []string) error {
+	if c.kind.args != "" {
+		if len(args) == 0 {
+			return errors.Errorf("no %s specified", c.kind.name)
+		}
+		if err := c.kind.validateArg(args[0]); err != nil {
+			return errors.Trace(err)
+		}
+		c.arg, args = args[0], args[1:]
+	}
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	if c.timeout <= 0 {
+		return errors.Errorf("timeout must be positive, got %v", c.timeout)
+	}
+	q, err := query.Parse(c.queryText)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range q.Identifiers() {
+		if _, ok := c.kind.identifiers[name]; !ok {
+			return errors.Errorf("%s queries do not support identifier %q", c.kind.name, name)
+		}
+	}
+	c.query = q
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *waitForCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer watcher.Stop()
+
+	done := make(chan struct{})
+	defer close(done)
+	deltasCh := make(chan []multiwatcher.Delta)
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			deltas, err := watcher.Next()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			select {
+			case deltasCh <- deltas:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	interrupted := make(chan os.Signal, 1)
+	ctx.InterruptNotify(interrupted)
+	defer ctx.StopInterruptNotify(interrupted)
+
+	store := newEntityStore()
+	timeout := c.clock.After(c.timeout)
+	var unsatisfied []string
+	for {
+		select {
+		case <-interrupted:
+			return errors.New("interrupted")
+		case err := <-errCh:
+			return errors.Annotate(err, "watching model")
+		case <-timeout:
+			return errors.Errorf(
+				"timed out after %v waiting for %s; unsatisfied: %s",
+				c.timeout, c.description(), strings.Join(unsatisfied, ", "),
+			)
+		case deltas := <-deltasCh:
+			store.apply(deltas)
+			var satisfied bool
+			satisfied, unsatisfied, err = c.evaluate(store)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if satisfied {
+				if c.summary {
+					ctx.Infof("%s satisfied", c.description())
+				}
+				return nil
+			}
+		}
+	}
+}
+
+func (c *waitForCommand) description() string {
+	if c.arg == "" {
+		return fmt.Sprintf("%s %q", c.kind.name, c.query)
+	}
+	return fmt.Sprintf("%s %q %q", c.kind.name, c.arg, c.query)
+}
+
+// evaluate runs the query against all of the matching entities. The
+// query is satisfied when there is at least one matching entity and
+// all matching entities satisfy it.
+func (c *waitForCommand) evaluate(store *entityStore) (bool, []string, error) {
+	entities := c.kind.entities(store, c.arg)
+	if len(entities) == 0 {
+		what := c.kind.name
+		if c.arg != "" {
+			what = fmt.Sprintf("%s %q", what, c.arg)
+		}
+		return false, []string{what + " not found"}, nil
+	}
+	var unsatisfied []string
+	for name, s := range entities {
+		ok, err := c.query.Run(s)
+		if err != nil {
+			return false, nil, errors.Annotatef(err, "evaluating query for %s", name)
+		}
+		if !ok {
+			unsatisfied = append(unsatisfied, name)
+		}
+	}
+	sort.Strings(unsatisfied)
+	return len(unsatisfied) == 0, unsatisfied, nil
+}
+
+// entityStore holds the current state of the model entities, as
+// reported by the AllWatcher.
+type entityStore struct {
+	model        *multiwatcher.ModelInfo
+	applications map[string]*multiwatcher.ApplicationInfo
+	units        map[string]*multiwatcher.UnitInfo
+	machines     map[string]*multiwatcher.MachineInfo
+}
+
+func newEntityStore() *entityStore {
+	return &entityStore{
+		applications: make(map[string]*multiwatcher.ApplicationInfo),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+		machines:     make(map[string]*multiwatcher.MachineInfo),
+	}
+}
+
+func (s *entityStore) apply(deltas []multiwatcher.Delta) {
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ModelInfo:
+			if delta.Removed {
+				s.model = nil
+			} else {
+				s.model = info
+			}
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(s.applications, info.Name)
+			} else {
+				s.applications[info.Name] = info
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(s.units, info.Name)
+			} else {
+				s.units[info.Name] = info
+			}
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(s.machines, info.Id)
+			} else {
+				s.machines[info.Id] = info
+			}
+		}
+	}
+}
+
+// unitCount returns the number of units of the application.
+func (s *entityStore) unitCount(application string) int {
+	count := 0
+	for _, unit := range s.units {
+		if unit.Application == application {
+			count++
+		}
+	}
+	return count
+}
+
+// matches reports whether the name matches the pattern, which may
+// contain shell-style wildcards.
+func matches(pattern, name string) bool {
+	ok, _ := path.Match(pattern, name)
+	return ok
+}
+
+// validatePattern returns an error if the pattern is malformed.
+func validatePattern(pattern string) error {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return errors.Errorf("invalid pattern %q", pattern)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+type WaitForSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	watcher *fakeAllWatcher
+	api     *fakeWatchAllAPI
+}
+
+var _ = gc.Suite(&WaitForSuite{})
+
+func (s *WaitForSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.watcher = &fakeAllWatcher{
+		blocked: make(chan struct{}),
+		stop:    make(chan struct{}),
+	}
+	s.api = &fakeWatchAllAPI{watcher: s.watcher}
+}
+
+func (s *WaitForSuite) run(c *gc.C, kind string, args ...string) error {
+	command := waitfor.NewWaitForCommandForTest(kind, s.api, s.clock, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, command, args...)
+	return err
+}
+
+func unitDelta(name string, workload, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    "mysql",
+		MachineId:      "0",
+		WorkloadStatus: multiwatcher.StatusInfo{Current: workload},
+		AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+	}}
+}
+
+func (s *WaitForSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		kind string
+		args []string
+		err  string
+	}{{
+		kind: "unit",
+		err:  "no unit specified",
+	}, {
+		kind: "unit",
+		args: []string{"mysql/0", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		kind: "unit",
+		args: []string{"mysql/["},
+		err:  `invalid pattern "mysql/\["`,
+	}, {
+		kind: "unit",
+		args: []string{"mysql/0", "--query", "exposed"},
+		err:  `unit queries do not support identifier "exposed"`,
+	}, {
+		kind: "application",
+		args: []string{"mysql", "--query", "status=="},
+		err:  `parsing query "status==": .*`,
+	}, {
+		kind: "model",
+		args: []string{"--timeout", "0s"},
+		err:  "timeout must be positive, got 0s",
+	}} {
+		c.Logf("test %d: %s %v", i, test.kind, test.args)
+		err := s.run(c, test.kind, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WaitForSuite) TestUnitSatisfied(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{
+		{unitDelta("mysql/0", status.Maintenance, status.Executing)},
+		{unitDelta("mysql/0", status.Active, status.Idle)},
+	}
+	err := s.run(c, "unit", "mysql/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.watcher.deltas, gc.HasLen, 0)
+	c.Assert(s.watcher.stopped, jc.IsTrue)
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *WaitForSuite) TestPatternRequiresAllMatches(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{
+		{
+			unitDelta("mysql/0", status.Active, status.Idle),
+			unitDelta("mysql/1", status.Blocked, status.Idle),
+		},
+		{unitDelta("mysql/1", status.Active, status.Idle)},
+	}
+	err := s.run(c, "unit", "mysql/*", "--query", `workload-status=="active"`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.watcher.deltas, gc.HasLen, 0)
+}
+
+func (s *WaitForSuite) TestApplicationQuery(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{{
+		{Entity: &multiwatcher.ApplicationInfo{Name: "mysql", Exposed: true}},
+		unitDelta("mysql/0", status.Active, status.Idle),
+		unitDelta("mysql/1", status.Active, status.Idle),
+	}}
+	err := s.run(c, "application", "mysql", "--query", "exposed && unit-count >= 2")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WaitForSuite) TestTimeout(c *gc.C) {
+	s.watcher.deltas = [][]multiwatcher.Delta{
+		{unitDelta("mysql/0", status.Blocked, status.Idle)},
+	}
+	go func() {
+		<-s.watcher.blocked
+		c.Check(s.clock.WaitAdvance(time.Minute, testing.LongWait, 1), jc.ErrorIsNil)
+	}()
+	err := s.run(c, "unit", "mysql/*", "--timeout", "1m")
+	c.Assert(err, gc.ErrorMatches,
+		`timed out after 1m0s waiting for unit "mysql/\*" ".*"; unsatisfied: mysql/0`)
+}
+
+func (s *WaitForSuite) TestWatcherError(c *gc.C) {
+	s.watcher.err = errors.New("boom")
+	err := s.run(c, "machine", "0")
+	c.Assert(err, gc.ErrorMatches, "watching model: boom")
+}
+
+type fakeWatchAllAPI struct {
+	watcher *fakeAllWatcher
+	closed  bool
+}
+
+func (a *fakeWatchAllAPI) WatchAll() (waitfor.AllWatcher, error) {
+	return a.watcher, nil
+}
+
+func (a *fakeWatchAllAPI) Close() error {
+	a.closed = true
+	return nil
+}
+
+// fakeAllWatcher returns each set of deltas in turn, and then the
+// error if set. Otherwise it blocks until stopped, closing blocked
+// to signal that it has run out of deltas.
+type fakeAllWatcher struct {
+	deltas  [][]multiwatcher.Delta
+	err     error
+	blocked chan struct{}
+	stop    chan struct{}
+	stopped bool
+}
+
+func (w *fakeAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	if len(w.deltas) > 0 {
+		next := w.deltas[0]
+		w.deltas = w.deltas[1:]
+		return next, nil
+	}
+	if w.err != nil {
+		return nil, w.err
+	}
+	close(w.blocked)
+	<-w.stop
+	return nil, errors.New("watcher stopped")
+}
+
+func (w *fakeAllWatcher) Stop() error {
+	w.stopped = true
+	close(w.stop)
+	return nil
+}
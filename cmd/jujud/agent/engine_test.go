@@ -112,6 +112,7 @@ var (
 		"metric-sender",
 		"metric-spool",
 		"proxy-config-updater",
+		"remote-introspection",
 		"uniter",
 	}
 
@@ -143,6 +144,7 @@ var (
 		"machiner",
 		"proxy-config-updater",
 		"reboot-executor",
+		"remote-introspection",
 		"ssh-authkeys-updater",
 		"storage-provisioner",
 		"unconverted-api-workers",
@@ -35,6 +35,7 @@ type introspectionConfig struct {
 	Engine             *dependency.Engine
 	StatePoolReporter  introspection.IntrospectionReporter
 	PubSubReporter     introspection.IntrospectionReporter
+	LeaseReporter      introspection.LeaseReporter
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	PresenceRecorder   presence.Recorder
@@ -60,6 +61,7 @@ func startIntrospection(cfg introspectionConfig) error {
 		DepEngine:          cfg.Engine,
 		StatePool:          cfg.StatePoolReporter,
 		PubSub:             cfg.PubSubReporter,
+		Leases:             cfg.LeaseReporter,
 		MachineLock:        cfg.MachineLock,
 		PrometheusGatherer: cfg.PrometheusGatherer,
 		Presence:           cfg.PresenceRecorder,
@@ -115,3 +117,13 @@ func (h *statePoolIntrospectionReporter) IntrospectionReport() string {
 	}
 	return h.pool.IntrospectionReport()
 }
+
+// LeaseReport is part of the introspection.LeaseReporter interface.
+func (h *statePoolIntrospectionReporter) LeaseReport() (map[string]interface{}, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.pool == nil {
+		return nil, errors.NewNotFound(nil, "agent has no pool set")
+	}
+	return h.pool.LeaseReport(), nil
+}
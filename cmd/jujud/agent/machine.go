@@ -51,8 +51,10 @@ import (
 	cmdutil "github.com/juju/juju/cmd/jujud/util"
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/kvm"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
@@ -528,6 +530,8 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 				DependencyEngine:   engine,
 				StatePool:          &statePoolReporter,
 				PubSub:             pubsubReporter,
+				Leases:             &statePoolReporter,
+				MachineLock:        a.machineLock,
 				PrometheusGatherer: a.prometheusRegistry,
 			}, handle)
 		}
@@ -549,6 +553,18 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			return environs.SupportsSpaces(state.CallContext(st), env), nil
 		}
 
+		// The lease FSM and store are created outside the engine,
+		// since the state opened by the engine's state worker uses
+		// the store for its leadership and singular leases.
+		leaseFSM := raftlease.NewFSM()
+		leaseStore, err := newLeaseStore(leaseFSM, a.centralHub)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		openState := func(agentConfig agent.Config) (*state.State, error) {
+			return a.initState(agentConfig, leaseStore)
+		}
+
 		manifolds := machineManifolds(machine.ManifoldsConfig{
 			PreviousAgentVersion: previousAgentVersion,
 			Agent:                agent.APIHostPortsSetter{Agent: a},
@@ -557,7 +573,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			UpgradeStepsLock:     a.upgradeComplete,
 			UpgradeCheckLock:     a.initialUpgradeCheckComplete,
 			OpenController:       a.initController,
-			OpenState:            a.initState,
+			OpenState:            openState,
 			OpenStateForUpgrade:  a.openStateForUpgrade,
 			StartAPIWorkers:      a.startAPIWorkers,
 			PreUpgradeSteps:      a.preUpgradeSteps,
@@ -581,6 +597,8 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
 			NewModelWorker:                    a.startModelWorkers,
 			ControllerSupportsSpaces:          controllerSupportsSpaces,
+			LeaseFSM:                          leaseFSM,
+			LeaseStore:                        leaseStore,
 		})
 		if err := dependency.Install(engine, manifolds); err != nil {
 			if err := worker.Stop(engine); err != nil {
@@ -593,6 +611,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			Engine:             engine,
 			StatePoolReporter:  &statePoolReporter,
 			PubSubReporter:     pubsubReporter,
+			LeaseReporter:      &statePoolReporter,
 			MachineLock:        a.machineLock,
 			NewSocketName:      a.newIntrospectionSocketName,
 			PrometheusGatherer: a.prometheusRegistry,
@@ -954,7 +973,36 @@ func (a *MachineAgent) initController(agentConfig agent.Config) (*state.Controll
 	return ctlr, nil
 }
 
-func (a *MachineAgent) initState(agentConfig agent.Config) (*state.State, error) {
+// leaseForwardTimeout is how long the raft lease store waits for a
+// command to be applied by the raft leader before giving up.
+const leaseForwardTimeout = 5 * time.Second
+
+// newLeaseStore returns a raft lease store that reads leases from the
+// given FSM, and sends commands over the hub to be applied by the raft
+// forwarder on the raft leader.
+func newLeaseStore(fsm *raftlease.FSM, hub *pubsub.StructuredHub) (*raftlease.Store, error) {
+	client, err := raftlease.NewPubsubClient(raftlease.PubsubClientConfig{
+		Hub:            hub,
+		RequestTopic:   machine.LeaseRequestTopic,
+		Clock:          clock.WallClock,
+		ForwardTimeout: leaseForwardTimeout,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	store, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:      fsm,
+		Client:   client,
+		Trapdoor: state.LeaseTrapdoorFunc(),
+		Clock:    clock.WallClock,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return store, nil
+}
+
+func (a *MachineAgent) initState(agentConfig agent.Config, leaseStore lease.Store) (*state.State, error) {
 	// Start MongoDB server and dial.
 	if err := a.ensureMongoServer(agentConfig); err != nil {
 		return nil, err
@@ -972,6 +1020,7 @@ func (a *MachineAgent) initState(agentConfig agent.Config) (*state.State, error)
 		agentConfig,
 		dialOpts,
 		a.mongoTxnCollector.AfterRunTransaction,
+		leaseStore,
 	)
 	if err != nil {
 		return nil, err
@@ -1054,6 +1103,13 @@ func (a *MachineAgent) ensureMongoServer(agentConfig agent.Config) (err error) {
 		}
 	}()
 
+	if agentConfig.Value(agent.MongoSidecar) == "true" {
+		// The container running mongod is managed by the
+		// controller's pod, not the agent.
+		logger.Debugf("mongo runs in a sidecar container, not managing it")
+		return nil
+	}
+
 	// EnsureMongoServer installs/upgrades the init config as necessary.
 	ensureServerParams, err := cmdutil.NewEnsureServerParams(agentConfig)
 	if err != nil {
@@ -1080,6 +1136,7 @@ func openState(
 	agentConfig agent.Config,
 	dialOpts mongo.DialOpts,
 	runTransactionObserver state.RunTransactionObserverFunc,
+	leaseStore lease.Store,
 ) (_ *state.State, _ *state.Machine, err error) {
 	info, ok := agentConfig.MongoInfo()
 	if !ok {
@@ -1098,6 +1155,7 @@ func openState(
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
 		RunTransactionObserver: runTransactionObserver,
+		LeaseStore:             leaseStore,
 	})
 	if err != nil {
 		return nil, nil, err
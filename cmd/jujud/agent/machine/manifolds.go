@@ -28,8 +28,10 @@ import (
 	apideployer "github.com/juju/juju/api/deployer"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/core/globalclock"
 	"github.com/juju/juju/core/machinelock"
 	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/state"
 	proxyconfig "github.com/juju/juju/utils/proxy"
@@ -42,6 +44,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/common"
@@ -58,6 +61,8 @@ import (
 	"github.com/juju/juju/worker/hostkeyreporter"
 	"github.com/juju/juju/worker/httpserver"
 	"github.com/juju/juju/worker/identityfilewriter"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/logforwarder/sinks"
 	"github.com/juju/juju/worker/logger"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/machineactions"
@@ -73,8 +78,10 @@ import (
 	"github.com/juju/juju/worker/raft/raftbackstop"
 	"github.com/juju/juju/worker/raft/raftclusterer"
 	"github.com/juju/juju/worker/raft/raftflag"
+	"github.com/juju/juju/worker/raft/raftforwarder"
 	"github.com/juju/juju/worker/raft/rafttransport"
 	"github.com/juju/juju/worker/reboot"
+	"github.com/juju/juju/worker/remoteintrospection"
 	"github.com/juju/juju/worker/restorewatcher"
 	"github.com/juju/juju/worker/resumer"
 	"github.com/juju/juju/worker/singular"
@@ -97,6 +104,10 @@ const (
 	// globalClockUpdaterBackoffDelay is the amount of time to
 	// delay when a concurrent global clock update is detected.
 	globalClockUpdaterBackoffDelay = 10 * time.Second
+
+	// LeaseRequestTopic is the pubsub topic that lease FSM updates
+	// will be published on.
+	LeaseRequestTopic = "lease.request"
 )
 
 // ManifoldsConfig allows specialisation of the result of Manifolds.
@@ -227,6 +238,16 @@ type ManifoldsConfig struct {
 	// This is used by a number of workers to ensure serialisation of actions
 	// across the machine.
 	MachineLock machinelock.Lock
+
+	// LeaseFSM is the raft FSM holding the controller's leases. The
+	// state's lease store reads from it as well as the raft worker
+	// applying commands to it, so it must outlive any raft worker.
+	LeaseFSM *raftlease.FSM
+
+	// LeaseStore is the raft lease store backed by LeaseFSM. The lease
+	// clock updater advances its global time, which is what allows
+	// leases held in it to expire.
+	LeaseStore globalclock.Updater
 }
 
 // Manifolds returns a set of co-configured manifolds covering the
@@ -271,6 +292,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 
 	agentConfig := config.Agent.CurrentConfig()
 	machineTag := agentConfig.Tag().(names.MachineTag)
+
 	controllerTag := agentConfig.Controller()
 
 	manifolds := dependency.Manifolds{
@@ -649,6 +671,17 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     machineactions.NewMachineActionsWorker,
 		})),
 
+		// The remote introspection worker runs the agent's
+		// introspection handlers on behalf of controller
+		// administrators, using the Introspection facade.
+		remoteIntrospectionName: ifNotMigrating(remoteintrospection.Manifold(remoteintrospection.ManifoldConfig{
+			AgentName:                         agentName,
+			APICallerName:                     apiCallerName,
+			RegisterIntrospectionHTTPHandlers: config.RegisterIntrospectionHTTPHandlers,
+			NewFacade:                         remoteintrospection.NewFacade,
+			NewWorker:                         remoteintrospection.NewWorker,
+		})),
+
 		hostKeyReporterName: ifNotMigrating(hostkeyreporter.Manifold(hostkeyreporter.ManifoldConfig{
 			AgentName:     agentName,
 			APICallerName: apiCallerName,
@@ -682,6 +715,15 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName: agentName,
+				ClockName: clockName,
+				StateName: stateName,
+				NewWorker: backupscheduler.NewWorker,
+			},
+		))),
+
 		httpServerName: httpserver.Manifold(httpserver.ManifoldConfig{
 			AgentName:             agentName,
 			CertWatcherName:       certificateWatcherName,
@@ -742,6 +784,23 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker: auditconfigupdater.New,
 		})),
 
+		// The audit log forwarder sends the audit records written by
+		// this controller's API server to the log forwarding target
+		// configured for the controller model.
+		auditLogForwarderName: ifController(logforwarder.AuditManifold(logforwarder.AuditManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Hub:           config.CentralHub,
+			Sinks: []logforwarder.LogSinkSpec{{
+				Name:   "juju-audit-forward",
+				OpenFn: sinks.OpenSyslog,
+			}, {
+				Name:       "juju-audit-forward-http",
+				ReadConfig: sinks.ReadHTTPConfig,
+				OpenFn:     sinks.OpenHTTP,
+			}},
+		})),
+
 		raftEnabledName: ifController(featureflag.Manifold(featureflag.ManifoldConfig{
 			StateName: stateName,
 			FlagName:  feature.DisableRaft,
@@ -767,7 +826,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			ClockName:     clockName,
 			AgentName:     agentName,
 			TransportName: raftTransportName,
-			FSM:           &raft.SimpleFSM{},
+			FSM:           config.LeaseFSM,
 			Logger:        loggo.GetLogger("juju.worker.raft"),
 			NewWorker:     raft.NewWorker,
 		}),
@@ -786,6 +845,29 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:      raftclusterer.NewWorker,
 		})),
 
+		// The raft forwarder accepts FSM commands from the hub and
+		// applies them to the raft leader.
+		raftForwarderName: ifRaftLeader(raftforwarder.Manifold(raftforwarder.ManifoldConfig{
+			RaftName:       raftName,
+			CentralHubName: centralHubName,
+			RequestTopic:   LeaseRequestTopic,
+			Logger:         loggo.GetLogger("juju.worker.raft.raftforwarder"),
+			NewWorker:      raftforwarder.NewWorker,
+		})),
+
+		// The lease clock updater advances the global time of the
+		// raft lease FSM, which is what allows leases to expire. It
+		// can run on any controller, as the updates are forwarded to
+		// the raft leader.
+		leaseClockUpdaterName: globalclockupdater.Manifold(globalclockupdater.ManifoldConfig{
+			ClockName:      clockName,
+			RaftName:       raftName,
+			Updater:        config.LeaseStore,
+			NewWorker:      globalclockupdater.NewWorker,
+			UpdateInterval: globalClockUpdaterUpdateInterval,
+			BackoffDelay:   globalClockUpdaterBackoffDelay,
+		}),
+
 		raftBackstopName: raftbackstop.Manifold(raftbackstop.ManifoldConfig{
 			RaftName:       raftName,
 			CentralHubName: centralHubName,
@@ -905,6 +987,7 @@ const (
 	identityFileWriterName        = "ssh-identity-writer"
 	toolsVersionCheckerName       = "tools-version-checker"
 	machineActionName             = "machine-action-runner"
+	remoteIntrospectionName       = "remote-introspection"
 	hostKeyReporterName           = "host-key-reporter"
 	fanConfigurerName             = "fan-configurer"
 	externalControllerUpdaterName = "external-controller-updater"
@@ -913,12 +996,14 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	logPrunerName                 = "log-pruner"
 	txnPrunerName                 = "transaction-pruner"
+	backupSchedulerName           = "backup-scheduler"
 	certificateWatcherName        = "certificate-watcher"
 	modelWorkerManagerName        = "model-worker-manager"
 	peergrouperName               = "peer-grouper"
 	restoreWatcherName            = "restore-watcher"
 	certificateUpdaterName        = "certificate-updater"
 	auditConfigUpdaterName        = "audit-config-updater"
+	auditLogForwarderName         = "audit-log-forwarder"
 
 	upgradeSeriesEnabledName = "upgrade-series-enabled"
 	upgradeSeriesWorkerName  = "upgrade-series"
@@ -932,6 +1017,9 @@ const (
 	raftFlagName      = "raft-leader-flag"
 	raftEnabledName   = "raft-enabled-flag"
 	raftBackstopName  = "raft-backstop"
+	raftForwarderName = "raft-forwarder"
+
+	leaseClockUpdaterName = "lease-clock-updater"
 
 	validCredentialFlagName = "valid-credential-flag"
 )
@@ -56,6 +56,8 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"api-config-watcher",
 		"api-server",
 		"audit-config-updater",
+		"audit-log-forwarder",
+		"backup-scheduler",
 		"central-hub",
 		"certificate-updater",
 		"certificate-watcher",
@@ -68,6 +70,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"http-server",
 		"is-controller-flag",
 		"is-primary-controller-flag",
+		"lease-clock-updater",
 		"log-pruner",
 		"log-sender",
 		"logging-config-updater",
@@ -86,9 +89,11 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"raft-backstop",
 		"raft-clusterer",
 		"raft-enabled-flag",
+		"raft-forwarder",
 		"raft-leader-flag",
 		"raft-transport",
 		"reboot-executor",
+		"remote-introspection",
 		"restore-watcher",
 		"serving-info-setter",
 		"ssh-authkeys-updater",
@@ -131,6 +136,7 @@ func (*ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"api-config-watcher",
 		"api-server",
 		"audit-config-updater",
+		"audit-log-forwarder",
 		"certificate-updater",
 		"certificate-watcher",
 		"central-hub",
@@ -139,6 +145,7 @@ func (*ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"http-server",
 		"is-controller-flag",
 		"is-primary-controller-flag",
+		"lease-clock-updater",
 		"log-forwarder",
 		"model-worker-manager",
 		"peer-grouper",
@@ -163,6 +170,7 @@ func (*ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"raft-backstop",
 		"raft-clusterer",
 		"raft-enabled-flag",
+		"raft-forwarder",
 		"raft-leader-flag",
 		"raft-transport",
 		"valid-credential-flag",
@@ -186,10 +194,12 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 	controllerWorkers := set.NewStrings(
 		"certificate-watcher",
 		"audit-config-updater",
+		"audit-log-forwarder",
 		"is-primary-controller-flag",
 		"raft-enabled-flag",
 	)
 	primaryControllerWorkers := set.NewStrings(
+		"backup-scheduler",
 		"external-controller-updater",
 		"log-pruner",
 		"transaction-pruner",
@@ -310,6 +320,30 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state",
 		"state-config-watcher"},
 
+	"audit-log-forwarder": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"is-controller-flag",
+		"state",
+		"state-config-watcher"},
+
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"central-hub": {"agent", "state-config-watcher"},
 
 	"certificate-updater": {
@@ -452,6 +486,17 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-flag",
 		"upgrade-steps-gate"},
 
+	"remote-introspection": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"machiner": {
 		"agent",
 		"api-caller",
@@ -533,6 +578,24 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"central-hub",
 		"state-config-watcher"},
 
+	"lease-clock-updater": {
+		"agent",
+		"central-hub",
+		"certificate-watcher",
+		"clock",
+		"http-server",
+		"is-controller-flag",
+		"raft",
+		"raft-enabled-flag",
+		"raft-transport",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"raft": {
 		"agent",
 		"central-hub",
@@ -593,6 +656,25 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state",
 		"state-config-watcher"},
 
+	"raft-forwarder": {
+		"agent",
+		"central-hub",
+		"certificate-watcher",
+		"clock",
+		"http-server",
+		"is-controller-flag",
+		"raft",
+		"raft-enabled-flag",
+		"raft-leader-flag",
+		"raft-transport",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"raft-leader-flag": {
 		"agent",
 		"central-hub",
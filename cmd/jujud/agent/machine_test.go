@@ -1234,6 +1234,22 @@ func (s *MachineSuite) TestReplicasetInitForNewController(c *gc.C) {
 	c.Assert(s.fakeEnsureMongo.InitiateCount, gc.Equals, 0)
 }
 
+func (s *MachineSuite) TestMongoSidecarNotManaged(c *gc.C) {
+	m, _, _ := s.primeAgent(c, state.JobManageModel)
+	a := s.newAgent(c, m)
+	err := a.ChangeConfig(func(config agent.ConfigSetter) error {
+		config.SetValue(agent.MongoSidecar, "true")
+		return nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = a.ensureMongoServer(a.CurrentConfig())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fakeEnsureMongo.EnsureCount, gc.Equals, 0)
+	c.Assert(s.fakeEnsureMongo.InitiateCount, gc.Equals, 0)
+}
+
 type nullWorker struct {
 	dead chan struct{}
 }
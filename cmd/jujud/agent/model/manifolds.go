@@ -272,6 +272,10 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Sinks: []logforwarder.LogSinkSpec{{
 				Name:   "juju-log-forward",
 				OpenFn: sinks.OpenSyslog,
+			}, {
+				Name:       "juju-log-forward-http",
+				ReadConfig: sinks.ReadHTTPConfig,
+				OpenFn:     sinks.OpenHTTP,
 			}},
 		})),
 		// The model upgrader runs on all controller agents, and
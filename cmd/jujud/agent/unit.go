@@ -4,6 +4,7 @@
 package agent
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/juju/clock"
@@ -192,21 +193,6 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		return nil, errors.Trace(err)
 	}
 
-	manifolds := unitManifolds(unit.ManifoldsConfig{
-		Agent:                agent.APIHostPortsSetter{a},
-		LogSource:            a.bufferedLogger.Logs(),
-		LeadershipGuarantee:  30 * time.Second,
-		AgentConfigChanged:   a.configChangedVal,
-		ValidateMigration:    a.validateMigration,
-		PrometheusRegisterer: a.prometheusRegistry,
-		UpdateLoggerConfig:   updateAgentConfLogging,
-		PreviousAgentVersion: agentConfig.UpgradedToVersion(),
-		PreUpgradeSteps:      a.preUpgradeSteps,
-		UpgradeStepsLock:     a.upgradeComplete,
-		UpgradeCheckLock:     a.initialUpgradeCheckComplete,
-		MachineLock:          machineLock,
-	})
-
 	config := dependency.EngineConfig{
 		IsFatal:     cmdutil.IsFatal,
 		WorstError:  cmdutil.MoreImportantError,
@@ -219,6 +205,30 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 	if err != nil {
 		return nil, err
 	}
+	registerIntrospectionHandlers := func(handle func(path string, h http.Handler)) {
+		introspection.RegisterHTTPHandlers(introspection.ReportSources{
+			DependencyEngine:   engine,
+			MachineLock:        machineLock,
+			PrometheusGatherer: a.prometheusRegistry,
+		}, handle)
+	}
+
+	manifolds := unitManifolds(unit.ManifoldsConfig{
+		Agent:                             agent.APIHostPortsSetter{a},
+		LogSource:                         a.bufferedLogger.Logs(),
+		LeadershipGuarantee:               30 * time.Second,
+		AgentConfigChanged:                a.configChangedVal,
+		ValidateMigration:                 a.validateMigration,
+		PrometheusRegisterer:              a.prometheusRegistry,
+		UpdateLoggerConfig:                updateAgentConfLogging,
+		PreviousAgentVersion:              agentConfig.UpgradedToVersion(),
+		PreUpgradeSteps:                   a.preUpgradeSteps,
+		UpgradeStepsLock:                  a.upgradeComplete,
+		UpgradeCheckLock:                  a.initialUpgradeCheckComplete,
+		MachineLock:                       machineLock,
+		RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
+	})
+
 	if err := dependency.Install(engine, manifolds); err != nil {
 		if err := worker.Stop(engine); err != nil {
 			logger.Errorf("while stopping engine with bad manifolds: %v", err)
@@ -4,6 +4,7 @@
 package unit
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/juju/clock"
@@ -40,6 +41,7 @@ import (
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationminion"
 	"github.com/juju/juju/worker/proxyupdater"
+	"github.com/juju/juju/worker/remoteintrospection"
 	"github.com/juju/juju/worker/retrystrategy"
 	"github.com/juju/juju/worker/uniter"
 	"github.com/juju/juju/worker/upgrader"
@@ -99,6 +101,12 @@ type ManifoldsConfig struct {
 	// This is used by a number of workers to ensure serialisation of actions
 	// across the machine.
 	MachineLock machinelock.Lock
+
+	// RegisterIntrospectionHTTPHandlers is a function that calls the
+	// supplied function to register introspection HTTP handlers. These
+	// are run on behalf of controller administrators by the remote
+	// introspection worker.
+	RegisterIntrospectionHTTPHandlers func(func(path string, _ http.Handler))
 }
 
 // Manifolds returns a set of co-configured manifolds covering the various
@@ -344,6 +352,17 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName:   apiCallerName,
 			MetricSpoolName: metricSpoolName,
 		})),
+
+		// The remote introspection worker runs the agent's
+		// introspection handlers on behalf of controller
+		// administrators, using the Introspection facade.
+		remoteIntrospectionName: ifNotMigrating(remoteintrospection.Manifold(remoteintrospection.ManifoldConfig{
+			AgentName:                         agentName,
+			APICallerName:                     apiCallerName,
+			RegisterIntrospectionHTTPHandlers: config.RegisterIntrospectionHTTPHandlers,
+			NewFacade:                         remoteintrospection.NewFacade,
+			NewWorker:                         remoteintrospection.NewWorker,
+		})),
 	}
 }
 
@@ -391,6 +410,8 @@ const (
 	meterStatusName   = "meter-status"
 	metricCollectName = "metric-collect"
 	metricSenderName  = "metric-sender"
+
+	remoteIntrospectionName = "remote-introspection"
 )
 
 type noopStatusSetter struct{}
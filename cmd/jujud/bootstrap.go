@@ -372,14 +372,18 @@ func (c *BootstrapCommand) startMongo(addrs []network.Address, agentConfig agent
 		net.JoinHostPort("localhost", fmt.Sprint(servingInfo.StatePort)),
 	}
 
-	logger.Debugf("calling ensureMongoServer")
-	ensureServerParams, err := cmdutil.NewEnsureServerParams(agentConfig)
-	if err != nil {
-		return err
-	}
-	_, err = cmdutil.EnsureMongoServer(ensureServerParams)
-	if err != nil {
-		return err
+	if agentConfig.Value(agent.MongoSidecar) == "true" {
+		logger.Debugf("mongo runs in a sidecar container, not installing it")
+	} else {
+		logger.Debugf("calling ensureMongoServer")
+		ensureServerParams, err := cmdutil.NewEnsureServerParams(agentConfig)
+		if err != nil {
+			return err
+		}
+		_, err = cmdutil.EnsureMongoServer(ensureServerParams)
+		if err != nil {
+			return err
+		}
 	}
 
 	peerAddr := mongo.SelectPeerAddress(addrs)
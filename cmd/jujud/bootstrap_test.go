@@ -76,6 +76,7 @@ type BootstrapSuite struct {
 	dataDir         string
 	logDir          string
 	mongoOplogSize  string
+	mongoSidecar    string
 	fakeEnsureMongo *agenttest.FakeEnsureMongo
 	bootstrapName   string
 	hostedModelUUID string
@@ -117,6 +118,7 @@ func (s *BootstrapSuite) SetUpTest(c *gc.C) {
 	s.logDir = c.MkDir()
 	s.bootstrapParamsFile = filepath.Join(s.dataDir, "bootstrap-params")
 	s.mongoOplogSize = "1234"
+	s.mongoSidecar = ""
 	s.fakeEnsureMongo = agenttest.InstallFakeEnsureMongo(s)
 	s.PatchValue(&initiateMongoServer, s.fakeEnsureMongo.InitiateMongo)
 	s.makeTestModel(c)
@@ -309,6 +311,7 @@ func (s *BootstrapSuite) initBootstrapCommand(c *gc.C, jobs []multiwatcher.Machi
 		Values: map[string]string{
 			agent.Namespace:      "foobar",
 			agent.MongoOplogSize: s.mongoOplogSize,
+			agent.MongoSidecar:   s.mongoSidecar,
 		},
 	}
 	servingInfo := params.StateServingInfo{
@@ -332,6 +335,19 @@ func (s *BootstrapSuite) initBootstrapCommand(c *gc.C, jobs []multiwatcher.Machi
 	return machineConf, cmd, err
 }
 
+func (s *BootstrapSuite) TestInitializeEnvironmentMongoSidecar(c *gc.C) {
+	s.mongoSidecar = "true"
+	_, cmd, err := s.initBootstrapCommand(c, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmd.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// mongod is left to its own container, but the replica set is
+	// still initiated.
+	c.Assert(s.fakeEnsureMongo.EnsureCount, gc.Equals, 0)
+	c.Assert(s.fakeEnsureMongo.InitiateCount, gc.Equals, 1)
+}
+
 func (s *BootstrapSuite) TestInitializeEnvironment(c *gc.C) {
 	machConf, cmd, err := s.initBootstrapCommand(c, nil)
 	c.Assert(err, jc.ErrorIsNil)
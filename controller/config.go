@@ -4,6 +4,7 @@
 package controller
 
 import (
+	"encoding/hex"
 	"fmt"
 	"net/url"
 	"regexp"
@@ -20,6 +21,7 @@ import (
 	"gopkg.in/macaroon-bakery.v2-unstable/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/cron"
 	"github.com/juju/juju/core/resources"
 )
 
@@ -118,6 +120,51 @@ const (
 	// MaxTxnLogSize is the maximum size the of capped txn log collection, eg "10M"
 	MaxTxnLogSize = "max-txn-log-size"
 
+	// BackupSchedule is a cron-like schedule on which the controller
+	// creates backups of itself, eg "0 2 * * *" or "@daily". No
+	// scheduled backups are made if it is not set.
+	BackupSchedule = "backup-schedule"
+
+	// BackupMaxCount is the number of scheduled backups to keep, or 0
+	// to keep any number.
+	BackupMaxCount = "backup-max-count"
+
+	// BackupMaxAge is the maximum age of scheduled backups before they
+	// are removed, eg "720h", or 0 to keep them indefinitely.
+	BackupMaxAge = "backup-max-age"
+
+	// BackupStorage is where the controller stores backup archives:
+	// either "mongo" (the default) to keep them in the controller's
+	// own database, or "s3" to upload them to an S3-compatible object
+	// store.
+	BackupStorage = "backup-storage"
+
+	// BackupS3Endpoint is the URL of the S3-compatible object store
+	// used when backup-storage is "s3", eg "https://s3.example.com".
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region of the object store used when
+	// backup-storage is "s3".
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3Bucket is the bucket backups are stored in when
+	// backup-storage is "s3".
+	BackupS3Bucket = "backup-s3-bucket"
+
+	// BackupS3AccessKey is the access key used to authenticate with
+	// the object store when backup-storage is "s3".
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to authenticate with
+	// the object store when backup-storage is "s3".
+	BackupS3SecretKey = "backup-s3-secret-key"
+
+	// BackupEncryptionKey is a hex-encoded 256 bit key used to encrypt
+	// backups before they are uploaded to an object store. Backups are
+	// uploaded unencrypted if it is not set. While it is set, backups
+	// that were uploaded unencrypted can't be read.
+	BackupEncryptionKey = "backup-encryption-key"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -136,6 +183,10 @@ const (
 	// keep.
 	DefaultAuditLogMaxBackups = 10
 
+	// DefaultBackupS3Region is the region used for backup storage in
+	// an object store if none is specified.
+	DefaultBackupS3Region = "us-east-1"
+
 	// DefaultNUMAControlPolicy should not be used by default.
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
@@ -159,6 +210,14 @@ const (
 	// DefaultMaxTxnLogCollectionMB is the maximum size the txn log collection.
 	DefaultMaxTxnLogCollectionMB = 10 // 10 MB
 
+	// BackupStorageMongo is the backup-storage value that keeps
+	// backups in the controller's database.
+	BackupStorageMongo = "mongo"
+
+	// BackupStorageS3 is the backup-storage value that keeps backups
+	// in an S3-compatible object store.
+	BackupStorageS3 = "s3"
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -197,6 +256,16 @@ var (
 		MaxLogsSize,
 		MaxLogsAge,
 		MaxTxnLogSize,
+		BackupSchedule,
+		BackupMaxCount,
+		BackupMaxAge,
+		BackupStorage,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+		BackupEncryptionKey,
 		JujuHASpace,
 		JujuManagementSpace,
 		AuditingEnabled,
@@ -216,12 +285,29 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		BackupSchedule,
+		BackupMaxCount,
+		BackupMaxAge,
+		BackupStorage,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+		BackupEncryptionKey,
 		JujuHASpace,
 		JujuManagementSpace,
 		CAASOperatorImagePath,
 		Features,
 	)
 
+	// SecretConfigAttributes are controller config attributes that
+	// hold credentials, and so are never handed out over the API.
+	SecretConfigAttributes = set.NewStrings(
+		BackupS3SecretKey,
+		BackupEncryptionKey,
+	)
+
 	// DefaultAuditLogExcludeMethods is the default list of methods to
 	// exclude from the audit log.
 	DefaultAuditLogExcludeMethods = []string{
@@ -485,6 +571,79 @@ func (c Config) MaxTxnLogSizeMB() int {
 	return int(val)
 }
 
+// BackupSchedule returns the schedule on which the controller creates
+// backups of itself, or "" if scheduled backups are disabled.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupMaxCount returns the number of scheduled backups to keep,
+// with 0 meaning no limit.
+func (c Config) BackupMaxCount() int {
+	value, _ := c[BackupMaxCount].(int)
+	return value
+}
+
+// BackupMaxAge returns the maximum age of scheduled backups before
+// they are removed, with 0 meaning no limit.
+func (c Config) BackupMaxAge() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(BackupMaxAge))
+	return val
+}
+
+// BackupStorage returns where the controller stores backup archives,
+// either BackupStorageMongo or BackupStorageS3.
+func (c Config) BackupStorage() string {
+	if value := c.asString(BackupStorage); value != "" {
+		return value
+	}
+	return BackupStorageMongo
+}
+
+// BackupS3Endpoint returns the URL of the object store backups are
+// stored in.
+func (c Config) BackupS3Endpoint() string {
+	return c.asString(BackupS3Endpoint)
+}
+
+// BackupS3Region returns the region of the object store backups are
+// stored in.
+func (c Config) BackupS3Region() string {
+	if value := c.asString(BackupS3Region); value != "" {
+		return value
+	}
+	return DefaultBackupS3Region
+}
+
+// BackupS3Bucket returns the bucket backups are stored in.
+func (c Config) BackupS3Bucket() string {
+	return c.asString(BackupS3Bucket)
+}
+
+// BackupS3AccessKey returns the access key for the object store
+// backups are stored in.
+func (c Config) BackupS3AccessKey() string {
+	return c.asString(BackupS3AccessKey)
+}
+
+// BackupS3SecretKey returns the secret key for the object store
+// backups are stored in.
+func (c Config) BackupS3SecretKey() string {
+	return c.asString(BackupS3SecretKey)
+}
+
+// BackupEncryptionKey returns the key used to encrypt backups stored
+// in an object store, or nil if they aren't encrypted.
+func (c Config) BackupEncryptionKey() []byte {
+	// Value has already been validated.
+	key, _ := hex.DecodeString(c.asString(BackupEncryptionKey))
+	if len(key) == 0 {
+		return nil
+	}
+	return key
+}
+
 // JujuHASpace is the network space within which the MongoDB replica-set
 // should communicate.
 func (c Config) JujuHASpace() string {
@@ -571,6 +730,28 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := cron.Parse(v); err != nil {
+			return errors.Annotate(err, "invalid backup schedule in configuration")
+		}
+	}
+
+	if v, ok := c[BackupMaxCount].(int); ok && v < 0 {
+		return errors.Errorf("invalid backup max count: should be a number of backups (or 0 to keep all), got %d", v)
+	}
+
+	if v, ok := c[BackupMaxAge].(string); ok {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid backup max age in configuration")
+		} else if d < 0 {
+			return errors.Errorf("invalid backup max age: should not be negative, got %q", v)
+		}
+	}
+
+	if err := c.validateBackupStorage(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if err := c.validateSpaceConfig(JujuHASpace, "juju HA"); err != nil {
 		return errors.Trace(err)
 	}
@@ -622,6 +803,41 @@ func Validate(c Config) error {
 	return nil
 }
 
+func (c Config) validateBackupStorage() error {
+	if v, ok := c[BackupEncryptionKey].(string); ok && v != "" {
+		if key, err := hex.DecodeString(v); err != nil || len(key) != 32 {
+			return errors.Errorf("invalid backup encryption key: should be 64 hexadecimal digits")
+		}
+	}
+
+	switch c.BackupStorage() {
+	case BackupStorageMongo:
+		return nil
+	case BackupStorageS3:
+	default:
+		return errors.Errorf("invalid backup storage: expected one of %s or %s, got %q",
+			BackupStorageMongo, BackupStorageS3, c.BackupStorage())
+	}
+
+	endpoint := c.BackupS3Endpoint()
+	if endpoint == "" {
+		return errors.Errorf("%s is required when %s is %q", BackupS3Endpoint, BackupStorage, BackupStorageS3)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return errors.Annotate(err, "invalid backup s3 endpoint")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Errorf("invalid backup s3 endpoint: expected an http or https URL, got %q", endpoint)
+	}
+	for _, key := range []string{BackupS3Bucket, BackupS3AccessKey, BackupS3SecretKey} {
+		if c.asString(key) == "" {
+			return errors.Errorf("%s is required when %s is %q", key, BackupStorage, BackupStorageS3)
+		}
+	}
+	return nil
+}
+
 func (c Config) validateSpaceConfig(key, topic string) error {
 	val := c[key]
 	if val == nil {
@@ -691,6 +907,16 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxLogsAge:              schema.String(),
 	MaxLogsSize:             schema.String(),
 	MaxTxnLogSize:           schema.String(),
+	BackupSchedule:          schema.String(),
+	BackupMaxCount:          schema.ForceInt(),
+	BackupMaxAge:            schema.String(),
+	BackupStorage:           schema.String(),
+	BackupS3Endpoint:        schema.String(),
+	BackupS3Region:          schema.String(),
+	BackupS3Bucket:          schema.String(),
+	BackupS3AccessKey:       schema.String(),
+	BackupS3SecretKey:       schema.String(),
+	BackupEncryptionKey:     schema.String(),
 	JujuHASpace:             schema.String(),
 	JujuManagementSpace:     schema.String(),
 	CAASOperatorImagePath:   schema.String(),
@@ -715,6 +941,16 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
 	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
 	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	BackupSchedule:          schema.Omit,
+	BackupMaxCount:          schema.Omit,
+	BackupMaxAge:            schema.Omit,
+	BackupStorage:           schema.Omit,
+	BackupS3Endpoint:        schema.Omit,
+	BackupS3Region:          schema.Omit,
+	BackupS3Bucket:          schema.Omit,
+	BackupS3AccessKey:       schema.Omit,
+	BackupS3SecretKey:       schema.Omit,
+	BackupEncryptionKey:     schema.Omit,
 	JujuHASpace:             schema.Omit,
 	JujuManagementSpace:     schema.Omit,
 	CAASOperatorImagePath:   schema.Omit,
@@ -4,6 +4,8 @@
 package controller_test
 
 import (
+	"fmt"
+	"strings"
 	stdtesting "testing"
 	"time"
 
@@ -230,6 +232,66 @@ var validateTests = []struct {
 		controller.CAASOperatorImagePath: "foo//bar",
 	},
 	expectError: `docker image path "foo//bar" not valid`,
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupSchedule: "0 25 * * *",
+	},
+	expectError: `invalid backup schedule in configuration: hour: value 25 outside range 0-23 not valid`,
+}, {
+	about: "invalid backup max count",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupMaxCount: -1,
+	},
+	expectError: `invalid backup max count: should be a number of backups \(or 0 to keep all\), got -1`,
+}, {
+	about: "invalid backup max age",
+	config: controller.Config{
+		controller.CACertKey:    testing.CACert,
+		controller.BackupMaxAge: "a month",
+	},
+	expectError: `invalid backup max age in configuration: time: invalid duration "?a month"?`,
+}, {
+	about: "invalid backup storage",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "floppy",
+	},
+	expectError: `invalid backup storage: expected one of mongo or s3, got "floppy"`,
+}, {
+	about: "backup s3 storage without endpoint",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "s3",
+	},
+	expectError: `backup-s3-endpoint is required when backup-storage is "s3"`,
+}, {
+	about: "invalid backup s3 endpoint",
+	config: controller.Config{
+		controller.CACertKey:        testing.CACert,
+		controller.BackupStorage:    "s3",
+		controller.BackupS3Endpoint: "s3.example.com",
+	},
+	expectError: `invalid backup s3 endpoint: expected an http or https URL, got "s3.example.com"`,
+}, {
+	about: "backup s3 storage without secret key",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Endpoint:  "https://s3.example.com",
+		controller.BackupS3Bucket:    "backups",
+		controller.BackupS3AccessKey: "access",
+	},
+	expectError: `backup-s3-secret-key is required when backup-storage is "s3"`,
+}, {
+	about: "invalid backup encryption key",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.BackupEncryptionKey: "deadbeef",
+	},
+	expectError: `invalid backup encryption key: should be 64 hexadecimal digits`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -265,6 +327,63 @@ func (s *ConfigSuite) TestLogConfigValues(c *gc.C) {
 	c.Assert(cfg.MaxLogSizeMB(), gc.Equals, 8192)
 }
 
+func (s *ConfigSuite) TestBackupConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "")
+	c.Assert(cfg.BackupMaxCount(), gc.Equals, 0)
+	c.Assert(cfg.BackupMaxAge(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestBackupConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule":  "@daily",
+			"backup-max-count": 7,
+			"backup-max-age":   "168h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, "@daily")
+	c.Assert(cfg.BackupMaxCount(), gc.Equals, 7)
+	c.Assert(cfg.BackupMaxAge(), gc.Equals, 168*time.Hour)
+}
+
+func (s *ConfigSuite) TestBackupStorageConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupStorage(), gc.Equals, "mongo")
+	c.Assert(cfg.BackupS3Region(), gc.Equals, "us-east-1")
+	c.Assert(cfg.BackupEncryptionKey(), gc.IsNil)
+}
+
+func (s *ConfigSuite) TestBackupStorageConfigValues(c *gc.C) {
+	key := strings.Repeat("0123456789abcdef", 4)
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-storage":        "s3",
+			"backup-s3-endpoint":    "https://s3.example.com",
+			"backup-s3-region":      "eu-west-1",
+			"backup-s3-bucket":      "backups",
+			"backup-s3-access-key":  "access",
+			"backup-s3-secret-key":  "secret",
+			"backup-encryption-key": key,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupStorage(), gc.Equals, "s3")
+	c.Assert(cfg.BackupS3Endpoint(), gc.Equals, "https://s3.example.com")
+	c.Assert(cfg.BackupS3Region(), gc.Equals, "eu-west-1")
+	c.Assert(cfg.BackupS3Bucket(), gc.Equals, "backups")
+	c.Assert(cfg.BackupS3AccessKey(), gc.Equals, "access")
+	c.Assert(cfg.BackupS3SecretKey(), gc.Equals, "secret")
+	c.Assert(fmt.Sprintf("%x", cfg.BackupEncryptionKey()), gc.Equals, key)
+}
+
 func (s *ConfigSuite) TestTxnLogConfigDefault(c *gc.C) {
 	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
 	c.Assert(err, jc.ErrorIsNil)
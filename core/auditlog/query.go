@@ -0,0 +1,364 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Filter selects the audit log entries returned by a query. Fields
+// left at their zero value match every entry.
+type Filter struct {
+	// Who matches the user that made the request.
+	Who string
+
+	// Model matches either the name or the UUID of the model the
+	// request was made against. The audit log only records the bare
+	// name of the model, so an owner-qualified name must be resolved
+	// to the model's UUID by the caller; otherwise models with the
+	// same name but different owners can't be told apart.
+	Model string
+
+	// Facade and Method match the API call made.
+	Facade string
+	Method string
+
+	// After and Before restrict the time range of the requests
+	// returned; both are exclusive.
+	After  time.Time
+	Before time.Time
+
+	// ErrorsOnly restricts the entries to those requests which
+	// resulted in an error.
+	ErrorsOnly bool
+
+	// Limit, if positive, is the maximum number of entries to
+	// return. The most recent entries are kept.
+	Limit int
+}
+
+// MaxQueryLimit is the largest number of entries that a controller
+// will return for a single query; queries with no limit or a larger
+// one are restricted to this many entries.
+const MaxQueryLimit = 1000
+
+// Validate returns an error if the filter is invalid.
+func (f Filter) Validate() error {
+	if f.Limit < 0 {
+		return errors.NotValidf("negative Limit")
+	}
+	if !f.After.IsZero() && !f.Before.IsZero() && !f.After.Before(f.Before) {
+		return errors.NotValidf("time range with After not before Before")
+	}
+	return nil
+}
+
+// Match returns whether the entry is selected by the filter.
+func (f Filter) Match(e Entry) bool {
+	if f.ErrorsOnly && len(e.Errors) == 0 {
+		return false
+	}
+	return f.matchRequest(e)
+}
+
+// matchRequest returns whether the entry is selected by the filter,
+// ignoring any errors the request produced.
+func (f Filter) matchRequest(e Entry) bool {
+	if f.Who != "" && e.Conversation.Who != f.Who {
+		return false
+	}
+	if f.Model != "" && e.Conversation.ModelName != f.Model && e.Conversation.ModelUUID != f.Model {
+		return false
+	}
+	if f.Facade != "" && e.Request.Facade != f.Facade {
+		return false
+	}
+	if f.Method != "" && e.Request.Method != f.Method {
+		return false
+	}
+	if !f.After.IsZero() || !f.Before.IsZero() {
+		when, err := e.When()
+		if err != nil {
+			return false
+		}
+		if !f.After.IsZero() && !when.After(f.After) {
+			return false
+		}
+		if !f.Before.IsZero() && !when.Before(f.Before) {
+			return false
+		}
+	}
+	return true
+}
+
+// Entry is a single API request recorded in the audit log, along
+// with the conversation it was part of and any errors it produced.
+type Entry struct {
+	Conversation Conversation `json:"conversation"`
+	Request      Request      `json:"request"`
+	Errors       []*Error     `json:"errors,omitempty"`
+}
+
+// When returns the time the request was made.
+func (e Entry) When() (time.Time, error) {
+	when, err := time.Parse(time.RFC3339, e.Request.When)
+	return when, errors.Trace(err)
+}
+
+type entryKey struct {
+	conversationID string
+	requestID      uint64
+}
+
+// entryReader links the records read from audit log files into
+// entries, keeping only those selected by the filter. So that large
+// logs can be queried without holding all of their entries in
+// memory, requests that don't match are dropped as they're read, and
+// only the most recent matches within the limit are retained.
+type entryReader struct {
+	filter        Filter
+	conversations map[string]Conversation
+
+	// entries holds the matching entries.
+	entries []*readEntry
+
+	// byKey holds the entries that may yet have errors added,
+	// until their response is read.
+	byKey map[entryKey]*readEntry
+
+	// requests counts the requests read, to order the entries.
+	requests int
+}
+
+// readEntry is an entry along with its position in the log.
+type readEntry struct {
+	Entry
+	seq int
+}
+
+func newEntryReader(filter Filter) *entryReader {
+	return &entryReader{
+		filter:        filter,
+		conversations: make(map[string]Conversation),
+		byKey:         make(map[entryKey]*readEntry),
+	}
+}
+
+// read consumes the records in source, which holds one JSON encoded
+// Record per line. Lines that can't be decoded (such as a partially
+// written final line) are skipped.
+func (r *entryReader) read(source io.Reader) error {
+	buf := bufio.NewReader(source)
+	for {
+		line, err := buf.ReadBytes('\n')
+		if len(line) > 0 {
+			var record Record
+			if jsonErr := json.Unmarshal(line, &record); jsonErr != nil {
+				logger.Debugf("skipping audit log line: %v", jsonErr)
+			} else {
+				r.add(record)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+func (r *entryReader) add(record Record) {
+	switch {
+	case record.Conversation != nil:
+		r.conversations[record.Conversation.ConversationID] = *record.Conversation
+	case record.Request != nil:
+		req := record.Request
+		conversation, ok := r.conversations[req.ConversationID]
+		if !ok {
+			// The conversation record was rotated away.
+			conversation = Conversation{
+				ConversationID: req.ConversationID,
+				ConnectionID:   req.ConnectionID,
+			}
+		}
+		r.requests++
+		entry := &readEntry{
+			Entry: Entry{
+				Conversation: conversation,
+				Request:      *req,
+			},
+			seq: r.requests,
+		}
+		if !r.filter.matchRequest(entry.Entry) {
+			return
+		}
+		r.byKey[entryKey{req.ConversationID, req.RequestID}] = entry
+		if !r.filter.ErrorsOnly {
+			r.keep(entry)
+		}
+	case record.Errors != nil:
+		key := entryKey{record.Errors.ConversationID, record.Errors.RequestID}
+		entry, ok := r.byKey[key]
+		if !ok {
+			return
+		}
+		// Each request has a single response, so the entry is
+		// now complete.
+		delete(r.byKey, key)
+		entry.Errors = append(entry.Errors, record.Errors.Errors...)
+		if r.filter.ErrorsOnly && len(entry.Errors) > 0 {
+			r.keep(entry)
+		}
+	}
+}
+
+// keep adds the entry to the results, discarding the oldest entries
+// beyond the limit.
+func (r *entryReader) keep(entry *readEntry) {
+	r.entries = append(r.entries, entry)
+	limit := r.filter.Limit
+	if limit <= 0 || len(r.entries) <= limit {
+		return
+	}
+	if r.filter.ErrorsOnly {
+		// Entries are kept as their responses are read, which may
+		// not be the order the requests were made in; so allow some
+		// slack before putting them in order and trimming them.
+		if len(r.entries) < 2*limit {
+			return
+		}
+		r.sortEntries()
+	}
+	for _, old := range r.entries[:len(r.entries)-limit] {
+		delete(r.byKey, entryKey{old.Request.ConversationID, old.Request.RequestID})
+	}
+	r.entries = append([]*readEntry(nil), r.entries[len(r.entries)-limit:]...)
+}
+
+func (r *entryReader) sortEntries() {
+	sort.Slice(r.entries, func(i, j int) bool {
+		return r.entries[i].seq < r.entries[j].seq
+	})
+}
+
+func (r *entryReader) query() []Entry {
+	r.sortEntries()
+	entries := r.entries
+	if limit := r.filter.Limit; limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	result := make([]Entry, len(entries))
+	for i, entry := range entries {
+		result[i] = entry.Entry
+	}
+	return result
+}
+
+// ReadEntries returns the entries recorded in the sources which are
+// selected by the filter. The sources must be supplied in the order
+// they were written.
+func ReadEntries(filter Filter, sources ...io.Reader) ([]Entry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	reader := newEntryReader(filter)
+	for _, source := range sources {
+		if err := reader.read(source); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return reader.query(), nil
+}
+
+// QueryLogDir returns the entries selected by the filter from the
+// audit.log file in logDir, including any rotated backups of it.
+func QueryLogDir(logDir string, filter Filter) ([]Entry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths, err := LogFilePaths(logDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reader := newEntryReader(filter)
+	for _, path := range paths {
+		if err := readLogFile(reader, path); err != nil {
+			return nil, errors.Annotatef(err, "reading %s", path)
+		}
+	}
+	return reader.query(), nil
+}
+
+// LogFilePaths returns the paths of the audit log files in logDir, in
+// the order they were written: the rotated backups of audit.log,
+// followed by audit.log itself.
+func LogFilePaths(logDir string) ([]string, error) {
+	// Rotated backups are named audit-<timestamp>.log(.gz), so
+	// sorting them by name puts them in the order they were written.
+	backups, err := filepath.Glob(filepath.Join(logDir, "audit-*.log*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Strings(backups)
+	var paths []string
+	for _, path := range backups {
+		// A backup is briefly present both uncompressed and
+		// compressed while it's being compressed.
+		if n := len(paths); n > 0 && path == paths[n-1]+".gz" {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return append(paths, filepath.Join(logDir, "audit.log")), nil
+}
+
+// BackupTime returns the time at which the audit log backup at path
+// was rotated, which is after all the records it holds were written.
+func BackupTime(path string) (time.Time, error) {
+	name := filepath.Base(path)
+	name = strings.TrimSuffix(name, ".gz")
+	name = strings.TrimSuffix(name, ".log")
+	if !strings.HasPrefix(name, "audit-") {
+		return time.Time{}, errors.NotValidf("audit log backup name %q", filepath.Base(path))
+	}
+	t, err := time.Parse(backupTimeFormat, strings.TrimPrefix(name, "audit-"))
+	if err != nil {
+		return time.Time{}, errors.NotValidf("audit log backup name %q", filepath.Base(path))
+	}
+	return t, nil
+}
+
+// backupTimeFormat is the format lumberjack uses for the timestamp in
+// the names of rotated backups.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+func readLogFile(reader *entryReader, path string) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	var source io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer gz.Close()
+		source = gz
+	}
+	return errors.Trace(reader.read(source))
+}
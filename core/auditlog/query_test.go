@@ -0,0 +1,207 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type QuerySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&QuerySuite{})
+
+const (
+	conversationLine = `{"conversation":{"who":"sheila","what":"juju remove-application wordpress","when":"2018-06-05T11:00:00Z","model-name":"admin/default","model-uuid":"deadbeef","conversation-id":"c1","connection-id":"A1"}}`
+	requestLine      = `{"request":{"conversation-id":"c1","connection-id":"A1","request-id":1,"when":"2018-06-05T11:00:01Z","facade":"Application","method":"Destroy","version":8}}`
+	errorsLine       = `{"errors":{"conversation-id":"c1","connection-id":"A1","request-id":1,"when":"2018-06-05T11:00:02Z","errors":[{"message":"boom","code":""}]}}`
+
+	conversation2Line = `{"conversation":{"who":"bob","what":"juju deploy mysql","when":"2018-06-06T09:00:00Z","model-name":"prod","model-uuid":"cafebabe","conversation-id":"c2","connection-id":"A2"}}`
+	request2Line      = `{"request":{"conversation-id":"c2","connection-id":"A2","request-id":1,"when":"2018-06-06T09:00:01Z","facade":"Application","method":"Deploy","version":8}}`
+	request3Line      = `{"request":{"conversation-id":"c2","connection-id":"A2","request-id":2,"when":"2018-06-06T09:00:05Z","facade":"Client","method":"FullStatus","version":1}}`
+)
+
+func lines(l ...string) string {
+	return strings.Join(l, "\n") + "\n"
+}
+
+func (s *QuerySuite) read(c *gc.C, filter auditlog.Filter) []auditlog.Entry {
+	entries, err := auditlog.ReadEntries(filter,
+		strings.NewReader(lines(conversationLine, requestLine, errorsLine)),
+		strings.NewReader(lines(conversation2Line, request2Line, request3Line)),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return entries
+}
+
+func methods(entries []auditlog.Entry) []string {
+	var result []string
+	for _, e := range entries {
+		result = append(result, e.Request.Facade+"."+e.Request.Method)
+	}
+	return result
+}
+
+func (s *QuerySuite) TestReadEntriesLinksRecords(c *gc.C) {
+	entries := s.read(c, auditlog.Filter{})
+	c.Assert(entries, gc.HasLen, 3)
+	c.Assert(entries[0].Conversation.Who, gc.Equals, "sheila")
+	c.Assert(entries[0].Request.Method, gc.Equals, "Destroy")
+	c.Assert(entries[0].Errors, jc.DeepEquals, []*auditlog.Error{{Message: "boom"}})
+	c.Assert(entries[2].Conversation.What, gc.Equals, "juju deploy mysql")
+	c.Assert(entries[2].Errors, gc.HasLen, 0)
+}
+
+func (s *QuerySuite) TestFilters(c *gc.C) {
+	for i, test := range []struct {
+		filter auditlog.Filter
+		expect []string
+	}{{
+		filter: auditlog.Filter{Who: "bob"},
+		expect: []string{"Application.Deploy", "Client.FullStatus"},
+	}, {
+		filter: auditlog.Filter{Model: "admin/default"},
+		expect: []string{"Application.Destroy"},
+	}, {
+		// Only the bare name is recorded, so an owner-qualified
+		// name must be resolved to a UUID by the caller.
+		filter: auditlog.Filter{Model: "bob/prod"},
+	}, {
+		filter: auditlog.Filter{Model: "prod"},
+		expect: []string{"Application.Deploy", "Client.FullStatus"},
+	}, {
+		filter: auditlog.Filter{Model: "bob/default"},
+	}, {
+		filter: auditlog.Filter{Model: "cafebabe", Method: "FullStatus"},
+		expect: []string{"Client.FullStatus"},
+	}, {
+		filter: auditlog.Filter{Facade: "Application"},
+		expect: []string{"Application.Destroy", "Application.Deploy"},
+	}, {
+		filter: auditlog.Filter{ErrorsOnly: true},
+		expect: []string{"Application.Destroy"},
+	}, {
+		filter: auditlog.Filter{
+			After:  time.Date(2018, 6, 6, 0, 0, 0, 0, time.UTC),
+			Before: time.Date(2018, 6, 6, 9, 0, 2, 0, time.UTC),
+		},
+		expect: []string{"Application.Deploy"},
+	}, {
+		filter: auditlog.Filter{Limit: 2},
+		expect: []string{"Application.Deploy", "Client.FullStatus"},
+	}, {
+		filter: auditlog.Filter{Who: "nobody"},
+	}} {
+		c.Logf("test %d: %+v", i, test.filter)
+		c.Check(methods(s.read(c, test.filter)), jc.DeepEquals, test.expect)
+	}
+}
+
+func (s *QuerySuite) TestErrorsOnlyLimitKeepsLatestRequests(c *gc.C) {
+	// The second request's response is written first, but it's
+	// still the most recent request.
+	entries, err := auditlog.ReadEntries(auditlog.Filter{ErrorsOnly: true, Limit: 1},
+		strings.NewReader(lines(
+			conversation2Line,
+			request2Line,
+			request3Line,
+			`{"errors":{"conversation-id":"c2","connection-id":"A2","request-id":2,"when":"2018-06-06T09:00:06Z","errors":[{"message":"nope","code":""}]}}`,
+			`{"errors":{"conversation-id":"c2","connection-id":"A2","request-id":1,"when":"2018-06-06T09:00:07Z","errors":[{"message":"boom","code":""}]}}`,
+		)),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(methods(entries), jc.DeepEquals, []string{"Client.FullStatus"})
+	c.Assert(entries[0].Errors, jc.DeepEquals, []*auditlog.Error{{Message: "nope"}})
+}
+
+func (s *QuerySuite) TestSkipsBadLines(c *gc.C) {
+	entries, err := auditlog.ReadEntries(auditlog.Filter{},
+		strings.NewReader(lines(conversationLine, "{not json", requestLine)+`{"errors":{"conv`),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(methods(entries), jc.DeepEquals, []string{"Application.Destroy"})
+}
+
+func (s *QuerySuite) TestRequestWithoutConversation(c *gc.C) {
+	entries, err := auditlog.ReadEntries(auditlog.Filter{}, strings.NewReader(lines(requestLine)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 1)
+	c.Assert(entries[0].Conversation.ConversationID, gc.Equals, "c1")
+	c.Assert(entries[0].Conversation.Who, gc.Equals, "")
+}
+
+func (s *QuerySuite) TestInvalidFilter(c *gc.C) {
+	_, err := auditlog.ReadEntries(auditlog.Filter{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative Limit not valid")
+	now := time.Now()
+	_, err = auditlog.ReadEntries(auditlog.Filter{After: now, Before: now})
+	c.Assert(err, gc.ErrorMatches, "time range with After not before Before not valid")
+}
+
+func (s *QuerySuite) TestQueryLogDirReadsBackups(c *gc.C) {
+	dir := c.MkDir()
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	_, err := gz.Write([]byte(lines(conversationLine, requestLine)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gz.Close(), jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dir, "audit-2018-06-05T12-00-00.000.log.gz"), compressed.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	// The response was written after the log was rotated.
+	err = ioutil.WriteFile(filepath.Join(dir, "audit.log"), []byte(lines(errorsLine, conversation2Line, request2Line)), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	entries, err := auditlog.QueryLogDir(dir, auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(methods(entries), jc.DeepEquals, []string{"Application.Destroy", "Application.Deploy"})
+	c.Assert(entries[0].Errors, gc.HasLen, 1)
+}
+
+func (s *QuerySuite) TestQueryLogDirMissingFile(c *gc.C) {
+	entries, err := auditlog.QueryLogDir(c.MkDir(), auditlog.Filter{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *QuerySuite) TestLogFilePaths(c *gc.C) {
+	dir := c.MkDir()
+	for _, name := range []string{
+		"audit-2018-06-05T13-00-00.000.log.gz",
+		// This backup is still being compressed.
+		"audit-2018-06-05T14-00-00.000.log",
+		"audit-2018-06-05T14-00-00.000.log.gz",
+		"audit-2018-06-05T12-00-00.000.log.gz",
+		"audit.log",
+		"machine-0.log",
+	} {
+		err := ioutil.WriteFile(filepath.Join(dir, name), nil, 0600)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	paths, err := auditlog.LogFilePaths(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paths, jc.DeepEquals, []string{
+		filepath.Join(dir, "audit-2018-06-05T12-00-00.000.log.gz"),
+		filepath.Join(dir, "audit-2018-06-05T13-00-00.000.log.gz"),
+		filepath.Join(dir, "audit-2018-06-05T14-00-00.000.log"),
+		filepath.Join(dir, "audit.log"),
+	})
+
+	rotated, err := auditlog.BackupTime(paths[1])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rotated, gc.Equals, time.Date(2018, 6, 5, 13, 0, 0, 0, time.UTC))
+	_, err = auditlog.BackupTime(paths[3])
+	c.Assert(err, gc.ErrorMatches, `audit log backup name "audit.log" not valid`)
+}
@@ -0,0 +1,206 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cron parses cron-like schedule specifications and
+// calculates when they are next due.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule describes a recurring point in time.
+type Schedule interface {
+	// Next returns the first time the schedule is due strictly
+	// after the given time. The zero time is returned if the
+	// schedule can never be satisfied.
+	Next(time.Time) time.Time
+}
+
+// maxSearch bounds how far into the future Next will look for a
+// matching time, so that impossible schedules (such as the 31st of
+// February) terminate.
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a schedule specification. Either the standard five
+// field form "minute hour day-of-month month day-of-week" may be
+// used, where each field may be "*", a number, a range "a-b", any
+// of those followed by a step "/n", or a comma-separated list of
+// these; or one of the descriptors @yearly, @annually, @monthly,
+// @weekly, @daily, @midnight, @hourly or "@every <duration>".
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, errors.NotValidf("empty schedule")
+	}
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(spec[len("@every "):]))
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid schedule %q", spec)
+		}
+		if d < time.Minute {
+			return nil, errors.NotValidf("schedule %q with interval less than a minute", spec)
+		}
+		return every(d), nil
+	}
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := descriptors[spec]
+		if !ok {
+			return nil, errors.NotValidf("schedule descriptor %q", spec)
+		}
+		spec = expanded
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.NotValidf("schedule %q with %d fields (expected 5)", spec, len(fields))
+	}
+	var s schedule
+	var err error
+	if s.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, errors.Annotate(err, "minute")
+	}
+	if s.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, errors.Annotate(err, "hour")
+	}
+	if s.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, errors.Annotate(err, "day of month")
+	}
+	if s.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, errors.Annotate(err, "month")
+	}
+	if s.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, errors.Annotate(err, "day of week")
+	}
+	// Sunday may be written as either 0 or 7.
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	s.domAny = fields[2] == "*"
+	s.dowAny = fields[4] == "*"
+	return &s, nil
+}
+
+// parseField parses a single schedule field into a bitset of the
+// values it matches.
+func parseField(field string, min, max uint) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, uint(1)
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.ParseUint(part[i+1:], 10, 8)
+			if err != nil || n == 0 {
+				return 0, errors.NotValidf("step in %q", part)
+			}
+			rangePart, step = part[:i], uint(n)
+		}
+		var lo, hi uint
+		switch {
+		case rangePart == "*":
+			lo, hi = min, max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = parseValue(bounds[0], min, max); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if hi, err = parseValue(bounds[1], min, max); err != nil {
+				return 0, errors.Trace(err)
+			}
+			if lo > hi {
+				return 0, errors.NotValidf("range %q", rangePart)
+			}
+		default:
+			var err error
+			if lo, err = parseValue(rangePart, min, max); err != nil {
+				return 0, errors.Trace(err)
+			}
+			hi = lo
+			if step > 1 {
+				// "a/n" means every n starting at a.
+				hi = max
+			}
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(s string, min, max uint) (uint, error) {
+	n, err := strconv.ParseUint(s, 10, 8)
+	if err != nil {
+		return 0, errors.NotValidf("value %q", s)
+	}
+	if uint(n) < min || uint(n) > max {
+		return 0, errors.NotValidf("value %d outside range %d-%d", n, min, max)
+	}
+	return uint(n), nil
+}
+
+// schedule is a Schedule parsed from the five field form.
+type schedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// Next is part of the Schedule interface.
+func (s *schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	limit := t.Add(maxSearch)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether the day of t matches the schedule. As
+// with cron, if both the day of month and day of week are restricted
+// then a day matching either is accepted.
+func (s *schedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// every is a Schedule that is due at a fixed interval.
+type every time.Duration
+
+// Next is part of the Schedule interface.
+func (e every) Next(t time.Time) time.Time {
+	return t.Truncate(time.Second).Add(time.Duration(e))
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cron_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cron"
+)
+
+type cronSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&cronSuite{})
+
+func mustParseTime(c *gc.C, s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	c.Assert(err, jc.ErrorIsNil)
+	return t
+}
+
+func (s *cronSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		from string
+		next string
+	}{
+		{"* * * * *", "2019-03-01 10:15", "2019-03-01 10:16"},
+		{"30 2 * * *", "2019-03-01 10:15", "2019-03-02 02:30"},
+		{"30 2 * * *", "2019-03-01 01:15", "2019-03-01 02:30"},
+		{"0 */6 * * *", "2019-03-01 10:15", "2019-03-01 12:00"},
+		{"15,45 * * * *", "2019-03-01 10:15", "2019-03-01 10:45"},
+		{"0 9-17/4 * * *", "2019-03-01 14:00", "2019-03-01 17:00"},
+		{"0 0 1 * *", "2019-03-01 10:15", "2019-04-01 00:00"},
+		{"0 0 * * 0", "2019-03-01 10:15", "2019-03-03 00:00"},
+		{"0 0 * * 7", "2019-03-01 10:15", "2019-03-03 00:00"},
+		{"0 0 13 * 5", "2019-03-02 10:15", "2019-03-08 00:00"},
+		{"0 0 29 2 *", "2019-03-01 10:15", "2020-02-29 00:00"},
+		{"0 0 31 2 *", "2019-03-01 10:15", ""},
+		{"@daily", "2019-12-31 23:59", "2020-01-01 00:00"},
+		{"@hourly", "2019-03-01 10:15", "2019-03-01 11:00"},
+		{"@weekly", "2019-03-01 10:15", "2019-03-03 00:00"},
+		{"@monthly", "2019-12-15 10:15", "2020-01-01 00:00"},
+		{"@yearly", "2019-03-01 10:15", "2020-01-01 00:00"},
+		{"@every 90m", "2019-03-01 10:15", "2019-03-01 11:45"},
+	} {
+		c.Logf("test %d: %q from %s", i, test.spec, test.from)
+		schedule, err := cron.Parse(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		next := schedule.Next(mustParseTime(c, test.from))
+		if test.next == "" {
+			c.Check(next.IsZero(), jc.IsTrue)
+		} else {
+			c.Check(next, gc.Equals, mustParseTime(c, test.next))
+		}
+	}
+}
+
+func (s *cronSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{
+		{"", "empty schedule not valid"},
+		{"* * * *", `schedule "\* \* \* \*" with 4 fields \(expected 5\) not valid`},
+		{"60 * * * *", "minute: value 60 outside range 0-59 not valid"},
+		{"* 24 * * *", "hour: value 24 outside range 0-23 not valid"},
+		{"* * 0 * *", "day of month: value 0 outside range 1-31 not valid"},
+		{"* * * 13 *", "month: value 13 outside range 1-12 not valid"},
+		{"* * * * 8", "day of week: value 8 outside range 0-7 not valid"},
+		{"5-1 * * * *", `minute: range "5-1" not valid`},
+		{"*/0 * * * *", `minute: step in "\*/0" not valid`},
+		{"x * * * *", `minute: value "x" not valid`},
+		{"@fortnightly", `schedule descriptor "@fortnightly" not valid`},
+		{"@every 10s", `schedule "@every 10s" with interval less than a minute not valid`},
+		{"@every soon", `invalid schedule "@every soon": .*`},
+	} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := cron.Parse(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+)
+
+const (
+	// ErrorCodeInvalid denotes a lease.ErrInvalid response.
+	ErrorCodeInvalid = "invalid"
+
+	// ErrorCodeTimeout denotes a lease.ErrTimeout response.
+	ErrorCodeTimeout = "timeout"
+
+	// ErrorCodeConcurrentUpdate denotes a
+	// globalclock.ErrConcurrentUpdate response.
+	ErrorCodeConcurrentUpdate = "concurrent-update"
+)
+
+// ForwardRequest is a message sent over the hub to the raft forwarder
+// (only running on the raft leader node).
+type ForwardRequest struct {
+	Command       string `yaml:"command"`
+	ResponseTopic string `yaml:"response-topic"`
+}
+
+// ForwardResponse is the response sent back from the raft forwarder.
+type ForwardResponse struct {
+	Error *ResponseError `yaml:"error"`
+}
+
+// ResponseError is used for sending error values back to the lease
+// store via the hub.
+type ResponseError struct {
+	Message string `yaml:"message"`
+	Code    string `yaml:"code"`
+}
+
+// AsResponseError returns a *ResponseError that can be sent back over
+// the hub in response to a forwarded FSM request.
+func AsResponseError(err error) *ResponseError {
+	if err == nil {
+		return nil
+	}
+	message := err.Error()
+	var code string
+	switch errors.Cause(err) {
+	case lease.ErrInvalid:
+		code = ErrorCodeInvalid
+	case lease.ErrTimeout:
+		code = ErrorCodeTimeout
+	case globalclock.ErrConcurrentUpdate:
+		code = ErrorCodeConcurrentUpdate
+	}
+	return &ResponseError{
+		Message: message,
+		Code:    code,
+	}
+}
+
+// RecoverError converts a ResponseError back into the specific error
+// it represents, or into a generic error if it wasn't one of the
+// singleton errors handled.
+func RecoverError(resp *ResponseError) error {
+	if resp == nil {
+		return nil
+	}
+	switch resp.Code {
+	case ErrorCodeInvalid:
+		return lease.ErrInvalid
+	case ErrorCodeTimeout:
+		return lease.ErrTimeout
+	case ErrorCodeConcurrentUpdate:
+		return globalclock.ErrConcurrentUpdate
+	}
+	return errors.New(resp.Message)
+}
+
+// PubsubClientConfig holds resources and settings needed to run a
+// PubsubClient.
+type PubsubClientConfig struct {
+	Hub            *pubsub.StructuredHub
+	RequestTopic   string
+	Clock          clock.Clock
+	ForwardTimeout time.Duration
+}
+
+// Validate returns an error if the configuration is invalid.
+func (config PubsubClientConfig) Validate() error {
+	if config.Hub == nil {
+		return errors.NotValidf("nil Hub")
+	}
+	if config.RequestTopic == "" {
+		return errors.NotValidf("empty RequestTopic")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.ForwardTimeout <= 0 {
+		return errors.NotValidf("non-positive ForwardTimeout")
+	}
+	return nil
+}
+
+// NewPubsubClient returns a Client that forwards commands over the
+// hub to the raft leader, where they are applied to the raft log.
+func NewPubsubClient(config PubsubClientConfig) (*PubsubClient, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &PubsubClient{config: config}, nil
+}
+
+// PubsubClient is a Client that sends commands to the raft forwarder
+// on the leader node, and waits for the response.
+type PubsubClient struct {
+	config PubsubClientConfig
+}
+
+// Request is part of Client.
+func (c *PubsubClient) Request(command *Command) error {
+	bytes, err := command.Marshal()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	responseTopic := c.config.RequestTopic + "." + uuid.String()
+
+	responseChan := make(chan ForwardResponse, 1)
+	errChan := make(chan error, 1)
+	unsubscribe, err := c.config.Hub.Subscribe(
+		responseTopic,
+		func(_ string, resp ForwardResponse, err error) {
+			if err != nil {
+				errChan <- err
+			} else {
+				responseChan <- resp
+			}
+		},
+	)
+	if err != nil {
+		return errors.Annotatef(err, "running %s", command.Operation)
+	}
+	defer unsubscribe()
+
+	_, err = c.config.Hub.Publish(c.config.RequestTopic, ForwardRequest{
+		Command:       string(bytes),
+		ResponseTopic: responseTopic,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "publishing %s", command.Operation)
+	}
+
+	select {
+	case <-c.config.Clock.After(c.config.ForwardTimeout):
+		return lease.ErrTimeout
+	case err := <-errChan:
+		return errors.Trace(err)
+	case response := <-responseChan:
+		return RecoverError(response.Error)
+	}
+}
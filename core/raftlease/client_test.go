@@ -0,0 +1,40 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestErrorRoundTrip(c *gc.C) {
+	for _, err := range []error{
+		lease.ErrInvalid,
+		lease.ErrTimeout,
+		globalclock.ErrConcurrentUpdate,
+	} {
+		wrapped := errors.Annotate(err, "wrapped")
+		c.Check(raftlease.RecoverError(raftlease.AsResponseError(wrapped)), gc.Equals, err)
+	}
+
+	recovered := raftlease.RecoverError(raftlease.AsResponseError(errors.New("boom")))
+	c.Assert(recovered, gc.ErrorMatches, "boom")
+	c.Assert(raftlease.RecoverError(raftlease.AsResponseError(nil)), gc.IsNil)
+}
+
+func (s *clientSuite) TestValidate(c *gc.C) {
+	_, err := raftlease.NewPubsubClient(raftlease.PubsubClientConfig{})
+	c.Assert(err, gc.ErrorMatches, "nil Hub not valid")
+}
@@ -0,0 +1,132 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/lease"
+)
+
+const (
+	// CommandVersion is the current version of the command format.
+	// Commands with a different version will be rejected by the FSM.
+	CommandVersion = 1
+
+	// OperationClaim denotes claiming a new lease.
+	OperationClaim = "claim"
+
+	// OperationExtend denotes extending an already-held lease.
+	OperationExtend = "extend"
+
+	// OperationExpire denotes removing a lease whose expiry time has
+	// passed.
+	OperationExpire = "expire"
+
+	// OperationSetTime denotes updating the recorded global clock time.
+	OperationSetTime = "setTime"
+)
+
+// Command captures the details of an operation to be run on the FSM.
+type Command struct {
+	// Version of the command format, in case it changes and we need
+	// to handle multiple formats.
+	Version int `yaml:"version"`
+
+	// Operation is one of claim, extend, expire or setTime.
+	Operation string `yaml:"operation"`
+
+	// Namespace is the kind of lease.
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// ModelUUID identifies the model the lease belongs to.
+	ModelUUID string `yaml:"model-uuid,omitempty"`
+
+	// Lease is the name of the lease the command affects.
+	Lease string `yaml:"lease,omitempty"`
+
+	// Holder is the name of the party claiming or extending the lease.
+	Holder string `yaml:"holder,omitempty"`
+
+	// Duration is how long the lease should last.
+	Duration time.Duration `yaml:"duration,omitempty"`
+
+	// OldTime is the previous time for time updates (to avoid
+	// applying stale ones).
+	OldTime time.Time `yaml:"old-time,omitempty"`
+
+	// NewTime is the time to store as the global time.
+	NewTime time.Time `yaml:"new-time,omitempty"`
+}
+
+// Validate checks that the command describes a valid state change.
+func (c *Command) Validate() error {
+	if c.Version != CommandVersion {
+		return errors.NotValidf("version %d", c.Version)
+	}
+	switch c.Operation {
+	case OperationClaim, OperationExtend:
+		if err := c.validateLeaseKey(); err != nil {
+			return errors.Trace(err)
+		}
+		if err := lease.ValidateString(c.Holder); err != nil {
+			return errors.Annotatef(err, "invalid holder")
+		}
+		if c.Duration <= 0 {
+			return errors.NotValidf("%s with duration %s", c.Operation, c.Duration)
+		}
+	case OperationExpire:
+		if err := c.validateLeaseKey(); err != nil {
+			return errors.Trace(err)
+		}
+	case OperationSetTime:
+		if c.OldTime.IsZero() && c.NewTime.IsZero() {
+			return errors.NotValidf("setTime with zero times")
+		}
+		if c.NewTime.Before(c.OldTime) {
+			return errors.NotValidf("setTime moving time backwards")
+		}
+	default:
+		return errors.NotValidf("operation %q", c.Operation)
+	}
+	return nil
+}
+
+func (c *Command) validateLeaseKey() error {
+	if c.Namespace == "" {
+		return errors.NotValidf("%s with empty namespace", c.Operation)
+	}
+	if c.ModelUUID == "" {
+		return errors.NotValidf("%s with empty model UUID", c.Operation)
+	}
+	if err := lease.ValidateString(c.Lease); err != nil {
+		return errors.Annotatef(err, "invalid lease")
+	}
+	return nil
+}
+
+// key returns the lease key the command refers to.
+func (c *Command) key() lease.Key {
+	return lease.Key{
+		Namespace: c.Namespace,
+		ModelUUID: c.ModelUUID,
+		Lease:     c.Lease,
+	}
+}
+
+// Marshal converts this command to a byte slice.
+func (c *Command) Marshal() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// UnmarshalCommand converts a marshalled command []byte into a
+// command.
+func UnmarshalCommand(data []byte) (*Command, error) {
+	var result Command
+	err := yaml.Unmarshal(data, &result)
+	return &result, err
+}
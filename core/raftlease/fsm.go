@@ -0,0 +1,275 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+)
+
+// SnapshotVersion is the current version of the snapshot format.
+const SnapshotVersion = 1
+
+// FSMResponse defines what will be available on the return value
+// from FSM apply calls.
+type FSMResponse interface {
+	// Error is a lease error (rather than anything to do with the
+	// raft machinery).
+	Error() error
+}
+
+// NewFSM returns a new FSM to store lease information.
+func NewFSM() *FSM {
+	return &FSM{
+		entries: make(map[lease.Key]*entry),
+	}
+}
+
+// FSM stores the state of leases in the system, and is driven by
+// commands applied through Raft. It implements raft.FSM.
+type FSM struct {
+	mu         sync.Mutex
+	globalTime time.Time
+	entries    map[lease.Key]*entry
+}
+
+func (f *FSM) claim(key lease.Key, holder string, duration time.Duration) error {
+	if _, found := f.entries[key]; found {
+		return lease.ErrInvalid
+	}
+	f.entries[key] = &entry{
+		holder:   holder,
+		start:    f.globalTime,
+		duration: duration,
+	}
+	return nil
+}
+
+func (f *FSM) extend(key lease.Key, holder string, duration time.Duration) error {
+	entry, found := f.entries[key]
+	if !found {
+		return lease.ErrInvalid
+	}
+	if entry.holder != holder {
+		return lease.ErrInvalid
+	}
+	expiry := f.globalTime.Add(duration)
+	if !expiry.After(entry.start.Add(entry.duration)) {
+		// No extension needed - the lease already expires after the
+		// new time.
+		return nil
+	}
+	// entry is a pointer back into the f.entries map, so this update
+	// isn't lost.
+	entry.start = f.globalTime
+	entry.duration = duration
+	return nil
+}
+
+func (f *FSM) expire(key lease.Key) error {
+	entry, found := f.entries[key]
+	if !found {
+		return lease.ErrInvalid
+	}
+	expiry := entry.start.Add(entry.duration)
+	if f.globalTime.Before(expiry) {
+		return lease.ErrInvalid
+	}
+	delete(f.entries, key)
+	return nil
+}
+
+func (f *FSM) setTime(oldTime, newTime time.Time) error {
+	if !f.globalTime.Equal(oldTime) {
+		return globalclock.ErrConcurrentUpdate
+	}
+	f.globalTime = newTime
+	return nil
+}
+
+// GlobalTime returns the FSM's internal time.
+func (f *FSM) GlobalTime() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.globalTime
+}
+
+// Leases gets information about all of the leases in the system,
+// with expiry times expressed relative to the given local time.
+func (f *FSM) Leases(localTime time.Time) map[lease.Key]lease.Info {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make(map[lease.Key]lease.Info, len(f.entries))
+	for key, entry := range f.entries {
+		globalExpiry := entry.start.Add(entry.duration)
+		remaining := globalExpiry.Sub(f.globalTime)
+		results[key] = lease.Info{
+			Holder: entry.holder,
+			Expiry: localTime.Add(remaining),
+		}
+	}
+	return results
+}
+
+// Apply is part of raft.FSM.
+func (f *FSM) Apply(log *raft.Log) interface{} {
+	var command Command
+	if err := yaml.Unmarshal(log.Data, &command); err != nil {
+		return &response{err: errors.Trace(err)}
+	}
+	if err := command.Validate(); err != nil {
+		return &response{err: errors.Trace(err)}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var err error
+	switch command.Operation {
+	case OperationClaim:
+		err = f.claim(command.key(), command.Holder, command.Duration)
+	case OperationExtend:
+		err = f.extend(command.key(), command.Holder, command.Duration)
+	case OperationExpire:
+		err = f.expire(command.key())
+	case OperationSetTime:
+		err = f.setTime(command.OldTime, command.NewTime)
+	default:
+		err = errors.NotValidf("operation %q", command.Operation)
+	}
+	return &response{err: err}
+}
+
+// Snapshot is part of raft.FSM.
+func (f *FSM) Snapshot() (raft.FSMSnapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries := make(map[SnapshotKey]SnapshotEntry, len(f.entries))
+	for key, entry := range f.entries {
+		entries[SnapshotKey{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+		}] = SnapshotEntry{
+			Holder:   entry.holder,
+			Start:    entry.start,
+			Duration: entry.duration,
+		}
+	}
+	return &Snapshot{
+		Version:    SnapshotVersion,
+		Entries:    entries,
+		GlobalTime: f.globalTime,
+	}, nil
+}
+
+// Restore is part of raft.FSM.
+func (f *FSM) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
+
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var snapshot Snapshot
+	if err := yaml.Unmarshal(data, &snapshot); err != nil {
+		return errors.Trace(err)
+	}
+	if snapshot.Version != SnapshotVersion {
+		return errors.NotValidf("snapshot version %d", snapshot.Version)
+	}
+	if snapshot.Entries == nil {
+		return errors.NotValidf("nil entries")
+	}
+
+	newEntries := make(map[lease.Key]*entry, len(snapshot.Entries))
+	for key, snapEntry := range snapshot.Entries {
+		newEntries[lease.Key{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+		}] = &entry{
+			holder:   snapEntry.Holder,
+			start:    snapEntry.Start,
+			duration: snapEntry.Duration,
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.globalTime = snapshot.GlobalTime
+	f.entries = newEntries
+	return nil
+}
+
+// Snapshot defines the format of the FSM snapshot.
+type Snapshot struct {
+	Version    int                           `yaml:"version"`
+	Entries    map[SnapshotKey]SnapshotEntry `yaml:"entries"`
+	GlobalTime time.Time                     `yaml:"global-time"`
+}
+
+// Persist is part of raft.FSMSnapshot.
+func (s *Snapshot) Persist(sink raft.SnapshotSink) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		sink.Cancel()
+		return errors.Trace(err)
+	}
+	if _, err := sink.Write(data); err != nil {
+		sink.Cancel()
+		return errors.Trace(err)
+	}
+	return sink.Close()
+}
+
+// Release is part of raft.FSMSnapshot.
+func (s *Snapshot) Release() {}
+
+// SnapshotKey defines the format of a lease key in a snapshot.
+type SnapshotKey struct {
+	Namespace string `yaml:"namespace"`
+	ModelUUID string `yaml:"model-uuid"`
+	Lease     string `yaml:"lease"`
+}
+
+// SnapshotEntry defines the format of a lease entry in a snapshot.
+type SnapshotEntry struct {
+	Holder   string        `yaml:"holder"`
+	Start    time.Time     `yaml:"start"`
+	Duration time.Duration `yaml:"duration"`
+}
+
+// entry holds the details of a lease.
+type entry struct {
+	// holder identifies the current holder of the lease.
+	holder string
+
+	// start is the global time at which the lease started.
+	start time.Time
+
+	// duration is the duration for which the lease is valid,
+	// from the start time.
+	duration time.Duration
+}
+
+// response is returned from FSM.Apply, and satisfies FSMResponse.
+type response struct {
+	err error
+}
+
+// Error is part of FSMResponse.
+func (r *response) Error() error {
+	return r.err
+}
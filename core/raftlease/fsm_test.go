@@ -0,0 +1,207 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+var zero time.Time
+
+type fsmSuite struct {
+	testing.IsolationSuite
+
+	fsm *raftlease.FSM
+}
+
+var _ = gc.Suite(&fsmSuite{})
+
+func (s *fsmSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.fsm = raftlease.NewFSM()
+}
+
+func (s *fsmSuite) apply(c *gc.C, command raftlease.Command) raftlease.FSMResponse {
+	data, err := command.Marshal()
+	c.Assert(err, jc.ErrorIsNil)
+	result := s.fsm.Apply(&raft.Log{Data: data})
+	response, ok := result.(raftlease.FSMResponse)
+	c.Assert(ok, gc.Equals, true)
+	return response
+}
+
+func (s *fsmSuite) claim(c *gc.C, holder string, duration time.Duration) error {
+	return s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    holder,
+		Duration:  duration,
+	}).Error()
+}
+
+func (s *fsmSuite) setTime(c *gc.C, oldTime, newTime time.Time) error {
+	return s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   oldTime,
+		NewTime:   newTime,
+	}).Error()
+}
+
+var leaseKey = lease.Key{
+	Namespace: "ns",
+	ModelUUID: "model",
+	Lease:     "lease",
+}
+
+func (s *fsmSuite) TestClaim(c *gc.C) {
+	c.Assert(s.claim(c, "me", time.Second), jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases(zero), gc.DeepEquals, map[lease.Key]lease.Info{
+		leaseKey: {
+			Holder: "me",
+			Expiry: zero.Add(time.Second),
+		},
+	})
+
+	// Can't claim it again.
+	c.Assert(s.claim(c, "you", time.Minute), gc.Equals, lease.ErrInvalid)
+}
+
+func (s *fsmSuite) TestExtend(c *gc.C) {
+	extend := func(holder string, duration time.Duration) error {
+		return s.apply(c, raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationExtend,
+			Namespace: "ns",
+			ModelUUID: "model",
+			Lease:     "lease",
+			Holder:    holder,
+			Duration:  duration,
+		}).Error()
+	}
+	// Can't extend unless we've previously claimed.
+	c.Assert(extend("me", time.Second), gc.Equals, lease.ErrInvalid)
+
+	c.Assert(s.claim(c, "me", time.Second), jc.ErrorIsNil)
+	// Can't extend someone else's lease.
+	c.Assert(extend("you", time.Second), gc.Equals, lease.ErrInvalid)
+
+	c.Assert(extend("me", time.Minute), jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases(zero)[leaseKey].Expiry, gc.Equals, zero.Add(time.Minute))
+
+	// Shorter extensions don't reduce the expiry.
+	c.Assert(extend("me", time.Second), jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases(zero)[leaseKey].Expiry, gc.Equals, zero.Add(time.Minute))
+}
+
+func (s *fsmSuite) TestExpire(c *gc.C) {
+	expire := func() error {
+		return s.apply(c, raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationExpire,
+			Namespace: "ns",
+			ModelUUID: "model",
+			Lease:     "lease",
+		}).Error()
+	}
+	c.Assert(expire(), gc.Equals, lease.ErrInvalid)
+
+	c.Assert(s.claim(c, "me", time.Second), jc.ErrorIsNil)
+	// Not expired yet.
+	c.Assert(expire(), gc.Equals, lease.ErrInvalid)
+
+	c.Assert(s.setTime(c, zero, zero.Add(2*time.Second)), jc.ErrorIsNil)
+	c.Assert(expire(), jc.ErrorIsNil)
+	c.Assert(s.fsm.Leases(zero), gc.HasLen, 0)
+}
+
+func (s *fsmSuite) TestSetTime(c *gc.C) {
+	c.Assert(s.setTime(c, zero, zero.Add(time.Second)), jc.ErrorIsNil)
+	c.Assert(s.fsm.GlobalTime(), gc.Equals, zero.Add(time.Second))
+
+	// Stale updates are rejected.
+	err := s.setTime(c, zero, zero.Add(2*time.Second))
+	c.Assert(err, gc.Equals, globalclock.ErrConcurrentUpdate)
+	c.Assert(s.fsm.GlobalTime(), gc.Equals, zero.Add(time.Second))
+}
+
+func (s *fsmSuite) TestLeasesRelativeToLocalTime(c *gc.C) {
+	c.Assert(s.claim(c, "me", time.Minute), jc.ErrorIsNil)
+	c.Assert(s.setTime(c, zero, zero.Add(10*time.Second)), jc.ErrorIsNil)
+
+	localTime := time.Date(2018, 6, 1, 12, 0, 0, 0, time.UTC)
+	c.Assert(s.fsm.Leases(localTime)[leaseKey].Expiry, gc.Equals, localTime.Add(50*time.Second))
+}
+
+func (s *fsmSuite) TestInvalidCommand(c *gc.C) {
+	err := s.apply(c, raftlease.Command{
+		Version:   300,
+		Operation: raftlease.OperationClaim,
+	}).Error()
+	c.Assert(err, gc.ErrorMatches, "version 300 not valid")
+
+	err = s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: "libera",
+	}).Error()
+	c.Assert(err, gc.ErrorMatches, `operation "libera" not valid`)
+}
+
+func (s *fsmSuite) TestSnapshotRestore(c *gc.C) {
+	c.Assert(s.claim(c, "me", time.Minute), jc.ErrorIsNil)
+	c.Assert(s.setTime(c, zero, zero.Add(10*time.Second)), jc.ErrorIsNil)
+
+	snapshot, err := s.fsm.Snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	sink := &fakeSnapshotSink{}
+	c.Assert(snapshot.Persist(sink), jc.ErrorIsNil)
+	c.Assert(sink.closed, jc.IsTrue)
+	c.Assert(sink.cancelled, jc.IsFalse)
+
+	restored := raftlease.NewFSM()
+	err = restored.Restore(ioutil.NopCloser(bytes.NewReader(sink.Bytes())))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored.GlobalTime(), gc.Equals, s.fsm.GlobalTime())
+	c.Assert(restored.Leases(zero), gc.DeepEquals, s.fsm.Leases(zero))
+}
+
+func (s *fsmSuite) TestRestoreInvalidVersion(c *gc.C) {
+	data := []byte("version: 2\nentries: {}\n")
+	err := s.fsm.Restore(ioutil.NopCloser(bytes.NewReader(data)))
+	c.Assert(err, gc.ErrorMatches, "snapshot version 2 not valid")
+}
+
+type fakeSnapshotSink struct {
+	bytes.Buffer
+	closed    bool
+	cancelled bool
+}
+
+func (s *fakeSnapshotSink) ID() string {
+	return "fake"
+}
+
+func (s *fakeSnapshotSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func (s *fakeSnapshotSink) Cancel() error {
+	s.cancelled = true
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+)
+
+// ReadonlyFSM defines the methods of the lease FSM the store can use
+// - any writes must go through the Client.
+type ReadonlyFSM interface {
+	// Leases returns all of the leases in the FSM, with expiry times
+	// expressed relative to the given local time.
+	Leases(localTime time.Time) map[lease.Key]lease.Info
+
+	// GlobalTime returns the FSM's current global time.
+	GlobalTime() time.Time
+}
+
+// Client is used to get commands applied to the raft log, most
+// likely by forwarding them to the raft leader.
+type Client interface {
+	// Request applies the command, returning the error recorded
+	// by the FSM (such as lease.ErrInvalid) or lease.ErrTimeout if
+	// the command could not be applied in time.
+	Request(command *Command) error
+}
+
+// TrapdoorFunc returns a trapdoor to be attached to lease details
+// for use by clients. This is intended to hold assertions that can
+// be added to state transactions to ensure the lease is still held
+// when the transaction is applied.
+type TrapdoorFunc func(lease.Key, string) lease.Trapdoor
+
+// StoreConfig holds resources and settings needed to run the Store.
+type StoreConfig struct {
+	FSM      ReadonlyFSM
+	Client   Client
+	Trapdoor TrapdoorFunc
+	Clock    clock.Clock
+}
+
+// Validate returns an error if the configuration is missing
+// resources.
+func (config StoreConfig) Validate() error {
+	if config.FSM == nil {
+		return errors.NotValidf("nil FSM")
+	}
+	if config.Client == nil {
+		return errors.NotValidf("nil Client")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// NewStore returns a lease.Store backed by the lease FSM, whose
+// updates are applied through raft by the configured Client.
+func NewStore(config StoreConfig) (*Store, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Store{config: config}, nil
+}
+
+// Store reads lease state from the local copy of the raft FSM, and
+// has writes applied to the raft log by its Client.
+type Store struct {
+	config StoreConfig
+}
+
+// ClaimLease is part of lease.Store.
+func (s *Store) ClaimLease(key lease.Key, request lease.Request) error {
+	return s.request(OperationClaim, key, request)
+}
+
+// ExtendLease is part of lease.Store.
+func (s *Store) ExtendLease(key lease.Key, request lease.Request) error {
+	return s.request(OperationExtend, key, request)
+}
+
+func (s *Store) request(operation string, key lease.Key, request lease.Request) error {
+	if err := request.Validate(); err != nil {
+		return errors.Annotatef(err, "invalid request")
+	}
+	return s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: operation,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+		Holder:    request.Holder,
+		Duration:  request.Duration,
+	})
+}
+
+// ExpireLease is part of lease.Store.
+func (s *Store) ExpireLease(key lease.Key) error {
+	return s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: OperationExpire,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+	})
+}
+
+// Leases is part of lease.Store.
+func (s *Store) Leases() map[lease.Key]lease.Info {
+	leaseMap := s.config.FSM.Leases(s.config.Clock.Now())
+	if s.config.Trapdoor == nil {
+		for key, info := range leaseMap {
+			info.Trapdoor = lease.LockedTrapdoor
+			leaseMap[key] = info
+		}
+		return leaseMap
+	}
+	for key, info := range leaseMap {
+		info.Trapdoor = s.config.Trapdoor(key, info.Holder)
+		leaseMap[key] = info
+	}
+	return leaseMap
+}
+
+// Refresh is part of lease.Store. The FSM is updated as commands
+// are applied to the raft log, so there's nothing to do here.
+func (s *Store) Refresh() error {
+	return nil
+}
+
+// Advance is part of globalclock.Updater.
+func (s *Store) Advance(duration time.Duration) error {
+	oldTime := s.config.FSM.GlobalTime()
+	return s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: OperationSetTime,
+		OldTime:   oldTime,
+		NewTime:   oldTime.Add(duration),
+	})
+}
+
+func (s *Store) runOnLeader(command *Command) error {
+	if err := command.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	err := s.config.Client.Request(command)
+	switch errors.Cause(err) {
+	case lease.ErrInvalid, lease.ErrTimeout, globalclock.ErrConcurrentUpdate:
+		// Callers compare these errors directly, so they
+		// mustn't be wrapped.
+		return errors.Cause(err)
+	}
+	return errors.Trace(err)
+}
@@ -0,0 +1,251 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftlease_test
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/globalclock"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+type storeSuite struct {
+	testing.IsolationSuite
+
+	fsm    *fakeFSM
+	client *fakeClient
+	clock  *testclock.Clock
+	store  *raftlease.Store
+}
+
+var _ = gc.Suite(&storeSuite{})
+
+func (s *storeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	startTime, err := time.Parse(time.RFC3339, "2018-08-08T08:08:08+08:00")
+	c.Assert(err, jc.ErrorIsNil)
+	s.clock = testclock.NewClock(startTime)
+	s.fsm = &fakeFSM{
+		leases:     make(map[lease.Key]lease.Info),
+		globalTime: s.clock.Now(),
+	}
+	s.client = &fakeClient{}
+	store, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:    s.fsm,
+		Client: s.client,
+		Trapdoor: func(key lease.Key, holder string) lease.Trapdoor {
+			return func(out interface{}) error {
+				if s, ok := out.(*string); ok {
+					*s = holder + " holds " + key.Lease
+				}
+				return nil
+			}
+		},
+		Clock: s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store = store
+}
+
+func (s *storeSuite) TestValidate(c *gc.C) {
+	_, err := raftlease.NewStore(raftlease.StoreConfig{
+		Client: s.client,
+		Clock:  s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil FSM not valid")
+	_, err = raftlease.NewStore(raftlease.StoreConfig{
+		FSM:   s.fsm,
+		Clock: s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Client not valid")
+	_, err = raftlease.NewStore(raftlease.StoreConfig{
+		FSM:    s.fsm,
+		Client: s.client,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *storeSuite) TestClaim(c *gc.C) {
+	err := s.store.ClaimLease(
+		lease.Key{"warframe", "rhino", "prime"},
+		lease.Request{"lotus", time.Second},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.commands, gc.DeepEquals, []*raftlease.Command{{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "warframe",
+		ModelUUID: "rhino",
+		Lease:     "prime",
+		Holder:    "lotus",
+		Duration:  time.Second,
+	}})
+}
+
+func (s *storeSuite) TestClaimInvalidRequest(c *gc.C) {
+	err := s.store.ClaimLease(
+		lease.Key{"warframe", "rhino", "prime"},
+		lease.Request{"lotus", 0},
+	)
+	c.Assert(err, gc.ErrorMatches, "invalid request: invalid duration")
+	c.Assert(s.client.commands, gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestExtend(c *gc.C) {
+	err := s.store.ExtendLease(
+		lease.Key{"warframe", "volt", "umbra"},
+		lease.Request{"maroo", 3 * time.Second},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.commands, gc.DeepEquals, []*raftlease.Command{{
+		Version:   1,
+		Operation: raftlease.OperationExtend,
+		Namespace: "warframe",
+		ModelUUID: "volt",
+		Lease:     "umbra",
+		Holder:    "maroo",
+		Duration:  3 * time.Second,
+	}})
+}
+
+func (s *storeSuite) TestExpire(c *gc.C) {
+	err := s.store.ExpireLease(lease.Key{"warframe", "oberon", "prime"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.commands, gc.DeepEquals, []*raftlease.Command{{
+		Version:   1,
+		Operation: raftlease.OperationExpire,
+		Namespace: "warframe",
+		ModelUUID: "oberon",
+		Lease:     "prime",
+	}})
+}
+
+func (s *storeSuite) TestErrorsUnwrapped(c *gc.C) {
+	key := lease.Key{"warframe", "ash", "prime"}
+	for _, expect := range []error{lease.ErrInvalid, lease.ErrTimeout} {
+		s.client.err = errors.Trace(expect)
+		err := s.store.ExpireLease(key)
+		c.Check(err, gc.Equals, expect)
+	}
+
+	s.client.err = errors.New("boom")
+	err := s.store.ExpireLease(key)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *storeSuite) TestLeases(c *gc.C) {
+	in5Seconds := s.clock.Now().Add(5 * time.Second)
+	lease1 := lease.Key{"quam", "olim", "abrahe"}
+	s.fsm.leases[lease1] = lease.Info{
+		Holder: "verdi",
+		Expiry: in5Seconds,
+	}
+
+	result := s.store.Leases()
+	c.Assert(s.fsm.localTime, gc.Equals, s.clock.Now())
+	c.Assert(result, gc.HasLen, 1)
+	info := result[lease1]
+	c.Assert(info.Holder, gc.Equals, "verdi")
+	c.Assert(info.Expiry, gc.Equals, in5Seconds)
+
+	var message string
+	c.Assert(info.Trapdoor(&message), jc.ErrorIsNil)
+	c.Assert(message, gc.Equals, "verdi holds abrahe")
+}
+
+func (s *storeSuite) TestRefresh(c *gc.C) {
+	c.Assert(s.store.Refresh(), jc.ErrorIsNil)
+	c.Assert(s.client.commands, gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestAdvance(c *gc.C) {
+	oldTime := s.fsm.globalTime
+	err := s.store.Advance(10 * time.Second)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.client.commands, gc.DeepEquals, []*raftlease.Command{{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   oldTime,
+		NewTime:   oldTime.Add(10 * time.Second),
+	}})
+}
+
+func (s *storeSuite) TestAdvanceConcurrentUpdate(c *gc.C) {
+	s.client.err = errors.Trace(globalclock.ErrConcurrentUpdate)
+	err := s.store.Advance(10 * time.Second)
+	c.Assert(err, gc.Equals, globalclock.ErrConcurrentUpdate)
+}
+
+func (s *storeSuite) TestAdvanceExpiresLeases(c *gc.C) {
+	// Use a real FSM, applying commands to it directly, to check
+	// that advancing the store's global time lets leases expire.
+	fsm := raftlease.NewFSM()
+	store, err := raftlease.NewStore(raftlease.StoreConfig{
+		FSM:    fsm,
+		Client: &fsmClient{fsm: fsm},
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	key := lease.Key{"warframe", "frost", "prime"}
+	err = store.ClaimLease(key, lease.Request{"lotus", 10 * time.Second})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(store.ExpireLease(key), gc.Equals, lease.ErrInvalid)
+
+	c.Assert(store.Advance(5*time.Second), jc.ErrorIsNil)
+	c.Assert(store.ExpireLease(key), gc.Equals, lease.ErrInvalid)
+	c.Assert(store.Leases(), gc.HasLen, 1)
+
+	c.Assert(store.Advance(6*time.Second), jc.ErrorIsNil)
+	c.Assert(store.ExpireLease(key), jc.ErrorIsNil)
+	c.Assert(store.Leases(), gc.HasLen, 0)
+}
+
+type fakeFSM struct {
+	leases     map[lease.Key]lease.Info
+	globalTime time.Time
+	localTime  time.Time
+}
+
+func (f *fakeFSM) Leases(t time.Time) map[lease.Key]lease.Info {
+	f.localTime = t
+	return f.leases
+}
+
+func (f *fakeFSM) GlobalTime() time.Time {
+	return f.globalTime
+}
+
+type fakeClient struct {
+	commands []*raftlease.Command
+	err      error
+}
+
+func (c *fakeClient) Request(command *raftlease.Command) error {
+	c.commands = append(c.commands, command)
+	return c.err
+}
+
+// fsmClient applies commands directly to an FSM, as the raft leader
+// would.
+type fsmClient struct {
+	fsm *raftlease.FSM
+}
+
+func (c *fsmClient) Request(command *raftlease.Command) error {
+	data, err := command.Marshal()
+	if err != nil {
+		return err
+	}
+	response := c.fsm.Apply(&raft.Log{Data: data})
+	return response.(raftlease.FSMResponse).Error()
+}
@@ -167,6 +167,10 @@ const (
 	// Detached indicates that the storage is not attached to
 	// any machine.
 	Detached Status = "detached"
+
+	// Resizing indicates that the storage is being grown to
+	// a new size.
+	Resizing Status = "resizing"
 )
 
 const (
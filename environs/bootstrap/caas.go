@@ -0,0 +1,207 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bootstrap
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/mongo"
+	coretools "github.com/juju/juju/tools"
+	jujuversion "github.com/juju/juju/version"
+)
+
+const (
+	// caasControllerSeries is the series of the image in which a
+	// controller runs on a CAAS cloud.
+	caasControllerSeries = "bionic"
+
+	// defaultMongoImagePath is the image in which the database of a
+	// controller on a CAAS cloud runs.
+	defaultMongoImagePath = "jujusolutions/juju-db:4.0"
+)
+
+// PrepareCAAS prepares a new controller which is to run in the
+// container substrate of a CAAS cloud, such as a Kubernetes cluster,
+// rather than on a machine. It is otherwise the same as Prepare.
+func PrepareCAAS(
+	ctx environs.BootstrapContext,
+	store jujuclient.ClientStore,
+	args PrepareParams,
+) (caas.ControllerBroker, *config.Config, error) {
+	if err := args.Validate(); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	_, err := store.ControllerByName(args.ControllerName)
+	if err == nil {
+		return nil, nil, errors.AlreadyExistsf("controller %q", args.ControllerName)
+	} else if !errors.IsNotFound(err) {
+		return nil, nil, errors.Annotatef(err, "error reading controller %q info", args.ControllerName)
+	}
+
+	cloudType, ok := args.ModelConfig["type"].(string)
+	if !ok {
+		return nil, nil, errors.NotFoundf("cloud type in base configuration")
+	}
+	p, err := environs.Provider(cloudType)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	cfg, err := config.New(config.NoDefaults, args.ModelConfig)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	cfg, err = p.PrepareConfig(environs.PrepareConfigParams{args.Cloud, cfg})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	broker, err := caas.Open(p, environs.OpenParams{
+		Cloud:  args.Cloud,
+		Config: cfg,
+	})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	controllerBroker, ok := broker.(caas.ControllerBroker)
+	if !ok {
+		return nil, nil, errors.NotSupportedf("bootstrapping a controller on %q", cloudType)
+	}
+
+	details, err := newPrepareDetails(args, cfg, model.CAAS)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if err := decorateAndWriteInfo(
+		store, details, args.ControllerName, cfg.Name(),
+	); err != nil {
+		return nil, nil, errors.Annotatef(err, "cannot create controller %q info", args.ControllerName)
+	}
+	return controllerBroker, cfg, nil
+}
+
+// BootstrapCAAS bootstraps a controller in the container substrate
+// managed by the given broker, using the controller model config
+// returned by PrepareCAAS. The controller's agent binaries come from
+// its image, so none are looked for or uploaded. It returns the
+// service through which the controller's API is reached.
+func BootstrapCAAS(
+	ctx environs.BootstrapContext,
+	broker caas.ControllerBroker,
+	cfg *config.Config,
+	args BootstrapParams,
+) (*caas.Service, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating bootstrap parameters")
+	}
+
+	agentVersion := jujuversion.Current
+	if args.AgentVersion != nil {
+		agentVersion = *args.AgentVersion
+	}
+	cfg, err := cfg.Apply(map[string]interface{}{
+		"agent-version": agentVersion.String(),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	publicKey, err := userPublicSigningKey()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	icfg, err := instancecfg.NewBootstrapInstanceConfig(
+		args.ControllerConfig,
+		args.BootstrapConstraints,
+		args.ModelConstraints,
+		caasControllerSeries,
+		publicKey,
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := icfg.SetTools(coretools.List{{
+		Version: version.Binary{
+			Number: agentVersion,
+			Series: caasControllerSeries,
+			Arch:   arch.AMD64,
+		},
+	}}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// TODO(caas) - install the Juju GUI in CAAS controllers.
+	args.GUIDataSourceBaseURL = ""
+	if err := finalizeInstanceBootstrapConfig(
+		ctx, icfg, args, cfg, broker.Provider().Version(), nil,
+	); err != nil {
+		return nil, errors.Annotate(err, "finalizing bootstrap instance config")
+	}
+
+	// The database runs alongside the agent from the start, so the
+	// replica set key must be known before the agent first runs.
+	sharedSecret, err := mongo.GenerateSharedSecret()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	servingInfo := &icfg.Bootstrap.StateServingInfo
+	servingInfo.SharedSecret = sharedSecret
+
+	// mongod runs in its own container in the controller pod.
+	if icfg.AgentEnvironment == nil {
+		icfg.AgentEnvironment = make(map[string]string)
+	}
+	icfg.AgentEnvironment[agent.MongoSidecar] = "true"
+	agentConfig, err := icfg.AgentConfig(names.NewMachineTag("0"), agentVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	agentConf, err := agentConfig.Render()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	bootstrapParams, err := icfg.Bootstrap.StateInitializationParams.Marshal()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	imagePath := args.ControllerConfig.CAASOperatorImagePath()
+	if imagePath == "" {
+		imagePath = fmt.Sprintf("%s/caas-jujud-operator:%s", "jujusolutions", agentVersion)
+	}
+	var storageSize uint64
+	if args.BootstrapConstraints.RootDisk != nil {
+		storageSize = *args.BootstrapConstraints.RootDisk
+	}
+
+	ctx.Infof("Creating Juju controller stack")
+	service, err := broker.BootstrapController(caas.ControllerBootstrapParams{
+		ControllerUUID:    args.ControllerConfig.ControllerUUID(),
+		OperatorImagePath: imagePath,
+		MongoImagePath:    defaultMongoImagePath,
+		Version:           agentVersion,
+		APIPort:           servingInfo.APIPort,
+		StatePort:         servingInfo.StatePort,
+		StorageSize:       storageSize,
+		AgentConf:         agentConf,
+		BootstrapParams:   bootstrapParams,
+		ServerPEM:         servingInfo.Cert + "\n" + servingInfo.PrivateKey,
+		SharedSecret:      sharedSecret,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "creating controller stack")
+	}
+	ctx.Infof("Controller stack created")
+	return service, nil
+}
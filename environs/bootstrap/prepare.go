@@ -163,6 +163,18 @@ func prepare(
 	if err := env.PrepareForBootstrap(ctx); err != nil {
 		return nil, details, errors.Trace(err)
 	}
+	// We only bootstrap IAAS models on clouds.
+	details, err = newPrepareDetails(args, cfg, model.IAAS)
+	if err != nil {
+		return nil, details, errors.Trace(err)
+	}
+	return env, details, nil
+}
+
+// newPrepareDetails returns the details of the controller being
+// prepared, to be recorded in the client store.
+func newPrepareDetails(args PrepareParams, cfg *config.Config, modelType model.ModelType) (prepareDetails, error) {
+	var details prepareDetails
 
 	// We store the base configuration only; we don't want the
 	// default attributes, generated secrets/certificates, or
@@ -179,7 +191,7 @@ func prepare(
 	// a CA certificate.
 	caCert, ok := args.ControllerConfig.CACert()
 	if !ok {
-		return nil, details, errors.New("controller config is missing CA certificate")
+		return details, errors.New("controller config is missing CA certificate")
 	}
 
 	// We want to store attributes describing how a controller has been configured.
@@ -205,8 +217,7 @@ func prepare(
 	details.Password = args.AdminSecret
 	details.LastKnownAccess = string(permission.SuperuserAccess)
 	details.ModelUUID = cfg.UUID()
-	// We only bootstrap IAAS models.
-	details.ModelType = model.IAAS
+	details.ModelType = modelType
 	details.ControllerDetails.Cloud = args.Cloud.Name
 	details.ControllerDetails.CloudRegion = args.Cloud.Region
 	details.BootstrapConfig.CloudType = args.Cloud.Type
@@ -218,7 +229,7 @@ func prepare(
 	details.CloudStorageEndpoint = args.Cloud.StorageEndpoint
 	details.Credential = args.CredentialName
 
-	return env, details, nil
+	return details, nil
 }
 
 type prepareDetails struct {
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
+	"github.com/juju/juju/logfwd/httpjson"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
 )
@@ -188,6 +189,18 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdHTTPURL sets the http or https URL to which log records
+	// are POSTed as newline-delimited JSON.
+	LogFwdHTTPURL = "logforward-http-url"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// HTTP log forwarding server certificate.
+	LogFwdHTTPCACert = "logforward-http-ca-cert"
+
+	// LogFwdHTTPBatchSize sets the maximum number of log records sent
+	// in a single HTTP request.
+	LogFwdHTTPBatchSize = "logforward-http-batch-size"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -589,9 +602,19 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	httpCfg, hasHTTP := cfg.LogFwdHTTP()
 	if lfCfg, ok := cfg.LogFwdSyslog(); ok {
-		if err := lfCfg.Validate(); err != nil {
-			return errors.Annotate(err, "invalid syslog forwarding config")
+		// Logs may be forwarded only over HTTP, in which case
+		// there's no syslog host.
+		if !hasHTTP || httpCfg.URL == "" || lfCfg.Host != "" {
+			if err := lfCfg.Validate(); err != nil {
+				return errors.Annotate(err, "invalid syslog forwarding config")
+			}
+		}
+	}
+	if hasHTTP {
+		if err := httpCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid HTTP log forwarding config")
 		}
 	}
 
@@ -1032,6 +1055,26 @@ func (c *Config) SnapStoreAssertions() string {
 	return c.asString(SnapStoreAssertionsKey)
 }
 
+// LogFwdHTTP returns the HTTP log forwarding config.
+func (c *Config) LogFwdHTTP() (*httpjson.RawConfig, bool) {
+	var lfCfg httpjson.RawConfig
+	s, ok := c.defined[LogFwdHTTPURL]
+	if !ok || s == "" {
+		return nil, false
+	}
+	lfCfg.URL = s.(string)
+	if s, ok := c.defined[LogForwardEnabled]; ok {
+		lfCfg.Enabled = s.(bool)
+	}
+	if s, ok := c.defined[LogFwdHTTPCACert]; ok && s != "" {
+		lfCfg.CACert = s.(string)
+	}
+	if n, ok := c.defined[LogFwdHTTPBatchSize].(int); ok {
+		lfCfg.BatchSize = n
+	}
+	return &lfCfg, true
+}
+
 // LogFwdSyslog returns the syslog forwarding config.
 func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 	partial := false
@@ -1442,6 +1485,9 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPCACert:       schema.Omit,
+	LogFwdHTTPBatchSize:    schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1898,6 +1944,21 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdHTTPURL: {
+		Description: `The http or https URL to which log records are sent as newline-delimited JSON.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the HTTP log forwarding server certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPBatchSize: {
+		Description: `The maximum number of log records sent in a single HTTP request.`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -552,6 +552,27 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid HTTP log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                       "my-type",
+			"name":                       "my-name",
+			"logforward-enabled":         true,
+			"logforward-http-url":        "https://logs.example.com/ingest",
+			"logforward-http-ca-cert":    testing.CACert,
+			"logforward-http-batch-size": 50,
+		}),
+	}, {
+		about:       "Invalid HTTP log forwarding URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"type":                "my-type",
+			"name":                "my-name",
+			"logforward-enabled":  true,
+			"logforward-http-url": "ftp://logs.example.com",
+		}),
+		err: `invalid HTTP log forwarding config: URL scheme "ftp" not valid`,
 	}, {
 		about:       "Valid container-inherit-properties",
 		useDefaults: config.UseDefaults,
@@ -646,6 +667,17 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	keys, _ := test.attrs["authorized-keys"].(string)
 	c.Assert(cfg.AuthorizedKeys(), gc.Equals, keys)
 
+	httpCfg, hasHTTPCfg := cfg.LogFwdHTTP()
+	if v, ok := test.attrs["logforward-http-url"].(string); ok {
+		c.Assert(hasHTTPCfg, jc.IsTrue)
+		c.Assert(httpCfg.URL, gc.Equals, v)
+		c.Assert(httpCfg.Enabled, gc.Equals, test.attrs["logforward-enabled"])
+		c.Assert(httpCfg.CACert, gc.Equals, test.attrs["logforward-http-ca-cert"])
+		c.Assert(httpCfg.BatchSize, gc.Equals, test.attrs["logforward-http-batch-size"])
+	} else {
+		c.Assert(hasHTTPCfg, jc.IsFalse)
+	}
+
 	lfCfg, hasLogCfg := cfg.LogFwdSyslog()
 	if v, ok := test.attrs["logforward-enabled"].(bool); ok {
 		c.Assert(hasLogCfg, jc.IsTrue)
@@ -43,10 +43,10 @@ const StrictMigration = "strict-migration"
 // This value is only checked using the controller config "features" attrubite.
 const OldPresence = "old-presence"
 
-// DisableRaft will prevent the raft workers from running. At the
-// moment the raft cluster isn't managing leadership, so we want the
-// ability to stop the workers from running if they cause any issues
-// (or just unwanted noise).
+// DisableRaft will prevent the raft workers from running. Leadership
+// and singular leases are then held in mongo instead of the raft
+// cluster. State only checks this flag when it's opened, so changing
+// it requires the controller agents to be restarted.
 const DisableRaft = "disable-raft"
 
 // UpgradeSeries is a development feature flag.
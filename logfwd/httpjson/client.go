@@ -0,0 +1,246 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// ContentType is the content type of the requests sent by Client.
+	ContentType = "application/x-ndjson"
+
+	requestTimeout = 30 * time.Second
+	retryAttempts  = 5
+	retryDelay     = time.Second
+	maxRetryDelay  = 30 * time.Second
+)
+
+// Doer exposes the underlying functionality needed by Client.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client sends log records to a remote HTTP(S) endpoint, as batches
+// of newline-delimited JSON documents.
+type Client struct {
+	// URL is the address to which the records are POSTed.
+	URL string
+
+	// BatchSize is the maximum number of records sent in a single
+	// request.
+	BatchSize int
+
+	// Doer is used to make the requests.
+	Doer Doer
+
+	// Clock is used when backing off between failed requests.
+	Clock clock.Clock
+}
+
+// Open returns a new client for the HTTP(S) endpoint described by the
+// config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	doer := &http.Client{
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsCfg,
+		},
+		Timeout: requestTimeout,
+	}
+	client, err := OpenForDoer(cfg, doer, clock.WallClock)
+	return client, errors.Trace(err)
+}
+
+// OpenForDoer returns a new client for the HTTP(S) endpoint described
+// by the config, which uses the given Doer to make requests.
+func OpenForDoer(cfg RawConfig, doer Doer, clock clock.Clock) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		URL:       cfg.URL,
+		BatchSize: cfg.batchSize(),
+		Doer:      doer,
+		Clock:     clock,
+	}, nil
+}
+
+// Close is part of the logforwarder.SendCloser interface. There are
+// no persistent connections to close.
+func (client *Client) Close() error {
+	return nil
+}
+
+// Send sends the records to the remote endpoint, in batches of at
+// most BatchSize records. Failed requests are retried with an
+// increasing delay; an error is returned if a batch couldn't be sent,
+// in which case none of the following batches will have been sent.
+func (client *Client) Send(records []logfwd.Record) error {
+	for len(records) > 0 {
+		n := client.BatchSize
+		if n <= 0 || n > len(records) {
+			n = len(records)
+		}
+		body, err := encodeBatch(records[:n])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := client.post(body); err != nil {
+			return errors.Trace(err)
+		}
+		records = records[n:]
+	}
+	return nil
+}
+
+func (client *Client) post(body []byte) error {
+	err := retry.Call(retry.CallArgs{
+		Func: func() error {
+			return client.postOnce(body)
+		},
+		IsFatalError: func(err error) bool {
+			_, ok := errors.Cause(err).(*fatalError)
+			return ok
+		},
+		Attempts:    retryAttempts,
+		Delay:       retryDelay,
+		MaxDelay:    maxRetryDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       client.Clock,
+	})
+	if err != nil {
+		return errors.Annotatef(retry.LastError(err), "sending records to %s", client.URL)
+	}
+	return nil
+}
+
+// fatalError is returned for responses which won't succeed if the
+// request is retried.
+type fatalError struct {
+	error
+}
+
+func (client *Client) postOnce(body []byte) error {
+	req, err := http.NewRequest("POST", client.URL, bytes.NewReader(body))
+	if err != nil {
+		return &fatalError{errors.Trace(err)}
+	}
+	req.Header.Set("Content-Type", ContentType)
+	resp, err := client.Doer.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		io.Copy(ioutil.Discard, resp.Body)
+		return nil
+	}
+	message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = errors.Errorf("%s: %s", resp.Status, bytes.TrimSpace(message))
+	// Only server errors and throttling are worth retrying.
+	if resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return &fatalError{err}
+	}
+	return err
+}
+
+func encodeBatch(records []logfwd.Record) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, rec := range records {
+		if err := encoder.Encode(documentFromRecord(rec)); err != nil {
+			return nil, errors.Annotatef(err, "encoding record %d", rec.ID)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// Document is the JSON representation of a single log record.
+type Document struct {
+	ID         int64     `json:"id"`
+	Timestamp  time.Time `json:"timestamp"`
+	Level      string    `json:"level"`
+	Message    string    `json:"message"`
+	Controller string    `json:"controller-uuid"`
+	Model      string    `json:"model-uuid"`
+	Hostname   string    `json:"hostname"`
+	OriginType string    `json:"origin-type"`
+	OriginName string    `json:"origin-name"`
+	Software   string    `json:"software"`
+	Version    string    `json:"version"`
+	Module     string    `json:"module,omitempty"`
+	Source     string    `json:"source,omitempty"`
+	Audit      *Audit    `json:"audit,omitempty"`
+}
+
+// Audit is the JSON representation of the details of an audit record.
+type Audit struct {
+	Kind           string `json:"kind"`
+	ConversationID string `json:"conversation-id"`
+	ConnectionID   string `json:"connection-id"`
+	Who            string `json:"who"`
+	What           string `json:"what,omitempty"`
+	ModelName      string `json:"model"`
+	ModelUUID      string `json:"model-uuid"`
+	RequestID      uint64 `json:"request-id,omitempty"`
+	Facade         string `json:"facade,omitempty"`
+	Method         string `json:"method,omitempty"`
+	Version        int    `json:"version,omitempty"`
+}
+
+func documentFromRecord(rec logfwd.Record) Document {
+	doc := Document{
+		ID:         rec.ID,
+		Timestamp:  rec.Timestamp.UTC(),
+		Level:      rec.Level.String(),
+		Message:    rec.Message,
+		Controller: rec.Origin.ControllerUUID,
+		Model:      rec.Origin.ModelUUID,
+		Hostname:   rec.Origin.Hostname,
+		OriginType: rec.Origin.Type.String(),
+		OriginName: rec.Origin.Name,
+		Software:   rec.Origin.Software.Name,
+		Version:    rec.Origin.Software.Version.String(),
+		Module:     rec.Location.Module,
+	}
+	if rec.Location.Filename != "" {
+		doc.Source = fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line)
+	}
+	if audit := rec.Audit; audit != nil {
+		doc.Audit = &Audit{
+			Kind:           audit.Kind,
+			ConversationID: audit.ConversationID,
+			ConnectionID:   audit.ConnectionID,
+			Who:            audit.Who,
+			What:           audit.What,
+			ModelName:      audit.ModelName,
+			ModelUUID:      audit.ModelUUID,
+			RequestID:      audit.RequestID,
+			Facade:         audit.Facade,
+			Method:         audit.Method,
+			Version:        audit.Version,
+		}
+	}
+	return doc
+}
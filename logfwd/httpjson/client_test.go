@@ -0,0 +1,199 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	doer  *fakeDoer
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.doer = &fakeDoer{}
+	s.clock = testclock.NewClock(time.Time{})
+}
+
+func (s *ClientSuite) open(c *gc.C, batchSize int) *httpjson.Client {
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		Enabled:   true,
+		URL:       "https://logs.example.com/ingest",
+		BatchSize: batchSize,
+	}, s.doer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) TestOpenDefaultBatchSize(c *gc.C) {
+	client := s.open(c, 0)
+	c.Check(client.BatchSize, gc.Equals, httpjson.DefaultBatchSize)
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := httpjson.OpenForDoer(httpjson.RawConfig{Enabled: true}, s.doer, s.clock)
+	c.Assert(err, gc.ErrorMatches, "empty URL not valid")
+}
+
+func (s *ClientSuite) TestSendBatches(c *gc.C) {
+	client := s.open(c, 2)
+	records := []logfwd.Record{record(1), record(2), record(3)}
+	records[2].Audit = &logfwd.AuditDetails{
+		Kind:   "request",
+		Who:    "bob",
+		Facade: "Application",
+		Method: "Deploy",
+	}
+
+	err := client.Send(records)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.doer.requests, gc.HasLen, 2)
+	req := s.doer.requests[0]
+	c.Check(req.Method, gc.Equals, "POST")
+	c.Check(req.URL.String(), gc.Equals, "https://logs.example.com/ingest")
+	c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/x-ndjson")
+
+	docs := s.doer.documents(c, 0)
+	c.Assert(docs, gc.HasLen, 2)
+	c.Check(docs[0], jc.DeepEquals, httpjson.Document{
+		ID:         1,
+		Timestamp:  time.Date(2018, 8, 8, 8, 8, 8, 0, time.UTC),
+		Level:      "INFO",
+		Message:    "you should know",
+		Controller: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		Model:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Hostname:   "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+		OriginType: "machine",
+		OriginName: "99",
+		Software:   "jujud-machine-agent",
+		Version:    "2.4.1",
+		Module:     "juju.test",
+		Source:     "test.go:42",
+	})
+	c.Check(docs[1].ID, gc.Equals, int64(2))
+
+	docs = s.doer.documents(c, 1)
+	c.Assert(docs, gc.HasLen, 1)
+	c.Check(docs[0].Audit, jc.DeepEquals, &httpjson.Audit{
+		Kind:   "request",
+		Who:    "bob",
+		Facade: "Application",
+		Method: "Deploy",
+	})
+}
+
+func (s *ClientSuite) TestSendRetriesServerErrors(c *gc.C) {
+	client := s.open(c, 0)
+	s.doer.statuses = []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- client.Send([]logfwd.Record{record(1)})
+	}()
+	// The delay doubles after each failure.
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(2*time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case err := <-errs:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for Send")
+	}
+	c.Assert(s.doer.requests, gc.HasLen, 3)
+}
+
+func (s *ClientSuite) TestSendClientErrorNotRetried(c *gc.C) {
+	client := s.open(c, 0)
+	s.doer.statuses = []int{http.StatusBadRequest}
+
+	err := client.Send([]logfwd.Record{record(1)})
+	c.Assert(err, gc.ErrorMatches, `sending records to https://logs.example.com/ingest: 400 Bad Request: nope`)
+	c.Assert(s.doer.requests, gc.HasLen, 1)
+}
+
+func record(id int64) logfwd.Record {
+	return logfwd.Record{
+		Origin: logfwd.Origin{
+			ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "machine-99.deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "99",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("2.4.1"),
+			},
+		},
+		ID:        id,
+		Timestamp: time.Date(2018, 8, 8, 8, 8, 8, 0, time.UTC),
+		Level:     loggo.INFO,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.test",
+			Filename: "test.go",
+			Line:     42,
+		},
+		Message: "you should know",
+	}
+}
+
+type fakeDoer struct {
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+}
+
+func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	d.requests = append(d.requests, req)
+	d.bodies = append(d.bodies, body)
+	status := http.StatusNoContent
+	if len(d.statuses) > 0 {
+		status, d.statuses = d.statuses[0], d.statuses[1:]
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Body:       ioutil.NopCloser(bytes.NewBufferString("nope")),
+	}, nil
+}
+
+func (d *fakeDoer) documents(c *gc.C, i int) []httpjson.Document {
+	var docs []httpjson.Document
+	scanner := bufio.NewScanner(bytes.NewReader(d.bodies[i]))
+	for scanner.Scan() {
+		var doc httpjson.Document
+		c.Assert(json.Unmarshal(scanner.Bytes(), &doc), jc.ErrorIsNil)
+		docs = append(docs, doc)
+	}
+	c.Assert(scanner.Err(), jc.ErrorIsNil)
+	return docs
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+// DefaultBatchSize is the maximum number of records sent in a single
+// request when RawConfig.BatchSize is not set.
+const DefaultBatchSize = 100
+
+// RawConfig holds the raw configuration data for a connection to an
+// HTTP(S) log forwarding target.
+type RawConfig struct {
+	// Enabled is true if the log forwarding feature is enabled.
+	Enabled bool
+
+	// URL is the http or https URL to which batches of records are
+	// POSTed.
+	URL string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting. If it
+	// is empty, the system roots are used.
+	CACert string
+
+	// BatchSize is the maximum number of records sent in a single
+	// request. If it is zero, DefaultBatchSize is used.
+	BatchSize int
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("empty URL")
+		}
+	} else {
+		u, err := url.Parse(cfg.URL)
+		if err != nil {
+			return errors.NotValidf("URL %q", cfg.URL)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.NotValidf("URL scheme %q", u.Scheme)
+		}
+		if u.Host == "" {
+			return errors.NotValidf("URL %q without host", cfg.URL)
+		}
+	}
+	if cfg.BatchSize < 0 {
+		return errors.NotValidf("negative BatchSize")
+	}
+	if _, err := cfg.tlsConfig(); err != nil {
+		return errors.Annotate(err, "validating TLS config")
+	}
+	return nil
+}
+
+func (cfg RawConfig) batchSize() int {
+	if cfg.BatchSize == 0 {
+		return DefaultBatchSize
+	}
+	return cfg.BatchSize
+}
+
+// tlsConfig returns the TLS config to use when connecting, or nil
+// if the defaults should be used.
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+	return &tls.Config{
+		RootCAs: rootCAs,
+	}, nil
+}
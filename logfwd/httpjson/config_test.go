@@ -0,0 +1,59 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Enabled:   true,
+		URL:       "https://logs.example.com/ingest",
+		CACert:    coretesting.CACert,
+		BatchSize: 10,
+	}
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg httpjson.RawConfig
+	c.Check(cfg.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateErrors(c *gc.C) {
+	for i, test := range []struct {
+		cfg    httpjson.RawConfig
+		expect string
+	}{{
+		cfg:    httpjson.RawConfig{Enabled: true},
+		expect: "empty URL not valid",
+	}, {
+		cfg:    httpjson.RawConfig{URL: "ftp://logs.example.com"},
+		expect: `URL scheme "ftp" not valid`,
+	}, {
+		cfg:    httpjson.RawConfig{URL: "http:///ingest"},
+		expect: `URL "http:///ingest" without host not valid`,
+	}, {
+		cfg:    httpjson.RawConfig{URL: "http://logs.example.com", BatchSize: -1},
+		expect: "negative BatchSize not valid",
+	}, {
+		cfg:    httpjson.RawConfig{URL: "http://logs.example.com", CACert: "<bad>"},
+		expect: "validating TLS config: parsing CA certificate: .*",
+	}} {
+		c.Logf("test %d", i)
+		c.Check(test.cfg.Validate(), gc.ErrorMatches, test.expect)
+	}
+}
@@ -30,6 +30,7 @@ func (s *OriginTypeSuite) TestParseOriginTypeValid(c *gc.C) {
 		"user":    logfwd.OriginTypeUser,
 		"machine": logfwd.OriginTypeMachine,
 		"unit":    logfwd.OriginTypeUnit,
+		"audit":   logfwd.OriginTypeAudit,
 	}
 	for str, expected := range tests {
 		c.Logf("trying %q", str)
@@ -59,6 +60,7 @@ func (s *OriginTypeSuite) TestString(c *gc.C) {
 		logfwd.OriginTypeUser:    "user",
 		logfwd.OriginTypeMachine: "machine",
 		logfwd.OriginTypeUnit:    "unit",
+		logfwd.OriginTypeAudit:   "audit",
 	}
 	for ot, expected := range tests {
 		c.Logf("trying %q", ot)
@@ -75,6 +77,7 @@ func (s *OriginTypeSuite) TestValidateValid(c *gc.C) {
 		logfwd.OriginTypeUser,
 		logfwd.OriginTypeMachine,
 		logfwd.OriginTypeUnit,
+		logfwd.OriginTypeAudit,
 	}
 	for _, ot := range tests {
 		c.Logf("trying %q", ot)
@@ -108,6 +111,7 @@ func (s *OriginTypeSuite) TestValidateNameValid(c *gc.C) {
 		logfwd.OriginTypeUser:    "a-user",
 		logfwd.OriginTypeMachine: "99",
 		logfwd.OriginTypeUnit:    "svc-a/0",
+		logfwd.OriginTypeAudit:   "0",
 	}
 	for ot, name := range tests {
 		c.Logf("trying %q + %q", ot, name)
@@ -139,6 +143,10 @@ func (s *OriginTypeSuite) TestValidateNameInvalid(c *gc.C) {
 		ot:   logfwd.OriginTypeUnit,
 		name: "...",
 		err:  `bad unit name`,
+	}, {
+		ot:   logfwd.OriginTypeAudit,
+		name: "...",
+		err:  `bad machine name`,
 	}}
 	for _, test := range tests {
 		c.Logf("trying %q + %q", test.ot, test.name)
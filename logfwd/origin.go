@@ -24,6 +24,7 @@ const (
 	OriginTypeUser               = iota
 	OriginTypeMachine
 	OriginTypeUnit
+	OriginTypeAudit
 )
 
 var originTypes = map[OriginType]string{
@@ -31,6 +32,7 @@ var originTypes = map[OriginType]string{
 	OriginTypeUser:    names.UserTagKind,
 	OriginTypeMachine: names.MachineTagKind,
 	OriginTypeUnit:    names.UnitTagKind,
+	OriginTypeAudit:   "audit",
 }
 
 // OriginType is the "enum" type for the different kinds of log record
@@ -85,6 +87,12 @@ func (ot OriginType) ValidateName(name string) error {
 		if !names.IsValidUnit(name) {
 			return errors.NewNotValid(nil, "bad unit name")
 		}
+	case OriginTypeAudit:
+		// Audit records are written by the API server on a
+		// controller machine.
+		if !names.IsValidMachine(name) {
+			return errors.NewNotValid(nil, "bad machine name")
+		}
 	}
 	return nil
 }
@@ -122,6 +130,15 @@ func OriginForUnitAgent(tag names.UnitTag, controller, model string, ver version
 	return originForAgent(OriginTypeUnit, tag, controller, model, ver)
 }
 
+// OriginForAudit populates a new origin for audit records written by
+// the API server on the controller machine.
+func OriginForAudit(tag names.MachineTag, controller, model string, ver version.Number) Origin {
+	origin := originForJuju(OriginTypeAudit, tag.Id(), controller, model, ver)
+	origin.Hostname = fmt.Sprintf("%s.%s", tag, model)
+	origin.Software.Name = "jujud-audit-log"
+	return origin
+}
+
 func originForAgent(oType OriginType, tag names.Tag, controller, model string, ver version.Number) Origin {
 	origin := originForJuju(oType, tag.Id(), controller, model, ver)
 	origin.Hostname = fmt.Sprintf("%s.%s", tag, model)
@@ -33,6 +33,31 @@ type Record struct {
 
 	// Message is the record's body. It may be empty.
 	Message string
+
+	// Audit holds the details of an audit log record. It is set
+	// only for records with an audit origin.
+	Audit *AuditDetails
+}
+
+// AuditDetails describes the API conversation, request or response
+// that an audit log record was written for.
+type AuditDetails struct {
+	// Kind is "conversation", "request" or "errors", matching the
+	// kinds of record written to the audit log.
+	Kind string
+
+	ConversationID string
+	ConnectionID   string
+	Who            string
+	What           string
+	ModelName      string
+	ModelUUID      string
+
+	// These are set for request and errors records.
+	RequestID uint64
+	Facade    string
+	Method    string
+	Version   int
 }
 
 // Validate ensures that the record is correct.
@@ -53,6 +78,14 @@ func (rec Record) Validate() error {
 
 	// rec.Message may be anything, so we don't check it.
 
+	isAudit := rec.Origin.Type == OriginTypeAudit
+	if isAudit && rec.Audit == nil {
+		return errors.NewNotValid(nil, "missing Audit details for audit origin")
+	}
+	if !isAudit && rec.Audit != nil {
+		return errors.NewNotValid(nil, "Audit details set for non-audit origin")
+	}
+
 	return nil
 }
 
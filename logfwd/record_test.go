@@ -67,6 +67,29 @@ func (s *RecordSuite) TestValidateBadLocation(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `invalid Location: Line set but Filename empty`)
 }
 
+func (s *RecordSuite) TestValidateAudit(c *gc.C) {
+	rec := validRecord
+	rec.Origin.Type = logfwd.OriginTypeAudit
+	rec.Origin.Name = "0"
+
+	err := rec.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `missing Audit details for audit origin`)
+
+	rec.Audit = &logfwd.AuditDetails{Kind: "conversation", Who: "bob"}
+	c.Check(rec.Validate(), jc.ErrorIsNil)
+}
+
+func (s *RecordSuite) TestValidateAuditDetailsWithoutAuditOrigin(c *gc.C) {
+	rec := validRecord
+	rec.Audit = &logfwd.AuditDetails{Kind: "conversation"}
+
+	err := rec.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `Audit details set for non-audit origin`)
+}
+
 type LocationSuite struct {
 	testing.IsolationSuite
 }
@@ -144,20 +144,25 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 					Value: rfc5424.StructuredDataParamValue(rec.Origin.ModelUUID),
 				}},
 			},
-			&sdelements.Private{
-				Name: "log",
-				PEN:  sdelements.PrivateEnterpriseNumber(rec.Origin.Software.PrivateEnterpriseNumber),
-				Data: []rfc5424.StructuredDataParam{{
-					Name:  "module",
-					Value: rfc5424.StructuredDataParamValue(rec.Location.Module),
-				}, {
-					Name:  "source",
-					Value: rfc5424.StructuredDataParamValue(fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line)),
-				}},
-			},
 		},
 		Msg: rec.Message,
 	}
+	pen := sdelements.PrivateEnterpriseNumber(rec.Origin.Software.PrivateEnterpriseNumber)
+	if rec.Audit != nil {
+		msg.StructuredData = append(msg.StructuredData, auditElement(pen, rec.Audit))
+	} else {
+		msg.StructuredData = append(msg.StructuredData, &sdelements.Private{
+			Name: "log",
+			PEN:  pen,
+			Data: []rfc5424.StructuredDataParam{{
+				Name:  "module",
+				Value: rfc5424.StructuredDataParamValue(rec.Location.Module),
+			}, {
+				Name:  "source",
+				Value: rfc5424.StructuredDataParamValue(fmt.Sprintf("%s:%d", rec.Location.Filename, rec.Location.Line)),
+			}},
+		})
+	}
 
 	switch rec.Level {
 	case loggo.ERROR:
@@ -177,3 +182,47 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 	}
 	return msg, nil
 }
+
+// auditElement returns the structured data describing an audit log
+// record, so that it can be ingested without parsing the message.
+func auditElement(pen sdelements.PrivateEnterpriseNumber, audit *logfwd.AuditDetails) rfc5424.StructuredDataElement {
+	data := []rfc5424.StructuredDataParam{{
+		Name:  "kind",
+		Value: rfc5424.StructuredDataParamValue(audit.Kind),
+	}, {
+		Name:  "conversation-id",
+		Value: rfc5424.StructuredDataParamValue(audit.ConversationID),
+	}, {
+		Name:  "connection-id",
+		Value: rfc5424.StructuredDataParamValue(audit.ConnectionID),
+	}, {
+		Name:  "who",
+		Value: rfc5424.StructuredDataParamValue(audit.Who),
+	}, {
+		Name:  "model",
+		Value: rfc5424.StructuredDataParamValue(audit.ModelName),
+	}, {
+		Name:  "model-uuid",
+		Value: rfc5424.StructuredDataParamValue(audit.ModelUUID),
+	}}
+	if audit.Kind != "conversation" {
+		data = append(data, rfc5424.StructuredDataParam{
+			Name:  "request-id",
+			Value: rfc5424.StructuredDataParamValue(fmt.Sprint(audit.RequestID)),
+		}, rfc5424.StructuredDataParam{
+			Name:  "facade",
+			Value: rfc5424.StructuredDataParamValue(audit.Facade),
+		}, rfc5424.StructuredDataParam{
+			Name:  "method",
+			Value: rfc5424.StructuredDataParamValue(audit.Method),
+		}, rfc5424.StructuredDataParam{
+			Name:  "version",
+			Value: rfc5424.StructuredDataParamValue(fmt.Sprint(audit.Version)),
+		})
+	}
+	return &sdelements.Private{
+		Name: "audit",
+		PEN:  pen,
+		Data: data,
+	}
+}
@@ -148,6 +148,78 @@ func (s *ClientSuite) TestSendLogFull(c *gc.C) {
 	})
 }
 
+func (s *ClientSuite) TestSendAudit(c *gc.C) {
+	tag := names.NewMachineTag("0")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	ts := time.Unix(12345, 0)
+	rec := logfwd.Record{
+		Origin:    logfwd.OriginForAudit(tag, cID, mID, ver),
+		Timestamp: ts,
+		Level:     loggo.INFO,
+		Message:   "bob: Application.Deploy",
+		Audit: &logfwd.AuditDetails{
+			Kind:           "request",
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AC1",
+			Who:            "bob",
+			ModelName:      "admin/default",
+			ModelUUID:      "cafebabe-2f18-4fd2-967d-db9663db7bea",
+			RequestID:      25,
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        8,
+		},
+	}
+	client := syslog.Client{Sender: s.sender}
+
+	err := client.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Send")
+	msg := s.stub.Calls()[0].Args[0].(rfc5424.Message)
+	c.Check(msg.Severity, gc.Equals, rfc5424.SeverityInformational)
+	c.Check(msg.Hostname.FQDN, gc.Equals, "machine-0.deadbeef-2f18-4fd2-967d-db9663db7bea")
+	c.Check(string(msg.AppName), gc.Equals, "jujud-audit-log-deadbeef-2f18-4fd2-967d-db9663db")
+	c.Assert(msg.StructuredData, gc.HasLen, 3)
+	c.Check(msg.StructuredData[2], jc.DeepEquals, &sdelements.Private{
+		Name: "audit",
+		PEN:  28978,
+		Data: []rfc5424.StructuredDataParam{{
+			Name:  "kind",
+			Value: "request",
+		}, {
+			Name:  "conversation-id",
+			Value: "0123456789abcdef",
+		}, {
+			Name:  "connection-id",
+			Value: "AC1",
+		}, {
+			Name:  "who",
+			Value: "bob",
+		}, {
+			Name:  "model",
+			Value: "admin/default",
+		}, {
+			Name:  "model-uuid",
+			Value: "cafebabe-2f18-4fd2-967d-db9663db7bea",
+		}, {
+			Name:  "request-id",
+			Value: "25",
+		}, {
+			Name:  "facade",
+			Value: "Application",
+		}, {
+			Name:  "method",
+			Value: "Deploy",
+		}, {
+			Name:  "version",
+			Value: "8",
+		}},
+	})
+}
+
 func (s *ClientSuite) TestSendLogLevels(c *gc.C) {
 	tag := names.NewMachineTag("99")
 	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
@@ -4,17 +4,23 @@
 package migration
 
 import (
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/naturalsort"
+	"github.com/juju/retry"
+	"github.com/juju/utils"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/resource"
@@ -63,6 +69,134 @@ func ImportModel(st *state.State, bytes []byte) (*state.Model, *state.State, err
 	return dbModel, dbState, nil
 }
 
+// TrialImportModel deserializes a model description from the bytes
+// and imports it into the database as a temporary model, to verify
+// that a migration of the model would be accepted, before removing
+// all trace of it again. The temporary model is given a fresh UUID
+// and name so that it can't be confused with the model itself.
+//
+// Note that the trial import is written to the controller's own
+// database, as there is nowhere else to import it to. Removal of the
+// temporary model is retried, and an error is returned if it can't be
+// removed so that the leftover model isn't silently ignored.
+func TrialImportModel(pool *state.StatePool, bytes []byte) (err error) {
+	st := pool.SystemState()
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	trialUUID, err := utils.NewUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	trial := &trialModel{
+		Model: model,
+		uuid:  trialUUID.String(),
+		name:  "migration-check-" + trialUUID.String()[:8],
+	}
+
+	// Importing adds the model's cloud credential if the controller
+	// doesn't know about it, so remember whether it needs removing.
+	var newCredential *names.CloudCredentialTag
+	if creds := model.CloudCredential(); creds != nil {
+		credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+		if names.IsValidCloudCredential(credID) {
+			credTag := names.NewCloudCredentialTag(credID)
+			if _, err := st.CloudCredential(credTag); errors.IsNotFound(err) {
+				newCredential = &credTag
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
+	defer func() {
+		cleanupErr := retry.Call(retry.CallArgs{
+			Func: func() error {
+				return removeTrialModel(pool, trial.uuid, newCredential)
+			},
+			NotifyFunc: func(err error, attempt int) {
+				logger.Warningf("removing trial import %s of model %s (attempt %d): %v",
+					trial.uuid, model.Tag().Id(), attempt, err)
+			},
+			Attempts:    trialCleanupAttempts,
+			Delay:       time.Second,
+			BackoffFunc: retry.DoubleDelay,
+			Clock:       clock.WallClock,
+		})
+		if cleanupErr == nil {
+			return
+		}
+		cleanupErr = retry.LastError(cleanupErr)
+		logger.Errorf("removing trial import %s of model %s: %v", trial.uuid, model.Tag().Id(), cleanupErr)
+		if err == nil {
+			err = errors.Annotatef(cleanupErr, "removing trial import %s", trial.uuid)
+		} else {
+			err = errors.Errorf("%v (trial import %s not removed: %v)", err, trial.uuid, cleanupErr)
+		}
+	}()
+	_, trialSt, err := st.Import(trial)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	trialSt.Close()
+	return nil
+}
+
+// trialCleanupAttempts is the number of times removal of a trial
+// import is attempted before giving up.
+const trialCleanupAttempts = 3
+
+func removeTrialModel(pool *state.StatePool, modelUUID string, credential *names.CloudCredentialTag) error {
+	// The import may have failed part way through, so whatever
+	// documents were written need removing.
+	st := pool.SystemState()
+	exists, err := st.ModelExists(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if exists {
+		trialSt, err := pool.Get(modelUUID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer trialSt.Release()
+		if err := trialSt.RemoveImportingModelDocs(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if credential != nil {
+		if err := st.RemoveCloudCredential(*credential); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// trialModel wraps a model description so that it's imported with a
+// different UUID and name.
+type trialModel struct {
+	description.Model
+	uuid string
+	name string
+}
+
+// Tag is part of description.Model.
+func (m *trialModel) Tag() names.ModelTag {
+	return names.NewModelTag(m.uuid)
+}
+
+// Config is part of description.Model.
+func (m *trialModel) Config() map[string]interface{} {
+	cfg := make(map[string]interface{})
+	for key, value := range m.Model.Config() {
+		cfg[key] = value
+	}
+	cfg["uuid"] = m.uuid
+	cfg["name"] = m.name
+	return cfg
+}
+
 // CharmDownlaoder defines a single method that is used to download a
 // charm from the source controller in a migration.
 type CharmDownloader interface {
@@ -88,6 +88,28 @@ func (s *ImportSuite) TestImportModel(c *gc.C) {
 	c.Assert(dbConfig.Name(), gc.Equals, "new-model")
 }
 
+func (s *ImportSuite) TestTrialImportModel(c *gc.C) {
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model being checked already exists here, but the trial
+	// import uses a fresh UUID and name so it is still accepted.
+	err = migration.TrialImportModel(s.StatePool, bytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing is left behind.
+	uuids, err := s.State.AllModelUUIDs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uuids, gc.DeepEquals, []string{s.State.ModelUUID()})
+}
+
+func (s *ImportSuite) TestTrialImportModelBadBytes(c *gc.C) {
+	err := migration.TrialImportModel(s.StatePool, []byte("not a model"))
+	c.Assert(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
+}
+
 func (s *ImportSuite) TestUploadBinariesConfigValidate(c *gc.C) {
 	type T migration.UploadBinariesConfig // alias for brevity
 
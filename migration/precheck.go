@@ -5,6 +5,7 @@ package migration
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -12,9 +13,11 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/crossmodel"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/tools"
@@ -35,6 +38,9 @@ type PrecheckBackend interface {
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
+	AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error)
+	AllVolumeSnapshotIDs() ([]string, error)
+	ResizingVolumeIDs() ([]string, error)
 }
 
 // Pool defines the interface to a StatePool used by the migration
@@ -74,6 +80,7 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	ExposedEndpoints() map[string]state.ExposedEndpoint
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -86,6 +93,8 @@ type PrecheckUnit interface {
 	AgentStatus() (status.StatusInfo, error)
 	Status() (status.StatusInfo, error)
 	AgentPresence() (bool, error)
+	CharmState() (map[string]string, error)
+	OpenedPortsByEndpoint() (map[string][]network.PortRange, error)
 }
 
 // PrecheckRelation describes the state interface for relations needed
@@ -118,45 +127,101 @@ func SourcePrecheck(
 	modelPresence ModelPresence,
 	controllerPresence ModelPresence,
 ) error {
-	ctx := precheckContext{backend, modelPresence}
+	_, err := sourcePrecheck(backend, modelPresence, controllerPresence, false)
+	return errors.Trace(err)
+}
+
+// SourcePrecheckAll runs the same checks as SourcePrecheck, but rather
+// than stopping at the first unmet precondition it returns a
+// description of every one. An error is returned only if the checks
+// could not be run.
+func SourcePrecheckAll(
+	backend PrecheckBackend,
+	modelPresence ModelPresence,
+	controllerPresence ModelPresence,
+) ([]string, error) {
+	return sourcePrecheck(backend, modelPresence, controllerPresence, true)
+}
+
+func sourcePrecheck(
+	backend PrecheckBackend,
+	modelPresence ModelPresence,
+	controllerPresence ModelPresence,
+	collect bool,
+) ([]string, error) {
+	ctx := &precheckContext{backend: backend, presence: modelPresence, collect: collect}
 	if err := ctx.checkModel(); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	if err := ctx.checkMachines(); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	appUnits, err := ctx.checkApplications()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	if err := ctx.checkRelations(appUnits); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
+	}
+
+	if err := ctx.checkOffers(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := ctx.checkVolumeSnapshots(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if err := ctx.checkVolumeResizes(); err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
-		return errors.Annotate(err, "checking cleanups")
+		return nil, errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		if err := ctx.failed(errors.New("cleanup needed")); err != nil {
+			return nil, err
+		}
 	}
 
 	// Check the source controller.
 	controllerBackend, err := backend.ControllerBackend()
 	if err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
-	controllerCtx := precheckContext{controllerBackend, controllerPresence}
+	controllerCtx := &precheckContext{backend: controllerBackend, presence: controllerPresence, collect: collect}
 	if err := controllerCtx.checkController(); err != nil {
-		return errors.Annotate(err, "controller")
+		return nil, errors.Annotate(err, "controller")
 	}
-	return nil
+	failures := ctx.failures
+	for _, failure := range controllerCtx.failures {
+		failures = append(failures, "controller: "+failure)
+	}
+	return failures, nil
 }
 
 type precheckContext struct {
 	backend  PrecheckBackend
 	presence ModelPresence
+
+	// collect is true when every unmet precondition should be
+	// recorded in failures, rather than returned as an error.
+	collect  bool
+	failures []string
+}
+
+// failed records an unmet precondition. If failures are being
+// collected it returns nil so that checking continues, otherwise
+// it returns err.
+func (ctx *precheckContext) failed(err error) error {
+	if !ctx.collect {
+		return err
+	}
+	ctx.failures = append(ctx.failures, err.Error())
+	return nil
 }
 
 func (ctx *precheckContext) checkModel() error {
@@ -165,10 +230,14 @@ func (ctx *precheckContext) checkModel() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.failed(errors.Errorf("model is %s", model.Life())); err != nil {
+			return err
+		}
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		if err := ctx.failed(errors.New("model is being imported as part of another migration")); err != nil {
+			return err
+		}
 	}
 	if credTag, found := model.CloudCredential(); found {
 		creds, err := ctx.backend.CloudCredential(credTag)
@@ -176,7 +245,7 @@ func (ctx *precheckContext) checkModel() error {
 			return errors.Trace(err)
 		}
 		if creds.Revoked {
-			return errors.New("model has revoked credentials")
+			return ctx.failed(errors.New("model has revoked credentials"))
 		}
 	}
 	return nil
@@ -186,9 +255,29 @@ func (ctx *precheckContext) checkModel() error {
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
 func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) error {
+	_, err := targetPrecheck(backend, pool, modelInfo, presence, false)
+	return errors.Trace(err)
+}
+
+// TargetPrecheckAll runs the same checks as TargetPrecheck, but rather
+// than stopping at the first unmet precondition it returns a
+// description of every one. An error is returned only if the checks
+// could not be run.
+func TargetPrecheckAll(backend PrecheckBackend, pool Pool, modelInfo coremigration.ModelInfo, presence ModelPresence) ([]string, error) {
+	return targetPrecheck(backend, pool, modelInfo, presence, true)
+}
+
+func targetPrecheck(
+	backend PrecheckBackend,
+	pool Pool,
+	modelInfo coremigration.ModelInfo,
+	presence ModelPresence,
+	collect bool,
+) ([]string, error) {
 	if err := modelInfo.Validate(); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
+	ctx := &precheckContext{backend: backend, presence: presence, collect: collect}
 
 	// This check is necessary because there is a window between the
 	// REAP phase and then end of the DONE phase where a model's
@@ -198,40 +287,51 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 	//
 	// See also https://lpad.tv/1611391
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
-		return errors.Annotate(err, "checking for active migration")
+		return nil, errors.Annotate(err, "checking for active migration")
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		if err := ctx.failed(errors.New("model is being migrated out of target controller")); err != nil {
+			return nil, err
+		}
 	}
 
 	controllerVersion, err := backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		return nil, errors.Annotate(err, "retrieving model version")
 	}
 
 	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
+		if err := ctx.failed(errors.Errorf("model has higher version than target controller (%s > %s)",
+			modelInfo.AgentVersion, controllerVersion)); err != nil {
+			return nil, err
+		}
 	}
 
 	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+		if err := ctx.failed(errors.Errorf("source controller has higher version than target controller (%s > %s)",
+			modelInfo.ControllerAgentVersion, controllerVersion)); err != nil {
+			return nil, err
+		}
 	}
 
-	controllerCtx := precheckContext{backend, presence}
+	// Failures of the controller checks are prefixed, as they are for
+	// the source controller. Errors are returned unchanged.
+	controllerCtx := &precheckContext{backend: backend, presence: presence, collect: collect}
 	if err := controllerCtx.checkController(); err != nil {
-		return errors.Trace(err)
+		return nil, errors.Trace(err)
+	}
+	for _, failure := range controllerCtx.failures {
+		ctx.failures = append(ctx.failures, "controller: "+failure)
 	}
 
 	// Check for conflicts with existing models
 	modelUUIDs, err := backend.AllModelUUIDs()
 	if err != nil {
-		return errors.Annotate(err, "retrieving models")
+		return nil, errors.Annotate(err, "retrieving models")
 	}
 	for _, modelUUID := range modelUUIDs {
 		model, release, err := pool.GetModel(modelUUID)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
 		defer release()
 
@@ -239,14 +339,18 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			if err := ctx.failed(errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)); err != nil {
+				return nil, err
+			}
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			if err := ctx.failed(errors.Errorf("model named %q already exists", model.Name())); err != nil {
+				return nil, err
+			}
 		}
 	}
 
-	return nil
+	return ctx.failures, nil
 }
 
 func controllerVersionCompatible(sourceVersion, targetVersion version.Number) bool {
@@ -272,13 +376,17 @@ func (ctx *precheckContext) checkController() error {
 		return errors.Annotate(err, "retrieving model")
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		if err := ctx.failed(errors.Errorf("model is %s", model.Life())); err != nil {
+			return err
+		}
 	}
 
 	if upgrading, err := ctx.backend.IsUpgrading(); err != nil {
 		return errors.Annotate(err, "checking for upgrades")
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		if err := ctx.failed(errors.New("upgrade in progress")); err != nil {
+			return err
+		}
 	}
 
 	return errors.Trace(ctx.checkMachines())
@@ -297,29 +405,37 @@ func (ctx *precheckContext) checkMachines() error {
 	modelPresenceContext := common.ModelPresenceContext{ctx.presence}
 	for _, machine := range machines {
 		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+			if err := ctx.failed(errors.Errorf("machine %s is %s", machine.Id(), machine.Life())); err != nil {
+				return err
+			}
 		}
 
 		if statusInfo, err := machine.InstanceStatus(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
 		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+			if err := ctx.failed(newStatusError("machine %s not running", machine.Id(), statusInfo.Status)); err != nil {
+				return err
+			}
 		}
 
 		if statusInfo, err := modelPresenceContext.MachineStatus(machine); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
 		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
+			if err := ctx.failed(newStatusError("machine %s agent not functioning at this time",
+				machine.Id(), statusInfo.Status)); err != nil {
+				return err
+			}
 		}
 
 		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
 			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
 		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
+			if err := ctx.failed(errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)); err != nil {
+				return err
+			}
 		}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
+		if err := ctx.checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -339,7 +455,17 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 	appUnits := make(map[string][]PrecheckUnit, len(apps))
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
+			if err := ctx.failed(errors.Errorf("application %s is %s", app.Name(), app.Life())); err != nil {
+				return nil, err
+			}
+		}
+		// The model description has no place for per-endpoint expose
+		// settings yet, so only exposing everything to everyone can
+		// be migrated.
+		if !exposedToAll(app.ExposedEndpoints()) {
+			if err := ctx.failed(errors.Errorf("application %s has expose settings, which can't be migrated", app.Name())); err != nil {
+				return nil, err
+			}
 		}
 		units, err := app.AllUnits()
 		if err != nil {
@@ -356,32 +482,74 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 
 func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []PrecheckUnit, modelVersion version.Number) error {
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		if err := ctx.failed(errors.Errorf("application %s is below its minimum units threshold", app.Name())); err != nil {
+			return err
+		}
 	}
 
 	appCharmURL, _ := app.CharmURL()
 
 	for _, unit := range units {
 		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
+			if err := ctx.failed(errors.Errorf("unit %s is %s", unit.Name(), unit.Life())); err != nil {
+				return err
+			}
 		}
 
 		if err := ctx.checkUnitAgentStatus(unit); err != nil {
 			return errors.Trace(err)
 		}
 
-		if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
+		if err := ctx.checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
 			return errors.Trace(err)
 		}
 
 		unitCharmURL, _ := unit.CharmURL()
 		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+			if err := ctx.failed(errors.Errorf("unit %s is upgrading", unit.Name())); err != nil {
+				return err
+			}
+		}
+
+		// The model description has no place for charm state yet,
+		// so it would be lost by the migration.
+		charmState, err := unit.CharmState()
+		if err != nil {
+			return errors.Annotatef(err, "retrieving unit %s charm state", unit.Name())
+		}
+		if len(charmState) > 0 {
+			if err := ctx.failed(errors.Errorf("unit %s has charm state, which can't be migrated", unit.Name())); err != nil {
+				return err
+			}
+		}
+
+		// Nor is there a place for ports opened for specific endpoints.
+		endpointPorts, err := unit.OpenedPortsByEndpoint()
+		if err != nil {
+			return errors.Annotatef(err, "retrieving unit %s opened ports", unit.Name())
+		}
+		for _, endpoint := range sortedEndpoints(endpointPorts) {
+			if endpoint == state.AllEndpoints {
+				continue
+			}
+			if err := ctx.failed(errors.Errorf("unit %s has ports opened for endpoint %s, which can't be migrated",
+				unit.Name(), endpoint)); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
+func sortedEndpoints(endpointPorts map[string][]network.PortRange) []string {
+	endpoints := make([]string, 0, len(endpointPorts))
+	for endpoint := range endpointPorts {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
 func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
 	modelPresenceContext := common.ModelPresenceContext{ctx.presence}
 	statusData, _ := modelPresenceContext.UnitStatus(unit)
@@ -393,24 +561,89 @@ func (ctx *precheckContext) checkUnitAgentStatus(unit PrecheckUnit) error {
 	case status.Idle, status.Executing:
 		// These two are fine.
 	default:
-		return newStatusError("unit %s not idle or executing", unit.Name(), agentStatus)
+		return ctx.failed(newStatusError("unit %s not idle or executing", unit.Name(), agentStatus))
 	}
 	return nil
 }
 
-func checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
+func (ctx *precheckContext) checkAgentTools(modelVersion version.Number, agent agentToolsGetter, agentLabel string) error {
 	tools, err := agent.AgentTools()
 	if err != nil {
 		return errors.Annotatef(err, "retrieving agent binaries for %s", agentLabel)
 	}
 	agentVersion := tools.Version.Number
 	if agentVersion != modelVersion {
-		return errors.Errorf("%s agent binaries don't match model (%s != %s)",
-			agentLabel, agentVersion, modelVersion)
+		return ctx.failed(errors.Errorf("%s agent binaries don't match model (%s != %s)",
+			agentLabel, agentVersion, modelVersion))
+	}
+	return nil
+}
+
+// checkOffers fails for each application offer hosted by the model.
+// Offers, and the connections to them, can't be exported yet.
+func (ctx *precheckContext) checkOffers() error {
+	offers, err := ctx.backend.AllApplicationOffers()
+	if err != nil {
+		return errors.Annotate(err, "retrieving application offers")
+	}
+	for _, offer := range offers {
+		if err := ctx.failed(errors.Errorf("application %s has offer %s, which can't be migrated",
+			offer.ApplicationName, offer.OfferName)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkVolumeSnapshots fails for each volume snapshot in the model.
+// Snapshots are the model's backups of its storage, which new storage
+// is restored from by add-storage and deploy --attach-storage. They
+// aren't part of the model description yet, so the target controller
+// wouldn't know of them and storage couldn't be restored from them
+// after migration; the model stays where its backups remain usable.
+func (ctx *precheckContext) checkVolumeSnapshots() error {
+	ids, err := ctx.backend.AllVolumeSnapshotIDs()
+	if err != nil {
+		return errors.Annotate(err, "retrieving volume snapshots")
+	}
+	for _, id := range ids {
+		if err := ctx.failed(errors.Errorf("volume snapshot %s can't be migrated; storage couldn't be restored from it afterwards", id)); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+// checkVolumeResizes fails for each volume with a resize in progress.
+// The pending size isn't exported, so the resize would be lost; the
+// model can be migrated once the volume has been resized.
+func (ctx *precheckContext) checkVolumeResizes() error {
+	ids, err := ctx.backend.ResizingVolumeIDs()
+	if err != nil {
+		return errors.Annotate(err, "retrieving volumes")
+	}
+	for _, id := range ids {
+		if err := ctx.failed(errors.Errorf("volume %s is being resized", id)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exposedToAll reports whether the expose settings are equivalent to
+// the application's exposed flag alone: all endpoints exposed to
+// 0.0.0.0/0.
+func exposedToAll(exposed map[string]state.ExposedEndpoint) bool {
+	if len(exposed) == 0 {
+		return true
+	}
+	all, ok := exposed[state.AllEndpoints]
+	if !ok || len(exposed) != 1 || len(all.ExposeToSpaces) != 0 {
+		return false
+	}
+	return len(all.ExposeToCIDRs) == 1 && all.ExposeToCIDRs[0] == "0.0.0.0/0"
+}
+
 type agentToolsGetter interface {
 	AgentTools() (*tools.Tools, error)
 }
@@ -457,7 +690,9 @@ func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) e
 					return errors.Trace(err)
 				}
 				if !inScope {
-					return errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)
+					if err := ctx.failed(errors.Errorf("unit %s hasn't joined relation %s yet", unit.Name(), rel)); err != nil {
+						return err
+					}
 				}
 			}
 		}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/version"
 
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 )
@@ -111,6 +112,51 @@ func (s *precheckShim) ListPendingResources(app string) ([]resource.Resource, er
 	return resources, nil
 }
 
+// AllApplicationOffers implements PrecheckBackend.
+func (s *precheckShim) AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error) {
+	offers, err := state.NewApplicationOffers(s.State).AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return offers, nil
+}
+
+// AllVolumeSnapshotIDs implements PrecheckBackend.
+func (s *precheckShim) AllVolumeSnapshotIDs() ([]string, error) {
+	sb, err := state.NewStorageBackend(s.State)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshots, err := sb.AllVolumeSnapshots()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := make([]string, len(snapshots))
+	for i, snapshot := range snapshots {
+		ids[i] = snapshot.Id()
+	}
+	return ids, nil
+}
+
+// ResizingVolumeIDs implements PrecheckBackend.
+func (s *precheckShim) ResizingVolumeIDs() ([]string, error) {
+	sb, err := state.NewStorageBackend(s.State)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes, err := sb.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	for _, volume := range volumes {
+		if _, ok := volume.PendingSize(); ok {
+			ids = append(ids, volume.VolumeTag().Id())
+		}
+	}
+	return ids, nil
+}
+
 // ControllerBackend implements PrecheckBackend.
 func (s *precheckShim) ControllerBackend() (PrecheckBackend, error) {
 	return PrecheckShim(s.controllerState, s.controllerState)
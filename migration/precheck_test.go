@@ -11,10 +11,12 @@ import (
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/crossmodel"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
@@ -255,6 +257,137 @@ func (s *SourcePrecheckSuite) TestUnitNotIdle(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "unit foo/0 not idle or executing (failed)")
 }
 
+func (s *SourcePrecheckSuite) TestUnitWithCharmState(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "foo/0", charmState: map[string]string{"a": "b"}},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "unit foo/0 has charm state, which can't be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestUnitWithEndpointPorts(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "foo/0", ports: map[string][]network.PortRange{
+						"":    {{FromPort: 443, ToPort: 443, Protocol: "tcp"}},
+						"web": {{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+					}},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "unit foo/0 has ports opened for endpoint web, which can't be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestUnitWithPortsForAllEndpoints(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "foo/0", ports: map[string][]network.PortRange{
+						"": {{FromPort: 443, ToPort: 443, Protocol: "tcp"}},
+					}},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SourcePrecheckSuite) TestApplicationExposeSettings(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				exposed: map[string]state.ExposedEndpoint{
+					"db": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo has expose settings, which can't be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationExposedToAll(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				exposed: map[string]state.ExposedEndpoint{
+					state.AllEndpoints: {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SourcePrecheckSuite) TestApplicationOffers(c *gc.C) {
+	backend := &fakeBackend{
+		offers: []*crossmodel.ApplicationOffer{{
+			OfferName:       "hosted-mysql",
+			ApplicationName: "mysql",
+		}},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application mysql has offer hosted-mysql, which can't be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestApplicationOffersError(c *gc.C) {
+	backend := &fakeBackend{
+		offersErr: errors.New("boom"),
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving application offers: boom")
+}
+
+func (s *SourcePrecheckSuite) TestVolumeSnapshots(c *gc.C) {
+	backend := &fakeBackend{
+		volumeSnapshots: []string{"0@1"},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "volume snapshot 0@1 can't be migrated; storage couldn't be restored from it afterwards")
+}
+
+func (s *SourcePrecheckSuite) TestVolumeSnapshotsError(c *gc.C) {
+	backend := &fakeBackend{
+		volumeSnapshotsErr: errors.New("boom"),
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving volume snapshots: boom")
+}
+
+func (s *SourcePrecheckSuite) TestResizingVolumes(c *gc.C) {
+	backend := &fakeBackend{
+		resizingVolumes: []string{"0"},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "volume 0 is being resized")
+}
+
+func (s *SourcePrecheckSuite) TestResizingVolumesError(c *gc.C) {
+	backend := &fakeBackend{
+		resizingVolumesErr: errors.New("boom"),
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "retrieving volumes: boom")
+}
+
 func (s *SourcePrecheckSuite) TestUnitLostLegacy(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -401,6 +534,42 @@ func (s *SourcePrecheckSuite) TestCrossModelUnitsNotYetInScope(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *SourcePrecheckSuite) TestAllReportsEveryFailure(c *gc.C) {
+	backend := newFakeBackend()
+	backend.model.life = state.Dying
+	backend.machines = []migration.PrecheckMachine{
+		&fakeMachine{id: "0", life: state.Dying},
+		&fakeMachine{id: "1", status: status.Down},
+	}
+	backend.cleanupNeeded = true
+	backend.controllerBackend = &fakeBackend{isUpgrading: true}
+	failures, err := migration.SourcePrecheckAll(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, jc.DeepEquals, []string{
+		"model is dying",
+		"machine 0 is dying",
+		"machine 1 agent not functioning at this time (down)",
+		"cleanup needed",
+		"controller: upgrade in progress",
+	})
+}
+
+func (s *SourcePrecheckSuite) TestAllSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	failures, err := migration.SourcePrecheckAll(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 0)
+}
+
+func (s *SourcePrecheckSuite) TestAllError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.model.life = state.Dying
+	backend.cleanupErr = errors.New("boom")
+	_, err := migration.SourcePrecheckAll(backend, allAlivePresence(), allAlivePresence())
+	c.Assert(err, gc.ErrorMatches, "checking cleanups: boom")
+}
+
 type TargetPrecheckSuite struct {
 	precheckBaseSuite
 	modelInfo coremigration.ModelInfo
@@ -628,6 +797,34 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestAllReportsEveryFailure(c *gc.C) {
+	pool := &fakePool{
+		models: []migration.PrecheckModel{
+			&fakeModel{uuid: modelUUID, name: modelName, owner: modelOwner},
+		},
+	}
+	backend := newFakeBackend()
+	backend.models = pool.uuids()
+	backend.isUpgrading = true
+	backend.migrationActive = true
+	s.modelInfo.AgentVersion = version.MustParse("1.2.4")
+	failures, err := migration.TargetPrecheckAll(backend, pool, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, jc.DeepEquals, []string{
+		"model is being migrated out of target controller",
+		"model has higher version than target controller (1.2.4 > 1.2.3)",
+		"controller: upgrade in progress",
+		"model with same UUID already exists (model-uuid)",
+		`model named "model-name" already exists`,
+	})
+}
+
+func (s *TargetPrecheckSuite) TestAllSuccess(c *gc.C) {
+	failures, err := migration.TargetPrecheckAll(newHappyBackend(), nil, s.modelInfo, allAlivePresence())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, gc.HasLen, 0)
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -763,6 +960,14 @@ type fakeBackend struct {
 	pendingResources    []resource.Resource
 	pendingResourcesErr error
 
+	offers    []*crossmodel.ApplicationOffer
+	offersErr error
+
+	volumeSnapshots    []string
+	volumeSnapshotsErr error
+	resizingVolumes    []string
+	resizingVolumesErr error
+
 	controllerBackend *fakeBackend
 }
 
@@ -810,6 +1015,18 @@ func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, err
 	return b.pendingResources, b.pendingResourcesErr
 }
 
+func (b *fakeBackend) AllApplicationOffers() ([]*crossmodel.ApplicationOffer, error) {
+	return b.offers, b.offersErr
+}
+
+func (b *fakeBackend) AllVolumeSnapshotIDs() ([]string, error) {
+	return b.volumeSnapshots, b.volumeSnapshotsErr
+}
+
+func (b *fakeBackend) ResizingVolumeIDs() ([]string, error) {
+	return b.resizingVolumes, b.resizingVolumesErr
+}
+
 func (b *fakeBackend) ControllerBackend() (migration.PrecheckBackend, error) {
 	if b.controllerBackend == nil {
 		return b, nil
@@ -939,6 +1156,7 @@ type fakeApp struct {
 	charmURL string
 	units    []migration.PrecheckUnit
 	minunits int
+	exposed  map[string]state.ExposedEndpoint
 }
 
 func (a *fakeApp) Name() string {
@@ -965,6 +1183,10 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) ExposedEndpoints() map[string]state.ExposedEndpoint {
+	return a.exposed
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
@@ -972,6 +1194,8 @@ type fakeUnit struct {
 	charmURL    string
 	agentStatus status.Status
 	lost        bool
+	charmState  map[string]string
+	ports       map[string][]network.PortRange
 }
 
 func (u *fakeUnit) Name() string {
@@ -1019,6 +1243,14 @@ func (u *fakeUnit) AgentPresence() (bool, error) {
 	return !u.lost, nil
 }
 
+func (u *fakeUnit) CharmState() (map[string]string, error) {
+	return u.charmState, nil
+}
+
+func (u *fakeUnit) OpenedPortsByEndpoint() (map[string][]network.PortRange, error) {
+	return u.ports, nil
+}
+
 type fakeRelation struct {
 	key           string
 	crossModel    bool
@@ -285,7 +285,24 @@ type ebsVolumeSource struct {
 	modelUUID string
 }
 
-var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var (
+	_ storage.VolumeSource  = (*ebsVolumeSource)(nil)
+	_ storage.VolumeResizer = (*ebsVolumeSource)(nil)
+)
+
+// volumeModifier is implemented by ec2 clients which support the
+// ModifyVolume call, with which EBS volumes are grown while they
+// remain attached.
+type volumeModifier interface {
+	ModifyVolume(volumeId string, size int) (*ec2.SimpleResp, error)
+}
+
+// newVolumeModifier returns the volumeModifier for the given client,
+// and false if the client cannot modify volumes.
+var newVolumeModifier = func(client *ec2.EC2) (volumeModifier, bool) {
+	modifier, ok := interface{}(client).(volumeModifier)
+	return modifier, ok
+}
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -484,6 +501,57 @@ func (v *ebsVolumeSource) DescribeVolumes(ctx context.ProviderCallContext, volId
 	return results, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *ebsVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	modifier, ok := newVolumeModifier(v.env.ec2)
+	for i, p := range params {
+		if !ok {
+			results[i].Error = errors.NotSupportedf("resizing volume %q", p.VolumeId)
+			continue
+		}
+		info, err := v.resizeVolume(ctx, modifier, p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %q", p.VolumeId)
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) resizeVolume(
+	ctx context.ProviderCallContext, modifier volumeModifier, p storage.VolumeResizeParams,
+) (*storage.VolumeInfo, error) {
+	volume, err := describeVolume(v.env.ec2, ctx, p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// EBS sizes are in GiB; mibToGib rounds up, so the
+	// volume is at least as large as requested.
+	size := int(mibToGib(p.Size))
+	if volume.Size < size {
+		if _, err := modifier.ModifyVolume(p.VolumeId, size); err != nil {
+			return nil, errors.Trace(maybeConvertCredentialError(err, ctx))
+		}
+		// The modification completes in the background, but
+		// the new size is usable once ModifyVolume succeeds.
+		volume.Size = size
+	}
+	info := &storage.VolumeInfo{
+		Size:       gibToMib(uint64(volume.Size)),
+		VolumeId:   volume.Id,
+		Persistent: true,
+	}
+	for _, attachment := range volume.Attachments {
+		if attachment.DeleteOnTermination {
+			info.Persistent = false
+			break
+		}
+	}
+	return info, nil
+}
+
 // DestroyVolumes is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) DestroyVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
 	return foreachVolume(v.env.ec2, ctx, volIds, destroyVolume), nil
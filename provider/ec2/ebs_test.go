@@ -577,6 +577,64 @@ func (s *ebsSuite) TestDescribeVolumes(c *gc.C) {
 	}})
 }
 
+type fakeVolumeModifier struct {
+	calls []string
+}
+
+func (m *fakeVolumeModifier) ModifyVolume(volumeId string, size int) (*awsec2.SimpleResp, error) {
+	m.calls = append(m.calls, fmt.Sprintf("%s:%d", volumeId, size))
+	return &awsec2.SimpleResp{}, nil
+}
+
+func (s *ebsSuite) TestResizeVolumes(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+	modifier := &fakeVolumeModifier{}
+	s.PatchValue(ec2.NewVolumeModifier, func(*awsec2.EC2) (ec2.VolumeModifier, bool) {
+		return modifier, true
+	})
+
+	resizer, ok := vs.(storage.VolumeResizer)
+	c.Assert(ok, jc.IsTrue)
+	results, err := resizer.ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     15000,
+	}, {
+		// vol-1 is already larger than requested.
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     10240,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{
+		VolumeInfo: &storage.VolumeInfo{
+			Size:       15360,
+			VolumeId:   "vol-0",
+			Persistent: true,
+		},
+	}, {
+		VolumeInfo: &storage.VolumeInfo{
+			Size:       20480,
+			VolumeId:   "vol-1",
+			Persistent: true,
+		},
+	}})
+	c.Assert(modifier.calls, jc.DeepEquals, []string{"vol-0:15"})
+}
+
+func (s *ebsSuite) TestResizeVolumesNotSupported(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes(s.cloudCallCtx, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "vol-0",
+		Size:     15000,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *ebsSuite) TestDescribeVolumesNotFound(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	vols, err := vs.DescribeVolumes(s.cloudCallCtx, []string{"vol-42"})
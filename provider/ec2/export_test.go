@@ -60,8 +60,11 @@ var (
 	DeleteSecurityGroupInsistently = &deleteSecurityGroupInsistently
 	TerminateInstancesById         = &terminateInstancesById
 	MaybeConvertCredentialError    = maybeConvertCredentialError
+	NewVolumeModifier              = &newVolumeModifier
 )
 
+type VolumeModifier = volumeModifier
+
 const VPCIDNone = vpcIDNone
 
 func VerifyCredentials(env environs.Environ, ctx context.ProviderCallContext) error {
@@ -420,6 +420,46 @@ func (v *volumeSource) describeOneVolume(ctx context.ProviderCallContext, volNam
 	return desc, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *volumeSource) ResizeVolumes(ctx context.ProviderCallContext, params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		info, err := v.resizeOneVolume(ctx, p)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeOneVolume(ctx context.ProviderCallContext, p storage.VolumeResizeParams) (*storage.VolumeInfo, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid volume id %q", p.VolumeId)
+	}
+	disk, err := v.gce.Disk(zone, p.VolumeId)
+	if err != nil {
+		return nil, google.HandleCredentialError(errors.Annotatef(err, "cannot get volume %q", p.VolumeId), ctx)
+	}
+	size := disk.Size
+	// GCE refuses to "resize" a disk to its current size,
+	// so only ask for more space if it is needed.
+	if size < p.Size {
+		sizeGB := mibToGib(p.Size)
+		if err := v.gce.ResizeDisk(zone, p.VolumeId, sizeGB); err != nil {
+			return nil, google.HandleCredentialError(errors.Annotatef(err, "cannot resize volume %q", p.VolumeId), ctx)
+		}
+		size = sizeGB * 1024
+	}
+	return &storage.VolumeInfo{
+		VolumeId:   disk.Name,
+		Size:       size,
+		Persistent: true,
+	}, nil
+}
+
 // TODO(perrito666) These rules are yet to be defined.
 func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
 	return nil
@@ -4,9 +4,15 @@
 package openstack
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"math"
+	"net/http"
 	"net/url"
+	"path"
 	"sync"
 	"time"
 
@@ -33,10 +39,12 @@ const (
 	// you'd like Cinder to automatically assign a mount point.
 	autoAssignedMountPoint = ""
 
-	volumeStatusAvailable = "available"
-	volumeStatusDeleting  = "deleting"
-	volumeStatusError     = "error"
-	volumeStatusInUse     = "in-use"
+	volumeStatusAvailable      = "available"
+	volumeStatusDeleting       = "deleting"
+	volumeStatusError          = "error"
+	volumeStatusInUse          = "in-use"
+	volumeStatusExtending      = "extending"
+	volumeStatusErrorExtending = "error_extending"
 )
 
 var cinderConfigFields = schema.Fields{
@@ -117,20 +125,30 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 	}
 
 	cinderCl := cinderClient{cinder.Basic(env.volumeURL, client.TenantId(), client.Token)}
+	actions := cinderVolumeActions{
+		endpoint:   env.volumeURL,
+		token:      client.Token,
+		httpClient: http.DefaultClient,
+	}
 
 	cloudSpec := env.cloud
 	if len(cloudSpec.CACertificates) > 0 {
+		tlsCfg := tlsConfig(cloudSpec.CACertificates)
 		cinderCl = cinderClient{cinder.BasicTLSConfig(
 			env.volumeURL,
 			client.TenantId(),
 			client.Token,
-			tlsConfig(cloudSpec.CACertificates)),
+			tlsCfg),
+		}
+		actions.httpClient = &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsCfg},
 		}
 	}
 
 	return &openstackStorageAdapter{
 		cinderCl,
 		novaClient{env.novaUnlocked},
+		actions,
 	}, nil
 }
 
@@ -211,7 +229,10 @@ type cinderVolumeSource struct {
 	namespace      instance.Namespace
 }
 
-var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var (
+	_ storage.VolumeSource  = (*cinderVolumeSource)(nil)
+	_ storage.VolumeResizer = (*cinderVolumeSource)(nil)
+)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -515,6 +536,51 @@ func (s *cinderVolumeSource) ImportVolume(ctx context.ProviderCallContext, volum
 	return cinderToJujuVolumeInfo(volume), nil
 }
 
+// ResizeVolumes is part of the storage.VolumeResizer interface.
+func (s *cinderVolumeSource) ResizeVolumes(ctx context.ProviderCallContext, args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		info, err := s.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %q", arg.VolumeId)
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) resizeVolume(arg storage.VolumeResizeParams) (*storage.VolumeInfo, error) {
+	volume, err := s.storageAdapter.GetVolume(arg.VolumeId)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting volume")
+	}
+	// Cinder sizes are in GiB; round up so the volume
+	// is at least as large as requested.
+	newSize := int(math.Ceil(float64(arg.Size) / 1024))
+	if volume.Size < newSize {
+		if err := s.storageAdapter.ExtendVolume(arg.VolumeId, newSize); err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Extending is asynchronous. Wait for it to finish,
+		// so we can record the volume's actual size.
+		volume, err = waitVolume(s.storageAdapter, arg.VolumeId, func(v *cinder.Volume) (bool, error) {
+			switch v.Status {
+			case volumeStatusErrorExtending:
+				return false, errors.New("volume failed to extend")
+			case volumeStatusExtending:
+				return false, nil
+			}
+			return v.Size >= newSize, nil
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "waiting for volume to be extended")
+		}
+	}
+	info := cinderToJujuVolumeInfo(volume)
+	return &info, nil
+}
+
 func waitVolume(
 	storageAdapter OpenstackStorage,
 	volumeId string,
@@ -603,6 +669,7 @@ type OpenstackStorage interface {
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	ExtendVolume(volumeId string, newSize int) error
 }
 
 type endpointResolver interface {
@@ -633,6 +700,7 @@ func getVolumeEndpointURL(client endpointResolver, region string) (*url.URL, err
 type openstackStorageAdapter struct {
 	cinderClient
 	novaClient
+	volumeActions cinderVolumeActions
 }
 
 type cinderClient struct {
@@ -678,6 +746,11 @@ func (ga *openstackStorageAdapter) SetVolumeMetadata(volumeId string, metadata m
 	return ga.cinderClient.SetVolumeMetadata(volumeId, metadata)
 }
 
+// ExtendVolume is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) ExtendVolume(volumeId string, newSize int) error {
+	return ga.volumeActions.extendVolume(volumeId, newSize)
+}
+
 // DeleteVolume is part of the OpenstackStorage interface.
 func (ga *openstackStorageAdapter) DeleteVolume(volumeId string) error {
 	if err := ga.cinderClient.DeleteVolume(volumeId); err != nil {
@@ -703,3 +776,47 @@ func (ga *openstackStorageAdapter) DetachVolume(serverId, attachmentId string) e
 	}
 	return nil
 }
+
+// cinderVolumeActions performs the volume actions that the goose
+// cinder client does not provide, by posting them directly to the
+// volume endpoint.
+type cinderVolumeActions struct {
+	endpoint   *url.URL
+	token      func() string
+	httpClient *http.Client
+}
+
+func (a cinderVolumeActions) extendVolume(volumeId string, newSize int) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"os-extend": map[string]int{"new_size": newSize},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	actionURL := *a.endpoint
+	actionURL.Path = path.Join(actionURL.Path, "volumes", volumeId, "action")
+	req, err := http.NewRequest("POST", actionURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Auth-Token", a.token())
+	// Extending an in-use volume requires microversion 3.42 of
+	// the block storage API. Endpoints that do not support
+	// microversions ignore the header, and will only extend
+	// volumes that are not attached.
+	req.Header.Set("OpenStack-API-Version", "volume 3.42")
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+		return nil
+	case http.StatusNotFound:
+		return errors.NotFoundf("volume %q", volumeId)
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return errors.Errorf("%s: %s", resp.Status, bytes.TrimSpace(msg))
+}
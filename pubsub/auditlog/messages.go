@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+// QueryTopic is the topic that audit log queries are published on.
+// Every API server responds with the matching entries from its local
+// audit log, publishing them on the request's ResponseTopic.
+// data: `QueryRequest`
+const QueryTopic = "auditlog.query"
+
+// QueryRequest asks each API server for the entries in its audit log
+// matching the filter.
+type QueryRequest struct {
+	// ResponseTopic is the topic the responses should be published
+	// on. It is unique to the request.
+	ResponseTopic string `yaml:"response-topic"`
+
+	Who        string `yaml:"who,omitempty"`
+	Model      string `yaml:"model,omitempty"`
+	Facade     string `yaml:"facade,omitempty"`
+	Method     string `yaml:"method,omitempty"`
+	After      string `yaml:"after,omitempty"`
+	Before     string `yaml:"before,omitempty"`
+	ErrorsOnly bool   `yaml:"errors-only,omitempty"`
+	Limit      int    `yaml:"limit,omitempty"`
+}
+
+// NewQueryRequest returns a QueryRequest for the filter, expecting
+// responses on the topic given.
+func NewQueryRequest(filter auditlog.Filter, responseTopic string) QueryRequest {
+	return QueryRequest{
+		ResponseTopic: responseTopic,
+		Who:           filter.Who,
+		Model:         filter.Model,
+		Facade:        filter.Facade,
+		Method:        filter.Method,
+		After:         formatTime(filter.After),
+		Before:        formatTime(filter.Before),
+		ErrorsOnly:    filter.ErrorsOnly,
+		Limit:         filter.Limit,
+	}
+}
+
+// Filter returns the audit log filter described by the request.
+func (r QueryRequest) Filter() (auditlog.Filter, error) {
+	after, err := parseTime(r.After)
+	if err != nil {
+		return auditlog.Filter{}, errors.Annotate(err, "parsing after")
+	}
+	before, err := parseTime(r.Before)
+	if err != nil {
+		return auditlog.Filter{}, errors.Annotate(err, "parsing before")
+	}
+	return auditlog.Filter{
+		Who:        r.Who,
+		Model:      r.Model,
+		Facade:     r.Facade,
+		Method:     r.Method,
+		After:      after,
+		Before:     before,
+		ErrorsOnly: r.ErrorsOnly,
+		Limit:      r.Limit,
+	}, nil
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	return t, errors.Trace(err)
+}
+
+// QueryResponse holds the entries matching a query from the audit log
+// of the API server identified by Origin.
+type QueryResponse struct {
+	Origin  string           `yaml:"origin"`
+	Entries []auditlog.Entry `yaml:"entries,omitempty"`
+	Error   string           `yaml:"error,omitempty"`
+}
+
+// RecordTopic is the topic that each API server publishes the records
+// written to its audit log on, so that they can be forwarded to a
+// remote log sink.
+// data: `RecordMessage`
+const RecordTopic = "auditlog.record"
+
+// RecordMessage holds a single audit log record. Records are only
+// delivered to subscribers on the controller that wrote them.
+type RecordMessage struct {
+	Record    auditlog.Record `yaml:"record"`
+	LocalOnly bool            `yaml:"local-only"`
+}
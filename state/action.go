@@ -5,6 +5,7 @@ package state
 
 import (
 	"time"
+	"unicode/utf8"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -17,6 +18,16 @@ import (
 
 const (
 	actionMarker = "_a_"
+
+	// maxActionMessages is the maximum number of progress messages
+	// kept for an action; older messages are discarded.
+	maxActionMessages = 1000
+
+	// maxActionMessageLength is the maximum length, in bytes, of a
+	// progress message; longer messages are truncated. Together with
+	// maxActionMessages, it bounds the space the messages take in
+	// the action document.
+	maxActionMessageLength = 1024
 )
 
 var (
@@ -104,6 +115,22 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Messages are the most recent progress messages logged by the
+	// action.
+	Messages []ActionMessage `bson:"messages"`
+
+	// MessageCount is the number of progress messages ever logged by
+	// the action, including any that have been discarded.
+	MessageCount int `bson:"message-count"`
+}
+
+// ActionMessage represents a progress message logged by an action.
+type ActionMessage struct {
+	// Seq numbers the messages logged by an action, starting at 1.
+	Seq       int       `bson:"seq" json:"seq"`
+	Timestamp time.Time `bson:"timestamp" json:"timestamp"`
+	Message   string    `bson:"message" json:"message"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -162,6 +189,19 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action.
+func (a *action) Messages() []ActionMessage {
+	messages := make([]ActionMessage, len(a.doc.Messages))
+	for i, message := range a.doc.Messages {
+		messages[i] = ActionMessage{
+			Seq:       message.Seq,
+			Timestamp: message.Timestamp.UTC(),
+			Message:   message.Message,
+		}
+	}
+	return messages
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -210,6 +250,69 @@ func (a *action) Begin() (Action, error) {
 	return m.Action(a.Id())
 }
 
+// Log adds a timestamped progress message to the action. It asserts
+// that the action is currently running. Each message is given the next
+// sequence number for the action, so that messages can be told apart
+// even when only the most recent maxActionMessages are kept. Messages
+// longer than maxActionMessageLength are truncated.
+func (a *action) Log(message string) error {
+	message = truncateActionMessage(message)
+	m, err := a.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	actions, closer := m.st.db().GetCollection(actionsC)
+	defer closer()
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		var doc struct {
+			Status       ActionStatus `bson:"status"`
+			MessageCount int          `bson:"message-count"`
+			TxnRevno     int64        `bson:"txn-revno"`
+		}
+		err := actions.FindId(a.doc.DocId).One(&doc)
+		if err == mgo.ErrNotFound {
+			return nil, errors.NotFoundf("action %q", a.Id())
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Status != ActionRunning {
+			return nil, errors.Errorf("cannot log message to action %q: action not running", a.Id())
+		}
+		seq := doc.MessageCount + 1
+		return []txn.Op{{
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{
+				{"$set", bson.D{{"message-count", seq}}},
+				{"$push", bson.D{{"messages", bson.D{
+					{"$each", []ActionMessage{{
+						Seq:       seq,
+						Timestamp: a.st.nowToTheSecond(),
+						Message:   message,
+					}}},
+					{"$slice", -maxActionMessages},
+				}}}},
+			},
+		}}, nil
+	}
+	return errors.Trace(m.st.db().Run(buildTxn))
+}
+
+// truncateActionMessage returns the message cut down to at most
+// maxActionMessageLength bytes, without splitting a UTF-8 character.
+func truncateActionMessage(message string) string {
+	if len(message) <= maxActionMessageLength {
+		return message
+	}
+	end := maxActionMessageLength
+	for end > 0 && !utf8.RuneStart(message[end]) {
+		end--
+	}
+	return message[:end]
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -797,6 +798,93 @@ func (s *ActionSuite) TestWatchActionNotifications(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Messages can only be logged by running actions.
+	err = a.Log("too soon")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Log("one"), jc.ErrorIsNil)
+	s.Clock.Advance(time.Second)
+	c.Assert(a.Log("two"), jc.ErrorIsNil)
+
+	a, err = s.model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	now := s.Clock.Now().Round(time.Second).UTC()
+	c.Assert(a.Messages(), jc.DeepEquals, []state.ActionMessage{
+		{Seq: 1, Timestamp: now.Add(-time.Second), Message: "one"},
+		{Seq: 2, Timestamp: now, Message: "two"},
+	})
+
+	a, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action not running`)
+}
+
+func (s *ActionSuite) TestLogTruncatesLongMessages(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The message is cut short without splitting the last character.
+	long := strings.Repeat("x", state.MaxActionMessageLength-1) + "é and more"
+	c.Assert(a.Log(long), jc.ErrorIsNil)
+
+	a, err = s.model.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message, gc.Equals, strings.Repeat("x", state.MaxActionMessageLength-1))
+}
+
+func (s *ActionSuite) TestWatchActionLogs(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a.Log("first"), jc.ErrorIsNil)
+
+	w := s.model.WatchActionLogs(a.Id())
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(actionMessageJSON(c, 1, s.Clock.Now(), "first"))
+	wc.AssertNoChange()
+
+	c.Assert(a.Log("second"), jc.ErrorIsNil)
+	c.Assert(a.Log("third"), jc.ErrorIsNil)
+	wc.AssertChange(
+		actionMessageJSON(c, 2, s.Clock.Now(), "second"),
+		actionMessageJSON(c, 3, s.Clock.Now(), "third"),
+	)
+	wc.AssertNoChange()
+
+	// Identical messages logged in the same second are all reported.
+	c.Assert(a.Log("third"), jc.ErrorIsNil)
+	wc.AssertChange(actionMessageJSON(c, 4, s.Clock.Now(), "third"))
+	wc.AssertNoChange()
+
+	// Finishing the action changes the document but logs no messages.
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func actionMessageJSON(c *gc.C, seq int, timestamp time.Time, message string) string {
+	data, err := json.Marshal(state.ActionMessage{
+		Seq:       seq,
+		Timestamp: timestamp.Round(time.Second).UTC(),
+		Message:   message,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data)
+}
+
 func (s *ActionSuite) TestActionStatusWatcher(c *gc.C) {
 	testCase := []struct {
 		receiver state.ActionReceiver
@@ -277,6 +277,10 @@ func allCollections() collectionSchema {
 		// meterStatusC is the collection used to store meter status information.
 		meterStatusC: {},
 
+		// unitStatesC holds the charm state stored on behalf of
+		// each unit by the state-set hook tool.
+		unitStatesC: {},
+
 		// These collections hold reference counts which are used
 		// by the nsRefcounts struct.
 		refcountsC: {}, // Per model.
@@ -361,6 +365,11 @@ func allCollections() collectionSchema {
 			}},
 		},
 		volumeAttachmentsC: {},
+		volumeSnapshotsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "volumeid"},
+			}},
+		},
 
 		// -----
 
@@ -387,6 +396,10 @@ func allCollections() collectionSchema {
 		},
 		actionNotificationsC: {},
 
+		// This collection holds requests for agents to run their
+		// introspection handlers, made through the Introspection facade.
+		introspectionRequestsC: {},
+
 		// -----
 
 		// This collection holds information associated with charm payloads.
@@ -535,6 +548,7 @@ const (
 	guimetadataC               = "guimetadata"
 	guisettingsC               = "guisettings"
 	instanceDataC              = "instanceData"
+	introspectionRequestsC     = "introspectionrequests"
 	leasesC                    = "leases"
 	machinesC                  = "machines"
 	machineRemovalsC           = "machineremovals"
@@ -581,12 +595,14 @@ const (
 	txnLogC                    = "txns.log"
 	txnsC                      = "txns"
 	unitsC                     = "units"
+	unitStatesC                = "unitstates"
 	upgradeInfoC               = "upgradeInfo"
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
 	usersC                     = "users"
 	volumeAttachmentsC         = "volumeattachments"
 	volumesC                   = "volumes"
+	volumeSnapshotsC           = "volumesnapshots"
 	// "resources" (see resource/persistence/mongo.go)
 
 	// Cross model relations
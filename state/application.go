@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -44,25 +45,26 @@ type Application struct {
 // applicationDoc represents the internal state of an application in MongoDB.
 // Note the correspondence with ApplicationInfo in apiserver.
 type applicationDoc struct {
-	DocID                string       `bson:"_id"`
-	Name                 string       `bson:"name"`
-	ModelUUID            string       `bson:"model-uuid"`
-	Series               string       `bson:"series"`
-	Subordinate          bool         `bson:"subordinate"`
-	CharmURL             *charm.URL   `bson:"charmurl"`
-	Channel              string       `bson:"cs-channel"`
-	CharmModifiedVersion int          `bson:"charmmodifiedversion"`
-	ForceCharm           bool         `bson:"forcecharm"`
-	Life                 Life         `bson:"life"`
-	UnitCount            int          `bson:"unitcount"`
-	RelationCount        int          `bson:"relationcount"`
-	Exposed              bool         `bson:"exposed"`
-	MinUnits             int          `bson:"minunits"`
-	DesiredScale         int          `bson:"scale"`
-	Tools                *tools.Tools `bson:",omitempty"`
-	TxnRevno             int64        `bson:"txn-revno"`
-	MetricCredentials    []byte       `bson:"metric-credentials"`
-	PasswordHash         string       `bson:"passwordhash"`
+	DocID                string                     `bson:"_id"`
+	Name                 string                     `bson:"name"`
+	ModelUUID            string                     `bson:"model-uuid"`
+	Series               string                     `bson:"series"`
+	Subordinate          bool                       `bson:"subordinate"`
+	CharmURL             *charm.URL                 `bson:"charmurl"`
+	Channel              string                     `bson:"cs-channel"`
+	CharmModifiedVersion int                        `bson:"charmmodifiedversion"`
+	ForceCharm           bool                       `bson:"forcecharm"`
+	Life                 Life                       `bson:"life"`
+	UnitCount            int                        `bson:"unitcount"`
+	RelationCount        int                        `bson:"relationcount"`
+	Exposed              bool                       `bson:"exposed"`
+	ExposedEndpoints     map[string]ExposedEndpoint `bson:"exposed-endpoints,omitempty"`
+	MinUnits             int                        `bson:"minunits"`
+	DesiredScale         int                        `bson:"scale"`
+	Tools                *tools.Tools               `bson:",omitempty"`
+	TxnRevno             int64                      `bson:"txn-revno"`
+	MetricCredentials    []byte                     `bson:"metric-credentials"`
+	PasswordHash         string                     `bson:"passwordhash"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -426,29 +428,195 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
+// ExposedEndpoint describes where the ports opened for an application
+// endpoint may be accessed from when the application is exposed.
+type ExposedEndpoint struct {
+	// ExposeToSpaces holds the names of the spaces whose subnets
+	// may access the endpoint.
+	ExposeToSpaces []string `bson:"to-spaces,omitempty"`
+
+	// ExposeToCIDRs holds the CIDRs that may access the endpoint.
+	ExposeToCIDRs []string `bson:"to-cidrs,omitempty"`
+}
+
+// AllEndpoints is the endpoint name used in expose settings to refer
+// to every endpoint of an application.
+const AllEndpoints = ""
+
+// ExposedEndpoints returns the expose settings for each exposed endpoint
+// of the application, keyed by endpoint name; AllEndpoints holds the
+// settings for every endpoint. An exposed application without any expose
+// settings has all of its endpoints exposed to 0.0.0.0/0.
+func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if len(a.doc.ExposedEndpoints) == 0 {
+		return nil
+	}
+	result := make(map[string]ExposedEndpoint, len(a.doc.ExposedEndpoints))
+	for endpoint, exposed := range a.doc.ExposedEndpoints {
+		result[endpoint] = exposed
+	}
+	return result
+}
+
 // SetExposed marks the application as exposed.
 // See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
 	return a.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the application.
+// ClearExposed removes the exposed flag, and any expose settings, from
+// the application.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
 	return a.setExposed(false)
 }
 
 func (a *Application) setExposed(exposed bool) (err error) {
+	update := bson.D{{"$set", bson.D{{"exposed", exposed}}}}
+	if !exposed {
+		update = append(update, bson.DocElem{"$unset", bson.D{{"exposed-endpoints", nil}}})
+	}
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: update,
 	}}
 	if err := a.st.db().RunTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, applicationNotAliveErr))
 	}
 	a.doc.Exposed = exposed
+	if !exposed {
+		a.doc.ExposedEndpoints = nil
+	}
+	return nil
+}
+
+// MergeExposeSettings marks the application as exposed and merges the
+// supplied expose settings into the existing ones, replacing the
+// settings of any endpoint that already has some. An endpoint with no
+// spaces or CIDRs is exposed to 0.0.0.0/0. Passing no settings exposes
+// all endpoints to 0.0.0.0/0.
+func (a *Application) MergeExposeSettings(exposedEndpoints map[string]ExposedEndpoint) error {
+	if len(exposedEndpoints) == 0 {
+		exposedEndpoints = map[string]ExposedEndpoint{AllEndpoints: {}}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		if err := a.validateExposeSettings(exposedEndpoints); err != nil {
+			return nil, errors.Trace(err)
+		}
+		merged := a.ExposedEndpoints()
+		if merged == nil {
+			merged = make(map[string]ExposedEndpoint)
+		}
+		for endpoint, exposed := range exposedEndpoints {
+			if len(exposed.ExposeToSpaces) == 0 && len(exposed.ExposeToCIDRs) == 0 {
+				exposed.ExposeToCIDRs = []string{"0.0.0.0/0"}
+			}
+			merged[endpoint] = exposed
+		}
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"charmurl", a.doc.CharmURL},
+				{"txn-revno", a.doc.TxnRevno},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"exposed", true},
+				{"exposed-endpoints", merged},
+			}}},
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update expose settings for application %q", a)
+	}
+	return a.Refresh()
+}
+
+// UnsetExposeSettings removes the expose settings of the specified
+// endpoints. The application is unexposed if no expose settings remain.
+// It is an error to specify an endpoint that has no expose settings.
+func (a *Application) UnsetExposeSettings(endpoints []string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		remaining := a.ExposedEndpoints()
+		for _, endpoint := range endpoints {
+			if _, ok := remaining[endpoint]; !ok {
+				if endpoint == AllEndpoints {
+					return nil, errors.Errorf("application %q has no expose settings for all endpoints", a)
+				}
+				return nil, errors.Errorf("endpoint %q is not exposed", endpoint)
+			}
+			delete(remaining, endpoint)
+		}
+		update := bson.D{{"$set", bson.D{{"exposed-endpoints", remaining}}}}
+		if len(remaining) == 0 {
+			update = bson.D{
+				{"$set", bson.D{{"exposed", false}}},
+				{"$unset", bson.D{{"exposed-endpoints", nil}}},
+			}
+		}
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: a.doc.DocID,
+			Assert: bson.D{
+				{"life", Alive},
+				{"txn-revno", a.doc.TxnRevno},
+			},
+			Update: update,
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot update expose settings for application %q", a)
+	}
+	return a.Refresh()
+}
+
+// validateExposeSettings checks that the endpoints, spaces and CIDRs
+// in the expose settings exist and are well formed.
+func (a *Application) validateExposeSettings(exposedEndpoints map[string]ExposedEndpoint) error {
+	eps, err := a.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	known := set.NewStrings(AllEndpoints)
+	for _, ep := range eps {
+		known.Add(ep.Name)
+	}
+	for endpoint, exposed := range exposedEndpoints {
+		if !known.Contains(endpoint) {
+			return errors.NotValidf("endpoint %q", endpoint)
+		}
+		for _, spaceName := range exposed.ExposeToSpaces {
+			if _, err := a.st.Space(spaceName); errors.IsNotFound(err) {
+				return errors.NotFoundf("space %q", spaceName)
+			} else if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, cidr := range exposed.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+	}
 	return nil
 }
 
@@ -923,12 +1091,17 @@ func (a *Application) newCharmStorageOps(
 		)
 	}
 
+	poolOps, err := useStoragePoolsOps(sb, newStorageConstraints)
+	if err != nil {
+		return fail(err)
+	}
+
 	// Upgrade charm storage.
 	upgradeStorageOps, err := a.upgradeStorageOps(ch.Meta(), oldMeta, units, newStorageConstraints)
 	if err != nil {
 		return fail(err)
 	}
-	return checkStorageOps, upgradeStorageOps, []txn.Op{storageConstraintsOp}, nil
+	return checkStorageOps, upgradeStorageOps, append([]txn.Op{storageConstraintsOp}, poolOps...), nil
 }
 
 func (a *Application) upgradeStorageOps(
@@ -1372,6 +1545,10 @@ type applicationAddUnitOpsArgs struct {
 	storageCons   map[string]StorageConstraints
 	attachStorage []names.StorageTag
 
+	// restoreSnapshots holds the IDs of volume snapshots from which
+	// new storage is restored for the unit.
+	restoreSnapshots []string
+
 	// These optional attributes are relevant to CAAS models.
 	providerId *string
 	address    *string
@@ -1522,13 +1699,9 @@ func (a *Application) addUnitStorageOps(
 	}
 
 	// Reduce the count of new storage created for each existing storage
-	// being attached.
+	// being attached, and for each snapshot being restored.
 	var storageCons map[string]StorageConstraints
-	for _, tag := range args.attachStorage {
-		storageName, err := names.StorageName(tag.Id())
-		if err != nil {
-			return nil, -1, errors.Trace(err)
-		}
+	reduceCount := func(storageName string) {
 		if cons, ok := args.storageCons[storageName]; ok && cons.Count > 0 {
 			if storageCons == nil {
 				// We must not modify the contents of the original
@@ -1544,6 +1717,22 @@ func (a *Application) addUnitStorageOps(
 			storageCons[storageName] = cons
 		}
 	}
+	for _, tag := range args.attachStorage {
+		storageName, err := names.StorageName(tag.Id())
+		if err != nil {
+			return nil, -1, errors.Trace(err)
+		}
+		reduceCount(storageName)
+	}
+	snapshotStorageNames := make([]string, len(args.restoreSnapshots))
+	for i, id := range args.restoreSnapshots {
+		storageName, err := sb.volumeSnapshotStorageName(id)
+		if err != nil {
+			return nil, -1, errors.Annotatef(err, "restoring volume snapshot %q", id)
+		}
+		snapshotStorageNames[i] = storageName
+		reduceCount(storageName)
+	}
 
 	// Add storage instances/attachments for the unit. If the
 	// application is subordinate, we'll add the machine storage
@@ -1591,6 +1780,35 @@ func (a *Application) addUnitStorageOps(
 		numStorageAttachments++
 		storageTags[si.StorageName()] = append(storageTags[si.StorageName()], storageTag)
 	}
+	for i, id := range args.restoreSnapshots {
+		storageName := snapshotStorageNames[i]
+		charmStorage, ok := charm.Meta().Storage[storageName]
+		if !ok {
+			return nil, -1, errors.Errorf(
+				"restoring volume snapshot %q: charm has no storage %q", id, storageName,
+			)
+		}
+		cons := StorageConstraints{Snapshot: id, Count: 1}
+		snapshotOps, err := sb.restoreSnapshotConstraints(charmStorage, &cons)
+		if err != nil {
+			return nil, -1, errors.Annotatef(err, "restoring volume snapshot %q", id)
+		}
+		ops, tags, n, err := createStorageOps(
+			sb,
+			unitTag,
+			charm.Meta(),
+			map[string]StorageConstraints{storageName: cons},
+			a.doc.Series,
+			machineAssignable,
+		)
+		if err != nil {
+			return nil, -1, errors.Trace(err)
+		}
+		storageOps = append(storageOps, snapshotOps...)
+		storageOps = append(storageOps, ops...)
+		numStorageAttachments += n
+		storageTags[storageName] = append(storageTags[storageName], tags[storageName]...)
+	}
 	for name, tags := range storageTags {
 		count := len(tags)
 		charmStorage := charm.Meta().Storage[name]
@@ -1724,6 +1942,7 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 			Remove: true,
 		},
 		removeMeterStatusOp(a.st, u.globalMeterStatusKey()),
+		removeUnitStateOp(a.st, u.globalKey()),
 		removeStatusOp(a.st, u.globalAgentKey()),
 		removeStatusOp(a.st, u.globalKey()),
 		removeConstraintsOp(u.globalAgentKey()),
@@ -1815,6 +1815,85 @@ func (s *ApplicationSuite) TestApplicationExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestMergeExposeSettings(c *gc.C) {
+	_, err := s.State.AddSpace("dmz", "", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToSpaces: []string{"dmz"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+
+	// Settings for other endpoints are merged in; an endpoint with no
+	// spaces or CIDRs is exposed to everywhere.
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"juju-info": {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.MergeExposeSettings(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, err := s.State.Application(s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		state.AllEndpoints: {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+		"server":           {ExposeToSpaces: []string{"dmz"}},
+		"juju-info":        {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	})
+
+	// Unexposing removes the settings too.
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+	app, err = s.State.Application(s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedEndpoints(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestMergeExposeSettingsInvalid(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"bogus": {},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot update expose settings for application "mysql": endpoint "bogus" not valid`)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToSpaces: []string{"nowhere"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot update expose settings for application "mysql": space "nowhere" not found`)
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server": {ExposeToCIDRs: []string{"10.0.0.0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot update expose settings for application "mysql": CIDR "10.0.0.0" not valid`)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestUnsetExposeSettings(c *gc.C) {
+	err := s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"server":    {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"juju-info": {},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.UnsetExposeSettings([]string{"server"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"juju-info": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	})
+
+	err = s.mysql.UnsetExposeSettings([]string{"server"})
+	c.Assert(err, gc.ErrorMatches, `cannot update expose settings for application "mysql": endpoint "server" is not exposed`)
+
+	// Removing the last expose setting unexposes the application.
+	err = s.mysql.UnsetExposeSettings([]string{"juju-info"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.HasLen, 0)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit(state.AddUnitParams{})
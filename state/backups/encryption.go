@@ -0,0 +1,250 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/juju/errors"
+)
+
+// Encrypted backups are laid out as the magic string and a random nonce
+// prefix, followed by the archive split into chunks which are each
+// sealed with AES-256-GCM. The nonce of each chunk is the prefix, the
+// chunk's sequence number and a flag marking the final chunk, so chunks
+// can't be reordered, dropped or the archive truncated without failing
+// authentication. The header is authenticated as additional data of
+// every chunk. The key is derived from the key held in controller
+// config.
+const encryptionMagic = "JUJUBAK1"
+
+const (
+	// encryptionChunkSize is the size of the plaintext in every
+	// chunk but the last.
+	encryptionChunkSize = 64 * 1024
+
+	// encryptionNoncePrefixSize is the size of the random part of
+	// each chunk's nonce; the remaining 5 bytes hold the sequence
+	// number and the final chunk flag.
+	encryptionNoncePrefixSize = 7
+
+	encryptionHeaderSize = len(encryptionMagic) + encryptionNoncePrefixSize
+	encryptionTagSize    = 16
+)
+
+// encryptedSize returns the size of an archive of the given size once
+// it has been encrypted.
+func encryptedSize(size int64) int64 {
+	chunks := (size + encryptionChunkSize - 1) / encryptionChunkSize
+	if chunks == 0 {
+		// Empty archives still have a final chunk.
+		chunks = 1
+	}
+	return int64(encryptionHeaderSize) + size + chunks*encryptionTagSize
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("juju backup encryption"))
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return aead, nil
+}
+
+// chunkSealer holds the state shared by encryption and decryption of
+// the chunks of one archive.
+type chunkSealer struct {
+	aead   cipher.AEAD
+	header []byte
+	nonce  []byte
+	seq    uint32
+}
+
+func newChunkSealer(key, header []byte) (*chunkSealer, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	copy(nonce, header[len(encryptionMagic):])
+	return &chunkSealer{
+		aead:   aead,
+		header: header,
+		nonce:  nonce,
+	}, nil
+}
+
+// nextNonce returns the nonce for the next chunk.
+func (s *chunkSealer) nextNonce(final bool) ([]byte, error) {
+	if s.seq == ^uint32(0) {
+		return nil, errors.New("backup too large to encrypt")
+	}
+	binary.BigEndian.PutUint32(s.nonce[encryptionNoncePrefixSize:], s.seq)
+	s.nonce[len(s.nonce)-1] = 0
+	if final {
+		s.nonce[len(s.nonce)-1] = 1
+	}
+	s.seq++
+	return s.nonce, nil
+}
+
+// readChunk reads up to size bytes from r into buf, and reports whether
+// they are the last bytes r holds.
+func readChunk(r *bufio.Reader, buf []byte) (int, bool, error) {
+	n, err := io.ReadFull(r, buf)
+	switch err {
+	case nil:
+		if _, err := r.Peek(1); err == io.EOF {
+			return n, true, nil
+		} else if err != nil {
+			return 0, false, errors.Trace(err)
+		}
+		return n, false, nil
+	case io.EOF, io.ErrUnexpectedEOF:
+		return n, true, nil
+	}
+	return 0, false, errors.Trace(err)
+}
+
+// encryptingReader encrypts the data read from src.
+type encryptingReader struct {
+	src    *bufio.Reader
+	sealer *chunkSealer
+	plain  []byte
+	buf    []byte
+	out    []byte
+	done   bool
+}
+
+// newEncryptingReader returns a reader which yields the contents of r
+// encrypted with the given key.
+func newEncryptingReader(key []byte, r io.Reader) (io.Reader, error) {
+	header := make([]byte, encryptionHeaderSize)
+	copy(header, encryptionMagic)
+	if _, err := io.ReadFull(rand.Reader, header[len(encryptionMagic):]); err != nil {
+		return nil, errors.Annotate(err, "generating nonce")
+	}
+	sealer, err := newChunkSealer(key, header)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &encryptingReader{
+		src:    bufio.NewReader(r),
+		sealer: sealer,
+		plain:  make([]byte, encryptionChunkSize),
+		out:    header,
+	}, nil
+}
+
+// Read is part of io.Reader.
+func (r *encryptingReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		n, final, err := readChunk(r.src, r.plain)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		nonce, err := r.sealer.nextNonce(final)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		r.buf = r.sealer.aead.Seal(r.buf[:0], nonce, r.plain[:n], r.sealer.header)
+		r.out = r.buf
+		r.done = final
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// decryptingReader decrypts the data read from src, one chunk at a
+// time. Only authenticated data is ever returned.
+type decryptingReader struct {
+	src    *bufio.Reader
+	sealer *chunkSealer
+	sealed []byte
+	buf    []byte
+	out    []byte
+	done   bool
+}
+
+// Read is part of io.Reader.
+func (r *decryptingReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		n, final, err := readChunk(r.src, r.sealed)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		if n < encryptionTagSize {
+			return 0, errors.New("encrypted backup is truncated")
+		}
+		nonce, err := r.sealer.nextNonce(final)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		r.buf, err = r.sealer.aead.Open(r.buf[:0], nonce, r.sealed[:n], r.sealer.header)
+		if err != nil {
+			return 0, errors.New("encrypted backup failed integrity check")
+		}
+		r.out = r.buf
+		r.done = final
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// newDecryptingReader returns a reader which yields the contents of r,
+// decrypting them with the given key. When no key is given r must not
+// be encrypted, and when one is given r must be, so that an archive
+// can't be replaced by an unauthenticated one. Any corruption or
+// tampering is reported as an error by Read.
+func newDecryptingReader(key []byte, r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(len(encryptionMagic))
+	if err != nil && err != io.EOF {
+		return nil, errors.Trace(err)
+	}
+	encrypted := bytes.Equal(magic, []byte(encryptionMagic))
+	switch {
+	case !encrypted && len(key) == 0:
+		return br, nil
+	case !encrypted:
+		return nil, errors.New("backup is not encrypted but a backup encryption key is configured")
+	case len(key) == 0:
+		return nil, errors.New("backup is encrypted but no backup encryption key is configured")
+	}
+	header := make([]byte, encryptionHeaderSize)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, errors.Annotate(err, "reading encryption header")
+	}
+	sealer, err := newChunkSealer(key, header)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &decryptingReader{
+		src:    br,
+		sealer: sealer,
+		sealed: make([]byte, encryptionChunkSize+encryptionTagSize),
+	}, nil
+}
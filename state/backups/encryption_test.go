@@ -0,0 +1,117 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&encryptionSuite{})
+
+type encryptionSuite struct {
+	testing.BaseSuite
+}
+
+var testEncryptionKey = bytes.Repeat([]byte{0x42}, 32)
+
+func (s *encryptionSuite) encrypt(c *gc.C, data []byte) []byte {
+	r, err := newEncryptingReader(testEncryptionKey, bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	encrypted, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	return encrypted
+}
+
+func (s *encryptionSuite) TestRoundTrip(c *gc.C) {
+	data := bytes.Repeat([]byte("some backup data"), 10000)
+	encrypted := s.encrypt(c, data)
+	c.Assert(int64(len(encrypted)), gc.Equals, encryptedSize(int64(len(data))))
+	c.Assert(bytes.Contains(encrypted, []byte("some backup data")), jc.IsFalse)
+
+	r, err := newDecryptingReader(testEncryptionKey, bytes.NewReader(encrypted))
+	c.Assert(err, jc.ErrorIsNil)
+	decrypted, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decrypted, jc.DeepEquals, data)
+}
+
+func (s *encryptionSuite) TestEmpty(c *gc.C) {
+	encrypted := s.encrypt(c, nil)
+	c.Assert(int64(len(encrypted)), gc.Equals, encryptedSize(0))
+
+	r, err := newDecryptingReader(testEncryptionKey, bytes.NewReader(encrypted))
+	c.Assert(err, jc.ErrorIsNil)
+	decrypted, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decrypted, gc.HasLen, 0)
+}
+
+func (s *encryptionSuite) TestExactChunks(c *gc.C) {
+	data := bytes.Repeat([]byte{0x01}, 2*encryptionChunkSize)
+	encrypted := s.encrypt(c, data)
+	c.Assert(int64(len(encrypted)), gc.Equals, encryptedSize(int64(len(data))))
+
+	r, err := newDecryptingReader(testEncryptionKey, bytes.NewReader(encrypted))
+	c.Assert(err, jc.ErrorIsNil)
+	decrypted, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(decrypted, jc.DeepEquals, data)
+}
+
+func (s *encryptionSuite) TestUnencryptedWithoutKey(c *gc.C) {
+	r, err := newDecryptingReader(nil, bytes.NewReader([]byte("plain")))
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "plain")
+}
+
+func (s *encryptionSuite) TestUnencryptedWithKey(c *gc.C) {
+	_, err := newDecryptingReader(testEncryptionKey, bytes.NewReader([]byte("plain")))
+	c.Assert(err, gc.ErrorMatches, "backup is not encrypted but a backup encryption key is configured")
+}
+
+func (s *encryptionSuite) TestTampered(c *gc.C) {
+	encrypted := s.encrypt(c, []byte("some backup data"))
+	encrypted[len(encryptionMagic)+20] ^= 1
+
+	r, err := newDecryptingReader(testEncryptionKey, bytes.NewReader(encrypted))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ioutil.ReadAll(r)
+	c.Assert(err, gc.ErrorMatches, "encrypted backup failed integrity check")
+}
+
+func (s *encryptionSuite) TestTruncated(c *gc.C) {
+	data := bytes.Repeat([]byte("some backup data"), 10000)
+	encrypted := s.encrypt(c, data)
+	// Drop everything after the first chunk.
+	encrypted = encrypted[:encryptionHeaderSize+encryptionChunkSize+encryptionTagSize]
+
+	r, err := newDecryptingReader(testEncryptionKey, bytes.NewReader(encrypted))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ioutil.ReadAll(r)
+	c.Assert(err, gc.ErrorMatches, "encrypted backup failed integrity check")
+}
+
+func (s *encryptionSuite) TestWrongKey(c *gc.C) {
+	encrypted := s.encrypt(c, []byte("some backup data"))
+
+	r, err := newDecryptingReader(bytes.Repeat([]byte{0x24}, 32), bytes.NewReader(encrypted))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ioutil.ReadAll(r)
+	c.Assert(err, gc.ErrorMatches, "encrypted backup failed integrity check")
+}
+
+func (s *encryptionSuite) TestNoKey(c *gc.C) {
+	encrypted := s.encrypt(c, []byte("some backup data"))
+
+	_, err := newDecryptingReader(nil, bytes.NewReader(encrypted))
+	c.Assert(err, gc.ErrorMatches, "backup is encrypted but no backup encryption key is configured")
+}
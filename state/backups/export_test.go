@@ -30,10 +30,14 @@ var (
 	RunCommand            = &runCommandFn
 	ReplaceableFolders    = &replaceableFolders
 	MongoInstalledVersion = &mongoInstalledVersion
+	NewS3FileStorage      = newS3FileStorage
 )
 
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
 var _ filestorage.RawFileStorage = (*backupBlobStorage)(nil)
+var _ filestorage.DocStorage = (*s3DocStorage)(nil)
+var _ filestorage.MetadataStorage = (*s3MetadataStorage)(nil)
+var _ filestorage.RawFileStorage = (*s3FileStorage)(nil)
 
 func getBackupDBWrapper(st *state.State) *storageDBWrapper {
 	db := st.MongoSession().DB(storageDBName)
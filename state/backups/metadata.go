@@ -68,6 +68,11 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Scheduled records whether the backup was created by the
+	// controller's backup schedule rather than by a user. Only
+	// scheduled backups are subject to automatic removal.
+	Scheduled bool
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -162,6 +167,7 @@ type flatMetadata struct {
 	Started     time.Time
 	Finished    time.Time
 	Notes       string
+	Scheduled   bool `json:",omitempty"`
 	Environment string
 	Machine     string
 	Hostname    string
@@ -185,6 +191,7 @@ func (m *Metadata) AsJSONBuffer() (io.Reader, error) {
 
 		Started:      m.Started,
 		Notes:        m.Notes,
+		Scheduled:    m.Scheduled,
 		Environment:  m.Origin.Model,
 		Machine:      m.Origin.Machine,
 		Hostname:     m.Origin.Hostname,
@@ -234,6 +241,7 @@ func NewMetadataJSONReader(in io.Reader) (*Metadata, error) {
 		meta.Finished = &flat.Finished
 	}
 	meta.Notes = flat.Notes
+	meta.Scheduled = flat.Scheduled
 	meta.Origin = Origin{
 		Model:    flat.Environment,
 		Machine:  flat.Machine,
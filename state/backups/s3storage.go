@@ -0,0 +1,307 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+
+	"github.com/juju/juju/controller"
+)
+
+const (
+	s3MetadataSuffix = ".json"
+	s3ArchiveSuffix  = ".tar.gz"
+
+	// s3ListPageSize is the number of keys requested from the object
+	// store at a time when listing backups.
+	s3ListPageSize = 1000
+)
+
+// s3Storage holds backups in an S3-compatible object store, so that
+// they survive the loss of the controller. Each backup is stored as
+// two objects under backupStorageRoot: the metadata as JSON and the
+// archive itself. Both are encrypted if the controller has a backup
+// encryption key configured.
+type s3Storage struct {
+	bucket *s3.Bucket
+	key    []byte
+}
+
+// newS3FileStorage returns a FileStorage that stores backups in the
+// object store described by the controller config.
+func newS3FileStorage(cfg controller.Config) (filestorage.FileStorage, error) {
+	auth := aws.Auth{
+		AccessKey: cfg.BackupS3AccessKey(),
+		SecretKey: cfg.BackupS3SecretKey(),
+	}
+	region := aws.Region{
+		Name:       cfg.BackupS3Region(),
+		S3Endpoint: strings.TrimSuffix(cfg.BackupS3Endpoint(), "/"),
+	}
+	bucket, err := s3.New(auth, region).Bucket(cfg.BackupS3Bucket())
+	if err != nil {
+		return nil, errors.Annotate(err, "opening backup bucket")
+	}
+	stor := &s3Storage{
+		bucket: bucket,
+		key:    cfg.BackupEncryptionKey(),
+	}
+	docs := &s3MetadataStorage{
+		MetadataDocStorage: filestorage.MetadataDocStorage{&s3DocStorage{stor}},
+		stor:               stor,
+	}
+	return filestorage.NewFileStorage(docs, &s3FileStorage{stor}), nil
+}
+
+func s3ErrorCode(err error) string {
+	if err, ok := errors.Cause(err).(*s3.Error); ok {
+		return err.Code
+	}
+	return ""
+}
+
+func (s *s3Storage) metadataPath(id string) string {
+	return path.Join(backupStorageRoot, id+s3MetadataSuffix)
+}
+
+func (s *s3Storage) archivePath(id string) string {
+	return path.Join(backupStorageRoot, id+s3ArchiveSuffix)
+}
+
+// put uploads the contents of r, encrypting them if required.
+func (s *s3Storage) put(name string, r io.Reader, size int64) error {
+	if s.key != nil {
+		var err error
+		if r, err = newEncryptingReader(s.key, r); err != nil {
+			return errors.Trace(err)
+		}
+		size = encryptedSize(size)
+	}
+	err := s.bucket.PutReader(name, r, size, "application/octet-stream", s3.Private)
+	return errors.Annotatef(err, "uploading %q", name)
+}
+
+// putBytes uploads data, creating the bucket first if need be.
+func (s *s3Storage) putBytes(name string, data []byte) error {
+	err := s.put(name, bytes.NewReader(data), int64(len(data)))
+	if s3ErrorCode(err) != "NoSuchBucket" {
+		return errors.Trace(err)
+	}
+	if err := s.bucket.PutBucket(s3.Private); err != nil {
+		return errors.Annotate(err, "creating backup bucket")
+	}
+	return errors.Trace(s.put(name, bytes.NewReader(data), int64(len(data))))
+}
+
+// get downloads the named object, decrypting it if required.
+func (s *s3Storage) get(name string) (io.ReadCloser, error) {
+	rc, err := s.bucket.GetReader(name)
+	if err != nil {
+		switch s3ErrorCode(err) {
+		case "NoSuchKey", "NoSuchBucket":
+			return nil, errors.NotFoundf("backup file %q", name)
+		}
+		return nil, errors.Annotatef(err, "downloading %q", name)
+	}
+	r, err := newDecryptingReader(s.key, rc)
+	if err != nil {
+		rc.Close()
+		return nil, errors.Annotatef(err, "reading %q", name)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{r, rc}, nil
+}
+
+// list returns the names of all objects in the bucket with the
+// given prefix.
+func (s *s3Storage) list(prefix string) ([]string, error) {
+	var names []string
+	marker := ""
+	for {
+		resp, err := s.bucket.List(prefix, "", marker, s3ListPageSize)
+		if s3ErrorCode(err) == "NoSuchBucket" {
+			// Nothing has been backed up yet.
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Annotate(err, "listing backups")
+		}
+		for _, key := range resp.Contents {
+			names = append(names, key.Key)
+		}
+		if !resp.IsTruncated || len(resp.Contents) == 0 {
+			return names, nil
+		}
+		marker = resp.NextMarker
+		if marker == "" {
+			marker = resp.Contents[len(resp.Contents)-1].Key
+		}
+	}
+}
+
+func (s *s3Storage) metadata(id string) (*Metadata, error) {
+	rc, err := s.get(s.metadataPath(id))
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("backup metadata %q", id)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer rc.Close()
+
+	// Read everything before decoding so that the integrity of
+	// encrypted metadata is checked.
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading backup metadata %q", id)
+	}
+	meta, err := NewMetadataJSONReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading backup metadata %q", id)
+	}
+	return meta, nil
+}
+
+func (s *s3Storage) putMetadata(meta *Metadata) error {
+	buf, err := meta.AsJSONBuffer()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := ioutil.ReadAll(buf)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.putBytes(s.metadataPath(meta.ID()), data))
+}
+
+//---------------------------
+// metadata storage
+
+type s3DocStorage struct {
+	*s3Storage
+}
+
+type s3MetadataStorage struct {
+	filestorage.MetadataDocStorage
+	stor *s3Storage
+}
+
+// AddDoc adds the document to storage and returns the new ID.
+func (s *s3DocStorage) AddDoc(doc filestorage.Document) (string, error) {
+	metadata, ok := doc.(*Metadata)
+	if !ok {
+		return "", errors.Errorf("doc must be of type *backups.Metadata")
+	}
+	metaDoc := newStorageMetaDoc(metadata)
+	metaDoc.ID = newStorageID(&metaDoc)
+	if err := metaDoc.validate(); err != nil {
+		return "", errors.Trace(err)
+	}
+
+	existing, err := s.list(s.metadataPath(metaDoc.ID))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, name := range existing {
+		if name == s.metadataPath(metaDoc.ID) {
+			return "", errors.AlreadyExistsf("backup metadata %q", metaDoc.ID)
+		}
+	}
+
+	if err := s.putMetadata(docAsMetadata(&metaDoc)); err != nil {
+		return "", errors.Trace(err)
+	}
+	return metaDoc.ID, nil
+}
+
+// Doc returns the stored document associated with the given ID.
+func (s *s3DocStorage) Doc(id string) (filestorage.Document, error) {
+	meta, err := s.metadata(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// ListDocs returns the list of all stored documents.
+func (s *s3DocStorage) ListDocs() ([]filestorage.Document, error) {
+	names, err := s.list(backupStorageRoot + "/")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var list []filestorage.Document
+	for _, name := range names {
+		if !strings.HasSuffix(name, s3MetadataSuffix) {
+			continue
+		}
+		id := strings.TrimSuffix(path.Base(name), s3MetadataSuffix)
+		meta, err := s.metadata(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		list = append(list, meta)
+	}
+	return list, nil
+}
+
+// RemoveDoc removes the identified document from storage.
+func (s *s3DocStorage) RemoveDoc(id string) error {
+	err := s.bucket.Del(s.metadataPath(id))
+	return errors.Annotatef(err, "removing backup metadata %q", id)
+}
+
+// Close is part of filestorage.DocStorage.
+func (s *s3DocStorage) Close() error {
+	return nil
+}
+
+// SetStored records in the metadata the fact that the file was stored.
+func (s *s3MetadataStorage) SetStored(id string) error {
+	meta, err := s.stor.metadata(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stored := time.Now()
+	meta.SetStored(&stored)
+	return errors.Trace(s.stor.putMetadata(meta))
+}
+
+//---------------------------
+// raw file storage
+
+type s3FileStorage struct {
+	*s3Storage
+}
+
+// File returns the identified file from storage.
+func (s *s3FileStorage) File(id string) (io.ReadCloser, error) {
+	file, err := s.get(s.archivePath(id))
+	return file, errors.Trace(err)
+}
+
+// AddFile adds the file to storage.
+func (s *s3FileStorage) AddFile(id string, file io.Reader, size int64) error {
+	return errors.Trace(s.put(s.archivePath(id), file, size))
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *s3FileStorage) RemoveFile(id string) error {
+	err := s.bucket.Del(s.archivePath(id))
+	return errors.Annotatef(err, "removing backup archive %q", id)
+}
+
+// Close is part of filestorage.RawFileStorage.
+func (s *s3FileStorage) Close() error {
+	return nil
+}
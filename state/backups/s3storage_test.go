@@ -0,0 +1,171 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/s3"
+	"gopkg.in/amz.v3/s3/s3test"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type s3StorageSuite struct {
+	testing.BaseSuite
+	srv *s3test.Server
+}
+
+var _ = gc.Suite(&s3StorageSuite{})
+
+const testArchive = "some backup archive"
+
+func (s *s3StorageSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	srv, err := s3test.NewServer(&s3test.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.srv = srv
+	s.AddCleanup(func(*gc.C) { srv.Quit() })
+}
+
+func (s *s3StorageSuite) storage(c *gc.C, encryptionKey string) filestorage.FileStorage {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.BackupStorage:       controller.BackupStorageS3,
+		controller.BackupS3Endpoint:    s.srv.URL(),
+		controller.BackupS3Bucket:      "juju-backups",
+		controller.BackupS3AccessKey:   "access",
+		controller.BackupS3SecretKey:   "secret",
+		controller.BackupEncryptionKey: encryptionKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stor, err := backups.NewS3FileStorage(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return stor
+}
+
+func (s *s3StorageSuite) rawObject(c *gc.C, name string) []byte {
+	region := aws.Region{Name: "test", S3Endpoint: s.srv.URL()}
+	bucket, err := s3.New(aws.Auth{}, region).Bucket("juju-backups")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := bucket.Get(name)
+	c.Assert(err, jc.ErrorIsNil)
+	return data
+}
+
+func (s *s3StorageSuite) metadata(c *gc.C) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.Origin.Model = testing.ModelTag.Id()
+	meta.Origin.Machine = "0"
+	meta.Origin.Hostname = "localhost"
+	meta.Notes = "before upgrade"
+	err := meta.MarkComplete(int64(len(testArchive)), "some hash")
+	c.Assert(err, jc.ErrorIsNil)
+	return meta
+}
+
+func (s *s3StorageSuite) add(c *gc.C, stor filestorage.FileStorage) string {
+	id, err := stor.Add(s.metadata(c), strings.NewReader(testArchive))
+	c.Assert(err, jc.ErrorIsNil)
+	return id
+}
+
+func (s *s3StorageSuite) assertBackup(c *gc.C, stor filestorage.FileStorage, id string) {
+	meta, archive, err := stor.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Check(meta.ID(), gc.Equals, id)
+	c.Check(meta.(*backups.Metadata).Notes, gc.Equals, "before upgrade")
+	c.Check(meta.Size(), gc.Equals, int64(len(testArchive)))
+	c.Check(meta.Stored(), gc.NotNil)
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, testArchive)
+}
+
+func (s *s3StorageSuite) TestAddAndGet(c *gc.C) {
+	stor := s.storage(c, "")
+	id := s.add(c, stor)
+	s.assertBackup(c, stor, id)
+
+	c.Assert(string(s.rawObject(c, "backups/"+id+".tar.gz")), gc.Equals, testArchive)
+}
+
+func (s *s3StorageSuite) TestAddDuplicate(c *gc.C) {
+	stor := s.storage(c, "")
+	meta := s.metadata(c)
+	_, err := stor.Add(meta, strings.NewReader(testArchive))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = stor.Add(meta, strings.NewReader(testArchive))
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *s3StorageSuite) TestListFromAnotherController(c *gc.C) {
+	id := s.add(c, s.storage(c, ""))
+
+	// A new controller pointed at the same bucket sees the backup.
+	stor := s.storage(c, "")
+	list, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 1)
+	c.Assert(list[0].ID(), gc.Equals, id)
+	s.assertBackup(c, stor, id)
+}
+
+func (s *s3StorageSuite) TestListEmpty(c *gc.C) {
+	list, err := s.storage(c, "").List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 0)
+}
+
+func (s *s3StorageSuite) TestRemove(c *gc.C) {
+	stor := s.storage(c, "")
+	id := s.add(c, stor)
+
+	err := stor.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+	_, _, err = stor.Get(id)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	list, err := stor.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list, gc.HasLen, 0)
+}
+
+func (s *s3StorageSuite) TestGetNotFound(c *gc.C) {
+	_, _, err := s.storage(c, "").Get("20190101-000000." + testing.ModelTag.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *s3StorageSuite) TestEncrypted(c *gc.C) {
+	key := strings.Repeat("0123456789abcdef", 4)
+	stor := s.storage(c, key)
+	id := s.add(c, stor)
+	s.assertBackup(c, stor, id)
+
+	for _, name := range []string{"backups/" + id + ".json", "backups/" + id + ".tar.gz"} {
+		raw := s.rawObject(c, name)
+		c.Check(bytes.HasPrefix(raw, []byte("JUJUBAK1")), jc.IsTrue)
+		c.Check(bytes.Contains(raw, []byte(testArchive)), jc.IsFalse)
+		c.Check(bytes.Contains(raw, []byte("before upgrade")), jc.IsFalse)
+	}
+
+	_, err := s.storage(c, "").List()
+	c.Assert(err, gc.ErrorMatches, `.*backup is encrypted but no backup encryption key is configured`)
+}
+
+func (s *s3StorageSuite) TestUnencryptedRejectedWithKey(c *gc.C) {
+	id := s.add(c, s.storage(c, ""))
+
+	stor := s.storage(c, strings.Repeat("0123456789abcdef", 4))
+	_, _, err := stor.Get(id)
+	c.Assert(err, gc.ErrorMatches, `.*backup is not encrypted but a backup encryption key is configured`)
+}
@@ -44,9 +44,10 @@ type storageMetaDoc struct {
 
 	// backup
 
-	Started  int64  `bson:"started,minsize"`
-	Finished int64  `bson:"finished,minsize"`
-	Notes    string `bson:"notes,omitempty"`
+	Started   int64  `bson:"started,minsize"`
+	Finished  int64  `bson:"finished,minsize"`
+	Notes     string `bson:"notes,omitempty"`
+	Scheduled bool   `bson:"scheduled,omitempty"`
 
 	// origin
 
@@ -124,6 +125,7 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Scheduled = doc.Scheduled
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -175,6 +177,7 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Scheduled = meta.Scheduled
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -550,8 +553,23 @@ type DB interface {
 }
 
 // NewStorage returns a new FileStorage to use for storing backup
-// archives (and metadata).
-func NewStorage(st DB) filestorage.FileStorage {
+// archives (and metadata). Backups are kept in the controller's
+// database unless the controller is configured to keep them in an
+// object store, in which case an error is returned if the object
+// store can't be used.
+func NewStorage(st DB) (filestorage.FileStorage, error) {
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "reading backup storage config")
+	}
+	if cfg.BackupStorage() == controller.BackupStorageS3 {
+		stor, err := newS3FileStorage(cfg)
+		if err != nil {
+			return nil, errors.Annotatef(err, "using %s backup storage", cfg.BackupStorage())
+		}
+		return stor, nil
+	}
+
 	modelUUID := st.ModelTag().Id()
 	db := st.MongoSession().DB(storageDBName)
 	dbWrap := newStorageDBWrapper(db, storageMetaName, modelUUID)
@@ -559,5 +577,5 @@ func NewStorage(st DB) filestorage.FileStorage {
 
 	files := newFileStorage(dbWrap, backupStorageRoot)
 	docs := newMetadataStorage(dbWrap)
-	return filestorage.NewFileStorage(docs, files)
+	return filestorage.NewFileStorage(docs, files), nil
 }
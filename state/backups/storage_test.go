@@ -11,6 +11,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
 	statetesting "github.com/juju/juju/state/testing"
@@ -90,6 +91,20 @@ func (s *storageSuite) TestNewStorageID(c *gc.C) {
 	c.Check(id, gc.Equals, "20140912-131927.spam")
 }
 
+func (s *storageSuite) TestNewStorageConfigError(c *gc.C) {
+	_, err := backups.NewStorage(&configErrorDB{})
+	c.Assert(err, gc.ErrorMatches, "reading backup storage config: boom")
+}
+
+// configErrorDB is a backups.DB whose controller config can't be read.
+type configErrorDB struct {
+	backups.DB
+}
+
+func (*configErrorDB) ControllerConfig() (controller.Config, error) {
+	return nil, errors.New("boom")
+}
+
 func (s *storageSuite) TestGetBackupMetadataFound(c *gc.C) {
 	original := s.metadata(c)
 	id, err := backups.AddBackupMetadata(s.State, original)
@@ -0,0 +1,108 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const backupScheduleStatusKey = "backupScheduleStatus"
+
+// BackupScheduleStatus records the outcome of the most recent
+// scheduled controller backups.
+type BackupScheduleStatus struct {
+	// LastAttempt is when a scheduled backup was last started.
+	LastAttempt time.Time
+
+	// LastSuccess is when a scheduled backup last completed
+	// successfully.
+	LastSuccess time.Time
+
+	// LastBackupID is the ID of the most recent successful
+	// scheduled backup.
+	LastBackupID string
+
+	// LastError holds the error from the most recent attempt, or
+	// is empty if it succeeded.
+	LastError string
+}
+
+type backupScheduleStatusDoc struct {
+	LastAttempt  int64  `bson:"last-attempt"`
+	LastSuccess  int64  `bson:"last-success"`
+	LastBackupID string `bson:"last-backup-id"`
+	LastError    string `bson:"last-error"`
+}
+
+func timeToUnixNano0(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
+
+// BackupScheduleStatus returns the status of scheduled controller
+// backups. If no scheduled backup has been attempted, the zero value
+// is returned.
+func (st *State) BackupScheduleStatus() (BackupScheduleStatus, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc backupScheduleStatusDoc
+	err := controllers.FindId(backupScheduleStatusKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return BackupScheduleStatus{}, nil
+	} else if err != nil {
+		return BackupScheduleStatus{}, errors.Annotate(err, "cannot get backup schedule status")
+	}
+	return BackupScheduleStatus{
+		LastAttempt:  unixNanoToTime0(doc.LastAttempt).UTC(),
+		LastSuccess:  unixNanoToTime0(doc.LastSuccess).UTC(),
+		LastBackupID: doc.LastBackupID,
+		LastError:    doc.LastError,
+	}, nil
+}
+
+// SetBackupScheduleStatus records the status of scheduled controller
+// backups.
+func (st *State) SetBackupScheduleStatus(status BackupScheduleStatus) error {
+	doc := backupScheduleStatusDoc{
+		LastAttempt:  timeToUnixNano0(status.LastAttempt),
+		LastSuccess:  timeToUnixNano0(status.LastSuccess),
+		LastBackupID: status.LastBackupID,
+		LastError:    status.LastError,
+	}
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		count, err := controllers.FindId(backupScheduleStatusKey).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     backupScheduleStatusKey,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     backupScheduleStatusKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", &doc}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set backup schedule status")
+	}
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/mgo.v2"
 
 	jujucontroller "github.com/juju/juju/controller"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/network"
 )
 
@@ -43,6 +44,7 @@ type Controller struct {
 	policy                 Policy
 	newPolicy              NewPolicyFunc
 	runTransactionObserver RunTransactionObserverFunc
+	leaseStore             lease.Store
 }
 
 // Close the connection to the database.
@@ -66,6 +68,7 @@ func (ctlr *Controller) NewState(modelTag names.ModelTag) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	st.leaseStore = ctlr.leaseStore
 	if err := st.start(ctlr.controllerTag, nil); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	"github.com/juju/collections/set"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -40,6 +42,16 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.CharmStoreURL,
 		controller.Features,
 		controller.MeteringURL,
+		controller.BackupSchedule,
+		controller.BackupMaxCount,
+		controller.BackupMaxAge,
+		controller.BackupStorage,
+		controller.BackupS3Endpoint,
+		controller.BackupS3Region,
+		controller.BackupS3Bucket,
+		controller.BackupS3AccessKey,
+		controller.BackupS3SecretKey,
+		controller.BackupEncryptionKey,
 	)
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -170,3 +182,34 @@ func (s *ControllerSuite) TestUpdateControllerConfigAcceptsSpaceWithAddresses(c
 	}, nil)
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *ControllerSuite) TestBackupScheduleStatus(c *gc.C) {
+	status, err := s.State.BackupScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.BackupScheduleStatus{})
+
+	attempt := time.Date(2019, 3, 1, 2, 0, 0, 0, time.UTC)
+	err = s.State.SetBackupScheduleStatus(state.BackupScheduleStatus{
+		LastAttempt:  attempt,
+		LastSuccess:  attempt.Add(time.Minute),
+		LastBackupID: "backup-id",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.SetBackupScheduleStatus(state.BackupScheduleStatus{
+		LastAttempt:  attempt.Add(24 * time.Hour),
+		LastSuccess:  attempt.Add(time.Minute),
+		LastBackupID: "backup-id",
+		LastError:    "boom",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err = s.State.BackupScheduleStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.BackupScheduleStatus{
+		LastAttempt:  attempt.Add(24 * time.Hour),
+		LastSuccess:  attempt.Add(time.Minute),
+		LastBackupID: "backup-id",
+		LastError:    "boom",
+	})
+}
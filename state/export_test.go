@@ -47,6 +47,8 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+
+	MaxActionMessageLength = maxActionMessageLength
 )
 
 var (
@@ -6,6 +6,7 @@ package state
 import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -69,6 +70,7 @@ func (rc *externalController) ControllerInfo() crossmodel.ControllerInfo {
 // ExternalControllers instances provide access to external controllers in state.
 type ExternalControllers interface {
 	Save(_ crossmodel.ControllerInfo, modelUUIDs ...string) (ExternalController, error)
+	SaveAndMoveModels(_ crossmodel.ControllerInfo, modelUUIDs ...string) error
 	Controller(controllerUUID string) (ExternalController, error)
 	ControllerForModel(modelUUID string) (ExternalController, error)
 	Remove(controllerUUID string) error
@@ -145,6 +147,43 @@ func (ec *externalControllers) Save(controller crossmodel.ControllerInfo, modelU
 	}, nil
 }
 
+// SaveAndMoveModels creates or updates an external controller record, and
+// records the specified models as being hosted by that controller rather
+// than any other external controller. It is used when models have been
+// migrated from one external controller to another.
+func (ec *externalControllers) SaveAndMoveModels(controller crossmodel.ControllerInfo, modelUUIDs ...string) error {
+	if _, err := ec.Save(controller, modelUUIDs...); err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		coll, closer := ec.st.db().GetCollection(externalControllersC)
+		defer closer()
+		var docs []externalControllerDoc
+		err := coll.Find(bson.D{
+			{"_id", bson.D{{"$ne", controller.ControllerTag.Id()}}},
+			{"models", bson.D{{"$in", modelUUIDs}}},
+		}).All(&docs)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		for _, doc := range docs {
+			ops = append(ops, txn.Op{
+				C:      externalControllersC,
+				Id:     doc.Id,
+				Assert: txn.DocExists,
+				Update: bson.D{{"$pullAll", bson.D{{"models", modelUUIDs}}}},
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	err := ec.st.db().Run(buildTxn)
+	return errors.Annotate(err, "failed to move models from external controllers")
+}
+
 // Remove removes an external controller record with the given controller UUID.
 func (ec *externalControllers) Remove(controllerUUID string) error {
 	ops := []txn.Op{{
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/core/crossmodel"
@@ -139,6 +140,36 @@ func (s *externalControllerSuite) TestControllerForModel(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *externalControllerSuite) TestSaveAndMoveModels(c *gc.C) {
+	controllerInfo := crossmodel.ControllerInfo{
+		ControllerTag: testing.ControllerTag,
+		Alias:         "controller-alias",
+		Addrs:         []string{"192.168.1.0:1234", "10.0.0.1:1234"},
+		CACert:        testing.CACert,
+	}
+	uuid1 := utils.MustNewUUID().String()
+	uuid2 := utils.MustNewUUID().String()
+	_, err := s.externalControllers.Save(controllerInfo, uuid1, uuid2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newControllerInfo := crossmodel.ControllerInfo{
+		ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()),
+		Alias:         "new-controller",
+		Addrs:         []string{"10.0.0.2:17070"},
+		CACert:        testing.CACert,
+	}
+	err = s.externalControllers.SaveAndMoveModels(newControllerInfo, uuid1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	found, err := s.externalControllers.ControllerForModel(uuid1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.ControllerInfo(), jc.DeepEquals, newControllerInfo)
+	found, err = s.externalControllers.ControllerForModel(uuid2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found.ControllerInfo(), jc.DeepEquals, controllerInfo)
+	s.assertSavedControllerInfo(c, uuid2)
+}
+
 func (s *externalControllerSuite) TestController(c *gc.C) {
 	controllerInfo := crossmodel.ControllerInfo{
 		ControllerTag: testing.ControllerTag,
@@ -0,0 +1,215 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// introspectionMarker separates the agent tag from the sequence
+// number in the id of an introspection request.
+const introspectionMarker = "_i_"
+
+// introspectionRequestDoc records a request, made by a controller
+// administrator, for an agent to run one of its introspection
+// handlers and report back the output.
+type introspectionRequestDoc struct {
+	// DocID is the agent tag and a sequence number, joined by the
+	// introspection marker and prefixed by the model UUID.
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	Agent     string    `bson:"agent"`
+	Path      string    `bson:"path"`
+	Requester string    `bson:"requester"`
+	Requested time.Time `bson:"requested"`
+
+	Completed bool   `bson:"completed"`
+	Output    []byte `bson:"output"`
+	Error     string `bson:"error"`
+}
+
+// IntrospectionRequest is a request for an agent to run one of its
+// introspection handlers.
+type IntrospectionRequest struct {
+	st  *State
+	doc introspectionRequestDoc
+}
+
+// Id returns the id of the request.
+func (r *IntrospectionRequest) Id() string {
+	return r.st.localID(r.doc.DocID)
+}
+
+// Agent returns the tag of the agent that should handle the request.
+func (r *IntrospectionRequest) Agent() names.Tag {
+	tag, err := names.ParseTag(r.doc.Agent)
+	if err != nil {
+		// Agent tags are validated when the request is added.
+		panic(err)
+	}
+	return tag
+}
+
+// Path returns the introspection path requested, such as
+// "/depengine" or "/metrics".
+func (r *IntrospectionRequest) Path() string {
+	return r.doc.Path
+}
+
+// Requester returns the name of the user that made the request.
+func (r *IntrospectionRequest) Requester() string {
+	return r.doc.Requester
+}
+
+// Requested returns the time the request was made.
+func (r *IntrospectionRequest) Requested() time.Time {
+	return r.doc.Requested
+}
+
+// Completed returns whether the agent has handled the request.
+func (r *IntrospectionRequest) Completed() bool {
+	return r.doc.Completed
+}
+
+// Output returns the output of the introspection handler, once
+// the request has completed.
+func (r *IntrospectionRequest) Output() []byte {
+	return r.doc.Output
+}
+
+// Error returns the error the agent reported when handling the
+// request, if any.
+func (r *IntrospectionRequest) Error() string {
+	return r.doc.Error
+}
+
+// Refresh reloads the request from the database.
+func (r *IntrospectionRequest) Refresh() error {
+	req, err := r.st.IntrospectionRequest(r.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	r.doc = req.doc
+	return nil
+}
+
+// Watch returns a watcher that notifies when the request changes,
+// such as when the agent completes it.
+func (r *IntrospectionRequest) Watch() NotifyWatcher {
+	return newEntityWatcher(r.st, introspectionRequestsC, r.doc.DocID)
+}
+
+// Complete records the output of the introspection handler, or the
+// error encountered running it. A request may only be completed once.
+func (r *IntrospectionRequest) Complete(output []byte, errMsg string) error {
+	ops := []txn.Op{{
+		C:      introspectionRequestsC,
+		Id:     r.doc.DocID,
+		Assert: bson.D{{"completed", false}},
+		Update: bson.D{{"$set", bson.D{
+			{"completed", true},
+			{"output", output},
+			{"error", errMsg},
+		}}},
+	}}
+	if err := r.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		if err := r.Refresh(); err != nil {
+			return errors.Annotatef(err, "cannot complete introspection request %q", r.Id())
+		}
+		return errors.Errorf("introspection request %q already completed", r.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot complete introspection request %q", r.Id())
+	}
+	r.doc.Completed = true
+	r.doc.Output = output
+	r.doc.Error = errMsg
+	return nil
+}
+
+// Remove removes the request. Removing a request that has already
+// been removed is not an error.
+func (r *IntrospectionRequest) Remove() error {
+	ops := []txn.Op{{
+		C:      introspectionRequestsC,
+		Id:     r.doc.DocID,
+		Remove: true,
+	}}
+	return errors.Annotatef(r.st.db().RunTransaction(ops),
+		"cannot remove introspection request %q", r.Id())
+}
+
+// AddIntrospectionRequest records a request, made by the named user,
+// for the agent with the given machine or unit tag to run the
+// introspection handler at the given path.
+func (st *State) AddIntrospectionRequest(agent names.Tag, path, requester string) (*IntrospectionRequest, error) {
+	switch agent.(type) {
+	case names.MachineTag, names.UnitTag:
+	default:
+		return nil, errors.NotValidf("introspection agent %q", agent)
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, errors.NotValidf("introspection path %q", path)
+	}
+	seq, err := sequence(st, "introspection")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := introspectionRequestDoc{
+		DocID:     st.docID(fmt.Sprintf("%s%s%d", agent, introspectionMarker, seq)),
+		ModelUUID: st.ModelUUID(),
+		Agent:     agent.String(),
+		Path:      path,
+		Requester: requester,
+		Requested: st.nowToTheSecond(),
+	}
+	ops := []txn.Op{{
+		C:      introspectionRequestsC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return nil, errors.Annotate(err, "cannot add introspection request")
+	}
+	return &IntrospectionRequest{st: st, doc: doc}, nil
+}
+
+// IntrospectionRequest returns the introspection request with the
+// given id.
+func (st *State) IntrospectionRequest(id string) (*IntrospectionRequest, error) {
+	coll, closer := st.db().GetCollection(introspectionRequestsC)
+	defer closer()
+
+	var doc introspectionRequestDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("introspection request %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get introspection request %q", id)
+	}
+	return &IntrospectionRequest{st: st, doc: doc}, nil
+}
+
+// WatchIntrospectionRequests returns a StringsWatcher that notifies
+// of the ids of introspection requests added for, or changed on
+// behalf of, the agent with the given tag.
+func (st *State) WatchIntrospectionRequests(agent names.Tag) StringsWatcher {
+	prefix := st.docID(agent.String() + introspectionMarker)
+	return newCollectionWatcher(st, colWCfg{
+		col: introspectionRequestsC,
+		filter: func(key interface{}) bool {
+			id, ok := key.(string)
+			return ok && strings.HasPrefix(id, prefix)
+		},
+	})
+}
@@ -0,0 +1,111 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type IntrospectionSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&IntrospectionSuite{})
+
+func (s *IntrospectionSuite) TestAddIntrospectionRequest(c *gc.C) {
+	agent := names.NewUnitTag("mysql/0")
+	req, err := s.State.AddIntrospectionRequest(agent, "/depengine", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(req.Id(), gc.Equals, "unit-mysql-0_i_1")
+	c.Check(req.Agent(), gc.Equals, agent)
+	c.Check(req.Path(), gc.Equals, "/depengine")
+	c.Check(req.Requester(), gc.Equals, "admin")
+	c.Check(req.Requested().IsZero(), jc.IsFalse)
+	c.Check(req.Completed(), jc.IsFalse)
+
+	got, err := s.State.IntrospectionRequest(req.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got.Agent(), gc.Equals, agent)
+	c.Check(got.Path(), gc.Equals, "/depengine")
+}
+
+func (s *IntrospectionSuite) TestAddIntrospectionRequestInvalid(c *gc.C) {
+	_, err := s.State.AddIntrospectionRequest(names.NewApplicationTag("mysql"), "/depengine", "admin")
+	c.Assert(err, gc.ErrorMatches, `introspection agent "application-mysql" not valid`)
+	_, err = s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "depengine", "admin")
+	c.Assert(err, gc.ErrorMatches, `introspection path "depengine" not valid`)
+}
+
+func (s *IntrospectionSuite) TestComplete(c *gc.C) {
+	req, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/metrics", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = req.Complete([]byte("some output"), "")
+	c.Assert(err, jc.ErrorIsNil)
+
+	got, err := s.State.IntrospectionRequest(req.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(got.Completed(), jc.IsTrue)
+	c.Check(string(got.Output()), gc.Equals, "some output")
+	c.Check(got.Error(), gc.Equals, "")
+
+	err = got.Complete(nil, "boom")
+	c.Assert(err, gc.ErrorMatches, `introspection request "machine-0_i_1" already completed`)
+}
+
+func (s *IntrospectionSuite) TestRemove(c *gc.C) {
+	req, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/metrics", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = req.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.IntrospectionRequest(req.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing twice is fine.
+	err = req.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *IntrospectionSuite) TestWatchIntrospectionRequests(c *gc.C) {
+	agent := names.NewMachineTag("0")
+	existing, err := s.State.AddIntrospectionRequest(agent, "/metrics", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchIntrospectionRequests(agent)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(existing.Id())
+	wc.AssertNoChange()
+
+	// Requests for other agents are not reported; note that
+	// machine-0 is a prefix of machine-01.
+	_, err = s.State.AddIntrospectionRequest(names.NewMachineTag("01"), "/metrics", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddIntrospectionRequest(names.NewUnitTag("mysql/0"), "/metrics", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	req, err := s.State.AddIntrospectionRequest(agent, "/depengine", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(req.Id())
+	wc.AssertNoChange()
+}
+
+func (s *IntrospectionSuite) TestWatchRequest(c *gc.C) {
+	req, err := s.State.AddIntrospectionRequest(names.NewMachineTag("0"), "/metrics", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := req.Watch()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = req.Complete([]byte("output"), "")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
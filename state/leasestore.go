@@ -0,0 +1,52 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// scopedLeaseStore restricts a controller-wide lease.Store to the
+// leases in a single namespace and model, so that each of a State's
+// lease managers only sees, and expires, the leases it manages.
+type scopedLeaseStore struct {
+	lease.Store
+	namespace string
+	modelUUID string
+}
+
+// Leases is part of the lease.Store interface.
+func (s scopedLeaseStore) Leases() map[lease.Key]lease.Info {
+	leases := make(map[lease.Key]lease.Info)
+	for key, info := range s.Store.Leases() {
+		if key.Namespace == s.namespace && key.ModelUUID == s.modelUUID {
+			leases[key] = info
+		}
+	}
+	return leases
+}
+
+// LeaseTrapdoorFunc returns a function that makes trapdoors for leases
+// held outside mongo, such as in the raft lease store, for use with
+// leadership-gated transactions.
+//
+// The lease manager checks that the lease is held before opening the
+// trapdoor, but there's no document recording the holder for the
+// transaction to assert on, so the trapdoor replaces the supplied
+// *[]txn.Op with no ops at all.
+func LeaseTrapdoorFunc() func(lease.Key, string) lease.Trapdoor {
+	return func(key lease.Key, holder string) lease.Trapdoor {
+		return func(out interface{}) error {
+			outPtr, ok := out.(*[]txn.Op)
+			if !ok {
+				return errors.NotValidf("expected *[]txn.Op; %T", out)
+			}
+			*outPtr = nil
+			return nil
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/lease"
+)
+
+type LeaseStoreSuite struct{}
+
+var _ = gc.Suite(&LeaseStoreSuite{})
+
+func (*LeaseStoreSuite) TestScopedLeases(c *gc.C) {
+	expiry := time.Now()
+	store := &fakeLeaseStore{leases: map[lease.Key]lease.Info{
+		{"application-leadership", "model-1", "mysql"}: {Holder: "mysql/0", Expiry: expiry},
+		{"application-leadership", "model-2", "mysql"}: {Holder: "mysql/1", Expiry: expiry},
+		{"singular-controller", "model-1", "model-1"}:  {Holder: "machine-0", Expiry: expiry},
+	}}
+	scoped := scopedLeaseStore{
+		Store:     store,
+		namespace: "application-leadership",
+		modelUUID: "model-1",
+	}
+	c.Assert(scoped.Leases(), jc.DeepEquals, map[lease.Key]lease.Info{
+		{"application-leadership", "model-1", "mysql"}: {Holder: "mysql/0", Expiry: expiry},
+	})
+}
+
+func (*LeaseStoreSuite) TestLeaseTrapdoor(c *gc.C) {
+	trapdoor := LeaseTrapdoorFunc()(lease.Key{"ns", "model", "lease"}, "holder")
+	ops := []txn.Op{{C: "foo", Id: "bar"}}
+	c.Assert(trapdoor(&ops), jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 0)
+
+	var s string
+	c.Assert(trapdoor(&s), gc.ErrorMatches, `expected \*\[\]txn.Op; \*string not valid`)
+}
+
+type fakeLeaseStore struct {
+	lease.Store
+	leases map[lease.Key]lease.Info
+}
+
+func (s *fakeLeaseStore) Leases() map[lease.Key]lease.Info {
+	return s.leases
+}
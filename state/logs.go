@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -314,6 +315,7 @@ type LogRecord struct {
 type LogTailerParams struct {
 	StartID       int64
 	StartTime     time.Time
+	EndTime       time.Time // Tailing stops once the end time has passed.
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -321,7 +323,12 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+	// IncludeMessage and ExcludeMessage are regular expressions, in
+	// Go's regexp syntax, matched against the log messages.
+	IncludeMessage string
+	ExcludeMessage string
+	Clock          clock.Clock     // Defaults to the wall clock.
+	Oplog          *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -357,12 +364,25 @@ type LogTailerState interface {
 // NewLogTailer returns a LogTailer which filters according to the
 // parameters given.
 func NewLogTailer(st LogTailerState, params LogTailerParams) (LogTailer, error) {
+	includeMessage, err := compileMessagePattern(params.IncludeMessage)
+	if err != nil {
+		return nil, errors.Annotate(err, "include message pattern")
+	}
+	excludeMessage, err := compileMessagePattern(params.ExcludeMessage)
+	if err != nil {
+		return nil, errors.Annotate(err, "exclude message pattern")
+	}
+	if params.Clock == nil {
+		params.Clock = clock.WallClock
+	}
 	session := st.MongoSession().Copy()
 	t := &logTailer{
 		modelUUID:       st.ModelUUID(),
 		session:         session,
 		logsColl:        session.DB(logsDB).C(logCollectionName(st.ModelUUID())).With(session),
 		params:          params,
+		includeMessage:  includeMessage,
+		excludeMessage:  excludeMessage,
 		logCh:           make(chan *LogRecord),
 		recentIds:       newRecentIdTracker(maxRecentLogIds),
 		maxInitialLines: maxInitialLines,
@@ -382,6 +402,8 @@ type logTailer struct {
 	session         *mgo.Session
 	logsColl        *mgo.Collection
 	params          LogTailerParams
+	includeMessage  *regexp.Regexp
+	excludeMessage  *regexp.Regexp
 	logCh           chan *LogRecord
 	lastID          int64
 	lastTime        time.Time
@@ -419,13 +441,46 @@ func (t *logTailer) loop() error {
 		return err
 	}
 
-	if t.params.NoTail {
+	if t.params.NoTail || t.endTimePassed() {
 		return nil
 	}
 
 	return t.tailOplog()
 }
 
+// endTimePassed returns whether no more logs can be written before
+// the tailer's end time.
+func (t *logTailer) endTimePassed() bool {
+	return !t.params.EndTime.IsZero() && !t.params.EndTime.After(t.params.Clock.Now())
+}
+
+// compileMessagePattern returns the compiled message pattern, or nil
+// if there's no pattern.
+func compileMessagePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.NotValidf("regular expression %q", pattern)
+	}
+	return re, nil
+}
+
+// messageMatches returns whether the log message is selected by the
+// message patterns. These are applied here, rather than by the
+// database, so that they're evaluated with the same regexp syntax
+// they were validated with.
+func (t *logTailer) messageMatches(message string) bool {
+	if t.includeMessage != nil && !t.includeMessage.MatchString(message) {
+		return false
+	}
+	if t.excludeMessage != nil && t.excludeMessage.MatchString(message) {
+		return false
+	}
+	return true
+}
+
 func (t *logTailer) processReversed(query *mgo.Query) error {
 	// We must sort by exactly the fields in the index and exactly reversed
 	// so that Mongo will use the index and not try to sort in memory.
@@ -441,7 +496,11 @@ func (t *logTailer) processReversed(query *mgo.Query) error {
 			t.params.InitialLines, maxInitialLines)
 	}
 	query.Sort("-t", "-_id")
-	query.Limit(t.params.InitialLines)
+	if t.includeMessage == nil && t.excludeMessage == nil {
+		// Otherwise, the documents that don't match the message
+		// patterns would count towards the limit.
+		query.Limit(t.params.InitialLines)
+	}
 	iter := query.Iter()
 	defer iter.Close()
 	queue := make([]logDoc, t.params.InitialLines)
@@ -453,6 +512,9 @@ func (t *logTailer) processReversed(query *mgo.Query) error {
 			return errors.Trace(tomb.ErrDying)
 		default:
 		}
+		if !t.messageMatches(doc.Message) {
+			continue
+		}
 		cur--
 		queue[cur] = doc
 		if cur == 0 {
@@ -525,6 +587,10 @@ func (t *logTailer) processCollection() error {
 			}
 			deserialisationFailures = 0
 		}
+		if !t.messageMatches(rec.Message) {
+			t.recentIds.Add(doc.Id)
+			continue
+		}
 		select {
 		case <-t.tomb.Dying():
 			return tomb.ErrDying
@@ -565,12 +631,20 @@ func (t *logTailer) tailOplog() error {
 	// If we get a deserialisation error, write out the first failure,
 	// but don't write out any additional errors until we either hit
 	// a good value, or end the method.
+	var endTimeReached <-chan time.Time
+	if !t.params.EndTime.IsZero() {
+		// Allow for delayed log writes before stopping.
+		endTimeReached = t.params.Clock.After(t.params.EndTime.Sub(t.params.Clock.Now()) + oplogOverlap)
+	}
+
 	deserialisationFailures := 0
 	skipCount := 0
 	for {
 		select {
 		case <-t.tomb.Dying():
 			return tomb.ErrDying
+		case <-endTimeReached:
+			return nil
 		case oplogDoc, ok := <-oplogTailer.Out():
 			if !ok {
 				return errors.Annotate(oplogTailer.Err(), "oplog tailer died")
@@ -607,6 +681,9 @@ func (t *logTailer) tailOplog() error {
 				}
 				deserialisationFailures = 0
 			}
+			if !t.messageMatches(rec.Message) {
+				continue
+			}
 			select {
 			case <-t.tomb.Dying():
 				return tomb.ErrDying
@@ -618,8 +695,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
-	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+	if !params.StartTime.IsZero() || !params.EndTime.IsZero() {
+		timeSel := bson.M{}
+		if !params.StartTime.IsZero() {
+			timeSel["$gte"] = params.StartTime.UnixNano()
+		}
+		if !params.EndTime.IsZero() {
+			timeSel["$lte"] = params.EndTime.UnixNano()
+		}
+		sel = append(sel, bson.DocElem{"t", timeSel})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
@@ -622,6 +623,110 @@ func (s *LogTailerSuite) TestNoTail(c *gc.C) {
 	}
 }
 
+func (s *LogTailerSuite) TestEndTime(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT.Add(-5*time.Second), threshT, 5, want)
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(time.Millisecond), threshT.Add(5*time.Second), 5,
+		logTemplate{Message: "dont want"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		EndTime: threshT,
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// The end time has passed, so the tailer stops once the logs
+	// collection has been read.
+	s.assertTailer(c, tailer, 5, want)
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
+func (s *LogTailerSuite) TestEndTimeUsesClock(c *gc.C) {
+	now := time.Now()
+	clock := testclock.NewClock(now)
+	want := logTemplate{Message: "want"}
+	s.writeLogs(c, s.otherUUID, 1, want)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		EndTime: now.Add(time.Hour),
+		Clock:   clock,
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 1, want)
+
+	// The tailer waits for delayed writes once the end time has
+	// passed, then stops.
+	err = clock.WaitAdvance(time.Hour+time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
+func (s *LogTailerSuite) TestInitialLinesWithMessageFilter(c *gc.C) {
+	expected := logTemplate{Message: "hook failed"}
+	s.writeLogs(c, s.otherUUID, 3, expected)
+	s.writeLogs(c, s.otherUUID, 5, logTemplate{Message: "dont want"})
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		InitialLines:   2,
+		IncludeMessage: "^hook",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// The lines that don't match aren't counted.
+	s.assertTailer(c, tailer, 2, expected)
+}
+
+func (s *LogTailerSuite) TestInvalidMessagePattern(c *gc.C) {
+	_, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		ExcludeMessage: "hook (",
+	})
+	c.Assert(err, gc.ErrorMatches, `exclude message pattern: regular expression "hook \(" not valid`)
+}
+
+func (s *LogTailerSuite) TestIncludeExcludeMessage(c *gc.C) {
+	started := logTemplate{Message: "hook started"}
+	failed := logTemplate{Message: "hook failed: boom"}
+	ignored := logTemplate{Message: "hook failed: ignored"}
+	other := logTemplate{Message: "something else"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, started)
+		s.writeLogs(c, s.otherUUID, 1, failed)
+		s.writeLogs(c, s.otherUUID, 1, ignored)
+		s.writeLogs(c, s.otherUUID, 1, other)
+	}
+	params := state.LogTailerParams{
+		IncludeMessage: "^hook (started|failed)",
+		ExcludeMessage: "ignored$",
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, started)
+		s.assertTailer(c, tailer, 1, failed)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) TestIncludeEntity(c *gc.C) {
 	machine0 := logTemplate{Entity: names.NewMachineTag("0")}
 	foo0 := logTemplate{Entity: names.NewUnitTag("foo/0")}
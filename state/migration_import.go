@@ -382,15 +382,33 @@ func (i *importer) machine(m description.Machine) error {
 		StatusData: mStatus.Data(),
 		Updated:    mStatus.Updated().UnixNano(),
 	}
-	// A machine isn't valid if it doesn't have an instance.
+	// A machine without an instance is imported unprovisioned, so
+	// that the provisioner starts a new instance for it. This is
+	// used when restoring a model whose machines no longer exist.
 	instance := m.Instance()
-	instStatus := instance.Status()
 	instanceStatusDoc := statusDoc{
-		ModelUUID:  i.st.ModelUUID(),
-		Status:     status.Status(instStatus.Value()),
-		StatusInfo: instStatus.Message(),
-		StatusData: instStatus.Data(),
-		Updated:    instStatus.Updated().UnixNano(),
+		ModelUUID: i.st.ModelUUID(),
+		Status:    status.Pending,
+		Updated:   i.st.clock().Now().UnixNano(),
+	}
+	if instance != nil {
+		instStatus := instance.Status()
+		instanceStatusDoc = statusDoc{
+			ModelUUID:  i.st.ModelUUID(),
+			Status:     status.Status(instStatus.Value()),
+			StatusInfo: instStatus.Message(),
+			StatusData: instStatus.Data(),
+			Updated:    instStatus.Updated().UnixNano(),
+		}
+	} else {
+		// The provisioner sets a new nonce when it provisions
+		// the machine, and the new instance will have different
+		// addresses.
+		mdoc.Nonce = ""
+		mdoc.Addresses = nil
+		mdoc.MachineAddresses = nil
+		mdoc.PreferredPrivateAddress = address{}
+		mdoc.PreferredPublicAddress = address{}
 	}
 	cons := i.constraints(m.Constraints())
 	prereqOps, machineOp := i.st.baseNewMachineOps(
@@ -401,7 +419,9 @@ func (i *importer) machine(m description.Machine) error {
 	)
 
 	// 3. create op for adding in instance data
-	prereqOps = append(prereqOps, i.machineInstanceOp(mdoc, instance))
+	if instance != nil {
+		prereqOps = append(prereqOps, i.machineInstanceOp(mdoc, instance))
+	}
 
 	if parentId := ParentId(mdoc.Id); parentId != "" {
 		prereqOps = append(prereqOps,
@@ -381,6 +381,32 @@ func (s *MigrationImportSuite) TestMachines(c *gc.C) {
 	c.Assert(newCons.String(), gc.Equals, cons.String())
 }
 
+func (s *MigrationImportSuite) TestMachineWithoutInstance(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+
+	_, newSt := s.importModel(c, s.State, func(desc map[string]interface{}) {
+		machines := desc["machines"].(map[interface{}]interface{})
+		for _, item := range machines["machines"].([]interface{}) {
+			delete(item.(map[interface{}]interface{}), "instance")
+		}
+	})
+
+	imported, err := newSt.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = imported.InstanceId()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Assert(imported.Addresses(), gc.HasLen, 0)
+
+	instStatus, err := imported.InstanceStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instStatus.Status, gc.Equals, status.Pending)
+
+	// The machine can be provisioned afresh.
+	err = imported.SetProvisioned("new-instance", "new-nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(imported.CheckProvisioned("new-nonce"), jc.IsTrue)
+}
+
 func (s *MigrationImportSuite) TestMachineDevices(c *gc.C) {
 	machine := s.Factory.MakeMachine(c, nil)
 	// Create two devices, first with all fields set, second just to show that
@@ -932,6 +958,13 @@ func (s *MigrationImportSuite) TestUnitsOpenPorts(c *gc.C) {
 		ToPort:   2345,
 		Protocol: "tcp",
 	})
+
+	// The ranges are opened for every endpoint.
+	byEndpoint, err := imported.OpenedPortsByEndpoint()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(byEndpoint, jc.DeepEquals, map[string][]network.PortRange{
+		state.AllEndpoints: ports,
+	})
 }
 
 func (s *MigrationImportSuite) TestSpaces(c *gc.C) {
@@ -165,6 +165,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
 
+		// Introspection requests are transient, and are only
+		// meaningful to the agents connected to this controller.
+		introspectionRequestsC,
+
 		// Global settings store controller specific configuration settings
 		// and are not to be migrated.
 		globalSettingsC,
@@ -204,6 +208,15 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		relationNetworksC,
 		firewallRulesC,
 		dockerResourcesC,
+
+		// Unit charm state is not yet part of the model description;
+		// the migration prechecks refuse models which have any.
+		unitStatesC,
+
+		// Volume snapshots are not yet part of the model description;
+		// the migration prechecks refuse models which have any, as
+		// storage couldn't be restored from them after migration.
+		volumeSnapshotsC,
 	)
 
 	modelCollections := set.NewStrings()
@@ -374,6 +387,9 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		"RelationCount",
 		// TODO(caas)
 		"DesiredScale",
+		// The model description has no place for per-endpoint expose
+		// settings yet; the migration precheck refuses models using them.
+		"ExposedEndpoints",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -435,6 +451,21 @@ func (s *MigrationSuite) TestPortsDocFields(c *gc.C) {
 	s.AssertExportedFields(c, portsDoc{}, fields)
 }
 
+func (s *MigrationSuite) TestPortRangeFields(c *gc.C) {
+	fields := set.NewStrings(
+		"UnitName",
+		"FromPort",
+		"ToPort",
+		"Protocol",
+		// The model description has no place for the endpoint a range
+		// is opened for yet; the migration precheck refuses models with
+		// ports opened for a single endpoint, so the ranges imported are
+		// all opened for AllEndpoints.
+		"Endpoint",
+	)
+	s.AssertExportedFields(c, PortRange{}, fields)
+}
+
 func (s *MigrationSuite) TestMeterStatusDocFields(c *gc.C) {
 	fields := set.NewStrings(
 		// DocID itself isn't migrated
@@ -689,6 +720,12 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// Progress messages, and the count used to number them, are
+		// not migrated. They're only of use to clients following a
+		// running action, and those are disconnected by the migration;
+		// the action's final status and results are migrated.
+		"Messages",
+		"MessageCount",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -712,6 +749,8 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 		"Life",
 		"HostId",    // recreated from pool properties
 		"Releasing", // only when dying; can't migrate dying storage
+		// Migration is refused while a volume is being resized.
+		"PendingSize",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -725,7 +764,11 @@ func (s *MigrationSuite) TestVolumeDocFields(c *gc.C) {
 	s.AssertExportedFields(c, VolumeInfo{}, set.NewStrings(
 		"HardwareId", "WWN", "Size", "Pool", "VolumeId", "Persistent"))
 	s.AssertExportedFields(c, VolumeParams{}, set.NewStrings(
-		"Size", "Pool"))
+		"Size", "Pool",
+		// Only set when restoring from a snapshot, and models
+		// with snapshots are refused by the migration prechecks.
+		"SnapshotId",
+	))
 }
 
 func (s *MigrationSuite) TestVolumeAttachmentDocFields(c *gc.C) {
@@ -832,6 +832,31 @@ func IsMigrationActive(st *State, modelUUID string) (bool, error) {
 	return n > 0, nil
 }
 
+// CompletedMigration returns the latest migration of the model with the
+// given UUID if it reached the SUCCESS phase, meaning the model is now
+// hosted by the target controller. An error satisfying
+// errors.IsNotFound is returned otherwise. The State provided need not
+// be for the model in question.
+func CompletedMigration(st *State, modelUUID string) (ModelMigration, error) {
+	migColl, closer := st.db().GetCollection(migrationsC)
+	defer closer()
+	query := migColl.Find(bson.M{"model-uuid": modelUUID})
+	query = query.Sort("-attempt").Limit(1)
+	mig, err := st.migrationFromQuery(query)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	phase, err := mig.Phase()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch phase {
+	case migration.SUCCESS, migration.LOGTRANSFER, migration.REAP, migration.REAPFAILED, migration.DONE:
+		return mig, nil
+	}
+	return nil, errors.NotFoundf("completed migration for model %q", modelUUID)
+}
+
 func unixNanoToTime0(i int64) time.Time {
 	if i == 0 {
 		return time.Time{}
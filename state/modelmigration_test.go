@@ -115,6 +115,39 @@ func (s *MigrationSuite) TestIsMigrationActive(c *gc.C) {
 	check(true)
 }
 
+func (s *MigrationSuite) TestCompletedMigration(c *gc.C) {
+	modelUUID := s.State2.ModelUUID()
+	_, err := state.CompletedMigration(s.State, modelUUID)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, phase := range []migration.Phase{migration.IMPORT, migration.VALIDATION} {
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+		_, err = state.CompletedMigration(s.State, modelUUID)
+		c.Check(err, jc.Satisfies, errors.IsNotFound)
+	}
+
+	c.Assert(mig.SetPhase(migration.SUCCESS), jc.ErrorIsNil)
+	completed, err := state.CompletedMigration(s.State, modelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(completed.Id(), gc.Equals, mig.Id())
+	info, err := completed.TargetInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.ControllerTag, gc.Equals, s.stdSpec.TargetInfo.ControllerTag)
+	c.Check(info.Addrs, jc.DeepEquals, s.stdSpec.TargetInfo.Addrs)
+}
+
+func (s *MigrationSuite) TestCompletedMigrationAborted(c *gc.C) {
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.ABORT), jc.ErrorIsNil)
+	c.Assert(mig.SetPhase(migration.ABORTDONE), jc.ErrorIsNil)
+
+	_, err = state.CompletedMigration(s.State, s.State2.ModelUUID())
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *MigrationSuite) TestIdSequencesAreIndependent(c *gc.C) {
 	st2 := s.State2
 	st3 := s.Factory.MakeModel(c, nil)
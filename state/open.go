@@ -13,6 +13,7 @@ import (
 	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/mongo"
 )
 
@@ -47,6 +48,12 @@ type OpenParams struct {
 	// InitDatabaseFunc, if non-nil, is a function that will be called
 	// just after the state database is opened.
 	InitDatabaseFunc InitDatabaseFunc
+
+	// LeaseStore, if non-nil, is used to hold application leadership
+	// and singular leases for every model in the controller, in place
+	// of the leases collection. It is ignored if the controller has
+	// raft disabled.
+	LeaseStore lease.Store
 }
 
 // Validate validates the OpenParams.
@@ -90,6 +97,7 @@ func OpenController(args OpenParams) (*Controller, error) {
 		session:                session,
 		newPolicy:              args.NewPolicy,
 		runTransactionObserver: args.RunTransactionObserver,
+		leaseStore:             args.LeaseStore,
 	}, nil
 }
 
@@ -125,6 +133,7 @@ func Open(args OpenParams) (*State, error) {
 
 	// State should only be Opened on behalf of a controller environ; all
 	// other *States must be obtained via StatePool.
+	st.leaseStore = args.LeaseStore
 	if err := st.start(args.ControllerTag, nil); err != nil {
 		return nil, errors.Trace(err)
 	}
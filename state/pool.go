@@ -17,6 +17,7 @@ import (
 	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/worker/lease"
 )
 
 var errPoolClosed = errors.New("pool closed")
@@ -206,6 +207,7 @@ func (p *StatePool) openState(modelUUID string) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	newSt.leaseStore = p.systemState.leaseStore
 	if err := newSt.start(p.systemState.controllerTag, p.hub); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -344,3 +346,46 @@ func (p *StatePool) IntrospectionReport() string {
 		"Marked for removal: %d models\n"+
 		"\n%s", len(p.pool), removeCount, buff)
 }
+
+// LeaseReport returns the leases known to the lease managers of the
+// controller model and of every model with a State in the pool, by
+// namespace and model.
+func (p *StatePool) LeaseReport() map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	states := []*State{p.systemState}
+	for _, item := range p.pool {
+		states = append(states, item.state)
+	}
+	out := make(map[string]interface{})
+	for _, st := range states {
+		for _, manager := range []*lease.Manager{
+			st.workers.leadershipManager(),
+			st.workers.singularManager(),
+		} {
+			mergeLeaseReport(out, manager.Report())
+		}
+	}
+	return out
+}
+
+// mergeLeaseReport adds the models in each namespace of a lease
+// manager report to out.
+func mergeLeaseReport(out, report map[string]interface{}) {
+	for namespace, value := range report {
+		models, ok := value.(map[string]interface{})
+		if !ok {
+			// Dead managers report only an error.
+			continue
+		}
+		merged, _ := out[namespace].(map[string]interface{})
+		if merged == nil {
+			merged = make(map[string]interface{})
+			out[namespace] = merged
+		}
+		for modelUUID, leases := range models {
+			merged[modelUUID] = leases
+		}
+	}
+}
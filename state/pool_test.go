@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -195,3 +196,20 @@ func (s *statePoolSuite) TestGetRemovedNotAllowed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, fmt.Sprintf("model %v has been removed", s.ModelUUID1))
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *statePoolSuite) TestLeaseReport(c *gc.C) {
+	st1, err := s.StatePool.Get(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st1.Release()
+	err = st1.LeadershipClaimer().ClaimLeadership("mysql", "mysql/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	report := s.StatePool.LeaseReport()
+	models, ok := report["application-leadership"].(map[string]interface{})
+	c.Assert(ok, jc.IsTrue)
+	leases, ok := models[s.ModelUUID1].(map[string]interface{})
+	c.Assert(ok, jc.IsTrue)
+	mysql, ok := leases["mysql"].(map[string]interface{})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(mysql["holder"], gc.Equals, "mysql/0")
+}
@@ -37,6 +37,12 @@ type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
+
+	// Endpoint is the name of the application endpoint the ports are
+	// opened for, or AllEndpoints if they are opened for every endpoint.
+	// It's always stored, even when empty, so that a range opened for
+	// all endpoints is only added once to a ports document.
+	Endpoint string
 }
 
 // NewPortRange create a new port range and validate it.
@@ -121,7 +127,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 	// An exact port range match (including the associated unit name) is not
 	// considered a conflict due to the fact that many charms issue commands
-	// to open the same port multiple times.
+	// to open the same port multiple times. A unit may also open the same
+	// range for several of its endpoints.
+	prA.Endpoint, prB.Endpoint = AllEndpoints, AllEndpoints
 	if prA == prB {
 		return nil
 	}
@@ -137,10 +145,14 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
 	proto := strings.ToLower(p.Protocol)
+	owner := fmt.Sprintf("%q", p.UnitName)
+	if p.Endpoint != AllEndpoints {
+		owner += fmt.Sprintf(", endpoint %q", p.Endpoint)
+	}
 	if proto == "icmp" {
-		return fmt.Sprintf("%s (%q)", proto, p.UnitName)
+		return fmt.Sprintf("%s (%s)", proto, owner)
 	}
-	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, proto, p.UnitName)
+	return fmt.Sprintf("%d-%d/%s (%s)", p.FromPort, p.ToPort, proto, owner)
 }
 
 // portsDoc represents the state of ports opened on machines for networks
@@ -349,6 +361,14 @@ func (p *Ports) Refresh() error {
 	return nil
 }
 
+// PortRanges returns all the port ranges maintained by this document,
+// along with the units and endpoints they are opened for.
+func (p *Ports) PortRanges() []PortRange {
+	result := make([]PortRange, len(p.doc.Ports))
+	copy(result, p.doc.Ports)
+	return result
+}
+
 // AllPortRanges returns a map with network.PortRange as keys and unit
 // names as values.
 func (p *Ports) AllPortRanges() map[network.PortRange]string {
@@ -507,17 +527,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.doc.Ports {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
@@ -418,6 +418,18 @@ func replaceSettingsOp(db Database, collection, key string, values map[string]in
 	return op, assertFailed, nil
 }
 
+// replaceSettings replaces the Settings for key with the supplied values.
+func replaceSettings(db Database, collection, key string, values map[string]interface{}) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		op, _, err := replaceSettingsOp(db, collection, key, values)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{op}, nil
+	}
+	return db.Run(buildTxn)
+}
+
 func (s *Settings) assertUnchangedOp() txn.Op {
 	return txn.Op{
 		C:      s.collection,
@@ -484,6 +496,11 @@ func (s *StateSettings) RemoveSettings(key string) error {
 	return removeSettings(s.backend.db(), s.collection, key)
 }
 
+// ReplaceSettings exposes replaceSettings on state for use outside the state package.
+func (s *StateSettings) ReplaceSettings(key string, settings map[string]interface{}) error {
+	return replaceSettings(s.backend.db(), s.collection, key, settings)
+}
+
 // ListSettings exposes listSettings on state for use outside the state package.
 func (s *StateSettings) ListSettings(keyPrefix string) (map[string]map[string]interface{}, error) {
 	return listSettings(s.backend, s.collection, keyPrefix)
@@ -37,6 +37,7 @@ import (
 	coreglobalclock "github.com/juju/juju/core/globalclock"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/network"
@@ -98,6 +99,11 @@ type State struct {
 	// relatively-skewed.
 	leaseStoreId string
 
+	// leaseStore, if non-nil, is the controller-wide store holding
+	// leadership and singular leases in place of the leases
+	// collection.
+	leaseStore lease.Store
+
 	// workers is responsible for keeping the various sub-workers
 	// available by starting new ones as they fail. It doesn't do
 	// that yet, but having a type that collects them together is the
@@ -226,6 +232,13 @@ func (st *State) RemoveExportingModelDocs() error {
 func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 	modelUUID := st.ModelUUID()
 
+	// Offer permissions are held in a global collection, so the
+	// model's offers need to be read before their docs are removed.
+	offers, err := NewApplicationOffers(st).AllApplicationOffers()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	// Remove each collection in its own transaction.
 	for name, info := range st.database.Schema() {
 		if info.global || info.rawAccess {
@@ -259,12 +272,12 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 	// Logs and presence are in separate databases so don't get caught by that
 	// loop.
 	removeModelLogs(st.MongoSession(), modelUUID)
-	err := presence.RemovePresenceForModel(st.getPresenceCollection(), st.modelTag)
+	err = presence.RemovePresenceForModel(st.getPresenceCollection(), st.modelTag)
 	if err != nil {
 		return errors.Trace(err)
 	}
 
-	// Remove all user permissions for the model.
+	// Remove all user permissions for the model and its offers.
 	permPattern := bson.M{
 		"_id": bson.M{"$regex": "^" + permissionID(modelKey(modelUUID), "")},
 	}
@@ -272,6 +285,16 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	for _, offer := range offers {
+		offerPermPattern := bson.M{
+			"_id": bson.M{"$regex": "^" + permissionID(applicationOfferKey(offer.OfferUUID), "")},
+		}
+		offerOps, err := st.removeInCollectionOps(permissionsC, offerPermPattern)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, offerOps...)
+	}
 	err = st.db().RunTransaction(ops)
 	if err != nil {
 		return errors.Trace(err)
@@ -399,6 +422,20 @@ func (st *State) start(controllerTag names.ControllerTag, hub *pubsub.SimpleHub)
 	}
 	// now we've set up leaseStoreId, we can use workersFactory
 
+	if st.leaseStore != nil {
+		// The raft lease store is only usable while the raft
+		// workers are running, which they won't be if they've
+		// been disabled.
+		controllerConfig, err := st.ControllerConfig()
+		if err != nil {
+			return errors.Annotate(err, "reading controller config")
+		}
+		if controllerConfig.Features().Contains(feature.DisableRaft) {
+			logger.Infof("raft disabled; using mongo lease store")
+			st.leaseStore = nil
+		}
+	}
+
 	logger.Infof("starting standard state workers")
 	workers, err := newWorkers(st, hub)
 	if err != nil {
@@ -440,6 +477,14 @@ func (st *State) getSingularLeaseStore() (lease.Store, error) {
 }
 
 func (st *State) getLeaseStore(namespace string) (lease.Store, error) {
+	if st.leaseStore != nil {
+		return scopedLeaseStore{
+			Store:     st.leaseStore,
+			namespace: namespace,
+			modelUUID: st.modelUUID(),
+		}, nil
+	}
+
 	globalClock, err := st.globalClockReader()
 	if err != nil {
 		return nil, errors.Annotate(err, "getting global clock for lease store")
@@ -1025,6 +1070,7 @@ type AddApplicationArgs struct {
 	Storage           map[string]StorageConstraints
 	Devices           map[string]DeviceConstraints
 	AttachStorage     []names.StorageTag
+	RestoreSnapshots  []string
 	EndpointBindings  map[string]string
 	ApplicationConfig *application.Config
 	CharmConfig       charm.Settings
@@ -1075,6 +1121,9 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 	if len(args.AttachStorage) > 0 && args.NumUnits != 1 {
 		return nil, errors.Errorf("AttachStorage is non-empty but NumUnits is %d, must be 1", args.NumUnits)
 	}
+	if len(args.RestoreSnapshots) > 0 && args.NumUnits != 1 {
+		return nil, errors.Errorf("RestoreSnapshots is non-empty but NumUnits is %d, must be 1", args.NumUnits)
+	}
 
 	if err := validateCharmVersion(args.Charm); err != nil {
 		return nil, errors.Trace(err)
@@ -1211,6 +1260,10 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			} else if remoteExists {
 				return nil, errSameNameRemoteApplicationExists
 			}
+			// Ensure the storage pools haven't been removed.
+			if err := validateStorageConstraints(sb, args.Storage, args.Charm.Meta()); err != nil {
+				return nil, errors.Trace(err)
+			}
 		}
 		// The addApplicationOps does not include the model alive assertion,
 		// so we add it here.
@@ -1232,6 +1285,12 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		}
 		ops = append(ops, addOps...)
 
+		poolOps, err := useStoragePoolsOps(sb, args.Storage)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, poolOps...)
+
 		// Collect peer relation addition operations.
 		//
 		// TODO(dimitern): Ensure each st.Endpoint has a space name associated in a
@@ -1258,9 +1317,10 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		// Collect unit-adding operations.
 		for x := 0; x < args.NumUnits; x++ {
 			unitName, unitOps, err := app.addApplicationUnitOps(applicationAddUnitOpsArgs{
-				cons:          args.Constraints,
-				storageCons:   args.Storage,
-				attachStorage: args.AttachStorage,
+				cons:             args.Constraints,
+				storageCons:      args.Storage,
+				attachStorage:    args.AttachStorage,
+				restoreSnapshots: args.RestoreSnapshots,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
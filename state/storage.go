@@ -215,8 +215,9 @@ type storageInstanceDoc struct {
 // storageInstanceConstraints contains a subset of StorageConstraints,
 // for a single storage instance.
 type storageInstanceConstraints struct {
-	Pool string `bson:"pool"`
-	Size uint64 `bson:"size"`
+	Pool     string `bson:"pool"`
+	Size     uint64 `bson:"size"`
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 type storageAttachment struct {
@@ -347,6 +348,52 @@ func (sb *storageBackend) ReleaseStorageInstance(tag names.StorageTag, destroyAt
 	return sb.destroyStorageInstance(tag, destroyAttachments, true)
 }
 
+// ResizeStorageInstance records a request to grow the storage instance
+// with the specified tag to the given size, in MiB. Only storage that is
+// backed by a volume can be resized; the request is recorded against
+// that volume, as described for ResizeVolume.
+func (sb *storageBackend) ResizeStorageInstance(tag names.StorageTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize storage %q", tag.Id())
+	si, err := sb.storageInstance(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumeTag, err := sb.storageInstanceBackingVolume(si)
+	if err == ErrNoBackingVolume {
+		return errors.NotSupportedf("resizing filesystem storage with no backing volume")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(sb.resizeVolume(volumeTag, size))
+}
+
+// storageInstanceBackingVolume returns the tag of the volume backing
+// the given storage instance: the storage's own volume for block
+// storage, or the volume beneath the filesystem for filesystem storage.
+// ErrNoBackingVolume is returned for filesystem storage that is not
+// backed by a volume.
+func (sb *storageBackend) storageInstanceBackingVolume(si *storageInstance) (names.VolumeTag, error) {
+	switch si.Kind() {
+	case StorageKindBlock:
+		v, err := sb.storageInstanceVolume(si.StorageTag())
+		if err != nil {
+			return names.VolumeTag{}, errors.Trace(err)
+		}
+		return v.VolumeTag(), nil
+	case StorageKindFilesystem:
+		f, err := sb.storageInstanceFilesystem(si.StorageTag())
+		if err != nil {
+			return names.VolumeTag{}, errors.Trace(err)
+		}
+		volumeTag, err := f.Volume()
+		if err != nil && err != ErrNoBackingVolume {
+			return names.VolumeTag{}, errors.Trace(err)
+		}
+		return volumeTag, err
+	}
+	return names.VolumeTag{}, errors.NotSupportedf("%s storage", si.Kind())
+}
+
 func (sb *storageBackend) destroyStorageInstance(
 	tag names.StorageTag,
 	destroyAttachments bool,
@@ -769,8 +816,9 @@ func createStorageOps(
 				Owner:       owner,
 				StorageName: t.storageName,
 				Constraints: storageInstanceConstraints{
-					Pool: cons.Pool,
-					Size: cons.Size,
+					Pool:     cons.Pool,
+					Size:     cons.Size,
+					Snapshot: cons.Snapshot,
 				},
 			}
 			var hostStorageOps []txn.Op
@@ -1578,6 +1626,11 @@ type StorageConstraints struct {
 
 	// Count is the required number of storage instances.
 	Count uint64 `bson:"count"`
+
+	// Snapshot, if non-empty, is the ID of the volume snapshot from
+	// which the storage instances are to be restored. It is only
+	// honoured when adding storage to an existing unit.
+	Snapshot string `bson:"snapshot,omitempty"`
 }
 
 func createStorageConstraintsOp(key string, cons map[string]StorageConstraints) txn.Op {
@@ -1985,6 +2038,14 @@ func (sb *storageBackend) addStorageForUnitOps(
 	}
 	ops := u.assertCharmOps(ch)
 
+	if cons.Snapshot != "" {
+		snapshotOps, err := sb.restoreSnapshotConstraints(charmStorageMeta, &cons)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		ops = append(ops, snapshotOps...)
+	}
+
 	if cons.Pool == "" || cons.Size == 0 {
 		// Either pool or size, or both, were not specified. Take the
 		// values from the unit's recorded storage constraints.
@@ -2028,6 +2089,12 @@ func (sb *storageBackend) addStorageForUnitOps(
 		return nil, nil, errors.NotValidf("adding storage where instance count is 0")
 	}
 
+	poolOps, err := useStoragePoolsOps(sb, map[string]StorageConstraints{storageName: cons})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	ops = append(ops, poolOps...)
+
 	tags, addUnitStorageOps, err := sb.addUnitStorageOps(charmMeta, u, storageName, cons, -1)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
@@ -2036,6 +2103,49 @@ func (sb *storageBackend) addStorageForUnitOps(
 	return tags, ops, nil
 }
 
+// restoreSnapshotConstraints completes the given storage constraints for
+// storage that is to be restored from a volume snapshot, and returns
+// ops to ensure that the snapshot remains available. The storage must
+// be block storage, and must come from the same pool as the snapshot
+// and be at least as large.
+func (sb *storageBackend) restoreSnapshotConstraints(
+	charmStorage charm.Storage,
+	cons *StorageConstraints,
+) ([]txn.Op, error) {
+	if charmStorage.Type != charm.StorageBlock {
+		return nil, errors.NotSupportedf("restoring %s storage from a snapshot", charmStorage.Type)
+	}
+	s, err := sb.volumeSnapshot(cons.Snapshot)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := s.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if cons.Pool == "" {
+		cons.Pool = s.Pool()
+	} else if cons.Pool != s.Pool() {
+		return nil, errors.Errorf(
+			"pool %q does not match pool %q of snapshot %q",
+			cons.Pool, s.Pool(), s.Id(),
+		)
+	}
+	if cons.Size == 0 {
+		cons.Size = info.Size
+	} else if cons.Size < info.Size {
+		return nil, errors.Errorf(
+			"size %dMiB is smaller than size %dMiB of snapshot %q",
+			cons.Size, info.Size, s.Id(),
+		)
+	}
+	return []txn.Op{{
+		C:      volumeSnapshotsC,
+		Id:     s.doc.DocID,
+		Assert: bson.D{{"info.snapshotid", info.SnapshotId}},
+	}}, nil
+}
+
 // addUnitStorageOps returns transaction ops to create storage for the given
 // unit. If countMin is non-negative, the Count field of the constraints will
 // be ignored, and as many storage instances as necessary to make up the
@@ -0,0 +1,217 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"reflect"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// StorageInstancesInPool returns the storage instances in the model
+// that have been or will be provisioned from the named storage pool.
+func (sb *storageBackend) StorageInstancesInPool(poolName string) ([]StorageInstance, error) {
+	storageInstances, err := sb.storageInstances(bson.D{{"constraints.pool", poolName}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	out := make([]StorageInstance, len(storageInstances))
+	for i, s := range storageInstances {
+		out[i] = s
+	}
+	return out, nil
+}
+
+// UpdateStoragePool replaces the provider type and attributes of the
+// named storage pool. If providerType is empty, the pool's existing
+// provider type is kept. While the pool is in use, its provider type
+// may not be changed, and its attributes may only be changed if the
+// provider implements storage.ConfigChangeValidator and accepts the
+// change.
+func (sb *storageBackend) UpdateStoragePool(
+	poolName string, providerType storage.ProviderType, attrs map[string]interface{},
+) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot update storage pool %q", poolName)
+	poolManager := poolmanager.New(sb.settings, sb.registry)
+	existing, err := poolManager.Get(poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if providerType == "" {
+		providerType = existing.Provider()
+	}
+	inUse, err := sb.storagePoolInUse(poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if inUse {
+		if providerType != existing.Provider() {
+			return errors.Errorf("storage pool %q in use, cannot change its provider", poolName)
+		}
+		if !poolAttrsEqual(existing.Attrs(), attrs) {
+			cfg, err := storage.NewConfig(poolName, providerType, attrs)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			p, err := sb.registry.StorageProvider(providerType)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			validator, ok := p.(storage.ConfigChangeValidator)
+			if !ok {
+				return errors.Errorf(
+					"storage pool %q in use, %q provider cannot change its attributes",
+					poolName, providerType,
+				)
+			}
+			if err := validator.ValidateConfigChange(existing, cfg); err != nil {
+				return errors.Annotatef(err, "storage pool %q in use", poolName)
+			}
+		}
+	}
+	return errors.Trace(poolManager.Replace(poolName, providerType, attrs))
+}
+
+// poolAttrsEqual reports whether or not two sets of storage pool
+// attributes are the same, treating nil and empty as equal.
+func poolAttrsEqual(a, b map[string]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || !reflect.DeepEqual(v, bv) {
+			return false
+		}
+	}
+	return true
+}
+
+// RemoveStoragePool removes the named storage pool. A pool may not be
+// removed while it is the model's default storage source, or while it
+// is referenced by any application's storage constraints, or by any
+// storage instance, volume or filesystem.
+//
+// Storage constraints and storage that name a configured pool are
+// written together with a change to the pool's settings, and the
+// removal asserts that neither those settings nor the model config
+// have changed, so the pool can't come into use while it is being
+// removed.
+func (sb *storageBackend) RemoveStoragePool(poolName string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove storage pool %q", poolName)
+	poolManager := poolmanager.New(sb.settings, sb.registry)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := poolManager.Get(poolName); err != nil {
+			return nil, errors.Trace(err)
+		}
+		inUse, err := sb.storagePoolInUse(poolName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if inUse {
+			return nil, errors.Errorf("storage pool %q in use", poolName)
+		}
+		modelSettings, err := readSettings(sb.mb.db(), settingsC, modelGlobalKey)
+		if err != nil {
+			return nil, errors.Annotate(err, "reading model config")
+		}
+		poolSettings, err := readSettings(sb.mb.db(), settingsC, poolmanager.SettingsKey(poolName))
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading storage pool %q", poolName)
+		}
+		removeOp := poolSettings.assertUnchangedOp()
+		removeOp.Remove = true
+		return []txn.Op{modelSettings.assertUnchangedOp(), removeOp}, nil
+	}
+	return errors.Trace(sb.mb.db().Run(buildTxn))
+}
+
+// useStoragePoolsOps returns txn.Ops asserting that the storage pools
+// named by the given constraints still exist, and bumping the version
+// of their settings so that a concurrent removal of any of them fails.
+// Constraints that name a storage provider type, rather than a
+// configured pool, need no ops.
+func useStoragePoolsOps(sb *storageBackend, cons map[string]StorageConstraints) ([]txn.Op, error) {
+	var ops []txn.Op
+	seen := set.NewStrings()
+	for _, c := range cons {
+		if c.Pool == "" || seen.Contains(c.Pool) {
+			continue
+		}
+		seen.Add(c.Pool)
+		key := poolmanager.SettingsKey(c.Pool)
+		if _, err := sb.settings.ReadSettings(key); errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "reading storage pool %q", c.Pool)
+		}
+		ops = append(ops, txn.Op{
+			C:      settingsC,
+			Id:     key,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$inc", bson.D{{"version", 1}}}},
+		})
+	}
+	return ops, nil
+}
+
+// storagePoolInUse reports whether or not the named storage pool is
+// referenced by the model config, storage constraints, or any storage
+// entity.
+func (sb *storageBackend) storagePoolInUse(poolName string) (bool, error) {
+	cfg, err := sb.config()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if source, ok := cfg.StorageDefaultBlockSource(); ok && source == poolName {
+		return true, nil
+	}
+	if source, ok := cfg.StorageDefaultFilesystemSource(); ok && source == poolName {
+		return true, nil
+	}
+
+	consColl, closer := sb.mb.db().GetCollection(storageConstraintsC)
+	defer closer()
+	var doc storageConstraintsDoc
+	iter := consColl.Find(nil).Iter()
+	for iter.Next(&doc) {
+		for _, cons := range doc.Constraints {
+			if cons.Pool == poolName {
+				iter.Close()
+				return true, nil
+			}
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return false, errors.Annotate(err, "cannot get storage constraints")
+	}
+
+	for collection, query := range map[string]bson.D{
+		storageInstancesC: {{"constraints.pool", poolName}},
+		volumesC: {{"$or", []bson.D{
+			{{"params.pool", poolName}},
+			{{"info.pool", poolName}},
+		}}},
+		filesystemsC: {{"$or", []bson.D{
+			{{"params.pool", poolName}},
+			{{"info.pool", poolName}},
+		}}},
+	} {
+		coll, closer := sb.mb.db().GetCollection(collection)
+		n, err := coll.Find(query).Count()
+		closer()
+		if err != nil {
+			return false, errors.Annotatef(err, "cannot count %s", collection)
+		}
+		if n > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,134 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage/poolmanager"
+	"github.com/juju/juju/storage/provider"
+)
+
+type StoragePoolSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&StoragePoolSuite{})
+
+func (s *StoragePoolSuite) TestRemoveStoragePool(c *gc.C) {
+	err := s.storageBackend.RemoveStoragePool("persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.RemoveStoragePool("persistent-block")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StoragePoolSuite) TestRemoveStoragePoolNotFound(c *gc.C) {
+	err := s.storageBackend.RemoveStoragePool("foo")
+	c.Assert(err, gc.ErrorMatches, `cannot remove storage pool "foo": pool "foo" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StoragePoolSuite) TestRemoveStoragePoolInUseByStorage(c *gc.C) {
+	s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.storageBackend.RemoveStoragePool("loop-pool")
+	c.Assert(err, gc.ErrorMatches, `cannot remove storage pool "loop-pool": storage pool "loop-pool" in use`)
+}
+
+func (s *StoragePoolSuite) TestRemoveStoragePoolInUseByConstraints(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	s.AddTestingApplicationWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+		"data": makeStorageCons("loop-pool", 1024, 1),
+	})
+	err := s.storageBackend.RemoveStoragePool("loop-pool")
+	c.Assert(err, gc.ErrorMatches, `cannot remove storage pool "loop-pool": storage pool "loop-pool" in use`)
+}
+
+func (s *StoragePoolSuite) TestRemoveStoragePoolModelDefault(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"storage-default-block-source": "persistent-block",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.RemoveStoragePool("persistent-block")
+	c.Assert(err, gc.ErrorMatches, `cannot remove storage pool "persistent-block": storage pool "persistent-block" in use`)
+}
+
+func (s *StoragePoolSuite) TestRemoveStoragePoolConcurrentUse(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	defer state.SetBeforeHooks(c, s.st, func() {
+		s.AddTestingApplicationWithStorage(c, "storage-block", ch, map[string]state.StorageConstraints{
+			"data": makeStorageCons("loop-pool", 1024, 1),
+		})
+	}).Check()
+	err := s.storageBackend.RemoveStoragePool("loop-pool")
+	c.Assert(err, gc.ErrorMatches, `cannot remove storage pool "loop-pool": storage pool "loop-pool" in use`)
+}
+
+func (s *StoragePoolSuite) TestAddApplicationConcurrentPoolRemoval(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	defer state.SetBeforeHooks(c, s.st, func() {
+		err := s.storageBackend.RemoveStoragePool("loop-pool")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+	_, err := s.st.AddApplication(state.AddApplicationArgs{
+		Name:  "storage-block",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("loop-pool", 1024, 1),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "storage-block": .*pool "loop-pool" not found`)
+}
+
+func (s *StoragePoolSuite) TestUpdateStoragePool(c *gc.C) {
+	err := s.storageBackend.UpdateStoragePool("loop-pool", provider.TmpfsProviderType, map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := state.NewStateSettings(s.st).ReadSettings(poolmanager.SettingsKey("loop-pool"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]interface{}{
+		"name": "loop-pool",
+		"type": "tmpfs",
+		"foo":  "bar",
+	})
+}
+
+func (s *StoragePoolSuite) TestUpdateStoragePoolNotFound(c *gc.C) {
+	err := s.storageBackend.UpdateStoragePool("foo", "", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot update storage pool "foo": pool "foo" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StoragePoolSuite) TestUpdateStoragePoolInUseUnchanged(c *gc.C) {
+	s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.storageBackend.UpdateStoragePool("loop-pool", "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StoragePoolSuite) TestUpdateStoragePoolInUseProvider(c *gc.C) {
+	s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.storageBackend.UpdateStoragePool("loop-pool", provider.TmpfsProviderType, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot update storage pool "loop-pool": storage pool "loop-pool" in use, cannot change its provider`)
+}
+
+func (s *StoragePoolSuite) TestUpdateStoragePoolInUseAttributes(c *gc.C) {
+	s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.storageBackend.UpdateStoragePool("loop-pool", "", map[string]interface{}{"foo": "bar"})
+	c.Assert(err, gc.ErrorMatches, `cannot update storage pool "loop-pool": storage pool "loop-pool" in use, "loop" provider cannot change its attributes`)
+}
+
+func (s *StoragePoolSuite) TestStorageInstancesInPool(c *gc.C) {
+	s.setupSingleStorage(c, "block", "loop-pool")
+
+	storageInstances, err := s.storageBackend.StorageInstancesInPool("loop-pool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstances, gc.HasLen, 1)
+	c.Assert(storageInstances[0].StorageTag(), gc.Equals, names.NewStorageTag("data/0"))
+
+	storageInstances, err = s.storageBackend.StorageInstancesInPool("persistent-block")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storageInstances, gc.HasLen, 0)
+}
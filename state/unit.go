@@ -1225,6 +1225,103 @@ func (u *Unit) ClosePortsOnSubnet(subnetID, protocol string, fromPort, toPort in
 	return machinePorts.ClosePorts(ports)
 }
 
+// OpenPortsForEndpoint opens the given port range and protocol for the
+// named endpoint of the unit, if it does not conflict with another already
+// opened range on the unit's assigned machine. When the application is
+// exposed, ports opened for an endpoint may only be accessed from where
+// that endpoint is exposed to. An empty endpoint (AllEndpoints) opens the
+// ports for every endpoint, as OpenPorts does.
+func (u *Unit) OpenPortsForEndpoint(endpoint, protocol string, fromPort, toPort int) (err error) {
+	ports, err := u.endpointPortRange(endpoint, protocol, fromPort, toPort)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q", ports, u)
+
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	machinePorts, err := getOrCreatePorts(u.st, machineID, "")
+	if err != nil {
+		return errors.Annotate(err, "cannot get or create ports")
+	}
+	return machinePorts.OpenPorts(ports)
+}
+
+// ClosePortsForEndpoint closes the given port range and protocol for the
+// named endpoint of the unit. Closing ports opened for a different
+// endpoint, or for all endpoints, has no effect.
+func (u *Unit) ClosePortsForEndpoint(endpoint, protocol string, fromPort, toPort int) (err error) {
+	ports, err := u.endpointPortRange(endpoint, protocol, fromPort, toPort)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot close ports %v for unit %q", ports, u)
+
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	machinePorts, err := getOrCreatePorts(u.st, machineID, "")
+	if err != nil {
+		return errors.Annotate(err, "cannot get or create ports")
+	}
+	return machinePorts.ClosePorts(ports)
+}
+
+// endpointPortRange returns the port range for the unit's endpoint,
+// checking that the application has such an endpoint.
+func (u *Unit) endpointPortRange(endpoint, protocol string, fromPort, toPort int) (PortRange, error) {
+	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
+	if err != nil {
+		return PortRange{}, errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
+	}
+	if endpoint == AllEndpoints {
+		return ports, nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return PortRange{}, errors.Trace(err)
+	}
+	if _, err := app.Endpoint(endpoint); err != nil {
+		return PortRange{}, errors.NotValidf("endpoint %q for application %q", endpoint, app.Name())
+	}
+	ports.Endpoint = endpoint
+	return ports, nil
+}
+
+// OpenedPortsByEndpoint returns the port ranges opened by the unit on its
+// assigned machine, keyed by the endpoint they are opened for. Ranges
+// opened for every endpoint are keyed by AllEndpoints. A unit that is not
+// assigned to a machine has no opened ports.
+func (u *Unit) OpenedPortsByEndpoint() (map[string][]network.PortRange, error) {
+	machineID, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machinePorts, err := getPorts(u.st, machineID, "")
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "failed getting ports for unit %q", u)
+	}
+	result := make(map[string][]network.PortRange)
+	for _, port := range machinePorts.PortsForUnit(u.Name()) {
+		result[port.Endpoint] = append(result[port.Endpoint], network.PortRange{
+			Protocol: port.Protocol,
+			FromPort: port.FromPort,
+			ToPort:   port.ToPort,
+		})
+	}
+	for _, ranges := range result {
+		network.SortPortRanges(ranges)
+	}
+	return result, nil
+}
+
 // OpenPorts opens the given port range and protocol for the unit, if it does
 // not conflict with another already opened range on the unit's assigned
 // machine.
@@ -1296,12 +1393,19 @@ func (u *Unit) OpenedPortsOnSubnet(subnetID string) ([]network.PortRange, error)
 		return nil, errors.Annotatef(err, "failed getting ports for unit %q, subnet %q", u, subnetID)
 	}
 	ports := machinePorts.PortsForUnit(u.Name())
+	seen := make(map[network.PortRange]bool)
 	for _, port := range ports {
-		result = append(result, network.PortRange{
+		// The same range may be opened for several endpoints.
+		portRange := network.PortRange{
 			Protocol: port.Protocol,
 			FromPort: port.FromPort,
 			ToPort:   port.ToPort,
-		})
+		}
+		if seen[portRange] {
+			continue
+		}
+		seen[portRange] = true
+		result = append(result, portRange)
 	}
 	network.SortPortRanges(result)
 	return result, nil
@@ -2270,6 +2374,17 @@ func storageParamsForStorageInstance(
 				Pool:    storage.doc.Constraints.Pool,
 				Size:    storage.doc.Constraints.Size,
 			}
+			if snapshotId := storage.doc.Constraints.Snapshot; snapshotId != "" {
+				snapshot, err := sb.volumeSnapshot(snapshotId)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				info, err := snapshot.Info()
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				volumeParams.SnapshotId = info.SnapshotId
+			}
 			volumes = append(volumes, HostVolumeParams{
 				volumeParams, volumeAttachmentParams,
 			})
@@ -1295,6 +1295,53 @@ func (s *UnitSuite) testOpenedPorts(c *gc.C, subnetID, expectedErrorCauseMatches
 	}
 }
 
+func (s *UnitSuite) TestOpenClosePortsForEndpoint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenPortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPortsForEndpoint("logging-dir", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPorts("tcp", 443, 443)
+	c.Assert(err, jc.ErrorIsNil)
+
+	byEndpoint, err := s.unit.OpenedPortsByEndpoint()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(byEndpoint, jc.DeepEquals, map[string][]network.PortRange{
+		"":            {{443, 443, "tcp"}},
+		"url":         {{80, 80, "tcp"}},
+		"logging-dir": {{80, 80, "tcp"}},
+	})
+	open, err := s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, jc.DeepEquals, []network.PortRange{
+		{80, 80, "tcp"},
+		{443, 443, "tcp"},
+	})
+
+	err = s.unit.ClosePortsForEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	byEndpoint, err = s.unit.OpenedPortsByEndpoint()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(byEndpoint, jc.DeepEquals, map[string][]network.PortRange{
+		"":            {{443, 443, "tcp"}},
+		"logging-dir": {{80, 80, "tcp"}},
+	})
+}
+
+func (s *UnitSuite) TestOpenPortsForUnknownEndpoint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenPortsForEndpoint("bogus", "tcp", 80, 80)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *UnitSuite) TestOpenClosePortWhenDying(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1340,7 +1387,7 @@ func (s *UnitSuite) TestRemoveLastUnitOnMachineRemovesAllPorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), jc.DeepEquals, []state.PortRange{
-		{s.unit.Name(), 100, 200, "tcp"},
+		{s.unit.Name(), 100, 200, "tcp", ""},
 	})
 
 	// Now remove the unit and check again.
@@ -1382,10 +1429,10 @@ func (s *UnitSuite) TestRemoveUnitRemovesItsPortsOnly(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), jc.DeepEquals, []state.PortRange{
-		{s.unit.Name(), 100, 200, "tcp"},
+		{s.unit.Name(), 100, 200, "tcp", ""},
 	})
 	c.Assert(ports[0].PortsForUnit(otherUnit.Name()), jc.DeepEquals, []state.PortRange{
-		{otherUnit.Name(), 300, 400, "udp"},
+		{otherUnit.Name(), 300, 400, "udp", ""},
 	})
 
 	// Now remove the first unit and check again.
@@ -1402,7 +1449,7 @@ func (s *UnitSuite) TestRemoveUnitRemovesItsPortsOnly(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 1)
 	c.Assert(ports[0].PortsForUnit(s.unit.Name()), gc.HasLen, 0)
 	c.Assert(ports[0].PortsForUnit(otherUnit.Name()), jc.DeepEquals, []state.PortRange{
-		{otherUnit.Name(), 300, 400, "udp"},
+		{otherUnit.Name(), 300, 400, "udp", ""},
 	})
 }
 
@@ -0,0 +1,115 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	mgoutils "github.com/juju/juju/mongo/utils"
+)
+
+// unitStateDoc records the charm state of a unit: arbitrary key/value
+// pairs stored on behalf of the charm by the state-set hook tool.
+type unitStateDoc struct {
+	// DocID is the global key of the unit, prefixed by the model UUID.
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+
+	// CharmState holds the charm's key/value pairs, with keys
+	// escaped so that they may be stored in mongo.
+	CharmState map[string]string `bson:"charm-state"`
+}
+
+// CharmState returns the charm state of the unit. If the charm has not
+// stored any state, an empty map is returned.
+func (u *Unit) CharmState() (map[string]string, error) {
+	coll, cleanup := u.st.db().GetCollection(unitStatesC)
+	defer cleanup()
+
+	var doc unitStateDoc
+	err := coll.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return make(map[string]string), nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm state for unit %q", u.Name())
+	}
+	return unescapeCharmState(doc.CharmState), nil
+}
+
+// SetCharmState replaces the charm state of the unit with the supplied
+// key/value pairs. An error is returned if the unit is dead.
+func (u *Unit) SetCharmState(charmState map[string]string) error {
+	escaped := escapeCharmState(charmState)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() == Dead {
+			return nil, errors.Errorf("unit %q is dead", u.Name())
+		}
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+
+		coll, cleanup := u.st.db().GetCollection(unitStatesC)
+		defer cleanup()
+		var doc unitStateDoc
+		err := coll.FindId(u.globalKey()).One(&doc)
+		if err == mgo.ErrNotFound {
+			if len(escaped) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return append(ops, txn.Op{
+				C:      unitStatesC,
+				Id:     u.st.docID(u.globalKey()),
+				Assert: txn.DocMissing,
+				Insert: unitStateDoc{CharmState: escaped},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      unitStatesC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"charm-state", escaped}}}},
+		}), nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "cannot set charm state for unit %q", u.Name())
+	}
+	return nil
+}
+
+func removeUnitStateOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitStatesC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
+
+func escapeCharmState(charmState map[string]string) map[string]string {
+	escaped := make(map[string]string, len(charmState))
+	for k, v := range charmState {
+		escaped[mgoutils.EscapeKey(k)] = v
+	}
+	return escaped
+}
+
+func unescapeCharmState(charmState map[string]string) map[string]string {
+	unescaped := make(map[string]string, len(charmState))
+	for k, v := range charmState {
+		unescaped[mgoutils.UnescapeKey(k)] = v
+	}
+	return unescaped
+}
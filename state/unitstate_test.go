@@ -0,0 +1,71 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type UnitStateSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitStateSuite{})
+
+func (s *UnitStateSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+}
+
+func (s *UnitStateSuite) TestCharmStateEmpty(c *gc.C) {
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+}
+
+func (s *UnitStateSuite) TestSetCharmState(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{
+		"foo":         "bar",
+		"$dotted.key": "baz",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{
+		"foo":         "bar",
+		"$dotted.key": "baz",
+	})
+
+	// Setting the charm state replaces it entirely.
+	err = s.unit.SetCharmState(map[string]string{"wibble": "wobble"})
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err = s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"wibble": "wobble"})
+}
+
+func (s *UnitStateSuite) TestSetCharmStateDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, gc.ErrorMatches, `cannot set charm state for unit "[a-z0-9-]+/0": unit "[a-z0-9-]+/0" is dead`)
+}
+
+func (s *UnitStateSuite) TestRemoveUnitRemovesCharmState(c *gc.C) {
+	err := s.unit.SetCharmState(map[string]string{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+}
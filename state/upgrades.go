@@ -1694,3 +1694,41 @@ func migrateStorageMachineIds(st *State) error {
 	}
 	return nil
 }
+
+// AddPortRangeEndpoints records that the port ranges opened before
+// ports could be opened for a single endpoint are opened for all
+// endpoints.
+func AddPortRangeEndpoints(st *State) error {
+	return runForAllModelStates(st, addPortRangeEndpoints)
+}
+
+func addPortRangeEndpoints(st *State) error {
+	openedPorts, closer := st.db().GetCollection(openedPortsC)
+	defer closer()
+
+	var docs []portsDoc
+	err := openedPorts.Find(bson.D{{
+		"ports", bson.D{{"$elemMatch", bson.D{{
+			"endpoint", bson.D{{"$exists", false}},
+		}}}},
+	}}).All(&docs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var ops []txn.Op
+	for _, doc := range docs {
+		// The ranges without an endpoint are read with an empty
+		// one, AllEndpoints, which is then written back.
+		ops = append(ops, txn.Op{
+			C:      openedPortsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{{"ports", doc.Ports}}}},
+		})
+	}
+	if len(ops) > 0 {
+		return errors.Trace(st.db().RunTransaction(ops))
+	}
+	return nil
+}
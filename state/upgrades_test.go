@@ -2713,3 +2713,76 @@ func (s *upgradesSuite) TestMigrateStorageMachineIdFields(c *gc.C) {
 		expectUpgradedData{filesystemAttachmentsColl, expectedFilesystemAttachments},
 	)
 }
+
+func (s *upgradesSuite) TestAddPortRangeEndpoints(c *gc.C) {
+	coll, closer := s.state.db().GetRawCollection(openedPortsC)
+	defer closer()
+
+	uuid := s.state.ModelUUID()
+	err := coll.Insert(bson.M{
+		"_id":        uuid + ":m#0#",
+		"model-uuid": uuid,
+		"machine-id": "0",
+		"subnet-id":  "",
+		"ports": []bson.M{{
+			"unitname": "wordpress/0",
+			"fromport": 80,
+			"toport":   80,
+			"protocol": "tcp",
+		}, {
+			"unitname": "wordpress/0",
+			"fromport": 443,
+			"toport":   443,
+			"protocol": "tcp",
+			"endpoint": "website",
+		}},
+	}, bson.M{
+		"_id":        uuid + ":m#1#",
+		"model-uuid": uuid,
+		"machine-id": "1",
+		"subnet-id":  "",
+		"ports": []bson.M{{
+			"unitname": "mysql/0",
+			"fromport": 3306,
+			"toport":   3306,
+			"protocol": "tcp",
+			"endpoint": "",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := []bson.M{{
+		"_id":        uuid + ":m#0#",
+		"model-uuid": uuid,
+		"machine-id": "0",
+		"subnet-id":  "",
+		"ports": []interface{}{bson.M{
+			"unitname": "wordpress/0",
+			"fromport": 80,
+			"toport":   80,
+			"protocol": "tcp",
+			"endpoint": "",
+		}, bson.M{
+			"unitname": "wordpress/0",
+			"fromport": 443,
+			"toport":   443,
+			"protocol": "tcp",
+			"endpoint": "website",
+		}},
+	}, {
+		"_id":        uuid + ":m#1#",
+		"model-uuid": uuid,
+		"machine-id": "1",
+		"subnet-id":  "",
+		"ports": []interface{}{bson.M{
+			"unitname": "mysql/0",
+			"fromport": 3306,
+			"toport":   3306,
+			"protocol": "tcp",
+			"endpoint": "",
+		}},
+	}}
+	s.assertUpgradedData(c, AddPortRangeEndpoints,
+		expectUpgradedData{coll, expected},
+	)
+}
@@ -46,6 +46,11 @@ type Volume interface {
 	// returned parameters are usable for provisioning, otherwise false.
 	Params() (VolumeParams, bool)
 
+	// PendingSize returns the size, in MiB, that the volume has been
+	// asked to grow to. PendingSize returns false if there is no
+	// outstanding request to resize the volume.
+	PendingSize() (uint64, bool)
+
 	// Detachable reports whether or not the volume is detachable.
 	Detachable() bool
 
@@ -98,6 +103,11 @@ type volumeDoc struct {
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
 
+	// PendingSize is the size, in MiB, that the volume is to be
+	// grown to. It is cleared once the volume's info records a
+	// size at least this large.
+	PendingSize uint64 `bson:"pending-size,omitempty"`
+
 	// HostId is the ID of the host that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the volume as being non-detachable, and to determine
@@ -130,6 +140,10 @@ type VolumeParams struct {
 
 	Pool string `bson:"pool"`
 	Size uint64 `bson:"size"`
+
+	// SnapshotId, if non-empty, is the provider ID of the volume
+	// snapshot from which the volume is to be restored.
+	SnapshotId string `bson:"snapshotid,omitempty"`
 }
 
 // VolumeInfo describes information about a volume.
@@ -206,6 +220,11 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// PendingSize is required to implement Volume.
+func (v *volume) PendingSize() (uint64, bool) {
+	return v.doc.PendingSize, v.doc.PendingSize > 0
+}
+
 // Releasing is required to imeplement Volume.
 func (v *volume) Releasing() bool {
 	return v.doc.Releasing
@@ -219,7 +238,7 @@ func (v *volume) Status() (status.StatusInfo, error) {
 // SetStatus is required to implement StatusSetter.
 func (v *volume) SetStatus(volumeStatus status.StatusInfo) error {
 	switch volumeStatus.Status {
-	case status.Attaching, status.Attached, status.Detaching, status.Detached, status.Destroying:
+	case status.Attaching, status.Attached, status.Detaching, status.Detached, status.Destroying, status.Resizing:
 	case status.Error:
 		if volumeStatus.Message == "" {
 			return errors.Errorf("cannot set status %q without info", volumeStatus.Status)
@@ -1116,6 +1135,16 @@ func (sb *storageBackend) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (e
 			}
 		}
 		ops = append(ops, setVolumeInfoOps(tag, info, unsetParams)...)
+		if size, ok := v.PendingSize(); ok && info.Size >= size {
+			// The volume has grown to the requested
+			// size, so the resize is complete.
+			ops = append(ops, txn.Op{
+				C:      volumesC,
+				Id:     tag.Id(),
+				Assert: bson.D{{"pending-size", size}},
+				Update: bson.D{{"$unset", bson.D{{"pending-size", nil}}}},
+			})
+		}
 		return ops, nil
 	}
 	return sb.mb.db().Run(buildTxn)
@@ -1155,6 +1184,51 @@ func setVolumeInfoOps(tag names.VolumeTag, info VolumeInfo, unsetParams bool) []
 	}}
 }
 
+// ResizeVolume records a request to grow the specified volume to the
+// given size, in MiB. The volume must be alive and provisioned, and
+// the new size must be larger than the volume's current size. The
+// storage provisioner responsible for the volume will then resize it,
+// if its storage provider supports doing so.
+func (sb *storageBackend) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	return sb.resizeVolume(tag, size)
+}
+
+func (sb *storageBackend) resizeVolume(tag names.VolumeTag, size uint64) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := getVolumeByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.Errorf(
+				"new size %dMiB must be larger than current size %dMiB",
+				size, info.Size,
+			)
+		}
+		if pending, ok := v.PendingSize(); ok && pending == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:  volumesC,
+			Id: tag.Id(),
+			Assert: append(isAliveDoc,
+				bson.DocElem{"info.volumeid", info.VolumeId},
+				bson.DocElem{"info.size", info.Size},
+			),
+			Update: bson.D{{"$set", bson.D{{"pending-size", size}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
 // AllVolumes returns all Volumes scoped to the model.
 func (sb *storageBackend) AllVolumes() ([]Volume, error) {
 	volumes, err := sb.volumes(nil)
@@ -0,0 +1,266 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// VolumeSnapshot describes a point-in-time snapshot of a volume.
+// Snapshots outlive the volumes they are taken from, so that new
+// storage may be restored from them.
+type VolumeSnapshot interface {
+	// Id returns the ID of the snapshot, which is of the form
+	// <volume-id>@<n>.
+	Id() string
+
+	// Volume returns the tag of the volume that the snapshot
+	// was taken from.
+	Volume() names.VolumeTag
+
+	// Storage returns the tag of the storage instance that the
+	// volume was assigned to when the snapshot was requested, if
+	// any. Storage returns false if there was no such storage.
+	Storage() (names.StorageTag, bool)
+
+	// Pool returns the name of the storage pool that the volume
+	// was provisioned from. Storage restored from the snapshot
+	// must come from the same pool.
+	Pool() string
+
+	// Created returns the time at which the snapshot was requested.
+	Created() time.Time
+
+	// Info returns the snapshot's VolumeSnapshotInfo, or a NotProvisioned
+	// error if the snapshot has not yet been taken.
+	Info() (VolumeSnapshotInfo, error)
+}
+
+// VolumeSnapshotInfo describes information about a volume snapshot.
+type VolumeSnapshotInfo struct {
+	SnapshotId string `bson:"snapshotid"`
+	Size       uint64 `bson:"size"`
+}
+
+type volumeSnapshot struct {
+	doc volumeSnapshotDoc
+}
+
+// volumeSnapshotDoc records information about a volume snapshot.
+type volumeSnapshotDoc struct {
+	DocID     string              `bson:"_id"`
+	Id        string              `bson:"id"`
+	ModelUUID string              `bson:"model-uuid"`
+	Volume    string              `bson:"volumeid"`
+	StorageId string              `bson:"storageid,omitempty"`
+	Pool      string              `bson:"pool"`
+	Created   time.Time           `bson:"created"`
+	Info      *VolumeSnapshotInfo `bson:"info,omitempty"`
+}
+
+// Id is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Id() string {
+	return s.doc.Id
+}
+
+// Volume is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Volume() names.VolumeTag {
+	return names.NewVolumeTag(s.doc.Volume)
+}
+
+// Storage is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Storage() (names.StorageTag, bool) {
+	if s.doc.StorageId == "" {
+		return names.StorageTag{}, false
+	}
+	return names.NewStorageTag(s.doc.StorageId), true
+}
+
+// Pool is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Pool() string {
+	return s.doc.Pool
+}
+
+// Created is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Created() time.Time {
+	return s.doc.Created
+}
+
+// Info is required to implement VolumeSnapshot.
+func (s *volumeSnapshot) Info() (VolumeSnapshotInfo, error) {
+	if s.doc.Info == nil {
+		return VolumeSnapshotInfo{}, errors.NotProvisionedf("volume snapshot %q", s.doc.Id)
+	}
+	return *s.doc.Info, nil
+}
+
+// VolumeSnapshot returns the VolumeSnapshot with the specified ID.
+func (sb *storageBackend) VolumeSnapshot(id string) (VolumeSnapshot, error) {
+	s, err := sb.volumeSnapshot(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s, nil
+}
+
+func (sb *storageBackend) volumeSnapshot(id string) (*volumeSnapshot, error) {
+	coll, closer := sb.mb.db().GetCollection(volumeSnapshotsC)
+	defer closer()
+
+	var doc volumeSnapshotDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("volume snapshot %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get volume snapshot %q", id)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// volumeSnapshotStorageName returns the name of the charm storage
+// that the volume of the snapshot with the specified ID was assigned
+// to when the snapshot was requested.
+func (sb *storageBackend) volumeSnapshotStorageName(id string) (string, error) {
+	s, err := sb.volumeSnapshot(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	storageTag, ok := s.Storage()
+	if !ok {
+		return "", errors.Errorf("volume snapshot %q was not taken from storage", id)
+	}
+	storageName, err := names.StorageName(storageTag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return storageName, nil
+}
+
+// AllVolumeSnapshots returns all VolumeSnapshots in the model.
+func (sb *storageBackend) AllVolumeSnapshots() ([]VolumeSnapshot, error) {
+	coll, closer := sb.mb.db().GetCollection(volumeSnapshotsC)
+	defer closer()
+
+	var docs []volumeSnapshotDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get volume snapshots")
+	}
+	snapshots := make([]VolumeSnapshot, len(docs))
+	for i, doc := range docs {
+		snapshots[i] = &volumeSnapshot{doc}
+	}
+	return snapshots, nil
+}
+
+// CreateStorageInstanceSnapshot records a request to take a snapshot of
+// the volume backing the storage instance with the specified tag, as
+// described for CreateVolumeSnapshot.
+func (sb *storageBackend) CreateStorageInstanceSnapshot(tag names.StorageTag) (_ VolumeSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot snapshot storage %q", tag.Id())
+	si, err := sb.storageInstance(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeTag, err := sb.storageInstanceBackingVolume(si)
+	if err == ErrNoBackingVolume {
+		return nil, errors.NotSupportedf("snapshotting filesystem storage with no backing volume")
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return sb.createVolumeSnapshot(volumeTag)
+}
+
+// CreateVolumeSnapshot records a request to take a snapshot of the
+// specified volume, and returns the pending snapshot. The volume must
+// be alive and provisioned. The storage provisioner responsible for
+// the volume will then take the snapshot, if its storage provider
+// supports doing so.
+func (sb *storageBackend) CreateVolumeSnapshot(tag names.VolumeTag) (_ VolumeSnapshot, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot snapshot volume %q", tag.Id())
+	return sb.createVolumeSnapshot(tag)
+}
+
+func (sb *storageBackend) createVolumeSnapshot(tag names.VolumeTag) (*volumeSnapshot, error) {
+	seq, err := sequence(sb.mb, "volumesnapshot")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id := fmt.Sprintf("%s@%d", tag.Id(), seq)
+	var doc volumeSnapshotDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := getVolumeByTag(sb.mb, tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc = volumeSnapshotDoc{
+			DocID:     sb.mb.docID(id),
+			Id:        id,
+			ModelUUID: sb.mb.modelUUID(),
+			Volume:    tag.Id(),
+			Pool:      info.Pool,
+			Created:   sb.mb.clock().Now().UTC(),
+		}
+		if storageTag, err := v.StorageInstance(); err == nil {
+			doc.StorageId = storageTag.Id()
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: append(isAliveDoc, bson.DocElem{"info", bson.D{{"$exists", true}}}),
+		}, {
+			C:      volumeSnapshotsC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := sb.mb.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &volumeSnapshot{doc}, nil
+}
+
+// SetVolumeSnapshotInfo records the information for the snapshot with
+// the specified ID, once it has been taken. The information may not be
+// changed once it has been set.
+func (sb *storageBackend) SetVolumeSnapshotInfo(id string, info VolumeSnapshotInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set info for volume snapshot %q", id)
+	if info.SnapshotId == "" {
+		return errors.New("snapshot ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		s, err := sb.volumeSnapshot(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if oldInfo, err := s.Info(); err == nil {
+			if oldInfo != info {
+				return nil, errors.New("snapshot info already set")
+			}
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumeSnapshotsC,
+			Id:     s.doc.DocID,
+			Assert: bson.D{{"info", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
@@ -0,0 +1,256 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/testing"
+)
+
+type VolumeSnapshotSuite struct {
+	StorageStateSuiteBase
+}
+
+var _ = gc.Suite(&VolumeSnapshotSuite{})
+
+// setupProvisionedStorage adds a unit with a single block storage
+// instance, and records the storage's volume as provisioned.
+func (s *VolumeSnapshotSuite) setupProvisionedStorage(c *gc.C) (*state.Unit, names.StorageTag, names.VolumeTag) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Size: 1024, VolumeId: "vol-ume", Pool: "loop-pool",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return u, storageTag, volumeTag
+}
+
+func (s *VolumeSnapshotSuite) TestCreateStorageInstanceSnapshot(c *gc.C) {
+	_, storageTag, volumeTag := s.setupProvisionedStorage(c)
+
+	snapshot, err := s.storageBackend.CreateStorageInstanceSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Id(), gc.Equals, "0/0@0")
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+	snapshotStorage, ok := snapshot.Storage()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(snapshotStorage, gc.Equals, storageTag)
+	c.Assert(snapshot.Pool(), gc.Equals, "loop-pool")
+	c.Assert(snapshot.Created().IsZero(), jc.IsFalse)
+	_, err = snapshot.Info()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	snapshot, err = s.storageBackend.VolumeSnapshot("0/0@0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.Volume(), gc.Equals, volumeTag)
+
+	snapshots, err := s.storageBackend.AllVolumeSnapshots()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshots, gc.HasLen, 1)
+	c.Assert(snapshots[0].Id(), gc.Equals, "0/0@0")
+}
+
+func (s *VolumeSnapshotSuite) TestCreateVolumeSnapshotNotProvisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeTag := s.storageInstanceVolume(c, storageTag).VolumeTag()
+
+	_, err = s.storageBackend.CreateVolumeSnapshot(volumeTag)
+	c.Assert(err, gc.ErrorMatches, `cannot snapshot volume "0/0": volume "0/0" not provisioned`)
+}
+
+func (s *VolumeSnapshotSuite) TestCreateStorageInstanceSnapshotNoBackingVolume(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storageBackend.CreateStorageInstanceSnapshot(storageTag)
+	c.Assert(err, gc.ErrorMatches, `cannot snapshot storage "data/0": snapshotting filesystem storage with no backing volume not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *VolumeSnapshotSuite) TestVolumeSnapshotNotFound(c *gc.C) {
+	_, err := s.storageBackend.VolumeSnapshot("0/0@42")
+	c.Assert(err, gc.ErrorMatches, `volume snapshot "0/0@42" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *VolumeSnapshotSuite) TestSetVolumeSnapshotInfo(c *gc.C) {
+	_, storageTag, _ := s.setupProvisionedStorage(c)
+	snapshot, err := s.storageBackend.CreateStorageInstanceSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := state.VolumeSnapshotInfo{SnapshotId: "snap-1", Size: 1024}
+	err = s.storageBackend.SetVolumeSnapshotInfo(snapshot.Id(), info)
+	c.Assert(err, jc.ErrorIsNil)
+	snapshot, err = s.storageBackend.VolumeSnapshot(snapshot.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	snapshotInfo, err := snapshot.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshotInfo, jc.DeepEquals, info)
+
+	// Setting the same info again is fine; changing it is not.
+	err = s.storageBackend.SetVolumeSnapshotInfo(snapshot.Id(), info)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{SnapshotId: "snap-2"})
+	c.Assert(err, gc.ErrorMatches, `cannot set info for volume snapshot "0/0@0": snapshot info already set`)
+}
+
+func (s *VolumeSnapshotSuite) takeSnapshot(c *gc.C, storageTag names.StorageTag) state.VolumeSnapshot {
+	snapshot, err := s.storageBackend.CreateStorageInstanceSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{
+		SnapshotId: "snap-1", Size: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return snapshot
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshot(c *gc.C) {
+	u, storageTag, _ := s.setupProvisionedStorage(c)
+	snapshot := s.takeSnapshot(c, storageTag)
+
+	tags, err := s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Snapshot: snapshot.Id(),
+		Count:    1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, gc.HasLen, 1)
+
+	si, err := s.storageBackend.StorageInstance(tags[0])
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Pool(), gc.Equals, "loop-pool")
+	volume := s.storageInstanceVolume(c, tags[0])
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Pool, gc.Equals, "loop-pool")
+	c.Assert(params.Size, gc.Equals, uint64(1024))
+	c.Assert(params.SnapshotId, gc.Equals, "snap-1")
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromPendingSnapshot(c *gc.C) {
+	u, storageTag, _ := s.setupProvisionedStorage(c)
+	snapshot, err := s.storageBackend.CreateStorageInstanceSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Snapshot: snapshot.Id(),
+		Count:    1,
+	})
+	c.Assert(err, gc.ErrorMatches, `adding "allecto" storage to storage-block/0: volume snapshot "0/0@0" not provisioned`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshotTooSmall(c *gc.C) {
+	u, storageTag, _ := s.setupProvisionedStorage(c)
+	snapshot := s.takeSnapshot(c, storageTag)
+
+	_, err := s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Snapshot: snapshot.Id(),
+		Size:     512,
+		Count:    1,
+	})
+	c.Assert(err, gc.ErrorMatches, `adding "allecto" storage to storage-block/0: size 512MiB is smaller than size 1024MiB of snapshot "0/0@0"`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddStorageFromSnapshotDifferentPool(c *gc.C) {
+	u, storageTag, _ := s.setupProvisionedStorage(c)
+	snapshot := s.takeSnapshot(c, storageTag)
+
+	_, err := s.storageBackend.AddStorageForUnit(u.UnitTag(), "allecto", state.StorageConstraints{
+		Snapshot: snapshot.Id(),
+		Pool:     "static",
+		Count:    1,
+	})
+	c.Assert(err, gc.ErrorMatches, `adding "allecto" storage to storage-block/0: pool "static" does not match pool "loop-pool" of snapshot "0/0@0"`)
+}
+
+func (s *VolumeSnapshotSuite) TestAddApplicationRestoresSnapshot(c *gc.C) {
+	u, storageTag, _ := s.setupProvisionedStorage(c)
+	snapshot := s.takeSnapshot(c, storageTag)
+	app, err := u.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	ch, _, err := app.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+
+	app2, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:   "secondwind",
+		Series: app.Series(),
+		Charm:  ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("loop-pool", 1024, 1),
+		},
+		RestoreSnapshots: []string{snapshot.Id()},
+		NumUnits:         1,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := app2.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+
+	// The restored storage takes the place of the storage
+	// that would otherwise have been created.
+	attachments, err := s.storageBackend.UnitStorageAttachments(units[0].UnitTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(attachments, gc.HasLen, 1)
+	volume := s.storageInstanceVolume(c, attachments[0].StorageInstance())
+	params, ok := volume.Params()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(params.Pool, gc.Equals, "loop-pool")
+	c.Assert(params.Size, gc.Equals, uint64(1024))
+	c.Assert(params.SnapshotId, gc.Equals, "snap-1")
+}
+
+func (s *VolumeSnapshotSuite) TestAddApplicationRestoresSnapshotMultipleUnits(c *gc.C) {
+	u, storageTag, _ := s.setupProvisionedStorage(c)
+	snapshot := s.takeSnapshot(c, storageTag)
+	app, err := u.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	ch, _, err := app.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddApplication(state.AddApplicationArgs{
+		Name:             "secondwind",
+		Series:           app.Series(),
+		Charm:            ch,
+		RestoreSnapshots: []string{snapshot.Id()},
+		NumUnits:         2,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot add application "secondwind": RestoreSnapshots is non-empty but NumUnits is 2, must be 1`)
+}
+
+func (s *VolumeSnapshotSuite) TestWatchMachineVolumeSnapshots(c *gc.C) {
+	_, storageTag, _ := s.setupProvisionedStorage(c)
+
+	w := s.storageBackend.WatchMachineVolumeSnapshots(names.NewMachineTag("0"))
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent() // initial
+	wc.AssertNoChange()
+
+	// Snapshots of volumes on other machines are not reported.
+	w2 := s.storageBackend.WatchMachineVolumeSnapshots(names.NewMachineTag("1"))
+	defer testing.AssertStop(c, w2)
+	wc2 := testing.NewStringsWatcherC(c, s.State, w2)
+	wc2.AssertChangeInSingleEvent()
+
+	snapshot, err := s.storageBackend.CreateStorageInstanceSnapshot(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0@0")
+	wc.AssertNoChange()
+	wc2.AssertNoChange()
+
+	err = s.storageBackend.SetVolumeSnapshotInfo(snapshot.Id(), state.VolumeSnapshotInfo{SnapshotId: "snap-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0/0@0")
+	wc.AssertNoChange()
+	wc2.AssertNoChange()
+}
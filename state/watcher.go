@@ -4,6 +4,7 @@
 package state
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -234,14 +235,19 @@ func (sb *storageBackend) watchModelHostStorage(collection string) StringsWatche
 	mb := sb.mb
 	pattern := fmt.Sprintf("^%s$", mb.docID(machineOrUnitSnippet))
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
-	filter := func(id interface{}) bool {
+	return newLifecycleWatcher(mb, collection, members, modelHostStorageFilter(mb), nil)
+}
+
+// modelHostStorageFilter returns a watcher filter that matches the
+// IDs of model-scoped storage.
+func modelHostStorageFilter(mb modelBackend) func(interface{}) bool {
+	return func(id interface{}) bool {
 		k, err := mb.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
 		return !strings.Contains(k, "/")
 	}
-	return newLifecycleWatcher(mb, collection, members, filter, nil)
 }
 
 // WatchMachineVolumes returns a StringsWatcher that notifies of changes to
@@ -270,16 +276,66 @@ func (sb *storageBackend) watchHostStorage(host names.Tag, collection string) St
 	// The host parameter passed into this method is the application name, any of whose units we are interested in.
 	pattern := fmt.Sprintf("^%s(/%s)?/%s$", mb.docID(host.Id()), names.NumberSnippet, names.NumberSnippet)
 	members := bson.D{{"_id", bson.D{{"$regex", pattern}}}}
-	prefix := fmt.Sprintf("%s(/%s)?/.*", host.Id(), names.NumberSnippet)
+	return newLifecycleWatcher(mb, collection, members, hostStorageFilter(mb, host), nil)
+}
+
+// hostStorageFilter returns a watcher filter that matches the IDs
+// of storage scoped to the specified host.
+func hostStorageFilter(mb modelBackend, host names.Tag) func(interface{}) bool {
+	prefix := fmt.Sprintf("^%s(/%s)?/.*", host.Id(), names.NumberSnippet)
 	matchExp := regexp.MustCompile(prefix)
-	filter := func(id interface{}) bool {
+	return func(id interface{}) bool {
 		k, err := mb.strictLocalID(id.(string))
 		if err != nil {
 			return false
 		}
 		return matchExp.MatchString(k)
 	}
-	return newLifecycleWatcher(mb, collection, members, filter, nil)
+}
+
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to model-scoped volumes, so that requests to resize them
+// can be acted upon. Changes other than resize requests are also
+// notified; the volumes' pending sizes must be checked.
+func (sb *storageBackend) WatchModelVolumeResizes() StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    volumesC,
+		filter: modelHostStorageFilter(sb.mb),
+	})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to volumes scoped to the specified machine, so that requests
+// to resize them can be acted upon. Changes other than resize requests
+// are also notified; the volumes' pending sizes must be checked.
+func (sb *storageBackend) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    volumesC,
+		filter: hostStorageFilter(sb.mb, m),
+	})
+}
+
+// WatchModelVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to snapshots of model-scoped volumes, so that requests to take
+// them can be acted upon. Changes to snapshots that have already been
+// taken are also notified; the snapshots' info must be checked.
+func (sb *storageBackend) WatchModelVolumeSnapshots() StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    volumeSnapshotsC,
+		filter: modelHostStorageFilter(sb.mb),
+	})
+}
+
+// WatchMachineVolumeSnapshots returns a StringsWatcher that notifies of
+// changes to snapshots of volumes scoped to the specified machine, so
+// that requests to take them can be acted upon. Changes to snapshots
+// that have already been taken are also notified; the snapshots' info
+// must be checked.
+func (sb *storageBackend) WatchMachineVolumeSnapshots(m names.MachineTag) StringsWatcher {
+	return newCollectionWatcher(sb.mb, colWCfg{
+		col:    volumeSnapshotsC,
+		filter: hostStorageFilter(sb.mb, m),
+	})
 }
 
 // WatchModelVolumeAttachments returns a StringsWatcher that notifies of
@@ -1640,6 +1696,11 @@ func (sb *storageBackend) WatchStorageAttachment(s names.StorageTag, u names.Uni
 	return newEntityWatcher(sb.mb, storageAttachmentsC, sb.mb.docID(id))
 }
 
+// WatchVolume returns a watcher for observing changes to a volume.
+func (sb *storageBackend) WatchVolume(v names.VolumeTag) NotifyWatcher {
+	return newEntityWatcher(sb.mb, volumesC, sb.mb.docID(v.Id()))
+}
+
 // WatchVolumeAttachment returns a watcher for observing changes
 // to a volume attachment.
 func (sb *storageBackend) WatchVolumeAttachment(host names.Tag, v names.VolumeTag) NotifyWatcher {
@@ -2476,6 +2537,133 @@ func (st *State) watchEnqueuedActionsFilteredBy(receivers ...ActionReceiver) Str
 	})
 }
 
+// WatchActionLogs starts and returns a StringsWatcher that notifies
+// of the progress messages logged by the action with the given id.
+// Each message is reported as a JSON encoded ActionMessage; the first
+// event contains all messages logged so far.
+func (m *Model) WatchActionLogs(actionId string) StringsWatcher {
+	return newActionLogsWatcher(m.st, actionId)
+}
+
+// actionLogsWatcher reports the progress messages of an action.
+type actionLogsWatcher struct {
+	commonWatcher
+	actionId string
+	out      chan []string
+}
+
+var _ Watcher = (*actionLogsWatcher)(nil)
+
+func newActionLogsWatcher(backend modelBackend, actionId string) StringsWatcher {
+	w := &actionLogsWatcher{
+		commonWatcher: newCommonWatcher(backend),
+		actionId:      actionId,
+		out:           make(chan []string),
+	}
+	w.tomb.Go(func() error {
+		defer close(w.out)
+		return w.loop()
+	})
+	return w
+}
+
+// Changes returns the event channel for the actionLogsWatcher.
+func (w *actionLogsWatcher) Changes() <-chan []string {
+	return w.out
+}
+
+// messages returns the progress messages of the action, and the
+// txn-revno of the action document.
+func (w *actionLogsWatcher) messages() ([]ActionMessage, int64, error) {
+	actions, closer := w.db.GetCollection(actionsC)
+	defer closer()
+
+	var doc struct {
+		Messages []ActionMessage `bson:"messages"`
+		TxnRevno int64           `bson:"txn-revno"`
+	}
+	fields := bson.D{{"messages", 1}, {"txn-revno", 1}}
+	err := actions.FindId(w.actionId).Select(fields).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, 0, errors.NotFoundf("action %q", w.actionId)
+	} else if err != nil {
+		return nil, 0, errors.Annotatef(err, "cannot get action %q", w.actionId)
+	}
+	return doc.Messages, doc.TxnRevno, nil
+}
+
+func (w *actionLogsWatcher) loop() error {
+	messages, txnRevno, err := w.messages()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	changes, err := encodeActionMessages(messages)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	in := make(chan watcher.Change)
+	docID := w.backend.docID(w.actionId)
+	w.watcher.Watch(actionsC, docID, txnRevno, in)
+	defer w.watcher.Unwatch(actionsC, docID, in)
+
+	var lastSeq int
+	if len(messages) > 0 {
+		lastSeq = messages[len(messages)-1].Seq
+	}
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case <-in:
+			messages, _, err := w.messages()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			added := messagesAfter(messages, lastSeq)
+			if len(added) > 0 {
+				encoded, err := encodeActionMessages(added)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				changes = append(changes, encoded...)
+				lastSeq = added[len(added)-1].Seq
+				out = w.out
+			}
+		case out <- changes:
+			changes = nil
+			out = nil
+		}
+	}
+}
+
+// messagesAfter returns the messages with a sequence number greater
+// than lastSeq.
+func messagesAfter(messages []ActionMessage, lastSeq int) []ActionMessage {
+	for i, message := range messages {
+		if message.Seq > lastSeq {
+			return messages[i:]
+		}
+	}
+	return nil
+}
+
+// encodeActionMessages returns the JSON encoding of each message.
+func encodeActionMessages(messages []ActionMessage) ([]string, error) {
+	result := make([]string, len(messages))
+	for i, message := range messages {
+		message.Timestamp = message.Timestamp.UTC()
+		data, err := json.Marshal(message)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = string(data)
+	}
+	return result, nil
+}
+
 // WatchControllerStatusChanges starts and returns a StringsWatcher that
 // notifies when the status of a controller machine changes.
 // TODO(cherylj) Add unit tests for this, as per bug 1543408.
@@ -87,6 +87,18 @@ type Provider interface {
 	ValidateConfig(*Config) error
 }
 
+// ConfigChangeValidator provides an interface for checking changes to
+// the configuration of a storage pool that existing storage has been
+// provisioned from. Providers that can apply such changes should
+// implement ConfigChangeValidator; the configuration of a pool that is
+// in use may not otherwise be changed.
+type ConfigChangeValidator interface {
+	// ValidateConfigChange returns an error if the change from the
+	// old to the new storage provider config can't be applied to
+	// existing storage.
+	ValidateConfigChange(old, new *Config) error
+}
+
 // VolumeSource provides an interface for creating, destroying, describing,
 // attaching and detaching volumes in the environment. A VolumeSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -216,6 +228,33 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeResizer provides an interface for growing volumes in place,
+// while they remain attached to the machines that use them. Volume
+// sources that support online resizing should implement VolumeResizer.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified provider
+	// volume IDs to at least the requested sizes, returning the
+	// updated volume information for each.
+	//
+	// ResizeVolumes must be idempotent; it may be called for a
+	// volume that has already been grown to the requested size.
+	ResizeVolumes(ctx context.ProviderCallContext, params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
+// VolumeSnapshotter provides an interface for taking point-in-time
+// snapshots of volumes. Volume sources that implement VolumeSnapshotter
+// must also honour VolumeParams.SnapshotId when creating volumes, so
+// that new volumes may be restored from the snapshots.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots takes snapshots of the volumes with the
+	// specified provider volume IDs, returning the information for
+	// each snapshot taken.
+	//
+	// CreateVolumeSnapshots must be idempotent; it may be called
+	// again for a snapshot that has already been taken.
+	CreateVolumeSnapshots(ctx context.ProviderCallContext, params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -250,6 +289,52 @@ type VolumeParams struct {
 	// once the instance is created there are still unprovisioned volumes,
 	// the dynamic storage provisioner will take care of creating them.
 	Attachment *VolumeAttachmentParams
+
+	// SnapshotId, if non-empty, is the provider-supplied ID of the
+	// snapshot from which the volume should be restored. It is only
+	// set for volume sources that implement VolumeSnapshotter.
+	SnapshotId string
+}
+
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the unique tag assigned by Juju for the volume
+	// that should be resized.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume
+	// that should be resized.
+	VolumeId string
+
+	// Size is the minimum size, in MiB, that the volume should
+	// have once resized.
+	Size uint64
+
+	// Provider is the name of the storage provider that created
+	// the volume.
+	Provider ProviderType
+}
+
+// VolumeSnapshotParams is a set of parameters for taking a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Id is the unique ID assigned by Juju for the snapshot.
+	Id string
+
+	// Tag is the unique tag assigned by Juju for the volume
+	// that should be snapshotted.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume
+	// that should be snapshotted.
+	VolumeId string
+
+	// Size is the size of the volume, in MiB.
+	Size uint64
+
+	// Provider is the name of the storage provider that created
+	// the volume.
+	Provider ProviderType
 }
 
 // VolumeAttachmentParams is a set of parameters for volume attachment or
@@ -362,6 +447,21 @@ type AttachVolumesResult struct {
 	Error            error
 }
 
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. VolumeInfo should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	VolumeInfo *VolumeInfo
+	Error      error
+}
+
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one volume.
+// VolumeSnapshotInfo should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	VolumeSnapshotInfo *VolumeSnapshotInfo
+	Error              error
+}
+
 // CreateFilesystemsResult contains the result of a FilesystemSource.CreateFilesystems call
 // for one filesystem. Filesystem should only be used if Error is nil.
 type CreateFilesystemsResult struct {
@@ -19,6 +19,13 @@ type PoolManager interface {
 	// Delete removes the pool with name from state.
 	Delete(name string) error
 
+	// Replace replaces the configuration of the existing pool with
+	// name, and persists it to state. If providerType is empty, the
+	// pool's existing provider type is kept. Replace does not check
+	// whether the pool is in use; callers updating a pool that may be
+	// in use should go through state.
+	Replace(name string, providerType storage.ProviderType, attrs map[string]interface{}) error
+
 	// Get returns the pool with name from state.
 	Get(name string) (*storage.Config, error)
 
@@ -30,6 +37,7 @@ type SettingsManager interface {
 	CreateSettings(key string, settings map[string]interface{}) error
 	ReadSettings(key string) (map[string]interface{}, error)
 	RemoveSettings(key string) error
+	ReplaceSettings(key string, settings map[string]interface{}) error
 	ListSettings(keyPrefix string) (map[string]map[string]interface{}, error)
 }
 
@@ -66,6 +74,15 @@ func (m MemSettings) RemoveSettings(key string) error {
 	return nil
 }
 
+// ReplaceSettings is part of the SettingsManager interface.
+func (m MemSettings) ReplaceSettings(key string, settings map[string]interface{}) error {
+	if _, ok := m.Settings[key]; !ok {
+		return errors.NotFoundf("settings with key %q", key)
+	}
+	m.Settings[key] = settings
+	return nil
+}
+
 // ListSettings is part of the SettingsManager interface.
 func (m MemSettings) ListSettings(keyPrefix string) (map[string]map[string]interface{}, error) {
 	result := make(map[string]map[string]interface{})
@@ -39,6 +39,12 @@ func globalKey(name string) string {
 	return globalKeyPrefix + name
 }
 
+// SettingsKey returns the key of the settings in which the
+// configuration of the named pool is stored.
+func SettingsKey(name string) string {
+	return globalKey(name)
+}
+
 // Create is defined on PoolManager interface.
 func (pm *poolManager) Create(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error) {
 	if name == "" {
@@ -48,6 +54,42 @@ func (pm *poolManager) Create(name string, providerType storage.ProviderType, at
 		return nil, MissingTypeError
 	}
 
+	cfg, err := pm.validatedConfig(name, providerType, attrs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := pm.settings.CreateSettings(globalKey(name), poolAttrs(cfg)); err != nil {
+		return nil, errors.Annotatef(err, "creating pool %q", name)
+	}
+	return cfg, nil
+}
+
+// Replace is defined on PoolManager interface.
+func (pm *poolManager) Replace(name string, providerType storage.ProviderType, attrs map[string]interface{}) error {
+	if name == "" {
+		return MissingNameError
+	}
+	existing, err := pm.Get(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if providerType == "" {
+		providerType = existing.Provider()
+	}
+	cfg, err := pm.validatedConfig(name, providerType, attrs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := pm.settings.ReplaceSettings(globalKey(name), poolAttrs(cfg)); err != nil {
+		return errors.Annotatef(err, "replacing pool %q", name)
+	}
+	return nil
+}
+
+// validatedConfig returns the configuration for a pool with the
+// specified name, provider type and attributes, once validated by
+// the storage provider.
+func (pm *poolManager) validatedConfig(name string, providerType storage.ProviderType, attrs map[string]interface{}) (*storage.Config, error) {
 	cfg, err := storage.NewConfig(name, providerType, attrs)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -59,14 +101,19 @@ func (pm *poolManager) Create(name string, providerType storage.ProviderType, at
 	if err := provider.ValidateConfig(p, cfg); err != nil {
 		return nil, errors.Annotate(err, "validating storage provider config")
 	}
+	return cfg, nil
+}
 
-	poolAttrs := cfg.Attrs()
-	poolAttrs[Name] = name
-	poolAttrs[Type] = string(providerType)
-	if err := pm.settings.CreateSettings(globalKey(name), poolAttrs); err != nil {
-		return nil, errors.Annotatef(err, "creating pool %q", name)
+// poolAttrs returns the settings to persist for the pool
+// with the specified configuration.
+func poolAttrs(cfg *storage.Config) map[string]interface{} {
+	attrs := cfg.Attrs()
+	if attrs == nil {
+		attrs = make(map[string]interface{})
 	}
-	return cfg, nil
+	attrs[Name] = cfg.Name()
+	attrs[Type] = string(cfg.Provider())
+	return attrs
 }
 
 // Delete is defined on PoolManager interface.
@@ -130,6 +130,46 @@ func (s *poolSuite) TestCreateInvalidConfig(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
 }
 
+func (s *poolSuite) TestReplace(c *gc.C) {
+	s.createSettings(c)
+	err := s.poolManager.Replace("testpool", "", map[string]interface{}{"baz": "qux"})
+	c.Assert(err, jc.ErrorIsNil)
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Attrs(), gc.DeepEquals, map[string]interface{}{"baz": "qux"})
+	c.Assert(p.Provider(), gc.Equals, storage.ProviderType("loop"))
+}
+
+func (s *poolSuite) TestReplaceNoAttrs(c *gc.C) {
+	s.createSettings(c)
+	err := s.poolManager.Replace("testpool", "loop", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Attrs(), gc.HasLen, 0)
+}
+
+func (s *poolSuite) TestReplaceNotFound(c *gc.C) {
+	err := s.poolManager.Replace("testpool", "loop", nil)
+	c.Assert(err, gc.ErrorMatches, `pool "testpool" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *poolSuite) TestReplaceInvalidConfig(c *gc.C) {
+	s.createSettings(c)
+	s.registry.Providers["invalid"] = &dummystorage.StorageProvider{
+		ValidateConfigFunc: func(*storage.Config) error {
+			return errors.New("no good")
+		},
+	}
+	err := s.poolManager.Replace("testpool", "invalid", nil)
+	c.Assert(err, gc.ErrorMatches, "validating storage provider config: no good")
+	// The pool is unchanged.
+	p, err := s.poolManager.Get("testpool")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Attrs(), gc.DeepEquals, map[string]interface{}{"foo": "bar"})
+}
+
 func (s *poolSuite) TestDelete(c *gc.C) {
 	s.createSettings(c)
 	err := s.poolManager.Delete("testpool")
@@ -25,6 +25,8 @@ type VolumeSource struct {
 	ValidateVolumeParamsFunc func(storage.VolumeParams) error
 	AttachVolumesFunc        func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	DetachVolumesFunc        func(context.ProviderCallContext, []storage.VolumeAttachmentParams) ([]error, error)
+
+	CreateVolumeSnapshotsFunc func(context.ProviderCallContext, []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
 }
 
 // CreateVolumes is defined on storage.VolumeSource.
@@ -98,3 +100,12 @@ func (s *VolumeSource) DetachVolumes(ctx context.ProviderCallContext, params []s
 	}
 	return nil, errors.NotImplementedf("DetachVolumes")
 }
+
+// CreateVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) CreateVolumeSnapshots(ctx context.ProviderCallContext, params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "CreateVolumeSnapshots", ctx, params)
+	if s.CreateVolumeSnapshotsFunc != nil {
+		return s.CreateVolumeSnapshotsFunc(ctx, params)
+	}
+	return nil, errors.NotImplementedf("CreateVolumeSnapshots")
+}
@@ -36,6 +36,16 @@ type VolumeInfo struct {
 	Persistent bool
 }
 
+// VolumeSnapshotInfo describes a point-in-time snapshot of a volume.
+type VolumeSnapshotInfo struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// Size is the size of the volume from which the snapshot
+	// was taken, in MiB.
+	Size uint64
+}
+
 // VolumeAttachment identifies and describes machine-specific volume
 // attachment information, including how the volume is exposed on the
 // machine.
@@ -47,6 +47,7 @@ type StateBackend interface {
 	AddCloudModelCounts() error
 	ReplicaSetMembers() ([]replicaset.Member, error)
 	MigrateStorageMachineIdFields() error
+	AddPortRangeEndpoints() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -177,6 +178,10 @@ func (s stateBackend) MigrateStorageMachineIdFields() error {
 	return state.MigrateStorageMachineIdFields(s.st)
 }
 
+func (s stateBackend) AddPortRangeEndpoints() error {
+	return state.AddPortRangeEndpoints(s.st)
+}
+
 type modelShim struct {
 	st *state.State
 	m  *state.Model
@@ -13,5 +13,12 @@ func stateStepsFor25() []Step {
 				return context.State().MigrateStorageMachineIdFields()
 			},
 		},
+		&upgradeStep{
+			description: "record that existing opened ports are opened for all endpoints",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().AddPortRangeEndpoints()
+			},
+		},
 	}
 }
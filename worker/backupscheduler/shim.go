@@ -0,0 +1,128 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// backendShim implements Backend using the controller model's state.
+type backendShim struct {
+	*state.State
+	model *state.Model
+}
+
+// ModelStatus is part of the Backend interface.
+func (s backendShim) ModelStatus() (status.StatusInfo, error) {
+	return s.model.Status()
+}
+
+// SetModelStatus is part of the Backend interface.
+func (s backendShim) SetModelStatus(info status.StatusInfo) error {
+	return s.model.SetStatus(info)
+}
+
+// backupsShim implements Backups in the same way as the backups API
+// facade, creating the archive on the machine the worker runs on.
+type backupsShim struct {
+	st          *state.State
+	model       *state.Model
+	agentConfig agent.Config
+}
+
+func (s *backupsShim) db() backups.DB {
+	return struct {
+		*state.State
+		*state.Model
+	}{s.st, s.model}
+}
+
+func (s *backupsShim) withBackups(f func(backups.Backups) error) error {
+	stor, err := backups.NewStorage(s.db())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer stor.Close()
+	return f(backups.NewBackups(stor))
+}
+
+// Create is part of the Backups interface.
+func (s *backupsShim) Create() (string, error) {
+	session := s.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return "", errors.Annotatef(err, "HA not ready")
+	}
+	mgoInfo, ok := s.agentConfig.MongoInfo()
+	if !ok {
+		return "", errors.New("no mongo info found in agent config")
+	}
+	v, err := s.st.MongoVersion()
+	if err != nil {
+		return "", errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	machineID := s.agentConfig.Tag().Id()
+	machine, err := s.st.Machine(machineID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(s.db(), machineID, machine.Series())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta.Scheduled = true
+
+	modelConfig, err := s.model.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   s.agentConfig.DataDir(),
+		LogsDir:   s.agentConfig.LogDir(),
+	}
+	err = s.withBackups(func(b backups.Backups) error {
+		_, err := b.Create(meta, &paths, dbInfo, true, true)
+		return err
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return meta.ID(), nil
+}
+
+// List is part of the Backups interface.
+func (s *backupsShim) List() ([]*backups.Metadata, error) {
+	var result []*backups.Metadata
+	err := s.withBackups(func(b backups.Backups) error {
+		var err error
+		result, err = b.List()
+		return err
+	})
+	return result, errors.Trace(err)
+}
+
+// Remove is part of the Backups interface.
+func (s *backupsShim) Remove(id string) error {
+	return errors.Trace(s.withBackups(func(b backups.Backups) error {
+		return b.Remove(id)
+	}))
+}
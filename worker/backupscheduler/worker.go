@@ -0,0 +1,271 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/cron"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// statusDataKey is set in the controller model's status data when the
+// worker puts the model into an error state, so that it only clears
+// errors it set itself.
+const statusDataKey = "scheduled-backup-failed"
+
+// Backend provides the controller state needed by the worker.
+type Backend interface {
+	WatchControllerConfig() state.NotifyWatcher
+	ControllerConfig() (controller.Config, error)
+	BackupScheduleStatus() (state.BackupScheduleStatus, error)
+	SetBackupScheduleStatus(state.BackupScheduleStatus) error
+	ModelStatus() (status.StatusInfo, error)
+	SetModelStatus(status.StatusInfo) error
+}
+
+// Backups provides the means to create, list and remove backups of
+// the controller.
+type Backups interface {
+	// Create creates a new scheduled backup and returns its ID.
+	Create() (string, error)
+	List() ([]*backups.Metadata, error)
+	Remove(id string) error
+}
+
+// Config holds the dependencies of a backup scheduler worker.
+type Config struct {
+	Backend Backend
+	Backups Backups
+	Clock   clock.Clock
+}
+
+// Validate returns an error if the config cannot be used to start
+// a worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which creates controller backups on the
+// schedule given in the controller configuration, and removes old
+// scheduled backups according to the configured retention limits.
+// This worker must not be run in more than one agent concurrently.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &schedulerWorker{config: config}
+	return jworker.NewSimpleWorker(w.loop), nil
+}
+
+type schedulerWorker struct {
+	config Config
+
+	maxCount int
+	maxAge   time.Duration
+}
+
+func (w *schedulerWorker) loop(stopCh <-chan struct{}) error {
+	controllerConfigWatcher := w.config.Backend.WatchControllerConfig()
+	defer worker.Stop(controllerConfigWatcher)
+
+	var (
+		loaded                  bool
+		spec                    string
+		schedule                cron.Schedule
+		controllerConfigChanges = controllerConfigWatcher.Changes()
+		backupTimer             clock.Timer
+		backupCh                <-chan time.Time
+	)
+	defer func() {
+		if backupTimer != nil {
+			backupTimer.Stop()
+		}
+	}()
+
+	resetTimer := func() {
+		if backupTimer != nil {
+			backupTimer.Stop()
+		}
+		backupTimer, backupCh = nil, nil
+		if schedule == nil {
+			return
+		}
+		now := w.config.Clock.Now()
+		next := schedule.Next(now)
+		if next.IsZero() {
+			logger.Warningf("backup schedule %q is never due", spec)
+			return
+		}
+		logger.Debugf("next scheduled backup at %s", next)
+		backupTimer = w.config.Clock.NewTimer(next.Sub(now))
+		backupCh = backupTimer.Chan()
+	}
+
+	for {
+		select {
+		case <-stopCh:
+			return tomb.ErrDying
+
+		case _, ok := <-controllerConfigChanges:
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			controllerConfig, err := w.config.Backend.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load controller configuration")
+			}
+			maxCount, maxAge := controllerConfig.BackupMaxCount(), controllerConfig.BackupMaxAge()
+			limitsChanged := loaded && (maxCount != w.maxCount || maxAge != w.maxAge)
+			w.maxCount, w.maxAge, loaded = maxCount, maxAge, true
+			if limitsChanged {
+				// Apply the new retention limits to the existing
+				// backups now, rather than at the next backup.
+				logger.Infof("backup max count: %d, max age: %v", w.maxCount, w.maxAge)
+				if err := w.prune(); err != nil {
+					logger.Errorf("removing old scheduled backups: %v", err)
+				}
+			}
+			newSpec := controllerConfig.BackupSchedule()
+			if newSpec == spec && (schedule != nil || spec == "") {
+				continue
+			}
+			spec, schedule = newSpec, nil
+			if spec == "" {
+				logger.Infof("scheduled backups disabled")
+			} else if schedule, err = cron.Parse(spec); err != nil {
+				// The schedule is validated when the config is
+				// set, so this should never happen.
+				logger.Errorf("invalid backup schedule %q: %v", spec, err)
+			} else {
+				logger.Infof("backup schedule: %q, max count: %d, max age: %v", spec, w.maxCount, w.maxAge)
+			}
+			resetTimer()
+
+		case <-backupCh:
+			if err := w.backup(); err != nil {
+				return errors.Trace(err)
+			}
+			resetTimer()
+		}
+	}
+}
+
+// backup creates a scheduled backup, removes any scheduled backups
+// that have expired, and records the outcome. An error is returned
+// only if the outcome cannot be recorded.
+func (w *schedulerWorker) backup() error {
+	backupStatus, err := w.config.Backend.BackupScheduleStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	backupStatus.LastAttempt = w.config.Clock.Now()
+	backupStatus.LastError = ""
+
+	logger.Infof("creating scheduled backup")
+	id, err := w.config.Backups.Create()
+	if err != nil {
+		logger.Errorf("scheduled backup failed: %v", err)
+		backupStatus.LastError = err.Error()
+	} else {
+		logger.Infof("created scheduled backup %q", id)
+		backupStatus.LastSuccess = w.config.Clock.Now()
+		backupStatus.LastBackupID = id
+		if err := w.prune(); err != nil {
+			logger.Errorf("removing old scheduled backups: %v", err)
+			backupStatus.LastError = fmt.Sprintf("removing old backups: %v", err)
+		}
+	}
+
+	if err := w.config.Backend.SetBackupScheduleStatus(backupStatus); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.updateModelStatus(backupStatus.LastError))
+}
+
+// prune removes the scheduled backups beyond the configured count
+// or age. Backups made by users are never removed.
+func (w *schedulerWorker) prune() error {
+	if w.maxCount == 0 && w.maxAge == 0 {
+		return nil
+	}
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var scheduled []*backups.Metadata
+	for _, meta := range all {
+		if meta.Scheduled {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	// Newest first.
+	sort.Slice(scheduled, func(i, j int) bool {
+		return scheduled[i].Started.After(scheduled[j].Started)
+	})
+	now := w.config.Clock.Now()
+	for i, meta := range scheduled {
+		tooMany := w.maxCount > 0 && i >= w.maxCount
+		tooOld := w.maxAge > 0 && now.Sub(meta.Started) > w.maxAge
+		if !tooMany && !tooOld {
+			continue
+		}
+		logger.Infof("removing scheduled backup %q", meta.ID())
+		if err := w.config.Backups.Remove(meta.ID()); err != nil {
+			return errors.Annotatef(err, "removing backup %q", meta.ID())
+		}
+	}
+	return nil
+}
+
+// updateModelStatus puts the controller model into an error state
+// when a scheduled backup fails, and clears that error again once a
+// scheduled backup succeeds. Any other model status is left alone.
+func (w *schedulerWorker) updateModelStatus(backupErr string) error {
+	current, err := w.config.Backend.ModelStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ours := current.Status == status.Error && current.Data[statusDataKey] == true
+	switch {
+	case backupErr != "" && (current.Status == status.Available || ours):
+		now := w.config.Clock.Now()
+		return errors.Trace(w.config.Backend.SetModelStatus(status.StatusInfo{
+			Status:  status.Error,
+			Message: "scheduled backup failed: " + backupErr,
+			Data:    map[string]interface{}{statusDataKey: true},
+			Since:   &now,
+		}))
+	case backupErr == "" && ours:
+		now := w.config.Clock.Now()
+		return errors.Trace(w.config.Backend.SetModelStatus(status.StatusInfo{
+			Status: status.Available,
+			Since:  &now,
+		}))
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	changes chan struct{}
+	backend *mockBackend
+	backups *mockBackups
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var start = time.Date(2019, 3, 1, 1, 0, 0, 0, time.UTC)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(start)
+	s.changes = make(chan struct{}, 1)
+	s.backend = &mockBackend{
+		watcher: watchertest.NewNotifyWatcher(s.changes),
+		config: controller.Config{
+			controller.BackupSchedule: "0 2 * * *",
+		},
+		modelStatus: status.StatusInfo{Status: status.Available},
+	}
+	s.backups = &mockBackups{}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := backupscheduler.NewWorker(backupscheduler.Config{
+		Backend: s.backend,
+		Backups: s.backups,
+		Clock:   s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	s.changes <- struct{}{}
+	return w
+}
+
+// runBackup advances the clock to the next scheduled backup and
+// waits for the worker to schedule the one after.
+func (s *WorkerSuite) runBackup(c *gc.C, d time.Duration) {
+	c.Assert(s.clock.WaitAdvance(d, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := backupscheduler.NewWorker(backupscheduler.Config{
+		Backups: s.backups,
+		Clock:   s.clock,
+	})
+	c.Check(err, gc.ErrorMatches, "nil Backend not valid")
+	_, err = backupscheduler.NewWorker(backupscheduler.Config{
+		Backend: s.backend,
+		Clock:   s.clock,
+	})
+	c.Check(err, gc.ErrorMatches, "nil Backups not valid")
+	_, err = backupscheduler.NewWorker(backupscheduler.Config{
+		Backend: s.backend,
+		Backups: s.backups,
+	})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *WorkerSuite) TestScheduledBackup(c *gc.C) {
+	w := s.startWorker(c)
+	s.runBackup(c, time.Hour)
+	workertest.CleanKill(c, w)
+
+	c.Assert(s.backups.created, gc.Equals, 1)
+	c.Assert(s.backend.status, jc.DeepEquals, state.BackupScheduleStatus{
+		LastAttempt:  start.Add(time.Hour),
+		LastSuccess:  start.Add(time.Hour),
+		LastBackupID: "backup-1",
+	})
+	c.Assert(s.backend.modelStatus.Status, gc.Equals, status.Available)
+	c.Assert(s.backups.removed, gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestNoSchedule(c *gc.C) {
+	s.backend.config = controller.Config{}
+	w := s.startWorker(c)
+	// No backup timer is ever started.
+	err := s.clock.WaitAdvance(24*time.Hour, coretesting.ShortWait, 1)
+	c.Assert(err, gc.NotNil)
+	workertest.CleanKill(c, w)
+	c.Assert(s.backups.created, gc.Equals, 0)
+}
+
+func (s *WorkerSuite) TestPruneByCount(c *gc.C) {
+	s.backend.config[controller.BackupMaxCount] = 2
+	s.backups.existing = []*backups.Metadata{
+		newMetadata("manual", start.Add(-72*time.Hour), false),
+		newMetadata("old", start.Add(-47*time.Hour), true),
+		newMetadata("older", start.Add(-71*time.Hour), true),
+		newMetadata("recent", start.Add(-23*time.Hour), true),
+	}
+	w := s.startWorker(c)
+	s.runBackup(c, time.Hour)
+	workertest.CleanKill(c, w)
+
+	c.Assert(s.backups.removed, jc.SameContents, []string{"old", "older"})
+}
+
+func (s *WorkerSuite) TestPruneByAge(c *gc.C) {
+	s.backend.config[controller.BackupMaxAge] = "48h"
+	s.backups.existing = []*backups.Metadata{
+		newMetadata("manual", start.Add(-72*time.Hour), false),
+		newMetadata("old", start.Add(-47*time.Hour), true),
+		newMetadata("older", start.Add(-71*time.Hour), true),
+	}
+	w := s.startWorker(c)
+	s.runBackup(c, time.Hour)
+	workertest.CleanKill(c, w)
+
+	c.Assert(s.backups.removed, jc.DeepEquals, []string{"older"})
+}
+
+func (s *WorkerSuite) TestPruneOnConfigChange(c *gc.C) {
+	s.backups.existing = []*backups.Metadata{
+		newMetadata("manual", start.Add(-72*time.Hour), false),
+		newMetadata("old", start.Add(-47*time.Hour), true),
+		newMetadata("recent", start.Add(-23*time.Hour), true),
+	}
+	w := s.startWorker(c)
+	// Wait for the initial configuration to be loaded.
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+
+	s.backend.mu.Lock()
+	s.backend.config = controller.Config{
+		controller.BackupSchedule: "0 2 * * *",
+		controller.BackupMaxCount: 1,
+	}
+	s.backend.mu.Unlock()
+	s.changes <- struct{}{}
+
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.backups.mu.Lock()
+		removed := s.backups.removed
+		s.backups.mu.Unlock()
+		if len(removed) > 0 {
+			break
+		}
+	}
+	workertest.CleanKill(c, w)
+
+	c.Assert(s.backups.created, gc.Equals, 0)
+	c.Assert(s.backups.removed, jc.DeepEquals, []string{"old"})
+}
+
+func (s *WorkerSuite) TestFailureSetsModelStatus(c *gc.C) {
+	s.backups.createErr = errors.New("disk full")
+	w := s.startWorker(c)
+	s.runBackup(c, time.Hour)
+
+	s.backend.mu.Lock()
+	c.Check(s.backend.status, jc.DeepEquals, state.BackupScheduleStatus{
+		LastAttempt: start.Add(time.Hour),
+		LastError:   "disk full",
+	})
+	c.Check(s.backend.modelStatus.Status, gc.Equals, status.Error)
+	c.Check(s.backend.modelStatus.Message, gc.Equals, "scheduled backup failed: disk full")
+	s.backend.mu.Unlock()
+
+	// The next successful backup clears the error.
+	s.backups.mu.Lock()
+	s.backups.createErr = nil
+	s.backups.mu.Unlock()
+	s.runBackup(c, 24*time.Hour)
+	workertest.CleanKill(c, w)
+
+	c.Check(s.backend.status.LastError, gc.Equals, "")
+	c.Check(s.backend.status.LastBackupID, gc.Equals, "backup-1")
+	c.Check(s.backend.modelStatus.Status, gc.Equals, status.Available)
+	c.Check(s.backend.modelStatus.Message, gc.Equals, "")
+}
+
+func (s *WorkerSuite) TestFailureLeavesOtherModelStatus(c *gc.C) {
+	s.backend.modelStatus = status.StatusInfo{Status: status.Busy, Message: "upgrading"}
+	s.backups.createErr = errors.New("disk full")
+	w := s.startWorker(c)
+	s.runBackup(c, time.Hour)
+	workertest.CleanKill(c, w)
+
+	c.Check(s.backend.status.LastError, gc.Equals, "disk full")
+	c.Check(s.backend.modelStatus, jc.DeepEquals, status.StatusInfo{Status: status.Busy, Message: "upgrading"})
+}
+
+func newMetadata(id string, started time.Time, scheduled bool) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Scheduled = scheduled
+	return meta
+}
+
+type mockBackend struct {
+	mu          sync.Mutex
+	watcher     state.NotifyWatcher
+	config      controller.Config
+	status      state.BackupScheduleStatus
+	modelStatus status.StatusInfo
+}
+
+func (b *mockBackend) WatchControllerConfig() state.NotifyWatcher {
+	return b.watcher
+}
+
+func (b *mockBackend) ControllerConfig() (controller.Config, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.config, nil
+}
+
+func (b *mockBackend) BackupScheduleStatus() (state.BackupScheduleStatus, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.status, nil
+}
+
+func (b *mockBackend) SetBackupScheduleStatus(status state.BackupScheduleStatus) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.status = status
+	return nil
+}
+
+func (b *mockBackend) ModelStatus() (status.StatusInfo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.modelStatus, nil
+}
+
+func (b *mockBackend) SetModelStatus(info status.StatusInfo) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	info.Since = nil
+	b.modelStatus = info
+	return nil
+}
+
+type mockBackups struct {
+	mu        sync.Mutex
+	existing  []*backups.Metadata
+	created   int
+	createErr error
+	removed   []string
+}
+
+func (b *mockBackups) Create() (string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.createErr != nil {
+		return "", b.createErr
+	}
+	b.created++
+	b.existing = append(b.existing, newMetadata("backup-1", start.Add(time.Hour), true))
+	return "backup-1", nil
+}
+
+func (b *mockBackups) List() ([]*backups.Metadata, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.existing, nil
+}
+
+func (b *mockBackups) Remove(id string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.removed = append(b.removed, id)
+	return nil
+}
@@ -4,11 +4,14 @@
 package caasfirewaller
 
 import (
+	"reflect"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
-	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
 )
 
 type applicationWorker struct {
@@ -21,6 +24,11 @@ type applicationWorker struct {
 
 	initial           bool
 	previouslyExposed bool
+	previousCIDRs     []string
+
+	// subnetsChange is signalled when the model's subnets change, so
+	// that any spaces the application is exposed to are resolved again.
+	subnetsChange chan struct{}
 }
 
 func newApplicationWorker(
@@ -28,13 +36,14 @@ func newApplicationWorker(
 	applicationGetter ApplicationGetter,
 	applicationExposer ServiceExposer,
 	lifeGetter LifeGetter,
-) (worker.Worker, error) {
+) (*applicationWorker, error) {
 	w := &applicationWorker{
 		application:       application,
 		applicationGetter: applicationGetter,
 		serviceExposer:    applicationExposer,
 		lifeGetter:        lifeGetter,
 		initial:           true,
+		subnetsChange:     make(chan struct{}, 1),
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -55,6 +64,15 @@ func (w *applicationWorker) Wait() error {
 	return w.catacomb.Wait()
 }
 
+// subnetsChanged tells the worker that the model's subnets have changed.
+func (w *applicationWorker) subnetsChanged() {
+	select {
+	case w.subnetsChange <- struct{}{}:
+	default:
+		// A change is already pending.
+	}
+}
+
 func (w *applicationWorker) loop() (err error) {
 	defer func() {
 		// If the application has been deleted, we can return nil.
@@ -76,33 +94,39 @@ func (w *applicationWorker) loop() (err error) {
 			if !ok {
 				return errors.New("application watcher closed")
 			}
-			if err := w.processApplicationChange(); err != nil {
-				if strings.Contains(err.Error(), "unexpected EOF") {
-					return nil
-				}
-				return errors.Trace(err)
+		case <-w.subnetsChange:
+			if w.initial || !w.previouslyExposed {
+				continue
+			}
+		}
+		if err := w.processApplicationChange(); err != nil {
+			if strings.Contains(err.Error(), "unexpected EOF") {
+				return nil
 			}
+			return errors.Trace(err)
 		}
 	}
 }
 
 func (w *applicationWorker) processApplicationChange() (err error) {
-	exposed, err := w.applicationGetter.IsExposed(w.application)
+	exposed, exposedEndpoints, err := w.applicationGetter.ExposeInfo(w.application)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if !w.initial && exposed == w.previouslyExposed {
+	sourceCIDRs := exposedSourceCIDRs(exposedEndpoints)
+	if !w.initial && exposed == w.previouslyExposed && reflect.DeepEqual(sourceCIDRs, w.previousCIDRs) {
 		return nil
 	}
 
 	w.initial = false
 	w.previouslyExposed = exposed
+	w.previousCIDRs = sourceCIDRs
 	if exposed {
 		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		if err := w.serviceExposer.ExposeService(w.application, appConfig); err != nil {
+		if err := w.serviceExposer.ExposeService(w.application, appConfig, sourceCIDRs); err != nil {
 			return errors.Trace(err)
 		}
 		return nil
@@ -112,3 +136,23 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	}
 	return nil
 }
+
+// exposedSourceCIDRs returns the sorted CIDRs that an exposed service
+// may be accessed from, or nil if it may be accessed from everywhere.
+// Service ports are not associated with endpoints, so this is the union
+// of the CIDRs of all exposed endpoints.
+func exposedSourceCIDRs(exposedEndpoints map[string]params.ExposedEndpoint) []string {
+	if len(exposedEndpoints) == 0 {
+		return nil
+	}
+	cidrs := set.NewStrings()
+	for _, exposedEndpoint := range exposedEndpoints {
+		for _, cidr := range exposedEndpoint.ExposeToCIDRs {
+			cidrs.Add(cidr)
+		}
+	}
+	if cidrs.Contains("0.0.0.0/0") {
+		return nil
+	}
+	return cidrs.SortedValues()
+}
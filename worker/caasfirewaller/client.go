@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher"
@@ -24,7 +25,8 @@ type Client interface {
 type ApplicationGetter interface {
 	WatchApplications() (watcher.StringsWatcher, error)
 	WatchApplication(string) (watcher.NotifyWatcher, error)
-	IsExposed(string) (bool, error)
+	WatchSubnets() (watcher.StringsWatcher, error)
+	ExposeInfo(string) (bool, map[string]params.ExposedEndpoint, error)
 	ApplicationConfig(string) (application.ConfigAttributes, error)
 }
 
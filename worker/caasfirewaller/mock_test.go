@@ -7,6 +7,7 @@ import (
 	"github.com/juju/testing"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
@@ -33,8 +34,8 @@ type mockServiceExposer struct {
 	unexposed chan<- struct{}
 }
 
-func (m *mockServiceExposer) ExposeService(appName string, config application.ConfigAttributes) error {
-	m.MethodCall(m, "ExposeService", appName, config)
+func (m *mockServiceExposer) ExposeService(appName string, config application.ConfigAttributes, sourceCIDRs []string) error {
+	m.MethodCall(m, "ExposeService", appName, config, sourceCIDRs)
 	m.exposed <- struct{}{}
 	return m.NextErr()
 }
@@ -47,9 +48,11 @@ func (m *mockServiceExposer) UnexposeService(appName string) error {
 
 type mockApplicationGetter struct {
 	testing.Stub
-	allWatcher *watchertest.MockStringsWatcher
-	appWatcher *watchertest.MockNotifyWatcher
-	exposed    bool
+	allWatcher     *watchertest.MockStringsWatcher
+	appWatcher     *watchertest.MockNotifyWatcher
+	subnetsWatcher *watchertest.MockStringsWatcher
+	exposed        bool
+	endpoints      map[string]params.ExposedEndpoint
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -68,12 +71,20 @@ func (m *mockApplicationGetter) WatchApplication(appName string) (watcher.Notify
 	return m.appWatcher, nil
 }
 
-func (m *mockApplicationGetter) IsExposed(appName string) (bool, error) {
-	m.MethodCall(m, "IsExposed", appName)
+func (m *mockApplicationGetter) WatchSubnets() (watcher.StringsWatcher, error) {
+	m.MethodCall(m, "WatchSubnets")
 	if err := m.NextErr(); err != nil {
-		return false, err
+		return nil, err
+	}
+	return m.subnetsWatcher, nil
+}
+
+func (m *mockApplicationGetter) ExposeInfo(appName string) (bool, map[string]params.ExposedEndpoint, error) {
+	m.MethodCall(m, "ExposeInfo", appName)
+	if err := m.NextErr(); err != nil {
+		return false, nil, err
 	}
-	return m.exposed, nil
+	return m.exposed, m.endpoints, nil
 }
 
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
@@ -10,6 +10,7 @@ import (
 	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/watcher"
 )
 
 var logger = loggo.GetLogger("juju.workers.caasfirewaller")
@@ -72,7 +73,22 @@ func (p *firewaller) loop() error {
 		return errors.Trace(err)
 	}
 
-	appWorkers := make(map[string]worker.Worker)
+	// The CIDRs of the spaces applications are exposed to change with
+	// the spaces' subnets.
+	var subnetsChange watcher.StringsChannel
+	subnetsWatcher, err := p.config.ApplicationGetter.WatchSubnets()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not watching subnets: %v", err)
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := p.catacomb.Add(subnetsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		subnetsChange = subnetsWatcher.Changes()
+	}
+
+	appWorkers := make(map[string]*applicationWorker)
 	for {
 		select {
 		case <-p.catacomb.Dying():
@@ -113,6 +129,13 @@ func (p *firewaller) loop() error {
 				appWorkers[appId] = w
 				p.catacomb.Add(w)
 			}
+		case _, ok := <-subnetsChange:
+			if !ok {
+				return errors.New("subnets watcher closed channel")
+			}
+			for _, w := range appWorkers {
+				w.subnetsChanged()
+			}
 		}
 	}
 }
@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/watcher/watchertest"
@@ -28,6 +29,7 @@ type WorkerSuite struct {
 	lifeGetter        mockLifeGetter
 
 	applicationChanges chan []string
+	subnetChanges      chan []string
 	appExposedChange   chan struct{}
 	serviceExposed     chan struct{}
 	serviceUnexposed   chan struct{}
@@ -39,15 +41,18 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 
 	s.applicationChanges = make(chan []string)
+	s.subnetChanges = make(chan []string)
 	s.appExposedChange = make(chan struct{})
 	s.serviceExposed = make(chan struct{})
 	s.serviceUnexposed = make(chan struct{})
 
 	s.applicationGetter = mockApplicationGetter{
-		allWatcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
-		appWatcher: watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		allWatcher:     watchertest.NewMockStringsWatcher(s.applicationChanges),
+		appWatcher:     watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		subnetsWatcher: watchertest.NewMockStringsWatcher(s.subnetChanges),
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.allWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.subnetsWatcher) })
 
 	s.lifeGetter = mockLifeGetter{
 		life: life.Alive,
@@ -137,7 +142,87 @@ func (s *WorkerSuite) TestExposedChange(c *gc.C) {
 	}
 	s.serviceExposer.CheckCallNames(c, "UnexposeService", "ExposeService")
 	s.serviceExposer.CheckCall(c, 1, "ExposeService", "gitlab",
-		application.ConfigAttributes{"juju-external-hostname": "exthost"})
+		application.ConfigAttributes{"juju-external-hostname": "exthost"}, []string(nil))
+}
+
+func (s *WorkerSuite) TestExposedCIDRsChange(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+
+	// Restricting the exposed endpoints to some CIDRs
+	// exposes the service again, to those CIDRs only.
+	s.applicationGetter.endpoints = map[string]params.ExposedEndpoint{
+		"http":  {ExposeToCIDRs: []string{"10.0.0.0/24"}},
+		"https": {ExposeToCIDRs: []string{"192.168.0.0/16", "10.0.0.0/24"}},
+	}
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+	s.serviceExposer.CheckCallNames(c, "ExposeService", "ExposeService")
+	s.serviceExposer.CheckCall(c, 1, "ExposeService", "gitlab",
+		application.ConfigAttributes{"juju-external-hostname": "exthost"},
+		[]string{"10.0.0.0/24", "192.168.0.0/16"})
+}
+
+func (s *WorkerSuite) TestSubnetsChange(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.exposed = true
+	s.applicationGetter.endpoints = map[string]params.ExposedEndpoint{
+		"http": {ExposeToSpaces: []string{"dmz"}, ExposeToCIDRs: []string{"10.0.0.0/24"}},
+	}
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+
+	// A subnet added to the space is picked up without the
+	// application changing.
+	s.applicationGetter.endpoints = map[string]params.ExposedEndpoint{
+		"http": {ExposeToSpaces: []string{"dmz"}, ExposeToCIDRs: []string{"10.0.0.0/24", "10.0.1.0/24"}},
+	}
+	select {
+	case s.subnetChanges <- []string{"10.0.1.0/24"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending subnets change")
+	}
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+	s.serviceExposer.CheckCallNames(c, "ExposeService", "ExposeService")
+	s.serviceExposer.CheckCall(c, 1, "ExposeService", "gitlab",
+		application.ConfigAttributes{"juju-external-hostname": "exthost"},
+		[]string{"10.0.0.0/24", "10.0.1.0/24"})
 }
 
 func (s *WorkerSuite) TestUnexposedChange(c *gc.C) {
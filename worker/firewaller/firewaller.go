@@ -36,6 +36,7 @@ import (
 type FirewallerAPI interface {
 	WatchModelMachines() (watcher.StringsWatcher, error)
 	WatchOpenedPorts() (watcher.StringsWatcher, error)
+	WatchSubnets() (watcher.StringsWatcher, error)
 	Machine(tag names.MachineTag) (*firewaller.Machine, error)
 	Unit(tag names.UnitTag) (*firewaller.Unit, error)
 	Relation(tag names.RelationTag) (*firewaller.Relation, error)
@@ -119,6 +120,11 @@ func (cfg Config) Validate() error {
 
 type portRanges map[network.PortRange]bool
 
+// endpointPortRanges holds the port ranges opened by a unit, keyed by
+// the endpoint they were opened for. Port ranges opened for all of the
+// unit's endpoints are keyed by the empty string.
+type endpointPortRanges map[string]portRanges
+
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
 // Uses Firewaller API V1.
@@ -131,6 +137,7 @@ type Firewaller struct {
 
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	subnetsWatcher       watcher.StringsWatcher
 	machineds            map[names.MachineTag]*machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
@@ -226,6 +233,17 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	// The CIDRs of the spaces applications are exposed to change with
+	// the spaces' subnets.
+	fw.subnetsWatcher, err = fw.firewallerApi.WatchSubnets()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not watching subnets: %v", err)
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	} else if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	fw.remoteRelationsWatcher, err = fw.remoteRelationsApi.WatchRemoteRelations()
 	if err != nil {
 		return errors.Trace(err)
@@ -244,6 +262,10 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var subnetsChange watcher.StringsChannel
+	if fw.subnetsWatcher != nil {
+		subnetsChange = fw.subnetsWatcher.Changes()
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -282,6 +304,13 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-subnetsChange:
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			for _, applicationd := range fw.applicationids {
+				applicationd.subnetsChanged()
+			}
 		case change, ok := <-fw.remoteRelationsWatcher.Changes():
 			if !ok {
 				return errors.New("remote relations watcher closed")
@@ -304,6 +333,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedEndpoints = change.exposedEndpoints
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -345,7 +375,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		tag:          tag,
 		unitds:       make(map[names.UnitTag]*unitData),
 		ingressRules: make([]network.IngressRule, 0),
-		definedPorts: make(map[names.UnitTag]portRanges),
+		definedPorts: make(map[names.UnitTag]endpointPortRanges),
 	}
 	m, err := machined.machine()
 	if params.IsCodeNotFound(err) {
@@ -468,22 +498,24 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposed, exposedEndpoints, err := applicationExposedEndpoints(app)
 	if err != nil {
 		return err
 	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:               fw,
+		application:      app,
+		exposed:          exposed,
+		exposedEndpoints: exposedEndpoints,
+		unitds:           make(map[names.UnitTag]*unitData),
+		subnetsChange:    make(chan struct{}, 1),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, exposedEndpoints)
 		},
 	})
 	if err != nil {
@@ -649,13 +681,13 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 		return err
 	}
 
-	ports, err := m.OpenedPorts(subnetTag)
+	ports, err := m.OpenedEndpointPorts(subnetTag)
 	if err != nil {
 		return err
 	}
 
-	newPortRanges := make(map[names.UnitTag]portRanges)
-	for portRange, unitTag := range ports {
+	newPortRanges := make(map[names.UnitTag]endpointPortRanges)
+	for unitTag, endpointPorts := range ports {
 		unitd, ok := machined.unitds[unitTag]
 		if !ok {
 			// It is common to receive port change notification before
@@ -664,12 +696,15 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 			logger.Debugf("failed to lookup %q, skipping port change", unitTag)
 			return nil
 		}
-		ranges, ok := newPortRanges[unitd.tag]
-		if !ok {
-			ranges = make(portRanges)
-			newPortRanges[unitd.tag] = ranges
+		unitRanges := make(endpointPortRanges)
+		for endpoint, endpointRanges := range endpointPorts {
+			ranges := make(portRanges)
+			for _, portRange := range endpointRanges {
+				ranges[portRange] = true
+			}
+			unitRanges[endpoint] = ranges
 		}
-		ranges[portRange] = true
+		newPortRanges[unitd.tag] = unitRanges
 	}
 
 	if !unitPortsEqual(machined.definedPorts, newPortRanges) {
@@ -679,7 +714,23 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 	return nil
 }
 
-func unitPortsEqual(a, b map[names.UnitTag]portRanges) bool {
+func unitPortsEqual(a, b map[names.UnitTag]endpointPortRanges) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, valueA := range a {
+		valueB, exists := b[key]
+		if !exists {
+			return false
+		}
+		if !endpointPortRangesEqual(valueA, valueB) {
+			return false
+		}
+	}
+	return true
+}
+
+func endpointPortRangesEqual(a, b endpointPortRanges) bool {
 	if len(a) != len(b) {
 		return false
 	}
@@ -744,34 +795,53 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.IngressRule, error) {
 	var want []network.IngressRule
 	for _, machined := range machines {
-		for unitTag, portRanges := range machined.definedPorts {
+		for unitTag, endpointRanges := range machined.definedPorts {
 			unitd, known := machined.unitds[unitTag]
 			if !known {
 				logger.Debugf("no ingress rules for unknown %v on %v", unitTag, machined.tag)
 				continue
 			}
 
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
+			// A port range may be opened for several endpoints, so
+			// gather the CIDRs for each range before making the rules.
+			rangeCIDRs := make(map[network.PortRange]set.Strings)
 			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
+				// If the unit is exposed, allow access to the ports of
+				// each endpoint from the CIDRs that endpoint is
+				// exposed to.
+				for endpoint, portRanges := range endpointRanges {
+					cidrs := unitd.applicationd.exposedCIDRs(endpoint)
+					for portRange := range portRanges {
+						if existing, ok := rangeCIDRs[portRange]; ok {
+							cidrs = cidrs.Union(existing)
+						}
+						rangeCIDRs[portRange] = cidrs
+					}
+				}
 			} else {
 				// Not exposed, so add any ingress rules required by remote relations.
+				cidrs := set.NewStrings()
 				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
 					return nil, errors.Trace(err)
 				}
 				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
-			}
-			if cidrs.Size() > 0 {
-				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
-						return nil, errors.Trace(err)
+				for _, portRanges := range endpointRanges {
+					for portRange := range portRanges {
+						rangeCIDRs[portRange] = cidrs
 					}
-					want = append(want, rule)
 				}
 			}
+			for portRange, cidrs := range rangeCIDRs {
+				if cidrs.Size() == 0 {
+					continue
+				}
+				sourceCidrs := cidrs.SortedValues()
+				rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				want = append(want, rule)
+			}
 		}
 	}
 	return want, nil
@@ -1026,7 +1096,7 @@ type machineData struct {
 	unitds       map[names.UnitTag]*unitData
 	ingressRules []network.IngressRule
 	// ports defined by units on this machine
-	definedPorts map[names.UnitTag]portRanges
+	definedPorts map[names.UnitTag]endpointPortRanges
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
@@ -1074,10 +1144,12 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag and exposed endpoint
+// CIDRs for one specific application.
 type exposedChange struct {
-	applicationd *applicationData
-	exposed      bool
+	applicationd     *applicationData
+	exposed          bool
+	exposedEndpoints map[string]set.Strings
 }
 
 // applicationData holds application details and watches exposure changes.
@@ -1086,11 +1158,91 @@ type applicationData struct {
 	fw          *Firewaller
 	application *firewaller.Application
 	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	// exposedEndpoints holds the CIDRs that the ports of each endpoint
+	// may be accessed from when the application is exposed. The empty
+	// endpoint name applies to all of the application's endpoints.
+	exposedEndpoints map[string]set.Strings
+	unitds           map[names.UnitTag]*unitData
+	// subnetsChange is signalled when the model's subnets change, so
+	// that any spaces the application is exposed to are resolved again.
+	subnetsChange chan struct{}
+}
+
+// subnetsChanged tells the application's watch loop that the model's
+// subnets have changed.
+func (ad *applicationData) subnetsChanged() {
+	select {
+	case ad.subnetsChange <- struct{}{}:
+	default:
+		// A change is already pending.
+	}
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// exposedCIDRs returns the CIDRs that ports opened for the named
+// endpoint may be accessed from when the application is exposed. Ports
+// opened for all endpoints, which have an empty endpoint name, may be
+// accessed from the CIDRs of every exposed endpoint.
+func (ad *applicationData) exposedCIDRs(endpoint string) set.Strings {
+	if endpoint == "" {
+		cidrs := set.NewStrings()
+		for _, endpointCIDRs := range ad.exposedEndpoints {
+			cidrs = cidrs.Union(endpointCIDRs)
+		}
+		return cidrs
+	}
+	cidrs := set.NewStrings()
+	if endpointCIDRs, ok := ad.exposedEndpoints[endpoint]; ok {
+		cidrs = cidrs.Union(endpointCIDRs)
+	}
+	if allCIDRs, ok := ad.exposedEndpoints[""]; ok {
+		cidrs = cidrs.Union(allCIDRs)
+	}
+	return cidrs
+}
+
+// applicationExposedEndpoints returns whether the application is
+// exposed, and the CIDRs that the ports of each endpoint may then be
+// accessed from. An application exposed without any endpoint settings
+// may be accessed from everywhere. Spaces are resolved to their subnets'
+// CIDRs by the controller at the time of the call.
+func applicationExposedEndpoints(app *firewaller.Application) (bool, map[string]set.Strings, error) {
+	exposed, exposedEndpoints, err := app.ExposeInfo()
+	if err != nil {
+		return false, nil, errors.Trace(err)
+	}
+	if !exposed {
+		return false, nil, nil
+	}
+	if len(exposedEndpoints) == 0 {
+		return true, map[string]set.Strings{"": set.NewStrings("0.0.0.0/0")}, nil
+	}
+	result := make(map[string]set.Strings)
+	for endpoint, exposedEndpoint := range exposedEndpoints {
+		result[endpoint] = set.NewStrings(exposedEndpoint.ExposeToCIDRs...)
+	}
+	return true, result, nil
+}
+
+func exposedEndpointsEqual(a, b map[string]set.Strings) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for endpoint, cidrsA := range a {
+		cidrsB, ok := b[endpoint]
+		if !ok {
+			return false
+		}
+		if cidrsA.Size() != cidrsB.Size() || !cidrsA.Difference(cidrsB).IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// watchLoop watches the application's exposed flag and exposed endpoint
+// CIDRs for changes. The CIDRs are fetched again when the application
+// changes, and when the model's subnets change while it's exposed.
+func (ad *applicationData) watchLoop(exposed bool, exposedEndpoints map[string]set.Strings) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1115,20 +1267,27 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := ad.application.IsExposed()
-			if err != nil {
-				return errors.Trace(err)
-			}
-			if change == exposed {
+		case <-ad.subnetsChange:
+			if !exposed {
 				continue
 			}
+		}
+		change, changedEndpoints, err := applicationExposedEndpoints(ad.application)
+		if params.IsCodeNotFound(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if change == exposed && exposedEndpointsEqual(changedEndpoints, exposedEndpoints) {
+			continue
+		}
 
-			exposed = change
-			select {
-			case <-ad.catacomb.Dying():
-				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
-			}
+		exposed = change
+		exposedEndpoints = changedEndpoints
+		select {
+		case <-ad.catacomb.Dying():
+			return ad.catacomb.ErrDying()
+		case ad.fw.exposedChange <- &exposedChange{ad, change, changedEndpoints}:
 		}
 	}
 }
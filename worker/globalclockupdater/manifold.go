@@ -6,19 +6,29 @@ package globalclockupdater
 import (
 	"time"
 
+	"github.com/hashicorp/raft"
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 
+	"github.com/juju/juju/core/globalclock"
 	workerstate "github.com/juju/juju/worker/state"
 )
 
 // ManifoldConfig holds the information necessary to run a GlobalClockUpdater
 // worker in a dependency.Engine.
+//
+// The worker updates the global clock in mongo if StateName is set.
+// Otherwise it uses Updater, which is expected to be the raft lease
+// store, and only runs while the raft node named by RaftName does: the
+// store reads global time from the FSM that the raft node applies
+// updates to.
 type ManifoldConfig struct {
 	ClockName string
 	StateName string
+	RaftName  string
+	Updater   globalclock.Updater
 
 	NewWorker      func(Config) (worker.Worker, error)
 	UpdateInterval time.Duration
@@ -30,7 +40,14 @@ func (config ManifoldConfig) Validate() error {
 		return errors.NotValidf("empty ClockName")
 	}
 	if config.StateName == "" {
-		return errors.NotValidf("empty StateName")
+		if config.RaftName == "" {
+			return errors.NotValidf("empty StateName and RaftName")
+		}
+		if config.Updater == nil {
+			return errors.NotValidf("nil Updater")
+		}
+	} else if config.RaftName != "" {
+		return errors.NotValidf("both StateName and RaftName")
 	}
 	if config.NewWorker == nil {
 		return errors.NotValidf("nil NewWorker")
@@ -47,12 +64,15 @@ func (config ManifoldConfig) Validate() error {
 // Manifold returns a dependency.Manifold that will run a global clock
 // updater worker.
 func Manifold(config ManifoldConfig) dependency.Manifold {
+	inputs := []string{config.ClockName}
+	if config.StateName != "" {
+		inputs = append(inputs, config.StateName)
+	} else {
+		inputs = append(inputs, config.RaftName)
+	}
 	return dependency.Manifold{
-		Inputs: []string{
-			config.ClockName,
-			config.StateName,
-		},
-		Start: config.start,
+		Inputs: inputs,
+		Start:  config.start,
 	}
 }
 
@@ -67,6 +87,10 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
+	if config.StateName == "" {
+		return config.startRaft(context, clock)
+	}
+
 	var stTracker workerstate.StateTracker
 	if err := context.Get(config.StateName, &stTracker); err != nil {
 		return nil, errors.Trace(err)
@@ -93,3 +117,27 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	}()
 	return worker, nil
 }
+
+// startRaft starts a worker that updates the raft lease store's global
+// time.
+func (config ManifoldConfig) startRaft(context dependency.Context, clock clock.Clock) (worker.Worker, error) {
+	// We don't use the raft node directly, but the FSM is only kept
+	// up to date while it's running.
+	var r *raft.Raft
+	if err := context.Get(config.RaftName, &r); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	worker, err := config.NewWorker(Config{
+		NewUpdater: func() (globalclock.Updater, error) {
+			return config.Updater, nil
+		},
+		LocalClock:     clock,
+		UpdateInterval: config.UpdateInterval,
+		BackoffDelay:   config.BackoffDelay,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return worker, nil
+}
@@ -6,6 +6,7 @@ package globalclockupdater_test
 import (
 	"time"
 
+	"github.com/hashicorp/raft"
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -16,6 +17,7 @@ import (
 	dt "gopkg.in/juju/worker.v1/dependency/testing"
 	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/core/globalclock"
 	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/globalclockupdater"
@@ -69,7 +71,18 @@ func (s *ManifoldSuite) TestStartValidateClockName(c *gc.C) {
 
 func (s *ManifoldSuite) TestStartValidateStateName(c *gc.C) {
 	s.config.StateName = ""
-	s.testStartValidateConfig(c, "empty StateName not valid")
+	s.testStartValidateConfig(c, "empty StateName and RaftName not valid")
+}
+
+func (s *ManifoldSuite) TestStartValidateStateAndRaftName(c *gc.C) {
+	s.config.RaftName = "raft"
+	s.testStartValidateConfig(c, "both StateName and RaftName not valid")
+}
+
+func (s *ManifoldSuite) TestStartValidateUpdater(c *gc.C) {
+	s.config.StateName = ""
+	s.config.RaftName = "raft"
+	s.testStartValidateConfig(c, "nil Updater not valid")
 }
 
 func (s *ManifoldSuite) TestStartValidateUpdateInterval(c *gc.C) {
@@ -167,6 +180,50 @@ func (s *ManifoldSuite) TestStoppingWorkerReleasesState(c *gc.C) {
 	s.stateTracker.CheckCallNames(c, "Use", "Done")
 }
 
+func (s *ManifoldSuite) TestRaftInputs(c *gc.C) {
+	s.config.StateName = ""
+	s.config.RaftName = "raft"
+	s.config.Updater = fakeUpdater{}
+	manifold := globalclockupdater.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"clock", "raft"})
+}
+
+func (s *ManifoldSuite) TestStartMissingRaft(c *gc.C) {
+	s.config.StateName = ""
+	s.config.RaftName = "raft"
+	s.config.Updater = fakeUpdater{}
+	manifold := globalclockupdater.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"clock": fakeClock{},
+		"raft":  dependency.ErrMissing,
+	})
+
+	worker, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	c.Check(worker, gc.IsNil)
+}
+
+func (s *ManifoldSuite) TestStartRaftNewWorkerSuccess(c *gc.C) {
+	s.config.StateName = ""
+	s.config.RaftName = "raft"
+	s.config.Updater = fakeUpdater{}
+	manifold := globalclockupdater.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"clock": fakeClock{},
+		"raft":  new(raft.Raft),
+	})
+	worker, err := manifold.Start(context)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(worker, gc.Equals, s.worker)
+
+	s.stub.CheckCallNames(c, "NewWorker")
+	config := s.stub.Calls()[0].Args[0].(globalclockupdater.Config)
+	updater, err := config.NewUpdater()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updater, gc.Equals, fakeUpdater{})
+	s.stateTracker.CheckNoCalls(c)
+}
+
 func (s *ManifoldSuite) startManifold(c *gc.C) (worker.Worker, error) {
 	manifold := globalclockupdater.Manifold(s.config)
 	context := dt.StubContext(nil, map[string]interface{}{
@@ -190,6 +247,10 @@ type fakeClock struct {
 	clock.Clock
 }
 
+type fakeUpdater struct {
+	globalclock.Updater
+}
+
 type stubStateTracker struct {
 	testing.Stub
 	pool state.StatePool
@@ -9,4 +9,13 @@
 //   - prints out all the goroutines in the agent
 // * `/debug/pprof/heap?debug=1`
 //   - prints out the heap profile
+// * `/leases`
+//   - prints out the current lease holders and expiries (controllers only)
+// * `/raft`
+//   - prints out the Raft cluster state (controllers only)
+// * `/uniter`
+//   - prints out the uniter's operation state and remote state (unit agents only)
+//
+// The lease, Raft and uniter reports are YAML, or JSON if `?format=json`
+// is added to the path.
 package introspection
@@ -65,6 +65,18 @@ juju_machine_or_unit () {
   juju_agent_call $agent $1
 }
 
+juju_report () {
+  # First arg is the report path, followed by an optional --json to get
+  # JSON rather than YAML, and an optional agent name.
+  local path=$1
+  shift
+  if [ "$1" = "--json" ]; then
+    path="$path?format=json"
+    shift
+  fi
+  juju_machine_or_unit $path $@
+}
+
 juju_goroutines () {
   juju_machine_or_unit debug/pprof/goroutine?debug=1 $@
 }
@@ -113,6 +125,34 @@ juju_machine_lock () {
   done
 }
 
+juju_leases () {
+  juju_report leases $@
+}
+
+juju_raft_state () {
+  juju_report raft $@
+}
+
+juju_uniter_state () {
+  # Reports on the given unit agent, or on all of the unit agents
+  # on the machine.
+  local format=
+  if [ "$1" = "--json" ]; then
+    format=--json
+    shift
+  fi
+  if [ "$#" -gt 0 ]; then
+    juju_report uniter $format $1
+    return
+  fi
+  for agent in $(ls /var/lib/juju/agents | grep ^unit-); do
+    if [ -z "$format" ]; then
+      echo "# $agent"
+    fi
+    juju_report uniter $format $agent
+  done
+}
+
 # This asks for the command of the current pid.
 # Can't use $0 nor $SHELL due to this being wrong in various situations.
 shell=$(ps -p "$$" -o comm --no-headers)
@@ -120,6 +160,7 @@ if [ "$shell" = "bash" ]; then
   export -f juju_agent_call
   export -f juju_machine_agent_name
   export -f juju_machine_or_unit
+  export -f juju_report
   export -f juju_goroutines
   export -f juju_cpu_profile
   export -f juju_heap_profile
@@ -130,5 +171,8 @@ if [ "$shell" = "bash" ]; then
   export -f juju_pubsub_report
   export -f juju_presence_report
   export -f juju_machine_lock
+  export -f juju_leases
+  export -f juju_raft_state
+  export -f juju_uniter_state
 fi
 `
@@ -4,6 +4,7 @@
 package introspection
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/http"
@@ -15,6 +16,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
 	"gopkg.in/tomb.v2"
 	"gopkg.in/yaml.v2"
 
@@ -39,12 +41,20 @@ type IntrospectionReporter interface {
 	IntrospectionReport() string
 }
 
+// LeaseReporter provides insight into the leases held in the controller.
+type LeaseReporter interface {
+	// LeaseReport returns a map describing the current lease holders
+	// and expiries.
+	LeaseReport() (map[string]interface{}, error)
+}
+
 // Config describes the arguments required to create the introspection worker.
 type Config struct {
 	SocketName         string
 	DepEngine          DepEngineReporter
 	StatePool          IntrospectionReporter
 	PubSub             IntrospectionReporter
+	Leases             LeaseReporter
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	Presence           presence.Recorder
@@ -68,6 +78,7 @@ type socketListener struct {
 	depEngine          DepEngineReporter
 	statePool          IntrospectionReporter
 	pubsub             IntrospectionReporter
+	leases             LeaseReporter
 	machineLock        machinelock.Lock
 	prometheusGatherer prometheus.Gatherer
 	presence           presence.Recorder
@@ -101,6 +112,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		depEngine:          config.DepEngine,
 		statePool:          config.StatePool,
 		pubsub:             config.PubSub,
+		leases:             config.Leases,
 		machineLock:        config.MachineLock,
 		prometheusGatherer: config.PrometheusGatherer,
 		presence:           config.Presence,
@@ -118,6 +130,7 @@ func (w *socketListener) serve() {
 			DependencyEngine:   w.depEngine,
 			StatePool:          w.statePool,
 			PubSub:             w.pubsub,
+			Leases:             w.leases,
 			MachineLock:        w.machineLock,
 			PrometheusGatherer: w.prometheusGatherer,
 			Presence:           w.presence,
@@ -156,6 +169,7 @@ type ReportSources struct {
 	DependencyEngine   DepEngineReporter
 	StatePool          IntrospectionReporter
 	PubSub             IntrospectionReporter
+	Leases             LeaseReporter
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	Presence           presence.Recorder
@@ -189,6 +203,18 @@ func RegisterHTTPHandlers(
 		handle("/presence/", presenceHandler{sources.Presence})
 	}
 	handle("/machinelock/", machineLockHandler{sources.MachineLock})
+	handle("/leases", reportHandler{
+		name:   "Leases",
+		report: leaseReport(sources.Leases),
+	})
+	handle("/raft", reportHandler{
+		name:   "Raft",
+		report: workerReport(sources.DependencyEngine, "raft"),
+	})
+	handle("/uniter", reportHandler{
+		name:   "Uniter",
+		report: workerReport(sources.DependencyEngine, "uniter"),
+	})
 }
 
 type depengineHandler struct {
@@ -268,6 +294,79 @@ func (h introspectionReporterHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 	fmt.Fprint(w, h.reporter.IntrospectionReport())
 }
 
+// reportHandler serves a structured report as YAML, or as JSON if
+// the format=json query parameter is given.
+type reportHandler struct {
+	name   string
+	report func() (map[string]interface{}, error)
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h reportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report, err := h.report()
+	if errors.IsNotFound(err) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, "%s: %v\n", h.name, err)
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+
+	var bytes []byte
+	contentType := "text/plain; charset=utf-8"
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "yaml":
+		bytes, err = yaml.Marshal(report)
+	case "json":
+		contentType = "application/json"
+		bytes, err = json.MarshalIndent(report, "", "  ")
+		bytes = append(bytes, '\n')
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "unknown format %q\n", format)
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Write(bytes)
+}
+
+func leaseReport(reporter LeaseReporter) func() (map[string]interface{}, error) {
+	return func() (map[string]interface{}, error) {
+		if reporter == nil {
+			return nil, errors.NewNotFound(nil, "missing reporter")
+		}
+		return reporter.LeaseReport()
+	}
+}
+
+// workerReport returns a function that extracts the report of the
+// named manifold's worker from the dependency engine report.
+func workerReport(engine DepEngineReporter, name string) func() (map[string]interface{}, error) {
+	return func() (map[string]interface{}, error) {
+		if engine == nil {
+			return nil, errors.NewNotFound(nil, "missing dependency engine reporter")
+		}
+		manifolds, _ := engine.Report()[dependency.KeyManifolds].(map[string]interface{})
+		manifold, ok := manifolds[name].(map[string]interface{})
+		if !ok {
+			return nil, errors.NotFoundf("%s worker", name)
+		}
+		report, ok := manifold[dependency.KeyReport].(map[string]interface{})
+		if !ok {
+			return nil, errors.NewNotFound(nil, fmt.Sprintf("%s worker not running", name))
+		}
+		return report, nil
+	}
+}
+
 type presenceHandler struct {
 	presence presence.Recorder
 }
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/prometheus/client_golang/prometheus"
@@ -59,6 +60,7 @@ type introspectionSuite struct {
 	name     string
 	worker   worker.Worker
 	reporter introspection.DepEngineReporter
+	leases   introspection.LeaseReporter
 	gatherer prometheus.Gatherer
 	recorder presence.Recorder
 }
@@ -71,6 +73,7 @@ func (s *introspectionSuite) SetUpTest(c *gc.C) {
 	}
 	s.IsolationSuite.SetUpTest(c)
 	s.reporter = nil
+	s.leases = nil
 	s.worker = nil
 	s.recorder = nil
 	s.gatherer = newPrometheusGatherer()
@@ -82,6 +85,7 @@ func (s *introspectionSuite) startWorker(c *gc.C) {
 	w, err := introspection.NewWorker(introspection.Config{
 		SocketName:         s.name,
 		DepEngine:          s.reporter,
+		Leases:             s.leases,
 		PrometheusGatherer: s.gatherer,
 		Presence:           s.recorder,
 	})
@@ -170,6 +174,108 @@ func (s *introspectionSuite) TestEngineReporter(c *gc.C) {
 	matches(c, buf, "working: true")
 }
 
+func (s *introspectionSuite) TestMissingLeaseReporter(c *gc.C) {
+	buf := s.call(c, "/leases")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "Leases: missing reporter")
+}
+
+func (s *introspectionSuite) TestLeaseReporter(c *gc.C) {
+	// We need to make sure the existing worker is shut down
+	// so we can connect to the socket.
+	workertest.CheckKill(c, s.worker)
+	s.leases = &leaseReporter{
+		values: map[string]interface{}{
+			"application-leadership": map[string]interface{}{
+				"model-uuid": map[string]interface{}{
+					"mysql": map[string]interface{}{
+						"holder": "mysql/0",
+					},
+				},
+			},
+		},
+	}
+	s.startWorker(c)
+
+	buf := s.call(c, "/leases")
+	matches(c, buf, "200 OK")
+	matches(c, buf, "^application-leadership:$")
+	matches(c, buf, "^      holder: mysql/0$")
+
+	buf = s.call(c, "/leases?format=json")
+	matches(c, buf, "200 OK")
+	matches(c, buf, "Content-Type: application/json")
+	matches(c, buf, `^        "holder": "mysql/0"$`)
+
+	buf = s.call(c, "/leases?format=xml")
+	matches(c, buf, "400 Bad Request")
+	matches(c, buf, `unknown format "xml"`)
+}
+
+func (s *introspectionSuite) TestLeaseReporterError(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.leases = &leaseReporter{err: errors.New("boom")}
+	s.startWorker(c)
+
+	buf := s.call(c, "/leases")
+	matches(c, buf, "500 Internal Server Error")
+	matches(c, buf, "error: boom")
+}
+
+func (s *introspectionSuite) TestWorkerReportMissingEngine(c *gc.C) {
+	buf := s.call(c, "/raft")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "Raft: missing dependency engine reporter")
+}
+
+func (s *introspectionSuite) TestWorkerReports(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.reporter = &reporter{
+		values: map[string]interface{}{
+			"manifolds": map[string]interface{}{
+				"raft": map[string]interface{}{
+					"state": "started",
+					"report": map[string]interface{}{
+						"state":  "Leader",
+						"leader": "localhost",
+					},
+				},
+				"uniter": map[string]interface{}{
+					"state": "stopped",
+				},
+			},
+		},
+	}
+	s.startWorker(c)
+
+	buf := s.call(c, "/raft")
+	matches(c, buf, "200 OK")
+	matches(c, buf, "^state: Leader$")
+	matches(c, buf, "^leader: localhost$")
+
+	buf = s.call(c, "/raft?format=json")
+	matches(c, buf, "200 OK")
+	matches(c, buf, `^  "state": "Leader"$`)
+
+	buf = s.call(c, "/uniter")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "Uniter: uniter worker not running")
+}
+
+func (s *introspectionSuite) TestWorkerReportMissingManifold(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.reporter = &reporter{
+		values: map[string]interface{}{
+			"manifolds": map[string]interface{}{},
+		},
+	}
+	s.startWorker(c)
+
+	buf := s.call(c, "/uniter")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "Uniter: uniter worker not found")
+}
+
 func (s *introspectionSuite) TestMissingPresenceReporter(c *gc.C) {
 	buf := s.call(c, "/presence/")
 	matches(c, buf, "404 Not Found")
@@ -235,6 +341,15 @@ func (r *reporter) Report() map[string]interface{} {
 	return r.values
 }
 
+type leaseReporter struct {
+	values map[string]interface{}
+	err    error
+}
+
+func (r *leaseReporter) LeaseReport() (map[string]interface{}, error) {
+	return r.values, r.err
+}
+
 func newPrometheusGatherer() prometheus.Gatherer {
 	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "tau", Help: "Tau."})
 	counter.Add(6.283185)
@@ -0,0 +1,67 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	"github.com/juju/juju/worker/lease"
+)
+
+type ReportSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReportSuite{})
+
+func (s *ReportSuite) TestReport(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+			key("ns2", "model2", "mysql"): {
+				Holder: "mysql/1",
+				Expiry: offset(90 * time.Second),
+			},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		c.Assert(manager.Report(), jc.DeepEquals, map[string]interface{}{
+			"namespace": map[string]interface{}{
+				"modelUUID": map[string]interface{}{
+					"redis": map[string]interface{}{
+						"holder":     "redis/0",
+						"expiry":     "2073-03-03T09:41:00Z",
+						"expires-in": "1m0s",
+					},
+				},
+			},
+			"ns2": map[string]interface{}{
+				"model2": map[string]interface{}{
+					"mysql": map[string]interface{}{
+						"holder":     "mysql/1",
+						"expiry":     "2073-03-03T09:41:30Z",
+						"expires-in": "1m30s",
+					},
+				},
+			},
+		})
+	})
+}
+
+func (s *ReportSuite) TestReportDeadManager(c *gc.C) {
+	manager := lease.NewDeadManager(errors.New("boom"))
+	c.Assert(manager.Report(), jc.DeepEquals, map[string]interface{}{
+		"error": "lease manager stopped",
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"time"
+
+	"gopkg.in/juju/worker.v1/dependency"
+)
+
+// Report is part of the dependency.Reporter interface. The leases known
+// to the manager are reported by namespace and model, each with its
+// holder and expiry.
+func (manager *Manager) Report() map[string]interface{} {
+	out := make(map[string]interface{})
+	store := manager.config.Store
+	if store == nil {
+		// A dead manager has no store.
+		out[dependency.KeyError] = errStopped.Error()
+		return out
+	}
+
+	now := manager.config.Clock.Now()
+	for key, info := range store.Leases() {
+		models, _ := out[key.Namespace].(map[string]interface{})
+		if models == nil {
+			models = make(map[string]interface{})
+			out[key.Namespace] = models
+		}
+		leases, _ := models[key.ModelUUID].(map[string]interface{})
+		if leases == nil {
+			leases = make(map[string]interface{})
+			models[key.ModelUUID] = leases
+		}
+		leases[key.Lease] = map[string]interface{}{
+			"holder":     info.Holder,
+			"expiry":     info.Expiry.UTC().Format(time.RFC3339),
+			"expires-in": info.Expiry.Sub(now).Round(time.Second).String(),
+		}
+	}
+	return out
+}
@@ -0,0 +1,449 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	logfwdapi "github.com/juju/juju/api/logfwd"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	psauditlog "github.com/juju/juju/pubsub/auditlog"
+)
+
+const (
+	// maxAuditBatch is the most records returned by a single call to
+	// AuditLogStream.Next.
+	maxAuditBatch = 1000
+
+	// maxAuditConversations is the number of conversations that are
+	// remembered, so that later requests and responses can be
+	// annotated with the user and model they relate to.
+	maxAuditConversations = 1000
+)
+
+// Hub defines the methods of the central hub used by the audit log
+// stream.
+type Hub interface {
+	Subscribe(topic string, handler interface{}) (func(), error)
+}
+
+// AuditLogPosition identifies an audit record that was forwarded.
+// Audit records are timestamped to the second, so a record is
+// identified by its timestamp and its ID, which counts the records
+// forwarded with that timestamp.
+type AuditLogPosition struct {
+	ID        int64
+	Timestamp time.Time
+}
+
+// AuditLogStreamConfig holds the resources needed to open an
+// AuditLogStream.
+type AuditLogStreamConfig struct {
+	// Hub is the local hub that the API server publishes audit
+	// records on.
+	Hub Hub
+
+	// LogDir is the directory holding the audit.log file that the
+	// API server writes, and its rotated backups.
+	LogDir string
+
+	// LastSent identifies the last record that was forwarded before
+	// the stream was opened; the stream starts with the record after
+	// it. If it's nil, nothing has been forwarded, and the stream
+	// starts with the next record written.
+	LastSent *AuditLogPosition
+
+	// Tag identifies the controller machine the stream runs on.
+	Tag names.MachineTag
+
+	// ControllerUUID and ModelUUID identify the controller and its
+	// model, and are used for the origin of the forwarded records.
+	ControllerUUID string
+	ModelUUID      string
+
+	// Version is the version of the running software.
+	Version version.Number
+}
+
+// Validate returns an error if the config is invalid.
+func (config AuditLogStreamConfig) Validate() error {
+	if config.Hub == nil {
+		return errors.NotValidf("nil Hub")
+	}
+	if config.LogDir == "" {
+		return errors.NotValidf("empty LogDir")
+	}
+	if config.Tag.Id() == "" {
+		return errors.NotValidf("empty Tag")
+	}
+	if config.ControllerUUID == "" {
+		return errors.NotValidf("empty ControllerUUID")
+	}
+	if config.ModelUUID == "" {
+		return errors.NotValidf("empty ModelUUID")
+	}
+	return nil
+}
+
+// AuditLogStream is a LogStream of the records written to the audit
+// log by the API server on this controller machine.
+//
+// The records are read from the audit log files, rather than held in
+// memory until they're forwarded, so nothing accumulates while the
+// sink is disabled, and the stream can pick up where the last one
+// left off. The records published on the hub only signal that there
+// are more to read.
+type AuditLogStream struct {
+	origin      logfwd.Origin
+	unsubscribe func()
+	ready       chan struct{}
+
+	mu            sync.Mutex
+	closed        bool
+	tail          *auditLogTail
+	resume        *AuditLogPosition
+	last          AuditLogPosition
+	conversations map[string]*auditConversation
+	order         []string
+}
+
+type auditConversation struct {
+	conversation auditlog.Conversation
+	requests     map[uint64]auditlog.Request
+}
+
+// NewAuditLogStream returns a stream of the records written to the
+// audit log in the configured directory, after the last one sent.
+func NewAuditLogStream(config AuditLogStreamConfig) (*AuditLogStream, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths, err := auditlog.LogFilePaths(config.LogDir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	backups := paths[:len(paths)-1]
+	if config.LastSent == nil {
+		// Only the records written from now on will be forwarded,
+		// but the current file is read so that the requests in
+		// conversations that are already underway can be described.
+		backups = nil
+	}
+	var unread []string
+	for _, path := range backups {
+		// Backups rotated before the last record was sent only hold
+		// records that have already been forwarded.
+		rotated, err := auditlog.BackupTime(path)
+		if err == nil && rotated.Before(config.LastSent.Timestamp) {
+			continue
+		}
+		unread = append(unread, path)
+	}
+	stream := &AuditLogStream{
+		origin:        logfwd.OriginForAudit(config.Tag, config.ControllerUUID, config.ModelUUID, config.Version),
+		ready:         make(chan struct{}, 1),
+		tail:          newAuditLogTail(paths[len(paths)-1], unread),
+		resume:        config.LastSent,
+		conversations: make(map[string]*auditConversation),
+	}
+	if config.LastSent == nil {
+		for {
+			records, err := stream.read()
+			if err != nil {
+				stream.tail.close()
+				return nil, errors.Trace(err)
+			}
+			if len(records) < maxAuditBatch {
+				break
+			}
+		}
+	}
+	unsubscribe, err := config.Hub.Subscribe(psauditlog.RecordTopic, stream.onRecord)
+	if err != nil {
+		stream.tail.close()
+		return nil, errors.Trace(err)
+	}
+	stream.unsubscribe = unsubscribe
+	return stream, nil
+}
+
+// Next is part of LogStream. It blocks until records are available,
+// or the stream is closed.
+func (s *AuditLogStream) Next() ([]logfwd.Record, error) {
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return nil, errors.New("audit log stream closed")
+		}
+		records, err := s.read()
+		s.mu.Unlock()
+		if err != nil {
+			return nil, errors.Annotate(err, "reading audit log")
+		}
+		if len(records) > 0 {
+			return records, nil
+		}
+		<-s.ready
+	}
+}
+
+// Close unsubscribes from the hub, and causes any blocked or future
+// calls to Next to fail.
+func (s *AuditLogStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.ready)
+		s.unsubscribe()
+		s.tail.close()
+	}
+	return nil
+}
+
+func (s *AuditLogStream) onRecord(topic string, msg psauditlog.RecordMessage, err error) {
+	if err != nil {
+		logger.Errorf("bad %s message: %v", topic, err)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	// The record has already been written to the audit log, which
+	// is where it'll be read from.
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// read returns the next batch of records from the audit log files,
+// skipping any that were forwarded before the stream was opened.
+// It must be called with the lock held.
+func (s *AuditLogStream) read() ([]logfwd.Record, error) {
+	var records []logfwd.Record
+	for len(records) < maxAuditBatch {
+		line, err := s.tail.next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var in auditlog.Record
+		if err := json.Unmarshal(line, &in); err != nil {
+			logger.Warningf("skipping bad audit record %q: %v", line, err)
+			continue
+		}
+		record, ok := s.convert(in)
+		if !ok || s.sent(record) {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// sent returns whether the record was forwarded before the stream was
+// opened.
+func (s *AuditLogStream) sent(record logfwd.Record) bool {
+	if s.resume == nil {
+		return false
+	}
+	if record.Timestamp.Before(s.resume.Timestamp) ||
+		record.Timestamp.Equal(s.resume.Timestamp) && record.ID <= s.resume.ID {
+		return true
+	}
+	s.resume = nil
+	return false
+}
+
+// convert returns the logfwd.Record for the audit record, filling in
+// the details of the conversation it belongs to.
+func (s *AuditLogStream) convert(in auditlog.Record) (logfwd.Record, bool) {
+	out := logfwd.Record{
+		Origin: s.origin,
+		Level:  loggo.INFO,
+	}
+	var when string
+	switch {
+	case in.Conversation != nil:
+		conv := *in.Conversation
+		s.remember(conv)
+		when = conv.When
+		out.Audit = conversationDetails(conv)
+		out.Audit.Kind = "conversation"
+		out.Message = fmt.Sprintf("%s: %s", conv.Who, conv.What)
+	case in.Request != nil:
+		req := *in.Request
+		conv := s.conversation(req.ConversationID, req.ConnectionID)
+		conv.requests[req.RequestID] = req
+		when = req.When
+		out.Audit = requestDetails(conv.conversation, req)
+		out.Audit.Kind = "request"
+		out.Message = fmt.Sprintf("%s: %s.%s", conv.conversation.Who, req.Facade, req.Method)
+	case in.Errors != nil:
+		resp := *in.Errors
+		conv := s.conversation(resp.ConversationID, resp.ConnectionID)
+		req := conv.requests[resp.RequestID]
+		req.RequestID = resp.RequestID
+		delete(conv.requests, resp.RequestID)
+		var messages []string
+		for _, err := range resp.Errors {
+			if err != nil {
+				messages = append(messages, err.Message)
+			}
+		}
+		if len(messages) == 0 {
+			// Successful responses carry nothing worth forwarding.
+			return logfwd.Record{}, false
+		}
+		when = resp.When
+		out.Audit = requestDetails(conv.conversation, req)
+		out.Audit.Kind = "errors"
+		out.Level = loggo.WARNING
+		out.Message = fmt.Sprintf("%s: %s.%s failed: %s",
+			conv.conversation.Who, req.Facade, req.Method, strings.Join(messages, "; "))
+	default:
+		return logfwd.Record{}, false
+	}
+	timestamp, err := time.Parse(time.RFC3339, when)
+	if err != nil {
+		logger.Warningf("audit record has bad time %q", when)
+		timestamp = s.last.Timestamp
+	}
+	if timestamp.Equal(s.last.Timestamp) {
+		s.last.ID++
+	} else {
+		s.last = AuditLogPosition{ID: 1, Timestamp: timestamp}
+	}
+	out.ID = s.last.ID
+	out.Timestamp = s.last.Timestamp
+	return out, true
+}
+
+func (s *AuditLogStream) remember(conv auditlog.Conversation) {
+	s.conversations[conv.ConversationID] = &auditConversation{
+		conversation: conv,
+		requests:     make(map[uint64]auditlog.Request),
+	}
+	s.order = append(s.order, conv.ConversationID)
+	if len(s.order) > maxAuditConversations {
+		delete(s.conversations, s.order[0])
+		s.order = s.order[1:]
+	}
+}
+
+// conversation returns the conversation with the given ID. If it's
+// not known (for example, because the stream was opened part way
+// through the conversation) only the IDs are filled in.
+func (s *AuditLogStream) conversation(conversationID, connectionID string) *auditConversation {
+	conv, ok := s.conversations[conversationID]
+	if !ok {
+		s.remember(auditlog.Conversation{
+			ConversationID: conversationID,
+			ConnectionID:   connectionID,
+		})
+		conv = s.conversations[conversationID]
+	}
+	return conv
+}
+
+func conversationDetails(conv auditlog.Conversation) *logfwd.AuditDetails {
+	return &logfwd.AuditDetails{
+		ConversationID: conv.ConversationID,
+		ConnectionID:   conv.ConnectionID,
+		Who:            conv.Who,
+		What:           conv.What,
+		ModelName:      conv.ModelName,
+		ModelUUID:      conv.ModelUUID,
+	}
+}
+
+func requestDetails(conv auditlog.Conversation, req auditlog.Request) *logfwd.AuditDetails {
+	details := conversationDetails(conv)
+	details.RequestID = req.RequestID
+	details.Facade = req.Facade
+	details.Method = req.Method
+	details.Version = req.Version
+	return details
+}
+
+// auditLogStreams opens a separate AuditLogStream for each log
+// forwarder, so that every sink receives all the records, and closes
+// them all when the forwarders are stopped.
+type auditLogStreams struct {
+	config AuditLogStreamConfig
+
+	mu      sync.Mutex
+	streams []*AuditLogStream
+}
+
+// open is a LogStreamFn. The stream starts after the last record the
+// sink's tracker recorded as sent.
+func (s *auditLogStreams) open(caller base.APICaller, cfg params.LogStreamConfig, _ string) (LogStream, error) {
+	lastSent, err := s.lastSent(caller, cfg.Sink)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting last audit record sent to %s", cfg.Sink)
+	}
+	config := s.config
+	config.LastSent = lastSent
+	stream, err := NewAuditLogStream(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.streams = append(s.streams, stream)
+	return stream, nil
+}
+
+// lastSent returns the position of the last record forwarded to the
+// sink, as recorded by its lastSentTracker, or nil if nothing has
+// been forwarded yet.
+func (s *auditLogStreams) lastSent(caller base.APICaller, sink string) (*AuditLogPosition, error) {
+	client := logfwdapi.NewLastSentClient(func(name string) logfwdapi.FacadeCaller {
+		return base.NewFacadeCaller(caller, name)
+	})
+	results, err := client.GetLastSent([]logfwdapi.LastSentID{{
+		Model: names.NewModelTag(s.config.ModelUUID),
+		Sink:  sink,
+	}})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := results[0].Error; errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &AuditLogPosition{
+		ID:        results[0].RecordID,
+		Timestamp: results[0].RecordTimestamp,
+	}, nil
+}
+
+func (s *auditLogStreams) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, stream := range s.streams {
+		stream.Close()
+	}
+	s.streams = nil
+}
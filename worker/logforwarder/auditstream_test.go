@@ -0,0 +1,345 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/logfwd"
+	psauditlog "github.com/juju/juju/pubsub/auditlog"
+	"github.com/juju/juju/pubsub/centralhub"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/version"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+type AuditLogStreamSuite struct {
+	testing.IsolationSuite
+
+	hub    *pubsub.StructuredHub
+	logDir string
+	stream *logforwarder.AuditLogStream
+}
+
+var _ = gc.Suite(&AuditLogStreamSuite{})
+
+func (s *AuditLogStreamSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.hub = centralhub.New(names.NewMachineTag("0"))
+	s.logDir = c.MkDir()
+	s.stream = nil
+	s.AddCleanup(func(*gc.C) {
+		if s.stream != nil {
+			s.stream.Close()
+		}
+	})
+}
+
+func (s *AuditLogStreamSuite) open(c *gc.C, lastSent *logforwarder.AuditLogPosition) {
+	stream, err := logforwarder.NewAuditLogStream(logforwarder.AuditLogStreamConfig{
+		Hub:            s.hub,
+		LogDir:         s.logDir,
+		LastSent:       lastSent,
+		Tag:            names.NewMachineTag("0"),
+		ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+		Version:        version.Current,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.stream = stream
+}
+
+// write appends the records to the audit log, as the API server does.
+func (s *AuditLogStreamSuite) write(c *gc.C, records ...auditlog.Record) {
+	f, err := os.OpenFile(filepath.Join(s.logDir, "audit.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	for _, record := range records {
+		data, err := json.Marshal(record)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = f.Write(append(data, '\n'))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+// publish writes the record to the audit log and then publishes it,
+// as the API server does.
+func (s *AuditLogStreamSuite) publish(c *gc.C, record auditlog.Record) {
+	s.write(c, record)
+	done, err := s.hub.Publish(psauditlog.RecordTopic, psauditlog.RecordMessage{
+		Record:    record,
+		LocalOnly: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out publishing record")
+	}
+}
+
+func (s *AuditLogStreamSuite) next(c *gc.C) []logfwd.Record {
+	type result struct {
+		records []logfwd.Record
+		err     error
+	}
+	results := make(chan result, 1)
+	go func() {
+		records, err := s.stream.Next()
+		results <- result{records, err}
+	}()
+	select {
+	case r := <-results:
+		c.Assert(r.err, jc.ErrorIsNil)
+		return r.records
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for records")
+	}
+	return nil
+}
+
+func (s *AuditLogStreamSuite) TestValidate(c *gc.C) {
+	_, err := logforwarder.NewAuditLogStream(logforwarder.AuditLogStreamConfig{
+		LogDir:         s.logDir,
+		Tag:            names.NewMachineTag("0"),
+		ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Hub not valid")
+	_, err = logforwarder.NewAuditLogStream(logforwarder.AuditLogStreamConfig{
+		Hub:            s.hub,
+		Tag:            names.NewMachineTag("0"),
+		ControllerUUID: "feebdaed-2f18-4fd2-967d-db9663db7bea",
+		ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+	})
+	c.Assert(err, gc.ErrorMatches, "empty LogDir not valid")
+}
+
+func (s *AuditLogStreamSuite) TestConversation(c *gc.C) {
+	s.open(c, nil)
+	s.publish(c, auditlog.Record{
+		Conversation: &auditlog.Conversation{
+			Who:            "bob",
+			What:           "juju deploy mysql",
+			When:           "2018-08-08T08:08:08Z",
+			ModelName:      "admin/default",
+			ModelUUID:      "cafebabe-2f18-4fd2-967d-db9663db7bea",
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AB",
+		},
+	})
+	s.publish(c, auditlog.Record{
+		Request: &auditlog.Request{
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AB",
+			RequestID:      2,
+			When:           "2018-08-08T08:08:08Z",
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        7,
+		},
+	})
+
+	records := s.next(c)
+	c.Assert(records, gc.HasLen, 2)
+	for _, record := range records {
+		c.Check(record.Validate(), jc.ErrorIsNil)
+		c.Check(record.Origin.Type, gc.Equals, logfwd.OriginTypeAudit)
+	}
+	c.Check(records[0].ID, gc.Equals, int64(1))
+	c.Check(records[0].Level, gc.Equals, loggo.INFO)
+	c.Check(records[0].Message, gc.Equals, "bob: juju deploy mysql")
+	c.Check(records[0].Timestamp, gc.Equals, time.Date(2018, 8, 8, 8, 8, 8, 0, time.UTC))
+	c.Check(records[1].ID, gc.Equals, int64(2))
+	c.Check(records[1].Message, gc.Equals, "bob: Application.Deploy")
+	c.Check(records[1].Audit, jc.DeepEquals, &logfwd.AuditDetails{
+		Kind:           "request",
+		ConversationID: "0123456789abcdef",
+		ConnectionID:   "AB",
+		Who:            "bob",
+		What:           "juju deploy mysql",
+		ModelName:      "admin/default",
+		ModelUUID:      "cafebabe-2f18-4fd2-967d-db9663db7bea",
+		RequestID:      2,
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        7,
+	})
+
+	// Errors are reported against the request they relate to;
+	// successful responses are not forwarded.
+	s.publish(c, auditlog.Record{
+		Errors: &auditlog.ResponseErrors{
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AB",
+			RequestID:      1,
+			When:           "2018-08-08T08:08:10Z",
+			Errors:         []*auditlog.Error{nil},
+		},
+	})
+	s.publish(c, auditlog.Record{
+		Errors: &auditlog.ResponseErrors{
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AB",
+			RequestID:      2,
+			When:           "2018-08-08T08:08:10Z",
+			Errors:         []*auditlog.Error{{Message: "boom"}},
+		},
+	})
+	records = s.next(c)
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].ID, gc.Equals, int64(1))
+	c.Check(records[0].Timestamp, gc.Equals, time.Date(2018, 8, 8, 8, 8, 10, 0, time.UTC))
+	c.Check(records[0].Level, gc.Equals, loggo.WARNING)
+	c.Check(records[0].Message, gc.Equals, "bob: Application.Deploy failed: boom")
+	c.Check(records[0].Audit.Kind, gc.Equals, "errors")
+}
+
+func (s *AuditLogStreamSuite) TestClose(c *gc.C) {
+	s.open(c, nil)
+	errs := make(chan error, 1)
+	go func() {
+		_, err := s.stream.Next()
+		errs <- err
+	}()
+	c.Assert(s.stream.Close(), jc.ErrorIsNil)
+	select {
+	case err := <-errs:
+		c.Assert(err, gc.ErrorMatches, "audit log stream closed")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for Next to return")
+	}
+}
+
+var (
+	conversationRecord = auditlog.Record{
+		Conversation: &auditlog.Conversation{
+			Who:            "bob",
+			What:           "juju deploy mysql",
+			When:           "2018-08-08T08:08:08Z",
+			ModelName:      "admin/default",
+			ModelUUID:      "cafebabe-2f18-4fd2-967d-db9663db7bea",
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AB",
+		},
+	}
+	deployRecord = auditlog.Record{
+		Request: &auditlog.Request{
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AB",
+			RequestID:      1,
+			When:           "2018-08-08T08:08:08Z",
+			Facade:         "Application",
+			Method:         "Deploy",
+			Version:        7,
+		},
+	}
+	exposeRecord = auditlog.Record{
+		Request: &auditlog.Request{
+			ConversationID: "0123456789abcdef",
+			ConnectionID:   "AB",
+			RequestID:      2,
+			When:           "2018-08-08T08:08:09Z",
+			Facade:         "Application",
+			Method:         "Expose",
+			Version:        7,
+		},
+	}
+)
+
+func messages(records []logfwd.Record) []string {
+	var result []string
+	for _, record := range records {
+		result = append(result, record.Message)
+	}
+	return result
+}
+
+func (s *AuditLogStreamSuite) TestSkipsRecordsWrittenBeforeOpening(c *gc.C) {
+	s.write(c, conversationRecord, deployRecord)
+	s.open(c, nil)
+	s.publish(c, exposeRecord)
+
+	records := s.next(c)
+	c.Assert(messages(records), jc.DeepEquals, []string{"bob: Application.Expose"})
+	c.Check(records[0].Audit.ModelName, gc.Equals, "admin/default")
+}
+
+func (s *AuditLogStreamSuite) TestResumesAfterLastSent(c *gc.C) {
+	s.write(c, conversationRecord, deployRecord, exposeRecord)
+	s.open(c, &logforwarder.AuditLogPosition{
+		ID:        1,
+		Timestamp: time.Date(2018, 8, 8, 8, 8, 8, 0, time.UTC),
+	})
+
+	records := s.next(c)
+	c.Assert(messages(records), jc.DeepEquals, []string{
+		"bob: Application.Deploy",
+		"bob: Application.Expose",
+	})
+	c.Check(records[0].ID, gc.Equals, int64(2))
+	c.Check(records[1].ID, gc.Equals, int64(1))
+}
+
+func (s *AuditLogStreamSuite) TestResumeReadsBackups(c *gc.C) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	for _, record := range []auditlog.Record{conversationRecord, deployRecord} {
+		data, err := json.Marshal(record)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = gz.Write(append(data, '\n'))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(gz.Close(), jc.ErrorIsNil)
+	err := ioutil.WriteFile(filepath.Join(s.logDir, "audit-2018-08-08T08-08-08.500.log.gz"), compressed.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	// This backup was rotated before the last record was sent, so
+	// it isn't read.
+	err = ioutil.WriteFile(filepath.Join(s.logDir, "audit-2018-08-08T08-00-00.000.log"), []byte("not a record\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.write(c, exposeRecord)
+
+	s.open(c, &logforwarder.AuditLogPosition{
+		ID:        1,
+		Timestamp: time.Date(2018, 8, 8, 8, 8, 8, 0, time.UTC),
+	})
+	records := s.next(c)
+	c.Assert(messages(records), jc.DeepEquals, []string{
+		"bob: Application.Deploy",
+		"bob: Application.Expose",
+	})
+}
+
+func (s *AuditLogStreamSuite) TestFollowsRotation(c *gc.C) {
+	s.open(c, nil)
+	s.publish(c, conversationRecord)
+	c.Assert(messages(s.next(c)), jc.DeepEquals, []string{"bob: juju deploy mysql"})
+
+	// Records written before the file is rotated are still read.
+	s.write(c, deployRecord)
+	err := os.Rename(
+		filepath.Join(s.logDir, "audit.log"),
+		filepath.Join(s.logDir, "audit-2018-08-08T08-08-09.000.log"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.publish(c, exposeRecord)
+	c.Assert(messages(s.next(c)), jc.DeepEquals, []string{
+		"bob: Application.Deploy",
+		"bob: Application.Expose",
+	})
+}
@@ -0,0 +1,150 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logforwarder
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// auditLogTail reads the lines of a set of audit log backups, then
+// follows the audit.log file as it's written to and rotated.
+type auditLogTail struct {
+	path    string
+	backups []string
+
+	file    *os.File
+	gz      *gzip.Reader
+	reader  *bufio.Reader
+	follow  bool
+	rotated bool
+	partial []byte
+}
+
+func newAuditLogTail(path string, backups []string) *auditLogTail {
+	return &auditLogTail{
+		path:    path,
+		backups: backups,
+	}
+}
+
+// next returns the next complete line, or io.EOF if there isn't one
+// yet.
+func (t *auditLogTail) next() ([]byte, error) {
+	for {
+		if t.reader == nil {
+			if err := t.open(); err != nil {
+				return nil, err
+			}
+		}
+		line, err := t.reader.ReadBytes('\n')
+		if err == nil {
+			line = append(t.partial, line...)
+			t.partial = nil
+			return line, nil
+		} else if err != io.EOF {
+			return nil, errors.Trace(err)
+		}
+		// The rest of the line may not have been written yet.
+		t.partial = append(t.partial, line...)
+		if t.follow && !t.rotated {
+			rotated, err := t.isRotated()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !rotated {
+				return nil, io.EOF
+			}
+			// Nothing more is written to a file once it's been
+			// rotated, but that may have happened since it was
+			// read to the end, so it's read once more.
+			t.rotated = true
+			continue
+		}
+		if len(t.partial) > 0 {
+			logger.Warningf("skipping incomplete audit record at the end of %s", t.file.Name())
+		}
+		t.close()
+	}
+}
+
+// open opens the next file to be read: the oldest unread backup, or
+// audit.log when they've all been read. It returns io.EOF if there's
+// nothing to open.
+func (t *auditLogTail) open() error {
+	path := t.path
+	for len(t.backups) > 0 {
+		path = t.backups[0]
+		t.backups = t.backups[1:]
+		if _, err := os.Stat(path); err == nil {
+			break
+		}
+		// The backup may have been compressed since it was listed.
+		if _, err := os.Stat(path + ".gz"); err == nil {
+			path += ".gz"
+			break
+		}
+		// Otherwise it's been removed, as old backups are when new
+		// ones are made.
+		path = t.path
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		// The API server hasn't written anything yet.
+		return io.EOF
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	var source io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		t.gz, err = gzip.NewReader(file)
+		if err != nil {
+			file.Close()
+			return errors.Annotatef(err, "reading %s", path)
+		}
+		source = t.gz
+	}
+	t.file = file
+	t.reader = bufio.NewReader(source)
+	t.follow = path == t.path
+	t.rotated = false
+	return nil
+}
+
+// isRotated returns whether audit.log has been replaced by a new file
+// since it was opened.
+func (t *auditLogTail) isRotated() (bool, error) {
+	info, err := os.Stat(t.path)
+	if os.IsNotExist(err) {
+		// It's being rotated; the new file will be picked up next
+		// time.
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	current, err := t.file.Stat()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return !os.SameFile(info, current), nil
+}
+
+// close closes the file being read, if any.
+func (t *auditLogTail) close() {
+	if t.gz != nil {
+		t.gz.Close()
+		t.gz = nil
+	}
+	if t.file != nil {
+		t.file.Close()
+		t.file = nil
+	}
+	t.reader = nil
+	t.partial = nil
+}
@@ -4,14 +4,20 @@
 package logforwarder
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 
+	"github.com/juju/juju/agent"
 	apiagent "github.com/juju/juju/api/agent"
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/logstream"
 	"github.com/juju/juju/apiserver/params"
+	jujuversion "github.com/juju/juju/version"
+	"github.com/juju/juju/worker/common"
 )
 
 // ManifoldConfig defines the names of the manifolds on which a
@@ -78,3 +84,100 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 		},
 	}
 }
+
+// AuditManifoldConfig defines the names of the manifolds on which an
+// AuditManifold will depend, and the resources it uses.
+type AuditManifoldConfig struct {
+	// These are the dependency resource names.
+	AgentName     string
+	APICallerName string
+
+	// Hub is the central hub on which the API server publishes the
+	// audit records it writes.
+	Hub Hub
+
+	// Sinks are the named functions that opens the underlying log sinks
+	// to which audit records will be forwarded. Each sink receives all
+	// of the records.
+	Sinks []LogSinkSpec
+
+	// OpenLogForwarder opens each log forwarder that will be used.
+	OpenLogForwarder func(OpenLogForwarderArgs) (*LogForwarder, error)
+}
+
+// AuditManifold returns a dependency manifold that runs a worker
+// forwarding the audit records written on this controller machine to
+// the controller's log forwarding target.
+func AuditManifold(config AuditManifoldConfig) dependency.Manifold {
+	openForwarder := config.OpenLogForwarder
+	if openForwarder == nil {
+		openForwarder = NewLogForwarder
+	}
+
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+		},
+		Start: func(context dependency.Context) (worker.Worker, error) {
+			var agent agent.Agent
+			if err := context.Get(config.AgentName, &agent); err != nil {
+				return nil, errors.Trace(err)
+			}
+			var apiCaller base.APICaller
+			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+				return nil, errors.Trace(err)
+			}
+			currentConfig := agent.CurrentConfig()
+			machineTag, ok := currentConfig.Tag().(names.MachineTag)
+			if !ok {
+				return nil, errors.Errorf("expected a machine tag, got %v", currentConfig.Tag())
+			}
+
+			agentFacade, err := apiagent.NewState(apiCaller)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			controllerCfg, err := agentFacade.ControllerConfig()
+			if err != nil {
+				return nil, errors.Annotate(err, "cannot read controller config")
+			}
+
+			streams := &auditLogStreams{config: AuditLogStreamConfig{
+				Hub:            config.Hub,
+				LogDir:         currentConfig.LogDir(),
+				Tag:            machineTag,
+				ControllerUUID: controllerCfg.ControllerUUID(),
+				ModelUUID:      currentConfig.Model().Id(),
+				Version:        jujuversion.Current,
+			}}
+			if err := streams.config.Validate(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			// Each controller machine forwards the records from its
+			// own audit log, so each needs its own record of the
+			// last one sent.
+			sinks := make([]LogSinkSpec, len(config.Sinks))
+			for i, spec := range config.Sinks {
+				spec.Name = fmt.Sprintf("%s-%s", spec.Name, machineTag.Id())
+				sinks[i] = spec
+			}
+			orchestrator, err := newOrchestratorForController(OrchestratorArgs{
+				ControllerUUID:   controllerCfg.ControllerUUID(),
+				LogForwardConfig: agentFacade,
+				Caller:           apiCaller,
+				Sinks:            sinks,
+				OpenLogStream:    streams.open,
+				OpenLogForwarder: openForwarder,
+			})
+			if err != nil {
+				streams.closeAll()
+				return nil, errors.Annotate(err, "creating audit log forwarding orchestrator")
+			}
+			if orchestrator == nil {
+				return nil, dependency.ErrUninstall
+			}
+			return common.NewCleanupWorker(orchestrator, streams.closeAll), nil
+		},
+	}
+}
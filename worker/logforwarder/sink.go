@@ -4,6 +4,8 @@
 package logforwarder
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/logfwd/syslog"
 )
@@ -22,14 +24,42 @@ type LogSinkSpec struct {
 	// Name is the name of the log sink.
 	Name string
 
+	// ReadConfig reads the sink's configuration from the log forward
+	// config. If it's nil, ReadSyslogConfig is used.
+	ReadConfig SinkConfigFn
+
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
+// SinkConfig is the configuration of a single log sink.
+type SinkConfig interface {
+	// Validate returns an error if the config is not valid.
+	Validate() error
+}
+
+// SinkConfigFn is a function that reads a sink's configuration from
+// the log forward config, and reports whether forwarding to the sink
+// is enabled.
+type SinkConfigFn func(LogForwardConfig) (cfg SinkConfig, enabled bool, err error)
+
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg SinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
 	SendCloser
 }
+
+// ReadSyslogConfig is a SinkConfigFn that reads the config of the
+// syslog sink. Forwarding is enabled only if a syslog host is set.
+func ReadSyslogConfig(api LogForwardConfig) (SinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if !ok || !cfg.Enabled || cfg.Host == "" {
+		return nil, false, nil
+	}
+	return cfg, true, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// HTTPLogForwardConfig is implemented by log forward config sources
+// which also provide the config for forwarding over HTTP.
+type HTTPLogForwardConfig interface {
+	HTTPLogForwardConfig() (*httpjson.RawConfig, bool, error)
+}
+
+// ReadHTTPConfig is a logforwarder.SinkConfigFn that reads the config
+// of the HTTP JSON sink. Forwarding is enabled only if a URL is set.
+func ReadHTTPConfig(api logforwarder.LogForwardConfig) (logforwarder.SinkConfig, bool, error) {
+	httpAPI, ok := api.(HTTPLogForwardConfig)
+	if !ok {
+		return nil, false, nil
+	}
+	cfg, ok, err := httpAPI.HTTPLogForwardConfig()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if !ok || !cfg.Enabled || cfg.URL == "" {
+		return nil, false, nil
+	}
+	return cfg, true, nil
+}
+
+// OpenHTTP returns a sink which sends log records to an HTTP(S)
+// endpoint in batches of newline-delimited JSON.
+func OpenHTTP(cfg logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	httpCfg, ok := cfg.(*httpjson.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected HTTP config, got %T", cfg)
+	}
+	if !httpCfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := httpjson.Open(*httpCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/worker/logforwarder"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type HTTPSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HTTPSuite{})
+
+func (s *HTTPSuite) TestReadHTTPConfig(c *gc.C) {
+	api := &fakeConfig{http: &httpjson.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com",
+	}}
+	cfg, enabled, err := sinks.ReadHTTPConfig(api)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enabled, jc.IsTrue)
+	c.Assert(cfg, gc.Equals, api.http)
+}
+
+func (s *HTTPSuite) TestReadHTTPConfigDisabled(c *gc.C) {
+	for i, httpCfg := range []*httpjson.RawConfig{
+		nil,
+		{URL: "https://logs.example.com"},
+		{Enabled: true},
+	} {
+		c.Logf("test %d", i)
+		_, enabled, err := sinks.ReadHTTPConfig(&fakeConfig{http: httpCfg})
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(enabled, jc.IsFalse)
+	}
+}
+
+func (s *HTTPSuite) TestReadHTTPConfigNotSupported(c *gc.C) {
+	_, enabled, err := sinks.ReadHTTPConfig(&syslogOnlyConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(enabled, jc.IsFalse)
+}
+
+func (s *HTTPSuite) TestOpenHTTP(c *gc.C) {
+	sink, err := sinks.OpenHTTP(&httpjson.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.SendCloser, gc.FitsTypeOf, &httpjson.Client{})
+}
+
+func (s *HTTPSuite) TestOpenHTTPWrongConfig(c *gc.C) {
+	_, err := sinks.OpenHTTP(&syslog.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, `expected HTTP config, got \*syslog.RawConfig`)
+}
+
+type syslogOnlyConfig struct{}
+
+func (*syslogOnlyConfig) WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error) {
+	return nil, nil
+}
+
+func (*syslogOnlyConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
+	return nil, false, nil
+}
+
+type fakeConfig struct {
+	syslogOnlyConfig
+	http *httpjson.RawConfig
+}
+
+func (c *fakeConfig) HTTPLogForwardConfig() (*httpjson.RawConfig, bool, error) {
+	return c.http, c.http != nil, nil
+}
+
+var _ logforwarder.LogForwardConfig = (*fakeConfig)(nil)
@@ -12,11 +12,15 @@ import (
 )
 
 // OpenSyslog returns a sink used to receive log messages to be forwarded.
-func OpenSyslog(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-	if !cfg.Enabled {
+func OpenSyslog(cfg logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	syslogCfg, ok := cfg.(*syslog.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected syslog config, got %T", cfg)
+	}
+	if !syslogCfg.Enabled {
 		return nil, errors.New("log forwarding not enabled")
 	}
-	client, err := syslog.Open(*cfg)
+	client, err := syslog.Open(*syslogCfg)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -0,0 +1,156 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder
+
+import (
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+// applyTimeout is the longest we'll wait for a command to be applied
+// to the raft log before giving up.
+const applyTimeout = 5 * time.Second
+
+// Logger defines the methods we use from loggo.Logger.
+type Logger interface {
+	Tracef(string, ...interface{})
+	Debugf(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// RaftApplier allows applying a command to the raft FSM.
+type RaftApplier interface {
+	Apply(cmd []byte, timeout time.Duration) raft.ApplyFuture
+}
+
+// Config defines the resources the worker needs to run.
+type Config struct {
+	Hub    *pubsub.StructuredHub
+	Raft   RaftApplier
+	Logger Logger
+	Topic  string
+}
+
+// Validate checks that this config can be used.
+func (config Config) Validate() error {
+	if config.Hub == nil {
+		return errors.NotValidf("nil Hub")
+	}
+	if config.Raft == nil {
+		return errors.NotValidf("nil Raft")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.Topic == "" {
+		return errors.NotValidf("empty Topic")
+	}
+	return nil
+}
+
+// NewWorker creates and starts a worker that will forward leadership
+// claims from non-raft-leader machines to the leader, and applies
+// them to the raft log.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &forwarder{
+		config:   config,
+		requests: make(chan raftlease.ForwardRequest),
+	}
+	unsubscribe, err := config.Hub.Subscribe(config.Topic, w.handleRequest)
+	if err != nil {
+		return nil, errors.Annotatef(err, "subscribing to %q", config.Topic)
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: func() error {
+			defer unsubscribe()
+			return w.loop()
+		},
+	}); err != nil {
+		unsubscribe()
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type forwarder struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	requests chan raftlease.ForwardRequest
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *forwarder) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *forwarder) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *forwarder) handleRequest(_ string, req raftlease.ForwardRequest, err error) {
+	if err != nil {
+		// This should never happen, so treat it as fatal.
+		w.catacomb.Kill(errors.Annotate(err, "requests callback failed"))
+		return
+	}
+	select {
+	case <-w.catacomb.Dying():
+	case w.requests <- req:
+	}
+}
+
+func (w *forwarder) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case req := <-w.requests:
+			if err := w.processRequest(req); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (w *forwarder) processRequest(req raftlease.ForwardRequest) error {
+	w.config.Logger.Tracef("applying command %q", req.Command)
+	var response raftlease.ForwardResponse
+	future := w.config.Raft.Apply([]byte(req.Command), applyTimeout)
+	if err := future.Error(); err != nil {
+		switch errors.Cause(err) {
+		case raft.ErrNotLeader, raft.ErrLeadershipLost, raft.ErrEnqueueTimeout:
+			// Leadership has moved on, or raft is too busy to
+			// accept the command; the client should retry once
+			// the cluster settles.
+			w.config.Logger.Debugf("applying command failed: %v", err)
+			response.Error = raftlease.AsResponseError(lease.ErrTimeout)
+		default:
+			return errors.Annotate(err, "applying command")
+		}
+	} else {
+		fsmResponse, ok := future.Response().(raftlease.FSMResponse)
+		if !ok {
+			return errors.Errorf("expected raftlease.FSMResponse, got %T: %#v", future.Response(), future.Response())
+		}
+		response.Error = raftlease.AsResponseError(fsmResponse.Error())
+	}
+	_, err := w.config.Hub.Publish(req.ResponseTopic, response)
+	if err != nil {
+		w.config.Logger.Warningf("publishing response to %q: %v", req.ResponseTopic, err)
+	}
+	return nil
+}
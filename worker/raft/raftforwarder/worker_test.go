@@ -0,0 +1,138 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder_test
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/pubsub/centralhub"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/raft/raftforwarder"
+	"github.com/juju/juju/worker/raft/rafttest"
+)
+
+type workerFixture struct {
+	rafttest.RaftFixture
+	fsm    *raftlease.FSM
+	hub    *pubsub.StructuredHub
+	config raftforwarder.Config
+}
+
+func (s *workerFixture) SetUpTest(c *gc.C) {
+	s.fsm = raftlease.NewFSM()
+	s.FSM = s.fsm
+	s.RaftFixture.SetUpTest(c)
+	s.hub = centralhub.New(names.NewMachineTag("0"))
+	s.config = raftforwarder.Config{
+		Hub:    s.hub,
+		Raft:   s.Raft,
+		Logger: loggo.GetLogger("raftforwarder_test"),
+		Topic:  "lease.request",
+	}
+}
+
+type WorkerValidationSuite struct {
+	workerFixture
+}
+
+var _ = gc.Suite(&WorkerValidationSuite{})
+
+func (s *WorkerValidationSuite) TestValidateErrors(c *gc.C) {
+	type test struct {
+		f      func(*raftforwarder.Config)
+		expect string
+	}
+	tests := []test{{
+		func(cfg *raftforwarder.Config) { cfg.Hub = nil },
+		"nil Hub not valid",
+	}, {
+		func(cfg *raftforwarder.Config) { cfg.Raft = nil },
+		"nil Raft not valid",
+	}, {
+		func(cfg *raftforwarder.Config) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(cfg *raftforwarder.Config) { cfg.Topic = "" },
+		"empty Topic not valid",
+	}}
+	for i, test := range tests {
+		c.Logf("test #%d (%s)", i, test.expect)
+		config := s.config
+		test.f(&config)
+		w, err := raftforwarder.NewWorker(config)
+		if !c.Check(err, gc.NotNil) {
+			workertest.DirtyKill(c, w)
+			continue
+		}
+		c.Check(w, gc.IsNil)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+type WorkerSuite struct {
+	workerFixture
+	client *raftlease.PubsubClient
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.workerFixture.SetUpTest(c)
+	w, err := raftforwarder.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+
+	client, err := raftlease.NewPubsubClient(raftlease.PubsubClientConfig{
+		Hub:            s.hub,
+		RequestTopic:   "lease.request",
+		Clock:          clock.WallClock,
+		ForwardTimeout: coretesting.LongWait,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.client = client
+}
+
+func (s *WorkerSuite) claim(holder string) error {
+	return s.client.Request(&raftlease.Command{
+		Version:   raftlease.CommandVersion,
+		Operation: raftlease.OperationClaim,
+		Namespace: "singular",
+		ModelUUID: "model",
+		Lease:     "controller",
+		Holder:    holder,
+		Duration:  time.Minute,
+	})
+}
+
+func (s *WorkerSuite) TestAppliesCommand(c *gc.C) {
+	err := s.claim("machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	leases := s.fsm.Leases(time.Now())
+	c.Assert(leases, gc.HasLen, 1)
+	info := leases[lease.Key{
+		Namespace: "singular",
+		ModelUUID: "model",
+		Lease:     "controller",
+	}]
+	c.Assert(info.Holder, gc.Equals, "machine-0")
+}
+
+func (s *WorkerSuite) TestReturnsFSMErrors(c *gc.C) {
+	err := s.claim("machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.claim("machine-1")
+	c.Assert(err, gc.Equals, lease.ErrInvalid)
+}
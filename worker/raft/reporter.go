@@ -4,6 +4,8 @@
 package raft
 
 import (
+	"strconv"
+
 	humanize "github.com/dustin/go-humanize"
 	"github.com/hashicorp/raft"
 	"gopkg.in/juju/worker.v1/dependency"
@@ -33,6 +35,12 @@ func (w *Worker) Report() map[string]interface{} {
 		out["last-contact"] = lastContact
 	}
 
+	stats := r.Stats()
+	out["snapshot"] = map[string]interface{}{
+		"index": statValue(stats["last_snapshot_index"]),
+		"term":  statValue(stats["last_snapshot_term"]),
+	}
+
 	config := make(map[string]interface{})
 	future := r.GetConfiguration()
 	if err := future.Error(); err != nil {
@@ -51,3 +59,12 @@ func (w *Worker) Report() map[string]interface{} {
 
 	return out
 }
+
+// statValue returns the numeric value of a raft statistic, falling back
+// to the raw string if it is not a number.
+func statValue(value string) interface{} {
+	if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return n
+	}
+	return value
+}
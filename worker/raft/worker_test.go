@@ -232,6 +232,43 @@ func (s *WorkerSuite) TestRestoreSnapshot(c *gc.C) {
 	})
 }
 
+func (s *WorkerSuite) TestReport(c *gc.C) {
+	r := s.waitLeader(c)
+
+	f := r.Apply([]byte("command1"), time.Minute)
+	c.Assert(f.Error(), jc.ErrorIsNil)
+	sf := r.Snapshot()
+	c.Assert(sf.Error(), jc.ErrorIsNil)
+
+	c.Assert(s.worker.Report(), jc.DeepEquals, map[string]interface{}{
+		"state":  "Leader",
+		"leader": coreraft.ServerAddress("localhost"),
+		"index": map[string]interface{}{
+			"applied": uint64(3),
+			"last":    uint64(3),
+		},
+		"snapshot": map[string]interface{}{
+			"index": uint64(3),
+			"term":  uint64(2),
+		},
+		"cluster-config": map[string]interface{}{
+			"servers": map[string]interface{}{
+				"123": map[string]interface{}{
+					"suffrage": "Voter",
+					"address":  coreraft.ServerAddress("localhost"),
+				},
+			},
+		},
+	})
+}
+
+func (s *WorkerSuite) TestReportWorkerStopped(c *gc.C) {
+	s.worker.Kill()
+	c.Assert(s.worker.Report(), jc.DeepEquals, map[string]interface{}{
+		"error": raft.ErrWorkerStopped.Error(),
+	})
+}
+
 func (s *WorkerSuite) TestStartStop(c *gc.C) {
 	workertest.CleanKill(c, s.worker)
 }
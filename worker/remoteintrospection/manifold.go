@@ -0,0 +1,59 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/agentintrospection"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+)
+
+// ManifoldConfig describes the dependencies of the remote
+// introspection worker.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+
+	// RegisterIntrospectionHTTPHandlers registers the agent's
+	// introspection handlers, as served on its introspection socket.
+	RegisterIntrospectionHTTPHandlers func(func(path string, _ http.Handler))
+
+	NewFacade func(base.APICaller) Facade
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+// start is used by engine.AgentAPIManifold to create a StartFunc.
+func (config ManifoldConfig) start(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	if config.RegisterIntrospectionHTTPHandlers == nil {
+		return nil, errors.NotValidf("nil RegisterIntrospectionHTTPHandlers")
+	}
+	mux := http.NewServeMux()
+	config.RegisterIntrospectionHTTPHandlers(mux.Handle)
+	return config.NewWorker(Config{
+		Facade:  config.NewFacade(apiCaller),
+		Tag:     a.CurrentConfig().Tag(),
+		Handler: mux,
+	})
+}
+
+// Manifold returns a dependency.Manifold as configured.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	typedConfig := engine.AgentAPIManifoldConfig{
+		AgentName:     config.AgentName,
+		APICallerName: config.APICallerName,
+	}
+	return engine.AgentAPIManifold(typedConfig, config.start)
+}
+
+// NewFacade returns a Facade backed by the AgentIntrospection API.
+func NewFacade(apiCaller base.APICaller) Facade {
+	return agentintrospection.NewClient(apiCaller)
+}
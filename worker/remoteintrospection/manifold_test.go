@@ -0,0 +1,105 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection_test
+
+import (
+	"net/http"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/remoteintrospection"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) manifold(newWorker func(remoteintrospection.Config) (worker.Worker, error)) remoteintrospection.ManifoldConfig {
+	return remoteintrospection.ManifoldConfig{
+		AgentName:     "agent",
+		APICallerName: "api-caller",
+		RegisterIntrospectionHTTPHandlers: func(handle func(string, http.Handler)) {
+			handle("/depengine", http.NotFoundHandler())
+		},
+		NewFacade: func(base.APICaller) remoteintrospection.Facade {
+			return &fakeFacade{}
+		},
+		NewWorker: newWorker,
+	}
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := remoteintrospection.Manifold(s.manifold(nil))
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"agent", "api-caller"})
+}
+
+func (s *ManifoldSuite) TestStart(c *gc.C) {
+	var config remoteintrospection.Config
+	expect := &fakeWorker{}
+	manifold := remoteintrospection.Manifold(s.manifold(func(c remoteintrospection.Config) (worker.Worker, error) {
+		config = c
+		return expect, nil
+	}))
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewUnitTag("mysql/0")},
+		"api-caller": &fakeCaller{},
+	})
+
+	w, err := manifold.Start(context)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(w, gc.Equals, expect)
+	c.Check(config.Tag, gc.Equals, names.NewUnitTag("mysql/0"))
+	c.Check(config.Facade, gc.NotNil)
+	c.Check(config.Handler, gc.NotNil)
+}
+
+func (s *ManifoldSuite) TestStartWorkerError(c *gc.C) {
+	manifold := remoteintrospection.Manifold(s.manifold(func(remoteintrospection.Config) (worker.Worker, error) {
+		return nil, errors.New("blam")
+	}))
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      &fakeAgent{tag: names.NewMachineTag("0")},
+		"api-caller": &fakeCaller{},
+	})
+
+	w, err := manifold.Start(context)
+	c.Assert(err, gc.ErrorMatches, "blam")
+	c.Assert(w, gc.IsNil)
+}
+
+type fakeAgent struct {
+	agent.Agent
+	tag names.Tag
+}
+
+func (mock *fakeAgent) CurrentConfig() agent.Config {
+	return &fakeConfig{tag: mock.tag}
+}
+
+type fakeConfig struct {
+	agent.Config
+	tag names.Tag
+}
+
+func (mock *fakeConfig) Tag() names.Tag {
+	return mock.tag
+}
+
+type fakeCaller struct {
+	base.APICaller
+}
+
+type fakeWorker struct {
+	worker.Worker
+}
@@ -0,0 +1,236 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package remoteintrospection provides a worker that handles the
+// introspection requests made of an agent through the controller,
+// by running the agent's introspection handlers in-process.
+package remoteintrospection
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/agentintrospection"
+	"github.com/juju/juju/core/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.remoteintrospection")
+
+// maxOutputSize is the most output recorded for a request; anything
+// beyond it is discarded so that responses fit comfortably in the
+// controller's database.
+const maxOutputSize = 1 << 20
+
+// Facade defines the capabilities required by the worker from the API.
+type Facade interface {
+	WatchIntrospectionRequests(agent names.Tag) (watcher.StringsWatcher, error)
+	IntrospectionRequest(id string) (agentintrospection.Request, error)
+	CompleteIntrospectionRequest(id string, output []byte, errMsg string) error
+}
+
+// Config defines the worker's dependencies.
+type Config struct {
+	Facade  Facade
+	Tag     names.Tag
+	Handler http.Handler
+}
+
+// Validate returns an error if the configuration is not complete.
+func (c Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.Tag == nil {
+		return errors.NotValidf("nil Tag")
+	}
+	if c.Handler == nil {
+		return errors.NotValidf("nil Handler")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that watches for introspection requests
+// made of the agent, and responds to each with the output of the
+// handler for the requested path.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return watcher.NewStringsWorker(watcher.StringsConfig{
+		Handler: &handler{
+			config:  config,
+			ctx:     ctx,
+			cancel:  cancel,
+			running: make(map[string]bool),
+		},
+	})
+}
+
+// handler implements watcher.StringsHandler. Requests are run
+// concurrently, so that a long running one, such as a CPU profile,
+// doesn't hold up the others.
+type handler struct {
+	config Config
+
+	// ctx is cancelled when the worker stops, which stops any
+	// handlers that are still running.
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// SetUp is part of the watcher.StringsHandler interface.
+func (h *handler) SetUp() (watcher.StringsWatcher, error) {
+	return h.config.Facade.WatchIntrospectionRequests(h.config.Tag)
+}
+
+// Handle is part of the watcher.StringsHandler interface.
+func (h *handler) Handle(_ <-chan struct{}, ids []string) error {
+	for _, id := range ids {
+		if h.isRunning(id) {
+			continue
+		}
+		req, err := h.config.Facade.IntrospectionRequest(id)
+		if errors.IsNotFound(err) {
+			// The requester gave up waiting and removed it.
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "getting introspection request %s", id)
+		}
+		if req.Completed {
+			continue
+		}
+		h.setRunning(id, true)
+		h.wg.Add(1)
+		go func(req agentintrospection.Request) {
+			defer h.wg.Done()
+			defer h.setRunning(req.Id, false)
+			h.run(req)
+		}(req)
+	}
+	return nil
+}
+
+func (h *handler) isRunning(id string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.running[id]
+}
+
+func (h *handler) setRunning(id string, running bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if running {
+		h.running[id] = true
+	} else {
+		delete(h.running, id)
+	}
+}
+
+// run runs the introspection handler for the request and records its
+// outcome. Failures to record it are only logged, as the requester
+// will time out waiting in any case.
+func (h *handler) run(req agentintrospection.Request) {
+	logger.Infof("running introspection request %s for %s", req.Id, req.Path)
+	output, errMsg := h.introspect(req.Path)
+	if h.ctx.Err() != nil {
+		// The worker is stopping, so the handler may not have
+		// finished; there's no-one left to tell.
+		return
+	}
+	err := h.config.Facade.CompleteIntrospectionRequest(req.Id, output, errMsg)
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Errorf("completing introspection request %s: %v", req.Id, err)
+	}
+}
+
+// introspect runs the handler for the path, which may include a
+// query, and returns its output or the error it reported.
+func (h *handler) introspect(path string) ([]byte, string) {
+	httpReq, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, err.Error()
+	}
+	recorder := &responseRecorder{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+	h.config.Handler.ServeHTTP(recorder, httpReq.WithContext(h.ctx))
+
+	output := recorder.body.Bytes()
+	if recorder.status >= http.StatusBadRequest {
+		message := strings.TrimSpace(string(output))
+		if message == "" {
+			message = http.StatusText(recorder.status)
+		}
+		return nil, fmt.Sprintf("%s: %s", path, message)
+	}
+	if recorder.truncated {
+		contentType := recorder.header.Get("Content-Type")
+		if contentType == "" {
+			contentType = http.DetectContentType(output)
+		}
+		if !strings.HasPrefix(contentType, "text/") {
+			// Truncated binary output, such as a profile, is
+			// of no use.
+			return nil, fmt.Sprintf("%s: output larger than %d bytes", path, maxOutputSize)
+		}
+		output = append(output, "\n[output truncated]\n"...)
+	}
+	return output, ""
+}
+
+// TearDown is part of the watcher.StringsHandler interface.
+func (h *handler) TearDown() error {
+	h.cancel()
+	h.wg.Wait()
+	return nil
+}
+
+// responseRecorder is an http.ResponseWriter which records the
+// status and, up to maxOutputSize, the body of a response.
+type responseRecorder struct {
+	header    http.Header
+	status    int
+	body      bytes.Buffer
+	truncated bool
+	written   bool
+}
+
+// Header is part of the http.ResponseWriter interface.
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+// WriteHeader is part of the http.ResponseWriter interface.
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.written {
+		r.status = status
+		r.written = true
+	}
+}
+
+// Write is part of the http.ResponseWriter interface.
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.written = true
+	if room := maxOutputSize - r.body.Len(); len(data) > room {
+		r.body.Write(data[:room])
+		r.truncated = true
+	} else {
+		r.body.Write(data)
+	}
+	return len(data), nil
+}
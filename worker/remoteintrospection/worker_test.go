@@ -0,0 +1,250 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package remoteintrospection_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/api/agentintrospection"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/remoteintrospection"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	facade  *fakeFacade
+	handler http.Handler
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		changes:   make(chan []string, 1),
+		completed: make(chan response, 10),
+		requests: map[string]agentintrospection.Request{
+			"machine-0_i_1": {Id: "machine-0_i_1", Path: "/depengine"},
+			"machine-0_i_2": {Id: "machine-0_i_2", Path: "/broken"},
+			"machine-0_i_3": {Id: "machine-0_i_3", Path: "/depengine", Completed: true},
+			"machine-0_i_4": {Id: "machine-0_i_4", Path: "/report?format=json"},
+		},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/depengine", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "engine report")
+	})
+	mux.HandleFunc("/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, "missing reporter")
+	})
+	mux.HandleFunc("/report", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "format %s", r.URL.Query().Get("format"))
+	})
+	s.handler = mux
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := remoteintrospection.NewWorker(remoteintrospection.Config{
+		Tag:     names.NewMachineTag("0"),
+		Handler: s.handler,
+	})
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) {
+	w, err := remoteintrospection.NewWorker(remoteintrospection.Config{
+		Facade:  s.facade,
+		Tag:     names.NewMachineTag("0"),
+		Handler: s.handler,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+}
+
+func (s *WorkerSuite) nextResponse(c *gc.C) response {
+	select {
+	case resp := <-s.facade.completed:
+		return resp
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for response")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) TestHandlesRequests(c *gc.C) {
+	s.startWorker(c)
+	s.facade.changes <- []string{"machine-0_i_1", "machine-0_i_2", "machine-0_i_3", "machine-0_i_4", "machine-0_i_5"}
+
+	// Requests are handled concurrently, so may complete in any
+	// order. Completed requests, and those that have been removed,
+	// are skipped.
+	var responses []response
+	for i := 0; i < 3; i++ {
+		responses = append(responses, s.nextResponse(c))
+	}
+	c.Assert(responses, jc.SameContents, []response{{
+		id:     "machine-0_i_1",
+		output: "engine report",
+	}, {
+		id:     "machine-0_i_2",
+		errMsg: "/broken: missing reporter",
+	}, {
+		id:     "machine-0_i_4",
+		output: "format json",
+	}})
+	select {
+	case resp := <-s.facade.completed:
+		c.Fatalf("unexpected response %v", resp)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestSlowRequestDoesNotBlockOthers(c *gc.C) {
+	release := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/depengine", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+		fmt.Fprint(w, "engine report")
+	})
+	mux.HandleFunc("/report", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "report")
+	})
+	s.handler = mux
+	s.startWorker(c)
+	s.facade.changes <- []string{"machine-0_i_1", "machine-0_i_4"}
+
+	c.Assert(s.nextResponse(c), jc.DeepEquals, response{
+		id:     "machine-0_i_4",
+		output: "report",
+	})
+	close(release)
+	c.Assert(s.nextResponse(c), jc.DeepEquals, response{
+		id:     "machine-0_i_1",
+		output: "engine report",
+	})
+}
+
+func (s *WorkerSuite) TestStopCancelsRequests(c *gc.C) {
+	started := make(chan struct{})
+	mux := http.NewServeMux()
+	mux.HandleFunc("/depengine", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	})
+	s.handler = mux
+	w, err := remoteintrospection.NewWorker(remoteintrospection.Config{
+		Facade:  s.facade,
+		Tag:     names.NewMachineTag("0"),
+		Handler: s.handler,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.changes <- []string{"machine-0_i_1"}
+	select {
+	case <-started:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for request to start")
+	}
+	workertest.CleanKill(c, w)
+	select {
+	case resp := <-s.facade.completed:
+		c.Fatalf("unexpected response %v", resp)
+	default:
+	}
+}
+
+func (s *WorkerSuite) TestBinaryOutput(c *gc.C) {
+	profile := []byte{0x1f, 0x8b, 0x08, 0x00, 0x00, 0xff, 0xfe}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/depengine", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(profile)
+	})
+	s.handler = mux
+	s.startWorker(c)
+	s.facade.changes <- []string{"machine-0_i_1"}
+
+	c.Assert(s.nextResponse(c), jc.DeepEquals, response{
+		id:     "machine-0_i_1",
+		output: string(profile),
+	})
+}
+
+func (s *WorkerSuite) TestTruncatedBinaryOutput(c *gc.C) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/depengine", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(make([]byte, 2<<20))
+	})
+	s.handler = mux
+	s.startWorker(c)
+	s.facade.changes <- []string{"machine-0_i_1"}
+
+	c.Assert(s.nextResponse(c), jc.DeepEquals, response{
+		id:     "machine-0_i_1",
+		errMsg: "/depengine: output larger than 1048576 bytes",
+	})
+}
+
+func (s *WorkerSuite) TestTruncatesOutput(c *gc.C) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/depengine", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.Repeat("x", 2<<20))
+	})
+	s.handler = mux
+	s.startWorker(c)
+	s.facade.changes <- []string{"machine-0_i_1"}
+
+	resp := s.nextResponse(c)
+	c.Assert(resp.output, gc.HasLen, 1<<20+len("\n[output truncated]\n"))
+	c.Assert(strings.HasSuffix(resp.output, "\n[output truncated]\n"), jc.IsTrue)
+}
+
+type response struct {
+	id     string
+	output string
+	errMsg string
+}
+
+type fakeFacade struct {
+	changes   chan []string
+	completed chan response
+	requests  map[string]agentintrospection.Request
+}
+
+func (f *fakeFacade) WatchIntrospectionRequests(agent names.Tag) (watcher.StringsWatcher, error) {
+	if agent != names.NewMachineTag("0") {
+		return nil, errors.Errorf("unexpected agent %v", agent)
+	}
+	return watchertest.NewMockStringsWatcher(f.changes), nil
+}
+
+func (f *fakeFacade) IntrospectionRequest(id string) (agentintrospection.Request, error) {
+	req, ok := f.requests[id]
+	if !ok {
+		return agentintrospection.Request{}, errors.NotFoundf("introspection request %q", id)
+	}
+	return req, nil
+}
+
+func (f *fakeFacade) CompleteIntrospectionRequest(id string, output []byte, errMsg string) error {
+	f.completed <- response{id: id, output: string(output), errMsg: errMsg}
+	return nil
+}
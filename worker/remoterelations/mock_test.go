@@ -16,6 +16,7 @@ import (
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/worker/remoterelations"
@@ -250,6 +251,11 @@ func (m *mockRelationsFacade) SetRemoteApplicationStatus(applicationName string,
 	return nil
 }
 
+func (m *mockRelationsFacade) UpdateControllerForModel(controller crossmodel.ControllerInfo, modelUUID string) error {
+	m.stub.MethodCall(m, "UpdateControllerForModel", controller, modelUUID)
+	return m.stub.NextErr()
+}
+
 type mockRemoteRelationsFacade struct {
 	mu   sync.Mutex
 	stub *testing.Stub
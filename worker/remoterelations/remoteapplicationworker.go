@@ -10,7 +10,9 @@ import (
 	"gopkg.in/juju/worker.v1/catacomb"
 	"gopkg.in/macaroon.v2-unstable"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
@@ -108,6 +110,15 @@ func (w *remoteApplicationWorker) loop() (err error) {
 		}
 
 		w.remoteModelFacade, err = w.newRemoteModelRelationsFacadeFunc(apiInfo)
+		if redirectErr, ok := errors.Cause(err).(*api.RedirectError); ok {
+			// The offering model has been migrated to another controller.
+			// Record the new controller details so that the worker
+			// connects to it when restarted.
+			if err := w.updateControllerForRemoteModel(redirectErr); err != nil {
+				return errors.Trace(err)
+			}
+			return errors.Errorf("remote model %v has been migrated to controller %v", w.remoteModelUUID, redirectErr.ControllerTag.Id())
+		}
 		if err != nil {
 			return errors.Annotate(err, "opening facade to remote model")
 		}
@@ -177,6 +188,25 @@ func (w *remoteApplicationWorker) loop() (err error) {
 	}
 }
 
+func (w *remoteApplicationWorker) updateControllerForRemoteModel(redirectErr *api.RedirectError) error {
+	if redirectErr.ControllerTag.Id() == "" {
+		return errors.Errorf("remote model %v has been migrated to an unknown controller", w.remoteModelUUID)
+	}
+	var addrs []string
+	for _, hps := range redirectErr.Servers {
+		for _, hp := range hps {
+			addrs = append(addrs, hp.NetAddr())
+		}
+	}
+	logger.Infof("remote model %v has been migrated to controller %v", w.remoteModelUUID, redirectErr.ControllerTag.Id())
+	err := w.localModelFacade.UpdateControllerForModel(crossmodel.ControllerInfo{
+		ControllerTag: redirectErr.ControllerTag,
+		Addrs:         addrs,
+		CACert:        redirectErr.CACert,
+	}, w.remoteModelUUID)
+	return errors.Annotatef(err, "updating controller for remote model %v", w.remoteModelUUID)
+}
+
 func (w *remoteApplicationWorker) processRelationDying(key string, relations map[string]*relation) error {
 	logger.Debugf("relation %v dying", key)
 	relation, ok := relations[key]
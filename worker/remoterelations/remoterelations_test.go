@@ -22,9 +22,11 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/network"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/remoterelations"
 )
@@ -158,6 +160,43 @@ func (s *remoteRelationsSuite) TestRemoteApplicationRemoved(c *gc.C) {
 	s.waitForWorkerStubCalls(c, expected)
 }
 
+func (s *remoteRelationsSuite) TestRemoteModelMigrated(c *gc.C) {
+	s.relationsFacade.remoteApplications["db2"] = newMockRemoteApplication("db2", "db2url")
+	s.relationsFacade.controllerInfo["remote-model-uuid"] = &api.Info{
+		Addrs: []string{"1.2.3.4:1234"}, CACert: coretesting.CACert}
+	s.config.NewRemoteModelFacadeFunc = func(*api.Info) (remoterelations.RemoteModelRelationsFacadeCloser, error) {
+		return nil, &api.RedirectError{
+			Servers:       [][]network.HostPort{network.NewHostPorts(17070, "10.0.0.1", "10.0.0.2")},
+			CACert:        "target-cert",
+			ControllerTag: coretesting.ControllerTag,
+		}
+	}
+
+	w, err := remoterelations.New(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+	s.waitForWorkerStubCalls(c, []jujutesting.StubCall{
+		{"WatchRemoteApplications", nil},
+	})
+	s.stub.ResetCalls()
+
+	s.relationsFacade.remoteApplicationsWatcher.changes <- []string{"db2"}
+	expected := []jujutesting.StubCall{
+		{"RemoteApplications", []interface{}{[]string{"db2"}}},
+		{"WatchRemoteApplicationRelations", []interface{}{"db2"}},
+		{"ControllerAPIInfoForModel", []interface{}{"remote-model-uuid"}},
+		{"UpdateControllerForModel", []interface{}{
+			crossmodel.ControllerInfo{
+				ControllerTag: coretesting.ControllerTag,
+				Addrs:         []string{"10.0.0.1:17070", "10.0.0.2:17070"},
+				CACert:        "target-cert",
+			},
+			"remote-model-uuid",
+		}},
+	}
+	s.waitForWorkerStubCalls(c, expected)
+}
+
 func (s *remoteRelationsSuite) TestOfferStatusChange(c *gc.C) {
 	w := s.assertRemoteApplicationWorkers(c)
 	defer workertest.CleanKill(c, w)
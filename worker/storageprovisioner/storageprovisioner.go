@@ -55,6 +55,16 @@ type VolumeAccessor interface {
 	// provisioner is responsible for.
 	WatchVolumes(scope names.Tag) (watcher.StringsWatcher, error)
 
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, so that requests to
+	// resize them may be acted upon.
+	WatchVolumeResizes(scope names.Tag) (watcher.StringsWatcher, error)
+
+	// WatchVolumeSnapshots watches for changes to snapshots of volumes
+	// that this storage provisioner is responsible for, so that
+	// requests to take them may be acted upon.
+	WatchVolumeSnapshots(scope names.Tag) (watcher.StringsWatcher, error)
+
 	// WatchVolumeAttachments watches for changes to volume attachments
 	// that this storage provisioner is responsible for.
 	WatchVolumeAttachments(scope names.Tag) (watcher.MachineStorageIdsWatcher, error)
@@ -78,6 +88,14 @@ type VolumeAccessor interface {
 	// releasing the volumes with the specified tags.
 	RemoveVolumeParams([]names.VolumeTag) ([]params.RemoveVolumeParamsResult, error)
 
+	// VolumeResizeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
+	// VolumeSnapshotParams returns the parameters for taking the
+	// volume snapshots with the specified IDs.
+	VolumeSnapshotParams([]string) ([]params.VolumeSnapshotParamsResult, error)
+
 	// VolumeAttachmentParams returns the parameters for creating the
 	// volume attachments with the specified tags.
 	VolumeAttachmentParams([]params.MachineStorageId) ([]params.VolumeAttachmentParamsResult, error)
@@ -88,6 +106,10 @@ type VolumeAccessor interface {
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// SetVolumeSnapshotInfo records the details of newly taken
+	// volume snapshots.
+	SetVolumeSnapshotInfo([]params.VolumeSnapshotInfo) ([]params.ErrorResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -205,6 +227,8 @@ func (w *storageProvisioner) Wait() error {
 func (w *storageProvisioner) loop() error {
 	var (
 		volumesChanges               watcher.StringsChannel
+		volumeResizesChanges         watcher.StringsChannel
+		volumeSnapshotsChanges       watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
@@ -236,6 +260,34 @@ func (w *storageProvisioner) loop() error {
 			return errors.Trace(err)
 		}
 		volumesChanges = volumesWatcher.Changes()
+
+		// Controllers that do not support resizing
+		// volumes will not have the resize watcher.
+		volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes(w.config.Scope)
+		if params.IsCodeNotImplemented(err) {
+			logger.Debugf("volume resizing not supported by the controller")
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume resizes")
+		} else {
+			if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			volumeResizesChanges = volumeResizesWatcher.Changes()
+		}
+
+		// Likewise for controllers that do not support
+		// snapshotting volumes.
+		volumeSnapshotsWatcher, err := w.config.Volumes.WatchVolumeSnapshots(w.config.Scope)
+		if params.IsCodeNotImplemented(err) {
+			logger.Debugf("volume snapshots not supported by the controller")
+		} else if err != nil {
+			return errors.Annotate(err, "watching volume snapshots")
+		} else {
+			if err := w.catacomb.Add(volumeSnapshotsWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			volumeSnapshotsChanges = volumeSnapshotsWatcher.Changes()
+		}
 	}
 
 	filesystemsWatcher, err := w.config.Filesystems.WatchFilesystems(w.config.Scope)
@@ -307,6 +359,20 @@ func (w *storageProvisioner) loop() error {
 			if err := volumesChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
+		case changes, ok := <-volumeSnapshotsChanges:
+			if !ok {
+				return errors.New("volume snapshots watcher closed")
+			}
+			if err := volumeSnapshotsChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-volumeAttachmentsChanges:
 			if !ok {
 				return errors.New("volume attachments watcher closed")
@@ -352,6 +418,8 @@ func (w *storageProvisioner) loop() error {
 func processSchedule(ctx *context) error {
 	ready := ctx.schedule.Ready(ctx.config.Clock.Now())
 	createVolumeOps := make(map[names.VolumeTag]*createVolumeOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
+	createVolumeSnapshotOps := make(map[string]*createVolumeSnapshotOp)
 	removeVolumeOps := make(map[names.VolumeTag]*removeVolumeOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
@@ -365,6 +433,10 @@ func processSchedule(ctx *context) error {
 		switch op := op.(type) {
 		case *createVolumeOp:
 			createVolumeOps[key.(names.VolumeTag)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[key.(resizeVolumeKey).tag] = op
+		case *createVolumeSnapshotOp:
+			createVolumeSnapshotOps[key.(createVolumeSnapshotKey).id] = op
 		case *removeVolumeOp:
 			removeVolumeOps[key.(names.VolumeTag)] = op
 		case *attachVolumeOp:
@@ -391,6 +463,16 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "creating volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
+	if len(createVolumeSnapshotOps) > 0 {
+		if err := createVolumeSnapshots(ctx, createVolumeSnapshotOps); err != nil {
+			return errors.Annotate(err, "creating volume snapshots")
+		}
+	}
 	if len(detachVolumeOps) > 0 {
 		if err := detachVolumes(ctx, detachVolumeOps); err != nil {
 			return errors.Annotate(err, "detaching volumes")
@@ -1525,6 +1525,99 @@ func (s *storageProvisionerSuite) TestDetachFilesystems(c *gc.C) {
 	waitChannel(c, removed, "waiting for attachment to be removed")
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volume := volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volume.Info.Size = 1024
+	volumeAccessor.provisionedVolumes["volume-1"] = volume
+	volumeAccessor.provisionVolume(names.NewVolumeTag("2"))
+	volumeAccessor.pendingResizes["volume-1"] = 2048
+
+	resizeArgs := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizeArgs <- args
+		return []storage.ResizeVolumesResult{{
+			VolumeInfo: &storage.VolumeInfo{VolumeId: args[0].VolumeId, Size: 2048},
+		}}, nil
+	}
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		c.Assert(volumes, jc.DeepEquals, []params.Volume{{
+			VolumeTag: "volume-1",
+			Info: params.VolumeInfo{
+				VolumeId: "vol-1",
+				Size:     2048,
+			},
+		}})
+		return nil, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.volumesWatcher.changes <- []string{"1", "2"}
+	// Volume 2 has no resize pending, so is ignored.
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2"}
+	resized := waitChannel(c, resizeArgs, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+		Provider: "dummy",
+	}})
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "resizing"},
+		{Tag: "volume-1", Status: "detached", Info: "resized to 2048MiB"},
+	})
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volume := volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volume.Info.Size = 1024
+	volumeAccessor.provisionedVolumes["volume-1"] = volume
+	volumeAccessor.pendingSnapshots["1@0"] = names.NewVolumeTag("1")
+
+	snapshotArgs := make(chan interface{}, 1)
+	s.provider.createVolumeSnapshotsFunc = func(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+		snapshotArgs <- args
+		return []storage.CreateVolumeSnapshotsResult{{
+			VolumeSnapshotInfo: &storage.VolumeSnapshotInfo{SnapshotId: "snap-1", Size: 1024},
+		}}, nil
+	}
+	snapshotInfoSet := make(chan interface{})
+	volumeAccessor.setVolumeSnapshotInfo = func(snapshots []params.VolumeSnapshotInfo) ([]params.ErrorResult, error) {
+		defer close(snapshotInfoSet)
+		c.Assert(snapshots, jc.DeepEquals, []params.VolumeSnapshotInfo{{
+			Id:         "1@0",
+			SnapshotId: "snap-1",
+			Size:       1024,
+		}})
+		return nil, nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Snapshot 1@1 has already been taken, so is ignored.
+	volumeAccessor.snapshotsWatcher.changes <- []string{"1@0", "1@1"}
+	snapshotted := waitChannel(c, snapshotArgs, "waiting for volume snapshot to be taken")
+	c.Assert(snapshotted, jc.DeepEquals, []storage.VolumeSnapshotParams{{
+		Id:       "1@0",
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     1024,
+		Provider: "dummy",
+	}})
+	waitChannel(c, snapshotInfoSet, "waiting for volume snapshot info to be set")
+}
+
 func (s *storageProvisionerSuite) TestDestroyVolumes(c *gc.C) {
 	unprovisionedVolume := names.NewVolumeTag("0")
 	provisionedDestroyVolume := names.NewVolumeTag("1")
@@ -54,6 +54,85 @@ func volumesChanged(ctx *context, changes []string) error {
 	return nil
 }
 
+// volumeResizesChanged is called when the volumes with the provided
+// IDs have been seen to have changed, and may have a resize pending.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	paramsResults, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// There is no resize pending.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting resize parameters for volume %q", tags[i].Id(),
+			)
+		}
+		args := storage.VolumeResizeParams{
+			Tag:      tags[i],
+			VolumeId: result.Result.VolumeId,
+			Size:     result.Result.Size,
+			Provider: storage.ProviderType(result.Result.Provider),
+		}
+		// Replace any resize that is already scheduled,
+		// as the requested size may have changed.
+		op := &resizeVolumeOp{args: args}
+		ctx.schedule.Remove(op.key())
+		scheduleOperations(ctx, op)
+	}
+	return nil
+}
+
+// volumeSnapshotsChanged is called when the volume snapshots with the
+// provided IDs have been seen to have changed, and may be pending.
+func volumeSnapshotsChanged(ctx *context, changes []string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	paramsResults, err := ctx.config.Volumes.VolumeSnapshotParams(changes)
+	if err != nil {
+		return errors.Annotate(err, "getting volume snapshot params")
+	}
+	for i, result := range paramsResults {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The snapshot has already been taken.
+				continue
+			}
+			return errors.Annotatef(
+				result.Error, "getting parameters for volume snapshot %q", changes[i],
+			)
+		}
+		volumeTag, err := names.ParseVolumeTag(result.Result.VolumeTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		args := storage.VolumeSnapshotParams{
+			Id:       changes[i],
+			Tag:      volumeTag,
+			VolumeId: result.Result.VolumeId,
+			Size:     result.Result.Size,
+			Provider: storage.ProviderType(result.Result.Provider),
+		}
+		// The snapshot may already be scheduled, if
+		// it has changed since it was first seen.
+		op := &createVolumeSnapshotOp{args: args}
+		ctx.schedule.Remove(op.key())
+		scheduleOperations(ctx, op)
+	}
+	return nil
+}
+
 // volumeAttachmentsChanged is called when the lifecycle states of the volume
 // attachments with the provided IDs have been seen to have changed.
 func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageId) error {
@@ -512,6 +591,7 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 		in.Attributes,
 		in.Tags,
 		attachment,
+		in.SnapshotId,
 	}, nil
 }
 
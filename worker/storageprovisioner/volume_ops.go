@@ -4,6 +4,8 @@
 package storageprovisioner
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
@@ -271,6 +273,197 @@ func removeVolumes(ctx *context, ops map[names.VolumeTag]*removeVolumeOp) error
 	return nil
 }
 
+// resizeVolumes grows provisioned volumes to the sizes specified.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	volumeParams := make([]storage.VolumeParams, 0, len(ops))
+	for _, op := range ops {
+		volumeParams = append(volumeParams, storage.VolumeParams{
+			Tag:      op.args.Tag,
+			Provider: op.args.Provider,
+		})
+	}
+	paramsBySource, volumeSources, err := volumeParamsBySource(
+		ctx.config.StorageDir, volumeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var reschedule []scheduleOp
+	var volumes []storage.Volume
+	var statuses []params.EntityStatusArgs
+	for sourceName, volumeParams := range paramsBySource {
+		resizer, ok := volumeSources[sourceName].(storage.VolumeResizer)
+		if !ok {
+			for _, args := range volumeParams {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    args.Tag.String(),
+					Status: volumeAttachedStatus(ctx, args.Tag).String(),
+					Info:   fmt.Sprintf("resizing volumes is not supported by %q", sourceName),
+				})
+			}
+			continue
+		}
+		resizeParams := make([]storage.VolumeResizeParams, 0, len(volumeParams))
+		for _, args := range volumeParams {
+			if _, ok := ctx.volumes[args.Tag]; !ok {
+				// The volume's info has not been
+				// observed yet; try again later.
+				reschedule = append(reschedule, ops[args.Tag])
+				continue
+			}
+			resizeParams = append(resizeParams, ops[args.Tag].args)
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    args.Tag.String(),
+				Status: status.Resizing.String(),
+			})
+		}
+		if len(resizeParams) == 0 {
+			continue
+		}
+		logger.Debugf("resizing volumes: %v", resizeParams)
+		setStatus(ctx, statuses)
+		statuses = nil
+		results, err := resizer.ResizeVolumes(ctx.config.CloudCallContext, resizeParams)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := resizeParams[i].Tag
+			if result.Error != nil {
+				// Reschedule the resize, leaving the
+				// status as "resizing" to indicate
+				// that we will retry.
+				reschedule = append(reschedule, ops[tag])
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    tag.String(),
+					Status: status.Resizing.String(),
+					Info:   result.Error.Error(),
+				})
+				logger.Debugf("failed to resize %s: %v", names.ReadableString(tag), result.Error)
+				continue
+			}
+			volume := ctx.volumes[tag]
+			volume.Size = result.VolumeInfo.Size
+			volumes = append(volumes, volume)
+			// Report the new size, so that the charm or
+			// operator knows to grow any filesystem on it.
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: volumeAttachedStatus(ctx, tag).String(),
+				Info:   fmt.Sprintf("resized to %dMiB", volume.Size),
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(volumes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeInfo(volumesFromStorage(volumes))
+	if err != nil {
+		return errors.Annotate(err, "publishing volumes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume %s to state: %v",
+				volumes[i].Tag.Id(),
+				result.Error,
+			)
+			continue
+		}
+		updateVolume(ctx, volumes[i])
+	}
+	return nil
+}
+
+// createVolumeSnapshots takes snapshots of provisioned volumes.
+func createVolumeSnapshots(ctx *context, ops map[string]*createVolumeSnapshotOp) error {
+	volumeParams := make([]storage.VolumeParams, 0, len(ops))
+	for _, op := range ops {
+		volumeParams = append(volumeParams, storage.VolumeParams{
+			Tag:      op.args.Tag,
+			Provider: op.args.Provider,
+		})
+	}
+	_, volumeSources, err := volumeParamsBySource(
+		ctx.config.StorageDir, volumeParams, ctx.config.Registry,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	snapshotParamsBySource := make(map[string][]storage.VolumeSnapshotParams)
+	for _, op := range ops {
+		sourceName := string(op.args.Provider)
+		if volumeSources[sourceName] == nil {
+			continue
+		}
+		snapshotParamsBySource[sourceName] = append(snapshotParamsBySource[sourceName], op.args)
+	}
+	var reschedule []scheduleOp
+	var snapshots []params.VolumeSnapshotInfo
+	for sourceName, snapshotParams := range snapshotParamsBySource {
+		snapshotter, ok := volumeSources[sourceName].(storage.VolumeSnapshotter)
+		if !ok {
+			for _, args := range snapshotParams {
+				logger.Warningf(
+					"cannot snapshot %s: taking volume snapshots is not supported by %q",
+					names.ReadableString(args.Tag), sourceName,
+				)
+			}
+			continue
+		}
+		logger.Debugf("creating volume snapshots: %v", snapshotParams)
+		results, err := snapshotter.CreateVolumeSnapshots(ctx.config.CloudCallContext, snapshotParams)
+		if err != nil {
+			return errors.Annotatef(err, "creating volume snapshots from source %q", sourceName)
+		}
+		for i, result := range results {
+			id := snapshotParams[i].Id
+			if result.Error != nil {
+				// Reschedule the snapshot.
+				reschedule = append(reschedule, ops[id])
+				logger.Debugf("failed to create volume snapshot %q: %v", id, result.Error)
+				continue
+			}
+			snapshots = append(snapshots, params.VolumeSnapshotInfo{
+				Id:         id,
+				SnapshotId: result.VolumeSnapshotInfo.SnapshotId,
+				Size:       result.VolumeSnapshotInfo.Size,
+			})
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	if len(snapshots) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSnapshotInfo(snapshots)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume snapshots to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing volume snapshot %q to state: %v",
+				snapshots[i].Id,
+				result.Error,
+			)
+		}
+	}
+	return nil
+}
+
+// volumeAttachedStatus returns the status that a provisioned volume
+// should have when it is not otherwise being operated upon.
+func volumeAttachedStatus(ctx *context, tag names.VolumeTag) status.Status {
+	for id := range ctx.volumeAttachments {
+		if id.AttachmentTag == tag.String() {
+			return status.Attached
+		}
+	}
+	return status.Detached
+}
+
 func partitionRemoveVolumeParams(removeTags []names.VolumeTag, removeParams []params.RemoveVolumeParams) (
 	destroyTags []names.VolumeTag, destroyIds []string,
 	releaseTags []names.VolumeTag, releaseIds []string,
@@ -408,7 +601,12 @@ func validateVolumeParams(
 	valid := make([]storage.VolumeParams, 0, len(volumeParams))
 	results := make([]error, len(volumeParams))
 	for i, params := range volumeParams {
-		err := volumeSource.ValidateVolumeParams(params)
+		var err error
+		if _, ok := volumeSource.(storage.VolumeSnapshotter); params.SnapshotId != "" && !ok {
+			err = errors.NotSupportedf("restoring volumes from snapshots with %q provider", params.Provider)
+		} else {
+			err = volumeSource.ValidateVolumeParams(params)
+		}
 		if err == nil {
 			valid = append(valid, params)
 		}
@@ -499,6 +697,36 @@ func (op *removeVolumeOp) key() interface{} {
 	return op.tag
 }
 
+type resizeVolumeOp struct {
+	exponentialBackoff
+	args storage.VolumeResizeParams
+}
+
+// resizeVolumeKey is the schedule key for resizing a volume, which
+// is distinct from the key for the volume's other operations.
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey{op.args.Tag}
+}
+
+type createVolumeSnapshotOp struct {
+	exponentialBackoff
+	args storage.VolumeSnapshotParams
+}
+
+// createVolumeSnapshotKey is the schedule key for taking a
+// volume snapshot.
+type createVolumeSnapshotKey struct {
+	id string
+}
+
+func (op *createVolumeSnapshotOp) key() interface{} {
+	return createVolumeSnapshotKey{op.args.Id}
+}
+
 type attachVolumeOp struct {
 	exponentialBackoff
 	args storage.VolumeAttachmentParams
@@ -50,6 +50,8 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.StorageResized}, `invalid storage ID ""`},
+	{hook.Info{Kind: hook.StorageResized, StorageId: "data/0", StorageSize: 2048}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
@@ -63,3 +65,10 @@ func (s *InfoSuite) TestValidate(c *gc.C) {
 		}
 	}
 }
+
+func (s *InfoSuite) TestIsStorage(c *gc.C) {
+	c.Assert(hook.IsStorage(hooks.StorageAttached), jc.IsTrue)
+	c.Assert(hook.IsStorage(hooks.StorageDetaching), jc.IsTrue)
+	c.Assert(hook.IsStorage(hook.StorageResized), jc.IsTrue)
+	c.Assert(hook.IsStorage(hooks.ConfigChanged), jc.IsFalse)
+}
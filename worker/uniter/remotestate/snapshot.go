@@ -93,4 +93,8 @@ type StorageSnapshot struct {
 	Life     params.Life
 	Attached bool
 	Location string
+
+	// Size is the size, in MiB, of the volume backing the storage,
+	// or zero if it isn't backed by a volume.
+	Size uint64
 }
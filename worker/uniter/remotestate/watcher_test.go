@@ -425,6 +425,48 @@ func (s *WatcherSuite) TestStorageChanged(c *gc.C) {
 	})
 }
 
+func (s *WatcherSuite) TestStorageResized(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+
+	storageTag0 := names.NewStorageTag("blob/0")
+	storageAttachmentId0 := params.StorageAttachmentId{
+		UnitTag:    s.st.unit.tag.String(),
+		StorageTag: storageTag0.String(),
+	}
+	storageTag0Watcher := newMockNotifyWatcher()
+	s.st.storageAttachmentWatchers[storageTag0] = storageTag0Watcher
+	s.st.storageAttachment[storageAttachmentId0] = params.StorageAttachment{
+		UnitTag:    storageAttachmentId0.UnitTag,
+		StorageTag: storageAttachmentId0.StorageTag,
+		Life:       params.Alive,
+		Kind:       params.StorageKindBlock,
+		Location:   "malta",
+		Size:       1024,
+	}
+
+	s.st.unit.storageWatcher.changes <- []string{"blob/0"}
+	storageTag0Watcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Storage[storageTag0].Size, gc.Equals, uint64(1024))
+
+	// The watcher fires when the backing volume changes.
+	attachment := s.st.storageAttachment[storageAttachmentId0]
+	attachment.Size = 2048
+	s.st.storageAttachment[storageAttachmentId0] = attachment
+	storageTag0Watcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Storage, jc.DeepEquals, map[names.StorageTag]remotestate.StorageSnapshot{
+		storageTag0: {
+			Life:     params.Alive,
+			Kind:     params.StorageKindBlock,
+			Attached: true,
+			Location: "malta",
+			Size:     2048,
+		},
+	})
+}
+
 func (s *WatcherSuite) TestStorageUnattachedChanged(c *gc.C) {
 	s.signalAll()
 	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
@@ -0,0 +1,127 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"strconv"
+
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+)
+
+// Report is part of the dependency.Reporter interface. It reports the
+// persisted operation state of the unit and, once the uniter is
+// watching it, a snapshot of the unit's remote state.
+func (u *Uniter) Report() map[string]interface{} {
+	out := make(map[string]interface{})
+
+	// The operation state is read back from disk, as the executor
+	// is not safe for concurrent use.
+	opState, err := operation.NewStateFile(u.paths.State.OperationsFile).Read()
+	switch err {
+	case nil:
+		out["operation"] = operationStateReport(*opState)
+	case operation.ErrNoStateFile:
+	default:
+		out[dependency.KeyError] = err.Error()
+	}
+
+	u.watcherMu.Lock()
+	watcher := u.remoteStateWatcher
+	u.watcherMu.Unlock()
+	if watcher != nil {
+		out["remote-state"] = remoteStateReport(watcher.Snapshot())
+	}
+	return out
+}
+
+func operationStateReport(st operation.State) map[string]interface{} {
+	out := map[string]interface{}{
+		"kind":       string(st.Kind),
+		"step":       string(st.Step),
+		"installed":  st.Installed,
+		"started":    st.Started,
+		"stopped":    st.Stopped,
+		"leader":     st.Leader,
+		"status-set": st.StatusSet,
+	}
+	if st.Hook != nil {
+		hook := map[string]interface{}{
+			"kind": string(st.Hook.Kind),
+		}
+		if st.Hook.Kind.IsRelation() {
+			hook["relation-id"] = st.Hook.RelationId
+		}
+		if st.Hook.RemoteUnit != "" {
+			hook["remote-unit"] = st.Hook.RemoteUnit
+		}
+		if st.Hook.StorageId != "" {
+			hook["storage-id"] = st.Hook.StorageId
+		}
+		out["hook"] = hook
+	}
+	if st.ActionId != nil {
+		out["action-id"] = *st.ActionId
+	}
+	if st.CharmURL != nil {
+		out["charm"] = st.CharmURL.String()
+	}
+	return out
+}
+
+func remoteStateReport(snapshot remotestate.Snapshot) map[string]interface{} {
+	out := map[string]interface{}{
+		"life":                    string(snapshot.Life),
+		"charm-modified-version":  snapshot.CharmModifiedVersion,
+		"force-charm-upgrade":     snapshot.ForceCharmUpgrade,
+		"resolved-mode":           string(snapshot.ResolvedMode),
+		"retry-hook-version":      snapshot.RetryHookVersion,
+		"config-version":          snapshot.ConfigVersion,
+		"leader":                  snapshot.Leader,
+		"leader-settings-version": snapshot.LeaderSettingsVersion,
+		"update-status-version":   snapshot.UpdateStatusVersion,
+		"series":                  snapshot.Series,
+		"upgrade-series-status": map[string]interface{}{
+			"prepare":  string(snapshot.UpgradeSeriesPrepareStatus),
+			"complete": string(snapshot.UpgradeSeriesCompleteStatus),
+		},
+	}
+	if snapshot.CharmURL != nil {
+		out["charm"] = snapshot.CharmURL.String()
+	}
+	if len(snapshot.Actions) > 0 {
+		out["actions"] = snapshot.Actions
+	}
+	if len(snapshot.Commands) > 0 {
+		out["commands"] = snapshot.Commands
+	}
+
+	relations := make(map[string]interface{})
+	for id, rel := range snapshot.Relations {
+		members := make(map[string]interface{})
+		for unit, version := range rel.Members {
+			members[unit] = version
+		}
+		relations[strconv.Itoa(id)] = map[string]interface{}{
+			"life":      string(rel.Life),
+			"suspended": rel.Suspended,
+			"members":   members,
+		}
+	}
+	out["relations"] = relations
+
+	storage := make(map[string]interface{})
+	for tag, st := range snapshot.Storage {
+		storage[tag.Id()] = map[string]interface{}{
+			"kind":     st.Kind.String(),
+			"life":     string(st.Life),
+			"attached": st.Attached,
+			"location": st.Location,
+		}
+	}
+	out["storage"] = storage
+	return out
+}
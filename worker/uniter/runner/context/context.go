@@ -220,6 +220,15 @@ type HookContext struct {
 	// hook run, so the actual add will happen in a flush.
 	storageAddConstraints map[string][]params.StorageConstraints
 
+	// charmState is the cached value of the unit's charm state. It
+	// is read lazily from the controller, and any changes made during
+	// the hook are written back in a flush.
+	charmState map[string]string
+
+	// charmStateDirty is true if the charm state has been changed
+	// during the hook.
+	charmStateDirty bool
+
 	// clock is used for any time operations.
 	clock Clock
 
@@ -473,6 +482,24 @@ func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error
 	)
 }
 
+// OpenEndpointPorts implements jujuc.ContextNetworking.
+func (ctx *HookContext) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return tryOpenEndpointPorts(
+		endpoint, protocol, fromPort, toPort,
+		ctx.unit.Tag(),
+		ctx.machinePorts, ctx.pendingPorts,
+	)
+}
+
+// CloseEndpointPorts implements jujuc.ContextNetworking.
+func (ctx *HookContext) CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return tryCloseEndpointPorts(
+		endpoint, protocol, fromPort, toPort,
+		ctx.unit.Tag(),
+		ctx.machinePorts, ctx.pendingPorts,
+	)
+}
+
 func (ctx *HookContext) OpenedPorts() []network.PortRange {
 	var unitRanges []network.PortRange
 	for portRange, relUnit := range ctx.machinePorts {
@@ -568,6 +595,14 @@ func (ctx *HookContext) SetActionFailed() error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the Action.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.LogActionMessage(ctx.actionData.Tag, message)
+}
+
 // UpdateActionResults inserts new values for use with action-set and
 // action-fail.  The results struct will be delivered to the controller
 // upon completion of the Action.  It returns an error if not called on an
@@ -717,6 +752,59 @@ func (ctx *HookContext) Prepare() error {
 	return nil
 }
 
+// CharmState implements jujuc.ContextCharmState.
+func (ctx *HookContext) CharmState() (map[string]string, error) {
+	if err := ctx.ensureCharmState(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string, len(ctx.charmState))
+	for k, v := range ctx.charmState {
+		result[k] = v
+	}
+	return result, nil
+}
+
+// SetCharmStateValue implements jujuc.ContextCharmState.
+func (ctx *HookContext) SetCharmStateValue(key, value string) error {
+	if err := ctx.ensureCharmState(); err != nil {
+		return errors.Trace(err)
+	}
+	if current, ok := ctx.charmState[key]; ok && current == value {
+		return nil
+	}
+	ctx.charmState[key] = value
+	ctx.charmStateDirty = true
+	return nil
+}
+
+// DeleteCharmStateValue implements jujuc.ContextCharmState.
+func (ctx *HookContext) DeleteCharmStateValue(key string) error {
+	if err := ctx.ensureCharmState(); err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := ctx.charmState[key]; !ok {
+		return nil
+	}
+	delete(ctx.charmState, key)
+	ctx.charmStateDirty = true
+	return nil
+}
+
+func (ctx *HookContext) ensureCharmState() error {
+	if ctx.charmState != nil {
+		return nil
+	}
+	charmState, err := ctx.unit.CharmState()
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm state")
+	}
+	if charmState == nil {
+		charmState = make(map[string]string)
+	}
+	ctx.charmState = charmState
+	return nil
+}
+
 // Flush implements the Context interface.
 func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 	writeChanges := ctxErr == nil
@@ -756,14 +844,31 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		if writeChanges {
 			var e error
 			var op string
-			if rangeInfo.ShouldOpen {
+			switch {
+			case rangeInfo.ShouldOpen && rangeKey.Endpoint != "":
+				e = ctx.unit.OpenEndpointPorts(
+					rangeKey.Endpoint,
+					rangeKey.Ports.Protocol,
+					rangeKey.Ports.FromPort,
+					rangeKey.Ports.ToPort,
+				)
+				op = "open"
+			case rangeInfo.ShouldOpen:
 				e = ctx.unit.OpenPorts(
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
 				)
 				op = "open"
-			} else {
+			case rangeKey.Endpoint != "":
+				e = ctx.unit.CloseEndpointPorts(
+					rangeKey.Endpoint,
+					rangeKey.Ports.Protocol,
+					rangeKey.Ports.FromPort,
+					rangeKey.Ports.ToPort,
+				)
+				op = "close"
+			default:
 				e = ctx.unit.ClosePorts(
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
@@ -793,6 +898,17 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 		}
 	}
 
+	if ctx.charmStateDirty && writeChanges {
+		err := ctx.unit.SetCharmState(ctx.charmState)
+		if err != nil {
+			err = errors.Annotatef(err, "cannot set charm state")
+			logger.Errorf("%v", err)
+			if ctxErr == nil {
+				ctxErr = err
+			}
+		}
+	}
+
 	// TODO (tasdomas) 2014 09 03: context finalization needs to modified to apply all
 	//                             changes in one api call to minimize the risk
 	//                             of partial failures.
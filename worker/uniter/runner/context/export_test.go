@@ -19,6 +19,9 @@ var (
 	ValidatePortRange = validatePortRange
 	TryOpenPorts      = tryOpenPorts
 	TryClosePorts     = tryClosePorts
+
+	TryOpenEndpointPorts  = tryOpenEndpointPorts
+	TryCloseEndpointPorts = tryCloseEndpointPorts
 )
 
 func NewHookContext(
@@ -21,11 +21,13 @@ type PortRangeInfo struct {
 	RelationTag names.RelationTag
 }
 
-// PortRange contains a port range and a relation id. Used as key to
-// pendingRelations and is only exported for testing.
+// PortRange contains a port range, a relation id and the endpoint the
+// range is opened or closed for. Used as key to pendingRelations and is
+// only exported for testing.
 type PortRange struct {
 	Ports      network.PortRange
 	RelationId int
+	Endpoint   string
 }
 
 func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange, error) {
@@ -47,6 +49,18 @@ func tryOpenPorts(
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
 	pendingPorts map[PortRange]PortRangeInfo,
+) error {
+	return tryOpenEndpointPorts("", protocol, fromPort, toPort, unitTag, machinePorts, pendingPorts)
+}
+
+// tryOpenEndpointPorts records the port range as pending to be opened
+// for the endpoint, or for all endpoints if the endpoint is empty.
+func tryOpenEndpointPorts(
+	endpoint, protocol string,
+	fromPort, toPort int,
+	unitTag names.UnitTag,
+	machinePorts map[network.PortRange]params.RelationUnit,
+	pendingPorts map[PortRange]PortRangeInfo,
 ) error {
 	// TODO(dimitern) Once port ranges are linked to relations in
 	// addition to networks, refactor this functions and test it
@@ -61,6 +75,7 @@ func tryOpenPorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
@@ -87,6 +102,12 @@ func tryOpenPorts(
 		}
 		if newRange.ConflictsWith(portRange) {
 			if portRange == newRange && relUnitTag == unitTag {
+				if endpoint != "" {
+					// The machine ports don't say which endpoints
+					// the range is open for, so leave it to the
+					// controller to ignore it if it already is.
+					continue
+				}
 				// The same unit trying to open the same range is just
 				// ignored.
 				return nil
@@ -99,6 +120,10 @@ func tryOpenPorts(
 	}
 	// Ensure other pending port ranges do not conflict with this one.
 	for rangeKey, rangeInfo := range pendingPorts {
+		if rangeKey.Ports == newRange {
+			// The same range may be opened for several endpoints.
+			continue
+		}
 		if newRange.ConflictsWith(rangeKey.Ports) && rangeInfo.ShouldOpen {
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with %v requested earlier",
@@ -119,6 +144,18 @@ func tryClosePorts(
 	unitTag names.UnitTag,
 	machinePorts map[network.PortRange]params.RelationUnit,
 	pendingPorts map[PortRange]PortRangeInfo,
+) error {
+	return tryCloseEndpointPorts("", protocol, fromPort, toPort, unitTag, machinePorts, pendingPorts)
+}
+
+// tryCloseEndpointPorts records the port range as pending to be closed
+// for the endpoint, or for all endpoints if the endpoint is empty.
+func tryCloseEndpointPorts(
+	endpoint, protocol string,
+	fromPort, toPort int,
+	unitTag names.UnitTag,
+	machinePorts map[network.PortRange]params.RelationUnit,
+	pendingPorts map[PortRange]PortRangeInfo,
 ) error {
 	// TODO(dimitern) Once port ranges are linked to relations in
 	// addition to networks, refactor this functions and test it
@@ -133,6 +170,7 @@ func tryClosePorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
@@ -271,3 +271,42 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 		}
 	}
 }
+
+func (s *PortsSuite) TestTryOpenEndpointPorts(c *gc.C) {
+	pendingPorts := makePendingPorts("tcp", 10, 20, true)
+	err := context.TryOpenEndpointPorts(
+		"website", "tcp", 10, 20,
+		names.NewUnitTag("u/0"),
+		makeMachinePorts("u/0", "tcp", 10, 20),
+		pendingPorts,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	websiteKey := context.PortRange{
+		Ports:      network.PortRange{FromPort: 10, ToPort: 20, Protocol: "tcp"},
+		RelationId: -1,
+		Endpoint:   "website",
+	}
+	expectPending := makePendingPorts("tcp", 10, 20, true)
+	expectPending[websiteKey] = context.PortRangeInfo{ShouldOpen: true}
+	c.Assert(pendingPorts, jc.DeepEquals, expectPending)
+
+	err = context.TryCloseEndpointPorts(
+		"website", "tcp", 10, 20,
+		names.NewUnitTag("u/0"),
+		makeMachinePorts("u/0", "tcp", 10, 20),
+		pendingPorts,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pendingPorts, jc.DeepEquals, makePendingPorts("tcp", 10, 20, true))
+}
+
+func (s *PortsSuite) TestTryOpenEndpointPortsConflicts(c *gc.C) {
+	err := context.TryOpenEndpointPorts(
+		"website", "tcp", 10, 30,
+		names.NewUnitTag("u/0"),
+		nil,
+		makePendingPorts("tcp", 10, 20, true),
+	)
+	c.Assert(err, gc.ErrorMatches, `cannot open 10-30/tcp \(unit "u/0"\): conflicts with 10-20/tcp requested earlier`)
+}
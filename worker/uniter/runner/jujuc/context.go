@@ -36,6 +36,7 @@ type HookContext interface {
 	ContextInstance
 	ContextNetworking
 	ContextLeadership
+	ContextCharmState
 	ContextMetrics
 	ContextStorage
 	ContextComponents
@@ -85,6 +86,9 @@ type actionHookContext interface {
 
 	// SetActionFailed sets a failure state for the Action.
 	SetActionFailed() error
+
+	// LogActionMessage records a progress message for the Action.
+	LogActionMessage(string) error
 }
 
 // ContextUnit is the part of a hook context related to the unit.
@@ -154,6 +158,15 @@ type ContextNetworking interface {
 	// separately by a co- located unit).
 	ClosePorts(protocol string, fromPort, toPort int) error
 
+	// OpenEndpointPorts marks the supplied port range for opening for
+	// the named endpoint only, when the executing unit's application
+	// is exposed.
+	OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error
+
+	// CloseEndpointPorts ensures the supplied port range is no longer
+	// opened for the named endpoint.
+	CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error
+
 	// OpenedPorts returns all port ranges currently opened by this
 	// unit on its assigned machine. The result is sorted first by
 	// protocol, then by number.
@@ -180,6 +193,24 @@ type ContextLeadership interface {
 	WriteLeaderSettings(map[string]string) error
 }
 
+// ContextCharmState is the part of a hook context related to the
+// charm state stored in the controller on behalf of the unit.
+type ContextCharmState interface {
+	// CharmState returns the unit's charm state, including any changes
+	// made during the current hook.
+	CharmState() (map[string]string, error)
+
+	// SetCharmStateValue sets the value of a key in the unit's charm
+	// state. The change is only written to the controller if the hook
+	// completes successfully.
+	SetCharmStateValue(key, value string) error
+
+	// DeleteCharmStateValue removes a key from the unit's charm state.
+	// The change is only written to the controller if the hook completes
+	// successfully.
+	DeleteCharmStateValue(key string) error
+}
+
 // ContextMetrics is the part of a hook context related to metrics.
 type ContextMetrics interface {
 	// AddMetric records a metric to return after hook execution.
@@ -16,6 +16,7 @@ type ContextInfo struct {
 	Instance
 	NetworkInterface
 	Leadership
+	UnitCharmState
 	Metrics
 	Storage
 	Components
@@ -56,6 +57,7 @@ type Context struct {
 	ContextInstance
 	ContextNetworking
 	ContextLeader
+	ContextCharmState
 	ContextMetrics
 	ContextStorage
 	ContextComponents
@@ -78,6 +80,8 @@ func NewContext(stub *testing.Stub, info *ContextInfo) *Context {
 	ctx.ContextNetworking.info = &info.NetworkInterface
 	ctx.ContextLeader.stub = stub
 	ctx.ContextLeader.info = &info.Leadership
+	ctx.ContextCharmState.stub = stub
+	ctx.ContextCharmState.info = &info.UnitCharmState
 	ctx.ContextMetrics.stub = stub
 	ctx.ContextMetrics.info = &info.Metrics
 	ctx.ContextStorage.stub = stub
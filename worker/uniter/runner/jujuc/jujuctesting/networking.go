@@ -95,6 +95,28 @@ func (c *ContextNetworking) ClosePorts(protocol string, from, to int) error {
 	return nil
 }
 
+// OpenEndpointPorts implements jujuc.ContextNetworking.
+func (c *ContextNetworking) OpenEndpointPorts(endpoint, protocol string, from, to int) error {
+	c.stub.AddCall("OpenEndpointPorts", endpoint, protocol, from, to)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.AddPorts(protocol, from, to)
+	return nil
+}
+
+// CloseEndpointPorts implements jujuc.ContextNetworking.
+func (c *ContextNetworking) CloseEndpointPorts(endpoint, protocol string, from, to int) error {
+	c.stub.AddCall("CloseEndpointPorts", endpoint, protocol, from, to)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.RemovePorts(protocol, from, to)
+	return nil
+}
+
 // OpenedPorts implements jujuc.ContextNetworking.
 func (c *ContextNetworking) OpenedPorts() []network.PortRange {
 	c.stub.AddCall("OpenedPorts")
@@ -123,12 +123,14 @@ func parseArguments(args []string) (portRange, error) {
 // portCommand implements the open-port and close-port commands.
 type portCommand struct {
 	cmd.CommandBase
-	info       *cmd.Info
-	action     func(*portCommand) error
-	Protocol   string
-	FromPort   int
-	ToPort     int
-	formatFlag string // deprecated
+	info          *cmd.Info
+	action        func(*portCommand) error
+	Protocol      string
+	FromPort      int
+	ToPort        int
+	Endpoints     []string
+	endpointsFlag string
+	formatFlag    string // deprecated
 }
 
 func (c *portCommand) Info() *cmd.Info {
@@ -137,6 +139,7 @@ func (c *portCommand) Info() *cmd.Info {
 
 func (c *portCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	f.StringVar(&c.endpointsFlag, "endpoints", "", "a comma-delimited list of application endpoints to target with this operation")
 }
 
 func (c *portCommand) Init(args []string) error {
@@ -152,6 +155,12 @@ func (c *portCommand) Init(args []string) error {
 	c.FromPort = portRange.fromPort
 	c.ToPort = portRange.toPort
 	c.Protocol = portRange.protocol
+	c.Endpoints = nil
+	for _, endpoint := range strings.Split(c.endpointsFlag, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			c.Endpoints = append(c.Endpoints, endpoint)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
@@ -166,14 +175,27 @@ var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the application is exposed.",
+	Doc: `
+The port range will only be open while the application is exposed.
+
+By default the port range is opened for all of the application's
+endpoints. With --endpoints it is only opened for the listed endpoints,
+and may then only be accessed from where those endpoints are exposed to.`[1:],
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: openPortInfo,
 		action: func(c *portCommand) error {
-			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			if len(c.Endpoints) == 0 {
+				return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			for _, endpoint := range c.Endpoints {
+				if err := ctx.OpenEndpointPorts(endpoint, c.Protocol, c.FromPort, c.ToPort); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
 		},
 	}, nil
 }
@@ -182,13 +204,23 @@ var closePortInfo = &cmd.Info{
 	Name:    "close-port",
 	Args:    portFormat,
 	Purpose: "ensure a port or range is always closed",
+	Doc: `
+With --endpoints the port range is only closed for the listed endpoints.`[1:],
 }
 
 func NewClosePortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: closePortInfo,
 		action: func(c *portCommand) error {
-			return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			if len(c.Endpoints) == 0 {
+				return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			for _, endpoint := range c.Endpoints {
+				if err := ctx.CloseEndpointPorts(endpoint, c.Protocol, c.FromPort, c.ToPort); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			return nil
 		},
 	}, nil
 }
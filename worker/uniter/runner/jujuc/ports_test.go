@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -91,6 +92,31 @@ func (s *PortsSuite) TestOpenClose(c *gc.C) {
 	}
 }
 
+func (s *PortsSuite) TestOpenCloseEndpoints(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	for _, args := range [][]string{
+		{"open-port", "--endpoints", "website, admin", "80"},
+		{"close-port", "--endpoints", "admin", "80"},
+	} {
+		com, err := jujuc.NewCommand(hctx, cmdString(args[0]))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, args[1:])
+		c.Check(code, gc.Equals, 0)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+	}
+	s.Stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "OpenEndpointPorts",
+		Args:     []interface{}{"website", "tcp", 80, 80},
+	}, {
+		FuncName: "OpenEndpointPorts",
+		Args:     []interface{}{"admin", "tcp", 80, 80},
+	}, {
+		FuncName: "CloseEndpointPorts",
+		Args:     []interface{}{"admin", "tcp", 80, 80},
+	}})
+}
+
 var badPortsTests = []struct {
 	args []string
 	err  string
@@ -136,6 +162,10 @@ register a port or range to open
 
 Details:
 The port range will only be open while the application is exposed.
+
+By default the port range is opened for all of the application's
+endpoints. With --endpoints it is only opened for the listed endpoints,
+and may then only be accessed from where those endpoints are exposed to.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
@@ -145,6 +175,9 @@ Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp
 
 Summary:
 ensure a port or range is always closed
+
+Details:
+With --endpoints the port range is only closed for the listed endpoints.
 `[1:])
 }
 
@@ -82,6 +82,16 @@ func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) erro
 	return ErrRestrictedContext
 }
 
+// OpenEndpointPorts implements hooks.Context.
+func (*RestrictedContext) OpenEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
+// CloseEndpointPorts implements hooks.Context.
+func (*RestrictedContext) CloseEndpointPorts(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
 // OpenedPorts implements hooks.Context.
 func (*RestrictedContext) OpenedPorts() []network.PortRange { return nil }
 
@@ -101,6 +111,17 @@ func (*RestrictedContext) LeaderSettings() (map[string]string, error) {
 // WriteLeaderSettings implements hooks.Context.
 func (*RestrictedContext) WriteLeaderSettings(map[string]string) error { return ErrRestrictedContext }
 
+// CharmState implements hooks.Context.
+func (*RestrictedContext) CharmState() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// SetCharmStateValue implements hooks.Context.
+func (*RestrictedContext) SetCharmStateValue(string, string) error { return ErrRestrictedContext }
+
+// DeleteCharmStateValue implements hooks.Context.
+func (*RestrictedContext) DeleteCharmStateValue(string) error { return ErrRestrictedContext }
+
 // AddMetric implements hooks.Context.
 func (*RestrictedContext) AddMetric(string, string, time.Time) error { return ErrRestrictedContext }
 
@@ -159,6 +180,9 @@ func (*RestrictedContext) SetActionMessage(string) error { return ErrRestrictedC
 // SetActionFailed implements hooks.Context.
 func (*RestrictedContext) SetActionFailed() error { return ErrRestrictedContext }
 
+// LogActionMessage implements hooks.Context.
+func (*RestrictedContext) LogActionMessage(string) error { return ErrRestrictedContext }
+
 // Component implements jujc.Context.
 func (*RestrictedContext) Component(string) (ContextComponent, error) {
 	return nil, ErrRestrictedContext
@@ -0,0 +1,81 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateGetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&stateGetSuite{})
+
+func (s *stateGetSuite) newContext(c *gc.C) *Context {
+	hctx := s.newHookContext(c)
+	hctx.info.CharmState = map[string]string{
+		"one":   "two",
+		"three": "four",
+	}
+	return hctx
+}
+
+func (s *stateGetSuite) TestInitError(c *gc.C) {
+	com, err := jujuc.NewCommand(s.newContext(c), cmdString("state-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, []string{"x=x"})
+	c.Assert(err, gc.ErrorMatches, `invalid key "x=x"`)
+	err = cmdtesting.InitCommand(com, []string{"one", "two"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["two"\]`)
+}
+
+func (s *stateGetSuite) TestOutput(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		out  string
+	}{{
+		args: []string{"one"},
+		out:  "two\n",
+	}, {
+		args: []string{"missing"},
+		out:  "",
+	}, {
+		args: nil,
+		out:  "one: two\nthree: four\n",
+	}, {
+		args: []string{"-"},
+		out:  "one: two\nthree: four\n",
+	}, {
+		args: []string{"--format", "json", "one"},
+		out:  "\"two\"\n",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		com, err := jujuc.NewCommand(s.newContext(c), cmdString("state-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(com, ctx, t.args)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+		c.Check(bufferString(ctx.Stdout), gc.Equals, t.out)
+	}
+}
+
+func (s *stateGetSuite) TestCharmStateError(c *gc.C) {
+	hctx := s.newContext(c)
+	s.Stub.SetErrors(errors.New("zap"))
+	com, err := jujuc.NewCommand(hctx, cmdString("state-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, nil)
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot read charm state: zap\n")
+	s.Stub.CheckCallNames(c, "CharmState")
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+)
+
+// stateSetCommand implements the state-set command.
+type stateSetCommand struct {
+	cmd.CommandBase
+	ctx      Context
+	settings map[string]string
+}
+
+// NewStateSetCommand returns a new stateSetCommand with the given context.
+func NewStateSetCommand(ctx Context) (cmd.Command, error) {
+	return &stateSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateSetCommand) Info() *cmd.Info {
+	doc := `
+state-set sets the supplied key/value pairs in the unit's charm state. A key
+with an empty value is removed from the charm state.
+
+As with relation-set, changes are written to the controller only when the hook
+completes successfully; if the hook fails, they are discarded.
+`
+	return &cmd.Info{
+		Name:    "state-set",
+		Args:    "<key>=<value> [...]",
+		Purpose: "set charm state",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateSetCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no charm state specified")
+	}
+	c.settings, err = keyvalues.Parse(args, true)
+	return
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateSetCommand) Run(_ *cmd.Context) error {
+	for key, value := range c.settings {
+		var err error
+		if value == "" {
+			err = c.ctx.DeleteCharmStateValue(key)
+		} else {
+			err = c.ctx.SetCharmStateValue(key, value)
+		}
+		if err != nil {
+			return errors.Annotatef(err, "cannot set charm state")
+		}
+	}
+	return nil
+}
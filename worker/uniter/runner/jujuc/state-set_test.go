@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateSetSuite struct {
+	ContextSuite
+}
+
+var _ = gc.Suite(&stateSetSuite{})
+
+func (s *stateSetSuite) TestInitError(c *gc.C) {
+	com, err := jujuc.NewCommand(s.newHookContext(c), cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = cmdtesting.InitCommand(com, nil)
+	c.Assert(err, gc.ErrorMatches, "no charm state specified")
+	err = cmdtesting.InitCommand(com, []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `expected "key=value", got "foo"`)
+}
+
+func (s *stateSetSuite) TestSetAndDelete(c *gc.C) {
+	hctx := s.newHookContext(c)
+	hctx.info.CharmState = map[string]string{"gone": "soon"}
+	com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"one=two", "gone="})
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+	c.Check(hctx.info.CharmState, jc.DeepEquals, map[string]string{"one": "two"})
+}
+
+func (s *stateSetSuite) TestSetError(c *gc.C) {
+	hctx := s.newHookContext(c)
+	s.Stub.SetErrors(errors.New("zap"))
+	com, err := jujuc.NewCommand(hctx, cmdString("state-set"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(com, ctx, []string{"one=two"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot set charm state: zap\n")
+	s.Stub.CheckCalls(c, []testing.StubCall{{"SetCharmStateValue", []interface{}{"one", "two"}}})
+}
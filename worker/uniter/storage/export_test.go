@@ -18,8 +18,12 @@ func StateAttached(s State) bool {
 	return s.(*stateFile).attached
 }
 
+func StateSize(s State) uint64 {
+	return s.(*stateFile).size
+}
+
 func ValidateHook(tag names.StorageTag, attached bool, hi hook.Info) error {
-	st := &state{tag, attached}
+	st := &state{storage: tag, attached: attached}
 	return st.ValidateHook(hi)
 }
 
@@ -74,6 +74,11 @@ type Uniter struct {
 	lastReportedStatus  status.Status
 	lastReportedMessage string
 
+	// watcherMu protects remoteStateWatcher, which is the current
+	// remote state watcher, kept for reporting.
+	watcherMu          sync.Mutex
+	remoteStateWatcher *remotestate.RemoteStateWatcher
+
 	operationFactory     operation.Factory
 	operationExecutor    operation.Executor
 	newOperationExecutor NewExecutorFunc
@@ -228,10 +233,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		}
 	}
 
-	var (
-		watcher   *remotestate.RemoteStateWatcher
-		watcherMu sync.Mutex
-	)
+	var watcher *remotestate.RemoteStateWatcher
 
 	logger.Infof("hooks are retried %v", u.hookRetryStrategy.ShouldRetry)
 	retryHookChan := make(chan struct{}, 1)
@@ -259,8 +261,8 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 	}()
 
 	restartWatcher := func() error {
-		watcherMu.Lock()
-		defer watcherMu.Unlock()
+		u.watcherMu.Lock()
+		defer u.watcherMu.Unlock()
 
 		if watcher != nil {
 			// watcher added to catacomb, will kill uniter if there's an error.
@@ -284,6 +286,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		if err := u.catacomb.Add(watcher); err != nil {
 			return errors.Trace(err)
 		}
+		u.remoteStateWatcher = watcher
 		return nil
 	}
 
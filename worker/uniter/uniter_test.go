@@ -165,6 +165,31 @@ func (s *UniterSuite) TestPreviousDownloadsCleared(c *gc.C) {
 	})
 }
 
+func (s *UniterSuite) TestUniterReport(c *gc.C) {
+	s.runUniterTests(c, []uniterTest{
+		ut(
+			"report operation state and remote state",
+			quickStart{},
+			custom{func(c *gc.C, ctx *context) {
+				report := ctx.uniter.Report()
+				op, ok := report["operation"].(map[string]interface{})
+				c.Assert(ok, jc.IsTrue)
+				c.Check(op["kind"], gc.Equals, "continue")
+				c.Check(op["installed"], jc.IsTrue)
+				c.Check(op["started"], jc.IsTrue)
+				c.Check(op["stopped"], jc.IsFalse)
+
+				remote, ok := report["remote-state"].(map[string]interface{})
+				c.Assert(ok, jc.IsTrue)
+				c.Check(remote["life"], gc.Equals, "alive")
+				c.Check(remote["charm"], gc.Equals, curl(0).String())
+				c.Check(remote["leader"], jc.IsTrue)
+				c.Check(remote["relations"], gc.DeepEquals, map[string]interface{}{})
+			}},
+		),
+	})
+}
+
 func (s *UniterSuite) TestUniterBootstrap(c *gc.C) {
 	//TODO(bogdanteleaga): Fix this on windows
 	if runtime.GOOS == "windows" {